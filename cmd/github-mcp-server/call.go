@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var callCmd = &cobra.Command{
+	Use:   "call <tool>",
+	Short: "Call a single tool and print its result",
+	Long: `Call a tool through the full server stack, with the same authentication, toolsets, read-only mode, middlewares
+and transports as the stdio server, and print its result. Progress notifications are printed to standard error as the
+call runs. Exits with a non-zero status when the call fails or the tool returns an error result.`,
+	Example:       `  github-mcp-server call get_repository --args '{"owner":"github","repo":"github-mcp-server"}'`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := readConfigFile(); err != nil {
+			return err
+		}
+
+		serverConfig, err := stdioServerConfigFromViper()
+		if err != nil {
+			return err
+		}
+
+		var arguments map[string]any
+		if err := json.Unmarshal([]byte(viper.GetString("call-args")), &arguments); err != nil {
+			return fmt.Errorf("failed to parse --args, expected a JSON object: %w", err)
+		}
+
+		return ghmcp.RunCall(cmd.Context(), ghmcp.CallConfig{
+			Server:    serverConfig,
+			Tool:      args[0],
+			Arguments: arguments,
+			Timeout:   viper.GetDuration("call-timeout"),
+			JSON:      viper.GetBool("call-json"),
+		}, os.Stdout, os.Stderr)
+	},
+}
+
+func init() {
+	callCmd.Flags().String("args", "{}", "Arguments of the tool call as a JSON object")
+	callCmd.Flags().Duration("timeout", 0, "Maximum time the call may take (0 for no limit)")
+	callCmd.Flags().Bool("json", false, "Print the whole result as JSON, including its structured content and metadata")
+
+	_ = viper.BindPFlag("call-args", callCmd.Flags().Lookup("args"))
+	_ = viper.BindPFlag("call-timeout", callCmd.Flags().Lookup("timeout"))
+	_ = viper.BindPFlag("call-json", callCmd.Flags().Lookup("json"))
+
+	rootCmd.AddCommand(callCmd)
+}
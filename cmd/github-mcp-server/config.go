@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration of the server",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration of the server",
+	Long: `Load the configuration from the flags, environment and configuration file as the stdio server does, print
+the effective configuration with secrets masked, and check that the toolsets exist, that the GitHub host is reachable
+and that the token is valid and has the scopes the enabled tools need. Exits with a non-zero status when any check
+fails, so that misconfigurations surface before the first tool call.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if _, err := readConfigFile(); err != nil {
+			return err
+		}
+
+		serverConfig, err := stdioServerConfigFromViper()
+		if err != nil {
+			return err
+		}
+
+		return ghmcp.RunConfigValidation(cmd.Context(), ghmcp.ConfigValidationConfig{
+			Server:     serverConfig,
+			ConfigFile: viper.ConfigFileUsed(),
+			Timeout:    viper.GetDuration("config-validate-timeout"),
+		}, os.Stdout)
+	},
+}
+
+func init() {
+	configValidateCmd.Flags().Duration("timeout", 10*time.Second, "Maximum time the checks against GitHub may take")
+
+	_ = viper.BindPFlag("config-validate-timeout", configValidateCmd.Flags().Lookup("timeout"))
+
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
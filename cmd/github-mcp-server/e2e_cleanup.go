@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var e2eCleanupCmd = &cobra.Command{
+	Use:   "e2e-cleanup",
+	Short: "Delete repositories left over by e2e tests",
+	Long: `Delete the github-mcp-server-e2e-* repositories left behind by e2e test runs whose cleanup failed,
+keeping the ones younger than --max-age which may still be in use, and the pooled repositories.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if _, err := readConfigFile(); err != nil {
+			return err
+		}
+
+		token := viper.GetString("personal_access_token")
+		if token == "" {
+			return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
+		}
+
+		return ghmcp.RunE2ECleanup(cmd.Context(), ghmcp.E2ECleanupConfig{
+			Version: version,
+			Host:    viper.GetString("host"),
+			Token:   token,
+			Owner:   viper.GetString("e2e-cleanup-owner"),
+			MaxAge:  viper.GetDuration("e2e-cleanup-max-age"),
+			DryRun:  viper.GetBool("e2e-cleanup-dry-run"),
+		}, os.Stdout)
+	},
+}
+
+func init() {
+	e2eCleanupCmd.Flags().String("owner", "", "User or organization whose repositories are swept (default the owner of the token)")
+	e2eCleanupCmd.Flags().Duration("max-age", 24*time.Hour, "Only delete repositories created longer ago than this")
+	e2eCleanupCmd.Flags().Bool("dry-run", false, "List the repositories that would be deleted without deleting them")
+
+	_ = viper.BindPFlag("e2e-cleanup-owner", e2eCleanupCmd.Flags().Lookup("owner"))
+	_ = viper.BindPFlag("e2e-cleanup-max-age", e2eCleanupCmd.Flags().Lookup("max-age"))
+	_ = viper.BindPFlag("e2e-cleanup-dry-run", e2eCleanupCmd.Flags().Lookup("dry-run"))
+
+	rootCmd.AddCommand(e2eCleanupCmd)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var healthcheckCmd = &cobra.Command{
+	Use:   "healthcheck",
+	Short: "Check that the server can reach GitHub",
+	Long: `Check that the GitHub host is reachable, that the token is valid and that it has the required scopes,
+exiting with a non-zero status when any check fails. Suitable for container healthchecks.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if _, err := readConfigFile(); err != nil {
+			return err
+		}
+
+		token := viper.GetString("personal_access_token")
+		if token == "" {
+			return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
+		}
+
+		return ghmcp.RunHealthcheck(cmd.Context(), ghmcp.HealthcheckConfig{
+			Version:        version,
+			Host:           viper.GetString("host"),
+			Token:          token,
+			RequiredScopes: viper.GetStringSlice("healthcheck-required-scopes"),
+			Timeout:        viper.GetDuration("healthcheck-timeout"),
+		}, os.Stdout)
+	},
+}
+
+func init() {
+	healthcheckCmd.Flags().StringSlice("required-scopes", []string{"repo"}, "Scopes a classic personal access token must have (tokens with fine-grained permissions are not checked)")
+	healthcheckCmd.Flags().Duration("timeout", 10*time.Second, "Maximum time the healthcheck may take")
+
+	_ = viper.BindPFlag("healthcheck-required-scopes", healthcheckCmd.Flags().Lookup("required-scopes"))
+	_ = viper.BindPFlag("healthcheck-timeout", healthcheckCmd.Flags().Lookup("timeout"))
+
+	rootCmd.AddCommand(healthcheckCmd)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var loadtestCmd = &cobra.Command{
+	Use:   "loadtest",
+	Short: "Load test a running MCP server over HTTP",
+	Long: `Drive concurrent MCP sessions calling a mix of tools against a running MCP server, connected to with the
+streamable HTTP transport, and report the latency percentiles and error rates of the calls. Exits with a non-zero
+status when sessions fail to initialize or the error rate exceeds --max-error-rate. GITHUB_PERSONAL_ACCESS_TOKEN is
+sent to the server as a bearer token when set.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if _, err := readConfigFile(); err != nil {
+			return err
+		}
+
+		// Arguments are JSON, which must not be split on commas like viper does with string arrays
+		arguments, err := cmd.Flags().GetStringArray("tool-arguments")
+		if err != nil {
+			return err
+		}
+		tools, err := ghmcp.ParseLoadTestTools(viper.GetStringSlice("loadtest-tools"), arguments)
+		if err != nil {
+			return err
+		}
+
+		return ghmcp.RunLoadTest(cmd.Context(), ghmcp.LoadTestConfig{
+			Version:         version,
+			URL:             viper.GetString("loadtest-url"),
+			Token:           viper.GetString("personal_access_token"),
+			Sessions:        viper.GetInt("loadtest-sessions"),
+			CallsPerSession: viper.GetInt("loadtest-calls"),
+			Duration:        viper.GetDuration("loadtest-duration"),
+			Interval:        viper.GetDuration("loadtest-interval"),
+			Timeout:         viper.GetDuration("loadtest-timeout"),
+			Tools:           tools,
+			MaxErrorRate:    viper.GetFloat64("loadtest-max-error-rate"),
+		}, os.Stdout)
+	},
+}
+
+func init() {
+	loadtestCmd.Flags().String("url", "http://localhost:8080/mcp", "URL of the streamable HTTP endpoint of the MCP server under test")
+	loadtestCmd.Flags().Int("sessions", 10, "Number of MCP sessions calling tools concurrently")
+	loadtestCmd.Flags().Int("calls", 10, "Number of tools each session calls, ignored with --duration")
+	loadtestCmd.Flags().Duration("duration", 0, "Keep the sessions calling tools for this long rather than a number of calls")
+	loadtestCmd.Flags().Duration("interval", 0, "Pause between the calls of a session")
+	loadtestCmd.Flags().Duration("timeout", 30*time.Second, "Maximum time a single call may take (0 for no limit)")
+	loadtestCmd.Flags().StringSlice("tools", []string{"get_me"}, "Tools the sessions call in turn, as tool or tool=weight to call some more often than others")
+	loadtestCmd.Flags().StringArray("tool-arguments", nil, `Arguments of a tool as tool={"name":"value"}, repeated for every tool needing arguments`)
+	loadtestCmd.Flags().Float64("max-error-rate", 0, "Highest share of failed calls, between 0 and 1, before the load test fails")
+
+	_ = viper.BindPFlag("loadtest-url", loadtestCmd.Flags().Lookup("url"))
+	_ = viper.BindPFlag("loadtest-sessions", loadtestCmd.Flags().Lookup("sessions"))
+	_ = viper.BindPFlag("loadtest-calls", loadtestCmd.Flags().Lookup("calls"))
+	_ = viper.BindPFlag("loadtest-duration", loadtestCmd.Flags().Lookup("duration"))
+	_ = viper.BindPFlag("loadtest-interval", loadtestCmd.Flags().Lookup("interval"))
+	_ = viper.BindPFlag("loadtest-timeout", loadtestCmd.Flags().Lookup("timeout"))
+	_ = viper.BindPFlag("loadtest-tools", loadtestCmd.Flags().Lookup("tools"))
+	_ = viper.BindPFlag("loadtest-max-error-rate", loadtestCmd.Flags().Lookup("max-error-rate"))
+
+	rootCmd.AddCommand(loadtestCmd)
+}
@@ -4,10 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/transport"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -31,36 +36,17 @@ var (
 		Short: "Start stdio server",
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			token := viper.GetString("personal_access_token")
-			if token == "" {
-				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
+			configChanged, err := readConfigFile()
+			if err != nil {
+				return err
 			}
 
-			// If you're wondering why we're not using viper.GetStringSlice("toolsets"),
-			// it's because viper doesn't handle comma-separated values correctly for env
-			// vars when using GetStringSlice.
-			// https://github.com/spf13/viper/issues/380
-			var enabledToolsets []string
-			if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
-				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
-			}
-
-			if len(enabledToolsets) == 0 {
-				enabledToolsets = github.GetDefaultToolsetIDs()
-			}
-
-			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
-				ContentWindowSize:    viper.GetInt("content-window-size"),
+			stdioServerConfig, err := stdioServerConfigFromViper()
+			if err != nil {
+				return err
 			}
+			stdioServerConfig.LoadReloadableConfig = newReloadableConfigLoader()
+			stdioServerConfig.ConfigChanged = configChanged
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
 	}
@@ -76,21 +62,83 @@ func init() {
 	rootCmd.PersistentFlags().StringSlice("toolsets", nil, github.GenerateToolsetsHelp())
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
+	rootCmd.PersistentFlags().String("config", "", "Path to a configuration file (YAML, JSON or TOML), reloaded whenever it changes or on SIGHUP")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
+	rootCmd.PersistentFlags().String("log-level", "", "Minimum level of logged messages: debug, info, warn or error (default debug with --log-file, info otherwise)")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
 	rootCmd.PersistentFlags().Int("content-window-size", 5000, "Specify the content window size")
+	rootCmd.PersistentFlags().Duration("rate-limit-max-wait", time.Minute, "Maximum time a request may wait for GitHub rate limits to reset before retrying (0 disables retries)")
+	rootCmd.PersistentFlags().Int("retry-max-attempts", transport.DefaultRetryMaxAttempts, "Maximum attempts of idempotent GitHub requests failing with network errors or 502, 503 and 504 responses (1 disables retries)")
+	rootCmd.PersistentFlags().StringSlice("tool-retry-attempts", nil, "Override the attempts of the GitHub requests made by specific tools as tool=attempts, also retrying their non-idempotent requests")
+	rootCmd.PersistentFlags().Int("circuit-breaker-threshold", transport.DefaultCircuitBreakerThreshold, "Consecutive server errors or timeouts from a GitHub host after which requests to it fail fast (0 disables the circuit breaker)")
+	rootCmd.PersistentFlags().Duration("circuit-breaker-cooldown", transport.DefaultCircuitBreakerCooldown, "How long requests to an unavailable GitHub host fail fast before one is let through to check whether it recovered")
+	rootCmd.PersistentFlags().Duration("offline-cache-max-age", 0, "Serve cached results of read requests up to this age, flagged as stale, when GitHub can't be reached (0 disables the offline cache)")
+	rootCmd.PersistentFlags().Int("offline-cache-size", transport.DefaultOfflineCacheSize, "Number of GitHub responses kept for the offline cache")
+	rootCmd.PersistentFlags().Int("max-idle-conns", transport.DefaultMaxIdleConns, "Maximum number of idle connections kept open to GitHub across all hosts (0 for no limit)")
+	rootCmd.PersistentFlags().Int("max-idle-conns-per-host", transport.DefaultMaxIdleConnsPerHost, "Maximum number of idle connections kept open to a single GitHub host")
+	rootCmd.PersistentFlags().Duration("idle-conn-timeout", transport.DefaultIdleConnTimeout, "How long idle connections to GitHub are kept open (0 for no limit)")
+	rootCmd.PersistentFlags().Bool("disable-http2", false, "Only use HTTP/1.1 to connect to GitHub, for proxies that don't support HTTP/2")
+	rootCmd.PersistentFlags().Int("max-concurrent-requests", 0, "Maximum number of GitHub requests in flight across the server (0 for no limit)")
+	rootCmd.PersistentFlags().Int("max-concurrent-requests-per-host", 0, "Maximum number of GitHub requests in flight to a single host (0 for no limit)")
+	rootCmd.PersistentFlags().Duration("request-queue-timeout", 0, "Maximum time a request may wait for a free slot before failing (0 waits indefinitely)")
+	rootCmd.PersistentFlags().Bool("privacy-mode", false, "Strip emails, avatar URLs and other personal data of users from tool results")
+	rootCmd.PersistentFlags().Bool("minimal-output", false, "Strip null and empty fields, API links and other boilerplate from tool results to save tokens")
+	rootCmd.PersistentFlags().Bool("check-for-updates", false, "Check at startup whether a newer version of the server has been released, logging it when so")
+	rootCmd.PersistentFlags().Bool("disable-resources", false, "Don't offer resources, for clients that can't handle them")
+	rootCmd.PersistentFlags().Bool("restrict-to-roots", false, "Fail tool calls naming repositories outside the roots the client declared")
+	rootCmd.PersistentFlags().StringSlice("confirm-tools", nil, "Ask the user to confirm every call to these tools (e.g. delete_file) through elicitation, failing the calls of clients that can't ask")
+	rootCmd.PersistentFlags().StringSlice("allow-repository-deletion", nil, "Offer the delete_repository tool for these repositories, as owner/repo or owner/* (e.g. octo-org/scratch,octo-sandbox/*)")
+	rootCmd.PersistentFlags().Int("summarize-threshold", 0, "Summarize tool results longer than this many characters using the client's model, when it supports sampling (0 disables summarization)")
+	rootCmd.PersistentFlags().Int64("app-id", 0, "Authenticate as a GitHub App with this ID instead of with a token, can also be set with GITHUB_APP_ID")
+	rootCmd.PersistentFlags().Int64("app-installation-id", 0, "ID of the GitHub App installation to act as, can also be set with GITHUB_APP_INSTALLATION_ID")
+	rootCmd.PersistentFlags().String("app-private-key-file", "", "Path to the PEM private key of the GitHub App, can also be set with GITHUB_APP_PRIVATE_KEY_FILE")
+	rootCmd.PersistentFlags().String("webhook-listen-addr", "", "Address to receive GitHub webhooks on (e.g. :8080), forwarding them to clients as notifications")
+	rootCmd.PersistentFlags().String("webhook-secret", "", "Secret used to verify webhook deliveries, can also be set with GITHUB_WEBHOOK_SECRET")
+	rootCmd.PersistentFlags().StringSlice("webhook-repos", nil, "Only forward webhook events from these repositories (owner/repo or owner/*)")
+	rootCmd.PersistentFlags().StringSlice("webhook-events", nil, "Only forward these webhook events (e.g. workflow_run or issues.opened)")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
+	_ = viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
+	_ = viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
 	_ = viper.BindPFlag("content-window-size", rootCmd.PersistentFlags().Lookup("content-window-size"))
+	_ = viper.BindPFlag("rate-limit-max-wait", rootCmd.PersistentFlags().Lookup("rate-limit-max-wait"))
+	_ = viper.BindPFlag("retry-max-attempts", rootCmd.PersistentFlags().Lookup("retry-max-attempts"))
+	_ = viper.BindPFlag("tool-retry-attempts", rootCmd.PersistentFlags().Lookup("tool-retry-attempts"))
+	_ = viper.BindPFlag("circuit-breaker-threshold", rootCmd.PersistentFlags().Lookup("circuit-breaker-threshold"))
+	_ = viper.BindPFlag("circuit-breaker-cooldown", rootCmd.PersistentFlags().Lookup("circuit-breaker-cooldown"))
+	_ = viper.BindPFlag("offline-cache-max-age", rootCmd.PersistentFlags().Lookup("offline-cache-max-age"))
+	_ = viper.BindPFlag("offline-cache-size", rootCmd.PersistentFlags().Lookup("offline-cache-size"))
+	_ = viper.BindPFlag("max-idle-conns", rootCmd.PersistentFlags().Lookup("max-idle-conns"))
+	_ = viper.BindPFlag("max-idle-conns-per-host", rootCmd.PersistentFlags().Lookup("max-idle-conns-per-host"))
+	_ = viper.BindPFlag("idle-conn-timeout", rootCmd.PersistentFlags().Lookup("idle-conn-timeout"))
+	_ = viper.BindPFlag("disable-http2", rootCmd.PersistentFlags().Lookup("disable-http2"))
+	_ = viper.BindPFlag("max-concurrent-requests", rootCmd.PersistentFlags().Lookup("max-concurrent-requests"))
+	_ = viper.BindPFlag("max-concurrent-requests-per-host", rootCmd.PersistentFlags().Lookup("max-concurrent-requests-per-host"))
+	_ = viper.BindPFlag("request-queue-timeout", rootCmd.PersistentFlags().Lookup("request-queue-timeout"))
+	_ = viper.BindPFlag("summarize-threshold", rootCmd.PersistentFlags().Lookup("summarize-threshold"))
+	_ = viper.BindPFlag("check-for-updates", rootCmd.PersistentFlags().Lookup("check-for-updates"))
+	_ = viper.BindPFlag("disable-resources", rootCmd.PersistentFlags().Lookup("disable-resources"))
+	_ = viper.BindPFlag("restrict-to-roots", rootCmd.PersistentFlags().Lookup("restrict-to-roots"))
+	_ = viper.BindPFlag("confirm-tools", rootCmd.PersistentFlags().Lookup("confirm-tools"))
+	_ = viper.BindPFlag("allow-repository-deletion", rootCmd.PersistentFlags().Lookup("allow-repository-deletion"))
+	_ = viper.BindPFlag("privacy_mode", rootCmd.PersistentFlags().Lookup("privacy-mode"))
+	_ = viper.BindPFlag("minimal-output", rootCmd.PersistentFlags().Lookup("minimal-output"))
+	_ = viper.BindPFlag("app_id", rootCmd.PersistentFlags().Lookup("app-id"))
+	_ = viper.BindPFlag("app_installation_id", rootCmd.PersistentFlags().Lookup("app-installation-id"))
+	_ = viper.BindPFlag("app_private_key_file", rootCmd.PersistentFlags().Lookup("app-private-key-file"))
+	_ = viper.BindPFlag("webhook-listen-addr", rootCmd.PersistentFlags().Lookup("webhook-listen-addr"))
+	_ = viper.BindPFlag("webhook_secret", rootCmd.PersistentFlags().Lookup("webhook-secret"))
+	_ = viper.BindPFlag("webhook-repos", rootCmd.PersistentFlags().Lookup("webhook-repos"))
+	_ = viper.BindPFlag("webhook-events", rootCmd.PersistentFlags().Lookup("webhook-events"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
@@ -103,6 +151,195 @@ func initConfig() {
 
 }
 
+// configChangeDebounce is how long the configuration file must stay unchanged before it is reloaded.
+const configChangeDebounce = 200 * time.Millisecond
+
+// readConfigFile loads the configuration file given with --config, if any, and watches it for changes.
+func readConfigFile() (<-chan struct{}, error) {
+	path := viper.GetString("config")
+	if path == "" {
+		return nil, nil
+	}
+
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// Editors often truncate the file before writing it, so wait for the writes to settle
+	// before reloading rather than applying the intermediate empty configuration.
+	changed := make(chan struct{}, 1)
+	notify := time.AfterFunc(time.Hour, func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	notify.Stop()
+	viper.OnConfigChange(func(_ fsnotify.Event) {
+		notify.Reset(configChangeDebounce)
+	})
+	viper.WatchConfig()
+	return changed, nil
+}
+
+// stdioServerConfigFromViper reads the settings of the server from the flags, environment and configuration file,
+// for the commands running it.
+func stdioServerConfigFromViper() (ghmcp.StdioServerConfig, error) {
+	token := viper.GetString("personal_access_token")
+	appID := viper.GetInt64("app_id")
+	if token == "" && appID == 0 {
+		return ghmcp.StdioServerConfig{}, errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
+	}
+	var appPrivateKey []byte
+	if appID != 0 {
+		if viper.GetInt64("app_installation_id") == 0 {
+			return ghmcp.StdioServerConfig{}, errors.New("GITHUB_APP_INSTALLATION_ID not set, it is required with GITHUB_APP_ID")
+		}
+		var err error
+		if appPrivateKey, err = os.ReadFile(viper.GetString("app_private_key_file")); err != nil {
+			return ghmcp.StdioServerConfig{}, fmt.Errorf("failed to read GitHub App private key: %w", err)
+		}
+	}
+
+	enabledToolsets, err := enabledToolsetsFromConfig()
+	if err != nil {
+		return ghmcp.StdioServerConfig{}, err
+	}
+
+	toolRetryAttempts, err := github.ParseToolRetryAttempts(viper.GetStringSlice("tool-retry-attempts"))
+	if err != nil {
+		return ghmcp.StdioServerConfig{}, err
+	}
+
+	pool := transport.PoolConfig{
+		MaxIdleConns:        viper.GetInt("max-idle-conns"),
+		MaxIdleConnsPerHost: viper.GetInt("max-idle-conns-per-host"),
+		IdleConnTimeout:     viper.GetDuration("idle-conn-timeout"),
+		DisableHTTP2:        viper.GetBool("disable-http2"),
+	}
+
+	return ghmcp.StdioServerConfig{
+		Version:                      version,
+		Commit:                       commit,
+		BuildDate:                    date,
+		Host:                         viper.GetString("host"),
+		Token:                        token,
+		AppID:                        appID,
+		AppInstallationID:            viper.GetInt64("app_installation_id"),
+		AppPrivateKey:                appPrivateKey,
+		EnabledToolsets:              enabledToolsets,
+		DynamicToolsets:              viper.GetBool("dynamic_toolsets"),
+		ReadOnly:                     viper.GetBool("read-only"),
+		ExportTranslations:           viper.GetBool("export-translations"),
+		EnableCommandLogging:         viper.GetBool("enable-command-logging"),
+		LogFilePath:                  viper.GetString("log-file"),
+		ContentWindowSize:            viper.GetInt("content-window-size"),
+		RateLimitMaxWait:             viper.GetDuration("rate-limit-max-wait"),
+		RetryMaxAttempts:             viper.GetInt("retry-max-attempts"),
+		ToolRetryAttempts:            toolRetryAttempts,
+		CircuitBreakerThreshold:      viper.GetInt("circuit-breaker-threshold"),
+		CircuitBreakerCooldown:       viper.GetDuration("circuit-breaker-cooldown"),
+		OfflineCacheMaxAge:           viper.GetDuration("offline-cache-max-age"),
+		OfflineCacheSize:             viper.GetInt("offline-cache-size"),
+		Pool:                         pool,
+		MaxConcurrentRequests:        viper.GetInt("max-concurrent-requests"),
+		MaxConcurrentRequestsPerHost: viper.GetInt("max-concurrent-requests-per-host"),
+		RequestQueueTimeout:          viper.GetDuration("request-queue-timeout"),
+		SummarizeThreshold:           viper.GetInt("summarize-threshold"),
+		PrivacyMode:                  viper.GetBool("privacy_mode"),
+		MinimalOutput:                viper.GetBool("minimal-output"),
+		DisableResources:             viper.GetBool("disable-resources"),
+		RestrictToRoots:              viper.GetBool("restrict-to-roots"),
+		ConfirmTools:                 viper.GetStringSlice("confirm-tools"),
+		AllowRepositoryDeletion:      viper.GetStringSlice("allow-repository-deletion"),
+		CheckForUpdates:              viper.GetBool("check-for-updates"),
+		WebhookListenAddr:            viper.GetString("webhook-listen-addr"),
+		WebhookSecret:                viper.GetString("webhook_secret"),
+		WebhookRepos:                 viper.GetStringSlice("webhook-repos"),
+		WebhookEvents:                viper.GetStringSlice("webhook-events"),
+		LogLevel:                     viper.GetString("log-level"),
+	}, nil
+}
+
+// enabledToolsetsFromConfig returns the toolsets to enable, falling back to the default ones.
+func enabledToolsetsFromConfig() ([]string, error) {
+	// If you're wondering why we're not using viper.GetStringSlice("toolsets"),
+	// it's because viper doesn't handle comma-separated values correctly for env
+	// vars when using GetStringSlice.
+	// https://github.com/spf13/viper/issues/380
+	var enabledToolsets []string
+	if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal toolsets: %w", err)
+	}
+
+	if len(enabledToolsets) == 0 {
+		enabledToolsets = github.GetDefaultToolsetIDs()
+	}
+	return enabledToolsets, nil
+}
+
+// reloadableSettings are the settings that are applied when the configuration file is reloaded.
+var reloadableSettings = []string{
+	"toolsets", "log-level", "restrict-to-roots", "confirm-tools", "allow-repository-deletion", "privacy_mode",
+	"webhook-repos", "webhook-events",
+}
+
+// newReloadableConfigLoader returns a function re-reading the configuration file and returning the settings that
+// can change at runtime, along with the other settings that changed since the server started.
+func newReloadableConfigLoader() func() (ghmcp.ReloadableConfig, error) {
+	started := restartOnlySettings()
+	return func() (ghmcp.ReloadableConfig, error) {
+		if viper.ConfigFileUsed() != "" {
+			if err := viper.ReadInConfig(); err != nil {
+				return ghmcp.ReloadableConfig{}, fmt.Errorf("failed to read config file: %w", err)
+			}
+		}
+
+		enabledToolsets, err := enabledToolsetsFromConfig()
+		if err != nil {
+			return ghmcp.ReloadableConfig{}, err
+		}
+
+		current := restartOnlySettings()
+		var restartRequired []string
+		for key, value := range current {
+			if !reflect.DeepEqual(started[key], value) {
+				restartRequired = append(restartRequired, key)
+			}
+		}
+		for key := range started {
+			if _, ok := current[key]; !ok {
+				restartRequired = append(restartRequired, key)
+			}
+		}
+		sort.Strings(restartRequired)
+
+		return ghmcp.ReloadableConfig{
+			EnabledToolsets: enabledToolsets,
+			LogLevel:        viper.GetString("log-level"),
+			Policy: github.Policy{
+				RestrictToRoots:         viper.GetBool("restrict-to-roots"),
+				ConfirmTools:            viper.GetStringSlice("confirm-tools"),
+				AllowRepositoryDeletion: viper.GetStringSlice("allow-repository-deletion"),
+				PrivacyMode:             viper.GetBool("privacy_mode"),
+			},
+			WebhookRepos:    viper.GetStringSlice("webhook-repos"),
+			WebhookEvents:   viper.GetStringSlice("webhook-events"),
+			RestartRequired: restartRequired,
+		}, nil
+	}
+}
+
+// restartOnlySettings returns the settings that only take effect when the server starts.
+func restartOnlySettings() map[string]any {
+	settings := viper.AllSettings()
+	for _, key := range reloadableSettings {
+		delete(settings, key)
+	}
+	return settings
+}
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
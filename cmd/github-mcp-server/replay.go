@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <log-file>",
+	Short: "Replay a captured session against the server",
+	Long: `Replay the JSON-RPC messages a client sent, read from JSON Lines of messages or from the log file the stdio
+server writes with --enable-command-logging, against a server with the current configuration. With --mode dry-run,
+the default, the messages are listed along with the tool calls the server would reject, and no tool is called. With
+--mode live, every message is handed to the server, calling GitHub, and the responses are printed as JSON Lines.
+A dry run exits with a non-zero status when the server would reject a tool call.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := readConfigFile(); err != nil {
+			return err
+		}
+
+		serverConfig, err := stdioServerConfigFromViper()
+		if err != nil {
+			return err
+		}
+
+		log, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open the log: %w", err)
+		}
+		defer func() { _ = log.Close() }()
+
+		return ghmcp.RunReplay(cmd.Context(), ghmcp.ReplayConfig{
+			Server: serverConfig,
+			Log:    log,
+			Mode:   viper.GetString("replay-mode"),
+		}, os.Stdout, os.Stderr)
+	},
+}
+
+func init() {
+	replayCmd.Flags().String("mode", ghmcp.ReplayModeDryRun, "Replay mode: dry-run, which calls no tool, or live, which replays every message")
+
+	_ = viper.BindPFlag("replay-mode", replayCmd.Flags().Lookup("mode"))
+
+	rootCmd.AddCommand(replayCmd)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var toolsCmd = &cobra.Command{
+	Use:   "tools",
+	Short: "Inspect the tools of the server",
+}
+
+var toolsDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print the tools of the server with their schemas",
+	Long: `Print every tool the server would list to clients with the current configuration, along with its
+description, input and output schemas and annotations, as JSON or Markdown. The toolsets, read-only and dynamic
+toolsets settings apply as they do to the server. No request is made to GitHub, GITHUB_PERSONAL_ACCESS_TOKEN is only
+used to hide the tools its kind of token can't use.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if _, err := readConfigFile(); err != nil {
+			return err
+		}
+
+		enabledToolsets, err := enabledToolsetsFromConfig()
+		if err != nil {
+			return err
+		}
+
+		return ghmcp.RunToolsDump(cmd.Context(), ghmcp.ToolsDumpConfig{
+			Version:           version,
+			Host:              viper.GetString("host"),
+			Token:             viper.GetString("personal_access_token"),
+			EnabledToolsets:   enabledToolsets,
+			DynamicToolsets:   viper.GetBool("dynamic_toolsets"),
+			ReadOnly:          viper.GetBool("read-only"),
+			ContentWindowSize: viper.GetInt("content-window-size"),
+			Format:            viper.GetString("tools-dump-format"),
+		}, os.Stdout)
+	},
+}
+
+func init() {
+	toolsDumpCmd.Flags().String("format", ghmcp.ToolsDumpFormatJSON, "Output format: json or markdown")
+
+	_ = viper.BindPFlag("tools-dump-format", toolsDumpCmd.Flags().Lookup("format"))
+
+	toolsCmd.AddCommand(toolsDumpCmd)
+	rootCmd.AddCommand(toolsCmd)
+}
@@ -0,0 +1,275 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	gogithub "github.com/google/go-github/v74/github"
+	mcpClient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// baselinesPath is where the results the benchmarks are compared against are kept.
+	baselinesPath = "testdata/benchmarks/baselines.json"
+
+	// defaultRegressionThreshold is how many times slower, or allocating, than its baseline a benchmark may be
+	// when GITHUB_MCP_SERVER_E2E_BENCH_THRESHOLD isn't set.
+	defaultRegressionThreshold = 1.5
+)
+
+// benchmarkBaseline is the result of a benchmark on the machine the baselines were updated on.
+type benchmarkBaseline struct {
+	NsPerOp     int64 `json:"ns_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+}
+
+// mockBackend answers the requests of the server with canned responses, so that the benchmarks measure the
+// overhead of the server rather than the latency of GitHub.
+type mockBackend map[string][]byte
+
+// newMockBackend returns the canned responses for the requests made by the benchmarked tools.
+func newMockBackend(t testing.TB) mockBackend {
+	user := &gogithub.User{
+		Login:     gogithub.Ptr("e2e-user"),
+		ID:        gogithub.Ptr(int64(1)),
+		Name:      gogithub.Ptr("E2E User"),
+		HTMLURL:   gogithub.Ptr("https://github.com/e2e-user"),
+		CreatedAt: &gogithub.Timestamp{Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	issue := &gogithub.Issue{
+		Number:  gogithub.Ptr(1),
+		Title:   gogithub.Ptr("Benchmark issue"),
+		Body:    gogithub.Ptr("An issue returned by the mock backend of the benchmarks."),
+		State:   gogithub.Ptr("open"),
+		User:    user,
+		HTMLURL: gogithub.Ptr("https://github.com/e2e-user/benchmark/issues/1"),
+	}
+
+	// A full page of branches and search results measures the marshalling of large responses
+	branches := make([]*gogithub.Branch, 100)
+	for i := range branches {
+		branches[i] = &gogithub.Branch{
+			Name:      gogithub.Ptr(fmt.Sprintf("branch-%d", i)),
+			Commit:    &gogithub.RepositoryCommit{SHA: gogithub.Ptr(fmt.Sprintf("%040d", i))},
+			Protected: gogithub.Ptr(false),
+		}
+	}
+	repos := make([]*gogithub.Repository, 100)
+	for i := range repos {
+		repos[i] = &gogithub.Repository{
+			ID:              gogithub.Ptr(int64(i)),
+			Name:            gogithub.Ptr(fmt.Sprintf("repository-%d", i)),
+			FullName:        gogithub.Ptr(fmt.Sprintf("e2e-user/repository-%d", i)),
+			Description:     gogithub.Ptr("A repository returned by the mock backend of the benchmarks."),
+			HTMLURL:         gogithub.Ptr(fmt.Sprintf("https://github.com/e2e-user/repository-%d", i)),
+			StargazersCount: gogithub.Ptr(i),
+			Owner:           user,
+		}
+	}
+
+	responses := map[string]any{
+		"/user":                              user,
+		"/repos/e2e-user/benchmark/issues/1": issue,
+		"/repos/e2e-user/benchmark/branches": branches,
+		"/search/repositories":               &gogithub.RepositoriesSearchResult{Total: gogithub.Ptr(len(repos)), Repositories: repos},
+	}
+	backend := mockBackend{}
+	for path, response := range responses {
+		body, err := json.Marshal(response)
+		require.NoError(t, err, "expected to marshal the mock response of %s", path)
+		backend[path] = body
+	}
+	return backend
+}
+
+// RoundTrip answers the request with the canned response of its path, or a 404 like GitHub does.
+func (m mockBackend) RoundTrip(req *http.Request) (*http.Response, error) {
+	status := http.StatusOK
+	body, ok := m[req.URL.Path]
+	if !ok {
+		status = http.StatusNotFound
+		body = []byte(`{"message":"Not Found"}`)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// setupBenchmarkClient runs the server in-process against the mock backend, with the default toolsets.
+func setupBenchmarkClient(b *testing.B) *mcpClient.Client {
+	ghServer, err := ghmcp.NewMCPServer(ghmcp.MCPServerConfig{
+		Token:           "benchmark-token",
+		EnabledToolsets: github.GetDefaultToolsetIDs(),
+		Translator:      translations.NullTranslationHelper,
+		Transport:       newMockBackend(b),
+	})
+	require.NoError(b, err, "expected to construct MCP server successfully")
+
+	client, err := mcpClient.NewInProcessClient(ghServer)
+	require.NoError(b, err, "expected to create in-process client successfully")
+	b.Cleanup(func() {
+		require.NoError(b, client.Close(), "expected to close client successfully")
+	})
+
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = "2025-03-26"
+	request.Params.ClientInfo = mcp.Implementation{
+		Name:    "e2e-benchmark-client",
+		Version: "0.0.1",
+	}
+	_, err = client.Initialize(context.Background(), request)
+	require.NoError(b, err, "failed to initialize client")
+	return client
+}
+
+// benchmarkToolCall calls the tool on every iteration, failing unless the call fails exactly when expected.
+func benchmarkToolCall(name string, arguments map[string]any, expectError bool) func(b *testing.B) {
+	return func(b *testing.B) {
+		client := setupBenchmarkClient(b)
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = arguments
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			result, err := client.CallTool(context.Background(), request)
+			switch {
+			case expectError:
+				// Failed calls are either errors of the call or results flagged as errors, depending on the tool
+				if err == nil && !result.IsError {
+					b.Fatalf("expected %s to fail, got: %s", name, getTextContent(b, result))
+				}
+			case err != nil:
+				b.Fatalf("failed to call %s: %v", name, err)
+			case result.IsError:
+				b.Fatalf("expected %s to succeed, got: %s", name, getTextContent(b, result))
+			}
+		}
+	}
+}
+
+// benchmarkListTools lists the tools on every iteration, which marshals all of their schemas.
+func benchmarkListTools(b *testing.B) {
+	client := setupBenchmarkClient(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.ListTools(context.Background(), mcp.ListToolsRequest{}); err != nil {
+			b.Fatalf("failed to list tools: %v", err)
+		}
+	}
+}
+
+// benchmarks lists the benchmarks compared against the baselines, by name.
+func benchmarks() map[string]func(b *testing.B) {
+	return map[string]func(b *testing.B){
+		"ListTools":               benchmarkListTools,
+		"ToolCall/get_me":         benchmarkToolCall("get_me", map[string]any{}, false),
+		"ToolCall/get_issue":      benchmarkToolCall("get_issue", map[string]any{"owner": "e2e-user", "repo": "benchmark", "issue_number": 1}, false),
+		"ToolCall/list_branches":  benchmarkToolCall("list_branches", map[string]any{"owner": "e2e-user", "repo": "benchmark", "perPage": 100}, false),
+		"ToolCall/search":         benchmarkToolCall("search_repositories", map[string]any{"query": "benchmark", "perPage": 100}, false),
+		"ToolCall/not_found":      benchmarkToolCall("get_issue", map[string]any{"owner": "e2e-user", "repo": "benchmark", "issue_number": 2}, true),
+		"ToolCall/invalid_params": benchmarkToolCall("get_issue", map[string]any{"owner": "e2e-user", "repo": "benchmark"}, true),
+	}
+}
+
+// BenchmarkServer measures the overhead of the server for tool calls against a local mock backend, including
+// the validation of their arguments, the transports of the GitHub clients and the marshalling of the results.
+func BenchmarkServer(b *testing.B) {
+	all := benchmarks()
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b.Run(name, all[name])
+	}
+}
+
+// TestBenchmarkRegressions runs the benchmarks and fails when one of them is slower, or allocates more, than its
+// baseline by more than the threshold. UPDATE_BENCHMARK_BASELINES=true writes the results as the new baselines.
+func TestBenchmarkRegressions(t *testing.T) {
+	update := os.Getenv("UPDATE_BENCHMARK_BASELINES") == "true"
+	if os.Getenv("GITHUB_MCP_SERVER_E2E_BENCH") == "" && !update {
+		t.Skip("set GITHUB_MCP_SERVER_E2E_BENCH=1 to compare the benchmarks against their baselines")
+	}
+
+	threshold := defaultRegressionThreshold
+	if value := os.Getenv("GITHUB_MCP_SERVER_E2E_BENCH_THRESHOLD"); value != "" {
+		var err error
+		threshold, err = strconv.ParseFloat(value, 64)
+		require.NoError(t, err, "expected GITHUB_MCP_SERVER_E2E_BENCH_THRESHOLD to be a number")
+		require.GreaterOrEqual(t, threshold, 1.0, "expected GITHUB_MCP_SERVER_E2E_BENCH_THRESHOLD to be at least 1")
+	}
+
+	baselines := map[string]benchmarkBaseline{}
+	if !update {
+		contents, err := os.ReadFile(baselinesPath)
+		require.NoError(t, err, "expected to read the baselines, write them with UPDATE_BENCHMARK_BASELINES=true")
+		require.NoError(t, json.Unmarshal(contents, &baselines), "expected to parse the baselines")
+	}
+
+	results := map[string]benchmarkBaseline{}
+	for name, benchmark := range benchmarks() {
+		result := testing.Benchmark(benchmark)
+		require.NotZero(t, result.N, "expected benchmark %s to run, it failed", name)
+		results[name] = benchmarkBaseline{
+			NsPerOp:     result.NsPerOp(),
+			AllocsPerOp: result.AllocsPerOp(),
+			BytesPerOp:  result.AllocedBytesPerOp(),
+		}
+		t.Logf("%s: %s", name, result.String()+result.MemString())
+	}
+
+	if update {
+		contents, err := json.MarshalIndent(results, "", "  ")
+		require.NoError(t, err, "expected to marshal the baselines")
+		require.NoError(t, os.MkdirAll(filepath.Dir(baselinesPath), 0o750), "expected to create the baselines directory")
+		require.NoError(t, os.WriteFile(baselinesPath, append(contents, '\n'), 0o600), "expected to write the baselines")
+		return
+	}
+
+	for name, result := range results {
+		baseline, ok := baselines[name]
+		if !ok {
+			t.Errorf("%s has no baseline, write it with UPDATE_BENCHMARK_BASELINES=true", name)
+			continue
+		}
+		checkRegression(t, name, "ns/op", result.NsPerOp, baseline.NsPerOp, threshold)
+		checkRegression(t, name, "allocs/op", result.AllocsPerOp, baseline.AllocsPerOp, threshold)
+		checkRegression(t, name, "B/op", result.BytesPerOp, baseline.BytesPerOp, threshold)
+	}
+}
+
+// checkRegression fails the test when the measure exceeds its baseline by more than the threshold.
+func checkRegression(t *testing.T, name, unit string, measure, baseline int64, threshold float64) {
+	if float64(measure) > float64(baseline)*threshold {
+		t.Errorf("%s regressed to %d %s, more than %.2f times its baseline of %d %s", name, measure, unit, threshold, baseline, unit)
+	}
+}
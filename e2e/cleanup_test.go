@@ -0,0 +1,53 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/pkg/transport"
+)
+
+func TestMain(m *testing.M) {
+	if hostsFile := os.Getenv("GITHUB_MCP_SERVER_E2E_HOSTS"); hostsFile != "" {
+		os.Exit(runHosts(hostsFile))
+	}
+	sweepLeftoverRepos()
+	os.Exit(m.Run())
+}
+
+// sweepLeftoverRepos deletes the repositories left over by previous runs whose cleanup failed, when
+// GITHUB_MCP_SERVER_E2E_CLEANUP_MAX_AGE is set. Failing to do so doesn't fail the run.
+func sweepLeftoverRepos() {
+	value := os.Getenv("GITHUB_MCP_SERVER_E2E_CLEANUP_MAX_AGE")
+	if value == "" || getE2EVCRMode() == transport.VCRModeReplay {
+		return
+	}
+	maxAge, err := time.ParseDuration(value)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GITHUB_MCP_SERVER_E2E_CLEANUP_MAX_AGE must be a duration such as 6h, got %q\n", value)
+		os.Exit(1)
+	}
+
+	owners := []string{""}
+	if org := getE2EOrg(); org != "" {
+		owners = append(owners, org)
+	}
+	for _, owner := range owners {
+		err := ghmcp.RunE2ECleanup(context.Background(), ghmcp.E2ECleanupConfig{
+			Version: "e2e",
+			Host:    getE2EHost(),
+			Token:   os.Getenv("GITHUB_MCP_SERVER_E2E_TOKEN"),
+			Owner:   owner,
+			MaxAge:  maxAge,
+		}, os.Stderr)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: Failed to sweep leftover repositories: %v\n", err)
+		}
+	}
+}
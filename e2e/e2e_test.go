@@ -18,9 +18,11 @@ import (
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/transport"
 	gogithub "github.com/google/go-github/v74/github"
 	mcpClient "github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,6 +42,9 @@ var (
 func getE2EToken(t *testing.T) string {
 	getTokenOnce.Do(func() {
 		token = os.Getenv("GITHUB_MCP_SERVER_E2E_TOKEN")
+		if token == "" && getE2EVCRMode() == transport.VCRModeReplay {
+			token = replayToken
+		}
 		if token == "" {
 			t.Fatalf("GITHUB_MCP_SERVER_E2E_TOKEN environment variable is not set")
 		}
@@ -56,11 +61,21 @@ func getE2EHost() string {
 }
 
 func getRESTClient(t *testing.T) *gogithub.Client {
+	// Share the recording of the test when there is one
+	var httpClient *http.Client
+	if vcr := getVCR(t); vcr != nil {
+		httpClient = &http.Client{Transport: vcr}
+	}
+	return newRESTClient(t, httpClient)
+}
+
+// newRESTClient creates a GitHub REST client sending its requests with the given HTTP client, nil for the default one
+func newRESTClient(t *testing.T, httpClient *http.Client) *gogithub.Client {
 	// Get token and ensure Docker image is built
 	token := getE2EToken(t)
 
 	// Create a new GitHub client with the token
-	ghClient := gogithub.NewClient(nil).WithAuthToken(token)
+	ghClient := gogithub.NewClient(httpClient).WithAuthToken(token)
 
 	if host := getE2EHost(); host != "" && host != "https://github.com" {
 		var err error
@@ -94,6 +109,12 @@ func ensureDockerImageBuilt(t *testing.T) {
 type clientOpts struct {
 	// Toolsets to enable in the MCP server
 	enabledToolsets []string
+
+	// Whether the MCP server only offers read-only tools
+	readOnly bool
+
+	// Whether the MCP server lets the client enable toolsets
+	dynamicToolsets bool
 }
 
 // clientOption defines a function type for configuring ClientOpts
@@ -107,22 +128,78 @@ func withToolsets(toolsets []string) clientOption {
 	}
 }
 
-func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
-	// Get token and ensure Docker image is built
-	token := getE2EToken(t)
+// withServerConfig returns an option that runs the MCP server with the toolsets, read-only and dynamic toolsets
+// settings of the configuration.
+func withServerConfig(config TestConfiguration) clientOption {
+	return func(opts *clientOpts) {
+		opts.enabledToolsets = config.Toolsets
+		opts.readOnly = config.ReadOnly
+		opts.dynamicToolsets = config.DynamicToolsets
+	}
+}
 
-	// Create and configure options
-	opts := &clientOpts{}
+// newClientOpts applies the options to the configuration of the environment.
+func newClientOpts(options ...clientOption) *clientOpts {
+	config := GetTestConfig()
+	opts := &clientOpts{readOnly: config.ReadOnly, dynamicToolsets: config.DynamicToolsets}
+	if os.Getenv("GITHUB_TOOLSETS") != "" {
+		opts.enabledToolsets = config.Toolsets
+	}
 
 	// Apply all options to configure the opts struct
 	for _, option := range options {
 		option(opts)
 	}
+	return opts
+}
+
+// newInProcessServer constructs the MCP server to run in-process, sending its requests through the VCR when
+// recording or replaying.
+func newInProcessServer(t *testing.T, token string, opts *clientOpts) *server.MCPServer {
+	// We need this because the fully compiled server has a default for the viper config, which is
+	// not in scope for using the MCP server directly. This probably indicates that we should refactor
+	// so that there is a shared setup mechanism, but let's wait till we feel more friction.
+	enabledToolsets := opts.enabledToolsets
+	if enabledToolsets == nil {
+		enabledToolsets = github.GetDefaultToolsetIDs()
+	}
+
+	cfg := ghmcp.MCPServerConfig{
+		Token:           token,
+		EnabledToolsets: enabledToolsets,
+		DynamicToolsets: opts.dynamicToolsets,
+		ReadOnly:        opts.readOnly,
+		Host:            getE2EHost(),
+		Translator:      translations.NullTranslationHelper,
+	}
+	if vcr := getVCR(t); vcr != nil {
+		cfg.Transport = vcr
+	}
+	ghServer, err := ghmcp.NewMCPServer(cfg)
+	require.NoError(t, err, "expected to construct MCP server successfully")
+	return ghServer
+}
+
+// setupHTTPServer runs the MCP server in-process behind the streamable HTTP transport, returning the URL of its
+// endpoint, for tests driving it with concurrent sessions like the loadtest subcommand does.
+func setupHTTPServer(t *testing.T, options ...clientOption) string {
+	httpServer := server.NewTestStreamableHTTPServer(newInProcessServer(t, getE2EToken(t), newClientOpts(options...)))
+	t.Cleanup(httpServer.Close)
+	return httpServer.URL + "/mcp"
+}
+
+func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
+	// Get token and ensure Docker image is built
+	token := getE2EToken(t)
+
+	// Create and configure options, starting from the configuration of the environment
+	opts := newClientOpts(options...)
 
 	// By default, we run the tests including the Docker image, but with DEBUG
 	// enabled, we run the server in-process, allowing for easier debugging.
+	// Recording and replaying also run in-process, as they need to see the requests.
 	var client *mcpClient.Client
-	if os.Getenv("GITHUB_MCP_SERVER_E2E_DEBUG") == "" {
+	if os.Getenv("GITHUB_MCP_SERVER_E2E_DEBUG") == "" && getE2EVCRMode() == "" {
 		ensureDockerImageBuilt(t)
 
 		// Prepare Docker arguments
@@ -145,8 +222,14 @@ func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
 			args = append(args, "-e", "GITHUB_TOOLSETS")
 		}
 
-		// Add the image name
-		args = append(args, "github/e2e-github-mcp-server")
+		// Add the image name and the command
+		args = append(args, "github/e2e-github-mcp-server", "stdio")
+		if opts.readOnly {
+			args = append(args, "--read-only")
+		}
+		if opts.dynamicToolsets {
+			args = append(args, "--dynamic-toolsets")
+		}
 
 		// Construct the env vars for the MCP Client to execute docker with
 		dockerEnvVars := []string{
@@ -164,23 +247,10 @@ func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
 		client, err = mcpClient.NewStdioMCPClient(args[0], dockerEnvVars, args[1:]...)
 		require.NoError(t, err, "expected to create client successfully")
 	} else {
-		// We need this because the fully compiled server has a default for the viper config, which is
-		// not in scope for using the MCP server directly. This probably indicates that we should refactor
-		// so that there is a shared setup mechanism, but let's wait till we feel more friction.
-		enabledToolsets := opts.enabledToolsets
-		if enabledToolsets == nil {
-			enabledToolsets = github.DefaultTools
-		}
-
-		ghServer, err := ghmcp.NewMCPServer(ghmcp.MCPServerConfig{
-			Token:           token,
-			EnabledToolsets: enabledToolsets,
-			Host:            getE2EHost(),
-			Translator:      translations.NullTranslationHelper,
-		})
-		require.NoError(t, err, "expected to construct MCP server successfully")
+		ghServer := newInProcessServer(t, token, opts)
 
 		t.Log("Starting In Process MCP client...")
+		var err error
 		client, err = mcpClient.NewInProcessClient(ghServer)
 		require.NoError(t, err, "expected to create in-process client successfully")
 	}
@@ -300,7 +370,7 @@ func TestTags(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := uniqueName(t, fmt.Sprintf("github-mcp-server-e2e-%s", t.Name()))
 	createRepoRequest := mcp.CallToolRequest{}
 	createRepoRequest.Params.Name = "create_repository"
 	createRepoRequest.Params.Arguments = map[string]any{
@@ -438,7 +508,7 @@ func TestFileDeletion(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := uniqueName(t, fmt.Sprintf("github-mcp-server-e2e-%s", t.Name()))
 	createRepoRequest := mcp.CallToolRequest{}
 	createRepoRequest.Params.Name = "create_repository"
 	createRepoRequest.Params.Arguments = map[string]any{
@@ -627,7 +697,7 @@ func TestDirectoryDeletion(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := uniqueName(t, fmt.Sprintf("github-mcp-server-e2e-%s", t.Name()))
 	createRepoRequest := mcp.CallToolRequest{}
 	createRepoRequest.Params.Name = "create_repository"
 	createRepoRequest.Params.Arguments = map[string]any{
@@ -791,9 +861,7 @@ func TestDirectoryDeletion(t *testing.T) {
 func TestRequestCopilotReview(t *testing.T) {
 	t.Parallel()
 
-	if getE2EHost() != "" && getE2EHost() != "https://github.com" {
-		t.Skip("Skipping test because the host does not support copilot reviews")
-	}
+	requireHostCapability(t, capabilityCopilotReview)
 
 	mcpClient := setupMCPClient(t)
 	ctx := context.Background()
@@ -822,7 +890,7 @@ func TestRequestCopilotReview(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := uniqueName(t, fmt.Sprintf("github-mcp-server-e2e-%s", t.Name()))
 	createRepoRequest := mcp.CallToolRequest{}
 	createRepoRequest.Params.Name = "create_repository"
 	createRepoRequest.Params.Arguments = map[string]any{
@@ -939,9 +1007,7 @@ func TestRequestCopilotReview(t *testing.T) {
 func TestAssignCopilotToIssue(t *testing.T) {
 	t.Parallel()
 
-	if getE2EHost() != "" && getE2EHost() != "https://github.com" {
-		t.Skip("Skipping test because the host does not support copilot being assigned to issues")
-	}
+	requireHostCapability(t, capabilityCopilotAssignment)
 
 	mcpClient := setupMCPClient(t)
 	ctx := context.Background()
@@ -970,7 +1036,7 @@ func TestAssignCopilotToIssue(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := uniqueName(t, fmt.Sprintf("github-mcp-server-e2e-%s", t.Name()))
 	createRepoRequest := mcp.CallToolRequest{}
 	createRepoRequest.Params.Name = "create_repository"
 	createRepoRequest.Params.Arguments = map[string]any{
@@ -1073,7 +1139,7 @@ func TestPullRequestAtomicCreateAndSubmit(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := uniqueName(t, fmt.Sprintf("github-mcp-server-e2e-%s", t.Name()))
 	createRepoRequest := mcp.CallToolRequest{}
 	createRepoRequest.Params.Name = "create_repository"
 	createRepoRequest.Params.Arguments = map[string]any{
@@ -1233,7 +1299,7 @@ func TestPullRequestReviewCommentSubmit(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := uniqueName(t, fmt.Sprintf("github-mcp-server-e2e-%s", t.Name()))
 	createRepoRequest := mcp.CallToolRequest{}
 	createRepoRequest.Params.Name = "create_repository"
 	createRepoRequest.Params.Arguments = map[string]any{
@@ -1478,7 +1544,7 @@ func TestPullRequestReviewDeletion(t *testing.T) {
 	currentOwner := trimmedGetMeText.Login
 
 	// Then create a repository with a README (via autoInit)
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", t.Name(), time.Now().UnixMilli())
+	repoName := uniqueName(t, fmt.Sprintf("github-mcp-server-e2e-%s", t.Name()))
 	createRepoRequest := mcp.CallToolRequest{}
 	createRepoRequest.Params.Name = "create_repository"
 	createRepoRequest.Params.Arguments = map[string]any{
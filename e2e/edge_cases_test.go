@@ -33,7 +33,7 @@ func TestRateLimitingScenarios(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		helper.WaitForRateLimit() // Add delay between calls
 		helper.CallTool("get_repository", map[string]any{
-			"owner": helper.GetOwner(),
+			"owner": helper.GetRepoOwner(),
 			"repo":  repoName,
 		})
 	}
@@ -53,7 +53,7 @@ func TestInvalidParameters(t *testing.T) {
 	// Test invalid repository name
 	if helper.ValidateToolAvailability("get_repository") {
 		response := helper.CallToolWithError("get_repository", map[string]any{
-			"owner": helper.GetOwner(),
+			"owner": helper.GetRepoOwner(),
 			"repo":  "nonexistent-repo-12345-invalid",
 		})
 		require.True(t, response.IsError, "expected error for nonexistent repository")
@@ -117,7 +117,7 @@ func TestResourceNotFoundCases(t *testing.T) {
 	// Test non-existent file
 	if helper.ValidateToolAvailability("get_file_contents") {
 		response := helper.CallToolWithError("get_file_contents", map[string]any{
-			"owner":  helper.GetOwner(),
+			"owner":  helper.GetRepoOwner(),
 			"repo":   repoName,
 			"path":   "nonexistent-file.txt",
 			"branch": "main",
@@ -128,7 +128,7 @@ func TestResourceNotFoundCases(t *testing.T) {
 	// Test non-existent branch
 	if helper.ValidateToolAvailability("list_branches") {
 		response := helper.CallToolWithError("create_branch", map[string]any{
-			"owner":       helper.GetOwner(),
+			"owner":       helper.GetRepoOwner(),
 			"repo":        repoName,
 			"branch":      "new-branch",
 			"from_branch": "nonexistent-branch",
@@ -139,7 +139,7 @@ func TestResourceNotFoundCases(t *testing.T) {
 	// Test non-existent issue
 	if helper.ValidateToolAvailability("get_issue") {
 		response := helper.CallToolWithError("get_issue", map[string]any{
-			"owner":       helper.GetOwner(),
+			"owner":       helper.GetRepoOwner(),
 			"repo":        repoName,
 			"issueNumber": 99999,
 		})
@@ -149,7 +149,7 @@ func TestResourceNotFoundCases(t *testing.T) {
 	// Test non-existent pull request
 	if helper.ValidateToolAvailability("get_pull_request") {
 		response := helper.CallToolWithError("get_pull_request", map[string]any{
-			"owner":      helper.GetOwner(),
+			"owner":      helper.GetRepoOwner(),
 			"repo":       repoName,
 			"pullNumber": 99999,
 		})
@@ -180,7 +180,7 @@ func TestConcurrentOperationConflicts(t *testing.T) {
 
 	// Try to create/update the same file quickly
 	helper.CallTool("create_or_update_file", map[string]any{
-		"owner":   helper.GetOwner(),
+		"owner":   helper.GetRepoOwner(),
 		"repo":    repoName,
 		"path":    filePath,
 		"content": "Updated content",
@@ -190,7 +190,7 @@ func TestConcurrentOperationConflicts(t *testing.T) {
 
 	// Verify the file was updated
 	getResponse := helper.CallTool("get_file_contents", map[string]any{
-		"owner":  helper.GetOwner(),
+		"owner":  helper.GetRepoOwner(),
 		"repo":   repoName,
 		"path":   filePath,
 		"branch": "main",
@@ -211,8 +211,8 @@ func TestMalformedRequests(t *testing.T) {
 
 	// Test with invalid JSON in content fields
 	if helper.ValidateToolAvailability("create_issue") {
-		response := helper.CallToolWithError("create_issue", map[string]any{
-			"owner": helper.GetOwner(),
+		helper.CallToolWithError("create_issue", map[string]any{
+			"owner": helper.GetRepoOwner(),
 			"repo":  "test-repo",
 			"title": strings.Repeat("a", 1000), // Very long title
 			"body":  "Valid body",
@@ -226,7 +226,7 @@ func TestMalformedRequests(t *testing.T) {
 
 	if helper.ValidateToolAvailability("create_issue") {
 		response := helper.CallTool("create_issue", map[string]any{
-			"owner": helper.GetOwner(),
+			"owner": helper.GetRepoOwner(),
 			"repo":  repoName,
 			"title": "Test with special chars: éñüñ 中文 🚀",
 			"body":  "Body with special chars: @#$%^&*()",
@@ -262,7 +262,7 @@ func TestLargeDataHandling(t *testing.T) {
 	filePath := "large-file.txt"
 
 	response := helper.CallTool("create_or_update_file", map[string]any{
-		"owner":   helper.GetOwner(),
+		"owner":   helper.GetRepoOwner(),
 		"repo":    repoName,
 		"path":    filePath,
 		"content": largeContent,
@@ -274,7 +274,7 @@ func TestLargeDataHandling(t *testing.T) {
 
 	// Try to retrieve the large file
 	getResponse := helper.CallTool("get_file_contents", map[string]any{
-		"owner":  helper.GetOwner(),
+		"owner":  helper.GetRepoOwner(),
 		"repo":   repoName,
 		"path":   filePath,
 		"branch": "main",
@@ -300,7 +300,7 @@ func TestBoundaryConditions(t *testing.T) {
 	// Test minimum valid inputs
 	if helper.ValidateToolAvailability("create_issue") {
 		response := helper.CallTool("create_issue", map[string]any{
-			"owner": helper.GetOwner(),
+			"owner": helper.GetRepoOwner(),
 			"repo":  repoName,
 			"title": "x", // Minimum title length
 			"body":  "",  // Empty body is valid
@@ -310,7 +310,7 @@ func TestBoundaryConditions(t *testing.T) {
 
 	// Test file with empty content
 	helper.CallTool("create_or_update_file", map[string]any{
-		"owner":   helper.GetOwner(),
+		"owner":   helper.GetRepoOwner(),
 		"repo":    repoName,
 		"path":    "empty-file.txt",
 		"content": "",
@@ -341,7 +341,7 @@ func TestIdempotentOperations(t *testing.T) {
 
 	// Try to create the same branch again (should fail)
 	response := helper.CallToolWithError("create_branch", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"branch":      branchName,
 		"from_branch": "main",
@@ -351,7 +351,7 @@ func TestIdempotentOperations(t *testing.T) {
 	// Test getting the same repository multiple times (should succeed)
 	for i := 0; i < 3; i++ {
 		response := helper.CallTool("get_repository", map[string]any{
-			"owner": helper.GetOwner(),
+			"owner": helper.GetRepoOwner(),
 			"repo":  repoName,
 		})
 		require.False(t, response.IsError, "expected success getting repository multiple times")
@@ -0,0 +1,39 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFixtures checks the committed fixtures without the e2e build tag, so that go test ./... catches fixtures
+// that recorded nothing or were reformatted by hand.
+func TestFixtures(t *testing.T) {
+	err := filepath.WalkDir("testdata/fixtures", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".json" {
+			return err
+		}
+
+		t.Run(path, func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			var cassette transport.Cassette
+			require.NoError(t, json.Unmarshal(data, &cassette), "expected the fixture to be a recorded cassette")
+			assert.NotEmpty(t, cassette.Interactions, "expected the fixture to have interactions, tests that don't call GitHub are marked with withoutGitHub rather than given a fixture")
+
+			// The recorder writes the cassette indented, which editing a fixture by hand rarely keeps
+			recorded, err := json.MarshalIndent(cassette, "", "  ")
+			require.NoError(t, err)
+			assert.Equal(t, string(recorded)+"\n", string(data), "expected the fixture to be written by the recorder, record it again")
+		})
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -11,7 +11,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/google/go-github/v74/github"
+	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
@@ -23,18 +23,26 @@ type TestHelper struct {
 	client *client.Client
 	ctx    context.Context
 	owner  string
+
+	// repoOwner owns the test repositories, the test organization when there is one
+	repoOwner string
 }
 
 // NewTestHelper creates a new test helper instance
 func NewTestHelper(t *testing.T, client *client.Client) *TestHelper {
 	ctx := context.Background()
 	owner := getCurrentUser(t, client, ctx)
+	repoOwner := owner
+	if org := getE2EOrg(); org != "" {
+		repoOwner = org
+	}
 
 	return &TestHelper{
-		t:      t,
-		client: client,
-		ctx:    ctx,
-		owner:  owner,
+		t:         t,
+		client:    client,
+		ctx:       ctx,
+		owner:     owner,
+		repoOwner: repoOwner,
 	}
 }
 
@@ -47,17 +55,13 @@ func getCurrentUser(t *testing.T, client *client.Client, ctx context.Context) st
 	require.NoError(t, err, "expected to call 'get_me' tool successfully")
 	require.False(t, response.IsError, "expected result not to be an error")
 
-	var user struct {
+	return GetResponseAs[struct {
 		Login string `json:"login"`
-	}
-	err = json.Unmarshal([]byte(getTextContent(t, response)), &user)
-	require.NoError(t, err, "expected to unmarshal user data")
-
-	return user.Login
+	}](t, response).Login
 }
 
 // getTextContent extracts text content from MCP response
-func getTextContent(t *testing.T, response *mcp.CallToolResult) string {
+func getTextContent(t testing.TB, response *mcp.CallToolResult) string {
 	require.Len(t, response.Content, 1, "expected content to have one item")
 	textContent, ok := response.Content[0].(mcp.TextContent)
 	require.True(t, ok, "expected content to be of type TextContent")
@@ -90,9 +94,16 @@ func (h *TestHelper) CallToolWithError(toolName string, args map[string]any) *mc
 	return response
 }
 
-// CreateTestRepo creates a temporary repository for testing
+// CreateTestRepo creates a temporary repository for testing. With a test organization, a repository of its pool
+// is handed out instead, reset by the time the test gets it. Like the other helpers setting up test data, it skips
+// the test when the server doesn't offer the tool it needs, e.g. in read-only mode.
 func (h *TestHelper) CreateTestRepo(name string) string {
-	repoName := fmt.Sprintf("github-mcp-server-e2e-%s-%d", name, time.Now().UnixMilli())
+	if getE2EOrg() != "" {
+		return acquirePooledRepo(h.t)
+	}
+
+	h.SkipIfToolNotAvailable("create_repository")
+	repoName := uniqueName(h.t, "github-mcp-server-e2e-"+name)
 
 	h.CallTool("create_repository", map[string]any{
 		"name":     repoName,
@@ -113,14 +124,15 @@ func (h *TestHelper) DeleteTestRepo(repoName string) {
 	ghClient := getRESTClient(h.t)
 	_, err := ghClient.Repositories.Delete(context.Background(), h.owner, repoName)
 	if err != nil {
-		h.t.Logf("Warning: Failed to delete test repository %s/%s: %v", h.owner, repoName, err)
+		h.t.Logf("Warning: Failed to delete test repository %s/%s, it is left for e2e-cleanup: %v", h.owner, repoName, err)
 	}
 }
 
 // CreateTestBranch creates a test branch
 func (h *TestHelper) CreateTestBranch(repoName, branchName string) {
+	h.SkipIfToolNotAvailable("create_branch")
 	h.CallTool("create_branch", map[string]any{
-		"owner":       h.owner,
+		"owner":       h.repoOwner,
 		"repo":        repoName,
 		"branch":      branchName,
 		"from_branch": "main",
@@ -129,8 +141,9 @@ func (h *TestHelper) CreateTestBranch(repoName, branchName string) {
 
 // CreateTestFile creates a test file with content
 func (h *TestHelper) CreateTestFile(repoName, branchName, filePath, content, message string) {
+	h.SkipIfToolNotAvailable("create_or_update_file")
 	h.CallTool("create_or_update_file", map[string]any{
-		"owner":   h.owner,
+		"owner":   h.repoOwner,
 		"repo":    repoName,
 		"path":    filePath,
 		"content": content,
@@ -141,8 +154,9 @@ func (h *TestHelper) CreateTestFile(repoName, branchName, filePath, content, mes
 
 // CreateTestPR creates a test pull request
 func (h *TestHelper) CreateTestPR(repoName, title, body, head, base string) int {
+	h.SkipIfToolNotAvailable("create_pull_request")
 	response := h.CallTool("create_pull_request", map[string]any{
-		"owner": h.owner,
+		"owner": h.repoOwner,
 		"repo":  repoName,
 		"title": title,
 		"body":  body,
@@ -150,30 +164,23 @@ func (h *TestHelper) CreateTestPR(repoName, title, body, head, base string) int
 		"base":  base,
 	})
 
-	var pr struct {
+	return GetResponseAs[struct {
 		Number int `json:"number"`
-	}
-	err := json.Unmarshal([]byte(getTextContent(h.t, response)), &pr)
-	require.NoError(h.t, err, "expected to unmarshal PR data")
-
-	return pr.Number
+	}](h.t, response).Number
 }
 
 // CreateTestIssue creates a test issue
 func (h *TestHelper) CreateTestIssue(repoName, title string) int {
+	h.SkipIfToolNotAvailable("create_issue")
 	response := h.CallTool("create_issue", map[string]any{
-		"owner": h.owner,
+		"owner": h.repoOwner,
 		"repo":  repoName,
 		"title": title,
 	})
 
-	var issue struct {
+	return GetResponseAs[struct {
 		Number int `json:"number"`
-	}
-	err := json.Unmarshal([]byte(getTextContent(h.t, response)), &issue)
-	require.NoError(h.t, err, "expected to unmarshal issue data")
-
-	return issue.Number
+	}](h.t, response).Number
 }
 
 // AssertJSONResponse asserts that the response contains expected JSON structure
@@ -209,19 +216,24 @@ func (h *TestHelper) GetOwner() string {
 	return h.owner
 }
 
+// GetRepoOwner returns the owner of the repositories created with CreateTestRepo
+func (h *TestHelper) GetRepoOwner() string {
+	return h.repoOwner
+}
+
 // LogTestStep logs a test step for better debugging
-func (h *TestHelper) LogTestStep(step string) {
-	h.t.Logf("🔄 %s", step)
+func (h *TestHelper) LogTestStep(format string, args ...any) {
+	h.t.Logf("🔄 %s", fmt.Sprintf(format, args...))
 }
 
 // LogTestResult logs a test result
-func (h *TestHelper) LogTestResult(result string) {
-	h.t.Logf("✅ %s", result)
+func (h *TestHelper) LogTestResult(format string, args ...any) {
+	h.t.Logf("✅ %s", fmt.Sprintf(format, args...))
 }
 
 // GenerateUniqueName generates a unique name for test resources
-func GenerateUniqueName(prefix string) string {
-	return fmt.Sprintf("%s-%d", prefix, time.Now().UnixMilli())
+func GenerateUniqueName(t *testing.T, prefix string) string {
+	return uniqueName(t, prefix)
 }
 
 // WaitForRateLimit waits for rate limit reset if needed
@@ -255,6 +267,7 @@ func (h *TestHelper) SkipIfToolNotAvailable(toolName string) {
 
 // TestConfiguration holds test configuration
 type TestConfiguration struct {
+	Name            string
 	Toolsets        []string
 	Host            string
 	ReadOnly        bool
@@ -264,6 +277,7 @@ type TestConfiguration struct {
 // GetTestConfig returns the current test configuration
 func GetTestConfig() TestConfiguration {
 	config := TestConfiguration{
+		Name:            "environment",
 		Toolsets:        strings.Split(os.Getenv("GITHUB_TOOLSETS"), ","),
 		Host:            getE2EHost(),
 		ReadOnly:        os.Getenv("GITHUB_READ_ONLY") == "1",
@@ -276,31 +290,3 @@ func GetTestConfig() TestConfiguration {
 
 	return config
 }
-
-// getE2EToken ensures the environment variable is checked only once and returns the token
-func getE2EToken(t *testing.T) string {
-	token := os.Getenv("GITHUB_MCP_SERVER_E2E_TOKEN")
-	if token == "" {
-		t.Fatalf("GITHUB_MCP_SERVER_E2E_TOKEN environment variable is not set")
-	}
-	return token
-}
-
-// getE2EHost ensures the environment variable is checked only once and returns the host
-func getE2EHost() string {
-	return os.Getenv("GITHUB_MCP_SERVER_E2E_HOST")
-}
-
-// getRESTClient creates a GitHub REST client for testing
-func getRESTClient(t *testing.T) *github.Client {
-	token := getE2EToken(t)
-	ghClient := github.NewClient(nil).WithAuthToken(token)
-
-	if host := getE2EHost(); host != "" && host != "https://github.com" {
-		var err error
-		ghClient, err = ghClient.WithEnterpriseURLs(host, host)
-		require.NoError(t, err, "expected to create GitHub client with host")
-	}
-
-	return ghClient
-}
@@ -0,0 +1,231 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/transport"
+	"github.com/stretchr/testify/require"
+)
+
+// hostKind is the kind of GitHub product a host runs.
+type hostKind string
+
+const (
+	hostKindDotcom hostKind = "github.com"
+	hostKindGHEC   hostKind = "GHE.com"
+	hostKindGHES   hostKind = "GHES"
+)
+
+// hostCapability is a feature of GitHub some hosts don't have, which tests using it skip themselves on.
+type hostCapability string
+
+const (
+	capabilityCopilotReview     hostCapability = "copilot_review"
+	capabilityCopilotAssignment hostCapability = "copilot_assignment"
+)
+
+// ghesCapabilities lists the first GHES version having each capability, empty for the capabilities GHES doesn't
+// have at all. Capabilities missing from the list are available on every host.
+var ghesCapabilities = map[hostCapability]string{
+	capabilityCopilotReview:     "",
+	capabilityCopilotAssignment: "",
+}
+
+// e2eHost is an entry of the hosts file, which runs the suites against each of its hosts in turn.
+type e2eHost struct {
+	// Name identifies the host in the output, e.g. dotcom or ghes-3.14
+	Name string `json:"name"`
+
+	// Host is the URL of the host, empty for github.com
+	Host string `json:"host"`
+
+	// TokenEnv names the environment variable holding the token for the host, so that the file has no secrets
+	TokenEnv string `json:"token_env"`
+
+	// Org is the test organization of the host, none when empty
+	Org string `json:"org,omitempty"`
+
+	// Version overrides the GHES version detected from the host, which replaying needs as it can't reach the host
+	Version string `json:"version,omitempty"`
+
+	// Unsupported lists the capabilities the host lacks besides the ones its version lacks, e.g. features that
+	// aren't licensed or enabled on it
+	Unsupported []string `json:"unsupported,omitempty"`
+}
+
+// hostInfo describes the host the suites run against.
+type hostInfo struct {
+	Kind    hostKind
+	Version string
+}
+
+var (
+	getHostInfoOnce sync.Once
+	currentHost     hostInfo
+	hostInfoError   error
+)
+
+// runHosts runs the suites against every host of the hosts file, by running the test binary again for each of them
+// with its environment pointing at the host, and returns the exit code of the whole run.
+func runHosts(path string) int {
+	contents, err := os.ReadFile(path) //nolint:gosec // the hosts file is given by whoever runs the tests
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to read the hosts file: %v\n", err)
+		return 1
+	}
+	var hosts []e2eHost
+	if err := json.Unmarshal(contents, &hosts); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to parse the hosts file %s: %v\n", path, err)
+		return 1
+	}
+
+	var failed []string
+	for _, host := range hosts {
+		_, _ = fmt.Fprintf(os.Stdout, "=== HOST %s (%s)\n", host.Name, describeHost(host.Host))
+
+		cmd := exec.Command(os.Args[0], os.Args[1:]...) //nolint:gosec // runs the test binary itself
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		cmd.Env = append(os.Environ(),
+			"GITHUB_MCP_SERVER_E2E_HOSTS=",
+			"GITHUB_MCP_SERVER_E2E_HOST="+host.Host,
+			"GITHUB_MCP_SERVER_E2E_ORG="+host.Org,
+			"GITHUB_MCP_SERVER_E2E_HOST_VERSION="+host.Version,
+			"GITHUB_MCP_SERVER_E2E_HOST_UNSUPPORTED="+strings.Join(host.Unsupported, ","),
+		)
+		if host.TokenEnv != "" {
+			cmd.Env = append(cmd.Env, "GITHUB_MCP_SERVER_E2E_TOKEN="+os.Getenv(host.TokenEnv))
+		}
+
+		if err := cmd.Run(); err != nil {
+			_, _ = fmt.Fprintf(os.Stdout, "--- FAIL HOST %s: %v\n", host.Name, err)
+			failed = append(failed, host.Name)
+			continue
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "--- PASS HOST %s\n", host.Name)
+	}
+
+	if len(failed) > 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "FAIL: %d of %d hosts failed: %s\n", len(failed), len(hosts), strings.Join(failed, ", "))
+		return 1
+	}
+	return 0
+}
+
+func describeHost(host string) string {
+	if host == "" {
+		return "https://github.com"
+	}
+	return host
+}
+
+// getHostKind tells the kind of GitHub product the host runs from its URL.
+func getHostKind(host string) hostKind {
+	if host == "" || host == "https://github.com" {
+		return hostKindDotcom
+	}
+	if u, err := url.Parse(host); err == nil && strings.HasSuffix(u.Hostname(), ".ghe.com") {
+		return hostKindGHEC
+	}
+	return hostKindGHES
+}
+
+// getHostInfo returns the kind of the host the suites run against, and its version on GHES, taken from
+// GITHUB_MCP_SERVER_E2E_HOST_VERSION or else from the meta endpoint of the host.
+func getHostInfo(t *testing.T) hostInfo {
+	getHostInfoOnce.Do(func() {
+		currentHost = hostInfo{Kind: getHostKind(getE2EHost()), Version: os.Getenv("GITHUB_MCP_SERVER_E2E_HOST_VERSION")}
+		if currentHost.Kind != hostKindGHES || currentHost.Version != "" {
+			return
+		}
+		if getE2EVCRMode() == transport.VCRModeReplay {
+			hostInfoError = fmt.Errorf("replaying against %s requires GITHUB_MCP_SERVER_E2E_HOST_VERSION to be set", getE2EHost())
+			return
+		}
+
+		// The version is looked up once for all the tests, so the request is never recorded in the fixture of one
+		client := newRESTClient(t, nil)
+		req, err := client.NewRequest("GET", "meta", nil)
+		if err != nil {
+			hostInfoError = err
+			return
+		}
+		var meta struct {
+			InstalledVersion string `json:"installed_version"`
+		}
+		if _, err := client.Do(context.Background(), req, &meta); err != nil {
+			hostInfoError = fmt.Errorf("failed to get the version of %s: %w", getE2EHost(), err)
+			return
+		}
+		currentHost.Version = meta.InstalledVersion
+	})
+	require.NoError(t, hostInfoError, "expected to get the version of the host")
+	return currentHost
+}
+
+// requireHostCapability skips the test when the host the suites run against doesn't have the capability, because
+// of its kind, its version, or because the hosts file lists it as unsupported.
+func requireHostCapability(t *testing.T, capability hostCapability) {
+	for _, unsupported := range strings.Split(os.Getenv("GITHUB_MCP_SERVER_E2E_HOST_UNSUPPORTED"), ",") {
+		if strings.TrimSpace(unsupported) == string(capability) {
+			t.Skipf("Skipping test because %s is listed as unsupported by %s", capability, describeHost(getE2EHost()))
+		}
+	}
+
+	info := getHostInfo(t)
+	minVersion, limited := ghesCapabilities[capability]
+	if info.Kind != hostKindGHES || !limited {
+		return
+	}
+	if minVersion == "" {
+		t.Skipf("Skipping test because GHES doesn't support %s", capability)
+	}
+	if compareVersions(info.Version, minVersion) < 0 {
+		t.Skipf("Skipping test because GHES %s doesn't support %s, which requires GHES %s", info.Version, capability, minVersion)
+	}
+}
+
+// compareVersions compares dotted versions such as 3.14.2 numerically, returning -1, 0 or 1. Missing parts count
+// as zero, so 3.14 and 3.14.0 are equal.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// getFixturesDir returns where the fixtures of the host are kept, the fixtures of github.com being kept at the
+// root of fixturesDir and those of other hosts in a directory named after them.
+func getFixturesDir() string {
+	host := getE2EHost()
+	if getHostKind(host) == hostKindDotcom {
+		return fixturesDir
+	}
+	if u, err := url.Parse(host); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	return fixturesDir + "/" + host
+}
@@ -29,8 +29,7 @@ const (
 	checkMarkFeatureBranch   = "✓ Feature implemented"
 	checkMarkFeatureMerged   = "✓ Feature merged and issue closed"
 
-	featureBranchName = "feature-branch"
-	fixBranchName     = "fix-crash"
+	fixBranchName        = "fix-crash"
 	featureRequestBranch = "feature-request"
 )
 
@@ -61,7 +60,7 @@ func TestCompleteRepositoryLifecycle(t *testing.T) {
 
 	// Phase 5: Add PR comment
 	helper.CallTool("add_pull_request_comment", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": prNumber,
 		"body":       "Looks good! Ready for review.",
@@ -70,7 +69,7 @@ func TestCompleteRepositoryLifecycle(t *testing.T) {
 
 	// Phase 6: Create PR review
 	helper.CallTool("create_pull_request_review", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": prNumber,
 		"event":      "APPROVE",
@@ -80,7 +79,7 @@ func TestCompleteRepositoryLifecycle(t *testing.T) {
 
 	// Phase 7: Merge pull request
 	mergeResponse := helper.CallTool("merge_pull_request", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"pullNumber":  prNumber,
 		"mergeMethod": "merge",
@@ -95,7 +94,7 @@ func TestCompleteRepositoryLifecycle(t *testing.T) {
 
 	// Phase 8: Verify file exists in main branch
 	getFileResponse := helper.CallTool("get_file_contents", map[string]any{
-		"owner":  helper.GetOwner(),
+		"owner":  helper.GetRepoOwner(),
 		"repo":   repoName,
 		"path":   "feature.go",
 		"branch": "main",
@@ -125,7 +124,7 @@ func TestIssueManagementWorkflow(t *testing.T) {
 
 	// Phase 3: Add labels to issue
 	helper.CallTool("add_issue_labels", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"issueNumber": issueNumber,
 		"labels":      []string{"bug", "high-priority", "crash"},
@@ -134,7 +133,7 @@ func TestIssueManagementWorkflow(t *testing.T) {
 
 	// Phase 4: Assign issue
 	helper.CallTool("add_issue_assignees", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"issueNumber": issueNumber,
 		"assignees":   []string{helper.GetOwner()},
@@ -143,7 +142,7 @@ func TestIssueManagementWorkflow(t *testing.T) {
 
 	// Phase 5: Add issue comment
 	helper.CallTool("add_issue_comment", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"issueNumber": issueNumber,
 		"body":        "I've reproduced this issue. Working on a fix.",
@@ -164,7 +163,7 @@ func TestIssueManagementWorkflow(t *testing.T) {
 
 	// Phase 9: Merge the fix
 	helper.CallTool("merge_pull_request", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"pullNumber":  prNumber,
 		"mergeMethod": "merge",
@@ -173,7 +172,7 @@ func TestIssueManagementWorkflow(t *testing.T) {
 
 	// Phase 10: Close the issue
 	helper.CallTool("update_issue", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"issueNumber": issueNumber,
 		"state":       "closed",
@@ -213,7 +212,7 @@ func TestMultiBranchWorkflow(t *testing.T) {
 
 	// Phase 4: List all branches
 	listBranchesResponse := helper.CallTool("list_branches", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 	})
 
@@ -236,13 +235,13 @@ func TestMultiBranchWorkflow(t *testing.T) {
 
 	// Phase 5: List all pull requests
 	listPRsResponse := helper.CallTool("list_pull_requests", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 		"state": "open",
 	})
 
 	var prList []struct {
-		Number int `json:"number"`
+		Number int    `json:"number"`
 		Title  string `json:"title"`
 	}
 	helper.AssertJSONResponse(listPRsResponse, &prList)
@@ -279,7 +278,7 @@ func TestCollaborativeWorkflow(t *testing.T) {
 
 	for _, comment := range comments {
 		helper.CallTool("add_issue_comment", map[string]any{
-			"owner":       helper.GetOwner(),
+			"owner":       helper.GetRepoOwner(),
 			"repo":        repoName,
 			"issueNumber": issueNumber,
 			"body":        comment,
@@ -298,7 +297,7 @@ func TestCollaborativeWorkflow(t *testing.T) {
 
 	// Phase 6: Add PR review comments
 	helper.CallTool("create_pull_request_review", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": prNumber,
 		"event":      "COMMENT",
@@ -308,14 +307,14 @@ func TestCollaborativeWorkflow(t *testing.T) {
 
 	// Phase 7: Merge and close
 	helper.CallTool("merge_pull_request", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"pullNumber":  prNumber,
 		"mergeMethod": "merge",
 	})
 
 	helper.CallTool("update_issue", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"issueNumber": issueNumber,
 		"state":       "closed",
@@ -343,7 +342,7 @@ func TestErrorRecoveryWorkflow(t *testing.T) {
 
 	// Try to get non-existent issue
 	invalidIssueResponse := helper.CallToolWithError("get_issue", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"issueNumber": 99999,
 	})
@@ -352,7 +351,7 @@ func TestErrorRecoveryWorkflow(t *testing.T) {
 
 	// Try to get non-existent PR
 	invalidPRResponse := helper.CallToolWithError("get_pull_request", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": 99999,
 	})
@@ -372,14 +371,14 @@ func TestErrorRecoveryWorkflow(t *testing.T) {
 
 	// Phase 5: Verify everything still works after errors
 	getIssueResponse := helper.CallTool("get_issue", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"issueNumber": issueNumber,
 	})
 	require.False(t, getIssueResponse.IsError, "expected successful issue retrieval after errors")
 
 	getPRResponse := helper.CallTool("get_pull_request", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": prNumber,
 	})
@@ -0,0 +1,128 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	mcpClient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// serverMatrix lists the server configurations the matrix tests run against.
+func serverMatrix() []TestConfiguration {
+	return []TestConfiguration{
+		{Name: "default", Toolsets: github.GetDefaultToolsetIDs()},
+		{Name: "read-only", Toolsets: github.GetDefaultToolsetIDs(), ReadOnly: true},
+		{Name: "dynamic-toolsets", Toolsets: []string{"context"}, DynamicToolsets: true},
+		{Name: "restricted-toolsets", Toolsets: []string{"context", "repos", "issues"}},
+	}
+}
+
+// runServerMatrix runs the test against every configuration of the server matrix, as a subtest per configuration.
+func runServerMatrix(t *testing.T, test func(t *testing.T, config TestConfiguration, client *mcpClient.Client)) {
+	// The subtests share the fixture of the test, which has to outlive them
+	getVCR(t)
+
+	for _, config := range serverMatrix() {
+		t.Run(config.Name, func(t *testing.T) {
+			test(t, config, setupMCPClient(t, withServerConfig(config)))
+		})
+	}
+}
+
+// listTools lists the tools the server offers, along with their names.
+func listTools(t *testing.T, client *mcpClient.Client) ([]mcp.Tool, []string) {
+	response, err := client.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err, "expected to list tools successfully")
+
+	names := make([]string, 0, len(response.Tools))
+	for _, tool := range response.Tools {
+		names = append(names, tool.Name)
+	}
+	return response.Tools, names
+}
+
+func TestServerMatrixTools(t *testing.T) {
+	t.Parallel()
+	withoutGitHub(t)
+
+	expectations := map[string]struct {
+		present []string
+		absent  []string
+	}{
+		"default": {
+			present: []string{"get_me", "get_issue", "create_issue", "get_pull_request", "merge_pull_request"},
+			absent:  []string{"enable_toolset"},
+		},
+		"read-only": {
+			present: []string{"get_me", "get_issue", "get_pull_request"},
+			absent:  []string{"create_issue", "create_repository", "create_or_update_file", "merge_pull_request", "enable_toolset"},
+		},
+		"dynamic-toolsets": {
+			present: []string{"get_me", "enable_toolset", "list_available_toolsets", "get_toolset_tools"},
+			absent:  []string{"get_issue", "get_pull_request"},
+		},
+		"restricted-toolsets": {
+			present: []string{"get_me", "get_issue", "list_branches"},
+			absent:  []string{"get_pull_request", "enable_toolset"},
+		},
+	}
+
+	runServerMatrix(t, func(t *testing.T, config TestConfiguration, client *mcpClient.Client) {
+		expected, ok := expectations[config.Name]
+		require.True(t, ok, "expected tool expectations for configuration %s", config.Name)
+
+		tools, names := listTools(t, client)
+		for _, name := range expected.present {
+			require.Contains(t, names, name, "expected %s to be offered", name)
+		}
+		for _, name := range expected.absent {
+			require.NotContains(t, names, name, "expected %s not to be offered", name)
+		}
+
+		if config.ReadOnly {
+			// Tools must all be read-only, and mutating ones must be blocked rather than merely hidden
+			for _, tool := range tools {
+				readOnly := tool.Annotations.ReadOnlyHint
+				require.True(t, readOnly != nil && *readOnly, "expected %s to be read-only", tool.Name)
+			}
+
+			request := mcp.CallToolRequest{}
+			request.Params.Name = "create_issue"
+			request.Params.Arguments = map[string]any{"owner": "github", "repo": "github-mcp-server", "title": "Should not be created"}
+			result, err := client.CallTool(context.Background(), request)
+			require.True(t, err != nil || result.IsError, "expected create_issue to be blocked in read-only mode")
+		}
+
+		if config.DynamicToolsets {
+			request := mcp.CallToolRequest{}
+			request.Params.Name = "enable_toolset"
+			request.Params.Arguments = map[string]any{"toolset": "issues"}
+			result, err := client.CallTool(context.Background(), request)
+			require.NoError(t, err, "expected to call 'enable_toolset' tool successfully")
+			require.False(t, result.IsError, "expected result not to be an error")
+
+			_, names := listTools(t, client)
+			require.Contains(t, names, "get_issue", "expected the issues toolset to be enabled")
+			require.NotContains(t, names, "get_pull_request", "expected other toolsets to stay disabled")
+		}
+	})
+}
+
+func TestServerMatrixGetMe(t *testing.T) {
+	t.Parallel()
+
+	ghClient := getRESTClient(t)
+	user, _, err := ghClient.Users.Get(context.Background(), "")
+	require.NoError(t, err, "expected to get user successfully")
+
+	runServerMatrix(t, func(t *testing.T, _ TestConfiguration, client *mcpClient.Client) {
+		// The helper gets the login with get_me
+		helper := NewTestHelper(t, client)
+		require.Equal(t, user.GetLogin(), helper.GetOwner(), "expected login to match")
+	})
+}
@@ -3,10 +3,12 @@
 package e2e_test
 
 import (
-	"sync"
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,116 +17,54 @@ const (
 	cleanupBranchName = "cleanup-branch"
 )
 
-// TestConcurrentOperationsLoad tests handling of multiple concurrent operations
-func TestConcurrentOperationsLoad(t *testing.T) {
-	t.Parallel()
-
-	mcpClient := setupMCPClient(t)
-	helper := NewTestHelper(t, mcpClient)
-
-	helper.LogTestStep("Testing concurrent operations load")
-
-	repoName := helper.CreateTestRepo("concurrent-load-test")
-
-	// Test concurrent repository operations
-	var wg sync.WaitGroup
-	numWorkers := 5
-	operationsPerWorker := 10
-
-	startTime := time.Now()
-
-	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
-
-			for j := 0; j < operationsPerWorker; j++ {
-				// Perform various operations concurrently
-				helper.WaitForRateLimit()
-
-				// Get repository info
-				helper.CallTool("get_repository", map[string]any{
-					"owner": helper.GetOwner(),
-					"repo":  repoName,
-				})
-
-				// List branches
-				if helper.ValidateToolAvailability("list_branches") {
-					helper.CallTool("list_branches", map[string]any{
-						"owner": helper.GetOwner(),
-						"repo":  repoName,
-					})
-				}
-			}
-		}(i)
+// repositoryLoadTools is the mix of read tools the load tests call on the repository.
+func repositoryLoadTools(helper *TestHelper, repoName string) []ghmcp.LoadTestTool {
+	arguments := map[string]any{
+		"owner": helper.GetRepoOwner(),
+		"repo":  repoName,
 	}
+	tools := []ghmcp.LoadTestTool{{Name: "get_repository", Weight: 1, Arguments: arguments}}
+	if helper.ValidateToolAvailability("list_branches") {
+		tools = append(tools, ghmcp.LoadTestTool{Name: "list_branches", Weight: 1, Arguments: arguments})
+	}
+	return tools
+}
 
-	wg.Wait()
-	duration := time.Since(startTime)
-
-	helper.LogTestResult("Concurrent operations completed in %v", duration)
-	require.Less(t, duration, 2*time.Minute, "expected concurrent operations to complete within 2 minutes")
+// requireLoadTestSucceeded logs the stats of the load test, and fails the test unless every session and call succeeded.
+func requireLoadTestSucceeded(t *testing.T, helper *TestHelper, report *ghmcp.LoadTestReport) {
+	for _, stats := range append(report.Tools, report.Total) {
+		helper.LogTestResult("%s: %d calls, %d errors, p50 %v, p90 %v, p99 %v, max %v",
+			stats.Name, stats.Calls, stats.Errors, stats.P50, stats.P90, stats.P99, stats.Max)
+	}
+	require.Zero(t, report.FailedSessions, "expected all sessions to initialize, failures: %v", report.Failures)
+	require.Zero(t, report.Total.Errors, "expected all calls to succeed, failures: %v", report.Failures)
 }
 
-// TestResponseTimeBenchmarking tests response times for various operations
-func TestResponseTimeBenchmarking(t *testing.T) {
+// TestConcurrentOperationsLoad tests handling of multiple concurrent operations
+func TestConcurrentOperationsLoad(t *testing.T) {
 	t.Parallel()
 
 	mcpClient := setupMCPClient(t)
 	helper := NewTestHelper(t, mcpClient)
 
-	helper.LogTestStep("Benchmarking response times")
-
-	repoName := helper.CreateTestRepo("benchmark-test")
-
-	// Benchmark repository operations
-	operations := []struct {
-		name string
-		fn   func() error
-	}{
-		{"get_repository", func() error {
-			response := helper.CallTool("get_repository", map[string]any{
-				"owner": helper.GetOwner(),
-				"repo":  repoName,
-			})
-			if response.IsError {
-				return response.IsError
-			}
-			return nil
-		}},
-		{"list_branches", func() error {
-			if !helper.ValidateToolAvailability("list_branches") {
-				return nil // Skip if not available
-			}
-			response := helper.CallTool("list_branches", map[string]any{
-				"owner": helper.GetOwner(),
-				"repo":  repoName,
-			})
-			if response.IsError {
-				return response.IsError
-			}
-			return nil
-		}},
-		{"get_me", func() error {
-			response := helper.CallTool("get_me", map[string]any{})
-			if response.IsError {
-				return response.IsError
-			}
-			return nil
-		}},
-	}
+	helper.LogTestStep("Testing concurrent operations load")
 
-	for _, op := range operations {
-		start := time.Now()
-		err := op.fn()
-		duration := time.Since(start)
+	repoName := helper.CreateTestRepo("concurrent-load-test")
 
-		require.NoError(t, err, "expected %s operation to succeed", op.name)
-		helper.LogTestResult("%s operation took %v", op.name, duration)
+	// Test concurrent repository operations, from as many sessions as the loadtest subcommand would drive
+	report, err := ghmcp.LoadTest(context.Background(), ghmcp.LoadTestConfig{
+		URL:             setupHTTPServer(t),
+		Sessions:        5,
+		CallsPerSession: 20,
+		Interval:        500 * time.Millisecond,
+		Timeout:         30 * time.Second,
+		Tools:           repositoryLoadTools(helper, repoName),
+	})
+	require.NoError(t, err, "expected load test to run")
 
-		// Response should be reasonable (under 30 seconds)
-		require.Less(t, duration, 30*time.Second, "expected %s to complete within 30 seconds", op.name)
-	}
+	helper.LogTestResult("Concurrent operations completed in %v", report.Duration)
+	requireLoadTestSucceeded(t, helper, report)
+	require.Less(t, report.Duration, 2*time.Minute, "expected concurrent operations to complete within 2 minutes")
 }
 
 // TestStabilityUnderProlongedUsage tests system stability over extended periods
@@ -151,14 +91,14 @@ func TestStabilityUnderProlongedUsage(t *testing.T) {
 
 		// Get repository info
 		helper.CallTool("get_repository", map[string]any{
-			"owner": helper.GetOwner(),
+			"owner": helper.GetRepoOwner(),
 			"repo":  repoName,
 		})
 
 		// List branches
 		if helper.ValidateToolAvailability("list_branches") {
 			helper.CallTool("list_branches", map[string]any{
-				"owner": helper.GetOwner(),
+				"owner": helper.GetRepoOwner(),
 				"repo":  repoName,
 			})
 		}
@@ -172,7 +112,7 @@ func TestStabilityUnderProlongedUsage(t *testing.T) {
 
 	// Verify repository still exists and is accessible
 	finalResponse := helper.CallTool("get_repository", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 	})
 	require.False(t, finalResponse.IsError, "expected repository to still be accessible after prolonged usage")
@@ -234,7 +174,7 @@ func TestMemoryUsageMonitoring(t *testing.T) {
 
 		fileName := "memory-" + string(rune(i+'0')) + ".txt"
 		helper.CallTool("create_or_update_file", map[string]any{
-			"owner":   helper.GetOwner(),
+			"owner":   helper.GetRepoOwner(),
 			"repo":    repoName,
 			"path":    fileName,
 			"content": content,
@@ -244,7 +184,7 @@ func TestMemoryUsageMonitoring(t *testing.T) {
 
 		// Retrieve file
 		helper.CallTool("get_file_contents", map[string]any{
-			"owner":  helper.GetOwner(),
+			"owner":  helper.GetRepoOwner(),
 			"repo":   repoName,
 			"path":   fileName,
 			"branch": "main",
@@ -267,8 +207,8 @@ func TestOperationTimeouts(t *testing.T) {
 
 	// Test that operations complete within reasonable time
 	operations := []struct {
-		name     string
-		timeout  time.Duration
+		name      string
+		timeout   time.Duration
 		operation func() error
 	}{
 		{
@@ -276,11 +216,11 @@ func TestOperationTimeouts(t *testing.T) {
 			timeout: 10 * time.Second,
 			operation: func() error {
 				response := helper.CallTool("get_repository", map[string]any{
-					"owner": helper.GetOwner(),
+					"owner": helper.GetRepoOwner(),
 					"repo":  repoName,
 				})
 				if response.IsError {
-					return response.IsError
+					return fmt.Errorf("%s", getTextContent(t, response))
 				}
 				return nil
 			},
@@ -293,11 +233,11 @@ func TestOperationTimeouts(t *testing.T) {
 					return nil
 				}
 				response := helper.CallTool("list_branches", map[string]any{
-					"owner": helper.GetOwner(),
+					"owner": helper.GetRepoOwner(),
 					"repo":  repoName,
 				})
 				if response.IsError {
-					return response.IsError
+					return fmt.Errorf("%s", getTextContent(t, response))
 				}
 				return nil
 			},
@@ -331,38 +271,28 @@ func TestGradualLoadIncrease(t *testing.T) {
 	helper.LogTestStep("Testing gradual load increase")
 
 	repoName := helper.CreateTestRepo("gradual-load-test")
+	url := setupHTTPServer(t)
+	tools := repositoryLoadTools(helper, repoName)
 
-	// Gradually increase the number of operations
+	// Gradually increase the number of concurrent sessions
 	for batch := 1; batch <= 5; batch++ {
-		helper.LogTestStep("Batch %d: Performing %d operations", batch, batch*2)
-
-		startTime := time.Now()
-
-		// Perform batch*2 operations
-		for i := 0; i < batch*2; i++ {
-			helper.WaitForRateLimit()
-
-			// Alternate between different operations
-			if i%2 == 0 {
-				helper.CallTool("get_repository", map[string]any{
-					"owner": helper.GetOwner(),
-					"repo":  repoName,
-				})
-			} else {
-				if helper.ValidateToolAvailability("list_branches") {
-					helper.CallTool("list_branches", map[string]any{
-						"owner": helper.GetOwner(),
-						"repo":  repoName,
-					})
-				}
-			}
-		}
+		helper.LogTestStep("Batch %d: Performing %d operations from %d sessions", batch, batch*2, batch)
+
+		report, err := ghmcp.LoadTest(context.Background(), ghmcp.LoadTestConfig{
+			URL:             url,
+			Sessions:        batch,
+			CallsPerSession: 2,
+			Interval:        time.Second,
+			Timeout:         30 * time.Second,
+			Tools:           tools,
+		})
+		require.NoError(t, err, "expected load test to run")
 
-		batchDuration := time.Since(startTime)
-		helper.LogTestResult("Batch %d completed in %v", batch, batchDuration)
+		helper.LogTestResult("Batch %d completed in %v", batch, report.Duration)
+		requireLoadTestSucceeded(t, helper, report)
 
 		// Each batch should not take excessively long
-		require.Less(t, batchDuration, time.Duration(batch)*10*time.Second, "expected batch %d to complete within reasonable time", batch)
+		require.Less(t, report.Duration, time.Duration(batch)*10*time.Second, "expected batch %d to complete within reasonable time", batch)
 	}
 }
 
@@ -382,7 +312,7 @@ func TestRecoveryAfterLoad(t *testing.T) {
 	for i := 0; i < 20; i++ {
 		helper.WaitForRateLimit()
 		helper.CallTool("get_repository", map[string]any{
-			"owner": helper.GetOwner(),
+			"owner": helper.GetRepoOwner(),
 			"repo":  repoName,
 		})
 	}
@@ -392,7 +322,7 @@ func TestRecoveryAfterLoad(t *testing.T) {
 
 	for i := 0; i < 5; i++ {
 		response := helper.CallTool("get_repository", map[string]any{
-			"owner": helper.GetOwner(),
+			"owner": helper.GetRepoOwner(),
 			"repo":  repoName,
 		})
 		require.False(t, response.IsError, "expected operation to succeed after high load")
@@ -404,35 +334,3 @@ func TestRecoveryAfterLoad(t *testing.T) {
 
 	helper.LogTestResult("System recovered successfully after high load")
 }
-
-// TestOperationThroughput tests the overall throughput of operations
-func TestOperationThroughput(t *testing.T) {
-	t.Parallel()
-
-	mcpClient := setupMCPClient(t)
-	helper := NewTestHelper(t, mcpClient)
-
-	helper.LogTestStep("Testing operation throughput")
-
-	repoName := helper.CreateTestRepo("throughput-test")
-
-	// Measure throughput for a series of operations
-	numOperations := 30
-	startTime := time.Now()
-
-	for i := 0; i < numOperations; i++ {
-		helper.WaitForRateLimit()
-		helper.CallTool("get_repository", map[string]any{
-			"owner": helper.GetOwner(),
-			"repo":  repoName,
-		})
-	}
-
-	totalDuration := time.Since(startTime)
-	throughput := float64(numOperations) / totalDuration.Seconds()
-
-	helper.LogTestResult("Completed %d operations in %v (%.2f ops/sec)", numOperations, totalDuration, throughput)
-
-	// Throughput should be reasonable (at least 0.5 ops/sec with rate limiting)
-	require.Greater(t, throughput, 0.1, "expected minimum throughput of 0.1 ops/sec")
-}
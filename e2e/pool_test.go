@@ -0,0 +1,247 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/ghmcp"
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// defaultPoolSize is the number of pooled repositories when GITHUB_MCP_SERVER_E2E_POOL_SIZE isn't set.
+	defaultPoolSize = 8
+
+	// pristineTag points at the initial commit of a pooled repository, which its default branch is reset to.
+	pristineTag = "e2e-pristine"
+)
+
+var (
+	getPoolOnce sync.Once
+	pool        *repoPool
+	poolError   error
+)
+
+// repoPool hands out the repositories of the test organization to tests, so that they don't each create and
+// delete a repository. Repositories are reset when they're handed out, and given back once the test is done.
+type repoPool struct {
+	org    string
+	client *gogithub.Client
+
+	mu   sync.Mutex
+	free []string
+}
+
+// getE2EOrg returns the organization the tests create their repositories in, empty to create them in the account
+// of the token owner.
+func getE2EOrg() string {
+	return os.Getenv("GITHUB_MCP_SERVER_E2E_ORG")
+}
+
+// getRepoPool returns the pool of repositories of the test organization, creating the ones that don't exist yet
+// the first time it's called.
+func getRepoPool(t *testing.T) *repoPool {
+	getPoolOnce.Do(func() {
+		size := defaultPoolSize
+		if value := os.Getenv("GITHUB_MCP_SERVER_E2E_POOL_SIZE"); value != "" {
+			size, poolError = strconv.Atoi(value)
+			if poolError != nil || size < 1 {
+				poolError = fmt.Errorf("GITHUB_MCP_SERVER_E2E_POOL_SIZE must be a positive number, got %q", value)
+				return
+			}
+		}
+
+		// The pool is shared by all the tests, so its requests are never recorded in the fixture of one of them
+		pool = &repoPool{org: getE2EOrg(), client: newRESTClient(t, nil)}
+		for i := 1; i <= size; i++ {
+			name := fmt.Sprintf("%s%d", ghmcp.E2EPoolRepoPrefix, i)
+			if poolError = pool.ensure(context.Background(), name); poolError != nil {
+				return
+			}
+			pool.free = append(pool.free, name)
+		}
+	})
+	require.NoError(t, poolError, "expected to set up the repository pool of %s", getE2EOrg())
+	return pool
+}
+
+// acquire hands out a free repository of the pool, reset to its pristine state, which is given back once the
+// test is done. When all of them are in use, a repository is created for the test and deleted afterwards.
+func (p *repoPool) acquire(t *testing.T) string {
+	ctx := context.Background()
+
+	p.mu.Lock()
+	var name string
+	if len(p.free) > 0 {
+		name, p.free = p.free[0], p.free[1:]
+	}
+	p.mu.Unlock()
+
+	if name == "" {
+		name = fmt.Sprintf("%s%s-%d", ghmcp.E2ERepoPrefix, strings.ReplaceAll(t.Name(), "/", "-"), time.Now().UnixMilli())
+		t.Logf("All pooled repositories are in use, creating %s/%s", p.org, name)
+		_, _, err := p.client.Repositories.Create(ctx, p.org, &gogithub.Repository{
+			Name:     gogithub.Ptr(name),
+			Private:  gogithub.Ptr(true),
+			AutoInit: gogithub.Ptr(true),
+		})
+		require.NoError(t, err, "expected to create repository %s/%s", p.org, name)
+		t.Cleanup(func() {
+			if _, err := p.client.Repositories.Delete(context.Background(), p.org, name); err != nil {
+				t.Logf("Warning: Failed to delete test repository %s/%s: %v", p.org, name, err)
+			}
+		})
+		return name
+	}
+
+	t.Cleanup(func() {
+		p.mu.Lock()
+		p.free = append(p.free, name)
+		p.mu.Unlock()
+	})
+	require.NoError(t, p.reset(ctx, name), "expected to reset pooled repository %s/%s", p.org, name)
+	return name
+}
+
+// ensure creates a pooled repository when it doesn't exist, and tags its pristine state.
+func (p *repoPool) ensure(ctx context.Context, name string) error {
+	repo, resp, err := p.client.Repositories.Get(ctx, p.org, name)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		repo, _, err = p.client.Repositories.Create(ctx, p.org, &gogithub.Repository{
+			Name:        gogithub.Ptr(name),
+			Description: gogithub.Ptr("Pooled repository of the github-mcp-server e2e tests, reset between tests"),
+			Private:     gogithub.Ptr(true),
+			AutoInit:    gogithub.Ptr(true),
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get repository %s/%s: %w", p.org, name, err)
+	}
+
+	_, resp, err = p.client.Git.GetRef(ctx, p.org, name, "refs/tags/"+pristineTag)
+	if err == nil {
+		return nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to get the pristine tag of %s/%s: %w", p.org, name, err)
+	}
+
+	// Repositories created before the pool tagged them are pristine from their current state on
+	head, _, err := p.client.Git.GetRef(ctx, p.org, name, "refs/heads/"+repo.GetDefaultBranch())
+	if err != nil {
+		return fmt.Errorf("failed to get the default branch of %s/%s: %w", p.org, name, err)
+	}
+	_, _, err = p.client.Git.CreateRef(ctx, p.org, name, &gogithub.Reference{
+		Ref:    gogithub.Ptr("refs/tags/" + pristineTag),
+		Object: &gogithub.GitObject{SHA: head.Object.SHA},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag the pristine state of %s/%s: %w", p.org, name, err)
+	}
+	return nil
+}
+
+// reset brings a pooled repository back to its pristine state: its default branch points at the pristine commit,
+// other branches and tags are deleted, and open issues and pull requests are closed.
+func (p *repoPool) reset(ctx context.Context, name string) error {
+	repo, _, err := p.client.Repositories.Get(ctx, p.org, name)
+	if err != nil {
+		return fmt.Errorf("failed to get repository: %w", err)
+	}
+	defaultBranch := repo.GetDefaultBranch()
+
+	pristine, _, err := p.client.Git.GetRef(ctx, p.org, name, "refs/tags/"+pristineTag)
+	if err != nil {
+		return fmt.Errorf("failed to get the pristine tag: %w", err)
+	}
+	_, _, err = p.client.Git.UpdateRef(ctx, p.org, name, &gogithub.Reference{
+		Ref:    gogithub.Ptr("refs/heads/" + defaultBranch),
+		Object: &gogithub.GitObject{SHA: pristine.Object.SHA},
+	}, true)
+	if err != nil {
+		return fmt.Errorf("failed to reset %s: %w", defaultBranch, err)
+	}
+
+	// Closing pull requests before deleting their branches keeps them from being left open without a head
+	for opts := (&gogithub.PullRequestListOptions{State: "open", ListOptions: gogithub.ListOptions{PerPage: 100}}); ; {
+		pulls, resp, err := p.client.PullRequests.List(ctx, p.org, name, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list pull requests: %w", err)
+		}
+		for _, pull := range pulls {
+			if _, _, err := p.client.PullRequests.Edit(ctx, p.org, name, pull.GetNumber(), &gogithub.PullRequest{State: gogithub.Ptr("closed")}); err != nil {
+				return fmt.Errorf("failed to close pull request #%d: %w", pull.GetNumber(), err)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	for opts := (&gogithub.IssueListByRepoOptions{State: "open", ListOptions: gogithub.ListOptions{PerPage: 100}}); ; {
+		issues, resp, err := p.client.Issues.ListByRepo(ctx, p.org, name, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				continue
+			}
+			if _, _, err := p.client.Issues.Edit(ctx, p.org, name, issue.GetNumber(), &gogithub.IssueRequest{State: gogithub.Ptr("closed")}); err != nil {
+				return fmt.Errorf("failed to close issue #%d: %w", issue.GetNumber(), err)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	keep := map[string]bool{"refs/heads/" + defaultBranch: true, "refs/tags/" + pristineTag: true}
+	for _, prefix := range []string{"heads/", "tags/"} {
+		for opts := (&gogithub.ReferenceListOptions{Ref: prefix, ListOptions: gogithub.ListOptions{PerPage: 100}}); ; {
+			refs, resp, err := p.client.Git.ListMatchingRefs(ctx, p.org, name, opts)
+			if err != nil {
+				return fmt.Errorf("failed to list %s: %w", strings.TrimSuffix(prefix, "/"), err)
+			}
+			for _, ref := range refs {
+				if keep[ref.GetRef()] {
+					continue
+				}
+				if _, err := p.client.Git.DeleteRef(ctx, p.org, name, ref.GetRef()); err != nil {
+					return fmt.Errorf("failed to delete %s: %w", ref.GetRef(), err)
+				}
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+	}
+	return nil
+}
+
+// acquirePooledRepo hands out a repository of the pool to the test. When replaying, the repository recorded in the
+// fixture is used instead.
+func acquirePooledRepo(t *testing.T) string {
+	vcr := getVCR(t)
+	if vcr == nil {
+		return getRepoPool(t).acquire(t)
+	}
+	name, err := vcr.Value(func() string {
+		return getRepoPool(t).acquire(t)
+	})
+	require.NoError(t, err, "expected the fixture to have a recorded pooled repository")
+	return name
+}
@@ -31,7 +31,7 @@ func TestPullRequestsToolsetCreatePullRequest(t *testing.T) {
 	helper.CreateTestFile(repoName, featureBranchName, "feature.txt", "New feature content", "Add feature file")
 
 	response := helper.CallTool("create_pull_request", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 		"title": "Test Pull Request",
 		"body":  "This is a test PR created by E2E tests.",
@@ -77,7 +77,7 @@ func TestPullRequestsToolsetGetPullRequest(t *testing.T) {
 	prNumber := helper.CreateTestPR(repoName, "PR to Retrieve", "Test PR body", "main", "main")
 
 	response := helper.CallTool("get_pull_request", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": prNumber,
 	})
@@ -113,7 +113,7 @@ func TestPullRequestsToolsetListPullRequests(t *testing.T) {
 	pr2 := helper.CreateTestPR(repoName, "Second PR", "Second PR body", "main", "main")
 
 	response := helper.CallTool("list_pull_requests", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 		"state": "open",
 	})
@@ -160,7 +160,7 @@ func TestPullRequestsToolsetUpdatePullRequest(t *testing.T) {
 	prNumber := helper.CreateTestPR(repoName, "PR to Update", "Original body", "main", "main")
 
 	response := helper.CallTool("update_pull_request", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": prNumber,
 		"title":      "Updated PR Title",
@@ -198,7 +198,7 @@ func TestPullRequestsToolsetCreatePullRequestReview(t *testing.T) {
 	prNumber := helper.CreateTestPR(repoName, "PR for Review", "Test PR", "main", "main")
 
 	response := helper.CallTool("create_pull_request_review", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": prNumber,
 		"event":      "COMMENT",
@@ -206,10 +206,10 @@ func TestPullRequestsToolsetCreatePullRequestReview(t *testing.T) {
 	})
 
 	var review struct {
-		ID     int    `json:"id"`
-		State  string `json:"state"`
-		Body   string `json:"body"`
-		User   struct {
+		ID    int    `json:"id"`
+		State string `json:"state"`
+		Body  string `json:"body"`
+		User  struct {
 			Login string `json:"login"`
 		} `json:"user"`
 	}
@@ -238,7 +238,7 @@ func TestPullRequestsToolsetGetPullRequestReviews(t *testing.T) {
 
 	// Create a review first
 	helper.CallTool("create_pull_request_review", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": prNumber,
 		"event":      "COMMENT",
@@ -246,7 +246,7 @@ func TestPullRequestsToolsetGetPullRequestReviews(t *testing.T) {
 	})
 
 	response := helper.CallTool("get_pull_request_reviews", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": prNumber,
 	})
@@ -291,12 +291,12 @@ func TestPullRequestsToolsetMergePullRequest(t *testing.T) {
 	prNumber := helper.CreateTestPR(repoName, "PR to Merge", "Test PR for merging", mergeBranchName, "main")
 
 	response := helper.CallTool("merge_pull_request", map[string]any{
-		"owner":                helper.GetOwner(),
-		"repo":                 repoName,
-		"pullNumber":           prNumber,
-		"mergeMethod":          "merge",
-		"commitTitle":          "Merge PR: Test PR for merging",
-		"commitMessage":        "Merging test PR via E2E tests",
+		"owner":         helper.GetRepoOwner(),
+		"repo":          repoName,
+		"pullNumber":    prNumber,
+		"mergeMethod":   "merge",
+		"commitTitle":   "Merge PR: Test PR for merging",
+		"commitMessage": "Merging test PR via E2E tests",
 	})
 
 	var mergeResult struct {
@@ -326,7 +326,7 @@ func TestPullRequestsToolsetInvalidPullRequestNumber(t *testing.T) {
 
 	// Try to get a non-existent PR
 	response := helper.CallToolWithError("get_pull_request", map[string]any{
-		"owner":      helper.GetOwner(),
+		"owner":      helper.GetRepoOwner(),
 		"repo":       repoName,
 		"pullNumber": 99999,
 	})
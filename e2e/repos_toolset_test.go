@@ -3,9 +3,13 @@
 package e2e_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/github/github-mcp-server/pkg/github"
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
 )
 
@@ -19,7 +23,7 @@ func TestReposToolsetCreateRepository(t *testing.T) {
 	helper.SkipIfToolNotAvailable("create_repository")
 	helper.LogTestStep("Testing repository creation")
 
-	repoName := GenerateUniqueName("test-repo")
+	repoName := GenerateUniqueName(t, "test-repo")
 
 	response := helper.CallTool("create_repository", map[string]any{
 		"name":        repoName,
@@ -62,13 +66,13 @@ func TestReposToolsetGetRepository(t *testing.T) {
 	repoName := helper.CreateTestRepo("get-repo-test")
 
 	response := helper.CallTool("get_repository", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 	})
 
 	var repo struct {
-		Name     string `json:"name"`
-		Owner    struct {
+		Name  string `json:"name"`
+		Owner struct {
 			Login string `json:"login"`
 		} `json:"owner"`
 		DefaultBranch string `json:"default_branch"`
@@ -76,7 +80,7 @@ func TestReposToolsetGetRepository(t *testing.T) {
 
 	helper.AssertJSONResponse(response, &repo)
 	require.Equal(t, repoName, repo.Name, "expected repository name to match")
-	require.Equal(t, helper.GetOwner(), repo.Owner.Login, "expected owner to match")
+	require.Equal(t, helper.GetRepoOwner(), repo.Owner.Login, "expected owner to match")
 	require.Equal(t, "main", repo.DefaultBranch, "expected default branch to be main")
 
 	helper.LogTestResult("Repository retrieved successfully")
@@ -133,7 +137,7 @@ func TestReposToolsetSearchRepositories(t *testing.T) {
 	repoName := helper.CreateTestRepo("search-test")
 
 	response := helper.CallTool("search_repositories", map[string]any{
-		"query": fmt.Sprintf("repo:%s/%s", helper.GetOwner(), repoName),
+		"query": fmt.Sprintf("repo:%s/%s", helper.GetRepoOwner(), repoName),
 	})
 
 	var searchResult struct {
@@ -152,7 +156,7 @@ func TestReposToolsetSearchRepositories(t *testing.T) {
 
 	found := false
 	for _, item := range searchResult.Items {
-		if item.Name == repoName && item.Owner.Login == helper.GetOwner() {
+		if item.Name == repoName && item.Owner.Login == helper.GetRepoOwner() {
 			found = true
 			break
 		}
@@ -175,18 +179,12 @@ func TestReposToolsetListBranches(t *testing.T) {
 	repoName := helper.CreateTestRepo("branches-test")
 
 	response := helper.CallTool("list_branches", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 	})
 
-	var branches []struct {
-		Name   string `json:"name"`
-		Commit struct {
-			SHA string `json:"sha"`
-		} `json:"commit"`
-	}
-
-	helper.AssertJSONResponse(response, &branches)
+	helper.AssertStructuredResponse("list_branches", response)
+	branches := GetResponseAs[[]github.MinimalBranch](t, response)
 	require.GreaterOrEqual(t, len(branches), 1, "expected at least one branch (main)")
 
 	// Check that main branch exists
@@ -194,7 +192,7 @@ func TestReposToolsetListBranches(t *testing.T) {
 	for _, branch := range branches {
 		if branch.Name == "main" {
 			mainBranchFound = true
-			require.NotEmpty(t, branch.Commit.SHA, "expected main branch to have a commit SHA")
+			require.NotEmpty(t, branch.SHA, "expected main branch to have a commit SHA")
 			break
 		}
 	}
@@ -203,6 +201,38 @@ func TestReposToolsetListBranches(t *testing.T) {
 	helper.LogTestResult("Branch listing works correctly")
 }
 
+// TestReposToolsetListBranchesPaged tests following the pages of branch listing
+func TestReposToolsetListBranchesPaged(t *testing.T) {
+	t.Parallel()
+
+	mcpClient := setupMCPClient(t)
+	helper := NewTestHelper(t, mcpClient)
+
+	helper.SkipIfToolNotAvailable("list_branches")
+	helper.LogTestStep("Testing paged branch listing")
+
+	repoName := helper.CreateTestRepo("paged-branches-test")
+	helper.CreateTestBranch(repoName, "paged-1")
+	helper.CreateTestBranch(repoName, "paged-2")
+
+	pages := helper.CallToolPaged("list_branches", map[string]any{
+		"owner": helper.GetRepoOwner(),
+		"repo":  repoName,
+	}, 1)
+	for _, page := range pages {
+		helper.AssertStructuredResponse("list_branches", page)
+	}
+
+	var names []string
+	for _, branch := range GetPagedItems[github.MinimalBranch](t, pages) {
+		names = append(names, branch.Name)
+	}
+	require.ElementsMatch(t, []string{"main", "paged-1", "paged-2"}, names, "expected every branch once across the pages")
+	require.Len(t, pages, 3, "expected a page per branch")
+
+	helper.LogTestResult("Paged branch listing works correctly")
+}
+
 // TestReposToolsetCreateBranch tests branch creation
 func TestReposToolsetCreateBranch(t *testing.T) {
 	t.Parallel()
@@ -217,7 +247,7 @@ func TestReposToolsetCreateBranch(t *testing.T) {
 	branchName := "test-branch"
 
 	response := helper.CallTool("create_branch", map[string]any{
-		"owner":       helper.GetOwner(),
+		"owner":       helper.GetRepoOwner(),
 		"repo":        repoName,
 		"branch":      branchName,
 		"from_branch": "main",
@@ -256,7 +286,7 @@ func TestReposToolsetFileOperations(t *testing.T) {
 
 	// Create file
 	createResponse := helper.CallTool("create_or_update_file", map[string]any{
-		"owner":   helper.GetOwner(),
+		"owner":   helper.GetRepoOwner(),
 		"repo":    repoName,
 		"path":    filePath,
 		"content": initialContent,
@@ -275,7 +305,7 @@ func TestReposToolsetFileOperations(t *testing.T) {
 
 	// Read file
 	getResponse := helper.CallTool("get_file_contents", map[string]any{
-		"owner":  helper.GetOwner(),
+		"owner":  helper.GetRepoOwner(),
 		"repo":   repoName,
 		"path":   filePath,
 		"branch": branchName,
@@ -283,16 +313,15 @@ func TestReposToolsetFileOperations(t *testing.T) {
 
 	// The response should contain embedded resource with file content
 	require.Len(t, getResponse.Content, 2, "expected content and embedded resource")
-	embeddedResource := getResponse.Content[1]
-	textResource, ok := embeddedResource.(map[string]interface{})["resource"].(map[string]interface{})["textResourceContents"]
-	require.True(t, ok, "expected embedded resource to contain text content")
-
-	content := textResource.(map[string]interface{})["text"].(string)
-	require.Equal(t, initialContent, content, "expected file content to match initial content")
+	embeddedResource, ok := getResponse.Content[1].(mcp.EmbeddedResource)
+	require.True(t, ok, "expected content to be of type EmbeddedResource")
+	textResource, ok := embeddedResource.Resource.(mcp.TextResourceContents)
+	require.True(t, ok, "expected embedded resource to be of type TextResourceContents")
+	require.Equal(t, initialContent, textResource.Text, "expected file content to match initial content")
 
 	// Update file
 	updateResponse := helper.CallTool("create_or_update_file", map[string]any{
-		"owner":   helper.GetOwner(),
+		"owner":   helper.GetRepoOwner(),
 		"repo":    repoName,
 		"path":    filePath,
 		"content": updatedContent,
@@ -332,7 +361,7 @@ func TestReposToolsetDeleteFile(t *testing.T) {
 
 	// Delete file
 	deleteResponse := helper.CallTool("delete_file", map[string]any{
-		"owner":   helper.GetOwner(),
+		"owner":   helper.GetRepoOwner(),
 		"repo":    repoName,
 		"path":    filePath,
 		"message": "Delete test file",
@@ -366,7 +395,7 @@ func TestReposToolsetCommits(t *testing.T) {
 
 	// List commits
 	listResponse := helper.CallTool("list_commits", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 		"sha":   "main",
 	})
@@ -385,7 +414,7 @@ func TestReposToolsetCommits(t *testing.T) {
 
 	// Get specific commit
 	getResponse := helper.CallTool("get_commit", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 		"sha":   latestCommit.SHA,
 	})
@@ -422,10 +451,10 @@ func TestReposToolsetTags(t *testing.T) {
 
 	// Create a tag using GitHub API (since MCP server may not support tag creation)
 	ghClient := getRESTClient(t)
-	ref, _, err := ghClient.Git.GetRef(t.Context(), helper.GetOwner(), repoName, "refs/heads/main")
+	ref, _, err := ghClient.Git.GetRef(context.Background(), helper.GetRepoOwner(), repoName, "refs/heads/main")
 	require.NoError(t, err, "expected to get main branch ref")
 
-	tagObj, _, err := ghClient.Git.CreateTag(t.Context(), helper.GetOwner(), repoName, &gogithub.Tag{
+	tagObj, _, err := ghClient.Git.CreateTag(context.Background(), helper.GetRepoOwner(), repoName, &gogithub.Tag{
 		Tag:     gogithub.Ptr("v1.0.0"),
 		Message: gogithub.Ptr("Test tag v1.0.0"),
 		Object: &gogithub.GitObject{
@@ -435,7 +464,7 @@ func TestReposToolsetTags(t *testing.T) {
 	})
 	require.NoError(t, err, "expected to create tag object")
 
-	_, _, err = ghClient.Git.CreateRef(t.Context(), helper.GetOwner(), repoName, &gogithub.Reference{
+	_, _, err = ghClient.Git.CreateRef(context.Background(), helper.GetRepoOwner(), repoName, &gogithub.Reference{
 		Ref: gogithub.Ptr("refs/tags/v1.0.0"),
 		Object: &gogithub.GitObject{
 			SHA: tagObj.SHA,
@@ -445,7 +474,7 @@ func TestReposToolsetTags(t *testing.T) {
 
 	// List tags
 	listResponse := helper.CallTool("list_tags", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 	})
 
@@ -471,7 +500,7 @@ func TestReposToolsetTags(t *testing.T) {
 
 	// Get specific tag
 	getResponse := helper.CallTool("get_tag", map[string]any{
-		"owner": helper.GetOwner(),
+		"owner": helper.GetRepoOwner(),
 		"repo":  repoName,
 		"tag":   "v1.0.0",
 	})
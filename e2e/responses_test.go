@@ -0,0 +1,236 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/lfs"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/require"
+)
+
+// maxPagedCalls bounds the pages CallToolPaged fetches, in case a list keeps reporting more pages.
+const maxPagedCalls = 20
+
+var (
+	outputSchemasOnce sync.Once
+	outputSchemas     map[string]json.RawMessage
+)
+
+// GetResponseAs unmarshals the JSON text result of a tool call into a T.
+func GetResponseAs[T any](t testing.TB, response *mcp.CallToolResult) T {
+	var value T
+	err := json.Unmarshal([]byte(getTextContent(t, response)), &value)
+	require.NoError(t, err, "expected to unmarshal response into %T", value)
+	return value
+}
+
+// GetPagedItems unmarshals the JSON array results of the pages of a list, as returned by CallToolPaged, into the
+// items of all the pages.
+func GetPagedItems[T any](t testing.TB, responses []*mcp.CallToolResult) []T {
+	var items []T
+	for _, response := range responses {
+		items = append(items, GetResponseAs[[]T](t, response)...)
+	}
+	return items
+}
+
+// GetPaginationHints returns the pagination hints of a tool call, which the server adds to the metadata of the
+// results of list tools that listed a single page of a REST API list, and whether it has them.
+func GetPaginationHints(t testing.TB, response *mcp.CallToolResult) (github.PaginationHints, bool) {
+	var hints github.PaginationHints
+	meta, ok := response.Meta["pagination"]
+	if !ok {
+		return hints, false
+	}
+	encoded, err := json.Marshal(meta)
+	require.NoError(t, err, "expected to marshal the pagination hints")
+	require.NoError(t, json.Unmarshal(encoded, &hints), "expected to unmarshal the pagination hints")
+	return hints, true
+}
+
+// CallToolPaged calls a list tool page after page, following its pagination hints until there are no more pages,
+// and returns the result of every page. The perPage argument is set when perPage is positive.
+func (h *TestHelper) CallToolPaged(toolName string, args map[string]any, perPage int) []*mcp.CallToolResult {
+	pageArgs := make(map[string]any, len(args)+2)
+	for name, value := range args {
+		pageArgs[name] = value
+	}
+	if perPage > 0 {
+		pageArgs["perPage"] = perPage
+	}
+
+	var responses []*mcp.CallToolResult
+	for page := 1; ; {
+		pageArgs["page"] = page
+		response := h.CallTool(toolName, pageArgs)
+		responses = append(responses, response)
+
+		hints, ok := GetPaginationHints(h.t, response)
+		require.True(h.t, ok, "expected '%s' result to have pagination hints", toolName)
+		if !hints.HasMore {
+			return responses
+		}
+		require.Greater(h.t, hints.NextPage, page, "expected '%s' to have a next page after page %d", toolName, page)
+		require.Less(h.t, len(responses), maxPagedCalls, "expected '%s' to have at most %d pages", toolName, maxPagedCalls)
+		page = hints.NextPage
+	}
+}
+
+// AssertStructuredResponse asserts that the result of a tool call matches the output schema of the tool. The
+// structured content of results is the JSON of their text, wrapped in an object when it isn't one, which is
+// checked instead, as the client doesn't decode structured content.
+func (h *TestHelper) AssertStructuredResponse(toolName string, response *mcp.CallToolResult) {
+	schema, ok := getOutputSchemas()[toolName]
+	require.True(h.t, ok, "expected '%s' to have an output schema", toolName)
+
+	var decodedSchema map[string]any
+	require.NoError(h.t, json.Unmarshal(schema, &decodedSchema), "expected to unmarshal the output schema of '%s'", toolName)
+
+	decoder := json.NewDecoder(strings.NewReader(getTextContent(h.t, response)))
+	decoder.UseNumber()
+	var structured any
+	require.NoError(h.t, decoder.Decode(&structured), "expected '%s' result to be JSON", toolName)
+	if _, ok := structured.(map[string]any); !ok {
+		structured = map[string]any{toolsets.StructuredItemsKey: structured}
+	}
+
+	require.NoError(h.t, validateSchema("", decodedSchema, structured), "expected '%s' result to match its output schema", toolName)
+}
+
+// getOutputSchemas returns the output schemas of all the tools, by name. The client doesn't decode the output
+// schemas the server lists, so they're taken from the toolsets directly.
+func getOutputSchemas() map[string]json.RawMessage {
+	outputSchemasOnce.Do(func() {
+		getClient := func(_ context.Context) (*gogithub.Client, error) { return nil, nil }
+		getGQLClient := func(_ context.Context) (*githubv4.Client, error) { return nil, nil }
+		getRawClient := func(_ context.Context) (*raw.Client, error) { return nil, nil }
+		getLFSClient := func(_ context.Context) (*lfs.Client, error) { return nil, nil }
+		tsg := github.DefaultToolsetGroup(false, getClient, getGQLClient, getRawClient, getLFSClient, getClient, translations.NullTranslationHelper, 5000, github.ServerInfo{})
+
+		outputSchemas = map[string]json.RawMessage{}
+		for _, toolset := range tsg.Toolsets {
+			for _, tool := range toolset.GetAvailableTools() {
+				if tool.Tool.RawOutputSchema != nil {
+					outputSchemas[tool.Tool.Name] = tool.Tool.RawOutputSchema
+				}
+			}
+		}
+	})
+	return outputSchemas
+}
+
+// validateSchema checks a decoded JSON value against the subset of JSON Schema output schemas use: types,
+// including nullable ones, object properties, required properties and array items.
+func validateSchema(path string, schema map[string]any, value any) error {
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		actual := valueType(value)
+		matches := false
+		for _, schemaType := range types {
+			if schemaType == actual || (schemaType == "number" && actual == "integer") {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return fmt.Errorf("%s must be of type %s, got %s", describePath(path), strings.Join(types, " or "), actual)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range schemaTypes(schema["required"]) {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s is missing required property %s", describePath(path), name)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := properties[name].(map[string]any)
+			if _, present := v[name]; !ok || !present {
+				continue
+			}
+			if err := validateSchema(path+"."+name, property, v[name]); err != nil {
+				return err
+			}
+		}
+	case []any:
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		for i, item := range v {
+			if err := validateSchema(fmt.Sprintf("%s[%d]", path, i), items, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaTypes reads a schema keyword holding a string or a list of strings, such as type and required.
+func schemaTypes(keyword any) []string {
+	switch v := keyword.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		strs := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	default:
+		return nil
+	}
+}
+
+// valueType names the JSON Schema type of a value decoded with json.Number numbers.
+func valueType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, ok := new(big.Int).SetString(v.String(), 10); ok {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func describePath(path string) string {
+	if path == "" {
+		return "result"
+	}
+	return "result" + path
+}
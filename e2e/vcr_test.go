@@ -0,0 +1,107 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/transport"
+	"github.com/stretchr/testify/require"
+)
+
+// fixturesDir is where the interactions recorded with GITHUB_MCP_SERVER_E2E_VCR=record are kept, see getFixturesDir.
+const fixturesDir = "testdata/fixtures"
+
+// replayToken is the token used when replaying, the recorded interactions don't need a real one.
+const replayToken = "replay-token"
+
+var (
+	vcrsMu sync.Mutex
+	vcrs   = map[string]*transport.VCRTransport{}
+
+	// withoutGitHubTests are the top level tests that don't call GitHub, see withoutGitHub.
+	withoutGitHubTests = map[string]bool{}
+)
+
+// withoutGitHub marks the top level test as not calling GitHub. It gets no fixture, as a fixture without
+// interactions is rejected, and runs in-process when recording or replaying all the same.
+func withoutGitHub(t *testing.T) {
+	vcrsMu.Lock()
+	defer vcrsMu.Unlock()
+	withoutGitHubTests[strings.SplitN(t.Name(), "/", 2)[0]] = true
+}
+
+// getE2EVCRMode returns whether the tests record their interactions with GitHub or replay them, empty when they
+// run against GitHub without fixtures.
+func getE2EVCRMode() transport.VCRMode {
+	return transport.VCRMode(os.Getenv("GITHUB_MCP_SERVER_E2E_VCR"))
+}
+
+// getVCR returns the transport recording or replaying the interactions of the top level test, or nil when the
+// tests run against GitHub without fixtures or the test doesn't call GitHub. Subtests share the fixture of their top level test, and the fixture
+// is written once the test that first asked for it and its cleanups are done.
+func getVCR(t *testing.T) *transport.VCRTransport {
+	mode := getE2EVCRMode()
+	if mode == "" {
+		return nil
+	}
+
+	name := strings.SplitN(t.Name(), "/", 2)[0]
+	vcrsMu.Lock()
+	defer vcrsMu.Unlock()
+	if withoutGitHubTests[name] {
+		return nil
+	}
+	if vcr, ok := vcrs[name]; ok {
+		return vcr
+	}
+
+	path := filepath.Join(getFixturesDir(), name+".json")
+	if mode == transport.VCRModeReplay {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			t.Skipf("no interactions recorded for %s, record them with GITHUB_MCP_SERVER_E2E_VCR=record", name)
+		}
+	}
+	var secrets []string
+	if mode == transport.VCRModeRecord {
+		secrets = append(secrets, getE2EToken(t))
+	}
+	vcr, err := transport.NewVCRTransport(nil, mode, path, secrets...)
+	require.NoError(t, err, "expected to set up the %s of %s", mode, path)
+	vcrs[name] = vcr
+
+	t.Cleanup(func() {
+		vcrsMu.Lock()
+		delete(vcrs, name)
+		vcrsMu.Unlock()
+
+		require.NoError(t, vcr.Save(), "expected to save the recorded interactions")
+		if unreplayed := vcr.Unreplayed(); len(unreplayed) > 0 {
+			t.Logf("%d recorded interactions were not replayed, %s may need to be recorded again", len(unreplayed), path)
+		}
+	})
+	return vcr
+}
+
+// uniqueName returns a name for test resources that doesn't clash with previous runs. When recording, the name is
+// kept in the fixture so that the replay makes the same requests.
+func uniqueName(t *testing.T, prefix string) string {
+	generate := func() string {
+		return fmt.Sprintf("%s-%d", prefix, time.Now().UnixMilli())
+	}
+
+	vcr := getVCR(t)
+	if vcr == nil {
+		return generate()
+	}
+	name, err := vcr.Value(generate)
+	require.NoError(t, err, "expected the fixture to have a recorded name for %s", prefix)
+	return name
+}
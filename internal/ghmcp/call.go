@@ -0,0 +1,238 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type CallConfig struct {
+	// Server holds the settings of the server the tool is called through, as for the stdio server. Its logging,
+	// webhook, update check and reloading settings don't apply.
+	Server StdioServerConfig
+
+	// Tool is the name of the tool to call
+	Tool string
+
+	// Arguments of the tool call
+	Arguments map[string]any
+
+	// Timeout bounds the tool call, zero means no limit
+	Timeout time.Duration
+
+	// JSON prints the whole result of the call as JSON, including its structured content and metadata, rather than
+	// its content alone
+	JSON bool
+}
+
+// cliSession is the session of the commands handing messages to the server themselves, such as call and replay,
+// which writes the progress notifications and log messages of their requests to the error output.
+type cliSession struct {
+	notifications      chan mcp.JSONRPCNotification
+	initialized        atomic.Bool
+	clientInfo         atomic.Value
+	clientCapabilities atomic.Value
+	logLevel           atomic.Value
+}
+
+func (s *cliSession) SessionID() string { return "github-mcp-server-cli" }
+
+func (s *cliSession) Initialize() { s.initialized.Store(true) }
+
+func (s *cliSession) Initialized() bool { return s.initialized.Load() }
+
+func (s *cliSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+
+func (s *cliSession) GetClientInfo() mcp.Implementation {
+	info, _ := s.clientInfo.Load().(mcp.Implementation)
+	return info
+}
+
+func (s *cliSession) SetClientInfo(clientInfo mcp.Implementation) { s.clientInfo.Store(clientInfo) }
+
+func (s *cliSession) GetClientCapabilities() mcp.ClientCapabilities {
+	capabilities, _ := s.clientCapabilities.Load().(mcp.ClientCapabilities)
+	return capabilities
+}
+
+func (s *cliSession) SetClientCapabilities(clientCapabilities mcp.ClientCapabilities) {
+	s.clientCapabilities.Store(clientCapabilities)
+}
+
+func (s *cliSession) SetLogLevel(level mcp.LoggingLevel) { s.logLevel.Store(level) }
+
+// GetLogLevel returns the level set with logging/setLevel, such as by a replayed client, error by default as for
+// the stdio server.
+func (s *cliSession) GetLogLevel() mcp.LoggingLevel {
+	if level, ok := s.logLevel.Load().(mcp.LoggingLevel); ok {
+		return level
+	}
+	return mcp.LoggingLevelError
+}
+
+// startCLISession registers a session with the server, returning the context to hand it messages with, which
+// enables GitHub errors like the stdio server does, and the function closing the session.
+func startCLISession(ctx context.Context, ghServer *server.MCPServer, errOut io.Writer) (context.Context, func(), error) {
+	session := &cliSession{notifications: make(chan mcp.JSONRPCNotification, 100)}
+	if err := ghServer.RegisterSession(ctx, session); err != nil {
+		return nil, nil, fmt.Errorf("failed to register session: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for notification := range session.notifications {
+			params := notification.Params.AdditionalFields
+			switch notification.Method {
+			case "notifications/progress":
+				if message, ok := params["message"].(string); ok && message != "" {
+					_, _ = fmt.Fprintf(errOut, "progress: %s\n", message)
+				}
+			case "notifications/message":
+				data, _ := params["data"].(map[string]any)
+				if message, ok := data["message"].(string); ok && message != "" {
+					_, _ = fmt.Fprintf(errOut, "%v: %s\n", params["level"], message)
+				}
+			}
+		}
+	}()
+	closeSession := func() {
+		ghServer.UnregisterSession(context.Background(), session.SessionID())
+		close(session.notifications)
+		wg.Wait()
+	}
+	return errors.ContextWithGitHubErrors(ghServer.WithContext(ctx, session)), closeSession, nil
+}
+
+// RunCall calls a single tool through a server with the configuration, going through the same authentication,
+// middlewares and transports as calls from clients, and writes its result to out. Progress notifications are
+// written to errOut as the call runs. It returns an error when the call fails or the tool returns an error result.
+func RunCall(ctx context.Context, cfg CallConfig, out, errOut io.Writer) error {
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	t, _ := translations.TranslationHelper()
+	ghServer, _, _, err := newStdioMCPServer(cfg.Server, t, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	ctx, closeSession, err := startCLISession(ctx, ghServer, errOut)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+
+	if _, err := sendRequest(ctx, ghServer, 1, string(mcp.MethodInitialize), map[string]any{
+		"protocolVersion": mcp.LATEST_PROTOCOL_VERSION,
+		"capabilities":    map[string]any{},
+		"clientInfo":      mcp.Implementation{Name: "github-mcp-server-call", Version: cfg.Server.Version},
+	}); err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	arguments := cfg.Arguments
+	if arguments == nil {
+		arguments = map[string]any{}
+	}
+	response, err := sendRequest(ctx, ghServer, 2, string(mcp.MethodToolsCall), map[string]any{
+		"name":      cfg.Tool,
+		"arguments": arguments,
+		"_meta":     map[string]any{"progressToken": "call"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", cfg.Tool, err)
+	}
+	result, ok := response.(mcp.CallToolResult)
+	if !ok {
+		return fmt.Errorf("failed to call %s, unexpected result %T", cfg.Tool, response)
+	}
+
+	if cfg.JSON {
+		err = writeCallResultJSON(out, result)
+	} else {
+		err = writeCallResultContent(out, result)
+	}
+	if err != nil {
+		return err
+	}
+	if result.IsError {
+		return fmt.Errorf("%s returned an error", cfg.Tool)
+	}
+	return nil
+}
+
+// sendRequest hands a JSON-RPC request to the server and returns its result, or its error.
+func sendRequest(ctx context.Context, ghServer *server.MCPServer, id int, method string, params any) (any, error) {
+	message, err := json.Marshal(map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      id,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch response := ghServer.HandleMessage(ctx, message).(type) {
+	case mcp.JSONRPCResponse:
+		return response.Result, nil
+	case mcp.JSONRPCError:
+		return nil, fmt.Errorf("%s (code %d)", response.Error.Message, response.Error.Code)
+	default:
+		return nil, fmt.Errorf("unexpected response %T", response)
+	}
+}
+
+// writeCallResultJSON writes the whole result, which CallToolResult doesn't marshal its structured content of.
+func writeCallResultJSON(out io.Writer, result mcp.CallToolResult) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Meta              map[string]any `json:"_meta,omitempty"`
+		Content           []mcp.Content  `json:"content"`
+		StructuredContent any            `json:"structuredContent,omitempty"`
+		IsError           bool           `json:"isError,omitempty"`
+	}{result.Meta, result.Content, result.StructuredContent, result.IsError})
+}
+
+// writeCallResultContent writes the text of the result, and a summary of the contents that aren't text.
+func writeCallResultContent(out io.Writer, result mcp.CallToolResult) error {
+	for _, content := range result.Content {
+		var err error
+		switch content := content.(type) {
+		case mcp.TextContent:
+			_, err = fmt.Fprintln(out, content.Text)
+		case mcp.ImageContent:
+			_, err = fmt.Fprintf(out, "[image %s, %d bytes of base64]\n", content.MIMEType, len(content.Data))
+		case mcp.AudioContent:
+			_, err = fmt.Fprintf(out, "[audio %s, %d bytes of base64]\n", content.MIMEType, len(content.Data))
+		case mcp.EmbeddedResource:
+			if text, ok := content.Resource.(mcp.TextResourceContents); ok {
+				_, err = fmt.Fprintf(out, "[resource %s]\n%s\n", text.URI, text.Text)
+			} else {
+				_, err = fmt.Fprintln(out, "[binary resource]")
+			}
+		case mcp.ResourceLink:
+			_, err = fmt.Fprintf(out, "[resource link %s]\n", content.URI)
+		default:
+			_, err = fmt.Fprintf(out, "[%T content]\n", content)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
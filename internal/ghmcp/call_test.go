@@ -0,0 +1,174 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RunCall(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetUser,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode(gogithub.User{Login: gogithub.Ptr("octocat"), HTMLURL: gogithub.Ptr("https://github.com/octocat")})
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			}),
+		),
+	)
+
+	tests := []struct {
+		name        string
+		cfg         CallConfig
+		expectedOut []string
+		expectedErr string
+	}{
+		{
+			name:        "text result",
+			cfg:         CallConfig{Tool: "get_me"},
+			expectedOut: []string{`"login":"octocat"`},
+		},
+		{
+			name:        "JSON result",
+			cfg:         CallConfig{Tool: "get_me", JSON: true},
+			expectedOut: []string{`"content": [`, `"structuredContent": {`, `"login": "octocat"`},
+		},
+		{
+			name:        "tool returns an error",
+			cfg:         CallConfig{Tool: "get_issue", Arguments: map[string]any{"owner": "owner", "issue_number": 1}},
+			expectedOut: []string{"repo"},
+			expectedErr: "get_issue returned an error",
+		},
+		{
+			name:        "call fails",
+			cfg:         CallConfig{Tool: "get_issue", Arguments: map[string]any{"owner": "owner", "repo": "repo", "issue_number": 1}},
+			expectedErr: "failed to call get_issue: failed to get issue",
+		},
+		{
+			name:        "tool isn't enabled",
+			cfg:         CallConfig{Tool: "list_workflows"},
+			expectedErr: "failed to call list_workflows: tool 'list_workflows' not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.Server = StdioServerConfig{
+				Version:         "test",
+				Token:           "ghp_test",
+				EnabledToolsets: []string{"context", "issues"},
+				Transport:       mockedClient.Transport,
+			}
+
+			var out, errOut bytes.Buffer
+			err := RunCall(context.Background(), cfg, &out, &errOut)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, expected := range tc.expectedOut {
+				assert.Contains(t, out.String(), expected)
+			}
+		})
+	}
+}
+
+func Test_startCLISession(t *testing.T) {
+	ghServer := server.NewMCPServer("test", "1.0.0")
+
+	var errOut bytes.Buffer
+	ctx, closeSession, err := startCLISession(context.Background(), ghServer, &errOut)
+	require.NoError(t, err)
+	_, err = sendRequest(ctx, ghServer, 1, string(mcp.MethodInitialize), map[string]any{"protocolVersion": mcp.LATEST_PROTOCOL_VERSION})
+	require.NoError(t, err)
+
+	session := server.ClientSessionFromContext(ctx)
+	require.NotNil(t, session)
+	assert.Equal(t, "github-mcp-server-cli", session.SessionID())
+	assert.Equal(t, mcp.LoggingLevelError, session.(*cliSession).GetLogLevel())
+	session.(*cliSession).SetLogLevel(mcp.LoggingLevelDebug)
+	assert.Equal(t, mcp.LoggingLevelDebug, session.(*cliSession).GetLogLevel())
+
+	require.NoError(t, ghServer.SendNotificationToClient(ctx, "notifications/progress", map[string]any{"progress": 1, "message": "halfway there"}))
+	require.NoError(t, ghServer.SendNotificationToClient(ctx, "notifications/progress", map[string]any{"progress": 2}))
+	require.NoError(t, ghServer.SendNotificationToClient(ctx, "notifications/message", map[string]any{
+		"level": "warning",
+		"data":  map[string]any{"message": "rate limited"},
+	}))
+	require.NoError(t, ghServer.SendNotificationToClient(ctx, "notifications/tools/list_changed", nil))
+	closeSession()
+
+	// Only the notifications with a message are written, once the session is closed
+	assert.Equal(t, "progress: halfway there\nwarning: rate limited\n", errOut.String())
+}
+
+func Test_sendRequest(t *testing.T) {
+	ghServer := server.NewMCPServer("test", "1.0.0")
+
+	result, err := sendRequest(context.Background(), ghServer, 1, string(mcp.MethodInitialize), map[string]any{
+		"protocolVersion": mcp.LATEST_PROTOCOL_VERSION,
+		"clientInfo":      mcp.Implementation{Name: "test", Version: "1.0.0"},
+	})
+	require.NoError(t, err)
+	initialized, ok := result.(mcp.InitializeResult)
+	require.True(t, ok, "unexpected result %T", result)
+	assert.Equal(t, "test", initialized.ServerInfo.Name)
+
+	_, err = sendRequest(context.Background(), ghServer, 2, "does/not/exist", nil)
+	assert.ErrorContains(t, err, "(code -32601)")
+}
+
+func Test_writeCallResultContent(t *testing.T) {
+	result := mcp.CallToolResult{Content: []mcp.Content{
+		mcp.NewTextContent("hello"),
+		mcp.NewImageContent("aGVsbG8=", "image/png"),
+		mcp.NewAudioContent("aGVsbG8h", "audio/wav"),
+		mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "repo://owner/repo/contents/README.md", Text: "# Readme"}),
+		mcp.NewEmbeddedResource(mcp.BlobResourceContents{URI: "repo://owner/repo/contents/logo.png", Blob: "aGVsbG8="}),
+		mcp.NewResourceLink("repo://owner/repo/contents/src", "src", "", "application/json"),
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, writeCallResultContent(&out, result))
+	assert.Equal(t, "hello\n"+
+		"[image image/png, 8 bytes of base64]\n"+
+		"[audio audio/wav, 8 bytes of base64]\n"+
+		"[resource repo://owner/repo/contents/README.md]\n# Readme\n"+
+		"[binary resource]\n"+
+		"[resource link repo://owner/repo/contents/src]\n", out.String())
+}
+
+func Test_writeCallResultJSON(t *testing.T) {
+	result := mcp.CallToolResult{
+		Content:           []mcp.Content{mcp.NewTextContent(`{"login":"octocat"}`)},
+		StructuredContent: map[string]any{"login": "octocat"},
+		IsError:           true,
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeCallResultJSON(&out, result))
+	var written map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &written))
+	assert.Equal(t, map[string]any{
+		"content":           []any{map[string]any{"type": "text", "text": `{"login":"octocat"}`}},
+		"structuredContent": map[string]any{"login": "octocat"},
+		"isError":           true,
+	}, written)
+}
@@ -0,0 +1,265 @@
+package ghmcp
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	gogithub "github.com/google/go-github/v74/github"
+)
+
+type ConfigValidationConfig struct {
+	// Server holds the configuration to validate, as the stdio server would run with it
+	Server StdioServerConfig
+
+	// ConfigFile is the configuration file the settings were read from, empty when there is none
+	ConfigFile string
+
+	// Timeout bounds the checks against GitHub
+	Timeout time.Duration
+}
+
+// RunConfigValidation prints the effective configuration, with secrets masked, and checks that the server can run
+// with it: that its settings are valid, that its toolsets exist, that the host is reachable and that the token is
+// valid and has the scopes the enabled tools need. It returns an error when any check fails.
+func RunConfigValidation(ctx context.Context, cfg ConfigValidationConfig, out io.Writer) error {
+	if err := writeEffectiveConfig(out, cfg); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out)
+
+	var problems int
+	fail := func(format string, args ...any) {
+		problems++
+		_, _ = fmt.Fprintf(out, "error: "+format+"\n", args...)
+	}
+
+	if cfg.Server.LogLevel != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(cfg.Server.LogLevel)); err != nil {
+			fail("log level %q is invalid, use debug, info, warn or error", cfg.Server.LogLevel)
+		}
+	}
+
+	ghServer, tsg, installationTokens, err := newStdioMCPServer(cfg.Server, translations.NullTranslationHelper, nil, nil, nil)
+	if err != nil {
+		var notExist *toolsets.ToolsetDoesNotExistError
+		if stderrors.As(err, &notExist) {
+			fail("%v, the available toolsets are all, %s", err, strings.Join(availableToolsetIDs(), ", "))
+		} else {
+			fail("the server can't be created: %v", err)
+		}
+		return fmt.Errorf("configuration has %d problem(s)", problems)
+	}
+	_, _ = fmt.Fprintf(out, "ok: %d tools enabled from toolsets %s\n", len(tsg.ActiveTools()), strings.Join(cfg.Server.EnabledToolsets, ", "))
+	for _, tool := range cfg.Server.ConfirmTools {
+		if _, ok := tsg.GetActiveTool(tool); !ok && !cfg.Server.DynamicToolsets {
+			_, _ = fmt.Fprintf(out, "warning: tool %s is listed to confirm but isn't enabled\n", tool)
+		}
+	}
+
+	if cfg.Server.WebhookListenAddr != "" {
+		if _, _, err := newWebhookServer(ghServer, cfg.Server); err != nil {
+			fail("webhooks can't be received: %v, set --webhook-secret or GITHUB_WEBHOOK_SECRET", err)
+		} else {
+			_, _ = fmt.Fprintf(out, "ok: webhooks will be received on %s\n", cfg.Server.WebhookListenAddr)
+		}
+	}
+
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+	apiHost, err := parseAPIHost(cfg.Server.Host)
+	if err != nil {
+		fail("host %q is invalid: %v", cfg.Server.Host, err)
+		return fmt.Errorf("configuration has %d problem(s)", problems)
+	}
+
+	authMode := github.TokenAuthMode(cfg.Server.Token)
+	token := cfg.Server.Token
+	if installationTokens != nil {
+		authMode = github.AuthModeGitHubAppInstallation
+		if token, err = installationTokens.Token(ctx, cfg.Server.AppInstallationID); err != nil {
+			fail("GitHub App %d can't authenticate as installation %d: %v, check GITHUB_APP_ID, GITHUB_APP_INSTALLATION_ID and the private key",
+				cfg.Server.AppID, cfg.Server.AppInstallationID, err)
+			return fmt.Errorf("configuration has %d problem(s)", problems)
+		}
+		_, _ = fmt.Fprintf(out, "ok: GitHub App %d authenticated as installation %d\n", cfg.Server.AppID, cfg.Server.AppInstallationID)
+	}
+
+	client := gogithub.NewClient(&http.Client{Transport: cfg.Server.Transport}).WithAuthToken(token)
+	client.UserAgent = fmt.Sprintf("github-mcp-server/%s (config validate)", cfg.Server.Version)
+	client.BaseURL = apiHost.baseRESTURL
+	validation, resp, err := github.ValidateToken(ctx, client, tsg, authMode)
+	switch {
+	case err != nil && resp != nil && resp.StatusCode == http.StatusUnauthorized:
+		fail("token rejected by %s, it is invalid or has expired, create a new one and set GITHUB_PERSONAL_ACCESS_TOKEN", apiHost.baseRESTURL)
+	case err != nil:
+		fail("failed to reach %s: %v, check --gh-host and the network", apiHost.baseRESTURL, err)
+	default:
+		_, _ = fmt.Fprintf(out, "ok: reached %s\n", apiHost.baseRESTURL)
+		_, _ = fmt.Fprintf(out, "ok: token is valid (%s)\n", authMode)
+		if !validation.ScopesListed {
+			_, _ = fmt.Fprintln(out, "ok: token has permissions rather than scopes, scopes not checked")
+			break
+		}
+		// Tools missing the same scopes are reported together, as adding the scopes fixes them all
+		var order []string
+		byScopes := make(map[string][]string)
+		for _, issue := range validation.UnavailableTools {
+			scopes := strings.Join(issue.MissingScopes, ", ")
+			if _, ok := byScopes[scopes]; !ok {
+				order = append(order, scopes)
+			}
+			byScopes[scopes] = append(byScopes[scopes], issue.Tool)
+		}
+		for _, scopes := range order {
+			fail("the token is missing the scopes %s, which %d tools need (%s), add the scopes to the token or disable their toolsets",
+				scopes, len(byScopes[scopes]), strings.Join(byScopes[scopes], ", "))
+		}
+		for _, issue := range validation.LimitedTools {
+			_, _ = fmt.Fprintf(out, "warning: tool %s needs the scopes %s for %s\n", issue.Tool, strings.Join(issue.MissingScopes, ", "), issue.Impact)
+		}
+		if len(validation.UnavailableTools) == 0 {
+			_, _ = fmt.Fprintf(out, "ok: token has the scopes the enabled tools need (%s)\n", formatScopes(validation.Scopes))
+		}
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("configuration has %d problem(s)", problems)
+	}
+	return nil
+}
+
+// writeEffectiveConfig writes the settings the server would run with, masking its secrets.
+func writeEffectiveConfig(out io.Writer, cfg ConfigValidationConfig) error {
+	s := cfg.Server
+	configFile := cfg.ConfigFile
+	if configFile == "" {
+		configFile = "none"
+	}
+	host := s.Host
+	if host == "" {
+		host = "github.com"
+	}
+
+	settings := [][2]string{
+		{"config file", configFile},
+		{"host", host},
+	}
+	if s.AppID != 0 {
+		settings = append(settings,
+			[2]string{"app id", strconv.FormatInt(s.AppID, 10)},
+			[2]string{"app installation id", strconv.FormatInt(s.AppInstallationID, 10)},
+			[2]string{"app private key", fmt.Sprintf("set (%d bytes)", len(s.AppPrivateKey))})
+	} else {
+		settings = append(settings, [2]string{"token", maskSecret(s.Token)})
+	}
+	settings = append(settings,
+		[2]string{"toolsets", strings.Join(s.EnabledToolsets, ", ")},
+		[2]string{"dynamic toolsets", strconv.FormatBool(s.DynamicToolsets)},
+		[2]string{"read-only", strconv.FormatBool(s.ReadOnly)},
+		[2]string{"content window size", strconv.Itoa(s.ContentWindowSize)},
+		[2]string{"rate limit max wait", s.RateLimitMaxWait.String()},
+		[2]string{"retry max attempts", strconv.Itoa(s.RetryMaxAttempts)},
+		[2]string{"tool retry attempts", formatToolRetryAttempts(s.ToolRetryAttempts)},
+		[2]string{"circuit breaker", formatCircuitBreaker(s.CircuitBreakerThreshold, s.CircuitBreakerCooldown)},
+		[2]string{"offline cache", formatOfflineCache(s.OfflineCacheSize, s.OfflineCacheMaxAge)},
+		[2]string{"max concurrent requests", fmt.Sprintf("%d, %d per host, %s queue timeout", s.MaxConcurrentRequests, s.MaxConcurrentRequestsPerHost, s.RequestQueueTimeout)},
+		[2]string{"summarize threshold", strconv.Itoa(s.SummarizeThreshold)},
+		[2]string{"privacy mode", strconv.FormatBool(s.PrivacyMode)},
+		[2]string{"minimal output", strconv.FormatBool(s.MinimalOutput)},
+		[2]string{"disable resources", strconv.FormatBool(s.DisableResources)},
+		[2]string{"restrict to roots", strconv.FormatBool(s.RestrictToRoots)},
+		[2]string{"confirm tools", orNone(strings.Join(s.ConfirmTools, ", "))},
+		[2]string{"allow repository deletion", orNone(strings.Join(s.AllowRepositoryDeletion, ", "))},
+		[2]string{"check for updates", strconv.FormatBool(s.CheckForUpdates)},
+		[2]string{"log file", orNone(s.LogFilePath)},
+		[2]string{"log level", orNone(s.LogLevel)},
+	)
+	if s.WebhookListenAddr != "" {
+		settings = append(settings,
+			[2]string{"webhook listen addr", s.WebhookListenAddr},
+			[2]string{"webhook secret", maskSecret(s.WebhookSecret)},
+			[2]string{"webhook repos", orNone(strings.Join(s.WebhookRepos, ", "))},
+			[2]string{"webhook events", orNone(strings.Join(s.WebhookEvents, ", "))})
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "Effective configuration:")
+	for _, setting := range settings {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\n", setting[0], setting[1])
+	}
+	return w.Flush()
+}
+
+// maskSecret keeps the prefix and the last characters of a secret, enough to tell which one is set.
+func maskSecret(secret string) string {
+	switch {
+	case secret == "":
+		return "not set"
+	case len(secret) <= 12:
+		return "****"
+	default:
+		prefix := ""
+		if i := strings.IndexByte(secret, '_'); i > 0 && i < 12 {
+			prefix = secret[:i+1]
+		}
+		return prefix + "****" + secret[len(secret)-4:]
+	}
+}
+
+func formatToolRetryAttempts(attempts map[string]int) string {
+	if len(attempts) == 0 {
+		return "none"
+	}
+	overrides := make([]string, 0, len(attempts))
+	for tool, n := range attempts {
+		overrides = append(overrides, fmt.Sprintf("%s=%d", tool, n))
+	}
+	sort.Strings(overrides)
+	return strings.Join(overrides, ", ")
+}
+
+func formatCircuitBreaker(threshold int, cooldown time.Duration) string {
+	if threshold <= 0 {
+		return "disabled"
+	}
+	return fmt.Sprintf("after %d failures, %s cooldown", threshold, cooldown)
+}
+
+func formatOfflineCache(size int, maxAge time.Duration) string {
+	if maxAge <= 0 {
+		return "disabled"
+	}
+	return fmt.Sprintf("%d responses up to %s old", size, maxAge)
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+func availableToolsetIDs() []string {
+	available := github.AvailableTools()
+	ids := make([]string, 0, len(available))
+	for _, toolset := range available {
+		ids = append(ids, toolset.ID)
+	}
+	return ids
+}
@@ -0,0 +1,143 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	gogithub "github.com/google/go-github/v74/github"
+)
+
+const (
+	// E2ERepoPrefix prefixes the names of the repositories created by the e2e tests.
+	E2ERepoPrefix = "github-mcp-server-e2e-"
+
+	// E2EPoolRepoPrefix prefixes the names of the pooled repositories of the e2e tests, which are kept between runs.
+	E2EPoolRepoPrefix = E2ERepoPrefix + "pool-"
+)
+
+type E2ECleanupConfig struct {
+	// Version of the server
+	Version string
+
+	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
+	Host string
+
+	// GitHub Token to authenticate with the GitHub API
+	Token string
+
+	// Owner is the user or organization whose repositories are swept, the owner of the token when empty
+	Owner string
+
+	// MaxAge is the age from which leftover repositories are deleted, younger ones may still be in use by a run
+	MaxAge time.Duration
+
+	// DryRun lists the repositories that would be deleted without deleting them
+	DryRun bool
+
+	// Transport, when set, sends the requests to GitHub in place of the default transport, e.g. in tests
+	Transport http.RoundTripper
+}
+
+// RunE2ECleanup deletes the repositories left over by e2e tests whose cleanup failed, those named with E2ERepoPrefix
+// and older than the max age, writing every repository it deletes to out. Pooled repositories are never deleted.
+func RunE2ECleanup(ctx context.Context, cfg E2ECleanupConfig, out io.Writer) error {
+	apiHost, err := parseAPIHost(cfg.Host)
+	if err != nil {
+		return fmt.Errorf("failed to parse API host: %w", err)
+	}
+
+	client := gogithub.NewClient(&http.Client{Transport: cfg.Transport}).WithAuthToken(cfg.Token)
+	client.UserAgent = fmt.Sprintf("github-mcp-server/%s (e2e-cleanup)", cfg.Version)
+	client.BaseURL = apiHost.baseRESTURL
+
+	repos, err := listOwnedRepositories(ctx, client, cfg.Owner)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-cfg.MaxAge)
+	var swept, failed int
+	for _, repo := range repos {
+		name := repo.GetName()
+		if !strings.HasPrefix(name, E2ERepoPrefix) || strings.HasPrefix(name, E2EPoolRepoPrefix) {
+			continue
+		}
+		if repo.GetCreatedAt().After(cutoff) {
+			continue
+		}
+
+		created := repo.GetCreatedAt().UTC().Format(time.RFC3339)
+		if cfg.DryRun {
+			_, _ = fmt.Fprintf(out, "would delete %s (created %s)\n", repo.GetFullName(), created)
+			swept++
+			continue
+		}
+		if _, err := client.Repositories.Delete(ctx, repo.GetOwner().GetLogin(), name); err != nil {
+			_, _ = fmt.Fprintf(out, "failed to delete %s: %v\n", repo.GetFullName(), err)
+			failed++
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "deleted %s (created %s)\n", repo.GetFullName(), created)
+		swept++
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d leftover repositories", failed, failed+swept)
+	}
+	if cfg.DryRun {
+		_, _ = fmt.Fprintf(out, "%d leftover repositories older than %s would be deleted\n", swept, cfg.MaxAge)
+	} else {
+		_, _ = fmt.Fprintf(out, "deleted %d leftover repositories older than %s\n", swept, cfg.MaxAge)
+	}
+	return nil
+}
+
+// listOwnedRepositories lists all the repositories of the owner, the owner of the token when empty.
+func listOwnedRepositories(ctx context.Context, client *gogithub.Client, owner string) ([]*gogithub.Repository, error) {
+	isOrg := false
+	if owner != "" {
+		user, _, err := client.Users.Get(ctx, owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get owner %s: %w", owner, err)
+		}
+		isOrg = user.GetType() == "Organization"
+
+		// The private repositories of the token owner are only listed for the authenticated user
+		if !isOrg {
+			me, _, err := client.Users.Get(ctx, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the authenticated user: %w", err)
+			}
+			if strings.EqualFold(me.GetLogin(), owner) {
+				owner = ""
+			}
+		}
+	}
+
+	var all []*gogithub.Repository
+	for page := 1; page != 0; {
+		var repos []*gogithub.Repository
+		var resp *gogithub.Response
+		var err error
+		listOptions := gogithub.ListOptions{PerPage: 100, Page: page}
+		switch {
+		case isOrg:
+			repos, resp, err = client.Repositories.ListByOrg(ctx, owner, &gogithub.RepositoryListByOrgOptions{ListOptions: listOptions})
+		case owner != "":
+			// Other users' private repositories aren't listed, they can't be deleted with this token anyway
+			repos, resp, err = client.Repositories.ListByUser(ctx, owner, &gogithub.RepositoryListByUserOptions{Type: "owner", ListOptions: listOptions})
+		default:
+			repos, resp, err = client.Repositories.ListByAuthenticatedUser(ctx, &gogithub.RepositoryListByAuthenticatedUserOptions{Affiliation: "owner", ListOptions: listOptions})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+		all = append(all, repos...)
+		page = resp.NextPage
+	}
+	return all, nil
+}
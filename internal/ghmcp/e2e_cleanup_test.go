@@ -0,0 +1,207 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RunE2ECleanup(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	repos := []*gogithub.Repository{
+		newE2ERepo("github-mcp-server-e2e-old", old),
+		newE2ERepo("github-mcp-server-e2e-recent", recent),
+		newE2ERepo("github-mcp-server-e2e-pool-issues", old),
+		newE2ERepo("hello-world", old),
+		newE2ERepo("github-mcp-server-e2e-undeletable", old),
+	}
+
+	tests := []struct {
+		name            string
+		dryRun          bool
+		expectedDeleted []string
+		expectedOut     []string
+		expectedErr     string
+	}{
+		{
+			name:            "leftover repositories are deleted",
+			expectedDeleted: []string{"github-mcp-server-e2e-old", "github-mcp-server-e2e-undeletable"},
+			expectedOut: []string{
+				"deleted octocat/github-mcp-server-e2e-old (created ",
+				"failed to delete octocat/github-mcp-server-e2e-undeletable: ",
+			},
+			expectedErr: "failed to delete 1 of 2 leftover repositories",
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			expectedOut: []string{
+				"would delete octocat/github-mcp-server-e2e-old (created ",
+				"would delete octocat/github-mcp-server-e2e-undeletable (created ",
+				"2 leftover repositories older than 24h0m0s would be deleted\n",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var deleted []string
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserRepos,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						_ = json.NewEncoder(w).Encode(repos)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						mu.Lock()
+						deleted = append(deleted, r.URL.Path)
+						mu.Unlock()
+						if r.URL.Path == "/repos/octocat/github-mcp-server-e2e-undeletable" {
+							w.WriteHeader(http.StatusForbidden)
+							_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+							return
+						}
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			)
+
+			var out bytes.Buffer
+			err := RunE2ECleanup(context.Background(), E2ECleanupConfig{
+				Version:   "test",
+				Token:     "ghp_test",
+				MaxAge:    24 * time.Hour,
+				DryRun:    tc.dryRun,
+				Transport: mockedClient.Transport,
+			}, &out)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, expected := range tc.expectedOut {
+				assert.Contains(t, out.String(), expected)
+			}
+
+			expectedPaths := make([]string, 0, len(tc.expectedDeleted))
+			for _, name := range tc.expectedDeleted {
+				expectedPaths = append(expectedPaths, "/repos/octocat/"+name)
+			}
+			assert.ElementsMatch(t, expectedPaths, deleted)
+		})
+	}
+}
+
+func Test_listOwnedRepositories(t *testing.T) {
+	// listRepos serves two pages of repositories, checking the query of the list
+	listRepos := func(t *testing.T, query map[string]string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			for key, value := range query {
+				assert.Equal(t, value, r.URL.Query().Get(key), key)
+			}
+			page := r.URL.Query().Get("page")
+			if page == "1" {
+				w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+			}
+			_ = json.NewEncoder(w).Encode([]*gogithub.Repository{newE2ERepo("repo-"+page, time.Now())})
+		}
+	}
+	getUser := func(login, userType string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(gogithub.User{Login: gogithub.Ptr(login), Type: gogithub.Ptr(userType)})
+		}
+	}
+
+	tests := []struct {
+		name        string
+		owner       string
+		options     []mock.MockBackendOption
+		expectedErr string
+	}{
+		{
+			name: "repositories of the token owner",
+			options: []mock.MockBackendOption{
+				mock.WithRequestMatchHandler(mock.GetUserRepos, listRepos(t, map[string]string{"affiliation": "owner", "per_page": "100"})),
+			},
+		},
+		{
+			name:  "token owner given by login",
+			owner: "OctoCat",
+			options: []mock.MockBackendOption{
+				mock.WithRequestMatchHandler(mock.GetUsersByUsername, getUser("octocat", "User")),
+				mock.WithRequestMatchHandler(mock.GetUser, getUser("octocat", "User")),
+				mock.WithRequestMatchHandler(mock.GetUserRepos, listRepos(t, map[string]string{"affiliation": "owner"})),
+			},
+		},
+		{
+			name:  "repositories of another user",
+			owner: "hubot",
+			options: []mock.MockBackendOption{
+				mock.WithRequestMatchHandler(mock.GetUsersByUsername, getUser("hubot", "User")),
+				mock.WithRequestMatchHandler(mock.GetUser, getUser("octocat", "User")),
+				mock.WithRequestMatchHandler(mock.GetUsersReposByUsername, listRepos(t, map[string]string{"type": "owner"})),
+			},
+		},
+		{
+			name:  "repositories of an organization",
+			owner: "github",
+			options: []mock.MockBackendOption{
+				mock.WithRequestMatchHandler(mock.GetUsersByUsername, getUser("github", "Organization")),
+				mock.WithRequestMatchHandler(mock.GetOrgsReposByOrg, listRepos(t, map[string]string{"per_page": "100"})),
+			},
+		},
+		{
+			name:  "unknown owner",
+			owner: "nobody",
+			options: []mock.MockBackendOption{
+				mock.WithRequestMatchHandler(mock.GetUsersByUsername, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				})),
+			},
+			expectedErr: "failed to get owner nobody",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := gogithub.NewClient(mock.NewMockedHTTPClient(tc.options...))
+
+			repos, err := listOwnedRepositories(context.Background(), client, tc.owner)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(repos))
+			for _, repo := range repos {
+				names = append(names, repo.GetName())
+			}
+			assert.Equal(t, []string{"repo-1", "repo-2"}, names)
+		})
+	}
+}
+
+func newE2ERepo(name string, created time.Time) *gogithub.Repository {
+	return &gogithub.Repository{
+		Name:      gogithub.Ptr(name),
+		FullName:  gogithub.Ptr("octocat/" + name),
+		Owner:     &gogithub.User{Login: gogithub.Ptr("octocat")},
+		CreatedAt: &gogithub.Timestamp{Time: created},
+	}
+}
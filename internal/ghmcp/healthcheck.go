@@ -0,0 +1,85 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	gogithub "github.com/google/go-github/v74/github"
+)
+
+type HealthcheckConfig struct {
+	// Version of the server
+	Version string
+
+	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
+	Host string
+
+	// GitHub Token to authenticate with the GitHub API
+	Token string
+
+	// RequiredScopes are the scopes a classic personal access token must have
+	RequiredScopes []string
+
+	// Timeout bounds the whole healthcheck
+	Timeout time.Duration
+
+	// Transport, when set, sends the requests to GitHub in place of the default transport, e.g. in tests
+	Transport http.RoundTripper
+}
+
+// RunHealthcheck checks that the host is reachable, that the token is valid, and that classic personal access
+// tokens have the required scopes, writing the outcome of every check to out. It returns an error when any fails.
+func RunHealthcheck(ctx context.Context, cfg HealthcheckConfig, out io.Writer) error {
+	apiHost, err := parseAPIHost(cfg.Host)
+	if err != nil {
+		return fmt.Errorf("failed to parse API host: %w", err)
+	}
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	client := gogithub.NewClient(&http.Client{Transport: cfg.Transport}).WithAuthToken(cfg.Token)
+	client.UserAgent = fmt.Sprintf("github-mcp-server/%s (healthcheck)", cfg.Version)
+	client.BaseURL = apiHost.baseRESTURL
+
+	limits, resp, err := client.RateLimit.Get(ctx)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return fmt.Errorf("token rejected by %s, it is invalid or has expired", apiHost.baseRESTURL)
+		}
+		return fmt.Errorf("failed to reach %s: %w", apiHost.baseRESTURL, err)
+	}
+	_ = resp.Body.Close()
+	_, _ = fmt.Fprintf(out, "ok: reached %s\n", apiHost.baseRESTURL)
+	_, _ = fmt.Fprintf(out, "ok: token is valid (%s)\n", github.TokenAuthMode(cfg.Token))
+
+	scopes, listed := github.TokenScopes(resp.Header)
+	switch {
+	case !listed:
+		_, _ = fmt.Fprintln(out, "ok: token has permissions rather than scopes, scopes not checked")
+	case len(github.MissingScopes(scopes, cfg.RequiredScopes)) > 0:
+		return fmt.Errorf("token is missing the required scopes %s, it has %s",
+			strings.Join(github.MissingScopes(scopes, cfg.RequiredScopes), ", "), formatScopes(scopes))
+	default:
+		_, _ = fmt.Fprintf(out, "ok: token has scopes %s\n", formatScopes(scopes))
+	}
+
+	if core := limits.GetCore(); core != nil {
+		_, _ = fmt.Fprintf(out, "ok: %d of %d requests left until %s\n", core.Remaining, core.Limit, core.Reset.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func formatScopes(scopes []string) string {
+	if len(scopes) == 0 {
+		return "none"
+	}
+	return strings.Join(scopes, ", ")
+}
@@ -0,0 +1,111 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RunHealthcheck(t *testing.T) {
+	reset := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	rateLimit := func(status int, scopes *string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			if scopes != nil {
+				w.Header().Set("X-OAuth-Scopes", *scopes)
+			}
+			w.WriteHeader(status)
+			if status != http.StatusOK {
+				_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"resources": map[string]any{
+				"core": gogithub.Rate{Limit: 5000, Remaining: 4999, Reset: gogithub.Timestamp{Time: reset}},
+			}})
+		}
+	}
+	scopes := "repo, read:org"
+
+	tests := []struct {
+		name           string
+		token          string
+		requiredScopes []string
+		handler        http.HandlerFunc
+		expectedOut    string
+		expectedErr    string
+	}{
+		{
+			name:           "classic token with the required scopes",
+			token:          "ghp_test",
+			requiredScopes: []string{"repo"},
+			handler:        rateLimit(http.StatusOK, &scopes),
+			expectedOut: "ok: reached https://api.github.com/\n" +
+				"ok: token is valid (personal_access_token)\n" +
+				"ok: token has scopes repo, read:org\n" +
+				"ok: 4999 of 5000 requests left until 2025-01-02T03:04:05Z\n",
+		},
+		{
+			name:           "token with permissions",
+			token:          "github_pat_test",
+			requiredScopes: []string{"repo"},
+			handler:        rateLimit(http.StatusOK, nil),
+			expectedOut: "ok: reached https://api.github.com/\n" +
+				"ok: token is valid (fine_grained_personal_access_token)\n" +
+				"ok: token has permissions rather than scopes, scopes not checked\n" +
+				"ok: 4999 of 5000 requests left until 2025-01-02T03:04:05Z\n",
+		},
+		{
+			name:           "token missing required scopes",
+			token:          "ghp_test",
+			requiredScopes: []string{"repo", "workflow"},
+			handler:        rateLimit(http.StatusOK, &scopes),
+			expectedOut: "ok: reached https://api.github.com/\n" +
+				"ok: token is valid (personal_access_token)\n",
+			expectedErr: "token is missing the required scopes workflow, it has repo, read:org",
+		},
+		{
+			name:        "token rejected",
+			token:       "ghp_test",
+			handler:     rateLimit(http.StatusUnauthorized, nil),
+			expectedErr: "token rejected by https://api.github.com/, it is invalid or has expired",
+		},
+		{
+			name:        "host unreachable",
+			token:       "ghp_test",
+			handler:     rateLimit(http.StatusBadGateway, nil),
+			expectedErr: "failed to reach https://api.github.com/",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := RunHealthcheck(context.Background(), HealthcheckConfig{
+				Version:        "test",
+				Token:          tc.token,
+				RequiredScopes: tc.requiredScopes,
+				Transport:      mock.NewMockedHTTPClient(mock.WithRequestMatchHandler(mock.GetRateLimit, tc.handler)).Transport,
+			}, &out)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedOut, out.String())
+		})
+	}
+
+	t.Run("invalid host", func(t *testing.T) {
+		var out bytes.Buffer
+		err := RunHealthcheck(context.Background(), HealthcheckConfig{Host: "github.example.com", Token: "ghp_test"}, &out)
+		assert.ErrorContains(t, err, "failed to parse API host")
+		assert.Empty(t, out.String())
+	})
+}
@@ -0,0 +1,357 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	mcptransport "github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type LoadTestConfig struct {
+	// Version of the server
+	Version string
+
+	// URL of the streamable HTTP endpoint of the MCP server under test
+	URL string
+
+	// Token is sent as a bearer token to the MCP server, when set
+	Token string
+
+	// Sessions is the number of MCP sessions calling tools concurrently
+	Sessions int
+
+	// CallsPerSession is the number of tools each session calls, ignored when Duration is set
+	CallsPerSession int
+
+	// Duration is how long the sessions keep calling tools, the sessions make CallsPerSession calls when zero
+	Duration time.Duration
+
+	// Interval is the pause between the calls of a session, e.g. to stay within the GitHub rate limits
+	Interval time.Duration
+
+	// Timeout bounds every call, zero for no limit
+	Timeout time.Duration
+
+	// Tools is the mix of tools the sessions call, in proportion to their weights
+	Tools []LoadTestTool
+
+	// MaxErrorRate is the highest share of failed calls, between 0 and 1, before the load test fails
+	MaxErrorRate float64
+}
+
+// LoadTestTool is a tool called by the load test, with the arguments it's called with.
+type LoadTestTool struct {
+	Name      string
+	Weight    int
+	Arguments map[string]any
+}
+
+// LoadTestStats are the latencies and failures of the calls to a tool, or to all of them.
+type LoadTestStats struct {
+	Name   string
+	Calls  int
+	Errors int
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+}
+
+// ErrorRate is the share of the calls that failed.
+func (s LoadTestStats) ErrorRate() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Calls)
+}
+
+// LoadTestReport is the outcome of a load test.
+type LoadTestReport struct {
+	// Sessions is the number of sessions started, FailedSessions the number that couldn't be initialized
+	Sessions       int
+	FailedSessions int
+
+	// Duration is how long the load test took
+	Duration time.Duration
+
+	// Tools are the stats of every tool called, by name, and Total those of all the calls
+	Tools []LoadTestStats
+	Total LoadTestStats
+
+	// Failures counts the failures of the calls and sessions by message
+	Failures map[string]int
+}
+
+// ParseLoadTestTools parses the mix of tools of the load test, given as tool or tool=weight entries, along with their
+// arguments, given as tool=JSON object entries.
+func ParseLoadTestTools(mix []string, arguments []string) ([]LoadTestTool, error) {
+	tools := make([]LoadTestTool, 0, len(mix))
+	indexes := make(map[string]int, len(mix))
+	for _, entry := range mix {
+		name, value, hasWeight := strings.Cut(strings.TrimSpace(entry), "=")
+		if name == "" {
+			return nil, fmt.Errorf("invalid load test tool %q, expected tool or tool=weight", entry)
+		}
+		weight := 1
+		if hasWeight {
+			var err error
+			if weight, err = strconv.Atoi(value); err != nil || weight < 1 {
+				return nil, fmt.Errorf("invalid load test tool %q, weight must be a positive number", entry)
+			}
+		}
+		if _, ok := indexes[name]; ok {
+			return nil, fmt.Errorf("load test tool %s is listed more than once", name)
+		}
+		indexes[name] = len(tools)
+		tools = append(tools, LoadTestTool{Name: name, Weight: weight, Arguments: map[string]any{}})
+	}
+
+	for _, entry := range arguments {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid load test tool arguments %q, expected tool={...}", entry)
+		}
+		i, ok := indexes[name]
+		if !ok {
+			return nil, fmt.Errorf("arguments given for %s, which isn't one of the load test tools", name)
+		}
+		if err := json.Unmarshal([]byte(value), &tools[i].Arguments); err != nil {
+			return nil, fmt.Errorf("invalid arguments of %s, expected a JSON object: %w", name, err)
+		}
+	}
+	return tools, nil
+}
+
+// LoadTest runs concurrent MCP sessions against the server, each calling the mix of tools in turn, and reports how
+// long the calls took and how many failed.
+func LoadTest(ctx context.Context, cfg LoadTestConfig) (*LoadTestReport, error) {
+	switch {
+	case cfg.URL == "":
+		return nil, errors.New("the URL of the MCP server is required")
+	case cfg.Sessions < 1:
+		return nil, errors.New("at least one session is required")
+	case cfg.Duration <= 0 && cfg.CallsPerSession < 1:
+		return nil, errors.New("either a duration or at least one call per session is required")
+	case len(cfg.Tools) == 0:
+		return nil, errors.New("at least one tool is required")
+	}
+
+	// Tools are called in turn in proportion to their weights, every session starting at another one
+	var schedule []LoadTestTool
+	for _, tool := range cfg.Tools {
+		for i := 0; i < tool.Weight; i++ {
+			schedule = append(schedule, tool)
+		}
+	}
+
+	var (
+		mu        sync.Mutex
+		latencies = map[string][]time.Duration{}
+		errs      = map[string]int{}
+		report    = &LoadTestReport{Sessions: cfg.Sessions, Failures: map[string]int{}}
+	)
+	record := func(name string, latency time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		latencies[name] = append(latencies[name], latency)
+		if err != nil {
+			errs[name]++
+			report.Failures[err.Error()]++
+		}
+	}
+
+	var deadline time.Time
+	start := time.Now()
+	if cfg.Duration > 0 {
+		deadline = start.Add(cfg.Duration)
+	}
+
+	var wg sync.WaitGroup
+	for session := 0; session < cfg.Sessions; session++ {
+		wg.Add(1)
+		go func(session int) {
+			defer wg.Done()
+
+			client, err := startLoadTestSession(ctx, cfg)
+			if err != nil {
+				mu.Lock()
+				report.FailedSessions++
+				report.Failures[err.Error()]++
+				mu.Unlock()
+				return
+			}
+			defer func() { _ = client.Close() }()
+
+			for call := 0; ctx.Err() == nil; call++ {
+				if (deadline.IsZero() && call >= cfg.CallsPerSession) || (!deadline.IsZero() && !time.Now().Before(deadline)) {
+					return
+				}
+				if call > 0 && cfg.Interval > 0 {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(cfg.Interval):
+					}
+				}
+
+				tool := schedule[(session+call)%len(schedule)]
+				latency, err := callLoadTestTool(ctx, client, tool, cfg.Timeout)
+				record(tool.Name, latency, err)
+			}
+		}(session)
+	}
+	wg.Wait()
+	report.Duration = time.Since(start)
+
+	var all []time.Duration
+	for name, calls := range latencies {
+		report.Tools = append(report.Tools, loadTestStats(name, calls, errs[name]))
+		all = append(all, calls...)
+		report.Total.Errors += errs[name]
+	}
+	sort.Slice(report.Tools, func(i, j int) bool { return report.Tools[i].Name < report.Tools[j].Name })
+	report.Total = loadTestStats("total", all, report.Total.Errors)
+	return report, ctx.Err()
+}
+
+// RunLoadTest runs the load test and writes its report to out. It returns an error when sessions couldn't be
+// initialized or more calls than the max error rate failed.
+func RunLoadTest(ctx context.Context, cfg LoadTestConfig, out io.Writer) error {
+	report, err := LoadTest(ctx, cfg)
+	if report == nil {
+		return err
+	}
+	writeLoadTestReport(report, out)
+	if err != nil {
+		return fmt.Errorf("load test interrupted: %w", err)
+	}
+	if report.FailedSessions > 0 {
+		return fmt.Errorf("%d of %d sessions failed to initialize", report.FailedSessions, report.Sessions)
+	}
+	if rate := report.Total.ErrorRate(); rate > cfg.MaxErrorRate {
+		return fmt.Errorf("%.2f%% of the calls failed, more than the max error rate of %.2f%%", rate*100, cfg.MaxErrorRate*100)
+	}
+	return nil
+}
+
+// startLoadTestSession connects a session to the MCP server and initializes it.
+func startLoadTestSession(ctx context.Context, cfg LoadTestConfig) (*mcpclient.Client, error) {
+	var options []mcptransport.StreamableHTTPCOption
+	if cfg.Token != "" {
+		options = append(options, mcptransport.WithHTTPHeaders(map[string]string{"Authorization": "Bearer " + cfg.Token}))
+	}
+	client, err := mcpclient.NewStreamableHttpClient(cfg.URL, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	if err := client.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcp.Implementation{
+		Name:    "github-mcp-server-loadtest",
+		Version: cfg.Version,
+	}
+	if _, err := client.Initialize(ctx, request); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to initialize session: %w", err)
+	}
+	return client, nil
+}
+
+// callLoadTestTool calls the tool, returning how long the call took and an error when it failed, whether the call
+// itself or the tool did.
+func callLoadTestTool(ctx context.Context, client *mcpclient.Client, tool LoadTestTool, timeout time.Duration) (time.Duration, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = tool.Name
+	request.Params.Arguments = tool.Arguments
+
+	start := time.Now()
+	result, err := client.CallTool(ctx, request)
+	latency := time.Since(start)
+	switch {
+	case err != nil:
+		return latency, fmt.Errorf("%s: %w", tool.Name, err)
+	case result.IsError:
+		message := "tool returned an error"
+		if len(result.Content) > 0 {
+			if text, ok := result.Content[0].(mcp.TextContent); ok {
+				message = text.Text
+			}
+		}
+		return latency, fmt.Errorf("%s: %s", tool.Name, message)
+	}
+	return latency, nil
+}
+
+// loadTestStats computes the latency percentiles of the calls.
+func loadTestStats(name string, latencies []time.Duration, failed int) LoadTestStats {
+	stats := LoadTestStats{Name: name, Calls: len(latencies), Errors: failed}
+	if len(latencies) == 0 {
+		return stats
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	// Nearest rank percentiles
+	percentile := func(p int) time.Duration {
+		rank := (p*len(sorted) + 99) / 100
+		return sorted[rank-1]
+	}
+	stats.P50 = percentile(50)
+	stats.P90 = percentile(90)
+	stats.P99 = percentile(99)
+	stats.Max = sorted[len(sorted)-1]
+	return stats
+}
+
+func writeLoadTestReport(report *LoadTestReport, out io.Writer) {
+	_, _ = fmt.Fprintf(out, "%d sessions (%d failed), %d calls in %s (%.2f calls/s)\n\n",
+		report.Sessions, report.FailedSessions, report.Total.Calls, report.Duration.Round(time.Millisecond),
+		float64(report.Total.Calls)/report.Duration.Seconds())
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TOOL\tCALLS\tERRORS\tERROR RATE\tP50\tP90\tP99\tMAX")
+	for _, stats := range append(report.Tools, report.Total) {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%.2f%%\t%s\t%s\t%s\t%s\n", stats.Name, stats.Calls, stats.Errors, stats.ErrorRate()*100,
+			stats.P50.Round(time.Microsecond), stats.P90.Round(time.Microsecond), stats.P99.Round(time.Microsecond), stats.Max.Round(time.Microsecond))
+	}
+	_ = w.Flush()
+
+	if len(report.Failures) == 0 {
+		return
+	}
+	messages := make([]string, 0, len(report.Failures))
+	for message := range report.Failures {
+		messages = append(messages, message)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		if report.Failures[messages[i]] != report.Failures[messages[j]] {
+			return report.Failures[messages[i]] > report.Failures[messages[j]]
+		}
+		return messages[i] < messages[j]
+	})
+	_, _ = fmt.Fprintln(out, "\nFailures:")
+	for _, message := range messages {
+		_, _ = fmt.Fprintf(out, "  %d\t%s\n", report.Failures[message], message)
+	}
+}
@@ -0,0 +1,271 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLoadTestServer serves an MCP server with a tool that succeeds and one that fails over streamable HTTP,
+// requiring the token when it isn't empty.
+func newLoadTestServer(t *testing.T, token string) *httptest.Server {
+	mcpServer := server.NewMCPServer("test", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("get_me"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(`{"login":"octocat"}`), nil
+	})
+	mcpServer.AddTool(mcp.NewTool("get_issue"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("missing required parameter: owner"), nil
+	})
+
+	handler := server.NewStreamableHTTPServer(mcpServer)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func Test_ParseLoadTestTools(t *testing.T) {
+	tests := []struct {
+		name          string
+		mix           []string
+		arguments     []string
+		expectedTools []LoadTestTool
+		expectedErr   string
+	}{
+		{
+			name:      "weights and arguments",
+			mix:       []string{"get_me", " get_issue=3"},
+			arguments: []string{`get_issue={"owner":"octocat","issue_number":1}`},
+			expectedTools: []LoadTestTool{
+				{Name: "get_me", Weight: 1, Arguments: map[string]any{}},
+				{Name: "get_issue", Weight: 3, Arguments: map[string]any{"owner": "octocat", "issue_number": float64(1)}},
+			},
+		},
+		{
+			name:        "missing tool name",
+			mix:         []string{"=2"},
+			expectedErr: `invalid load test tool "=2", expected tool or tool=weight`,
+		},
+		{
+			name:        "invalid weight",
+			mix:         []string{"get_me=0"},
+			expectedErr: `invalid load test tool "get_me=0", weight must be a positive number`,
+		},
+		{
+			name:        "tool listed twice",
+			mix:         []string{"get_me", "get_me=2"},
+			expectedErr: "load test tool get_me is listed more than once",
+		},
+		{
+			name:        "arguments without a tool",
+			mix:         []string{"get_me"},
+			arguments:   []string{`{"owner":"octocat"}`},
+			expectedErr: `invalid load test tool arguments "{\"owner\":\"octocat\"}", expected tool={...}`,
+		},
+		{
+			name:        "arguments of another tool",
+			mix:         []string{"get_me"},
+			arguments:   []string{`get_issue={}`},
+			expectedErr: "arguments given for get_issue, which isn't one of the load test tools",
+		},
+		{
+			name:        "arguments that aren't an object",
+			mix:         []string{"get_me"},
+			arguments:   []string{`get_me=[1]`},
+			expectedErr: "invalid arguments of get_me, expected a JSON object",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tools, err := ParseLoadTestTools(tc.mix, tc.arguments)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTools, tools)
+		})
+	}
+}
+
+func Test_LoadTest(t *testing.T) {
+	srv := newLoadTestServer(t, "secret")
+
+	report, err := LoadTest(context.Background(), LoadTestConfig{
+		Version:         "test",
+		URL:             srv.URL,
+		Token:           "secret",
+		Sessions:        2,
+		CallsPerSession: 3,
+		Tools:           []LoadTestTool{{Name: "get_me", Weight: 2}, {Name: "get_issue", Weight: 1}},
+	})
+	require.NoError(t, err)
+
+	// Every session calls the tools in turn from another starting point, so both call get_me twice and get_issue once
+	assert.Equal(t, 2, report.Sessions)
+	assert.Zero(t, report.FailedSessions)
+	require.Len(t, report.Tools, 2)
+	assert.Equal(t, "get_issue", report.Tools[0].Name)
+	assert.Equal(t, 2, report.Tools[0].Calls)
+	assert.Equal(t, 2, report.Tools[0].Errors)
+	assert.Equal(t, "get_me", report.Tools[1].Name)
+	assert.Equal(t, 4, report.Tools[1].Calls)
+	assert.Zero(t, report.Tools[1].Errors)
+	assert.Equal(t, 6, report.Total.Calls)
+	assert.Equal(t, 2, report.Total.Errors)
+	assert.Equal(t, map[string]int{"get_issue: missing required parameter: owner": 2}, report.Failures)
+}
+
+func Test_LoadTest_Duration(t *testing.T) {
+	srv := newLoadTestServer(t, "")
+
+	report, err := LoadTest(context.Background(), LoadTestConfig{
+		URL:      srv.URL,
+		Sessions: 1,
+		Duration: 50 * time.Millisecond,
+		Interval: 10 * time.Millisecond,
+		Tools:    []LoadTestTool{{Name: "get_me", Weight: 1}},
+	})
+	require.NoError(t, err)
+	assert.Positive(t, report.Total.Calls)
+	assert.Zero(t, report.Total.Errors)
+	assert.GreaterOrEqual(t, report.Duration, 50*time.Millisecond)
+}
+
+func Test_LoadTest_InvalidConfig(t *testing.T) {
+	valid := LoadTestConfig{URL: "http://localhost", Sessions: 1, CallsPerSession: 1, Tools: []LoadTestTool{{Name: "get_me", Weight: 1}}}
+
+	tests := []struct {
+		name        string
+		update      func(cfg *LoadTestConfig)
+		expectedErr string
+	}{
+		{name: "missing URL", update: func(cfg *LoadTestConfig) { cfg.URL = "" }, expectedErr: "the URL of the MCP server is required"},
+		{name: "no sessions", update: func(cfg *LoadTestConfig) { cfg.Sessions = 0 }, expectedErr: "at least one session is required"},
+		{name: "no calls", update: func(cfg *LoadTestConfig) { cfg.CallsPerSession = 0 }, expectedErr: "either a duration or at least one call per session is required"},
+		{name: "no tools", update: func(cfg *LoadTestConfig) { cfg.Tools = nil }, expectedErr: "at least one tool is required"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := valid
+			tc.update(&cfg)
+			report, err := LoadTest(context.Background(), cfg)
+			assert.Nil(t, report)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func Test_RunLoadTest(t *testing.T) {
+	srv := newLoadTestServer(t, "secret")
+
+	tests := []struct {
+		name        string
+		cfg         LoadTestConfig
+		expectedOut []string
+		expectedErr string
+	}{
+		{
+			name:        "within the max error rate",
+			cfg:         LoadTestConfig{Token: "secret", Tools: []LoadTestTool{{Name: "get_me", Weight: 1}, {Name: "get_issue", Weight: 1}}, MaxErrorRate: 0.5},
+			expectedOut: []string{"1 sessions (0 failed), 2 calls in ", "\nFailures:\n  1\tget_issue: missing required parameter: owner\n"},
+		},
+		{
+			name:        "above the max error rate",
+			cfg:         LoadTestConfig{Token: "secret", Tools: []LoadTestTool{{Name: "get_me", Weight: 1}, {Name: "get_issue", Weight: 1}}, MaxErrorRate: 0.1},
+			expectedErr: "50.00% of the calls failed, more than the max error rate of 10.00%",
+		},
+		{
+			name:        "sessions failing to initialize",
+			cfg:         LoadTestConfig{Tools: []LoadTestTool{{Name: "get_me", Weight: 1}}},
+			expectedOut: []string{"1 sessions (1 failed), 0 calls in "},
+			expectedErr: "1 of 1 sessions failed to initialize",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.URL = srv.URL
+			cfg.Sessions = 1
+			cfg.CallsPerSession = 2
+
+			var out bytes.Buffer
+			err := RunLoadTest(context.Background(), cfg, &out)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, expected := range tc.expectedOut {
+				assert.Contains(t, out.String(), expected)
+			}
+		})
+	}
+}
+
+func Test_loadTestStats(t *testing.T) {
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, LoadTestStats{
+		Name:   "get_me",
+		Calls:  100,
+		Errors: 5,
+		P50:    50 * time.Millisecond,
+		P90:    90 * time.Millisecond,
+		P99:    99 * time.Millisecond,
+		Max:    100 * time.Millisecond,
+	}, loadTestStats("get_me", latencies, 5))
+	assert.Equal(t, LoadTestStats{Name: "get_me"}, loadTestStats("get_me", nil, 0))
+
+	// Nearest rank percentiles of few calls are the calls themselves
+	stats := loadTestStats("get_me", []time.Duration{3 * time.Millisecond, time.Millisecond}, 0)
+	assert.Equal(t, time.Millisecond, stats.P50)
+	assert.Equal(t, 3*time.Millisecond, stats.P90)
+	assert.Equal(t, 0.05, LoadTestStats{Calls: 100, Errors: 5}.ErrorRate())
+	assert.Zero(t, LoadTestStats{}.ErrorRate())
+}
+
+func Test_writeLoadTestReport(t *testing.T) {
+	report := &LoadTestReport{
+		Sessions: 2,
+		Duration: 2 * time.Second,
+		Tools: []LoadTestStats{
+			{Name: "get_issue", Calls: 2, Errors: 1, P50: time.Millisecond, P90: 2 * time.Millisecond, P99: 2 * time.Millisecond, Max: 2 * time.Millisecond},
+			{Name: "get_me", Calls: 2, P50: time.Millisecond, P90: time.Millisecond, P99: time.Millisecond, Max: time.Millisecond},
+		},
+		Total:    LoadTestStats{Name: "total", Calls: 4, Errors: 1, P50: time.Millisecond, P90: 2 * time.Millisecond, P99: 2 * time.Millisecond, Max: 2 * time.Millisecond},
+		Failures: map[string]int{"get_issue: not found": 1, "get_me: timeout": 1, "get_issue: rate limited": 3},
+	}
+
+	var out bytes.Buffer
+	writeLoadTestReport(report, &out)
+	assert.Equal(t, "2 sessions (0 failed), 4 calls in 2s (2.00 calls/s)\n\n"+
+		"TOOL       CALLS  ERRORS  ERROR RATE  P50  P90  P99  MAX\n"+
+		"get_issue  2      1       50.00%      1ms  2ms  2ms  2ms\n"+
+		"get_me     2      0       0.00%       1ms  1ms  1ms  1ms\n"+
+		"total      4      1       25.00%      1ms  2ms  2ms  2ms\n"+
+		"\nFailures:\n"+
+		// The most frequent failures come first
+		"  3\tget_issue: rate limited\n"+
+		"  1\tget_issue: not found\n"+
+		"  1\tget_me: timeout\n", out.String())
+}
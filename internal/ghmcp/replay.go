@@ -0,0 +1,228 @@
+package ghmcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	ReplayModeDryRun = "dry-run"
+	ReplayModeLive   = "live"
+)
+
+// commandLogReceivedMessage and commandLogData identify the lines the stdio server writes to its log file for the
+// bytes it reads from the client when command logging is enabled.
+const (
+	commandLogReceivedMessage = `msg="[stdin]: received bytes"`
+	commandLogData            = " data="
+)
+
+type ReplayConfig struct {
+	// Server holds the settings of the server the messages are replayed against, as for the stdio server. Its
+	// logging, webhook, update check and reloading settings don't apply.
+	Server StdioServerConfig
+
+	// Log holds the captured messages, either as JSON Lines of JSON-RPC messages or as the log file the stdio
+	// server writes with command logging enabled
+	Log io.Reader
+
+	// Mode is dry-run, which checks the requests against the server without calling tools, or live, which
+	// hands every message to the server
+	Mode string
+}
+
+// replayMessage is a message the client sent, as captured in the log.
+type replayMessage struct {
+	raw    json.RawMessage
+	Method string `json:"method"`
+	Params struct {
+		Name      string         `json:"name"`
+		Arguments map[string]any `json:"arguments"`
+	} `json:"params"`
+}
+
+// RunReplay replays the messages a client sent to the server, as captured in a log, to reproduce a session. In
+// live mode, every message is handed to a server with the configuration and the responses are written to out as
+// JSON Lines, as the client received them. In dry-run mode, the messages are listed along with the tool calls the
+// server would reject, such as calls to tools that aren't enabled, and no tool is called. It returns an error when
+// the log can't be read, or when a dry run finds tool calls the server would reject.
+func RunReplay(ctx context.Context, cfg ReplayConfig, out, errOut io.Writer) error {
+	if cfg.Mode != ReplayModeDryRun && cfg.Mode != ReplayModeLive {
+		return fmt.Errorf("unknown mode %q, expected %s or %s", cfg.Mode, ReplayModeDryRun, ReplayModeLive)
+	}
+	messages, err := readReplayMessages(cfg.Log)
+	if err != nil {
+		return err
+	}
+
+	t, _ := translations.TranslationHelper()
+	ghServer, tsg, _, err := newStdioMCPServer(cfg.Server, t, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	if cfg.Mode == ReplayModeDryRun {
+		// The tools of dynamic toolsets are registered with the server outside of the toolset group
+		dynamicTools := make(map[string]bool)
+		if cfg.Server.DynamicToolsets {
+			for _, tool := range github.InitDynamicToolset(ghServer, tsg, t).GetActiveTools() {
+				dynamicTools[tool.Tool.Name] = true
+			}
+		}
+		return dryRunReplay(messages, tsg, dynamicTools, out)
+	}
+
+	ctx, closeSession, err := startCLISession(ctx, ghServer, errOut)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+
+	var failed int
+	for i, message := range messages {
+		summary := message.Method
+		if message.Method == string(mcp.MethodToolsCall) {
+			summary += " " + message.Params.Name
+		}
+		_, _ = fmt.Fprintf(errOut, "replaying %d/%d: %s\n", i+1, len(messages), summary)
+
+		response := ghServer.HandleMessage(ctx, message.raw)
+		if response == nil {
+			continue
+		}
+		switch response := response.(type) {
+		case mcp.JSONRPCError:
+			failed++
+		case mcp.JSONRPCResponse:
+			if result, ok := response.Result.(mcp.CallToolResult); ok && result.IsError {
+				failed++
+			}
+		}
+		encoded, err := json.Marshal(response)
+		if err != nil {
+			return fmt.Errorf("failed to marshal the response to message %d: %w", i+1, err)
+		}
+		if _, err := fmt.Fprintf(out, "%s\n", encoded); err != nil {
+			return err
+		}
+	}
+	_, _ = fmt.Fprintf(errOut, "replayed %d messages, %d failed\n", len(messages), failed)
+	return nil
+}
+
+// dryRunReplay lists the messages along with the tool calls the server would reject. With dynamic toolsets,
+// whose tools are given, the toolsets enabled by enable_toolset calls count as enabled for the calls that follow.
+func dryRunReplay(messages []replayMessage, tsg *toolsets.ToolsetGroup, dynamicTools map[string]bool, out io.Writer) error {
+	enabled := make(map[string]bool)
+	available := func(name string) bool {
+		if _, ok := tsg.GetActiveTool(name); ok || dynamicTools[name] {
+			return true
+		}
+		for toolset := range enabled {
+			for _, tool := range tsg.Toolsets[toolset].GetAvailableTools() {
+				if tool.Tool.Name == name {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	var rejected int
+	for i, message := range messages {
+		if message.Method != string(mcp.MethodToolsCall) {
+			_, _ = fmt.Fprintf(out, "%d: %s\n", i+1, message.Method)
+			continue
+		}
+		arguments, _ := json.Marshal(message.Params.Arguments)
+		_, _ = fmt.Fprintf(out, "%d: %s %s %s\n", i+1, message.Method, message.Params.Name, arguments)
+		if !available(message.Params.Name) {
+			rejected++
+			_, _ = fmt.Fprintf(out, "error: tool %s isn't enabled with the current configuration\n", message.Params.Name)
+			continue
+		}
+		if message.Params.Name == "enable_toolset" {
+			toolset, _ := message.Params.Arguments["toolset"].(string)
+			if _, err := tsg.GetToolset(toolset); err != nil {
+				rejected++
+				_, _ = fmt.Fprintf(out, "error: %s\n", err)
+				continue
+			}
+			enabled[toolset] = true
+		}
+	}
+	if rejected > 0 {
+		return fmt.Errorf("%d of %d messages would be rejected", rejected, len(messages))
+	}
+	return nil
+}
+
+// readReplayMessages reads the messages the client sent from a log, skipping the responses it sent to requests of
+// the server, such as sampling requests, as they answer requests the replayed server doesn't make again.
+func readReplayMessages(log io.Reader) ([]replayMessage, error) {
+	var stream bytes.Buffer
+	scanner := bufio.NewScanner(log)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(strings.TrimSpace(line), "{"):
+			stream.WriteString(line)
+			stream.WriteByte('\n')
+		case strings.Contains(line, commandLogReceivedMessage):
+			// The stdio server logs the bytes it reads as they come, so messages can span several lines
+			i := strings.Index(line, commandLogData)
+			if i < 0 {
+				return nil, fmt.Errorf("line %d of the log has no data", lineNumber)
+			}
+			// Values are only quoted when they need to be, such as a chunk holding a closing brace alone
+			data := line[i+len(commandLogData):]
+			if strings.HasPrefix(data, `"`) {
+				unquoted, err := strconv.Unquote(data)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read the data of line %d of the log: %w", lineNumber, err)
+				}
+				data = unquoted
+			}
+			stream.WriteString(data)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the log: %w", err)
+	}
+
+	var messages []replayMessage
+	decoder := json.NewDecoder(&stream)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse message %d of the log: %w", len(messages)+1, err)
+		}
+		var message replayMessage
+		if err := json.Unmarshal(raw, &message); err != nil {
+			return nil, fmt.Errorf("failed to parse message %d of the log: %w", len(messages)+1, err)
+		}
+		if message.Method == "" {
+			continue
+		}
+		message.raw = raw
+		messages = append(messages, message)
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("the log holds no messages from a client")
+	}
+	return messages, nil
+}
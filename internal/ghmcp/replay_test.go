@@ -0,0 +1,185 @@
+package ghmcp
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commandLog returns the log the stdio server writes with command logging enabled when it reads the chunks.
+func commandLog(t *testing.T, chunks ...string) string {
+	var logged bytes.Buffer
+	readers := make([]io.Reader, 0, len(chunks))
+	for _, chunk := range chunks {
+		readers = append(readers, strings.NewReader(chunk))
+	}
+	logger := mcplog.NewIOLogger(io.MultiReader(readers...), nil, slog.New(slog.NewTextHandler(&logged, nil)))
+	_, err := io.ReadAll(logger)
+	require.NoError(t, err)
+	return logged.String()
+}
+
+func Test_readReplayMessages(t *testing.T) {
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`
+	call := `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_me","arguments":{"reason":"a test"}}}`
+
+	tests := []struct {
+		name            string
+		log             string
+		expectedMethods []string
+		expectedErr     string
+	}{
+		{
+			name:            "JSON Lines",
+			log:             initialize + "\n" + call + "\n",
+			expectedMethods: []string{"initialize", "tools/call"},
+		},
+		{
+			name:            "message split across chunks",
+			log:             commandLog(t, initialize+"\n"+call[:40], call[40:]+"\n"),
+			expectedMethods: []string{"initialize", "tools/call"},
+		},
+		{
+			name: "unquoted chunk",
+			// A chunk that needs no quoting, such as a closing brace, is logged as is
+			log:             commandLog(t, call[:len(call)-1], "}"),
+			expectedMethods: []string{"tools/call"},
+		},
+		{
+			name:            "responses to server requests are skipped",
+			log:             initialize + "\n" + `{"jsonrpc":"2.0","id":"sampling-1","result":{"content":"done"}}` + "\n" + call + "\n",
+			expectedMethods: []string{"initialize", "tools/call"},
+		},
+		{
+			name:        "no messages",
+			log:         `time=2025-01-01T00:00:00.000Z level=INFO msg="starting server"` + "\n",
+			expectedErr: "the log holds no messages from a client",
+		},
+		{
+			name:        "truncated message",
+			log:         commandLog(t, call[:20]),
+			expectedErr: "failed to parse message 1 of the log",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			messages, err := readReplayMessages(strings.NewReader(tc.log))
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			methods := make([]string, 0, len(messages))
+			for _, message := range messages {
+				methods = append(methods, message.Method)
+			}
+			assert.Equal(t, tc.expectedMethods, methods)
+			if last := messages[len(messages)-1]; last.Method == "tools/call" {
+				assert.Equal(t, "get_me", last.Params.Name)
+				assert.JSONEq(t, call, string(last.raw))
+			}
+		})
+	}
+}
+
+func Test_dryRunReplay(t *testing.T) {
+	newTool := func(name string) server.ServerTool {
+		return toolsets.NewServerTool(mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: github.ToBoolPtr(true)})), nil)
+	}
+	newGroup := func() *toolsets.ToolsetGroup {
+		tsg := toolsets.NewToolsetGroup(false)
+		tsg.AddToolset(toolsets.NewToolset("context", "Context").AddReadTools(newTool("get_me")))
+		tsg.AddToolset(toolsets.NewToolset("issues", "Issues").AddReadTools(newTool("get_issue")))
+		require.NoError(t, tsg.EnableToolsets([]string{"context"}))
+		return tsg
+	}
+	callMessage := func(name string, arguments map[string]any) replayMessage {
+		message := replayMessage{Method: string(mcp.MethodToolsCall)}
+		message.Params.Name = name
+		message.Params.Arguments = arguments
+		return message
+	}
+	dynamicTools := map[string]bool{"enable_toolset": true, "list_available_toolsets": true}
+
+	tests := []struct {
+		name           string
+		messages       []replayMessage
+		dynamicTools   map[string]bool
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name:           "enabled tools",
+			messages:       []replayMessage{{Method: "initialize"}, callMessage("get_me", nil)},
+			expectedOutput: "1: initialize\n2: tools/call get_me null\n",
+		},
+		{
+			name:     "tool of a disabled toolset",
+			messages: []replayMessage{callMessage("get_issue", map[string]any{"issue_number": 1})},
+			expectedOutput: "1: tools/call get_issue {\"issue_number\":1}\n" +
+				"error: tool get_issue isn't enabled with the current configuration\n",
+			expectedErr: "1 of 1 messages would be rejected",
+		},
+		{
+			name: "toolset enabled during the session",
+			messages: []replayMessage{
+				callMessage("enable_toolset", map[string]any{"toolset": "issues"}),
+				callMessage("get_issue", nil),
+			},
+			dynamicTools:   dynamicTools,
+			expectedOutput: "1: tools/call enable_toolset {\"toolset\":\"issues\"}\n2: tools/call get_issue null\n",
+		},
+		{
+			name: "tool called before its toolset is enabled",
+			messages: []replayMessage{
+				callMessage("get_issue", nil),
+				callMessage("enable_toolset", map[string]any{"toolset": "issues"}),
+			},
+			dynamicTools: dynamicTools,
+			expectedOutput: "1: tools/call get_issue null\n" +
+				"error: tool get_issue isn't enabled with the current configuration\n" +
+				"2: tools/call enable_toolset {\"toolset\":\"issues\"}\n",
+			expectedErr: "1 of 2 messages would be rejected",
+		},
+		{
+			name:         "unknown toolset enabled",
+			messages:     []replayMessage{callMessage("enable_toolset", map[string]any{"toolset": "nope"})},
+			dynamicTools: dynamicTools,
+			expectedOutput: "1: tools/call enable_toolset {\"toolset\":\"nope\"}\n" +
+				"error: toolset nope does not exist\n",
+			expectedErr: "1 of 1 messages would be rejected",
+		},
+		{
+			name:     "dynamic tools without dynamic toolsets",
+			messages: []replayMessage{callMessage("enable_toolset", map[string]any{"toolset": "issues"})},
+			expectedOutput: "1: tools/call enable_toolset {\"toolset\":\"issues\"}\n" +
+				"error: tool enable_toolset isn't enabled with the current configuration\n",
+			expectedErr: "1 of 1 messages would be rejected",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := dryRunReplay(tc.messages, newGroup(), tc.dynamicTools, &out)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package ghmcp
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"log"
@@ -11,13 +12,19 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/lfs"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/transport"
+	"github.com/github/github-mcp-server/pkg/webhooks"
 	gogithub "github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -28,12 +35,20 @@ type MCPServerConfig struct {
 	// Version of the server
 	Version string
 
+	// Commit and BuildDate identify the build of the server
+	Commit    string
+	BuildDate string
+
 	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
 	Host string
 
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// InstallationTokens authenticates as the GitHub App installation InstallationID instead of with Token, when set
+	InstallationTokens *transport.InstallationTokenSource
+	InstallationID     int64
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -50,32 +65,183 @@ type MCPServerConfig struct {
 
 	// Content window size
 	ContentWindowSize int
+
+	// RateLimitMaxWait is the longest a single request may wait for GitHub rate limits
+	// to reset before being retried, zero disables retries
+	RateLimitMaxWait time.Duration
+
+	// RetryMaxAttempts is the number of times idempotent requests failing with network errors or
+	// 502, 503 and 504 responses are attempted, values below 2 disable retries
+	RetryMaxAttempts int
+
+	// ToolRetryAttempts overrides the attempts of the requests made by the listed tools,
+	// retrying them even when they aren't idempotent
+	ToolRetryAttempts map[string]int
+
+	// CircuitBreakerThreshold is the number of consecutive server errors or timeouts from a host after which
+	// requests to it fail fast, zero disables the circuit breaker
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long requests to a failing host fail fast before one is let through again
+	CircuitBreakerCooldown time.Duration
+
+	// OfflineCacheMaxAge is the age up to which cached responses of read requests are served, flagged as stale,
+	// when GitHub can't be reached, zero disables the offline cache
+	OfflineCacheMaxAge time.Duration
+
+	// OfflineCacheSize is the number of responses kept for the offline cache
+	OfflineCacheSize int
+
+	// Pool tunes the connections to GitHub, shared by all the clients of the server
+	Pool transport.PoolConfig
+
+	// Transport, when set, sends the requests to GitHub in place of the connection pool, e.g. to record
+	// and replay them in end to end tests
+	Transport http.RoundTripper
+
+	// MaxConcurrentRequests caps the GitHub requests in flight across the server, zero means no limit
+	MaxConcurrentRequests int
+
+	// MaxConcurrentRequestsPerHost caps the GitHub requests in flight to a single host, zero means no limit
+	MaxConcurrentRequestsPerHost int
+
+	// RequestQueueTimeout is the longest a request may wait for a free slot, zero means no timeout
+	RequestQueueTimeout time.Duration
+
+	// SummarizeThreshold is the length in characters above which tool results are summarized
+	// through sampling when the client supports it, zero disables summarization
+	SummarizeThreshold int
+
+	// PrivacyMode strips emails, avatar URLs and other personal data of users from tool results
+	PrivacyMode bool
+
+	// MinimalOutput strips null and empty fields, API links and other boilerplate from tool results
+	MinimalOutput bool
+
+	// DisableResources skips registering resource templates, for clients that can't handle them
+	DisableResources bool
+
+	// Roots holds the roots the client declared, which default the owner and repo of its tool calls when set
+	Roots *github.Roots
+
+	// RestrictToRoots fails the tool calls naming repositories outside the roots of the client
+	RestrictToRoots bool
+
+	// ConfirmTools lists the tools whose calls the user of the client must confirm, through Elicitor, to run
+	ConfirmTools []string
+
+	// Elicitor asks the user of the client for confirmation, nil when the client can't be asked
+	Elicitor github.Elicitor
+
+	// AllowRepositoryDeletion lists the repositories, as owner/repo or owner/*, that delete_repository may
+	// delete, the tool isn't offered when it is empty
+	AllowRepositoryDeletion []string
+
+	// Policies holds the policy the tool calls follow, so that it can be swapped while the server runs. When nil,
+	// the policy is made of RestrictToRoots, ConfirmTools, AllowRepositoryDeletion and PrivacyMode.
+	Policies *github.PolicyStore
+}
+
+// newPooledTransport returns base when set, and otherwise a transport of a new pool of connections, so that
+// concurrent calls reuse connections to the API, asking for compressed responses so that large diffs and logs are
+// transferred compressed.
+func newPooledTransport(base http.RoundTripper, pool transport.PoolConfig) http.RoundTripper {
+	if base != nil {
+		return base
+	}
+	return transport.NewCompressionTransport(transport.NewPooledTransport(pool))
 }
 
+// newRetryTransport retries the requests failing with network errors or gateway errors, by default only when
+// they're idempotent, letting the client of the tool call know why it is taking longer.
+func newRetryTransport(base http.RoundTripper, maxAttempts int) http.RoundTripper {
+	return transport.NewRetryTransport(base, transport.RetryPolicy{MaxAttempts: maxAttempts},
+		func(ctx context.Context, attempt int, wait time.Duration, reason string) {
+			message := fmt.Sprintf("GitHub request failed (%s), retrying in %s (attempt %d)", reason, wait.Round(time.Millisecond), attempt)
+			github.NotifyProgress(ctx, float64(attempt), 0, message)
+			github.LogToClient(ctx, mcp.LoggingLevelWarning, github.ClientLoggerRetry, message, map[string]any{
+				"reason":  reason,
+				"attempt": attempt,
+				"wait_ms": wait.Milliseconds(),
+			})
+		})
+}
+
+// maxStoredResults bounds the number of full results kept around after being summarized
+const maxStoredResults = 100
+
 const stdioServerLogPrefix = "stdioserver"
 
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
+	ghServer, _, err := newMCPServer(cfg)
+	return ghServer, err
+}
+
+// newMCPServer creates the server along with its toolset group, so that toolsets can be changed later on.
+func newMCPServer(cfg MCPServerConfig) (*server.MCPServer, *toolsets.ToolsetGroup, error) {
 	apiHost, err := parseAPIHost(cfg.Host)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse API host: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
+	retryTransport := newRetryTransport(newPooledTransport(cfg.Transport, cfg.Pool), cfg.RetryMaxAttempts)
+
+	// Fail fast while GitHub is unavailable, rather than having every call time out after its retries
+	circuitBreakerTransport := transport.NewCircuitBreakerTransport(retryTransport,
+		cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown, nil)
+
+	// Answer read requests from recent responses while GitHub can't be reached
+	offlineCacheTransport := transport.NewOfflineCacheTransport(circuitBreakerTransport,
+		cfg.OfflineCacheMaxAge, cfg.OfflineCacheSize, github.MarkStale)
+
+	// Bound the requests in flight so bursts of tool calls don't trip secondary rate limits. The limit sits
+	// below the rate limit retries, so that a request waiting out a rate limit doesn't hold a slot meanwhile.
+	limitedTransport := transport.NewConcurrencyLimitTransport(offlineCacheTransport,
+		cfg.MaxConcurrentRequests, cfg.MaxConcurrentRequestsPerHost, cfg.RequestQueueTimeout)
+
+	// Retry requests that hit rate limits, letting the client know why the call is taking longer
+	rateLimitTransport := transport.NewRateLimitTransport(limitedTransport, cfg.RateLimitMaxWait,
+		func(ctx context.Context, attempt int, wait time.Duration) {
+			message := fmt.Sprintf("GitHub rate limit hit, retrying in %s (attempt %d)", wait.Round(time.Second), attempt)
+			github.NotifyProgress(ctx, float64(attempt), 0, message)
+			github.LogToClient(ctx, mcp.LoggingLevelWarning, github.ClientLoggerRateLimit, message, map[string]any{
+				"attempt": attempt,
+				"wait_ms": wait.Milliseconds(),
+			})
+		})
+
+	// Record the pagination links of list responses, so that tool results can tell whether there are more pages.
+	paginationTransport := transport.NewPaginationTransport(rateLimitTransport, github.RecordPageLinks)
+
+	// Learn the permissions the credential lacks from the requests GitHub forbids, so that doomed calls aren't repeated.
+	authMode := github.TokenAuthMode(cfg.Token)
+	if cfg.InstallationTokens != nil {
+		authMode = github.AuthModeGitHubAppInstallation
+	}
+	grants := github.NewCredentialGrants(authMode)
+	if cfg.InstallationTokens != nil {
+		grants.SetInstallationPermissions(func() map[string]string {
+			return cfg.InstallationTokens.Permissions(cfg.InstallationID)
+		})
+	}
+	permissionTransport := transport.NewPermissionDeniedTransport(paginationTransport, grants.RecordDenial)
+
 	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
+	var restClient *gogithub.Client
+	if cfg.InstallationTokens != nil {
+		restClient = gogithub.NewClient(&http.Client{
+			Transport: transport.NewInstallationAuthTransport(permissionTransport, cfg.InstallationTokens, cfg.InstallationID),
+		})
+	} else {
+		restClient = gogithub.NewClient(&http.Client{Transport: permissionTransport}).WithAuthToken(cfg.Token)
+	}
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
 
-	// Construct our GraphQL client
-	// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
-	// did the necessary API host parsing so that github.com will return the correct URL anyway.
-	gqlHTTPClient := &http.Client{
-		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
-			token:     cfg.Token,
-		},
-	} // We're going to wrap the Transport later in beforeInit
-	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
+	getClient := func(_ context.Context) (*gogithub.Client, error) {
+		return restClient, nil // closing over client
+	}
 
 	// When a client send an initialize request, update the user agent to include the client info.
 	beforeInit := func(_ context.Context, _ any, message *mcp.InitializeRequest) {
@@ -87,11 +253,6 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		)
 
 		restClient.UserAgent = userAgent
-
-		gqlHTTPClient.Transport = &userAgentTransport{
-			transport: gqlHTTPClient.Transport,
-			agent:     userAgent,
-		}
 	}
 
 	hooks := &server.Hooks{
@@ -119,33 +280,141 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// Generate instructions based on enabled toolsets
 	instructions := github.GenerateInstructions(enabledToolsets)
 
-	ghServer := github.NewServer(cfg.Version,
+	// The toolset group is created once the server is, but the roots of the client are checked against its tools
+	var tsg *toolsets.ToolsetGroup
+
+	policies := cfg.Policies
+	if policies == nil {
+		policies = github.NewPolicyStore(github.Policy{
+			RestrictToRoots:         cfg.RestrictToRoots,
+			ConfirmTools:            cfg.ConfirmTools,
+			AllowRepositoryDeletion: cfg.AllowRepositoryDeletion,
+			PrivacyMode:             cfg.PrivacyMode,
+		})
+	}
+	policy := policies.Load()
+
+	serverOpts := []server.ServerOption{
 		server.WithInstructions(instructions),
 		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(github.ProgressTokenMiddleware),
+	}
+	if cfg.Roots != nil {
+		// Defaults are filled in before the permission gate, which checks the repository the call ends up targeting
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.RootsMiddleware(cfg.Roots, policies,
+			func(name string) (server.ServerTool, bool) { return tsg.GetActiveTool(name) })))
+	}
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.PermissionGateMiddleware(grants, getClient,
+		func(name string) (server.ServerTool, bool) { return tsg.GetActiveTool(name) })))
+	serverOpts = append(serverOpts,
+		// The user is only asked once the permission gate let the call through, so that doomed calls aren't confirmed
+		server.WithToolHandlerMiddleware(github.ConfirmationMiddleware(cfg.Elicitor, policies,
+			func(name string) (server.ServerTool, bool) { return tsg.GetActiveTool(name) })),
+		server.WithToolHandlerMiddleware(github.PaginationHintsMiddleware(
+			func(name string) (server.ServerTool, bool) { return tsg.GetActiveTool(name) })),
+		server.WithToolHandlerMiddleware(github.FlattenOutputMiddleware),
 	)
+	if len(cfg.ToolRetryAttempts) > 0 {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.ToolRetryMiddleware(cfg.ToolRetryAttempts)))
+	}
+	if cfg.OfflineCacheMaxAge > 0 {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.StaleResultMiddleware))
+	}
 
-	getClient := func(_ context.Context) (*gogithub.Client, error) {
-		return restClient, nil // closing over client
+	var resultStore *github.ResultStore
+	if cfg.SummarizeThreshold > 0 {
+		resultStore = github.NewResultStore(maxStoredResults)
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.SummarizeLargeResultsMiddleware(resultStore, cfg.SummarizeThreshold)))
 	}
 
-	getGQLClient := func(_ context.Context) (*githubv4.Client, error) {
-		return gqlClient, nil // closing over client
+	// Redacting and minimizing run inside summarization so that summaries never see the personal data or boilerplate
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.RedactPersonalDataMiddleware(policies)))
+	if cfg.MinimalOutput {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.MinimalOutputMiddleware))
 	}
 
-	getRawClient := func(ctx context.Context) (*raw.Client, error) {
-		client, err := getClient(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	ghServer := github.NewServer(cfg.Version, serverOpts...)
+
+	// Full results are only stored once summarized, so they are only offered to clients supporting sampling.
+	// Registering them before the initialize response is built still declares the resources capability.
+	if resultStore != nil && !cfg.DisableResources {
+		var registerFullResults sync.Once
+		hooks.AddBeforeInitialize(func(_ context.Context, _ any, message *mcp.InitializeRequest) {
+			if !github.ClientFeaturesOf(message.Params.Capabilities).Sampling {
+				return
+			}
+			registerFullResults.Do(func() {
+				ghServer.EnableSampling()
+				ghServer.AddResourceTemplate(github.FullResultResourceTemplate(resultStore, cfg.Translator))
+			})
+		})
+	}
+
+	// Clients other than the REST one are only needed by some toolsets, so they are constructed on first use.
+	// By then the initialize request has set the user agent to include the client info.
+	getGQLClient := lazyClient(func() *githubv4.Client {
+		// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
+		// did the necessary API host parsing so that github.com will return the correct URL anyway.
+		var authTransport http.RoundTripper = &bearerAuthTransport{
+			transport: rateLimitTransport,
+			token:     cfg.Token,
+		}
+		if cfg.InstallationTokens != nil {
+			authTransport = transport.NewInstallationAuthTransport(rateLimitTransport, cfg.InstallationTokens, cfg.InstallationID)
+		}
+		gqlHTTPClient := &http.Client{
+			Transport: &userAgentTransport{
+				transport: authTransport,
+				agent:     restClient.UserAgent,
+			},
 		}
-		return raw.NewClient(client, apiHost.rawURL), nil // closing over client
+		return githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
+	})
+
+	getRawClient := lazyClient(func() *raw.Client {
+		return raw.NewClient(restClient, apiHost.rawURL)
+	})
+
+	// LFS objects live in storage that authenticates requests on its own, so they are
+	// transferred without the token the REST client adds to every request.
+	getLFSClient := lazyClient(func() *lfs.Client {
+		return lfs.NewClient(restClient, apiHost.lfsURL, &http.Client{Transport: rateLimitTransport})
+	})
+
+	// The server is released on github.com, whichever host it targets, so its releases are read without the token,
+	// but through the connections and retries of the API clients
+	getReleaseClient := func(_ context.Context) (*gogithub.Client, error) {
+		return newReleaseClient(retryTransport, cfg.Version), nil
 	}
 
 	// Create default toolsets
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentWindowSize)
+	tsg = github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, getLFSClient, getReleaseClient, cfg.Translator, cfg.ContentWindowSize, github.ServerInfo{
+		Version:   cfg.Version,
+		Commit:    cfg.Commit,
+		BuildDate: cfg.BuildDate,
+		Host:      apiHost.baseRESTURL.String(),
+		AuthMode:  authMode,
+	})
+	// Repositories are only deleted when the configuration names which ones may be. Once offered, the tool stays so
+	// until a restart, refusing every deletion when a reload empties the allowlist.
+	if len(policy.AllowRepositoryDeletion) > 0 {
+		repos, err := tsg.GetToolset("repos")
+		if err != nil {
+			return nil, nil, err
+		}
+		repos.AddWriteTools(toolsets.NewServerTool(github.DeleteRepository(getClient, policies, cfg.Translator)))
+	}
+	// Tools whose endpoints don't accept the token are hidden rather than left to fail
+	if unsupported := github.UnsupportedTools(tsg, authMode); len(unsupported) > 0 {
+		tsg.RemoveTools(unsupported...)
+	}
+	if cfg.DisableResources {
+		tsg.DisableResourceTemplates()
+	}
 	err = tsg.EnableToolsets(enabledToolsets)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
+		return nil, nil, fmt.Errorf("failed to enable toolsets: %w", err)
 	}
 
 	// Register all mcp functionality with the server
@@ -156,19 +425,29 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		dynamic.RegisterTools(ghServer)
 	}
 
-	return ghServer, nil
+	return ghServer, tsg, nil
 }
 
 type StdioServerConfig struct {
 	// Version of the server
 	Version string
 
+	// Commit and BuildDate identify the build of the server
+	Commit    string
+	BuildDate string
+
 	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
 	Host string
 
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// AppID, AppInstallationID and AppPrivateKey authenticate as a GitHub App installation instead of with Token,
+	// when AppID is set
+	AppID             int64
+	AppInstallationID int64
+	AppPrivateKey     []byte
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -192,6 +471,110 @@ type StdioServerConfig struct {
 
 	// Content window size
 	ContentWindowSize int
+
+	// RateLimitMaxWait is the longest a single request may wait for GitHub rate limits to reset
+	RateLimitMaxWait time.Duration
+
+	// RetryMaxAttempts is the number of times idempotent requests failing transiently are attempted
+	RetryMaxAttempts int
+
+	// ToolRetryAttempts overrides the attempts of the requests made by the listed tools
+	ToolRetryAttempts map[string]int
+
+	// CircuitBreakerThreshold is the number of consecutive failures from a host after which requests to it fail fast
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long requests to a failing host fail fast
+	CircuitBreakerCooldown time.Duration
+
+	// OfflineCacheMaxAge is the age up to which cached responses are served when GitHub can't be reached
+	OfflineCacheMaxAge time.Duration
+
+	// OfflineCacheSize is the number of responses kept for the offline cache
+	OfflineCacheSize int
+
+	// Pool tunes the connections to GitHub
+	Pool transport.PoolConfig
+
+	// Transport, when set, sends the requests to GitHub in place of the connection pool, e.g. in tests
+	Transport http.RoundTripper
+
+	// MaxConcurrentRequests caps the GitHub requests in flight across the server
+	MaxConcurrentRequests int
+
+	// MaxConcurrentRequestsPerHost caps the GitHub requests in flight to a single host
+	MaxConcurrentRequestsPerHost int
+
+	// RequestQueueTimeout is the longest a request may wait for a free slot
+	RequestQueueTimeout time.Duration
+
+	// SummarizeThreshold is the length in characters above which tool results are summarized
+	SummarizeThreshold int
+
+	// PrivacyMode strips personal data of users from tool results
+	PrivacyMode bool
+
+	// MinimalOutput strips boilerplate from tool results
+	MinimalOutput bool
+
+	// DisableResources skips registering resource templates
+	DisableResources bool
+
+	// RestrictToRoots fails the tool calls naming repositories outside the roots the client declared
+	RestrictToRoots bool
+
+	// ConfirmTools lists the tools whose calls the user must confirm through elicitation
+	ConfirmTools []string
+
+	// AllowRepositoryDeletion lists the repositories delete_repository may delete
+	AllowRepositoryDeletion []string
+
+	// CheckForUpdates logs at startup when a newer version of the server has been released
+	CheckForUpdates bool
+
+	// WebhookListenAddr is the address to receive GitHub webhooks on, empty disables the listener
+	WebhookListenAddr string
+
+	// WebhookSecret is the secret used to verify webhook deliveries
+	WebhookSecret string
+
+	// WebhookRepos restricts forwarded webhook events to these repositories
+	WebhookRepos []string
+
+	// WebhookEvents restricts forwarded webhook events to these event types
+	WebhookEvents []string
+
+	// LogLevel is the minimum level of logged messages (debug, info, warn or error),
+	// empty means debug when logging to a file and info otherwise
+	LogLevel string
+
+	// LoadReloadableConfig reads the settings to apply when the configuration is reloaded,
+	// either on SIGHUP or when ConfigChanged fires, nil disables reloading
+	LoadReloadableConfig func() (ReloadableConfig, error)
+
+	// ConfigChanged signals that the configuration file changed
+	ConfigChanged <-chan struct{}
+}
+
+// ReloadableConfig holds the settings that can change without restarting the server.
+type ReloadableConfig struct {
+	// EnabledToolsets is a list of toolsets to enable
+	EnabledToolsets []string
+
+	// LogLevel is the minimum level of logged messages, empty keeps the current level
+	LogLevel string
+
+	// Policy replaces the policy the tool calls follow
+	Policy github.Policy
+
+	// WebhookRepos restricts forwarded webhook events to these repositories
+	WebhookRepos []string
+
+	// WebhookEvents restricts forwarded webhook events to these event types
+	WebhookEvents []string
+
+	// RestartRequired names the other settings that changed, which only take effect after a restart
+	RestartRequired []string
 }
 
 // RunStdioServer is not concurrent safe.
@@ -202,37 +585,47 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
-	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:           cfg.Version,
-		Host:              cfg.Host,
-		Token:             cfg.Token,
-		EnabledToolsets:   cfg.EnabledToolsets,
-		DynamicToolsets:   cfg.DynamicToolsets,
-		ReadOnly:          cfg.ReadOnly,
-		Translator:        t,
-		ContentWindowSize: cfg.ContentWindowSize,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create MCP server: %w", err)
-	}
-
-	stdioServer := server.NewStdioServer(ghServer)
-
 	var slogHandler slog.Handler
 	var logOutput io.Writer
+	logLevel := new(slog.LevelVar)
 	if cfg.LogFilePath != "" {
 		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
 		if err != nil {
 			return fmt.Errorf("failed to open log file: %w", err)
 		}
 		logOutput = file
-		slogHandler = slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelDebug})
+		logLevel.Set(slog.LevelDebug)
 	} else {
 		logOutput = os.Stderr
-		slogHandler = slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelInfo})
+		logLevel.Set(slog.LevelInfo)
+	}
+	if cfg.LogLevel != "" {
+		if err := logLevel.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+			return fmt.Errorf("invalid log level: %w", err)
+		}
 	}
+	slogHandler = slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: logLevel})
 	logger := slog.New(slogHandler)
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly)
+
+	apiHost, err := parseAPIHost(cfg.Host)
+	if err != nil {
+		return fmt.Errorf("failed to parse API host: %w", err)
+	}
+	// Roots are the web URLs of repositories, such as https://github.com/octo-org/octo-repo
+	roots := github.NewRoots(apiHost.lfsURL.Hostname())
+
+	// The server and the startup checks share one pool of connections to GitHub
+	cfg.Transport = newPooledTransport(cfg.Transport, cfg.Pool)
+
+	client := newStdioClient(logger)
+	policies := github.NewPolicyStore(stdioPolicy(cfg))
+	ghServer, tsg, installationTokens, err := newStdioMCPServer(cfg, t, roots, client, policies)
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	stdioServer := server.NewStdioServer(ghServer)
 	stdLogger := log.New(logOutput, stdioServerLogPrefix, 0)
 	stdioServer.SetErrorLogger(stdLogger)
 
@@ -242,7 +635,7 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	}
 
 	// Start listening for messages
-	errC := make(chan error, 1)
+	errC := make(chan error, 2)
 	go func() {
 		in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
 
@@ -250,11 +643,63 @@ func RunStdioServer(cfg StdioServerConfig) error {
 			loggedIO := mcplog.NewIOLogger(in, out, logger)
 			in, out = loggedIO, loggedIO
 		}
+		client.registerRoots(ghServer, roots)
+		in, out = client.wrap(in, out)
+
 		// enable GitHub errors in the context
 		ctx := errors.ContextWithGitHubErrors(ctx)
 		errC <- stdioServer.Listen(ctx, in, out)
 	}()
 
+	var webhookHandler *webhooks.Handler
+	if cfg.WebhookListenAddr != "" {
+		var webhookServer *http.Server
+		webhookServer, webhookHandler, err = newWebhookServer(ghServer, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create webhook listener: %w", err)
+		}
+		go func() {
+			if err := webhookServer.ListenAndServe(); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+				errC <- fmt.Errorf("webhook listener failed: %w", err)
+			}
+		}()
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = webhookServer.Shutdown(shutdownCtx)
+		}()
+		logger.Info("listening for webhooks", "addr", cfg.WebhookListenAddr, "repos", cfg.WebhookRepos, "events", cfg.WebhookEvents)
+	}
+
+	if cfg.CheckForUpdates {
+		go checkForUpdates(ctx, cfg, logger)
+	}
+	if installationTokens != nil {
+		// Refresh the installation token ahead of its expiry, so that tool calls never wait for the exchange
+		go installationTokens.KeepFresh(ctx, cfg.AppInstallationID, func(err error) {
+			logger.Warn("failed to refresh GitHub App installation token", "error", err)
+		})
+	} else {
+		go validateTokenScopes(ctx, cfg, tsg, logger)
+	}
+
+	if cfg.LoadReloadableConfig != nil {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-hup:
+				case <-cfg.ConfigChanged:
+				}
+				reloadConfig(ghServer, tsg, policies, webhookHandler, cfg, logLevel, logger)
+			}
+		}()
+	}
+
 	// Output github-mcp-server string
 	_, _ = fmt.Fprintf(os.Stderr, "GitHub MCP Server running on stdio\n")
 
@@ -272,11 +717,209 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	return nil
 }
 
+// newStdioMCPServer creates the server with the settings of the stdio server, along with the source of its
+// installation tokens when it authenticates as a GitHub App installation. Tool calls are scoped to roots when set,
+// confirmed through elicitor when it is, and follow the policy of policies, or the one of cfg when it is nil.
+func newStdioMCPServer(cfg StdioServerConfig, t translations.TranslationHelperFunc, roots *github.Roots, elicitor github.Elicitor, policies *github.PolicyStore) (*server.MCPServer, *toolsets.ToolsetGroup, *transport.InstallationTokenSource, error) {
+	var installationTokens *transport.InstallationTokenSource
+	if cfg.AppID != 0 {
+		apiHost, err := parseAPIHost(cfg.Host)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to parse API host: %w", err)
+		}
+		installationTokens, err = transport.NewInstallationTokenSource(cfg.AppID, cfg.AppPrivateKey, apiHost.baseRESTURL, cfg.Transport)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	ghServer, tsg, err := newMCPServer(MCPServerConfig{
+		Version:                      cfg.Version,
+		Commit:                       cfg.Commit,
+		BuildDate:                    cfg.BuildDate,
+		Host:                         cfg.Host,
+		Token:                        cfg.Token,
+		InstallationTokens:           installationTokens,
+		InstallationID:               cfg.AppInstallationID,
+		EnabledToolsets:              cfg.EnabledToolsets,
+		DynamicToolsets:              cfg.DynamicToolsets,
+		ReadOnly:                     cfg.ReadOnly,
+		Translator:                   t,
+		ContentWindowSize:            cfg.ContentWindowSize,
+		RateLimitMaxWait:             cfg.RateLimitMaxWait,
+		RetryMaxAttempts:             cfg.RetryMaxAttempts,
+		ToolRetryAttempts:            cfg.ToolRetryAttempts,
+		CircuitBreakerThreshold:      cfg.CircuitBreakerThreshold,
+		CircuitBreakerCooldown:       cfg.CircuitBreakerCooldown,
+		OfflineCacheMaxAge:           cfg.OfflineCacheMaxAge,
+		OfflineCacheSize:             cfg.OfflineCacheSize,
+		Pool:                         cfg.Pool,
+		Transport:                    cfg.Transport,
+		MaxConcurrentRequests:        cfg.MaxConcurrentRequests,
+		MaxConcurrentRequestsPerHost: cfg.MaxConcurrentRequestsPerHost,
+		RequestQueueTimeout:          cfg.RequestQueueTimeout,
+		SummarizeThreshold:           cfg.SummarizeThreshold,
+		PrivacyMode:                  cfg.PrivacyMode,
+		MinimalOutput:                cfg.MinimalOutput,
+		DisableResources:             cfg.DisableResources,
+		Roots:                        roots,
+		RestrictToRoots:              cfg.RestrictToRoots,
+		ConfirmTools:                 cfg.ConfirmTools,
+		Elicitor:                     elicitor,
+		AllowRepositoryDeletion:      cfg.AllowRepositoryDeletion,
+		Policies:                     policies,
+	})
+	return ghServer, tsg, installationTokens, err
+}
+
+// stdioPolicy returns the policy set by the settings of the stdio server.
+func stdioPolicy(cfg StdioServerConfig) github.Policy {
+	return github.Policy{
+		RestrictToRoots:         cfg.RestrictToRoots,
+		ConfirmTools:            cfg.ConfirmTools,
+		AllowRepositoryDeletion: cfg.AllowRepositoryDeletion,
+		PrivacyMode:             cfg.PrivacyMode,
+	}
+}
+
+// newReleaseClient creates the client reading the releases of the server on github.com, without the token.
+func newReleaseClient(transport http.RoundTripper, version string) *gogithub.Client {
+	client := gogithub.NewClient(&http.Client{Transport: transport})
+	client.UserAgent = fmt.Sprintf("github-mcp-server/%s", version)
+	return client
+}
+
+// checkForUpdates logs when a newer version of the server than the running one has been released.
+func checkForUpdates(ctx context.Context, cfg StdioServerConfig, logger *slog.Logger) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	version := cfg.Version
+	client := newReleaseClient(newRetryTransport(newPooledTransport(cfg.Transport, cfg.Pool), cfg.RetryMaxAttempts), version)
+	check, _, err := github.CheckForUpdates(ctx, client, version)
+	if err != nil {
+		logger.Warn("failed to check for updates", "error", err)
+		return
+	}
+	if check.UpdateAvailable {
+		logger.Info("a newer version of the server is available", "version", check.LatestVersion, "current", version, "url", check.ReleaseURL)
+		return
+	}
+	logger.Debug(check.Message)
+}
+
+// validateTokenScopes logs the enabled tools that will fail because the token is missing scopes they need, so
+// that users find out at startup rather than when a call fails mid-task.
+func validateTokenScopes(ctx context.Context, cfg StdioServerConfig, tsg *toolsets.ToolsetGroup, logger *slog.Logger) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	apiHost, err := parseAPIHost(cfg.Host)
+	if err != nil {
+		return
+	}
+	// Requests go through the connections and retries of the API clients
+	httpClient := &http.Client{Transport: newRetryTransport(newPooledTransport(cfg.Transport, cfg.Pool), cfg.RetryMaxAttempts)}
+	client := gogithub.NewClient(httpClient).WithAuthToken(cfg.Token)
+	client.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
+	client.BaseURL = apiHost.baseRESTURL
+
+	validation, _, err := github.ValidateToken(ctx, client, tsg, github.TokenAuthMode(cfg.Token))
+	if err != nil {
+		logger.Warn("failed to validate token scopes", "error", err)
+		return
+	}
+	if !validation.ScopesListed {
+		logger.Debug(validation.Message)
+		return
+	}
+
+	// Tools missing the same scopes are logged together, as adding the scopes fixes them all
+	var order []string
+	byScopes := make(map[string][]string)
+	for _, issue := range validation.UnavailableTools {
+		scopes := strings.Join(issue.MissingScopes, ", ")
+		if _, ok := byScopes[scopes]; !ok {
+			order = append(order, scopes)
+		}
+		byScopes[scopes] = append(byScopes[scopes], issue.Tool)
+	}
+	for _, scopes := range order {
+		logger.Warn("tools will fail because the token is missing scopes", "missingScopes", scopes, "tools", byScopes[scopes])
+	}
+	for _, issue := range validation.LimitedTools {
+		logger.Info("tool is limited because the token is missing scopes", "tool", issue.Tool, "missingScopes", issue.MissingScopes, "neededFor", issue.Impact)
+	}
+}
+
+// reloadConfig applies the reloadable settings to the running server, keeping sessions open. The policy replaces
+// the one of policies, and the webhook filter the one of webhookHandler when the server receives webhooks.
+// Invalid settings are logged and leave the current ones in place.
+func reloadConfig(ghServer *server.MCPServer, tsg *toolsets.ToolsetGroup, policies *github.PolicyStore, webhookHandler *webhooks.Handler, cfg StdioServerConfig, logLevel *slog.LevelVar, logger *slog.Logger) {
+	reloaded, err := cfg.LoadReloadableConfig()
+	if err != nil {
+		logger.Error("failed to reload configuration", "error", err)
+		return
+	}
+
+	if reloaded.LogLevel != "" {
+		if err := logLevel.UnmarshalText([]byte(reloaded.LogLevel)); err != nil {
+			logger.Error("failed to reload log level", "error", err)
+		}
+	}
+	if len(reloaded.RestartRequired) > 0 {
+		logger.Warn("changed settings are only applied after a restart", "settings", reloaded.RestartRequired)
+	}
+	policies.Store(reloaded.Policy)
+	// delete_repository is only registered at startup, later allowlists apply to it once it is offered
+	if len(cfg.AllowRepositoryDeletion) == 0 && len(reloaded.Policy.AllowRepositoryDeletion) > 0 {
+		logger.Warn("delete_repository is only offered after a restart, as the server started without allow-repository-deletion")
+	}
+	if webhookHandler != nil {
+		webhookHandler.SetFilter(webhooks.Filter{Repos: reloaded.WebhookRepos, Events: reloaded.WebhookEvents})
+	}
+
+	// With dynamic toolsets, the enabled toolsets are managed by the client
+	if cfg.DynamicToolsets {
+		logger.Info("configuration reloaded, toolset changes are ignored with dynamic toolsets", "logLevel", logLevel.Level())
+		return
+	}
+	if err := tsg.ApplyToolsets(ghServer, reloaded.EnabledToolsets); err != nil {
+		logger.Error("failed to reload toolsets", "error", err)
+		return
+	}
+	logger.Info("configuration reloaded", "toolsets", reloaded.EnabledToolsets, "logLevel", logLevel.Level())
+}
+
+// newWebhookServer creates an HTTP server receiving GitHub webhooks on /webhook and
+// forwarding matching events to the connected clients as notifications, along with its handler.
+func newWebhookServer(ghServer *server.MCPServer, cfg StdioServerConfig) (*http.Server, *webhooks.Handler, error) {
+	filter := webhooks.Filter{Repos: cfg.WebhookRepos, Events: cfg.WebhookEvents}
+	handler, err := webhooks.NewHandler(cfg.WebhookSecret, filter, func(e webhooks.Event) {
+		ghServer.SendNotificationToAllClients(webhooks.NotificationMethod, webhooks.NotificationParams(e))
+		for _, uri := range webhooks.UpdatedResourceURIs(e) {
+			ghServer.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/webhook", handler)
+	return &http.Server{
+		Addr:              cfg.WebhookListenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}, handler, nil
+}
+
 type apiHost struct {
 	baseRESTURL *url.URL
 	graphqlURL  *url.URL
 	uploadURL   *url.URL
 	rawURL      *url.URL
+	lfsURL      *url.URL
 }
 
 func newDotcomHost() (apiHost, error) {
@@ -300,11 +943,17 @@ func newDotcomHost() (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse dotcom Raw URL: %w", err)
 	}
 
+	lfsURL, err := url.Parse("https://github.com/")
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse dotcom LFS URL: %w", err)
+	}
+
 	return apiHost{
 		baseRESTURL: baseRestURL,
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		lfsURL:      lfsURL,
 	}, nil
 }
 
@@ -339,11 +988,17 @@ func newGHECHost(hostname string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse GHEC Raw URL: %w", err)
 	}
 
+	lfsURL, err := url.Parse(fmt.Sprintf("https://%s/", u.Hostname()))
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse GHEC LFS URL: %w", err)
+	}
+
 	return apiHost{
 		baseRESTURL: restURL,
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		lfsURL:      lfsURL,
 	}, nil
 }
 
@@ -371,12 +1026,17 @@ func newGHESHost(hostname string) (apiHost, error) {
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES Raw URL: %w", err)
 	}
+	lfsURL, err := url.Parse(fmt.Sprintf("%s://%s/", u.Scheme, u.Hostname()))
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse GHES LFS URL: %w", err)
+	}
 
 	return apiHost{
 		baseRESTURL: restURL,
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		lfsURL:      lfsURL,
 	}, nil
 }
 
@@ -406,6 +1066,14 @@ func parseAPIHost(s string) (apiHost, error) {
 	return newGHESHost(s)
 }
 
+// lazyClient returns a function constructing a client on its first call, and returning that client afterwards.
+func lazyClient[T any](construct func() T) func(context.Context) (T, error) {
+	get := sync.OnceValue(construct)
+	return func(_ context.Context) (T, error) {
+		return get(), nil
+	}
+}
+
 type userAgentTransport struct {
 	transport http.RoundTripper
 	agent     string
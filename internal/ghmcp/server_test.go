@@ -0,0 +1,140 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reloadConfig(t *testing.T) {
+	reloadedPolicy := github.Policy{
+		RestrictToRoots:         true,
+		ConfirmTools:            []string{"delete_file"},
+		AllowRepositoryDeletion: []string{"octo-org/*"},
+		PrivacyMode:             true,
+	}
+
+	tests := []struct {
+		name              string
+		started           StdioServerConfig
+		reloaded          ReloadableConfig
+		loadErr           error
+		expectedPolicy    github.Policy
+		expectedDeletable bool
+		expectedLog       []string
+	}{
+		{
+			name:              "policy is replaced",
+			started:           StdioServerConfig{AllowRepositoryDeletion: []string{"octo-org/scratch"}},
+			reloaded:          ReloadableConfig{EnabledToolsets: []string{"repos"}, Policy: reloadedPolicy},
+			expectedPolicy:    reloadedPolicy,
+			expectedDeletable: true,
+			expectedLog:       []string{"configuration reloaded"},
+		},
+		{
+			name:              "emptied allowlist keeps delete_repository offered",
+			started:           StdioServerConfig{AllowRepositoryDeletion: []string{"octo-org/scratch"}},
+			reloaded:          ReloadableConfig{EnabledToolsets: []string{"repos"}},
+			expectedDeletable: true,
+		},
+		{
+			name:           "allowlist added at runtime needs a restart to offer delete_repository",
+			reloaded:       ReloadableConfig{EnabledToolsets: []string{"repos"}, Policy: reloadedPolicy},
+			expectedPolicy: reloadedPolicy,
+			expectedLog:    []string{"delete_repository is only offered after a restart"},
+		},
+		{
+			name:           "invalid configuration keeps the policy",
+			started:        StdioServerConfig{PrivacyMode: true},
+			loadErr:        errors.New("failed to read config file"),
+			expectedPolicy: github.Policy{PrivacyMode: true},
+			expectedLog:    []string{"failed to reload configuration"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.started
+			cfg.Version = "test"
+			cfg.Token = "ghp_test"
+			cfg.EnabledToolsets = []string{"repos"}
+			cfg.WebhookSecret = "s3cr3t"
+			cfg.LoadReloadableConfig = func() (ReloadableConfig, error) { return tc.reloaded, tc.loadErr }
+
+			policies := github.NewPolicyStore(stdioPolicy(cfg))
+			ghServer, tsg, _, err := newStdioMCPServer(cfg, translations.NullTranslationHelper, nil, nil, policies)
+			require.NoError(t, err)
+			_, webhookHandler, err := newWebhookServer(ghServer, cfg)
+			require.NoError(t, err)
+
+			var logged bytes.Buffer
+			reloadConfig(ghServer, tsg, policies, webhookHandler, cfg, new(slog.LevelVar), slog.New(slog.NewTextHandler(&logged, nil)))
+
+			assert.Equal(t, tc.expectedPolicy, *policies.Load())
+			_, deletable := tsg.GetActiveTool("delete_repository")
+			assert.Equal(t, tc.expectedDeletable, deletable)
+			for _, expected := range tc.expectedLog {
+				assert.Contains(t, logged.String(), expected)
+			}
+		})
+	}
+}
+
+func Test_validateTokenScopes(t *testing.T) {
+	var requests atomic.Int32
+	cfg := StdioServerConfig{
+		Version:          "test",
+		Token:            "ghp_test",
+		EnabledToolsets:  []string{"actions"},
+		RetryMaxAttempts: 2,
+		Transport: mock.NewMockedHTTPClient(mock.WithRequestMatchHandler(mock.GetRateLimit,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				// The first attempt fails, to check that requests are retried like those of the API clients
+				if requests.Add(1) == 1 {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				w.Header().Set(github.OAuthScopesHeader, "read:org")
+				_, _ = w.Write([]byte(`{"resources":{}}`))
+			}),
+		)).Transport,
+	}
+	_, tsg, _, err := newStdioMCPServer(cfg, translations.NullTranslationHelper, nil, nil, nil)
+	require.NoError(t, err)
+
+	var logged bytes.Buffer
+	validateTokenScopes(context.Background(), cfg, tsg, slog.New(slog.NewTextHandler(&logged, nil)))
+
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Contains(t, logged.String(), "tools will fail because the token is missing scopes")
+	assert.Contains(t, logged.String(), "missingScopes=repo")
+}
+
+func Test_checkForUpdates(t *testing.T) {
+	cfg := StdioServerConfig{
+		Version: "v1.0.0",
+		Transport: mock.NewMockedHTTPClient(mock.WithRequestMatchHandler(mock.GetReposReleasesLatestByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Releases are read without the token of the server
+				assert.Empty(t, r.Header.Get("Authorization"))
+				assert.Equal(t, "github-mcp-server/v1.0.0", r.Header.Get("User-Agent"))
+				_, _ = w.Write([]byte(`{"tag_name":"v1.1.0","html_url":"https://github.com/github/github-mcp-server/releases/tag/v1.1.0"}`))
+			}),
+		)).Transport,
+	}
+
+	var logged bytes.Buffer
+	checkForUpdates(context.Background(), cfg, slog.New(slog.NewTextHandler(&logged, nil)))
+
+	assert.Contains(t, logged.String(), `msg="a newer version of the server is available" version=v1.1.0 current=v1.0.0`)
+}
@@ -0,0 +1,242 @@
+package ghmcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// stdioClientRequestPrefix starts the IDs of the requests the server sends the client, which tells their
+// responses apart from the responses to the requests of the stdio server, such as sampling requests.
+const stdioClientRequestPrefix = "github-mcp-server-"
+
+// rootsRequestTimeout bounds how long the client may take to list its roots.
+const rootsRequestTimeout = 30 * time.Second
+
+// stdioClient sends requests to the client of the stdio server, such as roots/list and elicitation/create
+// requests. The stdio server of mcp-go can't send requests of its own to the client other than sampling requests,
+// so the requests are written to the client alongside its messages and their responses are taken out of the
+// stream of messages from the client.
+type stdioClient struct {
+	logger *slog.Logger
+
+	mu  sync.Mutex
+	out io.Writer
+
+	nextID    atomic.Int64
+	pendingMu sync.Mutex
+	pending   map[string]chan stdioClientResponse
+
+	// elicitation is set when the client declared support for elicitation when initializing, which mcp-go
+	// doesn't keep track of
+	elicitation atomic.Bool
+}
+
+type stdioClientResponse struct {
+	result json.RawMessage
+	err    error
+}
+
+func newStdioClient(logger *slog.Logger) *stdioClient {
+	return &stdioClient{logger: logger, pending: make(map[string]chan stdioClientResponse)}
+}
+
+// wrap returns the streams the stdio server reads the messages of the client from and writes its own to, taking
+// the responses to the requests of the stdio client out of in.
+func (c *stdioClient) wrap(in io.Reader, out io.Writer) (io.Reader, io.Writer) {
+	c.out = out
+
+	reader, writer := io.Pipe()
+	go func() {
+		lines := bufio.NewReader(in)
+		for {
+			line, err := lines.ReadString('\n')
+			if line != "" && !c.handleLine(line) {
+				if _, werr := io.WriteString(writer, line); werr != nil {
+					return
+				}
+			}
+			if err != nil {
+				c.closePending(err)
+				_ = writer.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return reader, c
+}
+
+// Write writes a message to the client, keeping it from interleaving with the requests of the stdio client.
+func (c *stdioClient) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.out.Write(p)
+}
+
+// request sends a request to the client and waits for its response.
+func (c *stdioClient) request(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	id := fmt.Sprintf("%s%d", stdioClientRequestPrefix, c.nextID.Add(1))
+	request := map[string]any{"jsonrpc": mcp.JSONRPC_VERSION, "id": id, "method": method}
+	if params != nil {
+		request["params"] = params
+	}
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s request: %w", method, err)
+	}
+
+	responses := make(chan stdioClientResponse, 1)
+	c.pendingMu.Lock()
+	c.pending[id] = responses
+	c.pendingMu.Unlock()
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+	}()
+
+	if _, err := c.Write(append(encoded, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to send %s request: %w", method, err)
+	}
+	select {
+	case response := <-responses:
+		return response.result, response.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// handleLine hands a response to the request of the stdio client waiting for it, reporting whether the line was
+// one. It also records the capabilities the client declares when initializing.
+func (c *stdioClient) handleLine(line string) bool {
+	if strings.Contains(line, `"initialize"`) {
+		var initialize struct {
+			Method string `json:"method"`
+			Params struct {
+				Capabilities struct {
+					Elicitation json.RawMessage `json:"elicitation"`
+				} `json:"capabilities"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal([]byte(line), &initialize); err == nil && initialize.Method == string(mcp.MethodInitialize) {
+			c.elicitation.Store(len(initialize.Params.Capabilities.Elicitation) > 0 && string(initialize.Params.Capabilities.Elicitation) != "null")
+		}
+		return false
+	}
+
+	if !strings.Contains(line, stdioClientRequestPrefix) {
+		return false
+	}
+	var response struct {
+		ID     any             `json:"id"`
+		Method string          `json:"method"`
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(line), &response); err != nil || response.Method != "" {
+		return false
+	}
+	id, ok := response.ID.(string)
+	if !ok || !strings.HasPrefix(id, stdioClientRequestPrefix) {
+		return false
+	}
+
+	c.pendingMu.Lock()
+	responses, ok := c.pending[id]
+	c.pendingMu.Unlock()
+	if !ok {
+		// The request gave up waiting
+		return true
+	}
+	answer := stdioClientResponse{result: response.Result}
+	if response.Error != nil {
+		answer = stdioClientResponse{err: fmt.Errorf("%s (code %d)", response.Error.Message, response.Error.Code)}
+	}
+	select {
+	case responses <- answer:
+	default:
+		// The client already answered the request
+	}
+	return true
+}
+
+// closePending fails the requests waiting for a response once the client stops sending messages.
+func (c *stdioClient) closePending(err error) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	for id, responses := range c.pending {
+		select {
+		case responses <- stdioClientResponse{err: fmt.Errorf("the client closed the connection: %w", err)}:
+		default:
+		}
+		delete(c.pending, id)
+	}
+}
+
+// registerRoots requests the roots of the client once it is initialized, and again whenever they change.
+func (c *stdioClient) registerRoots(ghServer *server.MCPServer, roots *github.Roots) {
+	ghServer.AddNotificationHandler("notifications/initialized", func(ctx context.Context, _ mcp.JSONRPCNotification) {
+		if github.ClientFeaturesFromContext(ctx).Roots {
+			c.requestRoots(roots)
+		}
+	})
+	ghServer.AddNotificationHandler("notifications/roots/list_changed", func(_ context.Context, _ mcp.JSONRPCNotification) {
+		c.requestRoots(roots)
+	})
+}
+
+// requestRoots asks the client for its roots, which tool calls wait for from now on.
+func (c *stdioClient) requestRoots(roots *github.Roots) {
+	roots.Request()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), rootsRequestTimeout)
+		defer cancel()
+
+		raw, err := c.request(ctx, "roots/list", nil)
+		var result mcp.ListRootsResult
+		if err == nil {
+			err = json.Unmarshal(raw, &result)
+		}
+		if err != nil {
+			roots.Fail()
+			c.logger.Warn("failed to list the roots of the client", "error", err)
+			return
+		}
+		roots.Set(result.Roots)
+		c.logger.Debug("received the roots of the client", "roots", len(result.Roots))
+	}()
+}
+
+// Elicit asks the user of the client for input through an elicitation/create request, waiting for the answer
+// as long as the tool call lasts.
+func (c *stdioClient) Elicit(ctx context.Context, message string, requestedSchema map[string]any) (github.ElicitationResult, error) {
+	if !c.elicitation.Load() {
+		return github.ElicitationResult{}, github.ErrElicitationUnsupported
+	}
+	raw, err := c.request(ctx, "elicitation/create", map[string]any{
+		"message":         message,
+		"requestedSchema": requestedSchema,
+	})
+	if err != nil {
+		return github.ElicitationResult{}, err
+	}
+	var result github.ElicitationResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return github.ElicitationResult{}, fmt.Errorf("failed to parse the answer of the client: %w", err)
+	}
+	return result, nil
+}
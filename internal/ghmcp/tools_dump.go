@@ -0,0 +1,174 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	ToolsDumpFormatJSON     = "json"
+	ToolsDumpFormatMarkdown = "markdown"
+)
+
+type ToolsDumpConfig struct {
+	// Version of the server
+	Version string
+
+	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
+	Host string
+
+	// GitHub Token to authenticate with the GitHub API, which only decides the tools hidden for its kind of
+	// token as nothing is requested from GitHub
+	Token string
+
+	// EnabledToolsets is a list of toolsets to enable
+	EnabledToolsets []string
+
+	// Whether to enable dynamic toolsets
+	DynamicToolsets bool
+
+	// ReadOnly indicates if we should only list read-only tools
+	ReadOnly bool
+
+	// ContentWindowSize is the content window size, which some tool descriptions mention
+	ContentWindowSize int
+
+	// Format of the dump, json or markdown
+	Format string
+}
+
+// dumpedTool is a tool as the server lists it to clients.
+type dumpedTool struct {
+	Name         string              `json:"name"`
+	Description  string              `json:"description,omitempty"`
+	InputSchema  json.RawMessage     `json:"inputSchema"`
+	OutputSchema json.RawMessage     `json:"outputSchema,omitempty"`
+	Annotations  *mcp.ToolAnnotation `json:"annotations,omitempty"`
+}
+
+// RunToolsDump writes the tools a server with the configuration would list to clients, with their descriptions,
+// input and output schemas and annotations, as JSON or Markdown. The tools are taken from the server itself rather
+// than from the toolsets, so that the dump matches what clients see.
+func RunToolsDump(ctx context.Context, cfg ToolsDumpConfig, out io.Writer) error {
+	if cfg.Format != ToolsDumpFormatJSON && cfg.Format != ToolsDumpFormatMarkdown {
+		return fmt.Errorf("unknown format %q, expected %s or %s", cfg.Format, ToolsDumpFormatJSON, ToolsDumpFormatMarkdown)
+	}
+
+	t, _ := translations.TranslationHelper()
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:           cfg.Version,
+		Host:              cfg.Host,
+		Token:             cfg.Token,
+		EnabledToolsets:   cfg.EnabledToolsets,
+		DynamicToolsets:   cfg.DynamicToolsets,
+		ReadOnly:          cfg.ReadOnly,
+		Translator:        t,
+		ContentWindowSize: cfg.ContentWindowSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	response := ghServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	listed, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		return fmt.Errorf("failed to list tools: %v", response)
+	}
+	result, ok := listed.Result.(mcp.ListToolsResult)
+	if !ok {
+		return fmt.Errorf("failed to list tools, unexpected result %T", listed.Result)
+	}
+
+	tools := make([]dumpedTool, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		dumped, err := newDumpedTool(tool)
+		if err != nil {
+			return err
+		}
+		tools = append(tools, dumped)
+	}
+
+	if cfg.Format == ToolsDumpFormatMarkdown {
+		return writeToolsMarkdown(out, tools)
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(tools)
+}
+
+// newDumpedTool marshals the tool the way it's listed, which picks its raw or structured input schema.
+func newDumpedTool(tool mcp.Tool) (dumpedTool, error) {
+	encoded, err := json.Marshal(tool)
+	if err != nil {
+		return dumpedTool{}, fmt.Errorf("failed to marshal tool %s: %w", tool.Name, err)
+	}
+	var dumped dumpedTool
+	if err := json.Unmarshal(encoded, &dumped); err != nil {
+		return dumpedTool{}, fmt.Errorf("failed to unmarshal tool %s: %w", tool.Name, err)
+	}
+	return dumped, nil
+}
+
+func writeToolsMarkdown(out io.Writer, tools []dumpedTool) error {
+	var buf strings.Builder
+	buf.WriteString("# Tools\n")
+	for _, tool := range tools {
+		fmt.Fprintf(&buf, "\n## %s\n\n", tool.Name)
+		if tool.Annotations != nil && tool.Annotations.Title != "" {
+			fmt.Fprintf(&buf, "**%s**\n\n", tool.Annotations.Title)
+		}
+		if tool.Description != "" {
+			fmt.Fprintf(&buf, "%s\n\n", tool.Description)
+		}
+		if hints := formatToolHints(tool.Annotations); hints != "" {
+			fmt.Fprintf(&buf, "Annotations:\n\n%s\n", hints)
+		}
+		if err := writeSchemaBlock(&buf, "Input schema", tool.InputSchema); err != nil {
+			return fmt.Errorf("failed to format the input schema of %s: %w", tool.Name, err)
+		}
+		if tool.OutputSchema != nil {
+			if err := writeSchemaBlock(&buf, "Output schema", tool.OutputSchema); err != nil {
+				return fmt.Errorf("failed to format the output schema of %s: %w", tool.Name, err)
+			}
+		}
+	}
+	_, err := io.WriteString(out, buf.String())
+	return err
+}
+
+// formatToolHints lists the hints the annotations set, one per line.
+func formatToolHints(annotations *mcp.ToolAnnotation) string {
+	if annotations == nil {
+		return ""
+	}
+	var hints strings.Builder
+	for _, hint := range []struct {
+		name  string
+		value *bool
+	}{
+		{"read-only", annotations.ReadOnlyHint},
+		{"destructive", annotations.DestructiveHint},
+		{"idempotent", annotations.IdempotentHint},
+		{"open world", annotations.OpenWorldHint},
+	} {
+		if hint.value != nil {
+			fmt.Fprintf(&hints, "- %s: %t\n", hint.name, *hint.value)
+		}
+	}
+	return hints.String()
+}
+
+func writeSchemaBlock(buf *strings.Builder, title string, schema json.RawMessage) error {
+	indented, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(buf, "%s:\n\n```json\n%s\n```\n\n", title, indented)
+	return nil
+}
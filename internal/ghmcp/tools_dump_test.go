@@ -0,0 +1,89 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RunToolsDump(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RunToolsDump(context.Background(), ToolsDumpConfig{
+			Version:         "test",
+			Token:           "ghp_test",
+			EnabledToolsets: []string{"issues"},
+			ReadOnly:        true,
+			Format:          ToolsDumpFormatJSON,
+		}, &out))
+
+		var tools []dumpedTool
+		require.NoError(t, json.Unmarshal(out.Bytes(), &tools))
+		byName := make(map[string]dumpedTool, len(tools))
+		for _, tool := range tools {
+			byName[tool.Name] = tool
+		}
+
+		// Only the read-only tools are listed in read-only mode
+		require.Contains(t, byName, "get_issue")
+		assert.NotContains(t, byName, "create_issue")
+		issue := byName["get_issue"]
+		assert.NotEmpty(t, issue.Description)
+		require.NotNil(t, issue.Annotations)
+		assert.True(t, *issue.Annotations.ReadOnlyHint)
+
+		var schema struct {
+			Type     string   `json:"type"`
+			Required []string `json:"required"`
+		}
+		require.NoError(t, json.Unmarshal(issue.InputSchema, &schema))
+		assert.Equal(t, "object", schema.Type)
+		assert.ElementsMatch(t, []string{"owner", "repo", "issue_number"}, schema.Required)
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, RunToolsDump(context.Background(), ToolsDumpConfig{
+			Version:         "test",
+			Token:           "ghp_test",
+			EnabledToolsets: []string{"context"},
+			Format:          ToolsDumpFormatMarkdown,
+		}, &out))
+
+		assert.True(t, strings.HasPrefix(out.String(), "# Tools\n\n## "))
+		assert.Contains(t, out.String(), "\n## get_me\n\n**Get my user profile**\n\n")
+		assert.Contains(t, out.String(), "Annotations:\n\n- read-only: true\n")
+		assert.Contains(t, out.String(), "Input schema:\n\n```json\n{\n")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		var out bytes.Buffer
+		err := RunToolsDump(context.Background(), ToolsDumpConfig{EnabledToolsets: []string{"context"}, Format: "yaml"}, &out)
+		assert.EqualError(t, err, `unknown format "yaml", expected json or markdown`)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("unknown toolset", func(t *testing.T) {
+		var out bytes.Buffer
+		err := RunToolsDump(context.Background(), ToolsDumpConfig{EnabledToolsets: []string{"nope"}, Format: ToolsDumpFormatJSON}, &out)
+		assert.ErrorContains(t, err, "failed to create MCP server")
+		assert.Empty(t, out.String())
+	})
+}
+
+func Test_formatToolHints(t *testing.T) {
+	assert.Empty(t, formatToolHints(nil))
+	assert.Empty(t, formatToolHints(&mcp.ToolAnnotation{Title: "Get my user profile"}))
+	assert.Equal(t, "- read-only: false\n- destructive: true\n- open world: true\n", formatToolHints(&mcp.ToolAnnotation{
+		ReadOnlyHint:    github.ToBoolPtr(false),
+		DestructiveHint: github.ToBoolPtr(true),
+		OpenWorldHint:   github.ToBoolPtr(true),
+	}))
+}
@@ -3,6 +3,7 @@ package buffer
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 )
@@ -26,12 +27,19 @@ import (
 // The function uses a ring buffer to efficiently store only the last maxJobLogLines lines.
 // If the response contains more lines than maxJobLogLines, only the most recent lines are kept.
 func ProcessResponseAsRingBufferToEnd(httpResp *http.Response, maxJobLogLines int) (string, int, *http.Response, error) {
+	content, totalLines, err := ProcessAsRingBufferToEnd(httpResp.Body, maxJobLogLines)
+	return content, totalLines, httpResp, err
+}
+
+// ProcessAsRingBufferToEnd reads r line by line like ProcessResponseAsRingBufferToEnd, for logs that
+// don't come straight from an HTTP response, such as the files of a log archive.
+func ProcessAsRingBufferToEnd(r io.Reader, maxJobLogLines int) (string, int, error) {
 	lines := make([]string, maxJobLogLines)
 	validLines := make([]bool, maxJobLogLines)
 	totalLines := 0
 	writeIndex := 0
 
-	scanner := bufio.NewScanner(httpResp.Body)
+	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 
 	for scanner.Scan() {
@@ -44,7 +52,7 @@ func ProcessResponseAsRingBufferToEnd(httpResp *http.Response, maxJobLogLines in
 	}
 
 	if err := scanner.Err(); err != nil {
-		return "", 0, httpResp, fmt.Errorf("failed to read log content: %w", err)
+		return "", 0, fmt.Errorf("failed to read log content: %w", err)
 	}
 
 	var result []string
@@ -65,5 +73,5 @@ func ProcessResponseAsRingBufferToEnd(httpResp *http.Response, maxJobLogLines in
 		}
 	}
 
-	return strings.Join(result, "\n"), totalLines, httpResp, nil
+	return strings.Join(result, "\n"), totalLines, nil
 }
@@ -1,8 +1,14 @@
 package errors
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -27,6 +33,226 @@ func (e *GitHubAPIError) Error() string {
 	return fmt.Errorf("%s: %w", e.Message, e.Err).Error()
 }
 
+// Headers GitHub responds with that tell which scopes or permissions a request needs and a token has.
+const (
+	oauthScopesHeader         = "X-OAuth-Scopes"
+	acceptedOAuthScopesHeader = "X-Accepted-OAuth-Scopes"
+	acceptedPermissionsHeader = "X-Accepted-GitHub-Permissions"
+)
+
+// Error codes of failed GitHub requests, so that clients can handle them without parsing messages.
+const (
+	CodeNotFound         = "RESOURCE_NOT_FOUND"
+	CodeUnauthenticated  = "UNAUTHENTICATED"
+	CodePermissionDenied = "PERMISSION_DENIED"
+	CodeRateLimited      = "RATE_LIMITED"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeMergeConflict    = "MERGE_CONFLICT"
+	CodeConflict         = "CONFLICT"
+	CodeUnavailable      = "GITHUB_UNAVAILABLE"
+	CodeRequestFailed    = "REQUEST_FAILED"
+	CodeUnknown          = "UNKNOWN"
+)
+
+// APIErrorDetails is the structure failed GitHub API requests are reported to clients in.
+type APIErrorDetails struct {
+	// Message describes what the server failed to do, and Err is the error it got.
+	Message string `json:"message"`
+	Err     string `json:"error"`
+
+	// Code classifies the failure, and Retryable and Hint tell how to recover from it.
+	// SuggestedTool names a tool that helps to, when there is one.
+	Code          string `json:"code"`
+	Retryable     bool   `json:"retryable"`
+	Hint          string `json:"hint,omitempty"`
+	SuggestedTool string `json:"suggested_tool,omitempty"`
+
+	Status           int               `json:"status,omitempty"`
+	GitHubMessage    string            `json:"github_message,omitempty"`
+	Errors           []ValidationError `json:"errors,omitempty"`
+	DocumentationURL string            `json:"documentation_url,omitempty"`
+	RateLimit        *RateLimitDetails `json:"rate_limit,omitempty"`
+
+	// TokenScopes are the scopes of a classic token, and AcceptedScopes the scopes any of which
+	// the request needs. MissingScopes is set when the token has none of them.
+	TokenScopes         []string `json:"token_scopes,omitempty"`
+	AcceptedScopes      []string `json:"accepted_scopes,omitempty"`
+	MissingScopes       []string `json:"missing_scopes,omitempty"`
+	AcceptedPermissions []string `json:"accepted_permissions,omitempty"`
+}
+
+// ValidationError is an individual error GitHub reports, usually for a field of a request that failed validation.
+type ValidationError struct {
+	Resource string `json:"resource,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// RateLimitDetails is the rate limit state of the token after a failed request.
+type RateLimitDetails struct {
+	Limit             int    `json:"limit,omitempty"`
+	Remaining         int    `json:"remaining"`
+	Reset             string `json:"reset,omitempty"`
+	RetryAfterSeconds int    `json:"retry_after_seconds,omitempty"`
+}
+
+// Details extracts what GitHub reported about the failed request from the error and response.
+func (e *GitHubAPIError) Details() APIErrorDetails {
+	details := APIErrorDetails{Message: e.Message}
+	if e.Err != nil {
+		details.Err = e.Err.Error()
+	}
+
+	var httpResp *http.Response
+	if e.Response != nil {
+		httpResp = e.Response.Response
+	}
+
+	var rate *github.Rate
+	if e.Response != nil && e.Response.Rate.Limit > 0 {
+		rate = &e.Response.Rate
+	}
+
+	var errorResponse *github.ErrorResponse
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	switch {
+	case errors.As(e.Err, &errorResponse):
+		details.GitHubMessage = errorResponse.Message
+		details.DocumentationURL = errorResponse.DocumentationURL
+		for _, validationErr := range errorResponse.Errors {
+			details.Errors = append(details.Errors, ValidationError(validationErr))
+		}
+		if httpResp == nil {
+			httpResp = errorResponse.Response
+		}
+	case errors.As(e.Err, &rateLimitErr):
+		details.GitHubMessage = rateLimitErr.Message
+		rate = &rateLimitErr.Rate
+		if httpResp == nil {
+			httpResp = rateLimitErr.Response
+		}
+	case errors.As(e.Err, &abuseErr):
+		details.GitHubMessage = abuseErr.Message
+		details.RateLimit = &RateLimitDetails{}
+		if rate != nil {
+			details.RateLimit.Limit = rate.Limit
+			details.RateLimit.Remaining = rate.Remaining
+		}
+		if abuseErr.RetryAfter != nil {
+			details.RateLimit.RetryAfterSeconds = int(abuseErr.RetryAfter.Seconds())
+		}
+		if httpResp == nil {
+			httpResp = abuseErr.Response
+		}
+	}
+
+	if details.RateLimit == nil && rate != nil {
+		details.RateLimit = &RateLimitDetails{
+			Limit:     rate.Limit,
+			Remaining: rate.Remaining,
+			Reset:     rate.Reset.UTC().Format(time.RFC3339),
+		}
+	}
+
+	if httpResp == nil {
+		details.classify(0, rateLimitErr != nil || abuseErr != nil)
+		return details
+	}
+	details.Status = httpResp.StatusCode
+	details.TokenScopes = headerList(httpResp.Header, oauthScopesHeader, ",")
+	details.AcceptedScopes = headerList(httpResp.Header, acceptedOAuthScopesHeader, ",")
+	details.AcceptedPermissions = headerList(httpResp.Header, acceptedPermissionsHeader, ";")
+
+	// Classic tokens list their scopes, so a request that accepts none of them failed for lack of a scope
+	_, hasScopes := httpResp.Header[http.CanonicalHeaderKey(oauthScopesHeader)]
+	if hasScopes && len(details.AcceptedScopes) > 0 && (details.Status == http.StatusForbidden || details.Status == http.StatusNotFound) {
+		granted := make(map[string]bool, len(details.TokenScopes))
+		for _, scope := range details.TokenScopes {
+			granted[scope] = true
+		}
+		missing := true
+		for _, scope := range details.AcceptedScopes {
+			if granted[scope] {
+				missing = false
+			}
+		}
+		if missing {
+			details.MissingScopes = details.AcceptedScopes
+		}
+	}
+
+	details.classify(details.Status, rateLimitErr != nil || abuseErr != nil || details.Status == http.StatusTooManyRequests)
+	return details
+}
+
+// classify sets the code of the failure and how to recover from it, from the status and messages GitHub reported.
+func (d *APIErrorDetails) classify(status int, rateLimited bool) {
+	message := strings.ToLower(d.GitHubMessage + " " + d.Err)
+	switch {
+	case rateLimited:
+		d.Code, d.Retryable = CodeRateLimited, true
+		d.Hint = "The GitHub rate limit was exceeded, wait before retrying"
+		if d.RateLimit != nil && d.RateLimit.RetryAfterSeconds > 0 {
+			d.Hint = fmt.Sprintf("A secondary GitHub rate limit was exceeded, retry after %d seconds and make fewer requests at once", d.RateLimit.RetryAfterSeconds)
+		} else if d.RateLimit != nil && d.RateLimit.Reset != "" {
+			d.Hint = fmt.Sprintf("The GitHub rate limit was exceeded, retry after it resets at %s", d.RateLimit.Reset)
+		}
+	case len(d.MissingScopes) > 0:
+		d.Code = CodePermissionDenied
+		d.Hint = fmt.Sprintf("The token needs one of the %s scopes for this request", strings.Join(d.MissingScopes, ", "))
+		d.SuggestedTool = "get_me"
+	case strings.Contains(message, "merge conflict") || strings.Contains(message, "not mergeable"):
+		d.Code = CodeMergeConflict
+		d.Hint = "The branches have conflicting changes, update the head branch with the base branch and resolve the conflicts first"
+		d.SuggestedTool = "update_pull_request_branch"
+	case status == http.StatusUnauthorized:
+		d.Code = CodeUnauthenticated
+		d.Hint = "The token is invalid or expired, it needs to be replaced in the server configuration"
+	case status == http.StatusForbidden:
+		d.Code = CodePermissionDenied
+		d.Hint = "The token isn't allowed to do this, check its scopes or permissions and the role of its user in the repository or organization"
+		if len(d.AcceptedPermissions) > 0 {
+			d.Hint = fmt.Sprintf("The token needs the %s permissions for this request", strings.Join(d.AcceptedPermissions, ", "))
+		}
+		d.SuggestedTool = "get_me"
+	case status == http.StatusNotFound:
+		d.Code = CodeNotFound
+		d.Hint = "Check the owner, repository and other identifiers. Private resources the token can't access are also reported as not found"
+	case status == http.StatusConflict:
+		d.Code = CodeConflict
+		d.Hint = "The resource changed or is in a state that conflicts with the request, get it again before retrying"
+	case status == http.StatusUnprocessableEntity || status == http.StatusBadRequest:
+		d.Code = CodeValidationFailed
+		d.Hint = "GitHub rejected the arguments, fix them before retrying"
+		if len(d.Errors) > 0 {
+			d.Hint = "GitHub rejected the arguments, fix the fields listed in errors before retrying"
+		}
+	case status >= http.StatusInternalServerError:
+		d.Code, d.Retryable = CodeUnavailable, true
+		d.Hint = "GitHub failed to handle the request, retry it later"
+	case status == 0:
+		d.Code, d.Retryable = CodeRequestFailed, true
+		d.Hint = "The request didn't get a response from GitHub, retry it"
+	default:
+		d.Code = CodeUnknown
+	}
+}
+
+// headerList splits the values of a header that lists items, dropping empty ones.
+func headerList(header http.Header, key string, sep string) []string {
+	var items []string
+	for _, value := range header.Values(key) {
+		for _, item := range strings.Split(value, sep) {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
 type GitHubGraphQLError struct {
 	Message string `json:"message"`
 	Err     error  `json:"-"`
@@ -43,6 +269,26 @@ func (e *GitHubGraphQLError) Error() string {
 	return fmt.Errorf("%s: %w", e.Message, e.Err).Error()
 }
 
+// Details classifies the failed GraphQL request. GraphQL errors have no status, so the messages GitHub
+// uses for the types of errors are matched instead.
+func (e *GitHubGraphQLError) Details() APIErrorDetails {
+	details := APIErrorDetails{Message: e.Message}
+	if e.Err != nil {
+		details.Err = e.Err.Error()
+	}
+
+	message := strings.ToLower(details.Err)
+	status := http.StatusOK
+	switch {
+	case strings.Contains(message, "could not resolve to"):
+		status = http.StatusNotFound
+	case strings.Contains(message, "resource not accessible") || strings.Contains(message, "does not have permission"):
+		status = http.StatusForbidden
+	}
+	details.classify(status, strings.Contains(message, "rate limit"))
+	return details
+}
+
 type GitHubErrorKey struct{}
 type GitHubCtxErrors struct {
 	api     []*GitHubAPIError
@@ -112,7 +358,19 @@ func NewGitHubAPIErrorResponse(ctx context.Context, message string, resp *github
 	if ctx != nil {
 		_, _ = addGitHubAPIErrorToContext(ctx, apiErr) // Explicitly ignore error for graceful handling
 	}
-	return mcp.NewToolResultErrorFromErr(message, err)
+	return detailsResult(apiErr.Details(), message, err)
+}
+
+// detailsResult reports the details of a failed request as a JSON tool error result.
+func detailsResult(details APIErrorDetails, message string, err error) *mcp.CallToolResult {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	// Keep the messages readable, they contain no HTML
+	encoder.SetEscapeHTML(false)
+	if encodeErr := encoder.Encode(details); encodeErr != nil {
+		return mcp.NewToolResultErrorFromErr(message, err)
+	}
+	return mcp.NewToolResultError(strings.TrimSuffix(buf.String(), "\n"))
 }
 
 // NewGitHubGraphQLErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
@@ -121,5 +379,5 @@ func NewGitHubGraphQLErrorResponse(ctx context.Context, message string, err erro
 	if ctx != nil {
 		_, _ = addGitHubGraphQLErrorToContext(ctx, graphQLErr) // Explicitly ignore error for graceful handling
 	}
-	return mcp.NewToolResultErrorFromErr(message, err)
+	return detailsResult(graphQLErr.Details(), message, err)
 }
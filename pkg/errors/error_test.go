@@ -2,11 +2,14 @@ package errors
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -377,3 +380,264 @@ func TestMiddlewareScenario(t *testing.T) {
 		assert.Contains(t, gqlMessages, "mutation failed")
 	})
 }
+
+func TestGitHubAPIErrorDetails(t *testing.T) {
+	// Rate limit errors include the request in their message
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/repos/owner/repo/issues", nil)
+	require.NoError(t, err)
+
+	t.Run("validation errors are reported with the documentation URL", func(t *testing.T) {
+		httpResp := &http.Response{StatusCode: 422, Header: http.Header{}}
+		err := &github.ErrorResponse{
+			Response: httpResp,
+			Message:  "Validation Failed",
+			Errors: []github.Error{
+				{Resource: "PullRequest", Field: "head", Code: "invalid"},
+			},
+			DocumentationURL: "https://docs.github.com/rest/pulls/pulls#create-a-pull-request",
+		}
+
+		details := newGitHubAPIError("failed to create pull request", &github.Response{Response: httpResp}, err).Details()
+
+		assert.Equal(t, APIErrorDetails{
+			Message:          "failed to create pull request",
+			Err:              err.Error(),
+			Code:             CodeValidationFailed,
+			Hint:             "GitHub rejected the arguments, fix the fields listed in errors before retrying",
+			Status:           422,
+			GitHubMessage:    "Validation Failed",
+			Errors:           []ValidationError{{Resource: "PullRequest", Field: "head", Code: "invalid"}},
+			DocumentationURL: "https://docs.github.com/rest/pulls/pulls#create-a-pull-request",
+		}, details)
+	})
+
+	t.Run("scopes the token is missing are reported", func(t *testing.T) {
+		httpResp := &http.Response{StatusCode: 404, Header: http.Header{}}
+		httpResp.Header.Set("X-OAuth-Scopes", "read:org, gist")
+		httpResp.Header.Set("X-Accepted-OAuth-Scopes", "repo")
+		err := &github.ErrorResponse{Response: httpResp, Message: "Not Found"}
+
+		details := newGitHubAPIError("failed to get repository", &github.Response{Response: httpResp}, err).Details()
+
+		assert.Equal(t, 404, details.Status)
+		assert.Equal(t, []string{"read:org", "gist"}, details.TokenScopes)
+		assert.Equal(t, []string{"repo"}, details.AcceptedScopes)
+		assert.Equal(t, []string{"repo"}, details.MissingScopes)
+	})
+
+	t.Run("scopes are not reported missing when the token has one", func(t *testing.T) {
+		httpResp := &http.Response{StatusCode: 404, Header: http.Header{}}
+		httpResp.Header.Set("X-OAuth-Scopes", "repo")
+		httpResp.Header.Set("X-Accepted-OAuth-Scopes", "repo, public_repo")
+		err := &github.ErrorResponse{Response: httpResp, Message: "Not Found"}
+
+		details := newGitHubAPIError("failed to get repository", &github.Response{Response: httpResp}, err).Details()
+
+		assert.Empty(t, details.MissingScopes)
+	})
+
+	t.Run("permissions of fine-grained tokens are reported", func(t *testing.T) {
+		httpResp := &http.Response{StatusCode: 403, Header: http.Header{}}
+		httpResp.Header.Set("X-Accepted-GitHub-Permissions", "pull_requests=write; contents=write")
+		err := &github.ErrorResponse{Response: httpResp, Message: "Resource not accessible by personal access token"}
+
+		details := newGitHubAPIError("failed to merge pull request", &github.Response{Response: httpResp}, err).Details()
+
+		assert.Equal(t, []string{"pull_requests=write", "contents=write"}, details.AcceptedPermissions)
+		assert.Empty(t, details.MissingScopes)
+	})
+
+	t.Run("rate limit state is reported", func(t *testing.T) {
+		reset := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		httpResp := &http.Response{StatusCode: 403, Header: http.Header{}, Request: req}
+		err := &github.RateLimitError{
+			Response: httpResp,
+			Message:  "API rate limit exceeded",
+			Rate:     github.Rate{Limit: 5000, Remaining: 0, Reset: github.Timestamp{Time: reset}},
+		}
+
+		details := newGitHubAPIError("failed to list issues", nil, err).Details()
+
+		assert.Equal(t, 403, details.Status)
+		assert.Equal(t, "API rate limit exceeded", details.GitHubMessage)
+		assert.Equal(t, &RateLimitDetails{Limit: 5000, Remaining: 0, Reset: "2025-01-02T03:04:05Z"}, details.RateLimit)
+	})
+
+	t.Run("secondary rate limits report when to retry", func(t *testing.T) {
+		retryAfter := 30 * time.Second
+		err := &github.AbuseRateLimitError{
+			Response:   &http.Response{StatusCode: 403, Header: http.Header{}, Request: req},
+			Message:    "You have exceeded a secondary rate limit",
+			RetryAfter: &retryAfter,
+		}
+
+		details := newGitHubAPIError("failed to create issue", nil, err).Details()
+
+		assert.Equal(t, &RateLimitDetails{RetryAfterSeconds: 30}, details.RateLimit)
+	})
+
+	t.Run("errors without a response only have the messages", func(t *testing.T) {
+		details := newGitHubAPIError("failed to get issue", nil, fmt.Errorf("connection refused")).Details()
+
+		assert.Equal(t, APIErrorDetails{
+			Message:   "failed to get issue",
+			Err:       "connection refused",
+			Code:      CodeRequestFailed,
+			Retryable: true,
+			Hint:      "The request didn't get a response from GitHub, retry it",
+		}, details)
+	})
+
+	t.Run("NewGitHubAPIErrorResponse reports the details as JSON", func(t *testing.T) {
+		httpResp := &http.Response{StatusCode: 404, Header: http.Header{}}
+		err := &github.ErrorResponse{Response: httpResp, Message: "Not Found", DocumentationURL: "https://docs.github.com/rest"}
+
+		result := NewGitHubAPIErrorResponse(context.Background(), "failed to get <issue>", &github.Response{Response: httpResp}, err)
+
+		require.True(t, result.IsError)
+		require.Len(t, result.Content, 1)
+		text := result.Content[0].(mcp.TextContent).Text
+		assert.Contains(t, text, "failed to get <issue>")
+
+		var details APIErrorDetails
+		require.NoError(t, json.Unmarshal([]byte(text), &details))
+		assert.Equal(t, 404, details.Status)
+		assert.Equal(t, "Not Found", details.GitHubMessage)
+		assert.Equal(t, "https://docs.github.com/rest", details.DocumentationURL)
+	})
+}
+
+func TestGitHubErrorCodes(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPut, "https://api.github.com/repos/owner/repo/pulls/1/merge", nil)
+	require.NoError(t, err)
+	errorResponse := func(status int, message string, header http.Header) error {
+		if header == nil {
+			header = http.Header{}
+		}
+		return &github.ErrorResponse{Response: &http.Response{StatusCode: status, Header: header, Request: req}, Message: message}
+	}
+	retryAfter := time.Minute
+
+	tests := []struct {
+		name                  string
+		err                   error
+		expectedCode          string
+		expectedRetryable     bool
+		expectedSuggestedTool string
+	}{
+		{
+			name:         "not found",
+			err:          errorResponse(http.StatusNotFound, "Not Found", nil),
+			expectedCode: CodeNotFound,
+		},
+		{
+			name:                  "not found for lack of a scope",
+			err:                   errorResponse(http.StatusNotFound, "Not Found", http.Header{"X-Oauth-Scopes": {"gist"}, "X-Accepted-Oauth-Scopes": {"repo"}}),
+			expectedCode:          CodePermissionDenied,
+			expectedSuggestedTool: "get_me",
+		},
+		{
+			name:         "bad credentials",
+			err:          errorResponse(http.StatusUnauthorized, "Bad credentials", nil),
+			expectedCode: CodeUnauthenticated,
+		},
+		{
+			name:                  "forbidden",
+			err:                   errorResponse(http.StatusForbidden, "Resource not accessible by integration", nil),
+			expectedCode:          CodePermissionDenied,
+			expectedSuggestedTool: "get_me",
+		},
+		{
+			name:              "rate limited",
+			err:               &github.RateLimitError{Response: &http.Response{StatusCode: http.StatusForbidden, Request: req}, Message: "API rate limit exceeded"},
+			expectedCode:      CodeRateLimited,
+			expectedRetryable: true,
+		},
+		{
+			name:              "secondary rate limited",
+			err:               &github.AbuseRateLimitError{Response: &http.Response{StatusCode: http.StatusForbidden, Request: req}, Message: "secondary rate limit", RetryAfter: &retryAfter},
+			expectedCode:      CodeRateLimited,
+			expectedRetryable: true,
+		},
+		{
+			name:              "too many requests",
+			err:               errorResponse(http.StatusTooManyRequests, "Too Many Requests", nil),
+			expectedCode:      CodeRateLimited,
+			expectedRetryable: true,
+		},
+		{
+			name:                  "merge conflict",
+			err:                   errorResponse(http.StatusConflict, "Merge conflict", nil),
+			expectedCode:          CodeMergeConflict,
+			expectedSuggestedTool: "update_pull_request_branch",
+		},
+		{
+			name:                  "not mergeable",
+			err:                   errorResponse(http.StatusMethodNotAllowed, "Pull Request is not mergeable", nil),
+			expectedCode:          CodeMergeConflict,
+			expectedSuggestedTool: "update_pull_request_branch",
+		},
+		{
+			name:         "conflict",
+			err:          errorResponse(http.StatusConflict, "Head branch was modified. Review and try the merge again.", nil),
+			expectedCode: CodeConflict,
+		},
+		{
+			name:         "validation failed",
+			err:          errorResponse(http.StatusUnprocessableEntity, "Validation Failed", nil),
+			expectedCode: CodeValidationFailed,
+		},
+		{
+			name:              "server error",
+			err:               errorResponse(http.StatusBadGateway, "Server Error", nil),
+			expectedCode:      CodeUnavailable,
+			expectedRetryable: true,
+		},
+		{
+			name:         "other status",
+			err:          errorResponse(http.StatusMethodNotAllowed, "Method Not Allowed", nil),
+			expectedCode: CodeUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			details := newGitHubAPIError("request failed", nil, tc.err).Details()
+
+			assert.Equal(t, tc.expectedCode, details.Code)
+			assert.Equal(t, tc.expectedRetryable, details.Retryable)
+			assert.Equal(t, tc.expectedSuggestedTool, details.SuggestedTool)
+			if tc.expectedCode != CodeUnknown {
+				assert.NotEmpty(t, details.Hint)
+			}
+		})
+	}
+
+	// GitHub's GraphQL error messages are capitalized sentences
+	graphQLError := func(message string) error { return fmt.Errorf("%s", message) }
+
+	t.Run("GraphQL errors are classified by their messages", func(t *testing.T) {
+		notFound := newGitHubGraphQLError("failed to get discussion", graphQLError("Could not resolve to a Repository with the name 'owner/repo'.")).Details()
+		assert.Equal(t, CodeNotFound, notFound.Code)
+
+		forbidden := newGitHubGraphQLError("failed to add comment", graphQLError("Resource not accessible by integration")).Details()
+		assert.Equal(t, CodePermissionDenied, forbidden.Code)
+
+		rateLimited := newGitHubGraphQLError("failed to list projects", graphQLError("API rate limit exceeded for user ID 1.")).Details()
+		assert.Equal(t, CodeRateLimited, rateLimited.Code)
+		assert.True(t, rateLimited.Retryable)
+
+		other := newGitHubGraphQLError("failed to list projects", graphQLError("Something went wrong")).Details()
+		assert.Equal(t, CodeUnknown, other.Code)
+	})
+
+	t.Run("NewGitHubGraphQLErrorResponse reports the details as JSON", func(t *testing.T) {
+		result := NewGitHubGraphQLErrorResponse(context.Background(), "failed to get discussion", graphQLError("Could not resolve to a Discussion with the number of 1."))
+
+		require.True(t, result.IsError)
+		var details APIErrorDetails
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &details))
+		assert.Equal(t, "failed to get discussion", details.Message)
+		assert.Equal(t, CodeNotFound, details.Code)
+	})
+}
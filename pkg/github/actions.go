@@ -1,9 +1,12 @@
 package github
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -30,6 +33,7 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_LIST_WORKFLOWS_USER_TITLE", "List workflows"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.Workflows](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -82,6 +86,72 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 		}
 }
 
+// GetWorkflow creates a tool to get a workflow of a repository by its ID or file name
+func GetWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_DESCRIPTION", "Get a workflow of a repository, such as its name, path and whether it is enabled")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_USER_TITLE", "Get workflow"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[*github.Workflow](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := RequiredParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var workflow *github.Workflow
+			var resp *github.Response
+			if workflowIDInt, parseErr := strconv.ParseInt(workflowID, 10, 64); parseErr == nil {
+				workflow, resp, err = client.Actions.GetWorkflowByID(ctx, owner, repo, workflowIDInt)
+			} else {
+				workflow, resp, err = client.Actions.GetWorkflowByFileName(ctx, owner, repo, workflowID)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get workflow",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(workflow)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // ListWorkflowRuns creates a tool to list workflow runs for a specific workflow
 func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflow_runs",
@@ -90,6 +160,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_WORKFLOW_RUNS_USER_TITLE", "List workflow runs"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.WorkflowRuns](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -150,6 +221,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Enum("queued", "in_progress", "completed", "requested", "waiting"),
 			),
 			WithPagination(),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -229,6 +301,7 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				Title:        t("TOOL_RUN_WORKFLOW_USER_TITLE", "Run workflow"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -328,6 +401,7 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_GET_WORKFLOW_RUN_USER_TITLE", "Get workflow run"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.WorkflowRun](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -377,13 +451,14 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 }
 
 // GetWorkflowRunLogs creates a tool to download logs for a specific workflow run
-func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_workflow_run_logs",
-			mcp.WithDescription(t("TOOL_GET_WORKFLOW_RUN_LOGS_DESCRIPTION", "Download logs for a specific workflow run (EXPENSIVE: downloads ALL logs as ZIP. Consider using get_job_logs with failed_only=true for debugging failed jobs)")),
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_RUN_LOGS_DESCRIPTION", "Download logs for a specific workflow run (EXPENSIVE: downloads ALL logs as ZIP. Consider using failed_only=true for debugging failed jobs)")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_WORKFLOW_RUN_LOGS_USER_TITLE", "Get workflow run logs"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -396,6 +471,16 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			mcp.WithBoolean("failed_only",
+				mcp.Description("When true, only gets the logs of the failed jobs of the run"),
+			),
+			mcp.WithBoolean("return_content",
+				mcp.Description("Returns the log content of each job, extracted from the ZIP archive, instead of the URL of the archive"),
+			),
+			mcp.WithNumber("tail_lines",
+				mcp.Description("Number of lines to return from the end of the log of each job"),
+				mcp.DefaultNumber(500),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -411,12 +496,33 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			runID := int64(runIDInt)
+			failedOnly, err := OptionalParam[bool](request, "failed_only")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			returnContent, err := OptionalParam[bool](request, "return_content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tailLines, err := OptionalIntParam(request, "tail_lines")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// Default to 500 lines if not specified
+			if tailLines == 0 {
+				tailLines = 500
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			if failedOnly {
+				// The logs of the failed jobs are fetched one by one rather than downloading the whole archive
+				return handleFailedJobLogs(ctx, client, owner, repo, runID, returnContent, tailLines, contentWindowSize)
+			}
+
 			// Get the download URL for the logs
 			url, resp, err := client.Actions.GetWorkflowRunLogs(ctx, owner, repo, runID, 1)
 			if err != nil {
@@ -424,13 +530,26 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			// Create response with the logs URL and information
-			result := map[string]any{
-				"logs_url":         url.String(),
-				"message":          "Workflow run logs are available for download",
-				"note":             "The logs_url provides a download link for the complete workflow run logs as a ZIP archive. You can download this archive to extract and examine individual job logs.",
-				"warning":          "This downloads ALL logs as a ZIP file which can be large and expensive. For debugging failed jobs, consider using get_job_logs with failed_only=true and run_id instead.",
-				"optimization_tip": "Use: get_job_logs with parameters {run_id: " + fmt.Sprintf("%d", runID) + ", failed_only: true} for more efficient failed job debugging",
+			var result map[string]any
+			if returnContent {
+				logs, httpResp, err := downloadRunLogs(ctx, url.String(), tailLines, contentWindowSize) //nolint:bodyclose // Response body is closed in downloadRunLogs, but we need to return httpResp
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to download workflow run logs", &github.Response{Response: httpResp}, err), nil
+				}
+				result = map[string]any{
+					"run_id":  runID,
+					"message": fmt.Sprintf("Retrieved logs for %d jobs", len(logs)),
+					"logs":    logs,
+				}
+			} else {
+				// Create response with the logs URL and information
+				result = map[string]any{
+					"logs_url":         url.String(),
+					"message":          "Workflow run logs are available for download",
+					"note":             "The logs_url provides a download link for the complete workflow run logs as a ZIP archive. Use return_content=true to get the log content of each job instead.",
+					"warning":          "This downloads ALL logs as a ZIP file which can be large and expensive. For debugging failed jobs, consider using failed_only=true instead.",
+					"optimization_tip": "Use: get_workflow_run_logs with parameters {run_id: " + fmt.Sprintf("%d", runID) + ", failed_only: true} for more efficient failed job debugging",
+				}
 			}
 
 			r, err := json.Marshal(result)
@@ -442,6 +561,71 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 		}
 }
 
+// maxRunLogsArchiveSize bounds the size of the log archives of workflow runs that are downloaded to extract the
+// logs of their jobs, as archives can only be read once they are downloaded whole.
+const maxRunLogsArchiveSize = 64 * 1024 * 1024
+
+// downloadRunLogs downloads the log archive of a workflow run and returns the end of the log of each of its jobs.
+func downloadRunLogs(ctx context.Context, logURL string, tailLines int, maxLines int) ([]map[string]any, *http.Response, error) {
+	httpResp, err := http.Get(logURL) //nolint:gosec
+	if err != nil {
+		return nil, httpResp, fmt.Errorf("failed to download logs: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, httpResp, fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
+	}
+
+	archive, err := io.ReadAll(io.LimitReader(NewProgressReader(ctx, httpResp.Body, httpResp.ContentLength, "Downloading logs"), maxRunLogsArchiveSize+1))
+	if err != nil {
+		return nil, httpResp, fmt.Errorf("failed to download logs: %w", err)
+	}
+	if len(archive) > maxRunLogsArchiveSize {
+		return nil, httpResp, fmt.Errorf("the log archive is larger than %d MiB, use failed_only=true or get_job_logs to get the logs of single jobs", maxRunLogsArchiveSize/1024/1024)
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, httpResp, fmt.Errorf("failed to read log archive: %w", err)
+	}
+
+	bufferSize := tailLines
+	if bufferSize > maxLines {
+		bufferSize = maxLines
+	}
+	logs := []map[string]any{}
+	for _, file := range zipReader.File {
+		// The archive holds the whole log of each job at its root, and the logs of their steps in folders
+		if strings.Contains(file.Name, "/") {
+			continue
+		}
+		content, totalLines, err := readArchivedLog(file, bufferSize)
+		if err != nil {
+			return nil, httpResp, err
+		}
+		lines := strings.Split(content, "\n")
+		if len(lines) > tailLines {
+			lines = lines[len(lines)-tailLines:]
+		}
+		logs = append(logs, map[string]any{
+			"file":            file.Name,
+			"logs_content":    strings.Join(lines, "\n"),
+			"original_length": totalLines,
+		})
+	}
+	return logs, httpResp, nil
+}
+
+// readArchivedLog returns the last lines of a log of a log archive, along with its number of lines.
+func readArchivedLog(file *zip.File, maxLines int) (string, int, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open %s in log archive: %w", file.Name, err)
+	}
+	defer func() { _ = rc.Close() }()
+	return buffer.ProcessAsRingBufferToEnd(rc, maxLines)
+}
+
 // ListWorkflowJobs creates a tool to list jobs for a specific workflow run
 func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflow_jobs",
@@ -450,6 +634,7 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_WORKFLOW_JOBS_USER_TITLE", "List workflow jobs"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -538,6 +723,7 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, con
 				Title:        t("TOOL_GET_JOB_LOGS_USER_TITLE", "Get job logs"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -656,7 +842,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 
 	// Collect logs for all failed jobs
 	var logResults []map[string]any
-	for _, job := range failedJobs {
+	for i, job := range failedJobs {
 		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent, tailLines, contentWindowSize)
 		if err != nil {
 			// Continue with other jobs even if one fails
@@ -670,6 +856,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		}
 
 		logResults = append(logResults, jobResult)
+		NotifyPartialResult(ctx, float64(i+1), float64(len(failedJobs)), fmt.Sprintf("Retrieved logs of job %s", job.GetName()), jobResult)
 	}
 
 	result := map[string]any{
@@ -756,6 +943,11 @@ func downloadLogContent(ctx context.Context, logURL string, tailLines int, maxLi
 	if httpResp.StatusCode != http.StatusOK {
 		return "", 0, httpResp, fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
 	}
+	// Logs of long jobs take a while to download, so clients are kept informed rather than left to time out
+	httpResp.Body = struct {
+		io.Reader
+		io.Closer
+	}{NewProgressReader(ctx, httpResp.Body, httpResp.ContentLength, "Downloading logs"), httpResp.Body}
 
 	bufferSize := tailLines
 	if bufferSize > maxLines {
@@ -786,6 +978,7 @@ func RerunWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_RERUN_WORKFLOW_RUN_USER_TITLE", "Rerun workflow run"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -849,6 +1042,7 @@ func RerunFailedJobs(getClient GetClientFn, t translations.TranslationHelperFunc
 				Title:        t("TOOL_RERUN_FAILED_JOBS_USER_TITLE", "Rerun failed jobs"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -907,11 +1101,12 @@ func RerunFailedJobs(getClient GetClientFn, t translations.TranslationHelperFunc
 // CancelWorkflowRun creates a tool to cancel a workflow run
 func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("cancel_workflow_run",
-			mcp.WithDescription(t("TOOL_CANCEL_WORKFLOW_RUN_DESCRIPTION", "Cancel a workflow run")),
+			mcp.WithDescription(t("TOOL_CANCEL_WORKFLOW_RUN_DESCRIPTION", "Cancel a workflow run. Use force to stop runs that don't respond to a regular cancellation, such as runs stuck in the cancelling state")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CANCEL_WORKFLOW_RUN_USER_TITLE", "Cancel workflow run"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -924,6 +1119,9 @@ func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFu
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			mcp.WithBoolean("force",
+				mcp.Description("Force cancel the run, bypassing the always() conditions of its jobs. Only use it when regular cancellation fails"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -939,13 +1137,22 @@ func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFu
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			runID := int64(runIDInt)
+			force, err := OptionalParam[bool](request, "force")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			resp, err := client.Actions.CancelWorkflowRunByID(ctx, owner, repo, runID)
+			var resp *github.Response
+			if force {
+				resp, err = forceCancelWorkflowRun(ctx, client, owner, repo, runID)
+			} else {
+				resp, err = client.Actions.CancelWorkflowRunByID(ctx, owner, repo, runID)
+			}
 			if err != nil {
 				if _, ok := err.(*github.AcceptedError); !ok {
 					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to cancel workflow run", resp, err), nil
@@ -956,6 +1163,7 @@ func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFu
 			result := map[string]any{
 				"message":     "Workflow run has been cancelled",
 				"run_id":      runID,
+				"forced":      force,
 				"status":      resp.Status,
 				"status_code": resp.StatusCode,
 			}
@@ -969,6 +1177,15 @@ func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFu
 		}
 }
 
+// forceCancelWorkflowRun force cancels a workflow run, which go-github has no method for.
+func forceCancelWorkflowRun(ctx context.Context, client *github.Client, owner, repo string, runID int64) (*github.Response, error) {
+	req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("repos/%s/%s/actions/runs/%d/force-cancel", owner, repo, runID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(ctx, req, nil)
+}
+
 // ListWorkflowRunArtifacts creates a tool to list artifacts for a workflow run
 func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflow_run_artifacts",
@@ -977,6 +1194,7 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 				Title:        t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_USER_TITLE", "List workflow artifacts"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.ArtifactList](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -1046,6 +1264,7 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 				Title:        t("TOOL_DOWNLOAD_WORKFLOW_RUN_ARTIFACT_USER_TITLE", "Download workflow artifact"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -1112,6 +1331,7 @@ func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelp
 				ReadOnlyHint:    ToBoolPtr(false),
 				DestructiveHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -1175,6 +1395,7 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 				Title:        t("TOOL_GET_WORKFLOW_RUN_USAGE_USER_TITLE", "Get workflow usage"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.WorkflowRunUsage](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryOwner),
@@ -1222,3 +1443,208 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// PendingDeploymentApproval describes a workflow run waiting for deployments to environments to be approved.
+type PendingDeploymentApproval struct {
+	RunID        int64                       `json:"run_id"`
+	WorkflowName string                      `json:"workflow_name,omitempty"`
+	HTMLURL      string                      `json:"html_url,omitempty"`
+	HeadBranch   string                      `json:"head_branch,omitempty"`
+	HeadSHA      string                      `json:"head_sha,omitempty"`
+	Actor        string                      `json:"actor,omitempty"`
+	Deployments  []*github.PendingDeployment `json:"deployments"`
+}
+
+// ListPendingDeployments creates a tool to list the deployments waiting for approval in a repository
+func ListPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pending_deployments",
+			mcp.WithDescription(t("TOOL_LIST_PENDING_DEPLOYMENTS_DESCRIPTION", "List the workflow runs of a repository waiting for deployments to environments with protection rules to be approved, along with the environments and whether the current user can approve them. Use multi_repo_query to list them across repositories.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PENDING_DEPLOYMENTS_USER_TITLE", "List pending deployments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]PendingDeploymentApproval](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Description("The unique identifier of a workflow run, to only list its pending deployments"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := OptionalIntParam(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var runs []*github.WorkflowRun
+			if runID != 0 {
+				run, resp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, int64(runID))
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow run", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				runs = append(runs, run)
+			} else {
+				// Runs wait for approval for up to 30 days, so there are few of them at any time
+				waiting, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{
+					Status:      "waiting",
+					ListOptions: github.ListOptions{PerPage: 100},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list waiting workflow runs", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				runs = waiting.WorkflowRuns
+			}
+
+			approvals := []PendingDeploymentApproval{}
+			for _, run := range runs {
+				deployments, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, run.GetID())
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get pending deployments of workflow run %d", run.GetID()), resp, err), nil
+				}
+				_ = resp.Body.Close()
+				if len(deployments) == 0 {
+					continue
+				}
+				approvals = append(approvals, PendingDeploymentApproval{
+					RunID:        run.GetID(),
+					WorkflowName: run.GetName(),
+					HTMLURL:      run.GetHTMLURL(),
+					HeadBranch:   run.GetHeadBranch(),
+					HeadSHA:      run.GetHeadSHA(),
+					Actor:        run.GetActor().GetLogin(),
+					Deployments:  deployments,
+				})
+			}
+
+			return MarshalledTextResult(approvals), nil
+		}
+}
+
+// ReviewPendingDeployments creates a tool to approve or reject the pending deployments of a workflow run
+func ReviewPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_pending_deployments",
+			mcp.WithDescription(t("TOOL_REVIEW_PENDING_DEPLOYMENTS_DESCRIPTION", "Approve or reject the deployments of a workflow run waiting for approval, with a comment. By default, all the environments the current user can approve are reviewed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_PENDING_DEPLOYMENTS_USER_TITLE", "Review pending deployments"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[[]*github.Deployment](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("Whether to approve or reject the deployments"),
+				mcp.Enum("approved", "rejected"),
+			),
+			mcp.WithString("comment",
+				mcp.Required(),
+				mcp.Description("A comment explaining the review"),
+			),
+			mcp.WithArray("environment_ids",
+				mcp.Description("The IDs of the environments to review, as returned by list_pending_deployments. Defaults to all the environments the current user can approve."),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if state != "approved" && state != "rejected" {
+				return mcp.NewToolResultError("state must be approved or rejected"), nil
+			}
+			comment, err := RequiredParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environmentIDs, err := OptionalIntArrayParam(request, "environment_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ids := make([]int64, 0, len(environmentIDs))
+			for _, id := range environmentIDs {
+				ids = append(ids, int64(id))
+			}
+			if len(ids) == 0 {
+				pending, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, runID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pending deployments", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				for _, deployment := range pending {
+					if deployment.GetCurrentUserCanApprove() {
+						ids = append(ids, deployment.GetEnvironment().GetID())
+					}
+				}
+				if len(ids) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("workflow run %d has no pending deployments the current user can review", runID)), nil
+				}
+			}
+
+			deployments, resp, err := client.Actions.PendingDeployments(ctx, owner, repo, runID, &github.PendingDeploymentsRequest{
+				EnvironmentIDs: ids,
+				State:          state,
+				Comment:        comment,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to review pending deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(deployments), nil
+		}
+}
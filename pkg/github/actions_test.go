@@ -1,6 +1,8 @@
 package github
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
@@ -132,6 +134,107 @@ func Test_ListWorkflows(t *testing.T) {
 	}
 }
 
+func Test_GetWorkflow(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflow(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_workflow", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "workflow_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/owner/repo/actions/workflows/123", "/repos/owner/repo/actions/workflows/ci.yml":
+					_ = json.NewEncoder(w).Encode(&github.Workflow{
+						ID:    github.Ptr(int64(123)),
+						Name:  github.Ptr("CI"),
+						Path:  github.Ptr(".github/workflows/ci.yml"),
+						State: github.Ptr("active"),
+					})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				}
+			}),
+		),
+	)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "get workflow by ID",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "123",
+			},
+		},
+		{
+			name: "get workflow by file name",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "ci.yml",
+			},
+		},
+		{
+			name: "workflow not found",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "missing.yml",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow",
+		},
+		{
+			name: "missing required parameter workflow_id",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: workflow_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockedClient)
+			_, handler := GetWorkflow(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response github.Workflow
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, int64(123), response.GetID())
+			assert.Equal(t, ".github/workflows/ci.yml", response.GetPath())
+		})
+	}
+}
+
 func Test_RunWorkflow(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -368,6 +471,24 @@ func Test_CancelWorkflowRun(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to cancel workflow run",
 		},
+		{
+			name: "successful forced workflow run cancellation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsForceCancelByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusAccepted)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+				"force":  true,
+			},
+			expectError: false,
+		},
 		{
 			name:         "missing required parameter run_id",
 			mockedClient: mock.NewMockedHTTPClient(),
@@ -413,6 +534,120 @@ func Test_CancelWorkflowRun(t *testing.T) {
 	}
 }
 
+func Test_RerunWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RerunWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "rerun_workflow_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful workflow run re-run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsRerunByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusCreated)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+		},
+		{
+			name: "workflow run that can't be re-run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsRerunByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "This workflow run cannot be rerun"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to rerun workflow run",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RerunWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, "Workflow run has been queued for re-run", response["message"])
+			assert.Equal(t, float64(12345), response["run_id"])
+		})
+	}
+}
+
+func Test_RerunFailedJobs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RerunFailedJobs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "rerun_failed_jobs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	var rerunPath string
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposActionsRunsRerunFailedJobsByOwnerByRepoByRunId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rerunPath = r.URL.Path
+				w.WriteHeader(http.StatusCreated)
+			}),
+		),
+	))
+	_, handler := RerunFailedJobs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":  "owner",
+		"repo":   "repo",
+		"run_id": float64(12345),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, float64(12345), response["run_id"])
+	assert.Equal(t, "/repos/owner/repo/actions/runs/12345/rerun-failed-jobs", rerunPath)
+}
+
 func Test_ListWorkflowRunArtifacts(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -811,6 +1046,282 @@ func Test_GetWorkflowRunUsage(t *testing.T) {
 	}
 }
 
+func Test_ListPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_pending_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	pendingDeployment := &github.PendingDeployment{
+		Environment: &github.PendingDeploymentEnvironment{
+			ID:   github.Ptr(int64(161088068)),
+			Name: github.Ptr("production"),
+		},
+		CurrentUserCanApprove: github.Ptr(true),
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectError       bool
+		expectedErrMsg    string
+		expectedApprovals []PendingDeploymentApproval
+	}{
+		{
+			name: "lists the pending deployments of waiting runs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"status":   "waiting",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.WorkflowRuns{
+							TotalCount: github.Ptr(2),
+							WorkflowRuns: []*github.WorkflowRun{
+								{
+									ID:         github.Ptr(int64(1)),
+									Name:       github.Ptr("Deploy"),
+									HeadBranch: github.Ptr("main"),
+									Actor:      &github.User{Login: github.Ptr("octocat")},
+								},
+								{
+									ID:   github.Ptr(int64(2)),
+									Name: github.Ptr("Approve first-time contributor"),
+								},
+							},
+						}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					[]*github.PendingDeployment{pendingDeployment},
+					[]*github.PendingDeployment{},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedApprovals: []PendingDeploymentApproval{
+				{
+					RunID:        1,
+					WorkflowName: "Deploy",
+					HeadBranch:   "main",
+					Actor:        "octocat",
+					Deployments:  []*github.PendingDeployment{pendingDeployment},
+				},
+			},
+		},
+		{
+			name: "waiting runs fail to list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list waiting workflow runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response []PendingDeploymentApproval
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedApprovals, response)
+		})
+	}
+}
+
+func Test_ReviewPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "review_pending_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "comment")
+	assert.Contains(t, tool.InputSchema.Properties, "environment_ids")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id", "state", "comment"})
+
+	mockDeployments := []*github.Deployment{
+		{
+			ID:          github.Ptr(int64(42)),
+			Environment: github.Ptr("production"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "approves the given environments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]any{
+						"environment_ids": []any{float64(161088068)},
+						"state":           "approved",
+						"comment":         "Ship it",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockDeployments),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"state":           "approved",
+				"comment":         "Ship it",
+				"environment_ids": []any{float64(161088068)},
+			},
+		},
+		{
+			name: "rejects the environments the user can approve by default",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					[]*github.PendingDeployment{
+						{
+							Environment:           &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(1))},
+							CurrentUserCanApprove: github.Ptr(true),
+						},
+						{
+							Environment:           &github.PendingDeploymentEnvironment{ID: github.Ptr(int64(2))},
+							CurrentUserCanApprove: github.Ptr(false),
+						},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]any{
+						"environment_ids": []any{float64(1)},
+						"state":           "rejected",
+						"comment":         "Not during the freeze",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockDeployments),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"run_id":  float64(12345),
+				"state":   "rejected",
+				"comment": "Not during the freeze",
+			},
+		},
+		{
+			name: "no environments the user can approve",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					[]*github.PendingDeployment{},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"run_id":  float64(12345),
+				"state":   "approved",
+				"comment": "Ship it",
+			},
+			expectError:    true,
+			expectedErrMsg: "workflow run 12345 has no pending deployments the current user can review",
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"run_id":  float64(12345),
+				"state":   "pending",
+				"comment": "Ship it",
+			},
+			expectError:    true,
+			expectedErrMsg: "state must be approved or rejected",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReviewPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response []*github.Deployment
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			require.Len(t, response, 1)
+			assert.Equal(t, int64(42), response[0].GetID())
+		})
+	}
+}
+
 func Test_GetJobLogs(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1175,6 +1686,125 @@ func Test_GetJobLogs_WithContentReturnAndTailLines(t *testing.T) {
 	assert.NotContains(t, response, "logs_url") // Should not have URL when returning content
 }
 
+func Test_GetWorkflowRunLogs_WithContentReturn(t *testing.T) {
+	// Serve a log archive laid out like the ones of GitHub, with the log of each job at the root
+	var archive bytes.Buffer
+	zipWriter := zip.NewWriter(&archive)
+	for name, content := range map[string]string{
+		"0_build.txt":            "Starting build...\nCompiling...\nBuild completed",
+		"1_test.txt":             "Running tests...\nTests failed",
+		"build/1_Set up job.txt": "Setting up job",
+	} {
+		w, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(archive.Bytes())
+	}))
+	defer testServer.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsRunsLogsByOwnerByRepoByRunId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", testServer.URL)
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetWorkflowRunLogs(stubGetClientFn(client), translations.NullTranslationHelper, 5000)
+
+	request := createMCPRequest(map[string]any{
+		"owner":          "owner",
+		"repo":           "repo",
+		"run_id":         float64(456),
+		"return_content": true,
+		"tail_lines":     float64(2),
+	})
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var response struct {
+		RunID   int64  `json:"run_id"`
+		Message string `json:"message"`
+		Logs    []struct {
+			File           string `json:"file"`
+			LogsContent    string `json:"logs_content"`
+			OriginalLength int    `json:"original_length"`
+		} `json:"logs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+	assert.Equal(t, int64(456), response.RunID)
+	assert.Equal(t, "Retrieved logs for 2 jobs", response.Message)
+	require.Len(t, response.Logs, 2)
+	logs := map[string]string{}
+	for _, log := range response.Logs {
+		logs[log.File] = log.LogsContent
+	}
+	assert.Equal(t, map[string]string{
+		"0_build.txt": "Compiling...\nBuild completed",
+		"1_test.txt":  "Running tests...\nTests failed",
+	}, logs)
+}
+
+func Test_GetWorkflowRunLogs_FailedOnly(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+			&github.Jobs{
+				TotalCount: github.Ptr(2),
+				Jobs: []*github.WorkflowJob{
+					{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Conclusion: github.Ptr("success")},
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+				},
+			},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", "https://github.com/logs/job/2")
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetWorkflowRunLogs(stubGetClientFn(client), translations.NullTranslationHelper, 5000)
+
+	request := createMCPRequest(map[string]any{
+		"owner":       "owner",
+		"repo":        "repo",
+		"run_id":      float64(456),
+		"failed_only": true,
+	})
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+	assert.Equal(t, float64(2), response["total_jobs"])
+	assert.Equal(t, float64(1), response["failed_jobs"])
+	logs, ok := response["logs"].([]any)
+	require.True(t, ok)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "test", logs[0].(map[string]any)["job_name"])
+}
+
 func Test_GetJobLogs_WithContentReturnAndLargeTailLines(t *testing.T) {
 	logContent := "Line 1\nLine 2\nLine 3"
 	expectedLogContent := "Line 1\nLine 2\nLine 3"
@@ -0,0 +1,328 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxBatchSteps bounds the number of tool calls a single batch can make.
+const maxBatchSteps = 20
+
+var (
+	// wholeReferencePattern matches arguments that are entirely a reference, such as "$0.number",
+	// which are replaced by the referenced value keeping its JSON type.
+	wholeReferencePattern = regexp.MustCompile(`^\$(\d+)((?:\.[^.\s{}]+)*)$`)
+	// embeddedReferencePattern matches references embedded in longer strings, such as "Fixes #${0.number}".
+	embeddedReferencePattern = regexp.MustCompile(`\$\{(\d+)((?:\.[^.\s{}]+)*)\}`)
+)
+
+type batchStep struct {
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+type batchStepResult struct {
+	Tool   string `json:"tool"`
+	Status string `json:"status"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type batchResult struct {
+	Steps []batchStepResult `json:"steps"`
+}
+
+// Batch creates a tool that runs a list of calls to the other enabled tools in order, so that multi-step tasks
+// don't cost a round trip per step. Arguments can refer to the results of earlier steps. In read-only mode only read
+// tools are active, so the batch tool is read-only as well.
+func Batch(toolsetGroup *toolsets.ToolsetGroup, readOnly bool, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("batch",
+			mcp.WithDescription(t("TOOL_BATCH_DESCRIPTION", fmt.Sprintf("Run up to %d calls to other enabled tools in order within a single request, returning the result of each step. Arguments can refer to the result of an earlier step with \"$<step>.<path>\", e.g. \"$0.number\" or \"$1.items.0.sha\", which is replaced by the referenced value, or embed it in a string with \"${<step>.<path>}\", e.g. \"Fixes #${0.number}\". Steps are numbered from 0.", maxBatchSteps))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BATCH_USER_TITLE", "Run several tools"),
+				ReadOnlyHint: ToBoolPtr(readOnly),
+			}),
+			WithJSONOutputSchema[batchResult](),
+			mcp.WithArray("steps",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"tool"},
+						"properties": map[string]interface{}{
+							"tool": map[string]interface{}{
+								"type":        "string",
+								"description": "name of the tool to call",
+							},
+							"arguments": map[string]interface{}{
+								"type":        "object",
+								"description": "arguments of the tool",
+							},
+						},
+					}),
+				mcp.Description("Tool calls to run in order, each object with tool (string) and arguments (object)"),
+			),
+			mcp.WithBoolean("stop_on_error",
+				mcp.Description("Whether to skip the remaining steps once a step fails. Default is true."),
+				mcp.DefaultBool(true),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			steps, err := batchStepsParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			stopOnError, err := OptionalBoolParamWithDefault(request, "stop_on_error", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			results := make([]batchStepResult, len(steps))
+			// outputs holds the decoded result of each step, for references to resolve against
+			outputs := make([]any, len(steps))
+			failed := false
+			for i, step := range steps {
+				results[i] = batchStepResult{Tool: step.Tool}
+				if failed && stopOnError {
+					results[i].Status = "skipped"
+					continue
+				}
+
+				NotifyProgress(ctx, float64(i), float64(len(steps)), fmt.Sprintf("Running step %d: %s", i, step.Tool))
+				output, err := runBatchStep(ctx, toolsetGroup, step, results[:i], outputs[:i])
+				if err != nil {
+					results[i].Status = "error"
+					results[i].Error = err.Error()
+					failed = true
+				} else {
+					results[i].Status = "ok"
+					results[i].Result = output
+					outputs[i] = output
+				}
+				NotifyPartialResult(ctx, float64(i+1), float64(len(steps)), fmt.Sprintf("Finished step %d: %s", i, step.Tool), results[i])
+			}
+
+			r, err := json.Marshal(batchResult{Steps: results})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal batch results: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func batchStepsParam(request mcp.CallToolRequest) ([]batchStep, error) {
+	raw, ok := request.GetArguments()["steps"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("steps parameter must be an array of objects with tool and arguments")
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("steps must contain at least one step")
+	}
+	if len(raw) > maxBatchSteps {
+		return nil, fmt.Errorf("steps can't contain more than %d steps, got %d", maxBatchSteps, len(raw))
+	}
+
+	steps := make([]batchStep, 0, len(raw))
+	for i, item := range raw {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("step %d must be an object with tool and arguments", i)
+		}
+		name, ok := obj["tool"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("step %d is missing the tool to call", i)
+		}
+		arguments := map[string]any{}
+		if a, ok := obj["arguments"]; ok && a != nil {
+			if arguments, ok = a.(map[string]any); !ok {
+				return nil, fmt.Errorf("arguments of step %d must be an object", i)
+			}
+		}
+		steps = append(steps, batchStep{Tool: name, Arguments: arguments})
+	}
+	return steps, nil
+}
+
+// runBatchStep calls the tool of a step once its references are resolved, returning its decoded result.
+func runBatchStep(ctx context.Context, toolsetGroup *toolsets.ToolsetGroup, step batchStep, previous []batchStepResult, outputs []any) (any, error) {
+	if step.Tool == "batch" {
+		return nil, fmt.Errorf("batches can't be nested")
+	}
+	tool, ok := toolsetGroup.GetActiveTool(step.Tool)
+	if !ok {
+		return nil, fmt.Errorf("tool %s is not available, it may belong to a toolset that isn't enabled", step.Tool)
+	}
+
+	arguments, err := resolveReferences(step.Arguments, previous, outputs)
+	if err != nil {
+		return nil, err
+	}
+	resolved, _ := arguments.(map[string]any)
+	owner, _ := resolved["owner"].(string)
+	repo, _ := resolved["repo"].(string)
+	if err := checkRootsScope(ctx, owner, repo); err != nil {
+		return nil, err
+	}
+	// Forbidden requests of the step are recorded for its tool rather than for the batch
+	ctx, err = gateToolCall(ctx, tool.Tool, resolved)
+	if err != nil {
+		return nil, err
+	}
+	if result := confirmToolCall(ctx, tool.Tool, resolved); result != nil {
+		return toolResultValue(result)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = step.Tool
+	request.Params.Arguments = arguments
+	result, err := tool.Handler(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return toolResultValue(result)
+}
+
+// toolResultValue decodes the JSON text of a tool result, returning its text as an error if the tool failed.
+func toolResultValue(result *mcp.CallToolResult) (any, error) {
+	if result == nil {
+		return nil, nil
+	}
+	text, _ := resultText(result)
+	if result.IsError {
+		return nil, fmt.Errorf("%s", text)
+	}
+
+	// Results that aren't JSON, such as confirmations, are kept as text
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		return text, nil
+	}
+	return decoded, nil
+}
+
+// resolveReferences returns a copy of v where the references to the results of earlier steps are replaced.
+func resolveReferences(v any, previous []batchStepResult, outputs []any) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		resolved := make(map[string]any, len(v))
+		for k, field := range v {
+			r, err := resolveReferences(field, previous, outputs)
+			if err != nil {
+				return nil, err
+			}
+			resolved[k] = r
+		}
+		return resolved, nil
+	case []any:
+		resolved := make([]any, len(v))
+		for i, item := range v {
+			r, err := resolveReferences(item, previous, outputs)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = r
+		}
+		return resolved, nil
+	case string:
+		if m := wholeReferencePattern.FindStringSubmatch(v); m != nil {
+			return lookupReference(m[1], m[2], previous, outputs)
+		}
+		var lookupErr error
+		resolved := embeddedReferencePattern.ReplaceAllStringFunc(v, func(ref string) string {
+			m := embeddedReferencePattern.FindStringSubmatch(ref)
+			value, err := lookupReference(m[1], m[2], previous, outputs)
+			if err != nil {
+				lookupErr = err
+				return ref
+			}
+			if s, ok := value.(string); ok {
+				return s
+			}
+			b, err := json.Marshal(value)
+			if err != nil {
+				lookupErr = err
+				return ref
+			}
+			return string(b)
+		})
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		return resolved, nil
+	default:
+		return v, nil
+	}
+}
+
+// lookupReference returns the value at path, a list of dot-prefixed object keys or array indices, of a step result.
+func lookupReference(step, path string, previous []batchStepResult, outputs []any) (any, error) {
+	ref := "$" + step + path
+	i, err := strconv.Atoi(step)
+	if err != nil || i >= len(outputs) {
+		return nil, fmt.Errorf("reference %s must refer to an earlier step", ref)
+	}
+	if previous[i].Status != "ok" {
+		return nil, fmt.Errorf("reference %s refers to step %d, which failed", ref, i)
+	}
+
+	value := outputs[i]
+	for _, key := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		if key == "" {
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			field, ok := v[key]
+			if !ok {
+				return nil, fmt.Errorf("reference %s: result of step %d has no field %s", ref, i, key)
+			}
+			value = field
+		case []any:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("reference %s: index %s is out of range of a list of %d items", ref, key, len(v))
+			}
+			value = v[index]
+		default:
+			return nil, fmt.Errorf("reference %s: can't look up %s in a value that isn't an object or a list", ref, key)
+		}
+	}
+	return decodedArgument(value), nil
+}
+
+// decodedArgument converts the numbers of a decoded result to float64, as tools expect them in their arguments.
+func decodedArgument(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		converted := make(map[string]any, len(v))
+		for k, field := range v {
+			converted[k] = decodedArgument(field)
+		}
+		return converted
+	case []any:
+		converted := make([]any, len(v))
+		for i, item := range v {
+			converted[i] = decodedArgument(item)
+		}
+		return converted
+	default:
+		return v
+	}
+}
@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBatchTestToolsetGroup returns a toolset group with an enabled toolset whose tools echo their arguments,
+// along with a disabled one.
+func newBatchTestToolsetGroup() *toolsets.ToolsetGroup {
+	echo := func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		r, err := json.Marshal(request.GetArguments())
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(string(r)), nil
+	}
+	fail := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("failed to create pull request: 422 Validation Failed"), nil
+	}
+	confirm := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("Successfully starred repository"), nil
+	}
+
+	read := mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})
+	write := mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(false)})
+
+	tsg := toolsets.NewToolsetGroup(false)
+	enabled := toolsets.NewToolset("enabled", "enabled toolset").
+		AddReadTools(
+			toolsets.NewServerTool(mcp.NewTool("echo", read, mcp.WithString("owner"), mcp.WithString("repo")), echo),
+			toolsets.NewServerTool(mcp.NewTool("confirm", read), confirm),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(mcp.NewTool("fail", write), fail),
+		)
+	enabled.SetEnabled(true)
+	tsg.AddToolset(enabled)
+	tsg.AddToolset(toolsets.NewToolset("disabled", "disabled toolset").
+		AddReadTools(toolsets.NewServerTool(mcp.NewTool("hidden", read), echo)))
+	return tsg
+}
+
+func Test_Batch(t *testing.T) {
+	tool, _ := Batch(newBatchTestToolsetGroup(), false, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "batch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "steps")
+	assert.Contains(t, tool.InputSchema.Properties, "stop_on_error")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"steps"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedSteps  []batchStepResult
+		expectedErrMsg string
+	}{
+		{
+			name: "references earlier results",
+			requestArgs: map[string]interface{}{
+				"steps": []interface{}{
+					map[string]interface{}{"tool": "echo", "arguments": map[string]interface{}{"number": float64(42), "labels": []interface{}{"bug"}}},
+					map[string]interface{}{"tool": "echo", "arguments": map[string]interface{}{
+						"issue_number": "$0.number",
+						"label":        "$0.labels.0",
+						"body":         "Fixes #${0.number}",
+					}},
+					map[string]interface{}{"tool": "confirm"},
+				},
+			},
+			expectedSteps: []batchStepResult{
+				{Tool: "echo", Status: "ok", Result: map[string]any{"number": json.Number("42"), "labels": []any{"bug"}}},
+				{Tool: "echo", Status: "ok", Result: map[string]any{"issue_number": json.Number("42"), "label": "bug", "body": "Fixes #42"}},
+				{Tool: "confirm", Status: "ok", Result: "Successfully starred repository"},
+			},
+		},
+		{
+			name: "stops at the first error",
+			requestArgs: map[string]interface{}{
+				"steps": []interface{}{
+					map[string]interface{}{"tool": "fail"},
+					map[string]interface{}{"tool": "echo"},
+				},
+			},
+			expectedSteps: []batchStepResult{
+				{Tool: "fail", Status: "error", Error: "failed to create pull request: 422 Validation Failed"},
+				{Tool: "echo", Status: "skipped"},
+			},
+		},
+		{
+			name: "continues after errors",
+			requestArgs: map[string]interface{}{
+				"stop_on_error": false,
+				"steps": []interface{}{
+					map[string]interface{}{"tool": "hidden"},
+					map[string]interface{}{"tool": "batch"},
+					map[string]interface{}{"tool": "echo", "arguments": map[string]interface{}{"number": "$0.number"}},
+					map[string]interface{}{"tool": "echo", "arguments": map[string]interface{}{"number": "$5.number"}},
+					map[string]interface{}{"tool": "echo", "arguments": map[string]interface{}{"a": "b"}},
+					map[string]interface{}{"tool": "echo", "arguments": map[string]interface{}{"c": "$4.missing"}},
+				},
+			},
+			expectedSteps: []batchStepResult{
+				{Tool: "hidden", Status: "error", Error: "tool hidden is not available, it may belong to a toolset that isn't enabled"},
+				{Tool: "batch", Status: "error", Error: "batches can't be nested"},
+				{Tool: "echo", Status: "error", Error: "reference $0.number refers to step 0, which failed"},
+				{Tool: "echo", Status: "error", Error: "reference $5.number must refer to an earlier step"},
+				{Tool: "echo", Status: "ok", Result: map[string]any{"a": "b"}},
+				{Tool: "echo", Status: "error", Error: "reference $4.missing: result of step 4 has no field missing"},
+			},
+		},
+		{
+			name:           "no steps",
+			requestArgs:    map[string]interface{}{"steps": []interface{}{}},
+			expectError:    true,
+			expectedErrMsg: "steps must contain at least one step",
+		},
+		{
+			name: "step without tool",
+			requestArgs: map[string]interface{}{
+				"steps": []interface{}{map[string]interface{}{"arguments": map[string]interface{}{}}},
+			},
+			expectError:    true,
+			expectedErrMsg: "step 0 is missing the tool to call",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := Batch(newBatchTestToolsetGroup(), false, translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			decoder := json.NewDecoder(strings.NewReader(textContent.Text))
+			decoder.UseNumber()
+			var returned batchResult
+			require.NoError(t, decoder.Decode(&returned))
+			assert.Equal(t, tc.expectedSteps, returned.Steps)
+		})
+	}
+}
+
+func Test_BatchConfirmsSteps(t *testing.T) {
+	tsg := newBatchTestToolsetGroup()
+	_, batch := Batch(tsg, false, translations.NullTranslationHelper)
+	elicitor := &fakeElicitor{result: ElicitationResult{Action: ElicitationActionDecline}}
+	handler := ConfirmationMiddleware(elicitor, NewPolicyStore(Policy{ConfirmTools: []string{"fail"}}), tsg.GetActiveTool)(batch)
+
+	request := createMCPRequest(map[string]interface{}{
+		"steps": []interface{}{
+			map[string]interface{}{"tool": "echo", "arguments": map[string]interface{}{"owner": "octo-org"}},
+			map[string]interface{}{"tool": "fail"},
+		},
+	})
+	request.Params.Name = "batch"
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+
+	var returned batchResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned.Steps, 2)
+	assert.Empty(t, returned.Steps[0].Error)
+	assert.Equal(t, "the user didn't confirm fail, nothing was changed, don't retry unless the user asks to", returned.Steps[1].Error)
+	assert.Len(t, elicitor.messages, 1)
+}
+
+func Test_BatchGatesSteps(t *testing.T) {
+	grants := NewCredentialGrants(AuthModeGitHubAppInstallation)
+	grants.SetInstallationPermissions(func() map[string]string {
+		return map[string]string{"issues": "read"}
+	})
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetInstallationRepositories,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"total_count":1,"repositories":[{"full_name":"owner/repo"}]}`))
+			}),
+		),
+	))
+	createIssue := func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// The transport records the forbidden request of the step
+		grants.RecordDenial(ctx, "/repos/owner/repo/issues", "issues=write")
+		return mcp.NewToolResultError("forbidden"), nil
+	}
+	tsg := newBatchTestToolsetGroup()
+	issues := toolsets.NewToolset("issues", "issues toolset").
+		AddWriteTools(toolsets.NewServerTool(mcp.NewTool("create_issue", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(false)})), createIssue))
+	issues.SetEnabled(true)
+	tsg.AddToolset(issues)
+
+	_, batch := Batch(tsg, false, translations.NullTranslationHelper)
+	handler := PermissionGateMiddleware(grants, stubGetClientFn(client), tsg.GetActiveTool)(batch)
+	runStep := func(tool, repo string) batchStepResult {
+		request := createMCPRequest(map[string]interface{}{
+			"steps": []interface{}{
+				map[string]interface{}{"tool": tool, "arguments": map[string]interface{}{"owner": "owner", "repo": repo}},
+			},
+		})
+		request.Params.Name = "batch"
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+
+		var returned batchResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+		require.Len(t, returned.Steps, 1)
+		return returned.Steps[0]
+	}
+
+	assert.Equal(t, "forbidden", runStep("create_issue", "repo").Error)
+
+	// The denial is recorded for the tool of the step, so later batches still run
+	assert.Equal(t, "ok", runStep("echo", "repo").Status)
+	assert.Contains(t, runStep("create_issue", "repo").Error, "create_issue was forbidden earlier on owner/repo because the credential lacks the issues=write permission")
+
+	// Steps that write are checked against the repositories of the installation
+	assert.Equal(t, "the GitHub App installation doesn't have access to the repository owner/private", runStep("create_issue", "private").Error)
+	assert.Equal(t, "ok", runStep("echo", "private").Status)
+}
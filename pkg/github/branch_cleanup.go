@@ -0,0 +1,331 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// defaultStaleBranchDays is how long a branch goes without commits before list_stale_branches reports it,
+	// unless specified otherwise.
+	defaultStaleBranchDays = 90
+	// staleBranchPageSize is the number of branches looked at per query. Comparing each of them with the default
+	// branch is expensive, so pages are smaller than usual.
+	staleBranchPageSize = 50
+	// maxStaleBranchPages bounds the pages of branches list_stale_branches looks at.
+	maxStaleBranchPages = 20
+	// maxDeleteBranches is the number of branches delete_branches deletes at most in a single call.
+	maxDeleteBranches = 100
+)
+
+// staleBranchesQuery lists the branches of a repository with their last commit, their protection and whether they
+// have commits the default branch doesn't.
+type staleBranchesQuery struct {
+	Repository struct {
+		Refs struct {
+			Nodes []struct {
+				Name   githubv4.String
+				Target struct {
+					Commit struct {
+						OID           githubv4.GitObjectID `graphql:"oid"`
+						CommittedDate githubv4.DateTime
+					} `graphql:"... on Commit"`
+				}
+				BranchProtectionRule *struct {
+					Pattern githubv4.String
+				}
+				// Compare compares the branch, as base, with the default branch: the branch is behind by the
+				// commits only it has.
+				Compare *struct {
+					BehindBy githubv4.Int
+				} `graphql:"compare(headRef: $defaultBranch)"`
+			}
+			PageInfo struct {
+				HasNextPage githubv4.Boolean
+				EndCursor   githubv4.String
+			}
+		} `graphql:"refs(refPrefix: \"refs/heads/\", first: $first, after: $after)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// StaleBranch is a branch without recent commits.
+type StaleBranch struct {
+	Name           string `json:"name"`
+	SHA            string `json:"sha"`
+	LastCommitDate string `json:"last_commit_date"`
+	// Merged is set when the default branch has all the commits of the branch.
+	Merged bool `json:"merged"`
+}
+
+// StaleBranchReport is the output type of list_stale_branches.
+type StaleBranchReport struct {
+	DefaultBranch string        `json:"default_branch"`
+	StaleBefore   string        `json:"stale_before"`
+	Scanned       int           `json:"scanned"`
+	Truncated     bool          `json:"truncated,omitempty"`
+	Branches      []StaleBranch `json:"branches"`
+}
+
+// BranchDeletion is the outcome of deleting a branch.
+type BranchDeletion struct {
+	Branch string `json:"branch"`
+	// Status is deleted, would_delete in dry runs, skipped or error.
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// BranchDeletionResult is the output type of delete_branches.
+type BranchDeletionResult struct {
+	DryRun bool `json:"dry_run,omitempty"`
+	// Deleted counts the branches that would be deleted in dry runs.
+	Deleted  int              `json:"deleted"`
+	Skipped  int              `json:"skipped"`
+	Failed   int              `json:"failed"`
+	Branches []BranchDeletion `json:"branches"`
+}
+
+// ListStaleBranches creates a tool to list the branches of a repository that had no commits for a number of days.
+func ListStaleBranches(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_stale_branches",
+			mcp.WithDescription(t("TOOL_LIST_STALE_BRANCHES_DESCRIPTION", fmt.Sprintf("List the branches of a GitHub repository that had no commits for a number of days, oldest first, with whether the default branch has all their commits. The default branch and protected branches are never listed. Up to %d branches are looked at.", staleBranchPageSize*maxStaleBranchPages))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_STALE_BRANCHES_USER_TITLE", "List stale branches"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[StaleBranchReport](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("days",
+				mcp.Description(fmt.Sprintf("Number of days without commits after which a branch is stale. Default is %d.", defaultStaleBranchDays)),
+				mcp.Min(1),
+			),
+			mcp.WithBoolean("merged_only",
+				mcp.Description("Whether to only list branches whose commits are all on the default branch. Default is false."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			days, err := OptionalIntParamWithDefault(request, "days", defaultStaleBranchDays)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			mergedOnly, err := OptionalParam[bool](request, "merged_only")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			defaultBranch := repository.GetDefaultBranch()
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			staleBefore := time.Now().AddDate(0, 0, -days)
+			report := StaleBranchReport{
+				DefaultBranch: defaultBranch,
+				StaleBefore:   staleBefore.UTC().Format(time.RFC3339),
+				Branches:      []StaleBranch{},
+			}
+			vars := map[string]any{
+				"owner":         githubv4.String(owner),
+				"repo":          githubv4.String(repo),
+				"defaultBranch": githubv4.String(defaultBranch),
+				"first":         githubv4.Int(staleBranchPageSize),
+				"after":         (*githubv4.String)(nil),
+			}
+			for page := 0; ; page++ {
+				if page == maxStaleBranchPages {
+					report.Truncated = true
+					break
+				}
+				var query staleBranchesQuery
+				if err := gqlClient.Query(ctx, &query, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list branches", err), nil
+				}
+
+				refs := query.Repository.Refs
+				for _, ref := range refs.Nodes {
+					report.Scanned++
+					name := string(ref.Name)
+					if name == defaultBranch || ref.BranchProtectionRule != nil {
+						continue
+					}
+					committedDate := ref.Target.Commit.CommittedDate.Time
+					if !committedDate.Before(staleBefore) {
+						continue
+					}
+					merged := ref.Compare != nil && ref.Compare.BehindBy == 0
+					if mergedOnly && !merged {
+						continue
+					}
+					report.Branches = append(report.Branches, StaleBranch{
+						Name:           name,
+						SHA:            string(ref.Target.Commit.OID),
+						LastCommitDate: committedDate.UTC().Format(time.RFC3339),
+						Merged:         merged,
+					})
+				}
+				if !refs.PageInfo.HasNextPage {
+					break
+				}
+				NotifyProgress(ctx, float64(report.Scanned), 0, fmt.Sprintf("Scanned %d branches", report.Scanned))
+				vars["after"] = githubv4.NewString(refs.PageInfo.EndCursor)
+			}
+
+			sort.SliceStable(report.Branches, func(i, j int) bool {
+				return report.Branches[i].LastCommitDate < report.Branches[j].LastCommitDate
+			})
+			return MarshalledTextResult(report), nil
+		}
+}
+
+// DeleteBranches creates a tool to delete several branches of a repository at once.
+func DeleteBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_branches",
+			mcp.WithDescription(t("TOOL_DELETE_BRANCHES_DESCRIPTION", fmt.Sprintf("Delete up to %d branches of a GitHub repository, reporting the outcome for each. The default branch and protected branches are skipped. Use dry_run to check which branches would be deleted without deleting them.", maxDeleteBranches))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_BRANCHES_USER_TITLE", "Delete branches"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[BranchDeletionResult](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("branches",
+				mcp.Required(),
+				mcp.Items(map[string]interface{}{
+					"type": "string",
+				}),
+				mcp.Description("Names of the branches to delete"),
+			),
+			mcp.WithBoolean("dry_run",
+				mcp.Description("Whether to only report which branches would be deleted. Default is false."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branches, err := OptionalStringArrayParam(request, "branches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(branches) == 0 {
+				return mcp.NewToolResultError("missing required parameter: branches"), nil
+			}
+			if len(branches) > maxDeleteBranches {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d branches can be deleted at once", maxDeleteBranches)), nil
+			}
+			dryRun, err := OptionalParam[bool](request, "dry_run")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			result := BranchDeletionResult{DryRun: dryRun, Branches: make([]BranchDeletion, 0, len(branches))}
+			for i, name := range branches {
+				NotifyProgress(ctx, float64(i), float64(len(branches)), fmt.Sprintf("Deleting %s", name))
+				deletion := deleteBranch(ctx, client, owner, repo, name, repository.GetDefaultBranch(), dryRun)
+				switch deletion.Status {
+				case "deleted", "would_delete":
+					result.Deleted++
+				case "skipped":
+					result.Skipped++
+				default:
+					result.Failed++
+				}
+				result.Branches = append(result.Branches, deletion)
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// deleteBranch deletes a branch unless it is the default branch or protected, or checks that it could in dry runs.
+func deleteBranch(ctx context.Context, client *github.Client, owner, repo, name, defaultBranch string, dryRun bool) BranchDeletion {
+	if name == defaultBranch {
+		return BranchDeletion{Branch: name, Status: "skipped", Reason: "the default branch can't be deleted"}
+	}
+
+	branch, resp, err := client.Repositories.GetBranch(ctx, owner, repo, name, 0)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return BranchDeletion{Branch: name, Status: "skipped", Reason: "the branch doesn't exist"}
+		}
+		return BranchDeletion{Branch: name, Status: "error", Reason: fmt.Sprintf("failed to get branch: %s", err)}
+	}
+	_ = resp.Body.Close()
+	if branch.GetProtected() {
+		return BranchDeletion{Branch: name, Status: "skipped", Reason: "the branch is protected"}
+	}
+	if dryRun {
+		return BranchDeletion{Branch: name, Status: "would_delete"}
+	}
+
+	resp, err = client.Git.DeleteRef(ctx, owner, repo, "refs/heads/"+name)
+	if err != nil {
+		return BranchDeletion{Branch: name, Status: "error", Reason: fmt.Sprintf("failed to delete branch: %s", err)}
+	}
+	_ = resp.Body.Close()
+	return BranchDeletion{Branch: name, Status: "deleted"}
+}
@@ -0,0 +1,194 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListStaleBranches(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListStaleBranches(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_stale_branches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "days")
+	assert.Contains(t, tool.InputSchema.Properties, "merged_only")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	old := time.Now().AddDate(-1, 0, 0).UTC().Truncate(time.Second)
+	recent := time.Now().AddDate(0, 0, -1).UTC().Truncate(time.Second)
+	branch := func(name string, committed time.Time, protected bool, behindBy int) map[string]any {
+		node := map[string]any{
+			"name":    name,
+			"target":  map[string]any{"oid": name + "-sha", "committedDate": committed.Format(time.RFC3339)},
+			"compare": map[string]any{"behindBy": behindBy},
+		}
+		if protected {
+			node["branchProtectionRule"] = map[string]any{"pattern": name}
+		}
+		return node
+	}
+	vars := map[string]any{
+		"owner":         githubv4.String("owner"),
+		"repo":          githubv4.String("repo"),
+		"defaultBranch": githubv4.String("main"),
+		"first":         githubv4.Int(staleBranchPageSize),
+		"after":         (*githubv4.String)(nil),
+	}
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(staleBranchesQuery{}, vars, githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"refs": map[string]any{
+					"nodes": []any{
+						branch("main", old, false, 0),
+						branch("abandoned", old.Add(time.Hour), false, 2),
+						branch("release", old, true, 0),
+						branch("merged", old, false, 0),
+						branch("active", recent, false, 5),
+					},
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "cursor1"},
+				},
+			},
+		})),
+	))
+	restClient := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposByOwnerByRepo,
+			&github.Repository{DefaultBranch: github.Ptr("main")},
+			&github.Repository{DefaultBranch: github.Ptr("main")},
+		),
+	))
+	_, handler := ListStaleBranches(stubGetClientFn(restClient), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+	t.Run("lists unprotected branches without recent commits", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+			"days":  float64(30),
+		}))
+		require.NoError(t, err)
+
+		var report StaleBranchReport
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+		assert.Equal(t, "main", report.DefaultBranch)
+		assert.Equal(t, 5, report.Scanned)
+		assert.False(t, report.Truncated)
+		assert.Equal(t, []StaleBranch{
+			{Name: "merged", SHA: "merged-sha", LastCommitDate: old.Format(time.RFC3339), Merged: true},
+			{Name: "abandoned", SHA: "abandoned-sha", LastCommitDate: old.Add(time.Hour).Format(time.RFC3339)},
+		}, report.Branches)
+	})
+
+	t.Run("lists merged branches only", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"merged_only": true,
+		}))
+		require.NoError(t, err)
+
+		var report StaleBranchReport
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+		require.Len(t, report.Branches, 1)
+		assert.Equal(t, "merged", report.Branches[0].Name)
+	})
+}
+
+func Test_DeleteBranches(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_branches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branches"})
+
+	newClient := func(deleted *[]string) *github.Client {
+		return github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposByOwnerByRepo,
+				&github.Repository{DefaultBranch: github.Ptr("main")},
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposBranchesByOwnerByRepoByBranch,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.URL.Path {
+					case "/repos/owner/repo/branches/release":
+						_ = json.NewEncoder(w).Encode(&github.Branch{Name: github.Ptr("release"), Protected: github.Ptr(true)})
+					case "/repos/owner/repo/branches/missing":
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Branch not found"}`))
+					default:
+						_ = json.NewEncoder(w).Encode(&github.Branch{Name: github.Ptr("stale"), Protected: github.Ptr(false)})
+					}
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposGitRefsByOwnerByRepoByRef,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					*deleted = append(*deleted, r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			),
+		))
+	}
+	args := map[string]any{
+		"owner":    "owner",
+		"repo":     "repo",
+		"branches": []any{"stale", "main", "release", "missing"},
+	}
+	expectedBranches := func(status string) []BranchDeletion {
+		return []BranchDeletion{
+			{Branch: "stale", Status: status},
+			{Branch: "main", Status: "skipped", Reason: "the default branch can't be deleted"},
+			{Branch: "release", Status: "skipped", Reason: "the branch is protected"},
+			{Branch: "missing", Status: "skipped", Reason: "the branch doesn't exist"},
+		}
+	}
+
+	t.Run("deletes unprotected branches", func(t *testing.T) {
+		var deleted []string
+		_, handler := DeleteBranches(stubGetClientFn(newClient(&deleted)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+
+		var deletion BranchDeletionResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &deletion))
+		assert.Equal(t, BranchDeletionResult{Deleted: 1, Skipped: 3, Branches: expectedBranches("deleted")}, deletion)
+		assert.Equal(t, []string{"/repos/owner/repo/git/refs/heads/stale"}, deleted)
+	})
+
+	t.Run("dry run deletes nothing", func(t *testing.T) {
+		var deleted []string
+		_, handler := DeleteBranches(stubGetClientFn(newClient(&deleted)), translations.NullTranslationHelper)
+
+		dryRunArgs := map[string]any{"dry_run": true}
+		for k, v := range args {
+			dryRunArgs[k] = v
+		}
+		result, err := handler(context.Background(), createMCPRequest(dryRunArgs))
+		require.NoError(t, err)
+
+		var deletion BranchDeletionResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &deletion))
+		assert.Equal(t, BranchDeletionResult{DryRun: true, Deleted: 1, Skipped: 3, Branches: expectedBranches("would_delete")}, deletion)
+		assert.Empty(t, deleted)
+	})
+}
@@ -0,0 +1,457 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalBranchProtection is the output type for the protection of a branch.
+type MinimalBranchProtection struct {
+	Branch                         string                       `json:"branch"`
+	Protected                      bool                         `json:"protected"`
+	RequiredStatusChecks           *MinimalRequiredStatusChecks `json:"required_status_checks,omitempty"`
+	RequiredReviews                *MinimalRequiredReviews      `json:"required_reviews,omitempty"`
+	EnforceAdmins                  bool                         `json:"enforce_admins"`
+	RequireLinearHistory           bool                         `json:"require_linear_history"`
+	RequiredConversationResolution bool                         `json:"required_conversation_resolution"`
+	RequiredSignatures             bool                         `json:"required_signatures"`
+	AllowForcePushes               bool                         `json:"allow_force_pushes"`
+	AllowDeletions                 bool                         `json:"allow_deletions"`
+	// PushRestricted is whether only some users, teams and apps can push to the branch.
+	PushRestricted bool `json:"push_restricted"`
+}
+
+// MinimalRequiredStatusChecks is the output type for the status checks a branch protection requires.
+type MinimalRequiredStatusChecks struct {
+	// Strict is whether branches must be up to date with the protected branch before merging.
+	Strict bool     `json:"strict"`
+	Checks []string `json:"checks"`
+}
+
+// MinimalRequiredReviews is the output type for the reviews a branch protection requires.
+type MinimalRequiredReviews struct {
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+	RequireLastPushApproval      bool `json:"require_last_push_approval"`
+}
+
+func convertToMinimalBranchProtection(branch string, protection *github.Protection) MinimalBranchProtection {
+	minimalProtection := MinimalBranchProtection{
+		Branch:             branch,
+		Protected:          true,
+		RequiredSignatures: protection.GetRequiredSignatures().GetEnabled(),
+		PushRestricted:     protection.Restrictions != nil,
+	}
+	if protection.EnforceAdmins != nil {
+		minimalProtection.EnforceAdmins = protection.EnforceAdmins.Enabled
+	}
+	if protection.RequireLinearHistory != nil {
+		minimalProtection.RequireLinearHistory = protection.RequireLinearHistory.Enabled
+	}
+	if protection.RequiredConversationResolution != nil {
+		minimalProtection.RequiredConversationResolution = protection.RequiredConversationResolution.Enabled
+	}
+	if protection.AllowForcePushes != nil {
+		minimalProtection.AllowForcePushes = protection.AllowForcePushes.Enabled
+	}
+	if protection.AllowDeletions != nil {
+		minimalProtection.AllowDeletions = protection.AllowDeletions.Enabled
+	}
+	if checks := protection.RequiredStatusChecks; checks != nil {
+		minimalProtection.RequiredStatusChecks = &MinimalRequiredStatusChecks{
+			Strict: checks.Strict,
+			Checks: requiredStatusCheckNames(checks),
+		}
+	}
+	if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+		minimalProtection.RequiredReviews = &MinimalRequiredReviews{
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequireLastPushApproval:      reviews.RequireLastPushApproval,
+		}
+	}
+	return minimalProtection
+}
+
+func requiredStatusCheckNames(checks *github.RequiredStatusChecks) []string {
+	names := []string{}
+	if checks.Checks != nil {
+		for _, check := range *checks.Checks {
+			names = append(names, check.Context)
+		}
+	} else if checks.Contexts != nil {
+		names = append(names, *checks.Contexts...)
+	}
+	return names
+}
+
+// actorLogins returns the logins of users and the slugs of teams and apps, as protection requests name them.
+func actorLogins(users []*github.User, teams []*github.Team, apps []*github.App) ([]string, []string, []string) {
+	userLogins, teamSlugs, appSlugs := []string{}, []string{}, []string{}
+	for _, user := range users {
+		userLogins = append(userLogins, user.GetLogin())
+	}
+	for _, team := range teams {
+		teamSlugs = append(teamSlugs, team.GetSlug())
+	}
+	for _, app := range apps {
+		appSlugs = append(appSlugs, app.GetSlug())
+	}
+	return userLogins, teamSlugs, appSlugs
+}
+
+// protectionRequest returns the request keeping a branch protection as it is, since updates replace all its settings.
+func protectionRequest(protection *github.Protection) *github.ProtectionRequest {
+	current := convertToMinimalBranchProtection("", protection)
+	request := &github.ProtectionRequest{
+		RequiredStatusChecks:           protection.RequiredStatusChecks,
+		EnforceAdmins:                  current.EnforceAdmins,
+		RequireLinearHistory:           github.Ptr(current.RequireLinearHistory),
+		AllowForcePushes:               github.Ptr(current.AllowForcePushes),
+		AllowDeletions:                 github.Ptr(current.AllowDeletions),
+		RequiredConversationResolution: github.Ptr(current.RequiredConversationResolution),
+	}
+	if protection.BlockCreations != nil {
+		request.BlockCreations = protection.BlockCreations.Enabled
+	}
+	if protection.LockBranch != nil {
+		request.LockBranch = protection.LockBranch.Enabled
+	}
+	if protection.AllowForkSyncing != nil {
+		request.AllowForkSyncing = protection.AllowForkSyncing.Enabled
+	}
+	if checks := request.RequiredStatusChecks; checks != nil {
+		// Urls are read-only, and contexts can't be sent along with checks.
+		request.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: checks.Strict, Checks: checks.Checks, Contexts: checks.Contexts}
+		if checks.Checks != nil {
+			request.RequiredStatusChecks.Contexts = nil
+		}
+	}
+	if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+		request.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      github.Ptr(reviews.RequireLastPushApproval),
+		}
+		if dismissal := reviews.DismissalRestrictions; dismissal != nil {
+			users, teams, apps := actorLogins(dismissal.Users, dismissal.Teams, dismissal.Apps)
+			request.RequiredPullRequestReviews.DismissalRestrictionsRequest = &github.DismissalRestrictionsRequest{Users: &users, Teams: &teams, Apps: &apps}
+		}
+		if bypass := reviews.BypassPullRequestAllowances; bypass != nil {
+			users, teams, apps := actorLogins(bypass.Users, bypass.Teams, bypass.Apps)
+			request.RequiredPullRequestReviews.BypassPullRequestAllowancesRequest = &github.BypassPullRequestAllowancesRequest{Users: users, Teams: teams, Apps: apps}
+		}
+	}
+	if restrictions := protection.Restrictions; restrictions != nil {
+		users, teams, apps := actorLogins(restrictions.Users, restrictions.Teams, restrictions.Apps)
+		request.Restrictions = &github.BranchRestrictionsRequest{Users: users, Teams: teams, Apps: apps}
+	}
+	return request
+}
+
+// withBranch adds the parameters naming a branch of a repository.
+func withBranch() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		)(tool)
+		mcp.WithString("branch",
+			mcp.Required(),
+			mcp.Description("Branch name, no wildcard characters are allowed"),
+		)(tool)
+	}
+}
+
+func requiredBranch(request mcp.CallToolRequest) (string, string, string, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return "", "", "", err
+	}
+	repo, err := RequiredParam[string](request, "repo")
+	if err != nil {
+		return "", "", "", err
+	}
+	branch, err := RequiredParam[string](request, "branch")
+	if err != nil {
+		return "", "", "", err
+	}
+	return owner, repo, branch, nil
+}
+
+// GetBranchProtection creates a tool to get the protection of a branch.
+func GetBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_protection",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_PROTECTION_DESCRIPTION", "Get the protection of a branch of a GitHub repository: the status checks and reviews it requires before merging, and whether it applies to admins. Rulesets, which can also protect branches, aren't included. Requires admin access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_PROTECTION_USER_TITLE", "Get branch protection"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[MinimalBranchProtection](),
+			withBranch(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, branch, err := requiredBranch(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			if errors.Is(err, github.ErrBranchNotProtected) {
+				return MarshalledTextResult(MinimalBranchProtection{Branch: branch}), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get the protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalBranchProtection(branch, protection)), nil
+		}
+}
+
+// UpdateBranchProtection creates a tool to protect a branch, or to change its protection.
+func UpdateBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_branch_protection",
+			mcp.WithDescription(t("TOOL_UPDATE_BRANCH_PROTECTION_DESCRIPTION", "Protect a branch of a GitHub repository, or change its protection. Only the given settings are changed, the others are kept as they are, or left off for branches that weren't protected. Requires admin access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_BRANCH_PROTECTION_USER_TITLE", "Update branch protection"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalBranchProtection](),
+			withBranch(),
+			mcp.WithArray("required_status_checks",
+				mcp.Description("Names of the status checks that must pass before merging, empty to not require any"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithBoolean("require_up_to_date",
+				mcp.Description("Whether branches must be up to date with the protected branch before merging, when status checks are required"),
+			),
+			mcp.WithNumber("required_approving_review_count",
+				mcp.Description("Number of approving reviews pull requests need before merging, from 1 to 6, or 0 to not require reviews"),
+				mcp.Min(0),
+				mcp.Max(6),
+			),
+			mcp.WithBoolean("dismiss_stale_reviews",
+				mcp.Description("Whether new commits dismiss the approving reviews, when reviews are required"),
+			),
+			mcp.WithBoolean("require_code_owner_reviews",
+				mcp.Description("Whether code owners must approve the changes to the files they own, when reviews are required"),
+			),
+			mcp.WithBoolean("require_last_push_approval",
+				mcp.Description("Whether the last push must be approved by someone else than its author, when reviews are required"),
+			),
+			mcp.WithBoolean("enforce_admins",
+				mcp.Description("Whether the protection also applies to repository admins"),
+			),
+			mcp.WithBoolean("require_linear_history",
+				mcp.Description("Whether merge commits can't be pushed to the branch"),
+			),
+			mcp.WithBoolean("required_conversation_resolution",
+				mcp.Description("Whether the review conversations of pull requests must be resolved before merging"),
+			),
+			mcp.WithBoolean("allow_force_pushes",
+				mcp.Description("Whether users with push access can force push to the branch"),
+			),
+			mcp.WithBoolean("allow_deletions",
+				mcp.Description("Whether users with push access can delete the branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, branch, err := requiredBranch(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasStatusChecks := request.GetArguments()["required_status_checks"]
+			statusChecks, err := OptionalStringArrayParam(request, "required_status_checks")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reviewCount, hasReviewCount, err := OptionalParamOK[float64](request, "required_approving_review_count")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if reviewCount < 0 || reviewCount > 6 {
+				return mcp.NewToolResultError("required_approving_review_count must be between 0 and 6"), nil
+			}
+			requireUpToDate, hasRequireUpToDate, err := OptionalParamOK[bool](request, "require_up_to_date")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			booleans := map[string]*bool{}
+			for _, name := range []string{
+				"dismiss_stale_reviews", "require_code_owner_reviews", "require_last_push_approval", "enforce_admins",
+				"require_linear_history", "required_conversation_resolution", "allow_force_pushes", "allow_deletions",
+			} {
+				value, ok, err := OptionalParamOK[bool](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					booleans[name] = github.Ptr(value)
+				}
+			}
+			if !hasStatusChecks && !hasReviewCount && !hasRequireUpToDate && len(booleans) == 0 {
+				return mcp.NewToolResultError("at least one protection setting to change must be given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protection := &github.ProtectionRequest{}
+			current, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+				protection = protectionRequest(current)
+			case !errors.Is(err, github.ErrBranchNotProtected):
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get the protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+
+			if hasStatusChecks {
+				if len(statusChecks) == 0 {
+					protection.RequiredStatusChecks = nil
+				} else {
+					// Keep the apps checks had to be reported by.
+					appIDs := map[string]*int64{}
+					if protection.RequiredStatusChecks != nil && protection.RequiredStatusChecks.Checks != nil {
+						for _, check := range *protection.RequiredStatusChecks.Checks {
+							appIDs[check.Context] = check.AppID
+						}
+					}
+					checks := make([]*github.RequiredStatusCheck, 0, len(statusChecks))
+					for _, name := range statusChecks {
+						checks = append(checks, &github.RequiredStatusCheck{Context: name, AppID: appIDs[name]})
+					}
+					strict := protection.RequiredStatusChecks != nil && protection.RequiredStatusChecks.Strict
+					protection.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: strict, Checks: &checks}
+				}
+			}
+			if hasRequireUpToDate {
+				if protection.RequiredStatusChecks == nil {
+					return mcp.NewToolResultError("require_up_to_date needs required_status_checks"), nil
+				}
+				protection.RequiredStatusChecks.Strict = requireUpToDate
+			}
+
+			if hasReviewCount {
+				if reviewCount == 0 {
+					protection.RequiredPullRequestReviews = nil
+				} else {
+					if protection.RequiredPullRequestReviews == nil {
+						protection.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{}
+					}
+					protection.RequiredPullRequestReviews.RequiredApprovingReviewCount = int(reviewCount)
+				}
+			}
+			for _, name := range []string{"dismiss_stale_reviews", "require_code_owner_reviews", "require_last_push_approval"} {
+				if _, ok := booleans[name]; ok && protection.RequiredPullRequestReviews == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s needs required_approving_review_count", name)), nil
+				}
+			}
+			if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+				if value, ok := booleans["dismiss_stale_reviews"]; ok {
+					reviews.DismissStaleReviews = *value
+				}
+				if value, ok := booleans["require_code_owner_reviews"]; ok {
+					reviews.RequireCodeOwnerReviews = *value
+				}
+				if value, ok := booleans["require_last_push_approval"]; ok {
+					reviews.RequireLastPushApproval = value
+				}
+			}
+
+			if value, ok := booleans["enforce_admins"]; ok {
+				protection.EnforceAdmins = *value
+			}
+			for name, field := range map[string]**bool{
+				"require_linear_history":           &protection.RequireLinearHistory,
+				"required_conversation_resolution": &protection.RequiredConversationResolution,
+				"allow_force_pushes":               &protection.AllowForcePushes,
+				"allow_deletions":                  &protection.AllowDeletions,
+			} {
+				if value, ok := booleans[name]; ok {
+					*field = value
+				}
+			}
+
+			updated, resp, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, protection)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update the protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalBranchProtection(branch, updated)), nil
+		}
+}
+
+// DeleteBranchProtection creates a tool to remove the protection of a branch.
+func DeleteBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_branch_protection",
+			mcp.WithDescription(t("TOOL_DELETE_BRANCH_PROTECTION_DESCRIPTION", "Remove the protection of a branch of a GitHub repository, so that it can be pushed to and merged into without checks or reviews. Rulesets targeting the branch still apply. Requires admin access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_BRANCH_PROTECTION_USER_TITLE", "Delete branch protection"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			withBranch(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, branch, err := requiredBranch(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.RemoveBranchProtection(ctx, owner, repo, branch)
+			if errors.Is(err, github.ErrBranchNotProtected) {
+				return mcp.NewToolResultError(fmt.Sprintf("branch %s of %s/%s is not protected", branch, owner, repo)), nil
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete the protection of branch %s", branch),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Branch %s of %s/%s is no longer protected", branch, owner, repo)), nil
+		}
+}
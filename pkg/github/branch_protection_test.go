@@ -0,0 +1,306 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mockBranchProtection = &github.Protection{
+	RequiredStatusChecks: &github.RequiredStatusChecks{
+		Strict: true,
+		Checks: &[]*github.RequiredStatusCheck{
+			{Context: "build", AppID: github.Ptr(int64(15368))},
+		},
+	},
+	RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+		RequiredApprovingReviewCount: 1,
+		DismissalRestrictions: &github.DismissalRestrictions{
+			Users: []*github.User{{Login: github.Ptr("octocat")}},
+		},
+	},
+	EnforceAdmins:        &github.AdminEnforcement{Enabled: false},
+	RequireLinearHistory: &github.RequireLinearHistory{Enabled: true},
+	AllowForcePushes:     &github.AllowForcePushes{Enabled: false},
+	AllowDeletions:       &github.AllowDeletions{Enabled: false},
+}
+
+func Test_GetBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_branch_protection", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		expectError        bool
+		expectedErrMsg     string
+		expectedProtection MinimalBranchProtection
+	}{
+		{
+			name: "protected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockBranchProtection,
+				),
+			),
+			expectedProtection: MinimalBranchProtection{
+				Branch:               "main",
+				Protected:            true,
+				RequiredStatusChecks: &MinimalRequiredStatusChecks{Strict: true, Checks: []string{"build"}},
+				RequiredReviews:      &MinimalRequiredReviews{RequiredApprovingReviewCount: 1},
+				RequireLinearHistory: true,
+			},
+		},
+		{
+			name: "branch not protected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+				),
+			),
+			expectedProtection: MinimalBranchProtection{Branch: "main"},
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get the protection of branch main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var protection MinimalBranchProtection
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &protection))
+			assert.Equal(t, tc.expectedProtection, protection)
+		})
+	}
+}
+
+func Test_UpdateBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_branch_protection", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "required_status_checks")
+	assert.Contains(t, tool.InputSchema.Properties, "required_approving_review_count")
+	assert.Contains(t, tool.InputSchema.Properties, "enforce_admins")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "existing protection is kept",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockBranchProtection,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]any{
+						"required_status_checks": map[string]any{
+							"strict": true,
+							"checks": []any{
+								map[string]any{"context": "build", "app_id": float64(15368)},
+								map[string]any{"context": "lint"},
+							},
+						},
+						"required_pull_request_reviews": map[string]any{
+							"dismissal_restrictions":          map[string]any{"users": []any{"octocat"}, "teams": []any{}, "apps": []any{}},
+							"dismiss_stale_reviews":           false,
+							"require_code_owner_reviews":      true,
+							"required_approving_review_count": float64(2),
+							"require_last_push_approval":      false,
+						},
+						"enforce_admins":                   true,
+						"restrictions":                     nil,
+						"required_linear_history":          true,
+						"allow_force_pushes":               false,
+						"allow_deletions":                  false,
+						"required_conversation_resolution": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockBranchProtection),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                           "owner",
+				"repo":                            "repo",
+				"branch":                          "main",
+				"required_status_checks":          []any{"build", "lint"},
+				"required_approving_review_count": float64(2),
+				"require_code_owner_reviews":      true,
+				"enforce_admins":                  true,
+			},
+		},
+		{
+			name: "unprotected branch is protected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+					expectRequestBody(t, map[string]any{
+						"required_status_checks": map[string]any{
+							"strict": true,
+							"checks": []any{map[string]any{"context": "build"}},
+						},
+						"required_pull_request_reviews": nil,
+						"enforce_admins":                false,
+						"restrictions":                  nil,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockBranchProtection),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"branch":                 "main",
+				"required_status_checks": []any{"build"},
+				"require_up_to_date":     true,
+			},
+		},
+		{
+			name:         "nothing to change",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one protection setting to change must be given",
+		},
+		{
+			name: "review settings without reviews",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"branch":                "main",
+				"dismiss_stale_reviews": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "dismiss_stale_reviews needs required_approving_review_count",
+		},
+		{
+			name: "no admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"branch":         "main",
+				"enforce_admins": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get the protection of branch main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var protection MinimalBranchProtection
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &protection))
+			assert.True(t, protection.Protected)
+		})
+	}
+}
+
+func Test_DeleteBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_branch_protection", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposBranchesProtectionByOwnerByRepoByBranch,
+			expectPath(t, "/repos/owner/repo/branches/main/protection").andThen(
+				mockResponse(t, http.StatusNoContent, ""),
+			),
+		),
+	))
+	_, handler := DeleteBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":  "owner",
+		"repo":   "repo",
+		"branch": "main",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "Branch main of owner/repo is no longer protected", getTextResult(t, result).Text)
+}
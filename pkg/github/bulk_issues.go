@@ -0,0 +1,351 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxBulkIssues is the number of issues bulk_update_issues updates at most in a single call.
+const maxBulkIssues = 100
+
+// BulkIssueUpdate is the outcome of updating one of the issues of a bulk update.
+type BulkIssueUpdate struct {
+	Number int    `json:"number"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkIssuesResult is the output type of the tools updating several issues at once.
+type BulkIssuesResult struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	// Truncated is set when the query matched more issues than are updated in a single call.
+	Truncated bool              `json:"truncated,omitempty"`
+	Issues    []BulkIssueUpdate `json:"issues"`
+}
+
+// bulkIssueChanges are the changes applied to each issue of a bulk update.
+type bulkIssueChanges struct {
+	comment      string
+	addLabels    []string
+	removeLabels []string
+	assignees    []string
+	milestone    int
+	state        string
+	stateReason  string
+}
+
+// BulkUpdateIssues creates a tool to apply the same labels, assignees, milestone or state to several issues.
+func BulkUpdateIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("bulk_update_issues",
+			mcp.WithDescription(t("TOOL_BULK_UPDATE_ISSUES_DESCRIPTION", fmt.Sprintf("Apply the same changes to up to %d issues of a GitHub repository in one call: add or remove labels, add assignees, set the milestone, or change the state. The issues are given by number or by a search query. The outcome is reported for each issue, so some may fail while others are updated.", maxBulkIssues))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BULK_UPDATE_ISSUES_USER_TITLE", "Update issues in bulk"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[BulkIssuesResult](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Description("Numbers of the issues to update. Either issue_numbers or query is required"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithString("query",
+				mcp.Description("Search query selecting the issues to update, using GitHub issues search syntax already scoped to the repository and to is:issue, e.g. \"is:open label:bug no:milestone\""),
+			),
+			mcp.WithArray("add_labels",
+				mcp.Description("Labels to add"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("remove_labels",
+				mcp.Description("Labels to remove"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("assignees",
+				mcp.Description("Users to assign"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithNumber("milestone",
+				mcp.Description("Milestone number to set"),
+			),
+			mcp.WithString("state",
+				mcp.Description("New state"),
+				mcp.Enum("open", "closed"),
+			),
+			mcp.WithString("state_reason",
+				mcp.Description("Reason for the state change. Ignored unless state is changed."),
+				mcp.Enum("completed", "not_planned", "reopened"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := MutuallyExclusiveParams(request, "issue_numbers", "query"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			numbers, err := OptionalIntArrayParam(request, "issue_numbers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var changes bulkIssueChanges
+			if changes.addLabels, err = OptionalStringArrayParam(request, "add_labels"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if changes.removeLabels, err = OptionalStringArrayParam(request, "remove_labels"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if changes.assignees, err = OptionalStringArrayParam(request, "assignees"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if changes.milestone, err = OptionalIntParam(request, "milestone"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if changes.state, err = OptionalParam[string](request, "state"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if changes.stateReason, err = OptionalParam[string](request, "state_reason"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(changes.addLabels) == 0 && len(changes.removeLabels) == 0 && len(changes.assignees) == 0 && changes.milestone == 0 && changes.state == "" {
+				return mcp.NewToolResultError("at least one of add_labels, remove_labels, assignees, milestone or state is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			return bulkUpdateIssues(ctx, client, owner, repo, numbers, query, changes)
+		}
+}
+
+// CloseIssues creates a tool to close several issues, optionally leaving the same comment on each.
+func CloseIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("close_issues",
+			mcp.WithDescription(t("TOOL_CLOSE_ISSUES_DESCRIPTION", fmt.Sprintf("Close up to %d issues of a GitHub repository in one call, optionally commenting on each first, e.g. to explain a stale-issue sweep. The outcome is reported for each issue, so some may fail while others are closed.", maxBulkIssues))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CLOSE_ISSUES_USER_TITLE", "Close issues"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[BulkIssuesResult](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("issue_numbers",
+				mcp.Required(),
+				mcp.Description("Numbers of the issues to close"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithString("comment",
+				mcp.Description("Comment to leave on each issue before closing it"),
+			),
+			mcp.WithString("reason",
+				mcp.Description("Reason the issues are closed"),
+				mcp.Enum("completed", "not_planned"),
+				mcp.DefaultString("completed"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			numbers, err := OptionalIntArrayParam(request, "issue_numbers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(numbers) == 0 {
+				return mcp.NewToolResultError("missing required parameter: issue_numbers"), nil
+			}
+
+			changes := bulkIssueChanges{state: "closed"}
+			if changes.comment, err = OptionalParam[string](request, "comment"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if changes.stateReason, err = OptionalParam[string](request, "reason"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if changes.stateReason == "" {
+				changes.stateReason = "completed"
+			}
+			if changes.stateReason != "completed" && changes.stateReason != "not_planned" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid reason %q, must be completed or not_planned", changes.stateReason)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			return bulkUpdateIssues(ctx, client, owner, repo, numbers, "", changes)
+		}
+}
+
+// bulkUpdateIssues applies the changes to the issues with the numbers, or to the issues matching the query, one at
+// a time so that secondary rate limits aren't tripped.
+func bulkUpdateIssues(ctx context.Context, client *github.Client, owner, repo string, numbers []int, query string, changes bulkIssueChanges) (*mcp.CallToolResult, error) {
+	result := BulkIssuesResult{Issues: []BulkIssueUpdate{}}
+	if query != "" {
+		var resp *github.Response
+		var err error
+		numbers, result.Truncated, resp, err = searchIssueNumbers(ctx, client, owner, repo, query)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to search issues",
+				resp,
+				err,
+			), nil
+		}
+	}
+	if len(numbers) == 0 && query == "" {
+		return mcp.NewToolResultError("either issue_numbers or query is required"), nil
+	}
+	if len(numbers) > maxBulkIssues {
+		return mcp.NewToolResultError(fmt.Sprintf("at most %d issues can be updated at once", maxBulkIssues)), nil
+	}
+
+	for i, number := range numbers {
+		NotifyProgress(ctx, float64(i), float64(len(numbers)), fmt.Sprintf("Updating issue #%d", number))
+		update := BulkIssueUpdate{Number: number, Status: "ok"}
+		if err := updateIssue(ctx, client, owner, repo, number, changes); err != nil {
+			update.Status, update.Error = "error", err.Error()
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+		result.Issues = append(result.Issues, update)
+	}
+	return MarshalledTextResult(result), nil
+}
+
+// searchIssueNumbers returns the numbers of the issues of the repository matching the query, reporting whether more
+// matched than are updated in a single call.
+func searchIssueNumbers(ctx context.Context, client *github.Client, owner, repo, query string) ([]int, bool, *github.Response, error) {
+	query = normalizeDateQualifiers(fmt.Sprintf("repo:%s/%s is:issue %s", owner, repo, query), time.Now())
+	issues, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: maxBulkIssues}})
+	if err != nil {
+		return nil, false, resp, err
+	}
+	_ = resp.Body.Close()
+
+	numbers := make([]int, 0, len(issues.Issues))
+	for _, issue := range issues.Issues {
+		numbers = append(numbers, issue.GetNumber())
+	}
+	return numbers, issues.GetTotal() > len(numbers), resp, nil
+}
+
+// updateIssue applies the changes of a bulk update to an issue. Requests are only made for the changes that are set.
+func updateIssue(ctx context.Context, client *github.Client, owner, repo string, number int, changes bulkIssueChanges) error {
+	// The comment comes first so that it's there, above the state change, when the issue is closed
+	if changes.comment != "" {
+		_, resp, err := client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: github.Ptr(changes.comment)})
+		if err != nil {
+			return fmt.Errorf("failed to comment: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if len(changes.addLabels) > 0 {
+		_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, changes.addLabels)
+		if err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	for _, label := range changes.removeLabels {
+		resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+		if err != nil {
+			// Issues without the label already are as requested
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return fmt.Errorf("failed to remove label %s: %w", label, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if len(changes.assignees) > 0 {
+		_, resp, err := client.Issues.AddAssignees(ctx, owner, repo, number, changes.assignees)
+		if err != nil {
+			return fmt.Errorf("failed to add assignees: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	issueRequest := &github.IssueRequest{}
+	edit := false
+	if changes.milestone != 0 {
+		issueRequest.Milestone = github.Ptr(changes.milestone)
+		edit = true
+	}
+	if changes.state != "" {
+		issueRequest.State = github.Ptr(changes.state)
+		if changes.stateReason != "" {
+			issueRequest.StateReason = github.Ptr(changes.stateReason)
+		}
+		edit = true
+	}
+	if edit {
+		_, resp, err := client.Issues.Edit(ctx, owner, repo, number, issueRequest)
+		if err != nil {
+			return fmt.Errorf("failed to update issue: %w", err)
+		}
+		_ = resp.Body.Close()
+	}
+	return nil
+}
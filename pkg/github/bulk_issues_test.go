@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BulkUpdateIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := BulkUpdateIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "bulk_update_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "issue_numbers")
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	t.Run("updates issues by number reporting failures", func(t *testing.T) {
+		var edits []string
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/repos/owner/repo/issues/2/labels" {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+						return
+					}
+					var labels []string
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&labels))
+					assert.Equal(t, []string{"triaged"}, labels)
+					_, _ = w.Write([]byte(`[]`))
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					// The issue didn't have the label
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Label does not exist"}`))
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, map[string]any{"milestone": float64(3)}, body)
+					edits = append(edits, r.URL.Path)
+					_, _ = w.Write([]byte(`{}`))
+				}),
+			),
+		))
+		_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": []any{float64(1), float64(2)},
+			"add_labels":    []any{"triaged"},
+			"remove_labels": []any{"needs-triage"},
+			"milestone":     float64(3),
+		}))
+		require.NoError(t, err)
+
+		var updates BulkIssuesResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &updates))
+		assert.Equal(t, 1, updates.Succeeded)
+		assert.Equal(t, 1, updates.Failed)
+		require.Len(t, updates.Issues, 2)
+		assert.Equal(t, BulkIssueUpdate{Number: 1, Status: "ok"}, updates.Issues[0])
+		assert.Equal(t, 2, updates.Issues[1].Number)
+		assert.Equal(t, "error", updates.Issues[1].Status)
+		assert.Contains(t, updates.Issues[1].Error, "failed to add labels")
+		assert.Equal(t, []string{"/repos/owner/repo/issues/1"}, edits)
+	})
+
+	t.Run("updates issues matching a query", func(t *testing.T) {
+		var edits []map[string]any
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetSearchIssues,
+				expectQueryParams(t, map[string]string{
+					"q":        "repo:owner/repo is:issue is:open label:stale",
+					"per_page": "100",
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.IssuesSearchResult{
+						Total:  github.Ptr(150),
+						Issues: []*github.Issue{{Number: github.Ptr(5)}, {Number: github.Ptr(6)}},
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					edits = append(edits, body)
+					_, _ = w.Write([]byte(`{}`))
+				}),
+			),
+		))
+		_, handler := BulkUpdateIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"query":        "is:open label:stale",
+			"state":        "closed",
+			"state_reason": "not_planned",
+		}))
+		require.NoError(t, err)
+
+		var updates BulkIssuesResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &updates))
+		assert.Equal(t, BulkIssuesResult{
+			Succeeded: 2,
+			Truncated: true,
+			Issues:    []BulkIssueUpdate{{Number: 5, Status: "ok"}, {Number: 6, Status: "ok"}},
+		}, updates)
+		require.Len(t, edits, 2)
+		assert.Equal(t, map[string]any{"state": "closed", "state_reason": "not_planned"}, edits[0])
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, handler := BulkUpdateIssues(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": []any{float64(1)},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "at least one of add_labels, remove_labels, assignees, milestone or state is required", getErrorResult(t, result).Text)
+
+		result, err = handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"add_labels": []any{"bug"},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "either issue_numbers or query is required", getErrorResult(t, result).Text)
+
+		result, err = handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": []any{float64(1)},
+			"query":         "is:open",
+			"add_labels":    []any{"bug"},
+		}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+}
+
+func Test_CloseIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CloseIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "close_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "comment")
+	assert.Contains(t, tool.InputSchema.Properties, "reason")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_numbers"})
+
+	t.Run("comments on and closes the issues", func(t *testing.T) {
+		var requests []string
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var comment map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+					assert.Equal(t, "Closing as stale", comment["body"])
+					requests = append(requests, "comment "+r.URL.Path)
+					_, _ = w.Write([]byte(`{}`))
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, map[string]any{"state": "closed", "state_reason": "not_planned"}, body)
+					requests = append(requests, "close "+r.URL.Path)
+					_, _ = w.Write([]byte(`{}`))
+				}),
+			),
+		))
+		_, handler := CloseIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": []any{float64(1), float64(2)},
+			"comment":       "Closing as stale",
+			"reason":        "not_planned",
+		}))
+		require.NoError(t, err)
+
+		var closed BulkIssuesResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &closed))
+		assert.Equal(t, BulkIssuesResult{
+			Succeeded: 2,
+			Issues:    []BulkIssueUpdate{{Number: 1, Status: "ok"}, {Number: 2, Status: "ok"}},
+		}, closed)
+		assert.Equal(t, []string{
+			"comment /repos/owner/repo/issues/1/comments",
+			"close /repos/owner/repo/issues/1",
+			"comment /repos/owner/repo/issues/2/comments",
+			"close /repos/owner/repo/issues/2",
+		}, requests)
+	})
+
+	t.Run("closes as completed by default", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, map[string]any{"state": "closed", "state_reason": "completed"}, body)
+					_, _ = w.Write([]byte(`{}`))
+				}),
+			),
+		))
+		_, handler := CloseIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": []any{float64(3)},
+		}))
+		require.NoError(t, err)
+
+		var closed BulkIssuesResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &closed))
+		assert.Equal(t, 1, closed.Succeeded)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, handler := CloseIssues(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "missing required parameter: issue_numbers", getErrorResult(t, result).Text)
+
+		result, err = handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":         "owner",
+			"repo":          "repo",
+			"issue_numbers": []any{float64(1)},
+			"reason":        "duplicate",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, `invalid reason "duplicate", must be completed or not_planned`, getErrorResult(t, result).Text)
+	})
+}
@@ -0,0 +1,47 @@
+package github
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ClientFeatures are the optional MCP features a client declared support for when initializing. Features that
+// rely on them are only offered to clients that support them.
+type ClientFeatures struct {
+	// Sampling is set when the server may ask the client's model for completions, used to summarize results.
+	Sampling bool `json:"sampling"`
+
+	// Roots is set when the server may ask for the client's root directories.
+	Roots bool `json:"roots"`
+
+	// RootsListChanged is set when the client notifies the server of changes to its roots.
+	RootsListChanged bool `json:"roots_list_changed"`
+}
+
+// ClientFeaturesOf returns the features declared by the capabilities of an initialize request.
+func ClientFeaturesOf(capabilities mcp.ClientCapabilities) ClientFeatures {
+	features := ClientFeatures{
+		Sampling: capabilities.Sampling != nil,
+		Roots:    capabilities.Roots != nil,
+	}
+	if capabilities.Roots != nil {
+		features.RootsListChanged = capabilities.Roots.ListChanged
+	}
+	return features
+}
+
+// ClientFeaturesFromContext returns the features of the client of the session in the context. Features the
+// session can't make use of, such as sampling over a transport without requests to the client, are left out.
+func ClientFeaturesFromContext(ctx context.Context) ClientFeatures {
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+	if !ok {
+		return ClientFeatures{}
+	}
+	features := ClientFeaturesOf(session.GetClientCapabilities())
+	if _, ok := session.(server.SessionWithSampling); !ok {
+		features.Sampling = false
+	}
+	return features
+}
@@ -0,0 +1,22 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ClientFeaturesOf(t *testing.T) {
+	assert.Equal(t, ClientFeatures{}, ClientFeaturesOf(mcp.ClientCapabilities{}))
+
+	capabilities := mcp.ClientCapabilities{Sampling: &struct{}{}}
+	capabilities.Roots = &struct {
+		ListChanged bool `json:"listChanged,omitempty"`
+	}{ListChanged: true}
+	assert.Equal(t, ClientFeatures{Sampling: true, Roots: true, RootsListChanged: true}, ClientFeaturesOf(capabilities))
+
+	// Without a session nothing is supported
+	assert.Equal(t, ClientFeatures{}, ClientFeaturesFromContext(context.Background()))
+}
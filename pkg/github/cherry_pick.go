@@ -0,0 +1,297 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxCherryPickCommits is the number of commits cherry_pick_commits applies at most in a single call.
+const maxCherryPickCommits = 20
+
+// CherryPickedCommit is a commit applied to the target branch, with the commit it was picked from.
+type CherryPickedCommit struct {
+	SourceSHA string `json:"source_sha"`
+	SHA       string `json:"sha"`
+}
+
+// CherryPickConflict describes the commit that couldn't be applied because its changes conflict with the branch.
+type CherryPickConflict struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	// Files are the files changed both by the commit and on the branch since the parent of the commit, which are
+	// the ones likely to conflict. GitHub doesn't report the conflicting files themselves.
+	Files []string `json:"files"`
+}
+
+// CherryPickResult is the output type of cherry_pick_commits.
+type CherryPickResult struct {
+	Branch  string               `json:"branch"`
+	HeadSHA string               `json:"head_sha"`
+	Applied []CherryPickedCommit `json:"applied"`
+	// Skipped are the commits whose changes the branch already has.
+	Skipped  []string            `json:"skipped,omitempty"`
+	Conflict *CherryPickConflict `json:"conflict,omitempty"`
+	Message  string              `json:"message"`
+}
+
+// CherryPickCommits creates a tool to apply commits to a branch without a local clone. GitHub can't cherry-pick, so
+// each commit is applied by merging it into a temporary commit that has the tree of the branch and the parent of the
+// commit: the merge applies the changes of the commit alone to the tree of the branch, which is then committed on
+// top of the branch.
+func CherryPickCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("cherry_pick_commits",
+			mcp.WithDescription(t("TOOL_CHERRY_PICK_COMMITS_DESCRIPTION", fmt.Sprintf("Apply the changes of commits to a branch of a GitHub repository, in order, as git cherry-pick -x does. The branch is only updated when all the commits apply; when one conflicts the result describes the conflict and the branch is left as it was. At most %d commits are applied per call.", maxCherryPickCommits))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CHERRY_PICK_COMMITS_USER_TITLE", "Cherry-pick commits"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[CherryPickResult](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to apply the commits to"),
+			),
+			mcp.WithArray("commits",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+				mcp.Description("SHAs of the commits to apply, oldest first"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commits, err := OptionalStringArrayParam(request, "commits")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(commits) == 0 {
+				return mcp.NewToolResultError("missing required parameter: commits"), nil
+			}
+			if len(commits) > maxCherryPickCommits {
+				return mcp.NewToolResultError(fmt.Sprintf("at most %d commits can be cherry-picked at once", maxCherryPickCommits)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get branch reference",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			head, resp, err := client.Git.GetCommit(ctx, owner, repo, ref.GetObject().GetSHA())
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get head commit",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			headSHA, headTree := head.GetSHA(), head.GetTree().GetSHA()
+
+			// The temporary branch the commits are merged on is deleted whatever happens
+			tempBranch := fmt.Sprintf("cherry-pick-%s-%d", shortSHA(headSHA), time.Now().UnixNano())
+			tempCreated := false
+			defer func() {
+				if tempCreated {
+					if resp, err := client.Git.DeleteRef(context.WithoutCancel(ctx), owner, repo, "refs/heads/"+tempBranch); err == nil {
+						_ = resp.Body.Close()
+					}
+				}
+			}()
+
+			result := CherryPickResult{Branch: branch, Applied: []CherryPickedCommit{}}
+			for _, sha := range commits {
+				commit, resp, err := client.Git.GetCommit(ctx, owner, repo, sha)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get commit %s", sha),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if len(commit.Parents) != 1 {
+					return mcp.NewToolResultError(fmt.Sprintf("commit %s has %d parents, only commits with a single parent can be cherry-picked", sha, len(commit.Parents))), nil
+				}
+				parentSHA := commit.Parents[0].GetSHA()
+
+				// A commit with the tree of the branch on the parent of the commit, to merge the commit into
+				base, resp, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+					Message: github.Ptr(fmt.Sprintf("Temporary commit to cherry-pick %s", sha)),
+					Tree:    &github.Tree{SHA: github.Ptr(headTree)},
+					Parents: []*github.Commit{{SHA: github.Ptr(parentSHA)}},
+				}, nil)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to create temporary commit",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				tempRef := &github.Reference{Ref: github.Ptr("refs/heads/" + tempBranch), Object: &github.GitObject{SHA: base.SHA}}
+				if tempCreated {
+					_, resp, err = client.Git.UpdateRef(ctx, owner, repo, tempRef, true)
+				} else {
+					_, resp, err = client.Git.CreateRef(ctx, owner, repo, tempRef)
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to update temporary branch",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				tempCreated = true
+
+				merge, resp, err := client.Repositories.Merge(ctx, owner, repo, &github.RepositoryMergeRequest{
+					Base:          github.Ptr(tempBranch),
+					Head:          github.Ptr(sha),
+					CommitMessage: github.Ptr(fmt.Sprintf("Temporary merge to cherry-pick %s", sha)),
+				})
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusConflict {
+						// The commits picked before aren't on the branch, so they aren't reported
+						result.HeadSHA, result.Applied, result.Skipped = ref.GetObject().GetSHA(), []CherryPickedCommit{}, nil
+						result.Conflict = &CherryPickConflict{
+							SHA:     sha,
+							Message: commit.GetMessage(),
+							Files:   likelyConflictingFiles(ctx, client, owner, repo, sha, parentSHA, headSHA),
+						}
+						result.Message = fmt.Sprintf("Commit %s conflicts with %s, so %s was left unchanged. Resolve the conflict on a branch and cherry-pick the remaining commits onto it.", sha, branch, branch)
+						return MarshalledTextResult(result), nil
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to apply commit %s", sha),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				// Nothing was merged when the changes of the commit are already on the branch
+				mergedTree := merge.GetCommit().GetTree().GetSHA()
+				if resp.StatusCode == http.StatusNoContent || mergedTree == headTree {
+					result.Skipped = append(result.Skipped, sha)
+					continue
+				}
+
+				picked, resp, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+					Message: github.Ptr(fmt.Sprintf("%s\n\n(cherry picked from commit %s)", commit.GetMessage(), sha)),
+					Tree:    &github.Tree{SHA: github.Ptr(mergedTree)},
+					Parents: []*github.Commit{{SHA: github.Ptr(headSHA)}},
+					Author:  commit.Author,
+				}, nil)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to commit changes of %s", sha),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				result.Applied = append(result.Applied, CherryPickedCommit{SourceSHA: sha, SHA: picked.GetSHA()})
+				headSHA, headTree = picked.GetSHA(), mergedTree
+			}
+
+			result.HeadSHA = headSHA
+			if len(result.Applied) == 0 {
+				result.Message = fmt.Sprintf("%s already has the changes of the commits.", branch)
+				return MarshalledTextResult(result), nil
+			}
+
+			// The branch isn't forced, so picks fail rather than drop commits pushed meanwhile
+			ref.Object.SHA = github.Ptr(headSHA)
+			_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, false)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to update branch",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			result.Message = fmt.Sprintf("Applied %d commits to %s.", len(result.Applied), branch)
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// likelyConflictingFiles returns the files changed both by a commit and on the branch at head since the parent of
+// the commit, sorted, or nil when they can't be listed. Only the first page of files is looked at.
+func likelyConflictingFiles(ctx context.Context, client *github.Client, owner, repo, sha, parentSHA, headSHA string) []string {
+	commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, nil)
+	if err != nil {
+		return nil
+	}
+	_ = resp.Body.Close()
+
+	comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, parentSHA, headSHA, nil)
+	if err != nil {
+		return nil
+	}
+	_ = resp.Body.Close()
+
+	changed := make(map[string]bool, len(comparison.Files))
+	for _, file := range comparison.Files {
+		changed[file.GetFilename()] = true
+	}
+	files := []string{}
+	for _, file := range commit.Files {
+		if changed[file.GetFilename()] {
+			files = append(files, file.GetFilename())
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// shortSHA abbreviates a commit SHA the way git does by default.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
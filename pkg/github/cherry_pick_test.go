@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CherryPickCommits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CherryPickCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "cherry_pick_commits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "commits"})
+
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/release"),
+		Object: &github.GitObject{SHA: github.Ptr("head0")},
+	}
+	gitCommit := func(sha, tree string, parents ...string) *github.Commit {
+		commit := &github.Commit{
+			SHA:     github.Ptr(sha),
+			Message: github.Ptr("Fix " + sha),
+			Tree:    &github.Tree{SHA: github.Ptr(tree)},
+			Author:  &github.CommitAuthor{Name: github.Ptr("Octocat"), Email: github.Ptr("octocat@github.com")},
+		}
+		for _, parent := range parents {
+			commit.Parents = append(commit.Parents, &github.Commit{SHA: github.Ptr(parent)})
+		}
+		return commit
+	}
+	mergeResult := func(tree string) *github.RepositoryCommit {
+		return &github.RepositoryCommit{SHA: github.Ptr("merge-" + tree), Commit: &github.Commit{Tree: &github.Tree{SHA: github.Ptr(tree)}}}
+	}
+	// createdCommits answers commit creations with sequential SHAs, recording the requests
+	createdCommits := func(requests *[]map[string]any) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			*requests = append(*requests, body)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(&github.Commit{SHA: github.Ptr(fmt.Sprintf("created%d", len(*requests)))})
+		}
+	}
+	deletedTempBranch := func(deleted *bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			*deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+
+	t.Run("applies commits on top of the branch", func(t *testing.T) {
+		var commitRequests []map[string]any
+		var refUpdates []map[string]any
+		deleted := false
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+			mock.WithRequestMatch(
+				mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+				gitCommit("head0", "tree0", "old"),
+				gitCommit("pick1", "tree-pick1", "parent1"),
+				gitCommit("pick2", "tree-pick2", "parent2"),
+			),
+			mock.WithRequestMatchHandler(mock.PostReposGitCommitsByOwnerByRepo, createdCommits(&commitRequests)),
+			mock.WithRequestMatch(mock.PostReposGitRefsByOwnerByRepo, &github.Reference{}),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposGitRefsByOwnerByRepoByRef,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					body["path"] = r.URL.Path
+					refUpdates = append(refUpdates, body)
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{}`))
+				}),
+			),
+			mock.WithRequestMatch(mock.PostReposMergesByOwnerByRepo, mergeResult("tree1"), mergeResult("tree2")),
+			mock.WithRequestMatchHandler(mock.DeleteReposGitRefsByOwnerByRepoByRef, deletedTempBranch(&deleted)),
+		))
+		_, handler := CherryPickCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"branch":  "release",
+			"commits": []any{"pick1", "pick2"},
+		}))
+		require.NoError(t, err)
+
+		var picked CherryPickResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &picked))
+		assert.Equal(t, CherryPickResult{
+			Branch:  "release",
+			HeadSHA: "created4",
+			Applied: []CherryPickedCommit{
+				{SourceSHA: "pick1", SHA: "created2"},
+				{SourceSHA: "pick2", SHA: "created4"},
+			},
+			Message: "Applied 2 commits to release.",
+		}, picked)
+
+		require.Len(t, commitRequests, 4)
+		// The temporary commits have the tree of the branch and the parent of the picked commit
+		assert.Equal(t, "tree0", commitRequests[0]["tree"])
+		assert.Equal(t, []any{"parent1"}, commitRequests[0]["parents"])
+		assert.Equal(t, "tree1", commitRequests[2]["tree"])
+		assert.Equal(t, []any{"parent2"}, commitRequests[2]["parents"])
+		// The picked commits have the merged tree on top of the branch, keeping their message and author
+		assert.Equal(t, "tree1", commitRequests[1]["tree"])
+		assert.Equal(t, []any{"head0"}, commitRequests[1]["parents"])
+		assert.Equal(t, "Fix pick1\n\n(cherry picked from commit pick1)", commitRequests[1]["message"])
+		assert.Equal(t, "Octocat", commitRequests[1]["author"].(map[string]any)["name"])
+		assert.Equal(t, "tree2", commitRequests[3]["tree"])
+		assert.Equal(t, []any{"created2"}, commitRequests[3]["parents"])
+
+		require.Len(t, refUpdates, 2)
+		assert.Equal(t, true, refUpdates[0]["force"])
+		assert.Equal(t, "/repos/owner/repo/git/refs/heads/release", refUpdates[1]["path"])
+		assert.Equal(t, "created4", refUpdates[1]["sha"])
+		assert.Equal(t, false, refUpdates[1]["force"])
+		assert.True(t, deleted)
+	})
+
+	t.Run("reports conflicts without updating the branch", func(t *testing.T) {
+		var commitRequests []map[string]any
+		deleted := false
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+			mock.WithRequestMatch(
+				mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+				gitCommit("head0", "tree0", "old"),
+				gitCommit("pick1", "tree-pick1", "parent1"),
+			),
+			mock.WithRequestMatchHandler(mock.PostReposGitCommitsByOwnerByRepo, createdCommits(&commitRequests)),
+			mock.WithRequestMatch(mock.PostReposGitRefsByOwnerByRepo, &github.Reference{}),
+			mock.WithRequestMatchHandler(
+				mock.PostReposMergesByOwnerByRepo,
+				mockResponse(t, http.StatusConflict, map[string]string{"message": "Merge conflict"}),
+			),
+			mock.WithRequestMatch(
+				mock.GetReposCommitsByOwnerByRepoByRef,
+				&github.RepositoryCommit{Files: []*github.CommitFile{{Filename: github.Ptr("main.go")}, {Filename: github.Ptr("README.md")}}},
+			),
+			mock.WithRequestMatch(
+				mock.GetReposCompareByOwnerByRepoByBasehead,
+				&github.CommitsComparison{Files: []*github.CommitFile{{Filename: github.Ptr("main.go")}, {Filename: github.Ptr("go.mod")}}},
+			),
+			mock.WithRequestMatchHandler(mock.DeleteReposGitRefsByOwnerByRepoByRef, deletedTempBranch(&deleted)),
+		))
+		_, handler := CherryPickCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"branch":  "release",
+			"commits": []any{"pick1", "pick2"},
+		}))
+		require.NoError(t, err)
+
+		var picked CherryPickResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &picked))
+		assert.Equal(t, "head0", picked.HeadSHA)
+		assert.Empty(t, picked.Applied)
+		assert.Equal(t, &CherryPickConflict{SHA: "pick1", Message: "Fix pick1", Files: []string{"main.go"}}, picked.Conflict)
+		assert.Contains(t, picked.Message, "release was left unchanged")
+		assert.Len(t, commitRequests, 1)
+		assert.True(t, deleted)
+	})
+
+	t.Run("rejects merge commits", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+			mock.WithRequestMatch(
+				mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+				gitCommit("head0", "tree0", "old"),
+				gitCommit("merge", "tree-merge", "parent1", "parent2"),
+			),
+		))
+		_, handler := CherryPickCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"branch":  "release",
+			"commits": []any{"merge"},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "commit merge has 2 parents, only commits with a single parent can be cherry-picked", getErrorResult(t, result).Text)
+	})
+
+	t.Run("requires commits", func(t *testing.T) {
+		_, handler := CherryPickCommits(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":   "owner",
+			"repo":    "repo",
+			"branch":  "release",
+			"commits": []any{},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "missing required parameter: commits", getErrorResult(t, result).Text)
+	})
+}
@@ -1,11 +1,17 @@
 package github
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"path"
+	"strconv"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -21,6 +27,7 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 				Title:        t("TOOL_GET_CODE_SCANNING_ALERT_USER_TITLE", "Get code scanning alert"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.Alert](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("The owner of the repository."),
@@ -87,6 +94,7 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 				Title:        t("TOOL_LIST_CODE_SCANNING_ALERTS_USER_TITLE", "List code scanning alerts"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.Alert](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("The owner of the repository."),
@@ -110,6 +118,7 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 			mcp.WithString("tool_name",
 				mcp.Description("The name of the tool used for code scanning."),
 			),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -167,3 +176,475 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// maxSarifUploadSize is the largest gzip-compressed SARIF file code scanning accepts.
+const maxSarifUploadSize = 10 * 1024 * 1024
+
+// MinimalCodeScanningAnalysis is the trimmed output type for code scanning analyses.
+type MinimalCodeScanningAnalysis struct {
+	ID           int64  `json:"id"`
+	Ref          string `json:"ref"`
+	CommitSHA    string `json:"commit_sha"`
+	AnalysisKey  string `json:"analysis_key,omitempty"`
+	Category     string `json:"category,omitempty"`
+	Environment  string `json:"environment,omitempty"`
+	ToolName     string `json:"tool_name,omitempty"`
+	ToolVersion  string `json:"tool_version,omitempty"`
+	ResultsCount int    `json:"results_count"`
+	RulesCount   int    `json:"rules_count"`
+	SarifID      string `json:"sarif_id,omitempty"`
+	CreatedAt    string `json:"created_at,omitempty"`
+	Deletable    bool   `json:"deletable"`
+	Error        string `json:"error,omitempty"`
+	Warning      string `json:"warning,omitempty"`
+}
+
+// SarifUploadResult is the output type of upload_sarif and get_sarif_upload.
+type SarifUploadResult struct {
+	ID               string   `json:"id"`
+	ProcessingStatus string   `json:"processing_status,omitempty"`
+	AnalysisIDs      []int64  `json:"analysis_ids,omitempty"`
+	Errors           []string `json:"errors,omitempty"`
+}
+
+// DeletedCodeScanningAnalysis is the output type of delete_code_scanning_analysis.
+type DeletedCodeScanningAnalysis struct {
+	ID int64 `json:"id"`
+	// NextAnalysisID is the analysis to delete next to delete the whole set the analysis belongs to.
+	NextAnalysisID int64 `json:"next_analysis_id,omitempty"`
+	// NextRequiresConfirmDelete is set when the next analysis is the last of its set.
+	NextRequiresConfirmDelete bool `json:"next_requires_confirm_delete,omitempty"`
+}
+
+func convertToMinimalCodeScanningAnalysis(analysis *github.ScanningAnalysis) MinimalCodeScanningAnalysis {
+	minimalAnalysis := MinimalCodeScanningAnalysis{
+		ID:           analysis.GetID(),
+		Ref:          analysis.GetRef(),
+		CommitSHA:    analysis.GetCommitSHA(),
+		AnalysisKey:  analysis.GetAnalysisKey(),
+		Category:     analysis.GetCategory(),
+		Environment:  analysis.GetEnvironment(),
+		ToolName:     analysis.GetTool().GetName(),
+		ToolVersion:  analysis.GetTool().GetVersion(),
+		ResultsCount: analysis.GetResultsCount(),
+		RulesCount:   analysis.GetRulesCount(),
+		SarifID:      analysis.GetSarifID(),
+		Deletable:    analysis.GetDeletable(),
+		Error:        analysis.GetError(),
+		Warning:      analysis.GetWarning(),
+	}
+	if analysis.CreatedAt != nil {
+		minimalAnalysis.CreatedAt = analysis.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalAnalysis
+}
+
+// encodeSarif checks that a SARIF document has runs, and compresses and encodes it as code scanning expects it.
+func encodeSarif(sarif string) (string, error) {
+	var document struct {
+		Runs []json.RawMessage `json:"runs"`
+	}
+	if err := json.Unmarshal([]byte(sarif), &document); err != nil {
+		return "", fmt.Errorf("sarif is not valid JSON: %w", err)
+	}
+	if document.Runs == nil {
+		return "", fmt.Errorf("sarif has no runs")
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(sarif)); err != nil {
+		return "", fmt.Errorf("failed to compress sarif: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress sarif: %w", err)
+	}
+	if buf.Len() > maxSarifUploadSize {
+		return "", fmt.Errorf("sarif is %d bytes once compressed, more than the %d bytes code scanning accepts", buf.Len(), maxSarifUploadSize)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// analysisIDFromURL returns the ID an analysis URL ends with, or 0.
+func analysisIDFromURL(analysisURL string) int64 {
+	u, err := url.Parse(analysisURL)
+	if err != nil {
+		return 0
+	}
+	id, err := strconv.ParseInt(path.Base(u.Path), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// UploadSarif creates a tool to upload the results of an external scanner to code scanning.
+func UploadSarif(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("upload_sarif",
+			mcp.WithDescription(t("TOOL_UPLOAD_SARIF_DESCRIPTION", "Upload the results of a code scanner in SARIF format to code scanning, where they show up as code scanning alerts. Uploads are processed asynchronously, use get_sarif_upload with the returned ID to follow processing.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPLOAD_SARIF_USER_TITLE", "Upload SARIF results"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[SarifUploadResult](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithString("commit_sha",
+				mcp.Required(),
+				mcp.Description("The SHA of the commit that was scanned."),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("The full Git reference that was scanned, such as refs/heads/main or refs/pull/42/merge."),
+			),
+			mcp.WithString("sarif",
+				mcp.Required(),
+				mcp.Description("The SARIF document, as JSON. It is compressed and encoded before being uploaded."),
+			),
+			mcp.WithString("checkout_uri",
+				mcp.Description("The URI of the checkout the scanner ran in, which file locations in the results are relative to."),
+			),
+			mcp.WithString("started_at",
+				mcp.Description("When the scan started (ISO 8601 timestamp)."),
+			),
+			mcp.WithString("tool_name",
+				mcp.Description("The name of the scanner, overriding the one in the SARIF document."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commitSHA, err := RequiredParam[string](request, "commit_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sarif, err := RequiredParam[string](request, "sarif")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkoutURI, err := OptionalParam[string](request, "checkout_uri")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			startedAt, err := OptionalParam[string](request, "started_at")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toolName, err := OptionalParam[string](request, "tool_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			encoded, err := encodeSarif(sarif)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			analysis := &github.SarifAnalysis{
+				CommitSHA: github.Ptr(commitSHA),
+				Ref:       github.Ptr(ref),
+				Sarif:     github.Ptr(encoded),
+			}
+			if checkoutURI != "" {
+				analysis.CheckoutURI = github.Ptr(checkoutURI)
+			}
+			if startedAt != "" {
+				started, err := parseISOTimestamp(startedAt)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				analysis.StartedAt = &github.Timestamp{Time: started}
+			}
+			if toolName != "" {
+				analysis.ToolName = github.Ptr(toolName)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			sarifID, resp, err := client.CodeScanning.UploadSarif(ctx, owner, repo, analysis)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to upload SARIF",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(SarifUploadResult{
+				ID:               sarifID.GetID(),
+				ProcessingStatus: "pending",
+			}), nil
+		}
+}
+
+// GetSarifUpload creates a tool to get the processing status of a SARIF upload.
+func GetSarifUpload(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_sarif_upload",
+			mcp.WithDescription(t("TOOL_GET_SARIF_UPLOAD_DESCRIPTION", "Get the processing status of a SARIF upload, and the analyses it created once it is complete.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_SARIF_UPLOAD_USER_TITLE", "Get SARIF upload"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[SarifUploadResult](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithString("sarif_id",
+				mcp.Required(),
+				mcp.Description("The ID of the SARIF upload, as returned by upload_sarif."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sarifID, err := RequiredParam[string](request, "sarif_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The go-github type lacks the processing errors, so the upload is decoded here
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/code-scanning/sarifs/%s", owner, repo, sarifID), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var upload struct {
+				ProcessingStatus string   `json:"processing_status"`
+				AnalysesURL      string   `json:"analyses_url"`
+				Errors           []string `json:"errors"`
+			}
+			resp, err := client.Do(ctx, req, &upload)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get SARIF upload: %s", sarifID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := SarifUploadResult{
+				ID:               sarifID,
+				ProcessingStatus: upload.ProcessingStatus,
+				Errors:           upload.Errors,
+			}
+			if upload.ProcessingStatus == "complete" {
+				analyses, resp, err := client.CodeScanning.ListAnalysesForRepo(ctx, owner, repo, &github.AnalysesListOptions{
+					SarifID:     github.Ptr(sarifID),
+					ListOptions: github.ListOptions{PerPage: 100},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list analyses of SARIF upload: %s", sarifID),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				for _, analysis := range analyses {
+					result.AnalysisIDs = append(result.AnalysisIDs, analysis.GetID())
+				}
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// ListCodeScanningAnalyses creates a tool to list the code scanning analyses of a repository.
+func ListCodeScanningAnalyses(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_code_scanning_analyses",
+			mcp.WithDescription(t("TOOL_LIST_CODE_SCANNING_ANALYSES_DESCRIPTION", "List the code scanning analyses of a repository, newest first. Each analysis is the upload of the results of a tool for a commit.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CODE_SCANNING_ANALYSES_USER_TITLE", "List code scanning analyses"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalCodeScanningAnalysis](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Only list analyses of this Git reference, such as refs/heads/main or refs/pull/42/merge."),
+			),
+			mcp.WithString("sarif_id",
+				mcp.Description("Only list analyses created by this SARIF upload."),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sarifID, err := OptionalParam[string](request, "sarif_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.AnalysesListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if ref != "" {
+				opts.Ref = github.Ptr(ref)
+			}
+			if sarifID != "" {
+				opts.SarifID = github.Ptr(sarifID)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			analyses, resp, err := client.CodeScanning.ListAnalysesForRepo(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list analyses",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalAnalyses := make([]MinimalCodeScanningAnalysis, 0, len(analyses))
+			for _, analysis := range analyses {
+				minimalAnalyses = append(minimalAnalyses, convertToMinimalCodeScanningAnalysis(analysis))
+			}
+
+			return MarshalledTextResult(minimalAnalyses), nil
+		}
+}
+
+// DeleteCodeScanningAnalysis creates a tool to delete a code scanning analysis.
+func DeleteCodeScanningAnalysis(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_code_scanning_analysis",
+			mcp.WithDescription(t("TOOL_DELETE_CODE_SCANNING_ANALYSIS_DESCRIPTION", "Delete a deletable code scanning analysis. Analyses of a set are deleted one at a time, the result has the next analysis of the set to delete. Deleting the last analysis of a set requires confirm_delete, as it removes the alerts of the set.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_CODE_SCANNING_ANALYSIS_USER_TITLE", "Delete code scanning analysis"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[DeletedCodeScanningAnalysis](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("analysis_id",
+				mcp.Required(),
+				mcp.Description("The ID of the analysis, as returned by list_code_scanning_analyses."),
+			),
+			mcp.WithBoolean("confirm_delete",
+				mcp.Description("Allow deleting the last analysis of a set. Default is false."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			analysisID, err := RequiredInt(request, "analysis_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			confirmDelete, err := OptionalParam[bool](request, "confirm_delete")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// DeleteAnalysis doesn't take confirm_delete, so the request is made here
+			u := fmt.Sprintf("repos/%s/%s/code-scanning/analyses/%d", owner, repo, analysisID)
+			if confirmDelete {
+				u += "?confirm_delete=true"
+			}
+			req, err := client.NewRequest(http.MethodDelete, u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var deleted github.DeleteAnalysis
+			resp, err := client.Do(ctx, req, &deleted)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete analysis %d", analysisID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := DeletedCodeScanningAnalysis{ID: int64(analysisID)}
+			if next := analysisIDFromURL(deleted.GetNextAnalysisURL()); next != 0 {
+				result.NextAnalysisID = next
+			} else if next := analysisIDFromURL(deleted.GetConfirmDeleteURL()); next != 0 {
+				result.NextAnalysisID = next
+				result.NextRequiresConfirmDelete = true
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -1,8 +1,13 @@
 package github
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"io"
+	"math/rand"
 	"net/http"
 	"testing"
 
@@ -247,3 +252,439 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_UploadSarif(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UploadSarif(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "upload_sarif", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "checkout_uri")
+	assert.Contains(t, tool.InputSchema.Properties, "started_at")
+	assert.Contains(t, tool.InputSchema.Properties, "tool_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "commit_sha", "ref", "sarif"})
+
+	sarif := `{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"scanner"}},"results":[]}]}`
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful upload",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodeScanningSarifsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						var body map[string]any
+						require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+						assert.Equal(t, "abc123", body["commit_sha"])
+						assert.Equal(t, "refs/heads/main", body["ref"])
+						assert.Equal(t, "2025-06-01T12:00:00Z", body["started_at"])
+
+						compressed, err := base64.StdEncoding.DecodeString(body["sarif"].(string))
+						require.NoError(t, err)
+						reader, err := gzip.NewReader(bytes.NewReader(compressed))
+						require.NoError(t, err)
+						uploaded, err := io.ReadAll(reader)
+						require.NoError(t, err)
+						assert.Equal(t, sarif, string(uploaded))
+
+						mockResponse(t, http.StatusAccepted, `{"id": "47177e22-5596-11eb-80a1-c1e54ef945c6", "url": "https://api.github.com/repos/owner/repo/code-scanning/sarifs/47177e22-5596-11eb-80a1-c1e54ef945c6"}`)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"commit_sha": "abc123",
+				"ref":        "refs/heads/main",
+				"sarif":      sarif,
+				"started_at": "2025-06-01T12:00:00Z",
+			},
+		},
+		{
+			name:         "invalid SARIF",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"commit_sha": "abc123",
+				"ref":        "refs/heads/main",
+				"sarif":      `{"version":"2.1.0"}`,
+			},
+			expectError:    true,
+			expectedErrMsg: "sarif has no runs",
+		},
+		{
+			name: "upload rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodeScanningSarifsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Advanced Security must be enabled for this repository to use code scanning."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"commit_sha": "abc123",
+				"ref":        "refs/heads/main",
+				"sarif":      sarif,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to upload SARIF",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UploadSarif(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned SarifUploadResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "47177e22-5596-11eb-80a1-c1e54ef945c6", returned.ID)
+			assert.Equal(t, "pending", returned.ProcessingStatus)
+		})
+	}
+}
+
+func Test_encodeSarif(t *testing.T) {
+	_, err := encodeSarif("not json")
+	assert.ErrorContains(t, err, "sarif is not valid JSON")
+
+	// Random data barely compresses, so this is over the limit once compressed
+	random := make([]byte, maxSarifUploadSize+maxSarifUploadSize/10)
+	_, _ = rand.New(rand.NewSource(1)).Read(random)
+	_, err = encodeSarif(`{"runs":[],"data":"` + base64.StdEncoding.EncodeToString(random) + `"}`)
+	assert.ErrorContains(t, err, "bytes code scanning accepts")
+}
+
+func Test_GetSarifUpload(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetSarifUpload(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_sarif_upload", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sarif_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedResult SarifUploadResult
+	}{
+		{
+			name: "complete upload",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCodeScanningSarifsByOwnerByRepoBySarifId,
+					map[string]any{"processing_status": "complete", "analyses_url": "https://api.github.com/repos/owner/repo/code-scanning/analyses?sarif_id=sarif1"},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAnalysesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"sarif_id": "sarif1",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.ScanningAnalysis{
+							{ID: github.Ptr(int64(201))},
+							{ID: github.Ptr(int64(202))},
+						}),
+					),
+				),
+			),
+			expectedResult: SarifUploadResult{
+				ID:               "sarif1",
+				ProcessingStatus: "complete",
+				AnalysisIDs:      []int64{201, 202},
+			},
+		},
+		{
+			name: "failed upload",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCodeScanningSarifsByOwnerByRepoBySarifId,
+					map[string]any{"processing_status": "failed", "errors": []string{"locationFromSarifResult: expected artifact location"}},
+				),
+			),
+			expectedResult: SarifUploadResult{
+				ID:               "sarif1",
+				ProcessingStatus: "failed",
+				Errors:           []string{"locationFromSarifResult: expected artifact location"},
+			},
+		},
+		{
+			name: "upload not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningSarifsByOwnerByRepoBySarifId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get SARIF upload: sarif1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetSarifUpload(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"sarif_id": "sarif1",
+			})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned SarifUploadResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_ListCodeScanningAnalyses(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCodeScanningAnalyses(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_code_scanning_analyses", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "sarif_id")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockAnalyses := []*github.ScanningAnalysis{
+		{
+			ID:           github.Ptr(int64(201)),
+			Ref:          github.Ptr("refs/heads/main"),
+			CommitSHA:    github.Ptr("abc123"),
+			Category:     github.Ptr("scanner"),
+			ResultsCount: github.Ptr(4),
+			RulesCount:   github.Ptr(30),
+			Tool:         &github.Tool{Name: github.Ptr("scanner"), Version: github.Ptr("1.2.0")},
+			Deletable:    github.Ptr(true),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "analyses of a ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAnalysesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"ref":      "refs/heads/main",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAnalyses),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/main",
+			},
+		},
+		{
+			name: "code scanning not enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAnalysesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "no analysis found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list analyses",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCodeScanningAnalyses(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned []MinimalCodeScanningAnalysis
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, int64(201), returned[0].ID)
+			assert.Equal(t, "scanner", returned[0].ToolName)
+			assert.Equal(t, "1.2.0", returned[0].ToolVersion)
+			assert.Equal(t, 4, returned[0].ResultsCount)
+			assert.True(t, returned[0].Deletable)
+		})
+	}
+}
+
+func Test_DeleteCodeScanningAnalysis(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteCodeScanningAnalysis(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_code_scanning_analysis", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "confirm_delete")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "analysis_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult DeletedCodeScanningAnalysis
+	}{
+		{
+			name: "next analysis of the set",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposCodeScanningAnalysesByOwnerByRepoByAnalysisId,
+					expectPath(t, "/repos/owner/repo/code-scanning/analyses/201").andThen(
+						mockResponse(t, http.StatusOK, map[string]any{
+							"next_analysis_url":  "https://api.github.com/repos/owner/repo/code-scanning/analyses/200",
+							"confirm_delete_url": "https://api.github.com/repos/owner/repo/code-scanning/analyses/200?confirm_delete",
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"analysis_id": float64(201),
+			},
+			expectedResult: DeletedCodeScanningAnalysis{ID: 201, NextAnalysisID: 200},
+		},
+		{
+			name: "next analysis is the last of the set",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposCodeScanningAnalysesByOwnerByRepoByAnalysisId,
+					mockResponse(t, http.StatusOK, map[string]any{
+						"next_analysis_url":  nil,
+						"confirm_delete_url": "https://api.github.com/repos/owner/repo/code-scanning/analyses/199?confirm_delete",
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"analysis_id": float64(200),
+			},
+			expectedResult: DeletedCodeScanningAnalysis{ID: 200, NextAnalysisID: 199, NextRequiresConfirmDelete: true},
+		},
+		{
+			name: "confirmed deletion of the last analysis",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposCodeScanningAnalysesByOwnerByRepoByAnalysisId,
+					expectQueryParams(t, map[string]string{
+						"confirm_delete": "true",
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"analysis_id":    float64(199),
+				"confirm_delete": true,
+			},
+			expectedResult: DeletedCodeScanningAnalysis{ID: 199},
+		},
+		{
+			name: "last analysis without confirmation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposCodeScanningAnalysesByOwnerByRepoByAnalysisId,
+					mockResponse(t, http.StatusBadRequest, `{"message": "Analysis is last of its type and deletion may result in the loss of historical alert data. Please specify confirm_delete."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"analysis_id": float64(199),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete analysis 199",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteCodeScanningAnalysis(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned DeletedCodeScanningAnalysis
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalCollaborator is the output type for the collaborators of a repository, with their role on it.
+type MinimalCollaborator struct {
+	MinimalUser
+	RoleName string `json:"role_name,omitempty"`
+}
+
+// MinimalCollaboratorPermission is the output type for the permission of a user on a repository.
+type MinimalCollaboratorPermission struct {
+	Username string `json:"username"`
+	// Permission is one of admin, write, read or none, the roles maintain and triage mapping to write and read.
+	Permission string `json:"permission"`
+	RoleName   string `json:"role_name,omitempty"`
+}
+
+// MinimalCollaboratorInvitation is the output type for adding collaborators, who are invited unless they are
+// members of the organization owning the repository or already collaborators.
+type MinimalCollaboratorInvitation struct {
+	Username     string `json:"username"`
+	Invited      bool   `json:"invited"`
+	InvitationID int64  `json:"invitation_id,omitempty"`
+	Permission   string `json:"permission,omitempty"`
+	HTMLURL      string `json:"html_url,omitempty"`
+}
+
+// ListCollaborators creates a tool to list the collaborators of a repository.
+func ListCollaborators(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_collaborators",
+			mcp.WithDescription(t("TOOL_LIST_COLLABORATORS_DESCRIPTION", "List the users with access to a GitHub repository, with their role on it. For repositories of organizations, this includes the members of the organization and of the teams with access. Requires push access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COLLABORATORS_USER_TITLE", "List collaborators"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalCollaborator](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("affiliation",
+				mcp.Description("Use outside for collaborators who aren't members of the organization, and direct for those with access to the repository itself rather than through a team or the organization. Defaults to all"),
+				mcp.Enum("all", "direct", "outside"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Only list the collaborators with this permission"),
+				mcp.Enum(teamRepoPermissions...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			affiliation, err := OptionalParam[string](request, "affiliation")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			users, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, &github.ListCollaboratorsOptions{
+				Affiliation: affiliation,
+				Permission:  permission,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list collaborators of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			collaborators := make([]MinimalCollaborator, 0, len(users))
+			for _, user := range users {
+				collaborators = append(collaborators, MinimalCollaborator{
+					MinimalUser: *convertToMinimalUser(user),
+					RoleName:    user.GetRoleName(),
+				})
+			}
+
+			return MarshalledTextResult(collaborators), nil
+		}
+}
+
+// GetCollaboratorPermission creates a tool to get the permission of a user on a repository.
+func GetCollaboratorPermission(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_collaborator_permission",
+			mcp.WithDescription(t("TOOL_GET_COLLABORATOR_PERMISSION_DESCRIPTION", "Get the permission a user has on a GitHub repository, whether given directly, through a team or by the organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COLLABORATOR_PERMISSION_USER_TITLE", "Get collaborator permission"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[MinimalCollaboratorPermission](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			level, resp, err := client.Repositories.GetPermissionLevel(ctx, owner, repo, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get the permission of %s on %s/%s", username, owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(MinimalCollaboratorPermission{
+				Username:   username,
+				Permission: level.GetPermission(),
+				RoleName:   level.GetRoleName(),
+			}), nil
+		}
+}
+
+// AddCollaborator creates a tool to add a collaborator to a repository, or to change their permission on it.
+func AddCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_collaborator",
+			mcp.WithDescription(t("TOOL_ADD_COLLABORATOR_DESCRIPTION", "Give a user access to a GitHub repository, or change the permission of a collaborator. Users who aren't members of the organization owning the repository are invited and get access once they accept")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_COLLABORATOR_USER_TITLE", "Add or update collaborator"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalCollaboratorInvitation](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user to add"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Permission to grant, only for repositories of organizations. Defaults to push"),
+				mcp.Enum(teamRepoPermissions...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			invitation, resp, err := client.Repositories.AddCollaborator(ctx, owner, repo, username, &github.RepositoryAddCollaboratorOptions{
+				Permission: permission,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to add %s to %s/%s", username, owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// The API answers with no content when the user got access without an invitation.
+			if resp.StatusCode == http.StatusNoContent || invitation == nil {
+				return MarshalledTextResult(MinimalCollaboratorInvitation{
+					Username:   username,
+					Permission: permission,
+				}), nil
+			}
+			return MarshalledTextResult(MinimalCollaboratorInvitation{
+				Username:     username,
+				Invited:      true,
+				InvitationID: invitation.GetID(),
+				Permission:   invitation.GetPermissions(),
+				HTMLURL:      invitation.GetHTMLURL(),
+			}), nil
+		}
+}
+
+// RemoveCollaborator creates a tool to remove a collaborator from a repository.
+func RemoveCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_collaborator",
+			mcp.WithDescription(t("TOOL_REMOVE_COLLABORATOR_DESCRIPTION", "Remove a collaborator from a GitHub repository, or cancel their pending invitation. Users keep the access they have through teams or the organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_COLLABORATOR_USER_TITLE", "Remove collaborator"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the collaborator to remove"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.RemoveCollaborator(ctx, owner, repo, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to remove %s from %s/%s", username, owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("%s is no longer a collaborator of %s/%s", username, owner, repo)), nil
+		}
+}
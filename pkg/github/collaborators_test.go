@@ -0,0 +1,241 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCollaborators(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCollaborators(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_collaborators", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "outside collaborators",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"affiliation": "outside",
+						"page":        "1",
+						"per_page":    "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.User{
+							{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1)), RoleName: github.Ptr("maintain")},
+						}),
+					),
+				),
+			),
+		},
+		{
+			name: "no push access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have push access to view repository collaborators."}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list collaborators of owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCollaborators(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"affiliation": "outside",
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var collaborators []MinimalCollaborator
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &collaborators))
+			require.Len(t, collaborators, 1)
+			assert.Equal(t, "octocat", collaborators[0].Login)
+			assert.Equal(t, "maintain", collaborators[0].RoleName)
+		})
+	}
+}
+
+func Test_GetCollaboratorPermission(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCollaboratorPermission(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_collaborator_permission", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposCollaboratorsPermissionByOwnerByRepoByUsername,
+			&github.RepositoryPermissionLevel{
+				Permission: github.Ptr("write"),
+				RoleName:   github.Ptr("maintain"),
+				User:       &github.User{Login: github.Ptr("octocat")},
+			},
+		),
+	))
+	_, handler := GetCollaboratorPermission(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":    "owner",
+		"repo":     "repo",
+		"username": "octocat",
+	}))
+	require.NoError(t, err)
+
+	var permission MinimalCollaboratorPermission
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &permission))
+	assert.Equal(t, MinimalCollaboratorPermission{Username: "octocat", Permission: "write", RoleName: "maintain"}, permission)
+}
+
+func Test_AddCollaborator(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_collaborator", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		expectError        bool
+		expectedErrMsg     string
+		expectedInvitation MinimalCollaboratorInvitation
+	}{
+		{
+			name: "outside collaborator is invited",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{"permission": "triage"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.CollaboratorInvitation{
+							ID:          github.Ptr(int64(5)),
+							Permissions: github.Ptr("triage"),
+							HTMLURL:     github.Ptr("https://github.com/owner/repo/invitations"),
+						}),
+					),
+				),
+			),
+			expectedInvitation: MinimalCollaboratorInvitation{
+				Username:     "octocat",
+				Invited:      true,
+				InvitationID: 5,
+				Permission:   "triage",
+				HTMLURL:      "https://github.com/owner/repo/invitations",
+			},
+		},
+		{
+			name: "existing collaborator gets the new permission",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusNoContent, ""),
+				),
+			),
+			expectedInvitation: MinimalCollaboratorInvitation{
+				Username:   "octocat",
+				Permission: "triage",
+			},
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to add octocat to owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"username":   "octocat",
+				"permission": "triage",
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var invitation MinimalCollaboratorInvitation
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &invitation))
+			assert.Equal(t, tc.expectedInvitation, invitation)
+		})
+	}
+}
+
+func Test_RemoveCollaborator(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_collaborator", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposCollaboratorsByOwnerByRepoByUsername,
+			expectPath(t, "/repos/owner/repo/collaborators/octocat").andThen(
+				mockResponse(t, http.StatusNoContent, ""),
+			),
+		),
+	))
+	_, handler := RemoveCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":    "owner",
+		"repo":     "repo",
+		"username": "octocat",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "octocat is no longer a collaborator of owner/repo", getTextResult(t, result).Text)
+}
@@ -0,0 +1,194 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// commitStatusStates are the states a commit status can be set to.
+var commitStatusStates = []string{"pending", "success", "failure", "error"}
+
+// MinimalCommitStatus is the output type for commit statuses.
+type MinimalCommitStatus struct {
+	ID          int64  `json:"id"`
+	Context     string `json:"context"`
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Creator     string `json:"creator,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+func convertToMinimalCommitStatus(status *github.RepoStatus) MinimalCommitStatus {
+	minimalStatus := MinimalCommitStatus{
+		ID:          status.GetID(),
+		Context:     status.GetContext(),
+		State:       status.GetState(),
+		Description: status.GetDescription(),
+		TargetURL:   status.GetTargetURL(),
+		Creator:     status.GetCreator().GetLogin(),
+	}
+	if status.CreatedAt != nil {
+		minimalStatus.CreatedAt = status.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalStatus
+}
+
+// ListCommitStatuses creates a tool to list the statuses of a commit.
+func ListCommitStatuses(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_commit_statuses",
+			mcp.WithDescription(t("TOOL_LIST_COMMIT_STATUSES_DESCRIPTION", "List the statuses set on a commit of a GitHub repository, newest first. A context can have several statuses, the newest of which is its current state. Check runs of GitHub Actions and other apps aren't included")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COMMIT_STATUSES_USER_TITLE", "List commit statuses"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalCommitStatus](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch name or tag name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			statuses, resp, err := client.Repositories.ListStatuses(ctx, owner, repo, ref, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list the statuses of %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalStatuses := make([]MinimalCommitStatus, 0, len(statuses))
+			for _, status := range statuses {
+				minimalStatuses = append(minimalStatuses, convertToMinimalCommitStatus(status))
+			}
+
+			return MarshalledTextResult(minimalStatuses), nil
+		}
+}
+
+// CreateCommitStatus creates a tool to set a status on a commit.
+func CreateCommitStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_commit_status",
+			mcp.WithDescription(t("TOOL_CREATE_COMMIT_STATUS_DESCRIPTION", "Set a status on a commit of a GitHub repository, such as the result of a build or a release gate. The status replaces the previous one with the same context, and can be required to pass before merging")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_COMMIT_STATUS_USER_TITLE", "Create commit status"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalCommitStatus](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("State of the status"),
+				mcp.Enum(commitStatusStates...),
+			),
+			mcp.WithString("context",
+				mcp.Description("Label telling the status apart from those of other systems, such as ci/build. Defaults to default"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the status"),
+			),
+			mcp.WithString("target_url",
+				mcp.Description("URL with the details of the status, such as the logs of a build"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status := &github.RepoStatus{State: github.Ptr(state)}
+			for name, field := range map[string]**string{
+				"context":     &status.Context,
+				"description": &status.Description,
+				"target_url":  &status.TargetURL,
+			} {
+				value, err := OptionalParam[string](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				*field = ToStringPtr(value)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateStatus(ctx, owner, repo, sha, status)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create a status on %s", sha),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalCommitStatus(created)), nil
+		}
+}
@@ -0,0 +1,202 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCommitStatuses(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCommitStatuses(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_commit_statuses", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "statuses of a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusesByOwnerByRepoByRef,
+					expect(t, expectations{
+						path:        "/repos/owner/repo/commits/main/statuses",
+						queryParams: map[string]string{"page": "1", "per_page": "30"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepoStatus{
+							{
+								ID:        github.Ptr(int64(2)),
+								Context:   github.Ptr("ci/build"),
+								State:     github.Ptr("success"),
+								TargetURL: github.Ptr("https://ci.example.com/builds/2"),
+								Creator:   &github.User{Login: github.Ptr("octocat")},
+								CreatedAt: &github.Timestamp{Time: time.Date(2025, 5, 6, 7, 8, 9, 0, time.UTC)},
+							},
+							{
+								ID:      github.Ptr(int64(1)),
+								Context: github.Ptr("ci/build"),
+								State:   github.Ptr("pending"),
+							},
+						}),
+					),
+				),
+			),
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusesByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, `{"message": "No commit found for SHA: main"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list the statuses of main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCommitStatuses(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var statuses []MinimalCommitStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &statuses))
+			assert.Equal(t, []MinimalCommitStatus{
+				{
+					ID:        2,
+					Context:   "ci/build",
+					State:     "success",
+					TargetURL: "https://ci.example.com/builds/2",
+					Creator:   "octocat",
+					CreatedAt: "2025-05-06T07:08:09Z",
+				},
+				{ID: 1, Context: "ci/build", State: "pending"},
+			}, statuses)
+		})
+	}
+}
+
+func Test_CreateCommitStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommitStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_commit_status", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "context")
+	assert.Contains(t, tool.InputSchema.Properties, "target_url")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "state"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "release gate fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					expect(t, expectations{
+						path: "/repos/owner/repo/statuses/abc123",
+						requestBody: map[string]any{
+							"state":       "failure",
+							"context":     "release/gate",
+							"description": "Changelog entry missing",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepoStatus{
+							ID:          github.Ptr(int64(3)),
+							Context:     github.Ptr("release/gate"),
+							State:       github.Ptr("failure"),
+							Description: github.Ptr("Changelog entry missing"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"sha":         "abc123",
+				"state":       "failure",
+				"context":     "release/gate",
+				"description": "Changelog entry missing",
+			},
+		},
+		{
+			name: "too many statuses",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "This SHA and context has reached the maximum number of statuses."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"state": "pending",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create a status on abc123",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommitStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var status MinimalCommitStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, MinimalCommitStatus{
+				ID:          3,
+				Context:     "release/gate",
+				State:       "failure",
+				Description: "Changelog entry missing",
+			}, status)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// The actions of the user answering an elicitation request.
+const (
+	ElicitationActionAccept  = "accept"
+	ElicitationActionDecline = "decline"
+	ElicitationActionCancel  = "cancel"
+)
+
+// ErrElicitationUnsupported is returned by elicitors whose client didn't declare support for elicitation.
+var ErrElicitationUnsupported = errors.New("the client doesn't support elicitation")
+
+// ElicitationResult is the answer of the user to an elicitation request.
+type ElicitationResult struct {
+	Action  string         `json:"action"`
+	Content map[string]any `json:"content,omitempty"`
+}
+
+// Elicitor asks the user of the client for input through MCP elicitation.
+type Elicitor interface {
+	// Elicit shows the message to the user along with a form following the requested schema, returning
+	// ErrElicitationUnsupported when the client can't.
+	Elicit(ctx context.Context, message string, requestedSchema map[string]any) (ElicitationResult, error)
+}
+
+type confirmationKey struct{}
+
+// confirmation asks the user to confirm the calls to tools.
+type confirmation struct {
+	elicitor Elicitor
+	tools    map[string]bool
+}
+
+// ConfirmationMiddleware asks the user of the client to confirm the calls to the tools the policy lists, showing
+// what the call will do, before running them. Calls the user doesn't confirm fail without running, as do the calls
+// of clients that can't ask for confirmation, so that listing a tool guarantees a person approved each of its calls.
+func ConfirmationMiddleware(elicitor Elicitor, policies *PolicyStore, getTool func(name string) (server.ServerTool, bool)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// The tools of the call, such as the steps of a batch, are confirmed according to the policy it started with
+			tools := policies.Load().ConfirmTools
+			c := &confirmation{elicitor: elicitor, tools: make(map[string]bool, len(tools))}
+			for _, tool := range tools {
+				c.tools[tool] = true
+			}
+			ctx = context.WithValue(ctx, confirmationKey{}, c)
+			tool, ok := getTool(request.Params.Name)
+			if !ok {
+				tool = server.ServerTool{Tool: mcp.Tool{Name: request.Params.Name}}
+			}
+			if result := confirmToolCall(ctx, tool.Tool, request.GetArguments()); result != nil {
+				return result, nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// confirmToolCall asks the user to confirm a call to the tool when it requires confirmation, returning the result
+// of the call when it must not run. Tools calling other tools confirm their calls with it, as those calls don't go
+// through the middlewares of the server.
+func confirmToolCall(ctx context.Context, tool mcp.Tool, arguments map[string]any) *mcp.CallToolResult {
+	c, ok := ctx.Value(confirmationKey{}).(*confirmation)
+	if !ok || !c.tools[tool.Name] {
+		return nil
+	}
+	result, err := ElicitationResult{}, ErrElicitationUnsupported
+	if c.elicitor != nil {
+		result, err = c.elicitor.Elicit(ctx, confirmationMessage(tool, arguments), map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"confirm": map[string]any{
+					"type":        "boolean",
+					"title":       "Confirm",
+					"description": fmt.Sprintf("Run %s as described", tool.Name),
+				},
+			},
+			"required": []string{"confirm"},
+		})
+	}
+	var refusal string
+	level := mcp.LoggingLevelWarning
+	switch {
+	case errors.Is(err, ErrElicitationUnsupported):
+		refusal = fmt.Sprintf("%s requires the confirmation of the user, but the client doesn't support elicitation, nothing was changed", tool.Name)
+	case err != nil:
+		refusal = fmt.Sprintf("failed to ask the user to confirm %s, nothing was changed: %v", tool.Name, err)
+	default:
+		if confirmed, _ := result.Content["confirm"].(bool); result.Action == ElicitationActionAccept && confirmed {
+			return nil
+		}
+		refusal = fmt.Sprintf("the user didn't confirm %s, nothing was changed, don't retry unless the user asks to", tool.Name)
+		level = mcp.LoggingLevelNotice
+	}
+	LogToClient(ctx, level, ClientLoggerConfirmation, refusal, map[string]any{"tool": tool.Name})
+	return mcp.NewToolResultError(refusal)
+}
+
+// maxConfirmationValueLength bounds the length of the arguments shown to the user, such as the contents of files.
+const maxConfirmationValueLength = 200
+
+// confirmationMessage describes what a call to the tool will do, with its arguments in a stable order.
+func confirmationMessage(tool mcp.Tool, arguments map[string]any) string {
+	var b strings.Builder
+	title := tool.Annotations.Title
+	if title == "" {
+		title = tool.Name
+	}
+	_, _ = fmt.Fprintf(&b, "Confirm %s (%s)?", title, tool.Name)
+	if tool.Description != "" {
+		_, _ = fmt.Fprintf(&b, "\n\n%s", tool.Description)
+	}
+
+	names := make([]string, 0, len(arguments))
+	for name := range arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		b.WriteString("\n")
+	}
+	for _, name := range names {
+		value, ok := arguments[name].(string)
+		if !ok {
+			encoded, _ := json.Marshal(arguments[name])
+			value = string(encoded)
+		}
+		if runes := []rune(value); len(runes) > maxConfirmationValueLength {
+			value = fmt.Sprintf("%s... (%d characters)", string(runes[:maxConfirmationValueLength]), len(runes))
+		}
+		_, _ = fmt.Fprintf(&b, "\n%s: %s", name, value)
+	}
+	return b.String()
+}
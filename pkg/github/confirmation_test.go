@@ -0,0 +1,136 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeElicitor struct {
+	result   ElicitationResult
+	err      error
+	messages []string
+}
+
+func (e *fakeElicitor) Elicit(_ context.Context, message string, _ map[string]any) (ElicitationResult, error) {
+	e.messages = append(e.messages, message)
+	return e.result, e.err
+}
+
+func Test_ConfirmationMiddleware(t *testing.T) {
+	deleteFile := mcp.NewTool("delete_file",
+		mcp.WithDescription("Delete a file from a GitHub repository"),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{Title: "Delete file"}),
+		mcp.WithString("owner"),
+		mcp.WithString("path"),
+	)
+	getTool := func(name string) (server.ServerTool, bool) {
+		if name == deleteFile.Name {
+			return server.ServerTool{Tool: deleteFile}, true
+		}
+		return server.ServerTool{}, false
+	}
+
+	tests := []struct {
+		name          string
+		tool          string
+		elicitor      *fakeElicitor
+		expectedRun   bool
+		expectedError string
+	}{
+		{
+			name:        "tools not listed run unconfirmed",
+			tool:        "get_file_contents",
+			elicitor:    &fakeElicitor{},
+			expectedRun: true,
+		},
+		{
+			name:        "confirmed calls run",
+			tool:        "delete_file",
+			elicitor:    &fakeElicitor{result: ElicitationResult{Action: ElicitationActionAccept, Content: map[string]any{"confirm": true}}},
+			expectedRun: true,
+		},
+		{
+			name:          "accepting without confirming doesn't run",
+			tool:          "delete_file",
+			elicitor:      &fakeElicitor{result: ElicitationResult{Action: ElicitationActionAccept, Content: map[string]any{"confirm": false}}},
+			expectedError: "the user didn't confirm delete_file, nothing was changed, don't retry unless the user asks to",
+		},
+		{
+			name:          "declined calls don't run",
+			tool:          "delete_file",
+			elicitor:      &fakeElicitor{result: ElicitationResult{Action: ElicitationActionDecline}},
+			expectedError: "the user didn't confirm delete_file, nothing was changed, don't retry unless the user asks to",
+		},
+		{
+			name:          "calls of clients without elicitation don't run",
+			tool:          "delete_file",
+			elicitor:      &fakeElicitor{err: ErrElicitationUnsupported},
+			expectedError: "delete_file requires the confirmation of the user, but the client doesn't support elicitation, nothing was changed",
+		},
+		{
+			name:          "calls the client fails to confirm don't run",
+			tool:          "delete_file",
+			elicitor:      &fakeElicitor{err: errors.New("timed out")},
+			expectedError: "failed to ask the user to confirm delete_file, nothing was changed: timed out",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var ran bool
+			handler := ConfirmationMiddleware(tc.elicitor, NewPolicyStore(Policy{ConfirmTools: []string{"delete_file"}}), getTool)(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				ran = true
+				return mcp.NewToolResultText("ok"), nil
+			})
+			request := createMCPRequest(map[string]any{"owner": "octo-org", "path": "README.md"})
+			request.Params.Name = tc.tool
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRun, ran)
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, getErrorResult(t, result).Text)
+			}
+		})
+	}
+
+	t.Run("calls fail without an elicitor", func(t *testing.T) {
+		handler := ConfirmationMiddleware(nil, NewPolicyStore(Policy{ConfirmTools: []string{"delete_file"}}), getTool)(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			t.Fatal("expected the call not to run")
+			return nil, nil
+		})
+		request := createMCPRequest(map[string]any{})
+		request.Params.Name = "delete_file"
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("the user is shown what the call does", func(t *testing.T) {
+		elicitor := &fakeElicitor{result: ElicitationResult{Action: ElicitationActionAccept, Content: map[string]any{"confirm": true}}}
+		handler := ConfirmationMiddleware(elicitor, NewPolicyStore(Policy{ConfirmTools: []string{"delete_file"}}), getTool)(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+		request := createMCPRequest(map[string]any{"path": "README.md", "owner": "octo-org", "sha": nil})
+		request.Params.Name = "delete_file"
+		_, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		require.Len(t, elicitor.messages, 1)
+		assert.Equal(t, "Confirm Delete file (delete_file)?\n\nDelete a file from a GitHub repository\n\nowner: octo-org\npath: README.md\nsha: null", elicitor.messages[0])
+	})
+}
+
+func Test_ConfirmationMessageTruncatesLongArguments(t *testing.T) {
+	long := make([]rune, maxConfirmationValueLength+10)
+	for i := range long {
+		long[i] = 'é'
+	}
+	message := confirmationMessage(mcp.NewTool("create_or_update_file"), map[string]any{"content": string(long)})
+	assert.Equal(t, "Confirm create_or_update_file (create_or_update_file)?\n\ncontent: "+string(long[:maxConfirmationValueLength])+"... (210 characters)", message)
+}
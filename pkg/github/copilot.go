@@ -0,0 +1,292 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalCopilotSeat is the output type for a Copilot seat.
+type MinimalCopilotSeat struct {
+	Assignee string `json:"assignee"`
+	// AssigneeType is User, Team or Organization.
+	AssigneeType            string `json:"assignee_type"`
+	AssigningTeam           string `json:"assigning_team,omitempty"`
+	PlanType                string `json:"plan_type,omitempty"`
+	PendingCancellationDate string `json:"pending_cancellation_date,omitempty"`
+	LastActivityAt          string `json:"last_activity_at,omitempty"`
+	LastActivityEditor      string `json:"last_activity_editor,omitempty"`
+	CreatedAt               string `json:"created_at,omitempty"`
+}
+
+// MinimalCopilotSeats is the output type for a page of the Copilot seats of an organization.
+type MinimalCopilotSeats struct {
+	TotalSeats int64                `json:"total_seats"`
+	Seats      []MinimalCopilotSeat `json:"seats"`
+}
+
+func convertToMinimalCopilotSeat(seat *github.CopilotSeatDetails) MinimalCopilotSeat {
+	minimalSeat := MinimalCopilotSeat{
+		PlanType:                seat.GetPlanType(),
+		PendingCancellationDate: seat.GetPendingCancellationDate(),
+		LastActivityEditor:      seat.GetLastActivityEditor(),
+	}
+	if user, ok := seat.GetUser(); ok {
+		minimalSeat.Assignee, minimalSeat.AssigneeType = user.GetLogin(), "User"
+	} else if team, ok := seat.GetTeam(); ok {
+		minimalSeat.Assignee, minimalSeat.AssigneeType = team.GetSlug(), "Team"
+	} else if org, ok := seat.GetOrganization(); ok {
+		minimalSeat.Assignee, minimalSeat.AssigneeType = org.GetLogin(), "Organization"
+	}
+	if seat.AssigningTeam != nil {
+		minimalSeat.AssigningTeam = seat.AssigningTeam.GetSlug()
+	}
+	if seat.LastActivityAt != nil {
+		minimalSeat.LastActivityAt = seat.LastActivityAt.Format("2006-01-02T15:04:05Z")
+	}
+	if seat.CreatedAt != nil {
+		minimalSeat.CreatedAt = seat.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalSeat
+}
+
+// withCopilotSeatHolders adds the parameters naming the users and teams to assign or cancel Copilot seats of.
+func withCopilotSeatHolders(action string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("org",
+			mcp.Required(),
+			mcp.Description("Organization login"),
+		)(tool)
+		mcp.WithArray("usernames",
+			mcp.Description(fmt.Sprintf("Usernames of the members to %s Copilot seats of", action)),
+			mcp.Items(map[string]any{"type": "string"}),
+		)(tool)
+		mcp.WithArray("teams",
+			mcp.Description(fmt.Sprintf("Names of the teams whose members to %s Copilot seats of", action)),
+			mcp.Items(map[string]any{"type": "string"}),
+		)(tool)
+	}
+}
+
+func copilotSeatHoldersFromRequest(request mcp.CallToolRequest) (string, []string, []string, error) {
+	org, err := RequiredParam[string](request, "org")
+	if err != nil {
+		return "", nil, nil, err
+	}
+	usernames, err := OptionalStringArrayParam(request, "usernames")
+	if err != nil {
+		return "", nil, nil, err
+	}
+	teams, err := OptionalStringArrayParam(request, "teams")
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if len(usernames) == 0 && len(teams) == 0 {
+		return "", nil, nil, fmt.Errorf("at least one of usernames or teams is required")
+	}
+	return org, usernames, teams, nil
+}
+
+// GetCopilotBilling creates a tool to get the Copilot subscription of an organization.
+func GetCopilotBilling(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_copilot_billing",
+			mcp.WithDescription(t("TOOL_GET_COPILOT_BILLING_DESCRIPTION", "Get a summary of the Copilot subscription of an organization: how many seats it pays for, how many were added, are pending or unused this billing cycle, and how seats are managed. Requires being an owner of the organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COPILOT_BILLING_USER_TITLE", "Get Copilot billing"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[github.CopilotOrganizationDetails](),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			billing, resp, err := client.Copilot.GetCopilotBilling(ctx, org)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get the Copilot billing of %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(billing), nil
+		}
+}
+
+// ListCopilotSeats creates a tool to list the Copilot seats of an organization.
+func ListCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_copilot_seats",
+			mcp.WithDescription(t("TOOL_LIST_COPILOT_SEATS_DESCRIPTION", "List the Copilot seats of an organization: who has one, through which team, and when they last used Copilot. Requires being an owner of the organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COPILOT_SEATS_USER_TITLE", "List Copilot seats"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[MinimalCopilotSeats](),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			seats, resp, err := client.Copilot.ListCopilotSeats(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list the Copilot seats of %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalSeats := MinimalCopilotSeats{
+				TotalSeats: seats.TotalSeats,
+				Seats:      make([]MinimalCopilotSeat, 0, len(seats.Seats)),
+			}
+			for _, seat := range seats.Seats {
+				minimalSeats.Seats = append(minimalSeats.Seats, convertToMinimalCopilotSeat(seat))
+			}
+
+			return MarshalledTextResult(minimalSeats), nil
+		}
+}
+
+// AddCopilotSeats creates a tool to assign Copilot seats to members of an organization.
+func AddCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_copilot_seats",
+			mcp.WithDescription(t("TOOL_ADD_COPILOT_SEATS_DESCRIPTION", "Assign Copilot seats to members of an organization, directly or through their teams. The organization is billed for each new seat. Requires being an owner of the organization, and its Copilot access to be managed for selected members")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_COPILOT_SEATS_USER_TITLE", "Add Copilot seats"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[github.SeatAssignments](),
+			withCopilotSeatHolders("assign"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, usernames, teams, err := copilotSeatHoldersFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var changes github.SeatAssignments
+			if len(usernames) > 0 {
+				assignments, resp, err := client.Copilot.AddCopilotUsers(ctx, org, usernames)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to assign Copilot seats to users of %s", org),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				changes.SeatsCreated += assignments.SeatsCreated
+			}
+			if len(teams) > 0 {
+				assignments, resp, err := client.Copilot.AddCopilotTeams(ctx, org, teams)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to assign Copilot seats to teams of %s, after creating %d seats for users", org, changes.SeatsCreated),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				changes.SeatsCreated += assignments.SeatsCreated
+			}
+
+			return MarshalledTextResult(changes), nil
+		}
+}
+
+// RemoveCopilotSeats creates a tool to cancel the Copilot seats of members of an organization.
+func RemoveCopilotSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_copilot_seats",
+			mcp.WithDescription(t("TOOL_REMOVE_COPILOT_SEATS_DESCRIPTION", "Cancel the Copilot seats of members of an organization, assigned directly or through their teams. Members keep Copilot until the end of the billing cycle, unless they get a seat another way. Requires being an owner of the organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_COPILOT_SEATS_USER_TITLE", "Remove Copilot seats"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[github.SeatCancellations](),
+			withCopilotSeatHolders("cancel"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, usernames, teams, err := copilotSeatHoldersFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var changes github.SeatCancellations
+			if len(usernames) > 0 {
+				cancellations, resp, err := client.Copilot.RemoveCopilotUsers(ctx, org, usernames)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to cancel the Copilot seats of users of %s", org),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				changes.SeatsCancelled += cancellations.SeatsCancelled
+			}
+			if len(teams) > 0 {
+				cancellations, resp, err := client.Copilot.RemoveCopilotTeams(ctx, org, teams)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to cancel the Copilot seats of teams of %s, after cancelling %d seats of users", org, changes.SeatsCancelled),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				changes.SeatsCancelled += cancellations.SeatsCancelled
+			}
+
+			return MarshalledTextResult(changes), nil
+		}
+}
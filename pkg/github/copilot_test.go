@@ -0,0 +1,269 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCopilotBilling(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCopilotBilling(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_copilot_billing", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "seat breakdown",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsCopilotBillingByOrg,
+					&github.CopilotOrganizationDetails{
+						SeatBreakdown:         &github.CopilotSeatBreakdown{Total: 12, AddedThisCycle: 2, InactiveThisCycle: 3},
+						SeatManagementSetting: "assign_selected",
+					},
+				),
+			),
+		},
+		{
+			name: "no Copilot subscription",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get the Copilot billing of octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCopilotBilling(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"org": "octo-org",
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var billing github.CopilotOrganizationDetails
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &billing))
+			assert.Equal(t, 12, billing.SeatBreakdown.Total)
+			assert.Equal(t, "assign_selected", billing.SeatManagementSetting)
+		})
+	}
+}
+
+func Test_ListCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_copilot_seats", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	lastActivity := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsCopilotBillingSeatsByOrg,
+			expectQueryParams(t, map[string]string{
+				"page":     "2",
+				"per_page": "10",
+			}).andThen(
+				mockResponse(t, http.StatusOK, map[string]any{
+					"total_seats": 12,
+					"seats": []map[string]any{
+						{
+							"assignee":             map[string]any{"login": "octocat", "type": "User"},
+							"assigning_team":       map[string]any{"slug": "engineering"},
+							"plan_type":            "business",
+							"last_activity_at":     lastActivity,
+							"last_activity_editor": "vscode/1.98.0",
+						},
+						{
+							"assignee":                  map[string]any{"login": "hubot", "type": "User"},
+							"pending_cancellation_date": "2025-04-01",
+						},
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":     "octo-org",
+		"page":    float64(2),
+		"perPage": float64(10),
+	}))
+	require.NoError(t, err)
+
+	var seats MinimalCopilotSeats
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &seats))
+	assert.Equal(t, MinimalCopilotSeats{
+		TotalSeats: 12,
+		Seats: []MinimalCopilotSeat{
+			{
+				Assignee:           "octocat",
+				AssigneeType:       "User",
+				AssigningTeam:      "engineering",
+				PlanType:           "business",
+				LastActivityAt:     "2025-03-04T10:00:00Z",
+				LastActivityEditor: "vscode/1.98.0",
+			},
+			{
+				Assignee:                "hubot",
+				AssigneeType:            "User",
+				PendingCancellationDate: "2025-04-01",
+			},
+		},
+	}, seats)
+}
+
+func Test_AddCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_copilot_seats", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "usernames")
+	assert.Contains(t, tool.InputSchema.Properties, "teams")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedSeats  int
+	}{
+		{
+			name: "users and teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsCopilotBillingSelectedUsersByOrg,
+					expectRequestBody(t, map[string]any{"selected_usernames": []any{"octocat", "hubot"}}).andThen(
+						mockResponse(t, http.StatusCreated, &github.SeatAssignments{SeatsCreated: 2}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsCopilotBillingSelectedTeamsByOrg,
+					expectRequestBody(t, map[string]any{"selected_teams": []any{"engineering"}}).andThen(
+						mockResponse(t, http.StatusCreated, &github.SeatAssignments{SeatsCreated: 5}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"usernames": []any{"octocat", "hubot"},
+				"teams":     []any{"engineering"},
+			},
+			expectedSeats: 7,
+		},
+		{
+			name:         "no users or teams",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of usernames or teams is required",
+		},
+		{
+			name: "seats managed for all members",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsCopilotBillingSelectedUsersByOrg,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Copilot seat management is not set to assign selected members"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"usernames": []any{"octocat"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to assign Copilot seats to users of octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var assignments github.SeatAssignments
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &assignments))
+			assert.Equal(t, tc.expectedSeats, assignments.SeatsCreated)
+		})
+	}
+}
+
+func Test_RemoveCopilotSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveCopilotSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_copilot_seats", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsCopilotBillingSelectedTeamsByOrg,
+			expectRequestBody(t, map[string]any{"selected_teams": []any{"contractors"}}).andThen(
+				mockResponse(t, http.StatusOK, &github.SeatCancellations{SeatsCancelled: 3}),
+			),
+		),
+	))
+	_, handler := RemoveCopilotSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":   "octo-org",
+		"teams": []any{"contractors"},
+	}))
+	require.NoError(t, err)
+
+	var cancellations github.SeatCancellations
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &cancellations))
+	assert.Equal(t, 3, cancellations.SeatsCancelled)
+}
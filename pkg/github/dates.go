@@ -0,0 +1,158 @@
+package github
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// shortRelativeTimeRegex matches durations such as -7d, 2h or +1w, which are in the past unless prefixed with +.
+	shortRelativeTimeRegex = regexp.MustCompile(`^([+-]?)(\d+)\s*(s|m|min|mins|h|hr|hrs|d|w|mo|y)$`)
+	// agoRelativeTimeRegex matches durations such as 2 hours ago or in 3 days.
+	agoRelativeTimeRegex = regexp.MustCompile(`^(?:(in)\s+)?(\d+|an?)\s+(second|minute|hour|day|week|month|year)s?(?:\s+(ago))?$`)
+	// lastRelativeTimeRegex matches last monday, last week and similar.
+	lastRelativeTimeRegex = regexp.MustCompile(`^last\s+(monday|tuesday|wednesday|thursday|friday|saturday|sunday|week|month|year)$`)
+	// dateQualifierRegex matches the date qualifiers of search queries, with an optional comparison operator.
+	dateQualifierRegex = regexp.MustCompile(`\b(created|updated|closed|merged|pushed|author-date|committer-date):(>=|<=|>|<)?("[^"]*"|[^\s"]+)`)
+)
+
+var relativeTimeWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// parseRelativeTime parses times relative to now, such as -7d, 2 hours ago, yesterday or last monday, in UTC.
+// Days, like today and last monday, start at midnight. It returns false when the value isn't a relative time.
+func parseRelativeTime(value string, now time.Time) (time.Time, bool) {
+	value = strings.ToLower(strings.Join(strings.Fields(value), " "))
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch value {
+	case "now":
+		return now, true
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	}
+
+	if match := shortRelativeTimeRegex.FindStringSubmatch(value); match != nil {
+		amount, err := strconv.Atoi(match[2])
+		if err != nil {
+			return time.Time{}, false
+		}
+		if match[1] != "+" {
+			amount = -amount
+		}
+		units := map[string]string{
+			"s": "second", "m": "minute", "min": "minute", "mins": "minute", "h": "hour", "hr": "hour", "hrs": "hour",
+			"d": "day", "w": "week", "mo": "month", "y": "year",
+		}
+		return addTimeUnits(now, amount, units[match[3]]), true
+	}
+
+	if match := agoRelativeTimeRegex.FindStringSubmatch(value); match != nil {
+		// Exactly one of in and ago tells the direction
+		future, past := match[1] == "in", match[4] == "ago"
+		if future == past {
+			return time.Time{}, false
+		}
+		amount := 1
+		if match[2] != "a" && match[2] != "an" {
+			var err error
+			if amount, err = strconv.Atoi(match[2]); err != nil {
+				return time.Time{}, false
+			}
+		}
+		if past {
+			amount = -amount
+		}
+		return addTimeUnits(now, amount, match[3]), true
+	}
+
+	if match := lastRelativeTimeRegex.FindStringSubmatch(value); match != nil {
+		if weekday, ok := relativeTimeWeekdays[match[1]]; ok {
+			days := (int(today.Weekday()) - int(weekday) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			return today.AddDate(0, 0, -days), true
+		}
+		return addTimeUnits(now, -1, match[1]), true
+	}
+
+	return time.Time{}, false
+}
+
+// addTimeUnits adds an amount of time units, such as days, to a time. Months and years are calendar months and years.
+func addTimeUnits(t time.Time, amount int, unit string) time.Time {
+	switch unit {
+	case "second":
+		return t.Add(time.Duration(amount) * time.Second)
+	case "minute":
+		return t.Add(time.Duration(amount) * time.Minute)
+	case "hour":
+		return t.Add(time.Duration(amount) * time.Hour)
+	case "day":
+		return t.AddDate(0, 0, amount)
+	case "week":
+		return t.AddDate(0, 0, 7*amount)
+	case "month":
+		return t.AddDate(0, amount, 0)
+	default:
+		return t.AddDate(amount, 0, 0)
+	}
+}
+
+// formatDateFilter formats a time for a date filter, as a date when it is midnight.
+func formatDateFilter(t time.Time) string {
+	if t.Equal(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)) {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02T15:04:05Z")
+}
+
+// normalizeDateFilter replaces relative times in a date filter, which is a time with an optional comparison
+// operator such as >=-7d, or a range such as "last monday..today", with ISO 8601 dates. Other values, such
+// as ISO 8601 dates already, are kept as they are.
+func normalizeDateFilter(filter string, now time.Time) string {
+	if from, to, ok := strings.Cut(filter, ".."); ok {
+		return normalizeDateFilter(from, now) + ".." + normalizeDateFilter(to, now)
+	}
+
+	operator := ""
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(filter, op) {
+			operator = op
+			break
+		}
+	}
+	if t, ok := parseRelativeTime(strings.TrimPrefix(filter, operator), now); ok {
+		return operator + formatDateFilter(t)
+	}
+	return filter
+}
+
+// normalizeDateQualifiers replaces the relative times in the date qualifiers of a search query, such as
+// created:>-7d or updated:>"2 hours ago", with ISO 8601 dates.
+func normalizeDateQualifiers(query string, now time.Time) string {
+	return dateQualifierRegex.ReplaceAllStringFunc(query, func(qualifier string) string {
+		match := dateQualifierRegex.FindStringSubmatch(qualifier)
+		value := strings.Trim(match[3], `"`)
+		normalized := normalizeDateFilter(match[2]+value, now)
+		if normalized == match[2]+value {
+			return qualifier
+		}
+		return match[1] + ":" + normalized
+	})
+}
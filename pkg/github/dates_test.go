@@ -0,0 +1,91 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseRelativeTime(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{input: "now", expected: now},
+		{input: "today", expected: time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{input: "Yesterday", expected: time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{input: "tomorrow", expected: time.Date(2025, 3, 13, 0, 0, 0, 0, time.UTC)},
+		{input: "-7d", expected: time.Date(2025, 3, 5, 15, 30, 0, 0, time.UTC)},
+		{input: "7d", expected: time.Date(2025, 3, 5, 15, 30, 0, 0, time.UTC)},
+		{input: "+1w", expected: time.Date(2025, 3, 19, 15, 30, 0, 0, time.UTC)},
+		{input: "-2h", expected: time.Date(2025, 3, 12, 13, 30, 0, 0, time.UTC)},
+		{input: "30m", expected: time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC)},
+		{input: "-1mo", expected: time.Date(2025, 2, 12, 15, 30, 0, 0, time.UTC)},
+		{input: "-1y", expected: time.Date(2024, 3, 12, 15, 30, 0, 0, time.UTC)},
+		{input: "2 hours ago", expected: time.Date(2025, 3, 12, 13, 30, 0, 0, time.UTC)},
+		{input: "an hour ago", expected: time.Date(2025, 3, 12, 14, 30, 0, 0, time.UTC)},
+		{input: "3  days ago", expected: time.Date(2025, 3, 9, 15, 30, 0, 0, time.UTC)},
+		{input: "in 1 day", expected: time.Date(2025, 3, 13, 15, 30, 0, 0, time.UTC)},
+		{input: "last monday", expected: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{input: "last wednesday", expected: time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{input: "last thursday", expected: time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{input: "last week", expected: time.Date(2025, 3, 5, 15, 30, 0, 0, time.UTC)},
+		{input: "last month", expected: time.Date(2025, 2, 12, 15, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			parsed, ok := parseRelativeTime(tc.input, now)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, parsed)
+		})
+	}
+
+	for _, input := range []string{"", "2025-03-12", "15/01/2023", "2 hours", "in 2 hours ago", "last decade", "-7x"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, ok := parseRelativeTime(input, now)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func Test_NormalizeDateFilter(t *testing.T) {
+	now := time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC)
+
+	tests := map[string]string{
+		"-7d":                    "2025-03-05T15:30:00Z",
+		">=yesterday":            ">=2025-03-11",
+		"<2 hours ago":           "<2025-03-12T13:30:00Z",
+		"last monday..today":     "2025-03-10..2025-03-12",
+		"yesterday..*":           "2025-03-11..*",
+		"2025-01-01":             "2025-01-01",
+		">=2025-01-01":           ">=2025-01-01",
+		"2025-01-01..2025-02-01": "2025-01-01..2025-02-01",
+		"not a date":             "not a date",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, normalizeDateFilter(input, now), input)
+	}
+}
+
+func Test_NormalizeDateQualifiers(t *testing.T) {
+	now := time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC)
+
+	tests := map[string]string{
+		"is:open created:>-7d":                   "is:open created:>2025-03-05T15:30:00Z",
+		`updated:>="2 hours ago" label:bug`:      "updated:>=2025-03-12T13:30:00Z label:bug",
+		"merged:yesterday..today author:octocat": "merged:2025-03-11..2025-03-12 author:octocat",
+		"-closed:<last monday":                   "-closed:<last monday",
+		`-closed:<"last monday"`:                 "-closed:<2025-03-10",
+		"created:>=2025-01-01 stars:>10":         "created:>=2025-01-01 stars:>10",
+		`pushed:"not a date"`:                    `pushed:"not a date"`,
+		"fix created date parsing":               "fix created date parsing",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, normalizeDateQualifiers(input, now), input)
+	}
+}
@@ -22,6 +22,7 @@ func GetDependabotAlert(getClient GetClientFn, t translations.TranslationHelperF
 				Title:        t("TOOL_GET_DEPENDABOT_ALERT_USER_TITLE", "Get dependabot alert"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.DependabotAlert](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("The owner of the repository."),
@@ -89,6 +90,7 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 				Title:        t("TOOL_LIST_DEPENDABOT_ALERTS_USER_TITLE", "List dependabot alerts"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.DependabotAlert](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("The owner of the repository."),
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"strings"
+)
+
+// DefaultMaxDiffLines is the number of lines above which pull request diffs are summarized per file.
+const DefaultMaxDiffLines = 2000
+
+// DiffFile describes the changes to a single file in a diff.
+type DiffFile struct {
+	Path         string `json:"path"`
+	PreviousPath string `json:"previous_path,omitempty"`
+	Status       string `json:"status"`
+	Binary       bool   `json:"binary,omitempty"`
+	Additions    int    `json:"additions"`
+	Deletions    int    `json:"deletions"`
+	Lines        int    `json:"lines"`
+
+	diff string
+}
+
+// DiffSummary lists the files of a diff too long to be returned whole.
+type DiffSummary struct {
+	Message    string     `json:"message"`
+	TotalLines int        `json:"total_lines"`
+	Additions  int        `json:"additions"`
+	Deletions  int        `json:"deletions"`
+	Files      []DiffFile `json:"files"`
+}
+
+// splitDiff splits a diff in git format into the changes to each file.
+func splitDiff(diff string) []DiffFile {
+	var files []DiffFile
+	var current *DiffFile
+	var section strings.Builder
+	inHunk := false
+
+	finish := func() {
+		if current == nil {
+			return
+		}
+		current.diff = strings.TrimSuffix(section.String(), "\n")
+		current.Lines = strings.Count(current.diff, "\n") + 1
+		if current.Status == "" {
+			current.Status = "modified"
+		}
+		files = append(files, *current)
+		section.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if line == "" {
+			continue
+		}
+		content := strings.TrimRight(line, "\r\n")
+		if strings.HasPrefix(content, "diff --git ") {
+			finish()
+			current = &DiffFile{}
+			current.PreviousPath, current.Path = parseDiffGitHeader(strings.TrimPrefix(content, "diff --git "))
+			inHunk = false
+		}
+		if current == nil {
+			continue
+		}
+		section.WriteString(line)
+
+		switch {
+		case strings.HasPrefix(content, "@@"):
+			inHunk = true
+		case inHunk && strings.HasPrefix(content, "+"):
+			current.Additions++
+		case inHunk && strings.HasPrefix(content, "-"):
+			current.Deletions++
+		case inHunk:
+		case strings.HasPrefix(content, "new file mode"):
+			current.Status = "added"
+		case strings.HasPrefix(content, "deleted file mode"):
+			current.Status = "removed"
+		case strings.HasPrefix(content, "rename from "):
+			current.Status = "renamed"
+			current.PreviousPath = strings.TrimPrefix(content, "rename from ")
+		case strings.HasPrefix(content, "rename to "):
+			current.Path = strings.TrimPrefix(content, "rename to ")
+		case strings.HasPrefix(content, "copy from "):
+			current.Status = "copied"
+			current.PreviousPath = strings.TrimPrefix(content, "copy from ")
+		case strings.HasPrefix(content, "copy to "):
+			current.Path = strings.TrimPrefix(content, "copy to ")
+		case strings.HasPrefix(content, "Binary files ") || content == "GIT binary patch":
+			current.Binary = true
+		case strings.HasPrefix(content, "--- a/"):
+			current.PreviousPath = strings.TrimPrefix(content, "--- a/")
+		case strings.HasPrefix(content, "+++ b/"):
+			current.Path = strings.TrimPrefix(content, "+++ b/")
+		}
+	}
+	finish()
+
+	// Only renamed and copied files keep their previous path
+	for i := range files {
+		if files[i].Status != "renamed" && files[i].Status != "copied" {
+			files[i].PreviousPath = ""
+		}
+	}
+	return files
+}
+
+// parseDiffGitHeader returns the paths of a "diff --git a/old b/new" header. Paths containing " b/" are
+// ambiguous in it, so they are overridden by the unambiguous lines following the header when there are any.
+func parseDiffGitHeader(header string) (string, string) {
+	header = strings.TrimPrefix(header, "a/")
+	if i := strings.LastIndex(header, " b/"); i >= 0 {
+		return header[:i], header[i+len(" b/"):]
+	}
+	return header, header
+}
+
+// summarizeDiff lists the files of a diff.
+func summarizeDiff(files []DiffFile, totalLines int, message string) DiffSummary {
+	summary := DiffSummary{Message: message, TotalLines: totalLines, Files: files}
+	for _, file := range files {
+		summary.Additions += file.Additions
+		summary.Deletions += file.Deletions
+	}
+	return summary
+}
+
+// findDiffFile returns the changes to the file at path, matching renamed and copied files by their previous path too.
+func findDiffFile(files []DiffFile, path string) (DiffFile, bool) {
+	path = strings.TrimPrefix(path, "/")
+	for _, file := range files {
+		if file.Path == path || (file.PreviousPath != "" && file.PreviousPath == path) {
+			return file, true
+		}
+	}
+	return DiffFile{}, false
+}
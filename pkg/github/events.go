@@ -0,0 +1,337 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxEventsPerPage is the largest page of events the Events API returns.
+const maxEventsPerPage = 100
+
+// MinimalEvent is the trimmed output type for events of the Events API.
+type MinimalEvent struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Actor     string `json:"actor,omitempty"`
+	Repo      string `json:"repo,omitempty"`
+	Org       string `json:"org,omitempty"`
+	Public    bool   `json:"public"`
+	CreatedAt string `json:"created_at,omitempty"`
+	Action    string `json:"action,omitempty"`
+	Ref       string `json:"ref,omitempty"`
+	Number    int    `json:"number,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Commits   int    `json:"commits,omitempty"`
+}
+
+// eventPayload holds the fields of event payloads worth summarizing.
+type eventPayload struct {
+	Action  string `json:"action"`
+	Ref     string `json:"ref"`
+	Number  int    `json:"number"`
+	Size    int    `json:"size"`
+	Release *struct {
+		TagName string `json:"tag_name"`
+		Name    string `json:"name"`
+	} `json:"release"`
+	Issue *struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"issue"`
+	PullRequest *struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"pull_request"`
+}
+
+func convertToMinimalEvent(event *github.Event) MinimalEvent {
+	minimalEvent := MinimalEvent{
+		ID:     event.GetID(),
+		Type:   event.GetType(),
+		Actor:  event.GetActor().GetLogin(),
+		Repo:   event.GetRepo().GetName(),
+		Org:    event.GetOrg().GetLogin(),
+		Public: event.GetPublic(),
+	}
+	if event.CreatedAt != nil {
+		minimalEvent.CreatedAt = event.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	var payload eventPayload
+	if event.RawPayload == nil || json.Unmarshal(*event.RawPayload, &payload) != nil {
+		return minimalEvent
+	}
+	minimalEvent.Action = payload.Action
+	minimalEvent.Ref = payload.Ref
+	minimalEvent.Number = payload.Number
+	minimalEvent.Commits = payload.Size
+	switch {
+	case payload.PullRequest != nil:
+		minimalEvent.Number = payload.PullRequest.Number
+		minimalEvent.Title = payload.PullRequest.Title
+	case payload.Issue != nil:
+		minimalEvent.Number = payload.Issue.Number
+		minimalEvent.Title = payload.Issue.Title
+	case payload.Release != nil:
+		minimalEvent.Ref = payload.Release.TagName
+		minimalEvent.Title = payload.Release.Name
+	}
+	return minimalEvent
+}
+
+// withEventFilters adds the parameters shared by the tools listing events.
+func withEventFilters() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithArray("types",
+			mcp.Description("Only return events of these types, such as PushEvent, PullRequestEvent, IssuesEvent, IssueCommentEvent, PullRequestReviewEvent, CreateEvent or ReleaseEvent"),
+			mcp.Items(
+				map[string]any{
+					"type": "string",
+				},
+			),
+		)(tool)
+		mcp.WithString("since",
+			mcp.Description("Only return events created after this date (ISO 8601 timestamp, or a relative time such as -7d or 2 hours ago), fetching as many pages as needed. The Events API only returns events of the last 90 days, up to 300 of them."),
+		)(tool)
+		WithPagination()(tool)
+	}
+}
+
+// eventFilters selects the events returned by the tools listing events.
+type eventFilters struct {
+	types      []string
+	since      time.Time
+	pagination PaginationParams
+}
+
+func optionalEventFilters(request mcp.CallToolRequest) (eventFilters, error) {
+	types, err := OptionalStringArrayParam(request, "types")
+	if err != nil {
+		return eventFilters{}, err
+	}
+	sinceStr, err := OptionalParam[string](request, "since")
+	if err != nil {
+		return eventFilters{}, err
+	}
+	var since time.Time
+	if sinceStr != "" {
+		if since, err = parseISOTimestamp(sinceStr); err != nil {
+			return eventFilters{}, fmt.Errorf("failed to list events: %w", err)
+		}
+	}
+	pagination, err := OptionalPaginationParams(request)
+	if err != nil {
+		return eventFilters{}, err
+	}
+	return eventFilters{types: types, since: since, pagination: pagination}, nil
+}
+
+// listEvents lists a page of events matching the filters. When filtering by date, pages are fetched until the
+// events are older than the date, as the Events API lists the most recent events first.
+func listEvents(ctx context.Context, filters eventFilters, list func(opts *github.ListOptions) ([]*github.Event, *github.Response, error)) ([]MinimalEvent, *github.Response, error) {
+	opts := &github.ListOptions{Page: filters.pagination.Page, PerPage: filters.pagination.PerPage}
+	if !filters.since.IsZero() {
+		opts.PerPage = maxEventsPerPage
+	}
+
+	events := []MinimalEvent{}
+	for {
+		page, resp, err := list(opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+
+		for _, event := range page {
+			if !filters.since.IsZero() && event.GetCreatedAt().Before(filters.since) {
+				return events, resp, nil
+			}
+			if len(filters.types) > 0 && !slices.Contains(filters.types, event.GetType()) {
+				continue
+			}
+			events = append(events, convertToMinimalEvent(event))
+		}
+
+		if filters.since.IsZero() || resp.NextPage == 0 {
+			return events, resp, nil
+		}
+		NotifyProgress(ctx, float64(len(events)), 0, fmt.Sprintf("Listed %d events", len(events)))
+		opts.Page = resp.NextPage
+	}
+}
+
+// ListUserEvents creates a tool to list the events performed by a user.
+func ListUserEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_events",
+			mcp.WithDescription(t("TOOL_LIST_USER_EVENTS_DESCRIPTION", "List the recent activity of a user, most recent first, such as pushes, pull requests, issues, comments and reviews. Private events are included for the authenticated user.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_USER_EVENTS_USER_TITLE", "List user events"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalEvent](),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user"),
+			),
+			withEventFilters(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filters, err := optionalEventFilters(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			events, resp, err := listEvents(ctx, filters, func(opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+				return client.Activity.ListEventsPerformedByUser(ctx, username, false, opts)
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list events of user '%s'", username),
+					resp,
+					err,
+				), nil
+			}
+
+			return MarshalledTextResult(events), nil
+		}
+}
+
+// ListOrgEvents creates a tool to list the events of an organization.
+func ListOrgEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_events",
+			mcp.WithDescription(t("TOOL_LIST_ORG_EVENTS_DESCRIPTION", "List the recent activity in the repositories of an organization, most recent first, such as pushes, pull requests, issues, comments and reviews.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_EVENTS_USER_TITLE", "List organization events"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalEvent](),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithBoolean("include_private",
+				mcp.Description("Include the events of private repositories the authenticated user, who must be a member of the organization, has access to. Default is false."),
+			),
+			withEventFilters(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includePrivate, err := OptionalParam[bool](request, "include_private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filters, err := optionalEventFilters(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			list := func(opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+				return client.Activity.ListEventsForOrganization(ctx, org, opts)
+			}
+			if includePrivate {
+				// Private events are only listed in the organization dashboard of the authenticated user
+				user, resp, err := client.Users.Get(ctx, "")
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get the authenticated user",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				list = func(opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+					return client.Activity.ListUserEventsForOrganization(ctx, org, user.GetLogin(), opts)
+				}
+			}
+
+			events, resp, err := listEvents(ctx, filters, list)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list events of organization '%s'", org),
+					resp,
+					err,
+				), nil
+			}
+
+			return MarshalledTextResult(events), nil
+		}
+}
+
+// ListRepoEvents creates a tool to list the events of a repository.
+func ListRepoEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repo_events",
+			mcp.WithDescription(t("TOOL_LIST_REPO_EVENTS_DESCRIPTION", "List the recent activity in a repository, most recent first, such as pushes, pull requests, issues, comments and reviews.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPO_EVENTS_USER_TITLE", "List repository events"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalEvent](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			withEventFilters(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filters, err := optionalEventFilters(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			events, resp, err := listEvents(ctx, filters, func(opts *github.ListOptions) ([]*github.Event, *github.Response, error) {
+				return client.Activity.ListRepositoryEvents(ctx, owner, repo, opts)
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list events of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+
+			return MarshalledTextResult(events), nil
+		}
+}
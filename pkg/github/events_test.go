@@ -0,0 +1,286 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockEvent(id string, eventType string, createdAt time.Time, payload string) *github.Event {
+	raw := json.RawMessage(payload)
+	return &github.Event{
+		ID:         github.Ptr(id),
+		Type:       github.Ptr(eventType),
+		Actor:      &github.User{Login: github.Ptr("octocat")},
+		Repo:       &github.Repository{Name: github.Ptr("octo-org/repo")},
+		Public:     github.Ptr(true),
+		CreatedAt:  &github.Timestamp{Time: createdAt},
+		RawPayload: &raw,
+	}
+}
+
+func Test_ListRepoEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepoEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repo_events", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "types")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	firstPage := []*github.Event{
+		mockEvent("3", "PullRequestEvent", now.Add(-1*time.Hour), `{"action":"opened","number":7,"pull_request":{"number":7,"title":"Add feature"}}`),
+		mockEvent("2", "PushEvent", now.Add(-2*time.Hour), `{"ref":"refs/heads/main","size":3}`),
+	}
+	secondPage := []*github.Event{
+		mockEvent("1", "IssuesEvent", now.Add(-20*time.Hour), `{"action":"closed","issue":{"number":5,"title":"Bug"}}`),
+		mockEvent("0", "IssuesEvent", now.Add(-48*time.Hour), `{"action":"opened","issue":{"number":4,"title":"Old bug"}}`),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedIDs    []string
+		expectedFirst  MinimalEvent
+	}{
+		{
+			name: "single page filtered by type",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEventsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, firstPage),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"repo":  "repo",
+				"types": []any{"PushEvent"},
+			},
+			expectedIDs: []string{"2"},
+			expectedFirst: MinimalEvent{
+				ID:        "2",
+				Type:      "PushEvent",
+				Actor:     "octocat",
+				Repo:      "octo-org/repo",
+				Public:    true,
+				CreatedAt: "2025-06-02T10:00:00Z",
+				Ref:       "refs/heads/main",
+				Commits:   3,
+			},
+		},
+		{
+			name: "pages until events are older than since",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEventsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+						if r.URL.Query().Get("page") == "2" {
+							mockResponse(t, http.StatusOK, secondPage)(w, r)
+							return
+						}
+						w.Header().Set("Link", `<https://api.github.com/repos/octo-org/repo/events?page=2>; rel="next"`)
+						mockResponse(t, http.StatusOK, firstPage)(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"repo":  "repo",
+				"since": "2025-06-01T12:00:00Z",
+			},
+			expectedIDs: []string{"3", "2", "1"},
+			expectedFirst: MinimalEvent{
+				ID:        "3",
+				Type:      "PullRequestEvent",
+				Actor:     "octocat",
+				Repo:      "octo-org/repo",
+				Public:    true,
+				CreatedAt: "2025-06-02T11:00:00Z",
+				Action:    "opened",
+				Number:    7,
+				Title:     "Add feature",
+			},
+		},
+		{
+			name:         "invalid since",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"repo":  "repo",
+				"since": "not-a-date",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid ISO 8601 timestamp",
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEventsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list events of octo-org/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepoEvents(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []MinimalEvent
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+			require.NoError(t, err)
+			ids := make([]string, 0, len(returned))
+			for _, event := range returned {
+				ids = append(ids, event.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			assert.Equal(t, tc.expectedFirst, returned[0])
+		})
+	}
+}
+
+func Test_ListUserEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_user_events", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	mockClient = github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetUsersEventsByUsername,
+			[]*github.Event{
+				mockEvent("1", "ReleaseEvent", time.Now(), `{"action":"published","release":{"tag_name":"v1.0.0","name":"First release"}}`),
+			},
+		),
+	))
+	_, handler := ListUserEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"username": "octocat",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []MinimalEvent
+	err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+	require.NoError(t, err)
+	require.Len(t, returned, 1)
+	assert.Equal(t, "v1.0.0", returned[0].Ref)
+	assert.Equal(t, "First release", returned[0].Title)
+}
+
+func Test_ListOrgEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_events", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "include_private")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	events := []*github.Event{mockEvent("1", "PushEvent", time.Now(), `{"ref":"refs/heads/main","size":1}`)}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		requestArgs  map[string]interface{}
+	}{
+		{
+			name: "public events",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsEventsByOrg,
+					events,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+		},
+		{
+			name: "private events of the authenticated user's dashboard",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUser,
+					&github.User{Login: github.Ptr("octocat")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetUsersEventsOrgsByUsernameByOrg,
+					expectPath(t, "/users/octocat/events/orgs/octo-org").andThen(
+						mockResponse(t, http.StatusOK, events),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":             "octo-org",
+				"include_private": true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgEvents(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned []MinimalEvent
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+			require.NoError(t, err)
+			require.Len(t, returned, 1)
+			assert.Equal(t, "PushEvent", returned[0].Type)
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxFileCommits is the number of commits list_file_commits returns at most in a single call.
+const maxFileCommits = 100
+
+// MinimalFileCommit is a commit that changed a file, with the path the file had in it.
+type MinimalFileCommit struct {
+	MinimalCommit
+	Path string `json:"path"`
+	// PreviousPath is set on the commit that renamed the file to Path.
+	PreviousPath string `json:"previous_path,omitempty"`
+}
+
+// FileHistory is the output type of list_file_commits.
+type FileHistory struct {
+	Commits []MinimalFileCommit `json:"commits"`
+	// NextSHA and NextPath are the arguments to list the older commits with, when there are more.
+	NextSHA  string `json:"next_sha,omitempty"`
+	NextPath string `json:"next_path,omitempty"`
+}
+
+// FileAtCommit is the output type of get_file_at_commit.
+type FileAtCommit struct {
+	Path    string        `json:"path"`
+	SHA     string        `json:"sha"`
+	Size    int           `json:"size"`
+	Binary  bool          `json:"binary,omitempty"`
+	Content string        `json:"content,omitempty"`
+	Commit  MinimalCommit `json:"commit"`
+}
+
+// renamedFrom returns the path a file had before the commit renamed it, if it did. Only the first page of the
+// files of the commit is looked at, so renames in very large commits aren't found.
+func renamedFrom(ctx context.Context, client *github.Client, owner, repo, sha, path string) (string, *github.Response, error) {
+	commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, nil)
+	if err != nil {
+		return "", resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	for _, file := range commit.Files {
+		if file.GetStatus() == "renamed" && file.GetFilename() == path {
+			return file.GetPreviousFilename(), resp, nil
+		}
+	}
+	return "", resp, nil
+}
+
+// ListFileCommits creates a tool to list the commits that changed a file, following it across renames.
+func ListFileCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_file_commits",
+			mcp.WithDescription(t("TOOL_LIST_FILE_COMMITS_DESCRIPTION", "List the commits that changed a file, newest first, following the file across renames like git log --follow. Each commit has the path the file had in it. When there are more commits, call again with next_sha and next_path to list them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_FILE_COMMITS_USER_TITLE", "List file commits"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[FileHistory](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path of the file"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Commit SHA, branch or tag name to start listing from. If not provided, uses the default branch of the repository."),
+			),
+			mcp.WithBoolean("follow_renames",
+				mcp.Description("Whether to keep listing the commits of the file under its previous paths when it was renamed. Default is true."),
+				mcp.DefaultBool(true),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description(fmt.Sprintf("Number of commits to return, at most %d. Default is 30.", maxFileCommits)),
+				mcp.Min(1),
+				mcp.Max(maxFileCommits),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			followRenames, err := OptionalBoolParamWithDefault(request, "follow_renames", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			limit, err := OptionalIntParamWithDefault(request, "limit", 30)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if limit < 1 || limit > maxFileCommits {
+				return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxFileCommits)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			history := FileHistory{Commits: []MinimalFileCommit{}}
+			for {
+				requested := limit - len(history.Commits)
+				commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{
+					SHA:         sha,
+					Path:        path,
+					ListOptions: github.ListOptions{PerPage: requested},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to list commits of %s", path),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, commit := range commits {
+					history.Commits = append(history.Commits, MinimalFileCommit{
+						MinimalCommit: convertToMinimalCommit(commit, false),
+						Path:          path,
+					})
+				}
+				if len(commits) == 0 {
+					break
+				}
+
+				// The oldest commit listed under a path is the one that renamed the file to it, if any
+				oldest := commits[len(commits)-1]
+				nextPath := path
+				if followRenames {
+					previousPath, resp, err := renamedFrom(ctx, client, owner, repo, oldest.GetSHA(), path)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to get commit: %s", oldest.GetSHA()),
+							resp,
+							err,
+						), nil
+					}
+					if previousPath != "" {
+						history.Commits[len(history.Commits)-1].PreviousPath = previousPath
+						nextPath = previousPath
+					}
+				}
+
+				if len(oldest.Parents) == 0 || (len(commits) < requested && nextPath == path) {
+					break
+				}
+				sha = oldest.Parents[0].GetSHA()
+				path = nextPath
+				if len(history.Commits) == limit {
+					history.NextSHA = sha
+					history.NextPath = path
+					break
+				}
+			}
+
+			return MarshalledTextResult(history), nil
+		}
+}
+
+// GetFileAtCommit creates a tool to get the contents of a file as of a commit.
+func GetFileAtCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_at_commit",
+			mcp.WithDescription(t("TOOL_GET_FILE_AT_COMMIT_DESCRIPTION", "Get the contents of a file as of a commit, along with the commit. Use the paths returned by list_file_commits for files that were renamed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_AT_COMMIT_USER_TITLE", "Get file at commit"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[FileAtCommit](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path the file had in the commit"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, &github.ListOptions{PerPage: 1})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get commit: %s", sha),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			// Read the file at the commit SHA, so that it matches the commit when sha is a branch that moves
+			fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: commit.GetSHA()})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get %s at %s", path, commit.GetSHA()),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if fileContent == nil || dirContent != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is a directory at %s", path, commit.GetSHA())), nil
+			}
+			if fileContent.GetType() != "file" {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is a %s at %s, not a file", path, fileContent.GetType(), commit.GetSHA())), nil
+			}
+			// Files over 1MB have no content in the contents API
+			if fileContent.GetEncoding() == "none" {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is too large to be returned whole, use get_file_contents with sha %s and offset and length to read it in parts", path, commit.GetSHA())), nil
+			}
+			content, err := fileContent.GetContent()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode file content: %w", err)
+			}
+
+			result := FileAtCommit{
+				Path:   path,
+				SHA:    fileContent.GetSHA(),
+				Size:   fileContent.GetSize(),
+				Commit: convertToMinimalCommit(commit, false),
+			}
+			if isTextContent([]byte(content)) {
+				result.Content = content
+			} else {
+				result.Binary = true
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,353 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockFileCommit(sha string, message string, parents ...string) *github.RepositoryCommit {
+	commit := &github.RepositoryCommit{
+		SHA:     github.Ptr(sha),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/" + sha),
+		Commit:  &github.Commit{Message: github.Ptr(message)},
+	}
+	for _, parent := range parents {
+		commit.Parents = append(commit.Parents, &github.Commit{SHA: github.Ptr(parent)})
+	}
+	return commit
+}
+
+func Test_ListFileCommits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListFileCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_file_commits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "follow_renames")
+	assert.Contains(t, tool.InputSchema.Properties, "limit")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	// new.go was created as old.go in c1 and renamed in c2
+	listCommits := func(t *testing.T) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			switch {
+			case query.Get("path") == "new.go" && query.Get("sha") == "":
+				mockResponse(t, http.StatusOK, []*github.RepositoryCommit{
+					mockFileCommit("c3", "Tweak new.go", "c2"),
+					mockFileCommit("c2", "Rename old.go", "c1"),
+				})(w, r)
+			case query.Get("path") == "old.go" && query.Get("sha") == "c1":
+				mockResponse(t, http.StatusOK, []*github.RepositoryCommit{
+					mockFileCommit("c1", "Add old.go", "c0"),
+				})(w, r)
+			default:
+				t.Errorf("unexpected commits query: %s", r.URL.RawQuery)
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}
+	}
+	getCommit := func(t *testing.T) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/repos/owner/repo/commits/c2":
+				mockResponse(t, http.StatusOK, &github.RepositoryCommit{
+					SHA: github.Ptr("c2"),
+					Files: []*github.CommitFile{
+						{Filename: github.Ptr("new.go"), Status: github.Ptr("renamed"), PreviousFilename: github.Ptr("old.go")},
+					},
+				})(w, r)
+			case "/repos/owner/repo/commits/c1":
+				mockResponse(t, http.StatusOK, &github.RepositoryCommit{
+					SHA: github.Ptr("c1"),
+					Files: []*github.CommitFile{
+						{Filename: github.Ptr("old.go"), Status: github.Ptr("added")},
+					},
+				})(w, r)
+			default:
+				t.Errorf("unexpected commit: %s", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedSHAs    []string
+		expectedPaths   []string
+		expectedHistory FileHistory
+	}{
+		{
+			name: "follows renames",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, listCommits(t)),
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepoByRef, getCommit(t)),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "new.go",
+			},
+			expectedSHAs:  []string{"c3", "c2", "c1"},
+			expectedPaths: []string{"new.go", "new.go", "old.go"},
+		},
+		{
+			name: "without following renames",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, listCommits(t)),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"path":           "new.go",
+				"follow_renames": false,
+				"limit":          float64(2),
+			},
+			expectedSHAs:  []string{"c3", "c2"},
+			expectedPaths: []string{"new.go", "new.go"},
+			expectedHistory: FileHistory{
+				NextSHA:  "c1",
+				NextPath: "new.go",
+			},
+		},
+		{
+			name: "limit reached on a rename",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepo, listCommits(t)),
+				mock.WithRequestMatchHandler(mock.GetReposCommitsByOwnerByRepoByRef, getCommit(t)),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "new.go",
+				"limit": float64(2),
+			},
+			expectedSHAs:  []string{"c3", "c2"},
+			expectedPaths: []string{"new.go", "new.go"},
+			expectedHistory: FileHistory{
+				NextSHA:  "c1",
+				NextPath: "old.go",
+			},
+		},
+		{
+			name:         "limit out of range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "new.go",
+				"limit": float64(500),
+			},
+			expectError:    true,
+			expectedErrMsg: "limit must be between 1 and 100",
+		},
+		{
+			name: "commits listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "new.go",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list commits of new.go",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListFileCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var history FileHistory
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &history))
+
+			var shas, paths []string
+			for _, commit := range history.Commits {
+				shas = append(shas, commit.SHA)
+				paths = append(paths, commit.Path)
+			}
+			assert.Equal(t, tc.expectedSHAs, shas)
+			assert.Equal(t, tc.expectedPaths, paths)
+			assert.Equal(t, tc.expectedHistory.NextSHA, history.NextSHA)
+			assert.Equal(t, tc.expectedHistory.NextPath, history.NextPath)
+			if tc.requestArgs["follow_renames"] == nil {
+				assert.Equal(t, "old.go", history.Commits[1].PreviousPath)
+			}
+		})
+	}
+}
+
+func Test_GetFileAtCommit(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetFileAtCommit(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_file_at_commit", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "sha"})
+
+	mockCommit := mockFileCommit("abc123", "Update README")
+	fileContents := func(content *github.RepositoryContent) http.HandlerFunc {
+		return expectQueryParams(t, map[string]string{
+			"ref": "abc123",
+		}).andThen(
+			mockResponse(t, http.StatusOK, content),
+		)
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectedErrMsg  string
+		expectedContent string
+		expectedBinary  bool
+	}{
+		{
+			name: "text file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepoByRef, mockCommit),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, fileContents(&github.RepositoryContent{
+					Type:     github.Ptr("file"),
+					Path:     github.Ptr("README.md"),
+					SHA:      github.Ptr("blob1"),
+					Size:     github.Ptr(8),
+					Encoding: github.Ptr("base64"),
+					Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("# Hello\n"))),
+				})),
+			),
+			expectedContent: "# Hello\n",
+		},
+		{
+			name: "binary file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepoByRef, mockCommit),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, fileContents(&github.RepositoryContent{
+					Type:     github.Ptr("file"),
+					Path:     github.Ptr("README.md"),
+					SHA:      github.Ptr("blob1"),
+					Size:     github.Ptr(4),
+					Encoding: github.Ptr("base64"),
+					Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte{0x89, 0x00, 0x01, 0x02})),
+				})),
+			),
+			expectedBinary: true,
+		},
+		{
+			name: "large file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepoByRef, mockCommit),
+				mock.WithRequestMatchHandler(mock.GetReposContentsByOwnerByRepoByPath, fileContents(&github.RepositoryContent{
+					Type:     github.Ptr("file"),
+					Path:     github.Ptr("README.md"),
+					SHA:      github.Ptr("blob1"),
+					Size:     github.Ptr(5 * 1024 * 1024),
+					Encoding: github.Ptr("none"),
+				})),
+			),
+			expectError:    true,
+			expectedErrMsg: "use get_file_contents with sha abc123",
+		},
+		{
+			name: "directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepoByRef, mockCommit),
+				mock.WithRequestMatch(mock.GetReposContentsByOwnerByRepoByPath, []*github.RepositoryContent{
+					{Type: github.Ptr("file"), Path: github.Ptr("README.md/index.md")},
+				}),
+			),
+			expectError:    true,
+			expectedErrMsg: "README.md is a directory at abc123",
+		},
+		{
+			name: "file missing at commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepoByRef, mockCommit),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get README.md at abc123",
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: main"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get commit: main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetFileAtCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "README.md",
+				"sha":   "main",
+			})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var file FileAtCommit
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &file))
+			assert.Equal(t, "README.md", file.Path)
+			assert.Equal(t, "blob1", file.SHA)
+			assert.Equal(t, "abc123", file.Commit.SHA)
+			assert.Equal(t, "Update README", file.Commit.Commit.Message)
+			assert.Equal(t, tc.expectedContent, file.Content)
+			assert.Equal(t, tc.expectedBinary, file.Binary)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// flattenParam is the parameter of tools whose results can be flattened.
+const flattenParam = "flatten"
+
+// WithFlatten adds the flatten parameter to a tool whose results are commonly consumed as tables.
+func WithFlatten() mcp.ToolOption {
+	return mcp.WithBoolean(flattenParam,
+		mcp.Description("Flatten nested objects of the results into dotted keys, such as user.login or head.ref, for tabular use. Lists are kept, with their objects flattened."),
+	)
+}
+
+// FlattenOutputMiddleware flattens the nested objects of the JSON results of tool calls with flatten set, so that
+// the keys of the results are the same whatever the nesting of the GitHub objects. Structured content is left as it
+// is, as it has to match the output schema of the tool.
+func FlattenOutputMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		if flatten, _ := request.GetArguments()[flattenParam].(bool); !flatten {
+			return result, nil
+		}
+
+		for i, c := range result.Content {
+			text, ok := c.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+			text.Text = flattenJSON(text.Text)
+			result.Content[i] = text
+		}
+		return result, nil
+	}
+}
+
+// flattenJSON flattens a JSON document, leaving text that isn't JSON unchanged.
+func flattenJSON(text string) string {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return text
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(flattenValue(v)); err != nil {
+		return text
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// flattenValue flattens the objects of a decoded JSON value, including the objects of lists.
+func flattenValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		flat := make(map[string]any, len(v))
+		flattenObject(flat, "", v)
+		return flat
+	case []any:
+		for i, item := range v {
+			v[i] = flattenValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// flattenObject adds the fields of an object to a flat object, with their keys prefixed by the path of the object.
+// Empty objects are kept as null, so that the key is still there.
+func flattenObject(flat map[string]any, prefix string, object map[string]any) {
+	for k, field := range object {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := field.(map[string]any); ok {
+			if len(nested) == 0 {
+				flat[key] = nil
+				continue
+			}
+			flattenObject(flat, key, nested)
+			continue
+		}
+		flat[key] = flattenValue(field)
+	}
+}
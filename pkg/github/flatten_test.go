@@ -0,0 +1,83 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FlattenOutputMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]any
+		result   *mcp.CallToolResult
+		expected string
+	}{
+		{
+			name:     "flattens nested objects",
+			args:     map[string]any{"flatten": true},
+			result:   mcp.NewToolResultText(`{"number":42,"user":{"login":"octocat","id":1},"head":{"ref":"feature","repo":{"full_name":"o/r"}},"milestone":{}}`),
+			expected: `{"head.ref":"feature","head.repo.full_name":"o/r","milestone":null,"number":42,"user.id":1,"user.login":"octocat"}`,
+		},
+		{
+			name:     "flattens the objects of lists",
+			args:     map[string]any{"flatten": true},
+			result:   mcp.NewToolResultText(`[{"sha":"abc","commit":{"author":{"date":"2024-01-01T00:00:00Z"}},"parents":[{"sha":"def"}],"labels":["bug"]}]`),
+			expected: `[{"commit.author.date":"2024-01-01T00:00:00Z","labels":["bug"],"parents":[{"sha":"def"}],"sha":"abc"}]`,
+		},
+		{
+			name:     "keeps the lists of objects",
+			args:     map[string]any{"flatten": true},
+			result:   mcp.NewToolResultText(`{"total_count":1,"items":[{"owner":{"login":"octocat"}}]}`),
+			expected: `{"items":[{"owner.login":"octocat"}],"total_count":1}`,
+		},
+		{
+			name:     "leaves results unchanged without flatten",
+			args:     map[string]any{"flatten": false},
+			result:   mcp.NewToolResultText(`{"user":{"login":"octocat"}}`),
+			expected: `{"user":{"login":"octocat"}}`,
+		},
+		{
+			name:     "leaves plain text unchanged",
+			args:     map[string]any{"flatten": true},
+			result:   mcp.NewToolResultText("successfully starred repository"),
+			expected: "successfully starred repository",
+		},
+		{
+			name:     "leaves errors unchanged",
+			args:     map[string]any{"flatten": true},
+			result:   mcp.NewToolResultError(`{"error":{"message":"Not Found"}}`),
+			expected: `{"error":{"message":"Not Found"}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := FlattenOutputMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tc.result, nil
+			})
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, textContent.Text)
+		})
+	}
+}
+
+func Test_FlattenOutputMiddlewareKeepsStructuredContent(t *testing.T) {
+	structured := map[string]any{"user": map[string]any{"login": "octocat"}}
+	handler := FlattenOutputMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultStructured(structured, `{"user":{"login":"octocat"}}`), nil
+	})
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"flatten": true}))
+	require.NoError(t, err)
+	assert.Equal(t, structured, result.StructuredContent)
+	assert.Equal(t, `{"user.login":"octocat"}`, result.Content[0].(mcp.TextContent).Text)
+}
@@ -21,11 +21,12 @@ func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				Title:        t("TOOL_LIST_GISTS", "List Gists"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.Gist](),
 			mcp.WithString("username",
 				mcp.Description("GitHub username (omit for authenticated user's gists)"),
 			),
 			mcp.WithString("since",
-				mcp.Description("Only gists updated after this time (ISO 8601 timestamp)"),
+				mcp.Description("Only gists updated after this time (ISO 8601 timestamp, or a relative time such as -7d or 2 hours ago)"),
 			),
 			WithPagination(),
 		),
@@ -97,6 +98,7 @@ func CreateGist(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				Title:        t("TOOL_CREATE_GIST", "Create Gist"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[MinimalResponse](),
 			mcp.WithString("description",
 				mcp.Description("Description of the gist"),
 			),
@@ -187,6 +189,7 @@ func UpdateGist(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				Title:        t("TOOL_UPDATE_GIST", "Update Gist"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[MinimalResponse](),
 			mcp.WithString("gist_id",
 				mcp.Required(),
 				mcp.Description("ID of the gist to update"),
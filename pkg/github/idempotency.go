@@ -0,0 +1,136 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// idempotencyWindow is how far back issues and comments are looked up for a previous use of an idempotency key.
+const idempotencyWindow = 24 * time.Hour
+
+var idempotencyKeyPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// WithIdempotencyKey adds the idempotency_key parameter to tools creating issues or comments.
+func WithIdempotencyKey() mcp.ToolOption {
+	return mcp.WithString("idempotency_key",
+		mcp.Description("Unique key for this operation, such as a UUID. If an issue or comment created with the same key in the last 24 hours exists, it is returned instead of creating a duplicate, so the call can be safely retried."),
+	)
+}
+
+// OptionalIdempotencyKeyParam returns the idempotency_key parameter, which is empty when not provided.
+func OptionalIdempotencyKeyParam(r mcp.CallToolRequest) (string, error) {
+	key, err := OptionalParam[string](r, "idempotency_key")
+	if err != nil {
+		return "", err
+	}
+	if key != "" && !idempotencyKeyPattern.MatchString(key) {
+		return "", fmt.Errorf("idempotency_key must be at most 128 letters, digits, '.', '_', ':' or '-'")
+	}
+	return key, nil
+}
+
+// idempotencyMarker is the hidden comment recording the idempotency key in the body of what is created with it.
+func idempotencyMarker(key string) string {
+	return fmt.Sprintf("<!-- github-mcp-server idempotency-key: %s -->", key)
+}
+
+// withIdempotencyMarker appends the marker of key to body.
+func withIdempotencyMarker(body, key string) string {
+	if body == "" {
+		return idempotencyMarker(key)
+	}
+	return body + "\n\n" + idempotencyMarker(key)
+}
+
+// findIssueCommentByIdempotencyKey returns the recent comment of an issue created with key, if any.
+func findIssueCommentByIdempotencyKey(ctx context.Context, client *github.Client, owner, repo string, number int, key string) (*github.IssueComment, *github.Response, error) {
+	since := time.Now().Add(-idempotencyWindow)
+	opts := &github.IssueListCommentsOptions{
+		Since:       &since,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	marker := idempotencyMarker(key)
+	for {
+		comments, resp, err := client.Issues.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), marker) {
+				return comment, resp, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// findIssueByIdempotencyKey returns the recent issue of a repository created with key, if any.
+// Only the most recently created issues are looked at, as an earlier attempt was made moments before.
+func findIssueByIdempotencyKey(ctx context.Context, client *github.Client, owner, repo, key string) (*github.Issue, *github.Response, error) {
+	issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+		State:       "all",
+		Sort:        "created",
+		Direction:   "desc",
+		Since:       time.Now().Add(-idempotencyWindow),
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	marker := idempotencyMarker(key)
+	for _, issue := range issues {
+		if !issue.IsPullRequest() && strings.Contains(issue.GetBody(), marker) {
+			return issue, resp, nil
+		}
+	}
+	return nil, resp, nil
+}
+
+// findExistingBranch returns the branch when it already exists and points to sha, as happens when an earlier
+// attempt to create it reached GitHub but its response was lost.
+func findExistingBranch(ctx context.Context, client *github.Client, owner, repo, branch, sha string) *github.Reference {
+	ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return nil
+	}
+	_ = resp.Body.Close()
+	if ref.GetObject().GetSHA() != sha {
+		return nil
+	}
+	return ref
+}
+
+// findExistingPullRequest returns the open pull request from head to base with the given title, as created by an
+// earlier attempt whose response was lost.
+func findExistingPullRequest(ctx context.Context, client *github.Client, owner, repo, head, base, title string) *github.PullRequest {
+	if !strings.Contains(head, ":") {
+		head = owner + ":" + head
+	}
+	prs, resp, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  head,
+		Base:  base,
+	})
+	if err != nil {
+		return nil
+	}
+	_ = resp.Body.Close()
+	for _, pr := range prs {
+		if pr.GetTitle() == title {
+			return pr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddIssueComment_IdempotencyKey(t *testing.T) {
+	existing := &github.IssueComment{
+		ID:   github.Ptr(int64(123)),
+		Body: github.Ptr("Done\n\n" + idempotencyMarker("turn-1")),
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		key          string
+		expectedID   int64
+		expectedErr  string
+	}{
+		{
+			name: "returns the comment created with the same key",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					[]*github.IssueComment{{ID: github.Ptr(int64(1)), Body: github.Ptr("Unrelated")}, existing},
+				),
+			),
+			key:        "turn-1",
+			expectedID: 123,
+		},
+		{
+			name: "creates the comment with the key recorded",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					[]*github.IssueComment{existing},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"body": "Done\n\n" + idempotencyMarker("turn-2"),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(456))}),
+					),
+				),
+			),
+			key:        "turn-2",
+			expectedID: 456,
+		},
+		{
+			name:        "rejects invalid keys",
+			key:         "not a key",
+			expectedErr: "idempotency_key must be at most 128 letters",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddIssueComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"issue_number":    float64(42),
+				"body":            "Done",
+				"idempotency_key": tc.key,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectedErr != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErr)
+				return
+			}
+
+			var returned github.IssueComment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedID, returned.GetID())
+		})
+	}
+}
+
+func Test_CreateIssue_IdempotencyKey(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposIssuesByOwnerByRepo,
+			[]*github.Issue{
+				{
+					ID:               github.Ptr(int64(1)),
+					Body:             github.Ptr(idempotencyMarker("turn-1")),
+					PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/1")},
+				},
+				{
+					ID:      github.Ptr(int64(2)),
+					Body:    github.Ptr("Steps\n\n" + idempotencyMarker("turn-1")),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/issues/2"),
+				},
+			},
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := CreateIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":           "owner",
+		"repo":            "repo",
+		"title":           "Bug",
+		"body":            "Steps",
+		"idempotency_key": "turn-1",
+	}))
+	require.NoError(t, err)
+
+	var returned MinimalResponse
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "2", returned.ID)
+	assert.Equal(t, "https://github.com/owner/repo/issues/2", returned.URL)
+}
+
+func Test_CreateBranch_AlreadyCreated(t *testing.T) {
+	ref := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("abc123")},
+	}
+	created := &github.Reference{
+		Ref:    github.Ptr("refs/heads/feature"),
+		Object: &github.GitObject{SHA: github.Ptr("abc123")},
+	}
+	other := &github.Reference{
+		Ref:    github.Ptr("refs/heads/feature"),
+		Object: &github.GitObject{SHA: github.Ptr("def456")},
+	}
+
+	tests := []struct {
+		name        string
+		existing    *github.Reference
+		expectError bool
+	}{
+		{
+			name:     "returns the branch pointing to the same commit",
+			existing: created,
+		},
+		{
+			name:        "fails when the branch points to another commit",
+			existing:    other,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, ref, tc.existing),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Reference already exists"}`),
+				),
+			)
+
+			client := github.NewClient(mockedClient)
+			_, handler := CreateBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"branch":      "feature",
+				"from_branch": "main",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, "failed to create branch")
+				return
+			}
+
+			var returned github.Reference
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, "refs/heads/feature", returned.GetRef())
+		})
+	}
+}
+
+func Test_CreatePullRequest_AlreadyCreated(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposPullsByOwnerByRepo,
+			mockResponse(t, http.StatusUnprocessableEntity, `{"message": "A pull request already exists for owner:feature."}`),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposPullsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"state": "open",
+				"head":  "owner:feature",
+				"base":  "main",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.PullRequest{
+					{ID: github.Ptr(int64(7)), Title: github.Ptr("Other")},
+					{ID: github.Ptr(int64(8)), Title: github.Ptr("Add feature"), HTMLURL: github.Ptr("https://github.com/owner/repo/pull/8")},
+				}),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := CreatePullRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"title": "Add feature",
+		"head":  "feature",
+		"base":  "main",
+	}))
+	require.NoError(t, err)
+
+	var returned MinimalResponse
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "8", returned.ID)
+	assert.Equal(t, "https://github.com/owner/repo/pull/8", returned.URL)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -233,6 +234,7 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				Title:        t("TOOL_GET_ISSUE_USER_TITLE", "Get issue details"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.Issue](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("The owner of the repository"),
@@ -296,6 +298,7 @@ func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_LIST_ISSUE_TYPES_USER_TITLE", "List available issue types"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.IssueType](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("The organization owner of the repository"),
@@ -342,6 +345,7 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 				Title:        t("TOOL_ADD_ISSUE_COMMENT_USER_TITLE", "Add comment to issue"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[*github.IssueComment](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -358,6 +362,7 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 				mcp.Required(),
 				mcp.Description("Comment content"),
 			),
+			WithIdempotencyKey(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -376,15 +381,34 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			comment := &github.IssueComment{
-				Body: github.Ptr(body),
+			idempotencyKey, err := OptionalIdempotencyKeyParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+
+			if idempotencyKey != "" {
+				existing, resp, err := findIssueCommentByIdempotencyKey(ctx, client, owner, repo, issueNumber, idempotencyKey)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to look up comments for the idempotency key",
+						resp,
+						err,
+					), nil
+				}
+				if existing != nil {
+					return MarshalledTextResult(existing), nil
+				}
+				body = withIdempotencyMarker(body, idempotencyKey)
+			}
+
+			comment := &github.IssueComment{
+				Body: github.Ptr(body),
+			}
 			createdComment, resp, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, comment)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create comment: %w", err)
@@ -416,6 +440,7 @@ func AddSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				Title:        t("TOOL_ADD_SUB_ISSUE_USER_TITLE", "Add sub-issue"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[*github.SubIssue](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -504,6 +529,7 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_LIST_SUB_ISSUES_USER_TITLE", "List sub-issues"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.SubIssue](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -598,6 +624,7 @@ func RemoveSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_REMOVE_SUB_ISSUE_USER_TITLE", "Remove sub-issue"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[*github.SubIssue](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -677,6 +704,7 @@ func ReprioritizeSubIssue(getClient GetClientFn, t translations.TranslationHelpe
 				Title:        t("TOOL_REPRIORITIZE_SUB_ISSUE_USER_TITLE", "Reprioritize sub-issue"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[*github.SubIssue](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -790,9 +818,10 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				Title:        t("TOOL_SEARCH_ISSUES_USER_TITLE", "Search issues"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.IssuesSearchResult](),
 			mcp.WithString("query",
 				mcp.Required(),
-				mcp.Description("Search query using GitHub issues search syntax"),
+				mcp.Description("Search query using GitHub issues search syntax. Date qualifiers such as created:>-7d or updated:>\"2 hours ago\" accept relative times."),
 			),
 			mcp.WithString("owner",
 				mcp.Description("Optional repository owner. If provided with repo, only issues for this repository are listed."),
@@ -821,6 +850,8 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithSearchAggregation(),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return searchHandler(ctx, getClient, request, "issue", "failed to search issues")
@@ -835,6 +866,7 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				Title:        t("TOOL_CREATE_ISSUE_USER_TITLE", "Open new issue"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[MinimalResponse](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -872,6 +904,7 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithString("type",
 				mcp.Description("Type of this issue"),
 			),
+			WithIdempotencyKey(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -922,6 +955,34 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			idempotencyKey, err := OptionalIdempotencyKeyParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if idempotencyKey != "" {
+				existing, resp, err := findIssueByIdempotencyKey(ctx, client, owner, repo, idempotencyKey)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to look up issues for the idempotency key",
+						resp,
+						err,
+					), nil
+				}
+				if existing != nil {
+					return MarshalledTextResult(MinimalResponse{
+						ID:  fmt.Sprintf("%d", existing.GetID()),
+						URL: existing.GetHTMLURL(),
+					}), nil
+				}
+				body = withIdempotencyMarker(body, idempotencyKey)
+			}
+
 			// Create the issue request
 			issueRequest := &github.IssueRequest{
 				Title:     github.Ptr(title),
@@ -935,10 +996,6 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				issueRequest.Type = github.Ptr(issueType)
 			}
 
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
 			issue, resp, err := client.Issues.Create(ctx, owner, repo, issueRequest)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create issue: %w", err)
@@ -976,6 +1033,7 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_ISSUES_USER_TITLE", "List issues"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1005,9 +1063,10 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				mcp.Enum("ASC", "DESC"),
 			),
 			mcp.WithString("since",
-				mcp.Description("Filter by date (ISO 8601 timestamp)"),
+				mcp.Description("Filter by date (ISO 8601 timestamp, or a relative time such as -7d or 2 hours ago)"),
 			),
 			WithCursorPagination(),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1188,6 +1247,7 @@ func UpdateIssue(getClient GetClientFn, getGQLClient GetGQLClientFn, t translati
 				Title:        t("TOOL_UPDATE_ISSUE_USER_TITLE", "Edit issue"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[MinimalResponse](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1445,6 +1505,7 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_GET_ISSUE_COMMENTS_USER_TITLE", "Get issue comments"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.IssueComment](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1511,6 +1572,271 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// ListIssueComments creates a tool to list the comments of an issue, or of all the issues of a repository.
+func ListIssueComments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_issue_comments",
+			mcp.WithDescription(t("TOOL_LIST_ISSUE_COMMENTS_DESCRIPTION", "List the comments of an issue or pull request, or of all the issues and pull requests of a repository when issue_number is omitted. Use since to only get the comments updated after your last run, and direction to get the newest comments first.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ISSUE_COMMENTS_USER_TITLE", "List issue comments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]*github.IssueComment](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Description("Issue number, omit to list the comments of all issues of the repository"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only list comments updated at or after this time (ISO 8601 timestamp or date, or a relative time such as -7d or 2 hours ago)"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort comments by creation or update time, only when listing the comments of all issues"),
+				mcp.Enum("created", "updated"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Order of the comments, oldest first by default"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := OptionalIntParam(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if sort != "" && issueNumber != 0 {
+				return mcp.NewToolResultError("sort is only supported when listing the comments of all issues, omit issue_number"), nil
+			}
+
+			opts := &github.IssueListCommentsOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if since != "" {
+				sinceTime, err := parseISOTimestamp(since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list issue comments: %s", err.Error())), nil
+				}
+				opts.Since = &sinceTime
+			}
+			// The comments of a single issue can't be ordered by the API, so they are read from the last page
+			reverse := issueNumber != 0 && direction == "desc"
+			if issueNumber == 0 {
+				if sort != "" {
+					opts.Sort = &sort
+				}
+				if direction != "" {
+					opts.Direction = &direction
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if reverse {
+				// The first page tells how many there are, so that the requested page can be counted from the last one
+				page := max(opts.Page, 1)
+				opts.Page = 1
+				comments, resp, err := client.Issues.ListComments(ctx, owner, repo, issueNumber, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issue comments", resp, err), nil
+				}
+				_ = resp.Body.Close()
+
+				lastPage := max(resp.LastPage, 1)
+				switch {
+				case page > lastPage:
+					return MarshalledTextResult([]*github.IssueComment{}), nil
+				case page == lastPage:
+					if comments == nil {
+						comments = []*github.IssueComment{}
+					}
+					slices.Reverse(comments)
+					return MarshalledTextResult(comments), nil
+				}
+				opts.Page = lastPage - page + 1
+			}
+
+			comments, resp, err := client.Issues.ListComments(ctx, owner, repo, issueNumber, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issue comments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if reverse {
+				slices.Reverse(comments)
+			}
+			if comments == nil {
+				comments = []*github.IssueComment{}
+			}
+			return MarshalledTextResult(comments), nil
+		}
+}
+
+// commentClassifiers maps the minimize_comment classifiers to the reasons GitHub records for minimized comments.
+var commentClassifiers = map[string]githubv4.ReportedContentClassifiers{
+	"spam":      githubv4.ReportedContentClassifiersSpam,
+	"abuse":     githubv4.ReportedContentClassifiersAbuse,
+	"off_topic": githubv4.ReportedContentClassifiersOffTopic,
+	"outdated":  githubv4.ReportedContentClassifiersOutdated,
+	"duplicate": githubv4.ReportedContentClassifiersDuplicate,
+	"resolved":  githubv4.ReportedContentClassifiersResolved,
+}
+
+// MinimizedComment is the state of a comment after minimizing or unminimizing it.
+type MinimizedComment struct {
+	ID              string `json:"id"`
+	IsMinimized     bool   `json:"is_minimized"`
+	MinimizedReason string `json:"minimized_reason,omitempty"`
+}
+
+// MinimizeComment creates a tool to hide a comment without deleting it.
+func MinimizeComment(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	classifiers := make([]string, 0, len(commentClassifiers))
+	for classifier := range commentClassifiers {
+		classifiers = append(classifiers, classifier)
+	}
+	slices.Sort(classifiers)
+
+	return mcp.NewTool("minimize_comment",
+			mcp.WithDescription(t("TOOL_MINIMIZE_COMMENT_DESCRIPTION", "Hide a comment on an issue, pull request, discussion or commit without deleting it, recording why it was hidden. Hidden comments can still be expanded by readers and shown again with unminimize_comment.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MINIMIZE_COMMENT_USER_TITLE", "Hide comment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimizedComment](),
+			mcp.WithString("comment_id",
+				mcp.Required(),
+				mcp.Description("The node ID of the comment (its node_id field, e.g. IC_kwDOA...)"),
+			),
+			mcp.WithString("classifier",
+				mcp.Required(),
+				mcp.Description("Why the comment is hidden"),
+				mcp.Enum(classifiers...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			commentID, err := RequiredParam[string](request, "comment_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			classifier, err := RequiredParam[string](request, "classifier")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reason, ok := commentClassifiers[strings.ToLower(classifier)]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid classifier %q, must be one of: %s", classifier, strings.Join(classifiers, ", "))), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var mutation struct {
+				MinimizeComment struct {
+					MinimizedComment struct {
+						IsMinimized     githubv4.Boolean
+						MinimizedReason githubv4.String
+					}
+				} `graphql:"minimizeComment(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.MinimizeCommentInput{
+				SubjectID:  githubv4.ID(commentID),
+				Classifier: reason,
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to minimize comment", err), nil
+			}
+
+			comment := mutation.MinimizeComment.MinimizedComment
+			return MarshalledTextResult(MinimizedComment{
+				ID:              commentID,
+				IsMinimized:     bool(comment.IsMinimized),
+				MinimizedReason: string(comment.MinimizedReason),
+			}), nil
+		}
+}
+
+// UnminimizeComment creates a tool to show a hidden comment again.
+func UnminimizeComment(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unminimize_comment",
+			mcp.WithDescription(t("TOOL_UNMINIMIZE_COMMENT_DESCRIPTION", "Show a comment hidden with minimize_comment again")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNMINIMIZE_COMMENT_USER_TITLE", "Unhide comment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimizedComment](),
+			mcp.WithString("comment_id",
+				mcp.Required(),
+				mcp.Description("The node ID of the comment (its node_id field, e.g. IC_kwDOA...)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			commentID, err := RequiredParam[string](request, "comment_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var mutation struct {
+				UnminimizeComment struct {
+					UnminimizedComment struct {
+						IsMinimized githubv4.Boolean
+					}
+				} `graphql:"unminimizeComment(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.UnminimizeCommentInput{
+				SubjectID: githubv4.ID(commentID),
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unminimize comment", err), nil
+			}
+
+			return MarshalledTextResult(MinimizedComment{
+				ID:          commentID,
+				IsMinimized: bool(mutation.UnminimizeComment.UnminimizedComment.IsMinimized),
+			}), nil
+		}
+}
+
 // mvpDescription is an MVP idea for generating tool descriptions from structured data in a shared format.
 // It is not intended for widespread usage and is not a complete implementation.
 type mvpDescription struct {
@@ -1707,7 +2033,8 @@ type ReplaceActorsForAssignableInput struct {
 
 // parseISOTimestamp parses an ISO 8601 timestamp string into a time.Time object.
 // Returns the parsed time or an error if parsing fails.
-// Example formats supported: "2023-01-15T14:30:00Z", "2023-01-15"
+// Example formats supported: "2023-01-15T14:30:00Z", "2023-01-15", and times relative to now such as
+// "-7d", "2 hours ago" or "last monday"
 func parseISOTimestamp(timestamp string) (time.Time, error) {
 	if timestamp == "" {
 		return time.Time{}, fmt.Errorf("empty timestamp")
@@ -1725,8 +2052,13 @@ func parseISOTimestamp(timestamp string) (time.Time, error) {
 		return t, nil
 	}
 
+	// Try relative times, which LLMs often use
+	if t, ok := parseRelativeTime(timestamp, time.Now()); ok {
+		return t, nil
+	}
+
 	// Return error with supported formats
-	return time.Time{}, fmt.Errorf("invalid ISO 8601 timestamp: %s (supported formats: YYYY-MM-DDThh:mm:ssZ or YYYY-MM-DD)", timestamp)
+	return time.Time{}, fmt.Errorf("invalid ISO 8601 timestamp: %s (supported formats: YYYY-MM-DDThh:mm:ssZ, YYYY-MM-DD or relative times such as -7d, 2 hours ago or last monday)", timestamp)
 }
 
 func AssignCodingAgentPrompt(t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
@@ -3224,3 +3224,398 @@ func Test_ListIssueTypes(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListIssueComments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListIssueComments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_issue_comments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "direction")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	commentsPage := func(ids ...int64) []*github.IssueComment {
+		comments := make([]*github.IssueComment, len(ids))
+		for i, id := range ids {
+			comments[i] = &github.IssueComment{ID: github.Ptr(id), Body: github.Ptr(fmt.Sprintf("comment %d", id))}
+		}
+		return comments
+	}
+	// pagedComments serves three pages of two comments, linking to the last page like the API does
+	pagedComments := func(t *testing.T, requestedPages *[]string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page")
+			*requestedPages = append(*requestedPages, page)
+			w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/issues/42/comments?page=3&per_page=2>; rel="last"`)
+			switch page {
+			case "1":
+				mockResponse(t, http.StatusOK, commentsPage(1, 2))(w, r)
+			case "2":
+				mockResponse(t, http.StatusOK, commentsPage(3, 4))(w, r)
+			case "3":
+				w.Header().Del("Link")
+				mockResponse(t, http.StatusOK, commentsPage(5, 6))(w, r)
+			}
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   func(t *testing.T, requestedPages *[]string) *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedIDs    []int64
+		expectedPages  []string
+	}{
+		{
+			name: "comments of an issue since a time",
+			mockedClient: func(t *testing.T, _ *[]string) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(
+						mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+						expectQueryParams(t, map[string]string{
+							"since":    "2025-01-01T00:00:00Z",
+							"page":     "1",
+							"per_page": "30",
+						}).andThen(
+							mockResponse(t, http.StatusOK, commentsPage(1, 2)),
+						),
+					),
+				)
+			},
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"since":        "2025-01-01",
+			},
+			expectedIDs: []int64{1, 2},
+		},
+		{
+			name: "comments of all issues sorted by update",
+			mockedClient: func(t *testing.T, _ *[]string) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(
+						mock.GetReposIssuesCommentsByOwnerByRepo,
+						expectQueryParams(t, map[string]string{
+							"sort":      "updated",
+							"direction": "desc",
+							"page":      "1",
+							"per_page":  "30",
+						}).andThen(
+							mockResponse(t, http.StatusOK, commentsPage(2, 1)),
+						),
+					),
+				)
+			},
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"sort":      "updated",
+				"direction": "desc",
+			},
+			expectedIDs: []int64{2, 1},
+		},
+		{
+			name: "newest comments of an issue first",
+			mockedClient: func(t *testing.T, requestedPages *[]string) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber, pagedComments(t, requestedPages)),
+				)
+			},
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"direction":    "desc",
+				"perPage":      float64(2),
+			},
+			expectedIDs:   []int64{6, 5},
+			expectedPages: []string{"1", "3"},
+		},
+		{
+			name: "oldest page of an issue when newest first",
+			mockedClient: func(t *testing.T, requestedPages *[]string) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber, pagedComments(t, requestedPages)),
+				)
+			},
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"direction":    "desc",
+				"page":         float64(3),
+				"perPage":      float64(2),
+			},
+			expectedIDs:   []int64{2, 1},
+			expectedPages: []string{"1"},
+		},
+		{
+			name: "past the last page when newest first",
+			mockedClient: func(t *testing.T, requestedPages *[]string) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber, pagedComments(t, requestedPages)),
+				)
+			},
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"direction":    "desc",
+				"page":         float64(4),
+				"perPage":      float64(2),
+			},
+			expectedIDs:   []int64{},
+			expectedPages: []string{"1"},
+		},
+		{
+			name: "sort of a single issue",
+			mockedClient: func(_ *testing.T, _ *[]string) *http.Client {
+				return mock.NewMockedHTTPClient()
+			},
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"sort":         "updated",
+			},
+			expectError:    true,
+			expectedErrMsg: "sort is only supported when listing the comments of all issues",
+		},
+		{
+			name: "invalid since",
+			mockedClient: func(_ *testing.T, _ *[]string) *http.Client {
+				return mock.NewMockedHTTPClient()
+			},
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "not-a-date",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid ISO 8601 timestamp",
+		},
+		{
+			name: "API error",
+			mockedClient: func(t *testing.T, _ *[]string) *http.Client {
+				return mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(
+						mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+						mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+					),
+				)
+			},
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list issue comments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requestedPages []string
+			client := github.NewClient(tc.mockedClient(t, &requestedPages))
+			_, handler := ListIssueComments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var comments []*github.IssueComment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &comments))
+			ids := make([]int64, len(comments))
+			for i, comment := range comments {
+				ids[i] = comment.GetID()
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			if tc.expectedPages != nil {
+				assert.Equal(t, tc.expectedPages, requestedPages)
+			}
+		})
+	}
+}
+
+func Test_MinimizeComment(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := MinimizeComment(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "minimize_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "comment_id")
+	assert.Contains(t, tool.InputSchema.Properties, "classifier")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"comment_id", "classifier"})
+
+	mutation := struct {
+		MinimizeComment struct {
+			MinimizedComment struct {
+				IsMinimized     githubv4.Boolean
+				MinimizedReason githubv4.String
+			}
+		} `graphql:"minimizeComment(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult MinimizedComment
+		expectedErrMsg string
+	}{
+		{
+			name: "successful minimize",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					mutation,
+					githubv4.MinimizeCommentInput{
+						SubjectID:  githubv4.ID("IC_kwDOA0xdyM50BPaO"),
+						Classifier: githubv4.ReportedContentClassifiersSpam,
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"minimizeComment": map[string]any{
+							"minimizedComment": map[string]any{
+								"isMinimized":     true,
+								"minimizedReason": "spam",
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"comment_id": "IC_kwDOA0xdyM50BPaO",
+				"classifier": "spam",
+			},
+			expectedResult: MinimizedComment{
+				ID:              "IC_kwDOA0xdyM50BPaO",
+				IsMinimized:     true,
+				MinimizedReason: "spam",
+			},
+		},
+		{
+			name:         "invalid classifier",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"comment_id": "IC_kwDOA0xdyM50BPaO",
+				"classifier": "rude",
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid classifier "rude"`,
+		},
+		{
+			name: "comment not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					mutation,
+					githubv4.MinimizeCommentInput{
+						SubjectID:  githubv4.ID("IC_missing"),
+						Classifier: githubv4.ReportedContentClassifiersOffTopic,
+					},
+					nil,
+					githubv4mock.ErrorResponse("Could not resolve to a node with the global id of 'IC_missing'"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"comment_id": "IC_missing",
+				"classifier": "off_topic",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to minimize comment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := MinimizeComment(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned MinimizedComment
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_UnminimizeComment(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := UnminimizeComment(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unminimize_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "comment_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"comment_id"})
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewMutationMatcher(
+			struct {
+				UnminimizeComment struct {
+					UnminimizedComment struct {
+						IsMinimized githubv4.Boolean
+					}
+				} `graphql:"unminimizeComment(input: $input)"`
+			}{},
+			githubv4.UnminimizeCommentInput{
+				SubjectID: githubv4.ID("IC_kwDOA0xdyM50BPaO"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"unminimizeComment": map[string]any{
+					"unminimizedComment": map[string]any{
+						"isMinimized": false,
+					},
+				},
+			}),
+		),
+	)
+
+	_, handler := UnminimizeComment(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"comment_id": "IC_kwDOA0xdyM50BPaO",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned MinimizedComment
+	err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+	require.NoError(t, err)
+	assert.Equal(t, MinimizedComment{ID: "IC_kwDOA0xdyM50BPaO"}, returned)
+}
@@ -0,0 +1,39 @@
+package github
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// The loggers of the messages logged to clients, which tell what the messages are about.
+const (
+	ClientLoggerRateLimit    = "rate_limit"
+	ClientLoggerRetry        = "retry"
+	ClientLoggerPermissions  = "permissions"
+	ClientLoggerRoots        = "roots"
+	ClientLoggerConfirmation = "confirmation"
+	ClientLoggerOfflineCache = "offline_cache"
+)
+
+// LogToClient sends a log message about the tool call in the context to its client, as a notifications/message
+// notification whose data holds the message, the tool being called and the fields. Messages below the level the
+// client asked for with logging/setLevel, error by default, aren't sent. It is a no-op when there is no session to
+// notify.
+func LogToClient(ctx context.Context, level mcp.LoggingLevel, logger string, message string, fields map[string]any) {
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return
+	}
+
+	data := make(map[string]any, len(fields)+2)
+	for k, v := range fields {
+		data[k] = v
+	}
+	data["message"] = message
+	if tool, ok := ctx.Value(permissionCallKey{}).(string); ok {
+		data["tool"] = tool
+	}
+	_ = s.SendLogMessageToClient(ctx, mcp.NewLoggingMessageNotification(level, logger, data))
+}
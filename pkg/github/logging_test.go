@@ -0,0 +1,50 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type loggingSession struct {
+	notificationSession
+	level mcp.LoggingLevel
+}
+
+func (s *loggingSession) SetLogLevel(level mcp.LoggingLevel) { s.level = level }
+func (s *loggingSession) GetLogLevel() mcp.LoggingLevel      { return s.level }
+
+func Test_LogToClient(t *testing.T) {
+	// Without a server in the context this must be a no-op rather than panic
+	LogToClient(context.Background(), mcp.LoggingLevelWarning, ClientLoggerRetry, "retrying", nil)
+
+	s := NewServer("test")
+	s.AddTool(mcp.NewTool("get_issue"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx = context.WithValue(ctx, permissionCallKey{}, "get_issue")
+		LogToClient(ctx, mcp.LoggingLevelWarning, ClientLoggerRateLimit, "GitHub rate limit hit", map[string]any{"attempt": 1})
+		return mcp.NewToolResultText("ok"), nil
+	})
+	session := &loggingSession{
+		notificationSession: notificationSession{notifications: make(chan mcp.JSONRPCNotification, 10)},
+		level:               mcp.LoggingLevelError,
+	}
+	ctx := s.WithContext(context.Background(), session)
+	call := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_issue"}}`)
+
+	// Messages below the level the client asked for aren't sent
+	s.HandleMessage(ctx, call)
+	assert.Empty(t, session.notifications)
+
+	session.SetLogLevel(mcp.LoggingLevelWarning)
+	s.HandleMessage(ctx, call)
+	require.Len(t, session.notifications, 1)
+	notification := <-session.notifications
+	assert.Equal(t, "notifications/message", notification.Method)
+	params := notification.Params.AdditionalFields
+	assert.Equal(t, mcp.LoggingLevelWarning, params["level"])
+	assert.Equal(t, ClientLoggerRateLimit, params["logger"])
+	assert.Equal(t, map[string]any{"message": "GitHub rate limit hit", "tool": "get_issue", "attempt": 1}, params["data"])
+}
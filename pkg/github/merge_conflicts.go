@@ -0,0 +1,267 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ConflictingFile is a file of a pull request whose changes conflict with the changes made to it on the base branch.
+type ConflictingFile struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// MergeConflictReport is the output type of get_merge_conflicts.
+type MergeConflictReport struct {
+	PullNumber     int    `json:"pull_number"`
+	Base           string `json:"base"`
+	Head           string `json:"head"`
+	MergeBaseSHA   string `json:"merge_base_sha,omitempty"`
+	Mergeable      *bool  `json:"mergeable"`
+	MergeableState string `json:"mergeable_state,omitempty"`
+	Conflicted     bool   `json:"conflicted"`
+	// Files are the conflicting files found by comparing the changes of both sides since the merge base.
+	Files []ConflictingFile `json:"files"`
+}
+
+// changedLines are the lines of the merge base a change replaces, from start up to but excluding end. Insertions
+// replace no lines, so start and end are both the line they are inserted before.
+type changedLines struct {
+	start, end int
+}
+
+// GetMergeConflicts creates a tool to report whether a pull request conflicts with its base branch and which files
+// conflict. GitHub only computes whether a pull request can be merged, so the files are found by a trial three-way
+// merge of the paths changed on both sides: the lines of the merge base each side changes are compared, and the
+// changes conflict where they overlap or touch, as they do for git.
+func GetMergeConflicts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_merge_conflicts",
+			mcp.WithDescription(t("TOOL_GET_MERGE_CONFLICTS_DESCRIPTION", "Report whether a pull request has merge conflicts with its base branch and, when it has, which files conflict and where. Only the first 300 files changed on each side are compared.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MERGE_CONFLICTS_USER_TITLE", "Get pull request merge conflicts"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[MergeConflictReport](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := getPullRequestMergeability(ctx, client, owner, repo, pullNumber, mergeabilityRetryDelays)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			report := MergeConflictReport{
+				PullNumber:     pullNumber,
+				Base:           pr.GetBase().GetRef(),
+				Head:           pr.GetHead().GetRef(),
+				Mergeable:      pr.Mergeable,
+				MergeableState: pr.GetMergeableState(),
+				Files:          []ConflictingFile{},
+			}
+			// GitHub has already found the pull request free of conflicts
+			if pr.Mergeable != nil && *pr.Mergeable {
+				return MarshalledTextResult(report), nil
+			}
+
+			// The head is compared with the base branch rather than the base SHA of the pull request, which is
+			// only updated when the head is
+			headChanges, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, report.Base, pr.GetHead().GetSHA(), nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to compare the pull request with its base branch",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			report.MergeBaseSHA = headChanges.GetMergeBaseCommit().GetSHA()
+
+			// Nothing can conflict when the base branch has no commits the head doesn't have
+			if headChanges.GetBehindBy() == 0 {
+				return MarshalledTextResult(report), nil
+			}
+
+			baseChanges, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, report.MergeBaseSHA, report.Base, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get the changes on the base branch",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			changedOnBase := make(map[string]*github.CommitFile, len(baseChanges.Files))
+			for _, file := range baseChanges.Files {
+				changedOnBase[file.GetFilename()] = file
+				if file.GetPreviousFilename() != "" {
+					changedOnBase[file.GetPreviousFilename()] = file
+				}
+			}
+			var changedOnBoth []string
+			for _, file := range headChanges.Files {
+				baseFile, ok := changedOnBase[file.GetFilename()]
+				if !ok && file.GetPreviousFilename() != "" {
+					baseFile, ok = changedOnBase[file.GetPreviousFilename()]
+				}
+				if !ok {
+					continue
+				}
+				changedOnBoth = append(changedOnBoth, file.GetFilename())
+				if reason := fileConflict(file, baseFile); reason != "" {
+					report.Files = append(report.Files, ConflictingFile{Path: file.GetFilename(), Reason: reason})
+				}
+			}
+
+			// GitHub knows better when the trial merge misses the conflicts, e.g. among files past the first 300,
+			// so the files changed on both sides are the ones to look at
+			if pr.Mergeable != nil && len(report.Files) == 0 {
+				for _, path := range changedOnBoth {
+					report.Files = append(report.Files, ConflictingFile{Path: path, Reason: "changed on both branches"})
+				}
+			}
+			sort.Slice(report.Files, func(i, j int) bool { return report.Files[i].Path < report.Files[j].Path })
+			report.Conflicted = (pr.Mergeable != nil && !*pr.Mergeable) || len(report.Files) > 0
+
+			return MarshalledTextResult(report), nil
+		}
+}
+
+// fileConflict returns why the changes made to a file by the pull request and on the base branch since the merge
+// base conflict, or "" when they merge cleanly.
+func fileConflict(head, base *github.CommitFile) string {
+	// Both sides made the same change
+	if head.GetSHA() != "" && head.GetSHA() == base.GetSHA() {
+		return ""
+	}
+	switch {
+	case head.GetStatus() == "removed" && base.GetStatus() == "removed":
+		return ""
+	case head.GetStatus() == "removed":
+		return "deleted by the pull request and modified on the base branch"
+	case base.GetStatus() == "removed":
+		return "modified by the pull request and deleted on the base branch"
+	case head.GetStatus() == "added" && base.GetStatus() == "added":
+		return "added on both branches with different contents"
+	case head.GetPatch() == "" || base.GetPatch() == "":
+		return "changed on both branches, and binary or too large to compare"
+	}
+
+	headLines, baseLines := parseChangedLines(head.GetPatch()), parseChangedLines(base.GetPatch())
+	var overlaps []string
+	for _, h := range headLines {
+		for _, b := range baseLines {
+			if h.start > b.end || b.start > h.end {
+				continue
+			}
+			start, end := min(h.start, b.start), max(h.end, b.end)
+			if end > start {
+				end--
+			}
+			if start == end {
+				overlaps = append(overlaps, strconv.Itoa(start))
+			} else {
+				overlaps = append(overlaps, fmt.Sprintf("%d-%d", start, end))
+			}
+		}
+	}
+	if len(overlaps) == 0 {
+		return ""
+	}
+	lines := "lines"
+	if len(overlaps) == 1 && !strings.Contains(overlaps[0], "-") {
+		lines = "line"
+	}
+	return fmt.Sprintf("both branches change %s %s of the merge base version", lines, strings.Join(overlaps, ", "))
+}
+
+// parseChangedLines returns the lines of the old version of a file each group of consecutive changed lines of a
+// unified diff patch replaces.
+func parseChangedLines(patch string) []changedLines {
+	var changes []changedLines
+	var current *changedLines
+	oldLine := 0
+	finish := func() {
+		if current != nil {
+			changes = append(changes, *current)
+			current = nil
+		}
+	}
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			finish()
+			// @@ -start,count +start,count @@
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			start, _, _ := strings.Cut(strings.TrimPrefix(fields[1], "-"), ",")
+			oldLine, _ = strconv.Atoi(start)
+			if strings.HasSuffix(fields[1], ",0") {
+				// Hunks only adding lines are numbered from the line before them
+				oldLine++
+			}
+		case strings.HasPrefix(line, "\\"):
+			// \ No newline at end of file
+		case strings.HasPrefix(line, "-"):
+			if current == nil {
+				current = &changedLines{start: oldLine, end: oldLine}
+			}
+			oldLine++
+			current.end = oldLine
+		case strings.HasPrefix(line, "+"):
+			if current == nil {
+				current = &changedLines{start: oldLine, end: oldLine}
+			}
+		default:
+			finish()
+			oldLine++
+		}
+	}
+	finish()
+	return changes
+}
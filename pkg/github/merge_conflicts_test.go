@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetMergeConflicts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMergeConflicts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_merge_conflicts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	pr := func(mergeable *bool) *github.PullRequest {
+		return &github.PullRequest{
+			Number:    github.Ptr(42),
+			Mergeable: mergeable,
+			Base:      &github.PullRequestBranch{Ref: github.Ptr("main"), SHA: github.Ptr("base-sha")},
+			Head:      &github.PullRequestBranch{Ref: github.Ptr("feature"), SHA: github.Ptr("head-sha")},
+		}
+	}
+	file := func(name, status, patch string) *github.CommitFile {
+		return &github.CommitFile{Filename: github.Ptr(name), Status: github.Ptr(status), SHA: github.Ptr(name + "-" + patch), Patch: github.Ptr(patch)}
+	}
+	headChanges := &github.CommitsComparison{
+		BehindBy:        github.Ptr(2),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("merge-base-sha")},
+		Files: []*github.CommitFile{
+			file("conflict.go", "modified", "@@ -9,3 +9,4 @@ func a() {\n \tctx\n-\told\n+\tnew\n+\tnew2\n \tctx"),
+			file("clean.go", "modified", "@@ -1,2 +1,2 @@\n-package old\n+package clean\n \tctx"),
+			file("deleted.go", "modified", "@@ -1 +1 @@\n-a\n+b"),
+			file("head_only.go", "added", "@@ -0,0 +1 @@\n+a"),
+		},
+	}
+	baseChanges := &github.CommitsComparison{
+		Files: []*github.CommitFile{
+			file("conflict.go", "modified", "@@ -8,4 +8,3 @@ func a() {\n \tctx\n \tctx\n-\told\n \tctx"),
+			file("clean.go", "modified", "@@ -40,1 +40,2 @@\n \tctx\n+\tappended"),
+			file("deleted.go", "removed", ""),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectedReport MergeConflictReport
+	}{
+		{
+			name: "mergeable pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr(github.Ptr(true))),
+			),
+			expectedReport: MergeConflictReport{PullNumber: 42, Base: "main", Head: "feature", Mergeable: github.Ptr(true), Files: []ConflictingFile{}},
+		},
+		{
+			name: "conflicted pull request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr(github.Ptr(false))),
+				mock.WithRequestMatch(mock.GetReposCompareByOwnerByRepoByBasehead, headChanges, baseChanges),
+			),
+			expectedReport: MergeConflictReport{
+				PullNumber:   42,
+				Base:         "main",
+				Head:         "feature",
+				MergeBaseSHA: "merge-base-sha",
+				Mergeable:    github.Ptr(false),
+				Conflicted:   true,
+				Files: []ConflictingFile{
+					{Path: "conflict.go", Reason: "both branches change line 10 of the merge base version"},
+					{Path: "deleted.go", Reason: "modified by the pull request and deleted on the base branch"},
+				},
+			},
+		},
+		{
+			name: "mergeability not computed yet",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr(nil)),
+				mock.WithRequestMatch(mock.GetReposCompareByOwnerByRepoByBasehead,
+					headChanges,
+					&github.CommitsComparison{Files: baseChanges.Files[1:2]},
+				),
+			),
+			expectedReport: MergeConflictReport{PullNumber: 42, Base: "main", Head: "feature", MergeBaseSHA: "merge-base-sha", Files: []ConflictingFile{}},
+		},
+		{
+			name: "base branch without new commits",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr(nil)),
+				mock.WithRequestMatch(mock.GetReposCompareByOwnerByRepoByBasehead, &github.CommitsComparison{
+					BehindBy:        github.Ptr(0),
+					MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("base-sha")},
+				}),
+			),
+			expectedReport: MergeConflictReport{PullNumber: 42, Base: "main", Head: "feature", MergeBaseSHA: "base-sha", Files: []ConflictingFile{}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetMergeConflicts(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}))
+			require.NoError(t, err)
+
+			var report MergeConflictReport
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+			assert.Equal(t, tc.expectedReport, report)
+		})
+	}
+}
+
+func Test_ParseChangedLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		expected []changedLines
+	}{
+		{
+			name:     "replaced lines",
+			patch:    "@@ -9,4 +9,4 @@\n ctx\n-a\n-b\n+c\n ctx\n ctx",
+			expected: []changedLines{{start: 10, end: 12}},
+		},
+		{
+			name:     "insertion",
+			patch:    "@@ -5,0 +6,2 @@\n+a\n+b",
+			expected: []changedLines{{start: 6, end: 6}},
+		},
+		{
+			name:     "several changes and hunks",
+			patch:    "@@ -1,3 +1,3 @@\n-a\n+b\n ctx\n-c\n\\ No newline at end of file\n@@ -20,2 +20,3 @@\n ctx\n+d\n ctx",
+			expected: []changedLines{{start: 1, end: 2}, {start: 3, end: 4}, {start: 21, end: 21}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseChangedLines(tc.patch))
+		})
+	}
+}
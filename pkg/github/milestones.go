@@ -0,0 +1,444 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalMilestone is the trimmed output type for milestones.
+type MinimalMilestone struct {
+	Number       int    `json:"number"`
+	Title        string `json:"title"`
+	Description  string `json:"description,omitempty"`
+	State        string `json:"state"`
+	OpenIssues   int    `json:"open_issues"`
+	ClosedIssues int    `json:"closed_issues"`
+	DueOn        string `json:"due_on,omitempty"`
+	HTMLURL      string `json:"html_url,omitempty"`
+	ClosedAt     string `json:"closed_at,omitempty"`
+}
+
+func convertToMinimalMilestone(milestone *github.Milestone) MinimalMilestone {
+	minimalMilestone := MinimalMilestone{
+		Number:       milestone.GetNumber(),
+		Title:        milestone.GetTitle(),
+		Description:  milestone.GetDescription(),
+		State:        milestone.GetState(),
+		OpenIssues:   milestone.GetOpenIssues(),
+		ClosedIssues: milestone.GetClosedIssues(),
+		HTMLURL:      milestone.GetHTMLURL(),
+	}
+	if milestone.DueOn != nil {
+		minimalMilestone.DueOn = milestone.DueOn.Format("2006-01-02")
+	}
+	if milestone.ClosedAt != nil {
+		minimalMilestone.ClosedAt = milestone.ClosedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalMilestone
+}
+
+// parseDueOn parses the due date of a milestone, given as a date, a timestamp or a time relative to now such as
+// "in 2 weeks".
+func parseDueOn(value string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if dueOn, err := time.Parse(layout, value); err == nil {
+			return dueOn, nil
+		}
+	}
+	if dueOn, ok := parseRelativeTime(value, now); ok {
+		return dueOn, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid due_on %q, expected a date such as 2025-06-30", value)
+}
+
+// withMilestoneOptions adds the parameters of the fields of milestones shared by create_milestone and
+// update_milestone.
+func withMilestoneOptions() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("description",
+			mcp.Description("Description of the milestone"),
+		)(tool)
+		mcp.WithString("due_on",
+			mcp.Description("Due date of the milestone, such as 2025-06-30 or in 2 weeks"),
+		)(tool)
+		mcp.WithString("state",
+			mcp.Description("State of the milestone, closed milestones are kept but can't be set on new issues from the web"),
+			mcp.Enum("open", "closed"),
+		)(tool)
+	}
+}
+
+// milestoneFromRequest returns the fields of a milestone given in the request.
+func milestoneFromRequest(request mcp.CallToolRequest) (*github.Milestone, error) {
+	milestone := &github.Milestone{}
+	for name, field := range map[string]**string{
+		"title":       &milestone.Title,
+		"description": &milestone.Description,
+		"state":       &milestone.State,
+	} {
+		value, ok, err := OptionalParamOK[string](request, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+	dueOn, err := OptionalParam[string](request, "due_on")
+	if err != nil {
+		return nil, err
+	}
+	if dueOn != "" {
+		parsed, err := parseDueOn(dueOn, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		milestone.DueOn = &github.Timestamp{Time: parsed}
+	}
+	return milestone, nil
+}
+
+// ListMilestones creates a tool to list the milestones of a repository.
+func ListMilestones(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_milestones",
+			mcp.WithDescription(t("TOOL_LIST_MILESTONES_DESCRIPTION", "List the milestones of a GitHub repository with their progress, the one due the soonest first")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_MILESTONES_USER_TITLE", "List milestones"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalMilestone](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state. Defaults to open"),
+				mcp.Enum("open", "closed", "all"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort by due date or by the share of closed issues. Defaults to due_on"),
+				mcp.Enum("due_on", "completeness"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction. Defaults to asc"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			milestones, resp, err := client.Issues.ListMilestones(ctx, owner, repo, &github.MilestoneListOptions{
+				State:     state,
+				Sort:      sort,
+				Direction: direction,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list milestones",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalMilestones := make([]MinimalMilestone, 0, len(milestones))
+			for _, milestone := range milestones {
+				minimalMilestones = append(minimalMilestones, convertToMinimalMilestone(milestone))
+			}
+
+			return MarshalledTextResult(minimalMilestones), nil
+		}
+}
+
+// CreateMilestone creates a tool to create a milestone in a repository.
+func CreateMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_milestone",
+			mcp.WithDescription(t("TOOL_CREATE_MILESTONE_DESCRIPTION", "Create a milestone in a GitHub repository to group the issues and pull requests of a release")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_MILESTONE_USER_TITLE", "Create milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalMilestone](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the milestone"),
+			),
+			withMilestoneOptions(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := RequiredParam[string](request, "title"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone, err := milestoneFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Issues.CreateMilestone(ctx, owner, repo, milestone)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create milestone",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalMilestone(created)), nil
+		}
+}
+
+// UpdateMilestone creates a tool to change a milestone of a repository.
+func UpdateMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_milestone",
+			mcp.WithDescription(t("TOOL_UPDATE_MILESTONE_DESCRIPTION", "Change the title, description, due date or state of a milestone of a GitHub repository. Only the given fields are changed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_MILESTONE_USER_TITLE", "Update milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalMilestone](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Required(),
+				mcp.Description("Number of the milestone"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New title of the milestone"),
+			),
+			withMilestoneOptions(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone, err := milestoneFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			updated, resp, err := client.Issues.EditMilestone(ctx, owner, repo, number, milestone)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update milestone %d", number),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalMilestone(updated)), nil
+		}
+}
+
+// DeleteMilestone creates a tool to delete a milestone of a repository.
+func DeleteMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_milestone",
+			mcp.WithDescription(t("TOOL_DELETE_MILESTONE_DESCRIPTION", "Delete a milestone of a GitHub repository. Its issues and pull requests are kept, without a milestone. Close the milestone instead to keep it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_MILESTONE_USER_TITLE", "Delete milestone"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Required(),
+				mcp.Description("Number of the milestone"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Issues.DeleteMilestone(ctx, owner, repo, number)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to delete milestone %d", number),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Milestone %d of %s/%s has been deleted", number, owner, repo)), nil
+		}
+}
+
+// SetMilestone creates a tool to set or remove the milestone of an issue or a pull request.
+func SetMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_milestone",
+			mcp.WithDescription(t("TOOL_SET_MILESTONE_DESCRIPTION", "Set the milestone of an issue or a pull request, replacing its current one, or remove it when no milestone is given")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_MILESTONE_USER_TITLE", "Set milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue or pull request"),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Description("Number of the milestone to set. The milestone is removed when omitted"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestoneNumber, err := OptionalIntParam(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Pull requests are issues for the Issues API, which is the one that sets milestones.
+			var resp *github.Response
+			if milestoneNumber == 0 {
+				_, resp, err = client.Issues.RemoveMilestone(ctx, owner, repo, issueNumber)
+			} else {
+				_, resp, err = client.Issues.Edit(ctx, owner, repo, issueNumber, &github.IssueRequest{
+					Milestone: github.Ptr(milestoneNumber),
+				})
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to set the milestone of #%d", issueNumber),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if milestoneNumber == 0 {
+				return mcp.NewToolResultText(fmt.Sprintf("Removed the milestone of %s/%s#%d", owner, repo, issueNumber)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Set milestone %d on %s/%s#%d", milestoneNumber, owner, repo, issueNumber)), nil
+		}
+}
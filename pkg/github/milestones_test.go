@@ -0,0 +1,342 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseDueOn(t *testing.T) {
+	now := time.Date(2025, 6, 2, 15, 4, 5, 0, time.UTC)
+
+	for value, expected := range map[string]time.Time{
+		"2025-06-30":           time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+		"2025-06-30T12:00:00Z": time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC),
+		"in 2 weeks":           now.AddDate(0, 0, 14),
+	} {
+		dueOn, err := parseDueOn(value, now)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, dueOn, value)
+	}
+
+	_, err := parseDueOn("end of june", now)
+	assert.EqualError(t, err, `invalid due_on "end of june", expected a date such as 2025-06-30`)
+}
+
+func Test_ListMilestones(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListMilestones(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_milestones", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposMilestonesByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"state":     "all",
+				"sort":      "completeness",
+				"direction": "desc",
+				"page":      "1",
+				"per_page":  "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.Milestone{
+					{
+						Number:       github.Ptr(3),
+						Title:        github.Ptr("v1.2"),
+						State:        github.Ptr("open"),
+						OpenIssues:   github.Ptr(4),
+						ClosedIssues: github.Ptr(6),
+						DueOn:        &github.Timestamp{Time: time.Date(2025, 6, 30, 7, 0, 0, 0, time.UTC)},
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListMilestones(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":     "owner",
+		"repo":      "repo",
+		"state":     "all",
+		"sort":      "completeness",
+		"direction": "desc",
+	}))
+	require.NoError(t, err)
+
+	var milestones []MinimalMilestone
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestones))
+	require.Len(t, milestones, 1)
+	assert.Equal(t, MinimalMilestone{
+		Number:       3,
+		Title:        "v1.2",
+		State:        "open",
+		OpenIssues:   4,
+		ClosedIssues: 6,
+		DueOn:        "2025-06-30",
+	}, milestones[0])
+}
+
+func Test_CreateMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_milestone", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "due_on")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "milestone with a due date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMilestonesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"title":       "v1.3",
+						"description": "Next minor release",
+						"due_on":      "2025-07-31T00:00:00Z",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Milestone{
+							Number: github.Ptr(4),
+							Title:  github.Ptr("v1.3"),
+							State:  github.Ptr("open"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"title":       "v1.3",
+				"description": "Next minor release",
+				"due_on":      "2025-07-31",
+			},
+		},
+		{
+			name:         "invalid due date",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"title":  "v1.3",
+				"due_on": "soon",
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid due_on "soon"`,
+		},
+		{
+			name: "title already used",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMilestonesByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"title": "v1.2",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create milestone",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var milestone MinimalMilestone
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestone))
+			assert.Equal(t, 4, milestone.Number)
+		})
+	}
+}
+
+func Test_UpdateMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_milestone", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone_number"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+			expect(t, expectations{
+				path:        "/repos/owner/repo/milestones/3",
+				requestBody: map[string]any{"state": "closed"},
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.Milestone{
+					Number: github.Ptr(3),
+					Title:  github.Ptr("v1.2"),
+					State:  github.Ptr("closed"),
+				}),
+			),
+		),
+	))
+	_, handler := UpdateMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"milestone_number": float64(3),
+		"state":            "closed",
+	}))
+	require.NoError(t, err)
+
+	var milestone MinimalMilestone
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestone))
+	assert.Equal(t, "closed", milestone.State)
+}
+
+func Test_DeleteMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_milestone", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone_number"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposMilestonesByOwnerByRepoByMilestoneNumber,
+					expectPath(t, "/repos/owner/repo/milestones/3").andThen(
+						mockResponse(t, http.StatusNoContent, ""),
+					),
+				),
+			),
+		},
+		{
+			name: "milestone not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposMilestonesByOwnerByRepoByMilestoneNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to delete milestone 3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(3),
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			assert.Equal(t, "Milestone 3 of owner/repo has been deleted", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_SetMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_milestone", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	tests := []struct {
+		name         string
+		requestArgs  map[string]interface{}
+		expectedBody map[string]any
+		expectedText string
+	}{
+		{
+			name: "set the milestone of a pull request",
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(42),
+				"milestone_number": float64(3),
+			},
+			expectedBody: map[string]any{"milestone": float64(3)},
+			expectedText: "Set milestone 3 on owner/repo#42",
+		},
+		{
+			name: "remove the milestone",
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectedBody: map[string]any{"milestone": nil},
+			expectedText: "Removed the milestone of owner/repo#42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, tc.expectedBody).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(42)}),
+					),
+				),
+			))
+			_, handler := SetMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
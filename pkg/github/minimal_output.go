@@ -0,0 +1,135 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// boilerplateFields lists the fields of GitHub objects removed in minimal output mode on top of the
+// API link fields, as models have no use for them. Node IDs are kept, as tools backed by GraphQL take them.
+var boilerplateFields = map[string]bool{
+	"gravatar_id": true,
+	"_links":      true,
+}
+
+// MinimalOutputMiddleware strips null and empty fields, API link fields and other boilerplate from JSON tool
+// results, which otherwise make up most of the tokens of GitHub objects. Links to the web pages of objects are
+// kept, so that they can still be shared. Structured content is stripped the same way.
+func MinimalOutputMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+
+		for i, c := range result.Content {
+			text, ok := c.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+			text.Text = minimizeJSON(text.Text)
+			result.Content[i] = text
+		}
+		if structured, ok := result.StructuredContent.(map[string]any); ok {
+			// Results that aren't objects are required under the items key, even when empty
+			items, hasItems := structured[toolsets.StructuredItemsKey]
+			minimized, _ := minimizeValue(structured).(map[string]any)
+			if minimized == nil {
+				minimized = map[string]any{}
+			}
+			if hasItems {
+				if _, ok := minimized[toolsets.StructuredItemsKey]; !ok {
+					minimized[toolsets.StructuredItemsKey] = items
+				}
+			}
+			result.StructuredContent = minimized
+		}
+		return result, nil
+	}
+}
+
+// minimizeJSON strips a JSON document, leaving text that isn't JSON unchanged.
+func minimizeJSON(text string) string {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return text
+	}
+	if _, ok := v.(map[string]any); !ok {
+		if _, ok := v.([]any); !ok {
+			return text
+		}
+	}
+
+	minimized := minimizeValue(v)
+	if minimized == nil {
+		// Keep documents that are empty once stripped valid
+		if _, ok := v.([]any); ok {
+			return "[]"
+		}
+		return "{}"
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(minimized); err != nil {
+		return text
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// isAPILink reports whether a value is a link to the REST API of GitHub or of GitHub Enterprise Server.
+func isAPILink(v any) bool {
+	link, ok := v.(string)
+	return ok && (strings.Contains(link, "://api.") || strings.Contains(link, "/api/v3/"))
+}
+
+// minimizeValue strips a decoded JSON value, returning nil when nothing is left of it.
+func minimizeValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		_, hasHTMLURL := v["html_url"]
+		for k, field := range v {
+			// REST objects link to themselves in the API with url, and to their page with html_url
+			if boilerplateFields[k] || (strings.HasSuffix(k, "_url") && k != "html_url") || (k == "url" && (hasHTMLURL || isAPILink(field))) {
+				delete(v, k)
+				continue
+			}
+			if minimized := minimizeValue(field); minimized != nil {
+				v[k] = minimized
+			} else {
+				delete(v, k)
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		return v
+	case []any:
+		items := v[:0]
+		for _, item := range v {
+			if minimized := minimizeValue(item); minimized != nil {
+				items = append(items, minimized)
+			}
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return items
+	case string:
+		if v == "" {
+			return nil
+		}
+		return v
+	default:
+		return v
+	}
+}
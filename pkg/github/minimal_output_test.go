@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MinimalOutputMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *mcp.CallToolResult
+		expected string
+	}{
+		{
+			name:     "strips link fields and empty values of REST objects",
+			result:   mcp.NewToolResultText(`{"number":42,"title":"Bug","body":null,"labels":[],"milestone":{},"locked":false,"comments":0,"url":"https://api.github.com/repos/o/r/issues/42","html_url":"https://github.com/o/r/issues/42","comments_url":"https://api.github.com/repos/o/r/issues/42/comments","node_id":"I_1","user":{"login":"octocat","avatar_url":"https://avatars.githubusercontent.com/u/1","gravatar_id":"","url":"https://api.github.com/users/octocat","html_url":"https://github.com/octocat"}}`),
+			expected: `{"comments":0,"html_url":"https://github.com/o/r/issues/42","locked":false,"node_id":"I_1","number":42,"title":"Bug","user":{"html_url":"https://github.com/octocat","login":"octocat"}}`,
+		},
+		{
+			name:     "keeps urls of objects without a page",
+			result:   mcp.NewToolResultText(`{"name":"docs","url":"https://example.com","_links":{"self":"https://api.github.com"}}`),
+			expected: `{"name":"docs","url":"https://example.com"}`,
+		},
+		{
+			name:     "strips items of lists",
+			result:   mcp.NewToolResultText(`[{"sha":"abc","commit":{"message":"Fix","tree":{"url":"https://api.github.com"},"verification":null}},{"gravatar_id":""}]`),
+			expected: `[{"commit":{"message":"Fix"},"sha":"abc"}]`,
+		},
+		{
+			name:     "keeps documents that are empty once stripped valid",
+			result:   mcp.NewToolResultText(`{"body":"","_links":{}}`),
+			expected: `{}`,
+		},
+		{
+			name:     "leaves plain text unchanged",
+			result:   mcp.NewToolResultText("successfully starred repository"),
+			expected: "successfully starred repository",
+		},
+		{
+			name:     "leaves errors unchanged",
+			result:   mcp.NewToolResultError(`{"message":"Not Found","documentation_url":"https://docs.github.com"}`),
+			expected: `{"message":"Not Found","documentation_url":"https://docs.github.com"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := MinimalOutputMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tc.result, nil
+			})
+
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, textContent.Text)
+		})
+	}
+}
+
+func Test_MinimalOutputMiddleware_StructuredContent(t *testing.T) {
+	handler := MinimalOutputMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result := mcp.NewToolResultText(`{"login":"octocat","avatar_url":"https://avatars.githubusercontent.com/u/1"}`)
+		result.StructuredContent = map[string]any{"login": "octocat", "avatar_url": "https://avatars.githubusercontent.com/u/1"}
+		return result, nil
+	})
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"login": "octocat"}, result.StructuredContent)
+
+	// Empty lists stay under the items key the output schema requires
+	handler = MinimalOutputMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result := mcp.NewToolResultText(`[]`)
+		result.StructuredContent = map[string]any{toolsets.StructuredItemsKey: []any{}}
+		return result, nil
+	})
+	result, err = handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{toolsets.StructuredItemsKey: []any{}}, result.StructuredContent)
+	assert.Equal(t, `[]`, getTextResult(t, result).Text)
+}
@@ -15,6 +15,7 @@ type MinimalUser struct {
 type MinimalSearchUsersResult struct {
 	TotalCount        int           `json:"total_count"`
 	IncompleteResults bool          `json:"incomplete_results"`
+	IncompleteReason  string        `json:"incomplete_reason,omitempty"`
 	Items             []MinimalUser `json:"items"`
 }
 
@@ -31,6 +32,7 @@ type MinimalRepository struct {
 	OpenIssues    int      `json:"open_issues_count"`
 	UpdatedAt     string   `json:"updated_at,omitempty"`
 	CreatedAt     string   `json:"created_at,omitempty"`
+	PushedAt      string   `json:"pushed_at,omitempty"`
 	Topics        []string `json:"topics,omitempty"`
 	Private       bool     `json:"private"`
 	Fork          bool     `json:"fork"`
@@ -42,6 +44,7 @@ type MinimalRepository struct {
 type MinimalSearchRepositoriesResult struct {
 	TotalCount        int                 `json:"total_count"`
 	IncompleteResults bool                `json:"incomplete_results"`
+	IncompleteReason  string              `json:"incomplete_reason,omitempty"`
 	Items             []MinimalRepository `json:"items"`
 }
 
@@ -184,6 +187,41 @@ func convertToMinimalUser(user *github.User) *MinimalUser {
 	}
 }
 
+// MinimalStargazer is the output type for users who starred a repository.
+type MinimalStargazer struct {
+	MinimalUser
+	StarredAt string `json:"starred_at,omitempty"`
+}
+
+func convertToMinimalRepository(repo *github.Repository) MinimalRepository {
+	minimalRepo := MinimalRepository{
+		ID:            repo.GetID(),
+		Name:          repo.GetName(),
+		FullName:      repo.GetFullName(),
+		Description:   repo.GetDescription(),
+		HTMLURL:       repo.GetHTMLURL(),
+		Language:      repo.GetLanguage(),
+		Stars:         repo.GetStargazersCount(),
+		Forks:         repo.GetForksCount(),
+		OpenIssues:    repo.GetOpenIssuesCount(),
+		Topics:        repo.Topics,
+		Private:       repo.GetPrivate(),
+		Fork:          repo.GetFork(),
+		Archived:      repo.GetArchived(),
+		DefaultBranch: repo.GetDefaultBranch(),
+	}
+	if repo.CreatedAt != nil {
+		minimalRepo.CreatedAt = repo.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if repo.UpdatedAt != nil {
+		minimalRepo.UpdatedAt = repo.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if repo.PushedAt != nil {
+		minimalRepo.PushedAt = repo.PushedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalRepo
+}
+
 func convertToMinimalProjectItem(item *projectV2Item) *MinimalProjectItem {
 	if item == nil {
 		return nil
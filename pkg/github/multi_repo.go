@@ -0,0 +1,279 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxMultiRepoTargets bounds the number of repositories a single query can fan out to.
+	maxMultiRepoTargets = 250
+	// defaultMultiRepoConcurrency is the number of repositories queried at once unless specified otherwise.
+	defaultMultiRepoConcurrency = 5
+	// maxMultiRepoConcurrency bounds the number of repositories queried at once.
+	maxMultiRepoConcurrency = 20
+)
+
+type multiRepoResult struct {
+	Repository string `json:"repository"`
+	Status     string `json:"status"`
+	Result     any    `json:"result,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type multiRepoQueryResult struct {
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []multiRepoResult `json:"results"`
+}
+
+// MultiRepoQuery creates a tool that runs a read tool taking an owner and a repository against many repositories
+// concurrently, merging their results.
+func MultiRepoQuery(getClient GetClientFn, toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("multi_repo_query",
+			mcp.WithDescription(t("TOOL_MULTI_REPO_QUERY_DESCRIPTION", fmt.Sprintf("Run a read-only tool that takes owner and repo arguments, such as list_issues, get_file_contents or list_workflow_runs, against up to %d repositories concurrently and return the result for each repository. Repositories are given as \"owner/repo\", where repo may be a glob such as \"octo-org/*\" or \"octo-org/service-*\" matching the repositories of an organization or user.", maxMultiRepoTargets))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MULTI_REPO_QUERY_USER_TITLE", "Query multiple repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[multiRepoQueryResult](),
+			mcp.WithString("tool",
+				mcp.Required(),
+				mcp.Description("Name of the read-only tool to run in each repository"),
+			),
+			mcp.WithObject("arguments",
+				mcp.Description("Arguments of the tool, other than owner and repo"),
+			),
+			mcp.WithArray("repos",
+				mcp.Required(),
+				mcp.Items(map[string]interface{}{
+					"type": "string",
+				}),
+				mcp.Description("Repositories to query as \"owner/repo\", where repo may be a glob pattern"),
+			),
+			mcp.WithBoolean("include_archived",
+				mcp.Description("Whether glob patterns also match archived repositories. Default is false."),
+			),
+			mcp.WithNumber("max_concurrency",
+				mcp.Description(fmt.Sprintf("Number of repositories to query at once, up to %d. Default is %d.", maxMultiRepoConcurrency, defaultMultiRepoConcurrency)),
+				mcp.Min(1),
+				mcp.Max(maxMultiRepoConcurrency),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			toolName, err := RequiredParam[string](request, "tool")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			patterns, err := OptionalStringArrayParam(request, "repos")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(patterns) == 0 {
+				return mcp.NewToolResultError("missing required parameter: repos"), nil
+			}
+			includeArchived, err := OptionalParam[bool](request, "include_archived")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			concurrency, err := OptionalIntParamWithDefault(request, "max_concurrency", defaultMultiRepoConcurrency)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if concurrency < 1 || concurrency > maxMultiRepoConcurrency {
+				return mcp.NewToolResultError(fmt.Sprintf("max_concurrency must be between 1 and %d", maxMultiRepoConcurrency)), nil
+			}
+			arguments := map[string]any{}
+			if a, ok := request.GetArguments()["arguments"]; ok && a != nil {
+				if arguments, ok = a.(map[string]any); !ok {
+					return mcp.NewToolResultError("arguments must be an object"), nil
+				}
+			}
+
+			target, ok := toolsetGroup.GetActiveTool(toolName)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("tool %s is not available, it may belong to a toolset that isn't enabled", toolName)), nil
+			}
+			if target.Tool.Annotations.ReadOnlyHint == nil || !*target.Tool.Annotations.ReadOnlyHint {
+				return mcp.NewToolResultError(fmt.Sprintf("tool %s modifies data and can't be run across repositories", toolName)), nil
+			}
+			_, hasOwner := target.Tool.InputSchema.Properties["owner"]
+			_, hasRepo := target.Tool.InputSchema.Properties["repo"]
+			if !hasOwner || !hasRepo {
+				return mcp.NewToolResultError(fmt.Sprintf("tool %s doesn't take owner and repo arguments", toolName)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repos, errResult, err := expandRepositoryPatterns(ctx, client, patterns, includeArchived)
+			if errResult != nil || err != nil {
+				return errResult, err
+			}
+
+			results := make([]multiRepoResult, len(repos))
+			sem := make(chan struct{}, concurrency)
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			done := 0
+			for i, fullName := range repos {
+				wg.Add(1)
+				sem <- struct{}{}
+				go func(i int, fullName string) {
+					defer wg.Done()
+					defer func() { <-sem }()
+
+					results[i] = queryRepository(ctx, target, fullName, arguments)
+
+					mu.Lock()
+					done++
+					NotifyPartialResult(ctx, float64(done), float64(len(repos)), fmt.Sprintf("Queried %s", fullName), results[i])
+					mu.Unlock()
+				}(i, fullName)
+			}
+			wg.Wait()
+
+			merged := multiRepoQueryResult{Results: results}
+			for _, r := range results {
+				if r.Status == "ok" {
+					merged.Succeeded++
+				} else {
+					merged.Failed++
+				}
+			}
+			return MarshalledTextResult(merged), nil
+		}
+}
+
+// queryRepository runs the tool against a single repository.
+func queryRepository(ctx context.Context, target server.ServerTool, fullName string, arguments map[string]any) multiRepoResult {
+	owner, repo, _ := strings.Cut(fullName, "/")
+	if err := checkRootsScope(ctx, owner, repo); err != nil {
+		return multiRepoResult{Repository: fullName, Status: "error", Error: err.Error()}
+	}
+	args := make(map[string]any, len(arguments)+2)
+	for k, v := range arguments {
+		args[k] = v
+	}
+	args["owner"] = owner
+	args["repo"] = repo
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = target.Tool.Name
+	request.Params.Arguments = args
+
+	result, err := target.Handler(ctx, request)
+	var value any
+	if err == nil {
+		value, err = toolResultValue(result)
+	}
+	if err != nil {
+		return multiRepoResult{Repository: fullName, Status: "error", Error: err.Error()}
+	}
+	return multiRepoResult{Repository: fullName, Status: "ok", Result: value}
+}
+
+// expandRepositoryPatterns resolves "owner/repo" patterns to the full names of the matching repositories.
+func expandRepositoryPatterns(ctx context.Context, client *github.Client, patterns []string, includeArchived bool) ([]string, *mcp.CallToolResult, error) {
+	var repos []string
+	seen := map[string]bool{}
+	add := func(fullName string) bool {
+		key := strings.ToLower(fullName)
+		if !seen[key] {
+			seen[key] = true
+			repos = append(repos, fullName)
+		}
+		return len(repos) <= maxMultiRepoTargets
+	}
+	tooMany := mcp.NewToolResultError(fmt.Sprintf("repos match more than %d repositories, use narrower patterns", maxMultiRepoTargets))
+
+	for _, pattern := range patterns {
+		owner, repoPattern, ok := strings.Cut(pattern, "/")
+		if !ok || owner == "" || repoPattern == "" || strings.Contains(repoPattern, "/") {
+			return nil, mcp.NewToolResultError(fmt.Sprintf("invalid repository %q, expected owner/repo", pattern)), nil
+		}
+		if _, err := path.Match(repoPattern, ""); err != nil {
+			return nil, mcp.NewToolResultError(fmt.Sprintf("invalid repository pattern %q: %s", pattern, err)), nil
+		}
+		if !strings.ContainsAny(repoPattern, "*?[") {
+			if !add(pattern) {
+				return nil, tooMany, nil
+			}
+			continue
+		}
+
+		names, errResult, err := listOwnerRepositories(ctx, client, owner, includeArchived)
+		if errResult != nil || err != nil {
+			return nil, errResult, err
+		}
+		for _, name := range names {
+			if matched, _ := path.Match(strings.ToLower(repoPattern), strings.ToLower(name)); matched {
+				if !add(owner + "/" + name) {
+					return nil, tooMany, nil
+				}
+			}
+		}
+	}
+	if len(repos) == 0 {
+		return nil, mcp.NewToolResultError("repos don't match any repository"), nil
+	}
+	return repos, nil, nil
+}
+
+// listOwnerRepositories lists the names of the repositories of an organization, or of a user if owner isn't one.
+func listOwnerRepositories(ctx context.Context, client *github.Client, owner string, includeArchived bool) ([]string, *mcp.CallToolResult, error) {
+	var names []string
+	isOrg := true
+	page := 1
+	for page != 0 {
+		var repos []*github.Repository
+		var resp *github.Response
+		var err error
+		if isOrg {
+			repos, resp, err = client.Repositories.ListByOrg(ctx, owner, &github.RepositoryListByOrgOptions{
+				ListOptions: github.ListOptions{Page: page, PerPage: 100},
+			})
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				_ = resp.Body.Close()
+				isOrg = false
+				continue
+			}
+		} else {
+			repos, resp, err = client.Repositories.ListByUser(ctx, owner, &github.RepositoryListByUserOptions{
+				ListOptions: github.ListOptions{Page: page, PerPage: 100},
+			})
+		}
+		if err != nil {
+			return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to list repositories of %s", owner),
+				resp,
+				err,
+			), nil
+		}
+		_ = resp.Body.Close()
+
+		for _, r := range repos {
+			if r.GetArchived() && !includeArchived {
+				continue
+			}
+			names = append(names, r.GetName())
+		}
+		page = resp.NextPage
+		if page != 0 {
+			NotifyProgress(ctx, float64(len(names)), 0, fmt.Sprintf("Listed %d repositories of %s", len(names), owner))
+		}
+	}
+	return names, nil, nil
+}
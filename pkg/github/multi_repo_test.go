@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiRepoQuery(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := MultiRepoQuery(stubGetClientFn(mockClient), newBatchTestToolsetGroup(), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "multi_repo_query", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "tool")
+	assert.Contains(t, tool.InputSchema.Properties, "arguments")
+	assert.Contains(t, tool.InputSchema.Properties, "repos")
+	assert.Contains(t, tool.InputSchema.Properties, "include_archived")
+	assert.Contains(t, tool.InputSchema.Properties, "max_concurrency")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"tool", "repos"})
+
+	orgRepos := []*github.Repository{
+		{Name: github.Ptr("service-api")},
+		{Name: github.Ptr("service-web")},
+		{Name: github.Ptr("service-old"), Archived: github.Ptr(true)},
+		{Name: github.Ptr("docs")},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedResults []multiRepoResult
+		expectedErrMsg  string
+	}{
+		{
+			name: "expands organization globs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, orgRepos),
+			),
+			requestArgs: map[string]interface{}{
+				"tool":      "echo",
+				"arguments": map[string]interface{}{"state": "open"},
+				"repos":     []interface{}{"octo-org/service-*", "octocat/hello", "octo-org/service-api"},
+			},
+			expectedResults: []multiRepoResult{
+				{Repository: "octo-org/service-api", Status: "ok", Result: map[string]any{"owner": "octo-org", "repo": "service-api", "state": "open"}},
+				{Repository: "octo-org/service-web", Status: "ok", Result: map[string]any{"owner": "octo-org", "repo": "service-web", "state": "open"}},
+				{Repository: "octocat/hello", Status: "ok", Result: map[string]any{"owner": "octocat", "repo": "hello", "state": "open"}},
+			},
+		},
+		{
+			name: "falls back to user repositories and includes archived ones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatch(mock.GetUsersReposByUsername, orgRepos),
+			),
+			requestArgs: map[string]interface{}{
+				"tool":             "echo",
+				"repos":            []interface{}{"octocat/service-o*"},
+				"include_archived": true,
+			},
+			expectedResults: []multiRepoResult{
+				{Repository: "octocat/service-old", Status: "ok", Result: map[string]any{"owner": "octocat", "repo": "service-old"}},
+			},
+		},
+		{
+			name: "rejects tools that modify data",
+			requestArgs: map[string]interface{}{
+				"tool":  "fail",
+				"repos": []interface{}{"octocat/hello"},
+			},
+			expectError:    true,
+			expectedErrMsg: "tool fail modifies data and can't be run across repositories",
+		},
+		{
+			name: "rejects invalid repositories",
+			requestArgs: map[string]interface{}{
+				"tool":  "echo",
+				"repos": []interface{}{"hello"},
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid repository "hello", expected owner/repo`,
+		},
+		{
+			name: "patterns without matches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, orgRepos),
+			),
+			requestArgs: map[string]interface{}{
+				"tool":  "echo",
+				"repos": []interface{}{"octo-org/missing-*"},
+			},
+			expectError:    true,
+			expectedErrMsg: "repos don't match any repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := MultiRepoQuery(stubGetClientFn(client), newBatchTestToolsetGroup(), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned multiRepoQueryResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, len(tc.expectedResults), returned.Succeeded)
+			assert.Equal(t, 0, returned.Failed)
+			assert.Equal(t, tc.expectedResults, returned.Results)
+		})
+	}
+}
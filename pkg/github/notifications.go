@@ -30,15 +30,16 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				Title:        t("TOOL_LIST_NOTIFICATIONS_USER_TITLE", "List notifications"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.Notification](),
 			mcp.WithString("filter",
 				mcp.Description("Filter notifications to, use default unless specified. Read notifications are ones that have already been acknowledged by the user. Participating notifications are those that the user is directly involved in, such as issues or pull requests they have commented on or created."),
 				mcp.Enum(FilterDefault, FilterIncludeRead, FilterOnlyParticipating),
 			),
 			mcp.WithString("since",
-				mcp.Description("Only show notifications updated after the given time (ISO 8601 format)"),
+				mcp.Description("Only show notifications updated after the given time (ISO 8601 format, or a relative time such as -7d or 2 hours ago)"),
 			),
 			mcp.WithString("before",
-				mcp.Description("Only show notifications updated before the given time (ISO 8601 format)"),
+				mcp.Description("Only show notifications updated before the given time (ISO 8601 format, or a relative time such as -7d or 2 hours ago)"),
 			),
 			mcp.WithString("owner",
 				mcp.Description("Optional repository owner. If provided with repo, only notifications for this repository are listed."),
@@ -47,6 +48,7 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				mcp.Description("Optional repository name. If provided with owner, only notifications for this repository are listed."),
 			),
 			WithPagination(),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
@@ -95,7 +97,7 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 
 			// Parse time parameters if provided
 			if since != "" {
-				sinceTime, err := time.Parse(time.RFC3339, since)
+				sinceTime, err := parseISOTimestamp(since)
 				if err != nil {
 					return mcp.NewToolResultError(fmt.Sprintf("invalid since time format, should be RFC3339/ISO8601: %v", err)), nil
 				}
@@ -103,7 +105,7 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 			}
 
 			if before != "" {
-				beforeTime, err := time.Parse(time.RFC3339, before)
+				beforeTime, err := parseISOTimestamp(before)
 				if err != nil {
 					return mcp.NewToolResultError(fmt.Sprintf("invalid before time format, should be RFC3339/ISO8601: %v", err)), nil
 				}
@@ -299,6 +301,7 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 				Title:        t("TOOL_GET_NOTIFICATION_DETAILS_USER_TITLE", "Get notification details"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.Notification](),
 			mcp.WithString("notificationID",
 				mcp.Required(),
 				mcp.Description("The ID of the notification"),
@@ -523,3 +526,150 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// MarkNotificationDone creates a tool to mark a notification as done, removing it from the inbox.
+func MarkNotificationDone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("mark_notification_done",
+			mcp.WithDescription(t("TOOL_MARK_NOTIFICATION_DONE_DESCRIPTION", "Mark a notification as done, removing it from the inbox. It comes back when there is new activity on the thread.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MARK_NOTIFICATION_DONE_USER_TITLE", "Mark notification as done"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("threadID",
+				mcp.Required(),
+				mcp.Description("The ID of the notification thread"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			threadID, err := RequiredParam[string](request, "threadID")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			threadIDInt, err := strconv.ParseInt(threadID, 10, 64)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid threadID format: %v", err)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Activity.MarkThreadDone(ctx, threadIDInt)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to mark notification as done",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusResetContent && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to mark notification as done: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText("Notification marked as done"), nil
+		}
+}
+
+// SubscribeToThread creates a tool to subscribe to a notification thread.
+func SubscribeToThread(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("subscribe_to_thread",
+			mcp.WithDescription(t("TOOL_SUBSCRIBE_TO_THREAD_DESCRIPTION", "Subscribe to a notification thread, to be notified of all its activity")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUBSCRIBE_TO_THREAD_USER_TITLE", "Subscribe to notification thread"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("threadID",
+				mcp.Required(),
+				mcp.Description("The ID of the notification thread"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			threadID, err := RequiredParam[string](request, "threadID")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			sub := &github.Subscription{Ignored: ToBoolPtr(false), Subscribed: ToBoolPtr(true)}
+			subscription, resp, err := client.Activity.SetThreadSubscription(ctx, threadID, sub)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to subscribe to thread",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to subscribe to thread: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(subscription)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UnsubscribeFromThread creates a tool to unsubscribe from a notification thread.
+func UnsubscribeFromThread(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unsubscribe_from_thread",
+			mcp.WithDescription(t("TOOL_UNSUBSCRIBE_FROM_THREAD_DESCRIPTION", "Unsubscribe from a notification thread, muting its notifications until you comment on it or are mentioned in it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNSUBSCRIBE_FROM_THREAD_USER_TITLE", "Unsubscribe from notification thread"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("threadID",
+				mcp.Required(),
+				mcp.Description("The ID of the notification thread"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			threadID, err := RequiredParam[string](request, "threadID")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Activity.DeleteThreadSubscription(ctx, threadID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to unsubscribe from thread",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to unsubscribe from thread: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText("Unsubscribed from notification thread"), nil
+		}
+}
@@ -763,3 +763,203 @@ func Test_GetNotificationDetails(t *testing.T) {
 		})
 	}
 }
+
+func Test_MarkNotificationDone(t *testing.T) {
+	// Verify tool definition and schema
+	mockClient := github.NewClient(nil)
+	tool, _ := MarkNotificationDone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "mark_notification_done", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"threadID"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "mark as done",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteNotificationsThreadsByThreadId,
+					expectPath(t, "/notifications/threads/123").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs:  map[string]interface{}{"threadID": "123"},
+			expectedText: "Notification marked as done",
+		},
+		{
+			name:           "invalid threadID format",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{"threadID": "notanumber"},
+			expectError:    true,
+			expectedErrMsg: "invalid threadID format",
+		},
+		{
+			name: "API error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteNotificationsThreadsByThreadId,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs:    map[string]interface{}{"threadID": "123"},
+			expectError:    true,
+			expectedErrMsg: "failed to mark notification as done",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := MarkNotificationDone(stubGetClientFn(client), translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_SubscribeToThread(t *testing.T) {
+	// Verify tool definition and schema
+	mockClient := github.NewClient(nil)
+	tool, _ := SubscribeToThread(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "subscribe_to_thread", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"threadID"})
+
+	mockSub := &github.Subscription{Ignored: github.Ptr(false), Subscribed: github.Ptr(true)}
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "subscribe",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutNotificationsThreadsSubscriptionByThreadId,
+					expectRequestBody(t, map[string]any{"ignored": false, "subscribed": true}).andThen(
+						mockResponse(t, http.StatusOK, mockSub),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{"threadID": "123"},
+		},
+		{
+			name:           "missing required threadID",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: threadID",
+		},
+		{
+			name: "API error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutNotificationsThreadsSubscriptionByThreadId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs:    map[string]interface{}{"threadID": "123"},
+			expectError:    true,
+			expectedErrMsg: "failed to subscribe to thread",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SubscribeToThread(stubGetClientFn(client), translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			var sub github.Subscription
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &sub))
+			assert.True(t, sub.GetSubscribed())
+			assert.False(t, sub.GetIgnored())
+		})
+	}
+}
+
+func Test_UnsubscribeFromThread(t *testing.T) {
+	// Verify tool definition and schema
+	mockClient := github.NewClient(nil)
+	tool, _ := UnsubscribeFromThread(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unsubscribe_from_thread", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"threadID"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "unsubscribe",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteNotificationsThreadsSubscriptionByThreadId,
+					expectPath(t, "/notifications/threads/123/subscription").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "API error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteNotificationsThreadsSubscriptionByThreadId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to unsubscribe from thread",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UnsubscribeFromThread(stubGetClientFn(client), translations.NullTranslationHelper)
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"threadID": "123"}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Equal(t, "Unsubscribed from notification thread", getTextResult(t, result).Text)
+		})
+	}
+}
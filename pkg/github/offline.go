@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// staleTracker records the oldest cached response served during a tool call.
+type staleTracker struct {
+	mu       sync.Mutex
+	cachedAt time.Time
+}
+
+type staleTrackerKey struct{}
+
+// MarkStale records that a response cached at the given time was served to the tool call in the context, because
+// GitHub couldn't be reached. It is a no-op outside of StaleResultMiddleware.
+func MarkStale(ctx context.Context, cachedAt time.Time) {
+	tracker, ok := ctx.Value(staleTrackerKey{}).(*staleTracker)
+	if !ok {
+		return
+	}
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if tracker.cachedAt.IsZero() || cachedAt.Before(tracker.cachedAt) {
+		tracker.cachedAt = cachedAt
+	}
+}
+
+// StaleResultMiddleware flags the results of tool calls that were answered from the offline cache. The result
+// metadata gets stale and cachedAt fields, and a note is added for models reading the text content.
+func StaleResultMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tracker := &staleTracker{}
+		result, err := next(context.WithValue(ctx, staleTrackerKey{}, tracker), request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+
+		tracker.mu.Lock()
+		cachedAt := tracker.cachedAt
+		tracker.mu.Unlock()
+		if cachedAt.IsZero() {
+			return result, nil
+		}
+
+		if result.Meta == nil {
+			result.Meta = make(map[string]any)
+		}
+		LogToClient(ctx, mcp.LoggingLevelNotice, ClientLoggerOfflineCache,
+			fmt.Sprintf("GitHub is unreachable, %s was answered from a cache", request.Params.Name),
+			map[string]any{"tool": request.Params.Name, "cached_at": cachedAt.UTC().Format(time.RFC3339)})
+		result.Meta["stale"] = true
+		result.Meta["cachedAt"] = cachedAt.UTC().Format(time.RFC3339)
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+			"Note: GitHub is unreachable, this result was served from a cache and may be out of date (cached at %s).",
+			cachedAt.UTC().Format(time.RFC3339))))
+		return result, nil
+	}
+}
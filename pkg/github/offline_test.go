@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StaleResultMiddleware(t *testing.T) {
+	older := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	newer := older.Add(time.Minute)
+
+	tests := []struct {
+		name       string
+		cachedAt   []time.Time
+		isError    bool
+		expectNote bool
+	}{
+		{
+			name: "fresh results are unchanged",
+		},
+		{
+			name:       "stale results are flagged with the oldest cache time",
+			cachedAt:   []time.Time{newer, older},
+			expectNote: true,
+		},
+		{
+			name:     "errors are unchanged",
+			cachedAt: []time.Time{older},
+			isError:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := StaleResultMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				for _, cachedAt := range tc.cachedAt {
+					MarkStale(ctx, cachedAt)
+				}
+				if tc.isError {
+					return mcp.NewToolResultError("failed"), nil
+				}
+				return mcp.NewToolResultText(`{"id":1}`), nil
+			})
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+
+			if !tc.expectNote {
+				assert.Len(t, result.Content, 1)
+				assert.Nil(t, result.Meta)
+				return
+			}
+
+			require.Len(t, result.Content, 2)
+			assert.Equal(t, `{"id":1}`, result.Content[0].(mcp.TextContent).Text)
+			assert.Contains(t, result.Content[1].(mcp.TextContent).Text, "served from a cache")
+			assert.Equal(t, map[string]any{"stale": true, "cachedAt": "2025-01-02T03:04:05Z"}, result.Meta)
+		})
+	}
+
+	// Marking outside of the middleware is a no-op
+	MarkStale(context.Background(), older)
+}
@@ -0,0 +1,477 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalOrganization is the trimmed output type for organizations, with the settings admins can change.
+type MinimalOrganization struct {
+	Login                         string `json:"login"`
+	ID                            int64  `json:"id,omitempty"`
+	Name                          string `json:"name,omitempty"`
+	Description                   string `json:"description,omitempty"`
+	Company                       string `json:"company,omitempty"`
+	Blog                          string `json:"blog,omitempty"`
+	Location                      string `json:"location,omitempty"`
+	Email                         string `json:"email,omitempty"`
+	BillingEmail                  string `json:"billing_email,omitempty"`
+	HTMLURL                       string `json:"html_url,omitempty"`
+	Plan                          string `json:"plan,omitempty"`
+	PublicRepos                   int    `json:"public_repos"`
+	TotalPrivateRepos             int64  `json:"total_private_repos,omitempty"`
+	Verified                      bool   `json:"verified"`
+	TwoFactorRequirementEnabled   *bool  `json:"two_factor_requirement_enabled,omitempty"`
+	DefaultRepositoryPermission   string `json:"default_repository_permission,omitempty"`
+	MembersCanCreateRepositories  *bool  `json:"members_can_create_repositories,omitempty"`
+	MembersCanCreatePublicRepos   *bool  `json:"members_can_create_public_repositories,omitempty"`
+	MembersCanCreatePrivateRepos  *bool  `json:"members_can_create_private_repositories,omitempty"`
+	MembersCanCreateInternalRepos *bool  `json:"members_can_create_internal_repositories,omitempty"`
+	MembersCanForkPrivateRepos    *bool  `json:"members_can_fork_private_repositories,omitempty"`
+	WebCommitSignoffRequired      *bool  `json:"web_commit_signoff_required,omitempty"`
+	CreatedAt                     string `json:"created_at,omitempty"`
+}
+
+// MinimalOrgInvitation is the output type for invitations to join an organization.
+type MinimalOrgInvitation struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Role      string `json:"role"`
+	Inviter   string `json:"inviter,omitempty"`
+	TeamCount int    `json:"team_count"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+func convertToMinimalOrganization(org *github.Organization) MinimalOrganization {
+	minimalOrg := MinimalOrganization{
+		Login:                         org.GetLogin(),
+		ID:                            org.GetID(),
+		Name:                          org.GetName(),
+		Description:                   org.GetDescription(),
+		Company:                       org.GetCompany(),
+		Blog:                          org.GetBlog(),
+		Location:                      org.GetLocation(),
+		Email:                         org.GetEmail(),
+		BillingEmail:                  org.GetBillingEmail(),
+		HTMLURL:                       org.GetHTMLURL(),
+		Plan:                          org.GetPlan().GetName(),
+		PublicRepos:                   org.GetPublicRepos(),
+		TotalPrivateRepos:             org.GetTotalPrivateRepos(),
+		Verified:                      org.GetIsVerified(),
+		TwoFactorRequirementEnabled:   org.TwoFactorRequirementEnabled,
+		DefaultRepositoryPermission:   org.GetDefaultRepoSettings(),
+		MembersCanCreateRepositories:  org.MembersCanCreateRepos,
+		MembersCanCreatePublicRepos:   org.MembersCanCreatePublicRepos,
+		MembersCanCreatePrivateRepos:  org.MembersCanCreatePrivateRepos,
+		MembersCanCreateInternalRepos: org.MembersCanCreateInternalRepos,
+		MembersCanForkPrivateRepos:    org.MembersCanForkPrivateRepos,
+		WebCommitSignoffRequired:      org.WebCommitSignoffRequired,
+	}
+	if minimalOrg.DefaultRepositoryPermission == "" {
+		minimalOrg.DefaultRepositoryPermission = org.GetDefaultRepoPermission()
+	}
+	if org.CreatedAt != nil {
+		minimalOrg.CreatedAt = org.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalOrg
+}
+
+func convertToMinimalOrgInvitation(invitation *github.Invitation) MinimalOrgInvitation {
+	minimalInvitation := MinimalOrgInvitation{
+		ID:        invitation.GetID(),
+		Login:     invitation.GetLogin(),
+		Email:     invitation.GetEmail(),
+		Role:      invitation.GetRole(),
+		Inviter:   invitation.GetInviter().GetLogin(),
+		TeamCount: invitation.GetTeamCount(),
+	}
+	if invitation.CreatedAt != nil {
+		minimalInvitation.CreatedAt = invitation.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalInvitation
+}
+
+// GetOrg creates a tool to get the profile and member settings of an organization.
+func GetOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org",
+			mcp.WithDescription(t("TOOL_GET_ORG_DESCRIPTION", "Get the profile of an organization. Its owners also get its plan, billing email and the settings of its members, such as whether they can create repositories")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_USER_TITLE", "Get organization"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[MinimalOrganization](),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			organization, resp, err := client.Organizations.Get(ctx, org)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalOrganization(organization)), nil
+		}
+}
+
+// UpdateOrgSettings creates a tool to change the profile and member settings of an organization.
+func UpdateOrgSettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_org_settings",
+			mcp.WithDescription(t("TOOL_UPDATE_ORG_SETTINGS_DESCRIPTION", "Change the profile and member settings of an organization. Only the given settings are changed. Requires being an owner of the organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_ORG_SETTINGS_USER_TITLE", "Update organization settings"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalOrganization](),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("name",
+				mcp.Description("Display name of the organization"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description of the organization"),
+			),
+			mcp.WithString("company",
+				mcp.Description("Company name"),
+			),
+			mcp.WithString("blog",
+				mcp.Description("URL of the website of the organization"),
+			),
+			mcp.WithString("location",
+				mcp.Description("Location of the organization"),
+			),
+			mcp.WithString("email",
+				mcp.Description("Publicly visible email address"),
+			),
+			mcp.WithString("billing_email",
+				mcp.Description("Billing email address, which isn't publicly visible"),
+			),
+			mcp.WithString("default_repository_permission",
+				mcp.Description("Permission members get on all the repositories of the organization"),
+				mcp.Enum("read", "write", "admin", "none"),
+			),
+			mcp.WithBoolean("members_can_create_repositories",
+				mcp.Description("Whether members can create repositories, overridden by the settings per visibility"),
+			),
+			mcp.WithBoolean("members_can_create_public_repositories",
+				mcp.Description("Whether members can create public repositories"),
+			),
+			mcp.WithBoolean("members_can_create_private_repositories",
+				mcp.Description("Whether members can create private repositories"),
+			),
+			mcp.WithBoolean("members_can_create_internal_repositories",
+				mcp.Description("Whether members can create internal repositories, for organizations of an enterprise"),
+			),
+			mcp.WithBoolean("members_can_fork_private_repositories",
+				mcp.Description("Whether members can fork the private repositories of the organization"),
+			),
+			mcp.WithBoolean("web_commit_signoff_required",
+				mcp.Description("Whether contributors must sign off commits made on the web"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			settings := &github.Organization{}
+			changed := false
+			for name, field := range map[string]**string{
+				"name":                          &settings.Name,
+				"description":                   &settings.Description,
+				"company":                       &settings.Company,
+				"blog":                          &settings.Blog,
+				"location":                      &settings.Location,
+				"email":                         &settings.Email,
+				"billing_email":                 &settings.BillingEmail,
+				"default_repository_permission": &settings.DefaultRepoPermission,
+			} {
+				value, ok, err := OptionalParamOK[string](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+					changed = true
+				}
+			}
+			for name, field := range map[string]**bool{
+				"members_can_create_repositories":          &settings.MembersCanCreateRepos,
+				"members_can_create_public_repositories":   &settings.MembersCanCreatePublicRepos,
+				"members_can_create_private_repositories":  &settings.MembersCanCreatePrivateRepos,
+				"members_can_create_internal_repositories": &settings.MembersCanCreateInternalRepos,
+				"members_can_fork_private_repositories":    &settings.MembersCanForkPrivateRepos,
+				"web_commit_signoff_required":              &settings.WebCommitSignoffRequired,
+			} {
+				value, ok, err := OptionalParamOK[bool](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+					changed = true
+				}
+			}
+			if !changed {
+				return mcp.NewToolResultError("at least one setting to change must be given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			organization, resp, err := client.Organizations.Edit(ctx, org, settings)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update the settings of organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalOrganization(organization)), nil
+		}
+}
+
+// ListOrgMembers creates a tool to list the members of an organization.
+func ListOrgMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_members",
+			mcp.WithDescription(t("TOOL_LIST_ORG_MEMBERS_DESCRIPTION", "List the members of an organization. Members of the organization see concealed members too, others only its public members")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_MEMBERS_USER_TITLE", "List organization members"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalUser](),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Only list the members with this role, admin being the owners of the organization"),
+				mcp.Enum("all", "admin", "member"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("Use 2fa_disabled to only list the members without two-factor authentication, which requires being an owner"),
+				mcp.Enum("all", "2fa_disabled"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			members, resp, err := client.Organizations.ListMembers(ctx, org, &github.ListMembersOptions{
+				Role:   role,
+				Filter: filter,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list members of organization %s", org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalUsers(members)), nil
+		}
+}
+
+// InviteOrgMember creates a tool to invite a user to join an organization.
+func InviteOrgMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("invite_org_member",
+			mcp.WithDescription(t("TOOL_INVITE_ORG_MEMBER_DESCRIPTION", "Invite a user, by username or email address, to join an organization and optionally some of its teams. The user becomes a member once they accept the invitation. Requires being an owner of the organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_INVITE_ORG_MEMBER_USER_TITLE", "Invite organization member"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalOrgInvitation](),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("username",
+				mcp.Description("Username of the user to invite. Either username or email is required"),
+			),
+			mcp.WithString("email",
+				mcp.Description("Email address of the person to invite, who may not have a GitHub account yet. Either username or email is required"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role of the user in the organization. Defaults to direct_member"),
+				mcp.Enum("direct_member", "admin", "billing_manager"),
+			),
+			mcp.WithArray("team_slugs",
+				mcp.Description("Slugs of teams of the organization the user joins when accepting the invitation"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			email, err := OptionalParam[string](request, "email")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (username == "") == (email == "") {
+				return mcp.NewToolResultError("exactly one of username or email must be given"), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlugs, err := OptionalStringArrayParam(request, "team_slugs")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.CreateOrgInvitationOptions{
+				Email: ToStringPtr(email),
+				Role:  ToStringPtr(role),
+			}
+			if username != "" {
+				user, resp, err := client.Users.Get(ctx, username)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get user %s", username),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				opts.InviteeID = github.Ptr(user.GetID())
+			}
+			for _, teamSlug := range teamSlugs {
+				team, resp, err := client.Teams.GetTeamBySlug(ctx, org, teamSlug)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get team %s/%s", org, teamSlug),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				opts.TeamID = append(opts.TeamID, team.GetID())
+			}
+
+			invitation, resp, err := client.Organizations.CreateOrgInvitation(ctx, org, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to invite %s to organization %s", username+email, org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalOrgInvitation(invitation)), nil
+		}
+}
+
+// RemoveOrgMember creates a tool to remove a user from an organization.
+func RemoveOrgMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_org_member",
+			mcp.WithDescription(t("TOOL_REMOVE_ORG_MEMBER_DESCRIPTION", "Remove a user from an organization and all of its teams. The user loses access to the repositories of the organization, except those they are an outside collaborator of. Requires being an owner of the organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_ORG_MEMBER_USER_TITLE", "Remove organization member"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the member to remove"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Organizations.RemoveMember(ctx, org, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to remove %s from organization %s", username, org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("%s is no longer a member of organization %s", username, org)), nil
+		}
+}
@@ -0,0 +1,399 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOrg(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrg(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockOrg := &github.Organization{
+		Login:                       github.Ptr("octo-org"),
+		ID:                          github.Ptr(int64(42)),
+		Name:                        github.Ptr("Octo Org"),
+		BillingEmail:                github.Ptr("billing@example.com"),
+		Plan:                        &github.Plan{Name: github.Ptr("team")},
+		PublicRepos:                 github.Ptr(3),
+		TwoFactorRequirementEnabled: github.Ptr(true),
+		DefaultRepoSettings:         github.Ptr("read"),
+		MembersCanCreateRepos:       github.Ptr(false),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful organization fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsByOrg, mockOrg),
+			),
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get organization octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrg(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"org": "octo-org"}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var org MinimalOrganization
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &org))
+			assert.Equal(t, "octo-org", org.Login)
+			assert.Equal(t, "team", org.Plan)
+			assert.Equal(t, "read", org.DefaultRepositoryPermission)
+			assert.Equal(t, "billing@example.com", org.BillingEmail)
+			require.NotNil(t, org.MembersCanCreateRepositories)
+			assert.False(t, *org.MembersCanCreateRepositories)
+		})
+	}
+}
+
+func Test_UpdateOrgSettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateOrgSettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_org_settings", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "default_repository_permission")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "only the given settings are sent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsByOrg,
+					expectRequestBody(t, map[string]any{
+						"description":                     "",
+						"default_repository_permission":   "none",
+						"members_can_create_repositories": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Organization{
+							Login:                 github.Ptr("octo-org"),
+							DefaultRepoSettings:   github.Ptr("none"),
+							MembersCanCreateRepos: github.Ptr(false),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                             "octo-org",
+				"description":                     "",
+				"default_repository_permission":   "none",
+				"members_can_create_repositories": false,
+			},
+		},
+		{
+			name:         "no settings to change",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one setting to change must be given",
+		},
+		{
+			name: "not an owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":  "octo-org",
+				"name": "Octo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update the settings of organization octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateOrgSettings(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var org MinimalOrganization
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &org))
+			assert.Equal(t, "none", org.DefaultRepositoryPermission)
+		})
+	}
+}
+
+func Test_ListOrgMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_members", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsMembersByOrg,
+			expectQueryParams(t, map[string]string{
+				"role":     "admin",
+				"filter":   "2fa_disabled",
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.User{
+					{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1)), HTMLURL: github.Ptr("https://github.com/octocat")},
+				}),
+			),
+		),
+	))
+	_, handler := ListOrgMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":    "octo-org",
+		"role":   "admin",
+		"filter": "2fa_disabled",
+	}))
+	require.NoError(t, err)
+
+	var members []MinimalUser
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &members))
+	require.Len(t, members, 1)
+	assert.Equal(t, "octocat", members[0].Login)
+	assert.Equal(t, "https://github.com/octocat", members[0].ProfileURL)
+}
+
+func Test_InviteOrgMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := InviteOrgMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "invite_org_member", tool.Name)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "email")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slugs")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockInvitation := &github.Invitation{
+		ID:        github.Ptr(int64(99)),
+		Login:     github.Ptr("octocat"),
+		Role:      github.Ptr("direct_member"),
+		Inviter:   &github.User{Login: github.Ptr("admin")},
+		TeamCount: github.Ptr(1),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "invite by username into a team",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUsersByUsername, &github.User{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1))}),
+				mock.WithRequestMatch(mock.GetOrgsTeamsByOrgByTeamSlug, &github.Team{Slug: github.Ptr("core"), ID: github.Ptr(int64(7))}),
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsInvitationsByOrg,
+					expectRequestBody(t, map[string]any{
+						"invitee_id": float64(1),
+						"team_ids":   []any{float64(7)},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockInvitation),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"username":   "octocat",
+				"team_slugs": []interface{}{"core"},
+			},
+		},
+		{
+			name: "invite by email as billing manager",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsInvitationsByOrg,
+					expectRequestBody(t, map[string]any{
+						"email": "octocat@example.com",
+						"role":  "billing_manager",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockInvitation),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":   "octo-org",
+				"email": "octocat@example.com",
+				"role":  "billing_manager",
+			},
+		},
+		{
+			name:         "both username and email",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":      "octo-org",
+				"username": "octocat",
+				"email":    "octocat@example.com",
+			},
+			expectError:    true,
+			expectedErrMsg: "exactly one of username or email must be given",
+		},
+		{
+			name: "unknown team",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrgByTeamSlug,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"email":      "octocat@example.com",
+				"team_slugs": []interface{}{"missing"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get team octo-org/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := InviteOrgMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var invitation MinimalOrgInvitation
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &invitation))
+			assert.Equal(t, int64(99), invitation.ID)
+			assert.Equal(t, "admin", invitation.Inviter)
+		})
+	}
+}
+
+func Test_RemoveOrgMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveOrgMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_org_member", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful removal",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsMembersByOrgByUsername,
+					expectPath(t, "/orgs/octo-org/members/octocat").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "last owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsMembersByOrgByUsername,
+					mockResponse(t, http.StatusForbidden, `{"message": "Cannot remove the last owner"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to remove octocat from organization octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveOrgMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"org":      "octo-org",
+				"username": "octocat",
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			assert.Equal(t, "octocat is no longer a member of organization octo-org", getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package github
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	dateTimeTypes = map[reflect.Type]bool{
+		reflect.TypeOf(time.Time{}):             true,
+		reflect.TypeOf(github.Timestamp{}):      true,
+		reflect.TypeOf(githubv4.DateTime{}):     true,
+		reflect.TypeOf(githubv4.GitTimestamp{}): true,
+	}
+)
+
+// WithJSONOutputSchema declares the output schema of a tool whose results are the JSON encoding of T.
+// Tools created with toolsets.NewServerTool then return that JSON as structured content alongside the text.
+//
+// To keep tool listings small, the schema only describes the fields of T and of its elements, nested objects
+// are left open. Results that aren't JSON objects are described under toolsets.StructuredItemsKey.
+func WithJSONOutputSchema[T any]() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		schema, err := json.Marshal(outputSchemaFor(reflect.TypeOf((*T)(nil)).Elem()))
+		if err != nil {
+			return
+		}
+		tool.RawOutputSchema = schema
+	}
+}
+
+// outputSchemaFor returns the output schema of a tool returning the JSON encoding of t, which must be an object.
+func outputSchemaFor(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	schema := jsonSchemaFor(t, 0)
+	if schema["type"] == "object" {
+		return schema
+	}
+	return map[string]any{
+		"type":       "object",
+		"properties": map[string]any{toolsets.StructuredItemsKey: schema},
+		"required":   []string{toolsets.StructuredItemsKey},
+	}
+}
+
+// jsonSchemaFor describes the JSON encoding of t, only listing the properties of objects up to the given depth.
+func jsonSchemaFor(t reflect.Type, depth int) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if dateTimeTypes[t] {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	if t.Kind() != reflect.String && (t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType)) {
+		return map[string]any{}
+	}
+	if t.Kind() != reflect.String && (t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)) {
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "array", "items": jsonSchemaFor(t.Elem(), depth)}
+	case reflect.Map:
+		return map[string]any{"type": "object"}
+	case reflect.Struct:
+		if depth > 0 {
+			return map[string]any{"type": "object"}
+		}
+		properties := map[string]any{}
+		addStructProperties(t, properties)
+		return map[string]any{"type": "object", "properties": properties}
+	default:
+		return map[string]any{}
+	}
+}
+
+// addStructProperties adds the JSON encoded fields of t to properties, including the ones of embedded structs.
+func addStructProperties(t reflect.Type, properties map[string]any) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			addStructProperties(fieldType, properties)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := jsonSchemaFor(field.Type, 1)
+		// Fields that aren't omitted when empty may be encoded as null
+		if kind := field.Type.Kind(); !strings.Contains(opts, "omitempty") && schema["type"] != nil &&
+			(kind == reflect.Pointer || kind == reflect.Slice || kind == reflect.Map || kind == reflect.Interface) {
+			schema["type"] = []any{schema["type"], "null"}
+		}
+		properties[name] = schema
+	}
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithJSONOutputSchema(t *testing.T) {
+	type embedded struct {
+		ID int64 `json:"id"`
+	}
+	type result struct {
+		embedded
+		Title     string            `json:"title"`
+		Draft     bool              `json:"draft,omitempty"`
+		Labels    []string          `json:"labels"`
+		User      *github.User      `json:"user,omitempty"`
+		CreatedAt *github.Timestamp `json:"created_at,omitempty"`
+		Secret    string            `json:"-"`
+	}
+
+	tests := []struct {
+		name     string
+		option   mcp.ToolOption
+		expected string
+	}{
+		{
+			name:   "object",
+			option: WithJSONOutputSchema[*result](),
+			expected: `{
+				"type": "object",
+				"properties": {
+					"id": {"type": "integer"},
+					"title": {"type": "string"},
+					"draft": {"type": "boolean"},
+					"labels": {"type": ["array", "null"], "items": {"type": "string"}},
+					"user": {"type": "object"},
+					"created_at": {"type": "string", "format": "date-time"}
+				}
+			}`,
+		},
+		{
+			name:   "array",
+			option: WithJSONOutputSchema[[]embedded](),
+			expected: `{
+				"type": "object",
+				"properties": {
+					"items": {"type": "array", "items": {"type": "object", "properties": {"id": {"type": "integer"}}}}
+				},
+				"required": ["items"]
+			}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := mcp.NewTool("test_tool", tc.option)
+			require.NotNil(t, tool.RawOutputSchema)
+			assert.JSONEq(t, tc.expected, string(tool.RawOutputSchema))
+
+			// The schema is listed along with the tool
+			b, err := json.Marshal(tool)
+			require.NoError(t, err)
+			assert.Contains(t, string(b), `"outputSchema":`)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// packageTypes are the types of packages of the GitHub Packages API.
+var packageTypes = []string{"container", "npm", "maven", "rubygems", "nuget", "docker"}
+
+// withPackageOwner adds the parameters naming the owner of packages.
+func withPackageOwner() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Organization or user owning the packages"),
+		)(tool)
+		mcp.WithString("owner_type",
+			mcp.Required(),
+			mcp.Description("Whether owner is an organization or a user"),
+			mcp.Enum("org", "user"),
+		)(tool)
+	}
+}
+
+// withPackage adds the parameters naming a package.
+func withPackage() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		withPackageOwner()(tool)
+		mcp.WithString("package_type",
+			mcp.Required(),
+			mcp.Description("Type of the package"),
+			mcp.Enum(packageTypes...),
+		)(tool)
+		mcp.WithString("package_name",
+			mcp.Required(),
+			mcp.Description("Name of the package, such as the name of a container image without its owner"),
+		)(tool)
+	}
+}
+
+// packageRef names a package, or the packages of an owner when the type and name are empty.
+type packageRef struct {
+	owner       string
+	ownerIsUser bool
+	packageType string
+	packageName string
+}
+
+func packageOwnerFromRequest(request mcp.CallToolRequest) (packageRef, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return packageRef{}, err
+	}
+	ownerType, err := RequiredParam[string](request, "owner_type")
+	if err != nil {
+		return packageRef{}, err
+	}
+	if ownerType != "org" && ownerType != "user" {
+		return packageRef{}, fmt.Errorf("owner_type must be org or user, not %q", ownerType)
+	}
+	return packageRef{owner: owner, ownerIsUser: ownerType == "user"}, nil
+}
+
+func packageFromRequest(request mcp.CallToolRequest) (packageRef, error) {
+	ref, err := packageOwnerFromRequest(request)
+	if err != nil {
+		return packageRef{}, err
+	}
+	if ref.packageType, err = RequiredParam[string](request, "package_type"); err != nil {
+		return packageRef{}, err
+	}
+	if ref.packageName, err = RequiredParam[string](request, "package_name"); err != nil {
+		return packageRef{}, err
+	}
+	return ref, nil
+}
+
+// ListPackages creates a tool to list the packages of an organization or a user.
+func ListPackages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_packages",
+			mcp.WithDescription(t("TOOL_LIST_PACKAGES_DESCRIPTION", "List the packages of an organization or a user in GitHub Packages, such as container images and npm packages")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PACKAGES_USER_TITLE", "List packages"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]*github.Package](),
+			withPackageOwner(),
+			mcp.WithString("package_type",
+				mcp.Required(),
+				mcp.Description("Type of the packages"),
+				mcp.Enum(packageTypes...),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Only list the packages with this visibility"),
+				mcp.Enum("public", "private", "internal"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ref, err := packageOwnerFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageType, err := RequiredParam[string](request, "package_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, err := OptionalParam[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.PackageListOptions{
+				PackageType: github.Ptr(packageType),
+				Visibility:  ToStringPtr(visibility),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var packages []*github.Package
+			var resp *github.Response
+			if ref.ownerIsUser {
+				packages, resp, err = client.Users.ListPackages(ctx, ref.owner, opts)
+			} else {
+				packages, resp, err = client.Organizations.ListPackages(ctx, ref.owner, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list packages",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(packages), nil
+		}
+}
+
+// ListPackageVersions creates a tool to list the versions of a package.
+func ListPackageVersions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_package_versions",
+			mcp.WithDescription(t("TOOL_LIST_PACKAGE_VERSIONS_DESCRIPTION", "List the versions of a package in GitHub Packages, such as the tags of a container image, newest first")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PACKAGE_VERSIONS_USER_TITLE", "List package versions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]*github.PackageVersion](),
+			withPackage(),
+			mcp.WithString("state",
+				mcp.Description("Whether to list the active versions or the deleted versions, which can be restored. Defaults to active"),
+				mcp.Enum("active", "deleted"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ref, err := packageFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.PackageListOptions{
+				State: ToStringPtr(state),
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var versions []*github.PackageVersion
+			var resp *github.Response
+			if ref.ownerIsUser {
+				versions, resp, err = client.Users.PackageGetAllVersions(ctx, ref.owner, ref.packageType, ref.packageName, opts)
+			} else {
+				versions, resp, err = client.Organizations.PackageGetAllVersions(ctx, ref.owner, ref.packageType, ref.packageName, opts)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list package versions",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(versions), nil
+		}
+}
+
+// DeletePackageVersion creates a tool to delete a version of a package.
+func DeletePackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_package_version",
+			mcp.WithDescription(t("TOOL_DELETE_PACKAGE_VERSION_DESCRIPTION", "Delete a version of a package in GitHub Packages. Deleted versions can be restored for 30 days with restore_package_version. The last version of public packages with over 5,000 downloads can't be deleted")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_PACKAGE_VERSION_USER_TITLE", "Delete package version"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[map[string]any](),
+			withPackage(),
+			mcp.WithNumber("version_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the package version, as listed by list_package_versions"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ref, err := packageFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if ref.ownerIsUser {
+				resp, err = client.Users.PackageDeleteVersion(ctx, ref.owner, ref.packageType, ref.packageName, int64(versionID))
+			} else {
+				resp, err = client.Organizations.PackageDeleteVersion(ctx, ref.owner, ref.packageType, ref.packageName, int64(versionID))
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to delete package version",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":    "Package version has been deleted, it can be restored for 30 days",
+				"version_id": versionID,
+			}), nil
+		}
+}
+
+// RestorePackageVersion creates a tool to restore a deleted version of a package.
+func RestorePackageVersion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("restore_package_version",
+			mcp.WithDescription(t("TOOL_RESTORE_PACKAGE_VERSION_DESCRIPTION", "Restore a version of a package in GitHub Packages deleted in the last 30 days")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RESTORE_PACKAGE_VERSION_USER_TITLE", "Restore package version"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[map[string]any](),
+			withPackage(),
+			mcp.WithNumber("version_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the package version, as listed by list_package_versions with state deleted"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ref, err := packageFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			versionID, err := RequiredInt(request, "version_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			if ref.ownerIsUser {
+				resp, err = client.Users.PackageRestoreVersion(ctx, ref.owner, ref.packageType, ref.packageName, int64(versionID))
+			} else {
+				resp, err = client.Organizations.PackageRestoreVersion(ctx, ref.owner, ref.packageType, ref.packageName, int64(versionID))
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to restore package version",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":    "Package version has been restored",
+				"version_id": versionID,
+			}), nil
+		}
+}
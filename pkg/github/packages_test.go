@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListPackages(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPackages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_packages", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "package_type"})
+
+	var queries []string
+	listHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+		_ = json.NewEncoder(w).Encode([]*github.Package{{ID: github.Ptr(int64(1)), Name: github.Ptr("app")}})
+	})
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(mock.GetOrgsPackagesByOrg, listHandler),
+		mock.WithRequestMatchHandler(mock.GetUsersPackagesByUsername, listHandler),
+	))
+	_, handler := ListPackages(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	for _, args := range []map[string]any{
+		{"owner": "octo-org", "owner_type": "org", "package_type": "container", "visibility": "private"},
+		{"owner": "octocat", "owner_type": "user", "package_type": "npm"},
+	} {
+		result, err := handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+
+		var packages []*github.Package
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &packages))
+		require.Len(t, packages, 1)
+		assert.Equal(t, "app", packages[0].GetName())
+	}
+	assert.Equal(t, []string{
+		"/orgs/octo-org/packages?package_type=container&page=1&per_page=30&visibility=private",
+		"/users/octocat/packages?package_type=npm&page=1&per_page=30",
+	}, queries)
+
+	t.Run("owner_type must be org or user", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "octo-org",
+			"owner_type":   "enterprise",
+			"package_type": "container",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, `owner_type must be org or user, not "enterprise"`, getErrorResult(t, result).Text)
+	})
+}
+
+func Test_ListPackageVersions(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPackageVersions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_package_versions", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "package_type", "package_name"})
+
+	var query string
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsPackagesVersionsByOrgByPackageTypeByPackageName,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.Query().Get("state")
+				_ = json.NewEncoder(w).Encode([]*github.PackageVersion{{ID: github.Ptr(int64(7)), Name: github.Ptr("sha256:abc")}})
+			}),
+		),
+	))
+	_, handler := ListPackageVersions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "octo-org",
+		"owner_type":   "org",
+		"package_type": "container",
+		"package_name": "app",
+		"state":        "deleted",
+	}))
+	require.NoError(t, err)
+
+	var versions []*github.PackageVersion
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &versions))
+	require.Len(t, versions, 1)
+	assert.Equal(t, int64(7), versions[0].GetID())
+	assert.Equal(t, "deleted", query)
+}
+
+func Test_DeletePackageVersion(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := DeletePackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_package_version", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "package_type", "package_name", "version_id"})
+
+	tests := []struct {
+		name           string
+		status         int
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "deletes the version",
+			status: http.StatusNoContent,
+		},
+		{
+			name:           "last version of a popular public package",
+			status:         http.StatusBadRequest,
+			expectError:    true,
+			expectedErrMsg: "failed to delete package version",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var deleted string
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteUsersPackagesVersionsByUsernameByPackageTypeByPackageNameByPackageVersionId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						deleted = r.URL.Path
+						w.WriteHeader(tc.status)
+						if tc.status != http.StatusNoContent {
+							_, _ = w.Write([]byte(`{"message": "Publicly visible package versions with more than 5000 downloads cannot be deleted."}`))
+						}
+					}),
+				),
+			))
+			_, handler := DeletePackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "octocat",
+				"owner_type":   "user",
+				"package_type": "npm",
+				"package_name": "hello",
+				"version_id":   float64(7),
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+			assert.Equal(t, "/users/octocat/packages/npm/hello/versions/7", deleted)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			assert.Contains(t, getTextResult(t, result).Text, "Package version has been deleted")
+		})
+	}
+}
+
+func Test_RestorePackageVersion(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := RestorePackageVersion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "restore_package_version", tool.Name)
+
+	var restored string
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostOrgsPackagesVersionsRestoreByOrgByPackageTypeByPackageNameByPackageVersionId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				restored = r.URL.Path
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		),
+	))
+	_, handler := RestorePackageVersion(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "octo-org",
+		"owner_type":   "org",
+		"package_type": "container",
+		"package_name": "app",
+		"version_id":   float64(7),
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, getTextResult(t, result).Text, "Package version has been restored")
+	assert.Equal(t, "/orgs/octo-org/packages/container/app/versions/7/restore", restored)
+}
@@ -0,0 +1,141 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// PaginationHints tells whether a list has more pages than the one a tool returned.
+type PaginationHints struct {
+	HasMore    bool `json:"has_more"`
+	NextPage   int  `json:"next_page,omitempty"`
+	LastPage   int  `json:"last_page,omitempty"`
+	TotalCount *int `json:"total_count,omitempty"`
+}
+
+// paginationTracker records the number of list requests made during a tool call, and the pagination links of
+// the first one.
+type paginationTracker struct {
+	mu       sync.Mutex
+	requests int
+	link     string
+}
+
+type paginationTrackerKey struct{}
+
+// RecordPageLinks records the Link header of a response to a list request made for the tool call in the context.
+// It is a no-op outside of PaginationHintsMiddleware.
+func RecordPageLinks(ctx context.Context, link string) {
+	tracker, ok := ctx.Value(paginationTrackerKey{}).(*paginationTracker)
+	if !ok {
+		return
+	}
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.requests++
+	if tracker.requests == 1 {
+		tracker.link = link
+	}
+}
+
+// parsePageLinks reads the next and last pages from a Link header. Lists paginated with cursors have more
+// results when there is a next link, but have no page numbers.
+func parsePageLinks(link string) PaginationHints {
+	var hints PaginationHints
+	for _, part := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+		if !ok {
+			continue
+		}
+		target = strings.Trim(strings.TrimSpace(target), "<>")
+		page := 0
+		if u, err := url.Parse(target); err == nil {
+			page, _ = strconv.Atoi(u.Query().Get("page"))
+		}
+		for _, param := range strings.Split(params, ";") {
+			switch strings.TrimSpace(param) {
+			case `rel="next"`:
+				hints.HasMore = true
+				hints.NextPage = page
+			case `rel="last"`:
+				hints.LastPage = page
+			}
+		}
+	}
+	return hints
+}
+
+// resultTotalCount returns the total_count or totalCount field of a JSON object result, which search results
+// and some lists have.
+func resultTotalCount(result *mcp.CallToolResult) *int {
+	if len(result.Content) == 0 {
+		return nil
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(text.Text), &fields); err != nil {
+		return nil
+	}
+	for _, key := range []string{"total_count", "totalCount"} {
+		var total int
+		if raw, ok := fields[key]; ok && json.Unmarshal(raw, &total) == nil {
+			return &total
+		}
+	}
+	return nil
+}
+
+// isSingleListCall reports whether the call returns a single page of a list, chosen with its page argument.
+// Calls collecting results across pages with max_results, and tools without a page argument, such as the ones
+// combining several requests, return more than the page their last request listed.
+func isSingleListCall(tool mcp.Tool, request mcp.CallToolRequest) bool {
+	if _, ok := tool.InputSchema.Properties["page"]; !ok {
+		return false
+	}
+	_, aggregated := request.GetArguments()["max_results"]
+	return !aggregated
+}
+
+// PaginationHintsMiddleware adds whether there are more pages to the result metadata of the calls to list tools
+// that listed a single page of a REST API list, so that clients don't have to guess from the number of items.
+func PaginationHintsMiddleware(getTool func(name string) (server.ServerTool, bool)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool, ok := getTool(request.Params.Name)
+			if !ok || !isSingleListCall(tool.Tool, request) {
+				return next(ctx, request)
+			}
+
+			tracker := &paginationTracker{}
+			result, err := next(context.WithValue(ctx, paginationTrackerKey{}, tracker), request)
+			if err != nil || result == nil || result.IsError {
+				return result, err
+			}
+
+			tracker.mu.Lock()
+			requests, link := tracker.requests, tracker.link
+			tracker.mu.Unlock()
+			if requests != 1 {
+				return result, nil
+			}
+
+			hints := parsePageLinks(link)
+			hints.TotalCount = resultTotalCount(result)
+			if result.Meta == nil {
+				result.Meta = make(map[string]any)
+			}
+			result.Meta["pagination"] = hints
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParsePageLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     string
+		expected PaginationHints
+	}{
+		{
+			name: "single page",
+		},
+		{
+			name:     "first of several pages",
+			link:     `<https://api.github.com/repos/o/r/issues?page=2&per_page=30>; rel="next", <https://api.github.com/repos/o/r/issues?page=5&per_page=30>; rel="last"`,
+			expected: PaginationHints{HasMore: true, NextPage: 2, LastPage: 5},
+		},
+		{
+			name:     "last page",
+			link:     `<https://api.github.com/repos/o/r/issues?page=4&per_page=30>; rel="prev", <https://api.github.com/repos/o/r/issues?page=1&per_page=30>; rel="first"`,
+			expected: PaginationHints{},
+		},
+		{
+			name:     "cursor pagination",
+			link:     `<https://api.github.com/orgs/o/audit-log?after=abc&per_page=30>; rel="next"`,
+			expected: PaginationHints{HasMore: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parsePageLinks(tc.link))
+		})
+	}
+}
+
+func Test_PaginationHintsMiddleware(t *testing.T) {
+	total := 120
+	next := `<https://api.github.com/issues?page=3>; rel="next", <https://api.github.com/issues?page=4>; rel="last"`
+	tools := map[string]server.ServerTool{
+		"list_issues": {Tool: mcp.NewTool("list_issues", WithPagination())},
+		"search_code": {Tool: mcp.NewTool("search_code", WithPagination(), WithSearchAggregation())},
+		"batch":       {Tool: mcp.NewTool("batch")},
+	}
+
+	tests := []struct {
+		name          string
+		tool          string
+		arguments     map[string]any
+		links         []string
+		result        *mcp.CallToolResult
+		expectedHints *PaginationHints
+	}{
+		{
+			name:   "results without list requests are unchanged",
+			tool:   "list_issues",
+			result: mcp.NewToolResultText(`{"id":1}`),
+		},
+		{
+			name:   "errors are unchanged",
+			tool:   "list_issues",
+			links:  []string{next},
+			result: mcp.NewToolResultError("failed"),
+		},
+		{
+			name:          "single page lists have no more results",
+			tool:          "list_issues",
+			links:         []string{""},
+			result:        mcp.NewToolResultText(`[{"id":1}]`),
+			expectedHints: &PaginationHints{},
+		},
+		{
+			name:          "the page of the list is described",
+			tool:          "list_issues",
+			links:         []string{next},
+			result:        mcp.NewToolResultText(`[{"id":1}]`),
+			expectedHints: &PaginationHints{HasMore: true, NextPage: 3, LastPage: 4},
+		},
+		{
+			name:          "total counts of search results are included",
+			tool:          "search_code",
+			links:         []string{`<https://api.github.com/search/code?page=2>; rel="next"`},
+			result:        mcp.NewToolResultText(`{"total_count":120,"items":[]}`),
+			expectedHints: &PaginationHints{HasMore: true, NextPage: 2, TotalCount: &total},
+		},
+		{
+			name:   "calls listing several pages are unchanged",
+			tool:   "list_issues",
+			links:  []string{"", next},
+			result: mcp.NewToolResultText(`[{"id":1}]`),
+		},
+		{
+			name:      "aggregated searches are unchanged",
+			tool:      "search_code",
+			arguments: map[string]any{"max_results": 200},
+			links:     []string{next},
+			result:    mcp.NewToolResultText(`{"total_count":120,"items":[]}`),
+		},
+		{
+			name:   "tools without a page argument are unchanged",
+			tool:   "batch",
+			links:  []string{next},
+			result: mcp.NewToolResultText(`{"steps":[]}`),
+		},
+		{
+			name:   "unknown tools are unchanged",
+			tool:   "unknown",
+			links:  []string{next},
+			result: mcp.NewToolResultText(`[{"id":1}]`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			contentLen := len(tc.result.Content)
+			getTool := func(name string) (server.ServerTool, bool) {
+				tool, ok := tools[name]
+				return tool, ok
+			}
+			handler := PaginationHintsMiddleware(getTool)(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				for _, link := range tc.links {
+					RecordPageLinks(ctx, link)
+				}
+				return tc.result, nil
+			})
+
+			request := createMCPRequest(tc.arguments)
+			request.Params.Name = tc.tool
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			// The hints are only added to the metadata, leaving the content for clients parsing it
+			assert.Len(t, result.Content, contentLen)
+			if tc.expectedHints == nil {
+				assert.Nil(t, result.Meta)
+				return
+			}
+			assert.Equal(t, *tc.expectedHints, result.Meta["pagination"])
+		})
+	}
+}
+
+func Test_RecordPageLinksOutsideMiddleware(_ *testing.T) {
+	// Recording without the middleware must not panic
+	RecordPageLinks(context.Background(), `<https://api.github.com/issues?page=2>; rel="next"`)
+}
@@ -0,0 +1,324 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxInstallationRepositoryPages bounds the pages of repositories listed for a GitHub App installation. Installations
+// with more repositories are treated as having access to any repository.
+const maxInstallationRepositoryPages = 10
+
+// deniedPermissionTTL is how long tools stay failed on a resource after GitHub forbade them on it, so that
+// permissions granted meanwhile are picked up.
+const deniedPermissionTTL = 10 * time.Minute
+
+// unsupportedToolsets lists the toolsets whose endpoints don't accept the tokens of an authentication mode.
+var unsupportedToolsets = map[string][]string{
+	AuthModeGitHubAppInstallation:          {"notifications", "gists"},
+	AuthModeFineGrainedPersonalAccessToken: {"notifications"},
+}
+
+// unsupportedTools lists the tools whose endpoints need a user, which installation tokens don't act as.
+var unsupportedTools = map[string][]string{
+	AuthModeGitHubAppInstallation: {"get_me", "star_repository", "unstar_repository", "watch_repository", "unwatch_repository"},
+}
+
+// UnsupportedTools returns the tools of the group that can't work with tokens of an authentication mode, sorted by
+// name, so that they can be hidden from clients.
+func UnsupportedTools(tsg *toolsets.ToolsetGroup, authMode string) []string {
+	names := append([]string{}, unsupportedTools[authMode]...)
+	for _, name := range unsupportedToolsets[authMode] {
+		toolset, err := tsg.GetToolset(name)
+		if err != nil {
+			continue
+		}
+		for _, tool := range toolset.GetReadTools() {
+			names = append(names, tool.Tool.Name)
+		}
+		for _, tool := range toolset.GetWriteTools() {
+			names = append(names, tool.Tool.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CredentialGrants tracks what the credential of the server can't do, so that tools whose calls are doomed fail at
+// once instead of after a request to GitHub. Fine-grained personal access tokens and GitHub Apps have permissions
+// that GitHub doesn't list, so the permissions they lack are learned from the requests GitHub forbids, along with
+// the tools that made them. GitHub App installations are given their permissions when exchanging tokens, so a
+// permission forbidden to a tool on one repository is known to be lacking on all of them, and their repositories
+// are listed on first use.
+type CredentialGrants struct {
+	authMode string
+
+	mu sync.Mutex
+	// denied maps resources, which are owner/repo, an organization, or empty for the account, to the permissions
+	// GitHub last forbade requests to them for, each being the alternatives it accepted.
+	denied map[string]map[string]time.Time
+	// toolPermissions maps tools to the permissions GitHub accepted for requests they made and forbade.
+	toolPermissions map[string]map[string]bool
+	// repositories are the repositories of a GitHub App installation, nil until listed or when unrestricted.
+	repositories       map[string]bool
+	repositoriesListed bool
+	// installationPermissions returns the permissions granted to a GitHub App installation, nil until known.
+	installationPermissions func() map[string]string
+}
+
+// NewCredentialGrants creates the grants of a credential with the authentication mode.
+func NewCredentialGrants(authMode string) *CredentialGrants {
+	return &CredentialGrants{
+		authMode:        authMode,
+		denied:          make(map[string]map[string]time.Time),
+		toolPermissions: make(map[string]map[string]bool),
+	}
+}
+
+// SetInstallationPermissions sets how the permissions granted to the GitHub App installation are got, as returned
+// by the token exchange.
+func (g *CredentialGrants) SetInstallationPermissions(permissions func() map[string]string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.installationPermissions = permissions
+}
+
+type permissionCallKey struct{}
+
+// RecordDenial records that GitHub forbade a request for lack of one of the accepted permissions, which are
+// separated by semicolons as in the X-Accepted-GitHub-Permissions header.
+func (g *CredentialGrants) RecordDenial(ctx context.Context, path string, accepted string) {
+	permission := normalizePermissions(accepted)
+	if permission == "" {
+		return
+	}
+	resource := resourceOfPath(path)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.denied[resource] == nil {
+		g.denied[resource] = make(map[string]time.Time)
+	}
+	g.denied[resource][permission] = time.Now()
+	LogToClient(ctx, mcp.LoggingLevelWarning, ClientLoggerPermissions,
+		fmt.Sprintf("GitHub denied the request to %s, the credential lacks the %s permission", path, permission),
+		map[string]any{"path": path, "permission": permission})
+	if tool, ok := ctx.Value(permissionCallKey{}).(string); ok {
+		if g.toolPermissions[tool] == nil {
+			g.toolPermissions[tool] = make(map[string]bool)
+		}
+		g.toolPermissions[tool][permission] = true
+	}
+}
+
+// Check returns an error describing why the credential can't be used for a call of a tool, or nil when it may be.
+// Only calls of tools that write are checked against the repositories of GitHub App installations, as
+// installations can read public repositories they aren't installed on. The client lists those repositories.
+func (g *CredentialGrants) Check(ctx context.Context, getClient GetClientFn, tool string, readOnly bool, args map[string]any) error {
+	resource := resourceOfArgs(args)
+	if owner, repo, ok := strings.Cut(resource, "/"); ok && !readOnly && !g.hasRepository(ctx, getClient, resource) {
+		return fmt.Errorf("the GitHub App installation doesn't have access to the repository %s/%s", owner, repo)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var installationPermissions map[string]string
+	if g.installationPermissions != nil {
+		installationPermissions = g.installationPermissions()
+	}
+	var denied, lacking []string
+	for permission := range g.toolPermissions[tool] {
+		if deniedAt, ok := g.denied[resource][permission]; ok && time.Since(deniedAt) < deniedPermissionTTL {
+			denied = append(denied, permission)
+		} else if installationPermissions != nil && !grantsPermission(installationPermissions, permission) {
+			lacking = append(lacking, permission)
+		}
+	}
+	switch {
+	case len(denied) > 0:
+		sort.Strings(denied)
+		target := "the account"
+		if resource != "" {
+			target = resource
+		}
+		return fmt.Errorf("%s was forbidden earlier on %s because the credential lacks the %s permission, so it isn't called again for %s. Grant the permission to the token or GitHub App to use it",
+			tool, target, strings.Join(denied, " or "), deniedPermissionTTL)
+	case len(lacking) > 0:
+		sort.Strings(lacking)
+		return fmt.Errorf("%s was forbidden earlier because the GitHub App installation lacks the %s permission, which it isn't granted on any repository. Grant the permission to the GitHub App to use it",
+			tool, strings.Join(lacking, " or "))
+	default:
+		return nil
+	}
+}
+
+// permissionLevels orders the access levels of GitHub App permissions.
+var permissionLevels = map[string]int{"read": 1, "write": 2, "admin": 3}
+
+// grantsPermission reports whether granted permissions, such as {"issues": "write"}, include one of the accepted
+// alternatives, such as "issues=write; pull_requests=write".
+func grantsPermission(granted map[string]string, accepted string) bool {
+	for _, alternative := range strings.Split(accepted, "; ") {
+		name, level, _ := strings.Cut(alternative, "=")
+		if grantedLevel, ok := granted[name]; ok && permissionLevels[grantedLevel] >= permissionLevels[level] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRepository reports whether a GitHub App installation has access to a repository, listing the repositories of
+// the installation on first use. Other credentials, and installations whose repositories can't be listed, are
+// assumed to have access.
+func (g *CredentialGrants) hasRepository(ctx context.Context, getClient GetClientFn, fullName string) bool {
+	if g.authMode != AuthModeGitHubAppInstallation {
+		return true
+	}
+
+	g.mu.Lock()
+	listed, repositories := g.repositoriesListed, g.repositories
+	g.mu.Unlock()
+	if !listed {
+		repositories = g.listInstallationRepositories(ctx, getClient)
+	}
+	return repositories == nil || repositories[fullName]
+}
+
+// listInstallationRepositories lists the repositories of the GitHub App installation, returning nil when it can't.
+// Listings that fail for a transient reason are tried again on the next call.
+func (g *CredentialGrants) listInstallationRepositories(ctx context.Context, getClient GetClientFn) map[string]bool {
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil
+	}
+
+	repositories := make(map[string]bool)
+	opts := &github.ListOptions{PerPage: 100}
+	for page := 0; ; page++ {
+		if page == maxInstallationRepositoryPages {
+			repositories = nil
+			break
+		}
+		list, resp, err := client.Apps.ListRepos(ctx, opts)
+		if err != nil {
+			if resp != nil && resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+				// The token can't list repositories, possibly because it isn't an installation token after all
+				g.mu.Lock()
+				g.repositoriesListed = true
+				g.mu.Unlock()
+			}
+			return nil
+		}
+		_ = resp.Body.Close()
+		for _, repo := range list.Repositories {
+			repositories[strings.ToLower(repo.GetFullName())] = true
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.repositories, g.repositoriesListed = repositories, true
+	return repositories
+}
+
+// normalizePermissions sorts the alternatives of accepted permissions, so that the same ones are always recorded
+// the same way.
+func normalizePermissions(accepted string) string {
+	var permissions []string
+	for _, permission := range strings.Split(accepted, ";") {
+		if permission = strings.TrimSpace(permission); permission != "" {
+			permissions = append(permissions, permission)
+		}
+	}
+	sort.Strings(permissions)
+	return strings.Join(permissions, "; ")
+}
+
+// resourceOfPath returns the repository or organization a REST API request is for, in lowercase, or an empty string
+// for requests about the account.
+func resourceOfPath(path string) string {
+	// GitHub Enterprise Server serves the API under /api/v3
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api/v3"), "/"), "/")
+	switch {
+	case len(parts) >= 3 && parts[0] == "repos":
+		return strings.ToLower(parts[1] + "/" + parts[2])
+	case len(parts) >= 2 && parts[0] == "orgs":
+		return strings.ToLower(parts[1])
+	default:
+		return ""
+	}
+}
+
+// resourceOfArgs returns the repository or organization the arguments of a tool call are for, in lowercase, the
+// same way as resourceOfPath.
+func resourceOfArgs(args map[string]any) string {
+	owner, _ := args["owner"].(string)
+	repo, _ := args["repo"].(string)
+	org, _ := args["org"].(string)
+	switch {
+	case owner != "" && repo != "":
+		return strings.ToLower(owner + "/" + repo)
+	case org != "":
+		return strings.ToLower(org)
+	default:
+		return strings.ToLower(owner)
+	}
+}
+
+// permissionGate holds what's needed to gate the tool calls made during a call, such as the steps of a batch.
+type permissionGate struct {
+	grants    *CredentialGrants
+	getClient GetClientFn
+}
+
+type permissionGateKey struct{}
+
+// PermissionGateMiddleware fails the calls of tools that the credential can't be used for without calling them:
+// tools that write called for repositories a GitHub App installation doesn't have access to, and tools called again
+// after GitHub forbade them for lack of a permission. Calls are marked so that forbidden requests are recorded along
+// with the tool that made them. getTool looks up the tools, to tell whether they only read.
+func PermissionGateMiddleware(grants *CredentialGrants, getClient GetClientFn, getTool func(name string) (server.ServerTool, bool)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool, ok := getTool(request.Params.Name)
+			if !ok {
+				tool = server.ServerTool{Tool: mcp.Tool{Name: request.Params.Name}}
+			}
+			ctx = context.WithValue(ctx, permissionGateKey{}, permissionGate{grants: grants, getClient: getClient})
+			ctx, err := gateToolCall(ctx, tool.Tool, request.GetArguments())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// gateToolCall checks that the credential can be used for a call of the tool, returning the context of the call
+// marked as made by the tool. Calls made without the permission gate aren't checked.
+func gateToolCall(ctx context.Context, tool mcp.Tool, arguments map[string]any) (context.Context, error) {
+	gate, ok := ctx.Value(permissionGateKey{}).(permissionGate)
+	if !ok {
+		return ctx, nil
+	}
+	readOnly := tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+	if err := gate.grants.Check(ctx, gate.getClient, tool.Name, readOnly, arguments); err != nil {
+		LogToClient(ctx, mcp.LoggingLevelWarning, ClientLoggerPermissions, err.Error(), map[string]any{"tool": tool.Name})
+		return ctx, err
+	}
+	return context.WithValue(ctx, permissionCallKey{}, tool.Name), nil
+}
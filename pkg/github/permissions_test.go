@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UnsupportedTools(t *testing.T) {
+	newTool := func(name string, readOnly bool) server.ServerTool {
+		return toolsets.NewServerTool(mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(readOnly)})), nil)
+	}
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("context", "Context").AddReadTools(newTool("get_me", true)))
+	tsg.AddToolset(toolsets.NewToolset("notifications", "Notifications").
+		AddReadTools(newTool("list_notifications", true)).
+		AddWriteTools(newTool("dismiss_notification", false)))
+
+	assert.Empty(t, UnsupportedTools(tsg, AuthModePersonalAccessToken))
+	assert.Equal(t, []string{"dismiss_notification", "list_notifications"}, UnsupportedTools(tsg, AuthModeFineGrainedPersonalAccessToken))
+	assert.Equal(t, []string{"dismiss_notification", "get_me", "list_notifications", "star_repository", "unstar_repository", "unwatch_repository", "watch_repository"},
+		UnsupportedTools(tsg, AuthModeGitHubAppInstallation))
+}
+
+func Test_ResourceOfPath(t *testing.T) {
+	assert.Equal(t, "owner/repo", resourceOfPath("/repos/Owner/Repo/hooks"))
+	assert.Equal(t, "owner/repo", resourceOfPath("/api/v3/repos/owner/repo/issues/1"))
+	assert.Equal(t, "org", resourceOfPath("/orgs/Org/members"))
+	assert.Equal(t, "", resourceOfPath("/user/repos"))
+
+	assert.Equal(t, "owner/repo", resourceOfArgs(map[string]any{"owner": "Owner", "repo": "Repo"}))
+	assert.Equal(t, "org", resourceOfArgs(map[string]any{"org": "Org"}))
+	assert.Equal(t, "owner", resourceOfArgs(map[string]any{"owner": "owner"}))
+	assert.Equal(t, "", resourceOfArgs(map[string]any{}))
+}
+
+func Test_PermissionGateMiddleware(t *testing.T) {
+	grants := NewCredentialGrants(AuthModeFineGrainedPersonalAccessToken)
+	calls := 0
+	handler := PermissionGateMiddleware(grants, stubGetClientFn(github.NewClient(nil)), noPermissionGateTools)(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		// The transport records the forbidden request of the call
+		grants.RecordDenial(ctx, "/repos/owner/repo/hooks", "repository_hooks=read")
+		return mcp.NewToolResultError("forbidden"), nil
+	})
+	request := func(name string, args map[string]any) mcp.CallToolRequest {
+		r := createMCPRequest(args)
+		r.Params.Name = name
+		return r
+	}
+
+	result, err := handler(context.Background(), request("list_hooks", map[string]any{"owner": "owner", "repo": "repo"}))
+	require.NoError(t, err)
+	assert.Equal(t, "forbidden", getErrorResult(t, result).Text)
+	assert.Equal(t, 1, calls)
+
+	// Calling the tool again on the same repository fails without a request
+	result, err = handler(context.Background(), request("list_hooks", map[string]any{"owner": "Owner", "repo": "Repo"}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "list_hooks was forbidden earlier on owner/repo because the credential lacks the repository_hooks=read permission")
+	assert.Equal(t, 1, calls)
+
+	// Other repositories and tools are still called
+	_, err = handler(context.Background(), request("list_hooks", map[string]any{"owner": "owner", "repo": "other"}))
+	require.NoError(t, err)
+	_, err = handler(context.Background(), request("list_issues", map[string]any{"owner": "owner", "repo": "repo"}))
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func Test_PermissionGateMiddlewareInstallationRepositories(t *testing.T) {
+	listed := 0
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetInstallationRepositories,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				listed++
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"total_count":1,"repositories":[{"full_name":"owner/Repo"}]}`))
+			}),
+		),
+	))
+	tools := map[string]server.ServerTool{
+		"get_file_contents": toolsets.NewServerTool(mcp.NewTool("get_file_contents", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})), nil),
+		"create_issue":      toolsets.NewServerTool(mcp.NewTool("create_issue", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(false)})), nil),
+	}
+	grants := NewCredentialGrants(AuthModeGitHubAppInstallation)
+	handler := PermissionGateMiddleware(grants, stubGetClientFn(client), func(name string) (server.ServerTool, bool) {
+		tool, ok := tools[name]
+		return tool, ok
+	})(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	request := func(name string, args map[string]any) mcp.CallToolRequest {
+		r := createMCPRequest(args)
+		r.Params.Name = name
+		return r
+	}
+
+	result, err := handler(context.Background(), request("create_issue", map[string]any{"owner": "owner", "repo": "repo"}))
+	require.NoError(t, err)
+	assert.Equal(t, "ok", getTextResult(t, result).Text)
+
+	result, err = handler(context.Background(), request("create_issue", map[string]any{"owner": "owner", "repo": "private"}))
+	require.NoError(t, err)
+	assert.Equal(t, "the GitHub App installation doesn't have access to the repository owner/private", getErrorResult(t, result).Text)
+
+	// Public repositories the installation isn't installed on can still be read
+	result, err = handler(context.Background(), request("get_file_contents", map[string]any{"owner": "octo-org", "repo": "public"}))
+	require.NoError(t, err)
+	assert.Equal(t, "ok", getTextResult(t, result).Text)
+
+	// Calls without a repository aren't checked
+	result, err = handler(context.Background(), request("create_issue", map[string]any{"query": "is:open"}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	assert.Equal(t, 1, listed)
+}
+
+func Test_PermissionGateMiddlewareInstallationPermissions(t *testing.T) {
+	grants := NewCredentialGrants(AuthModeGitHubAppInstallation)
+	grants.SetInstallationPermissions(func() map[string]string {
+		return map[string]string{"contents": "write", "issues": "read"}
+	})
+	calls := 0
+	handler := PermissionGateMiddleware(grants, stubGetClientFn(github.NewClient(nil)), noPermissionGateTools)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		if request.Params.Name == "create_issue" {
+			grants.RecordDenial(ctx, "/repos/owner/repo/issues", "issues=write")
+			return mcp.NewToolResultError("forbidden"), nil
+		}
+		// Denied for a permission the installation has, such as on a repository with stricter rules
+		grants.RecordDenial(ctx, "/repos/owner/repo/contents/a", "contents=read")
+		return mcp.NewToolResultError("forbidden"), nil
+	})
+	request := func(name string, args map[string]any) mcp.CallToolRequest {
+		r := createMCPRequest(args)
+		r.Params.Name = name
+		return r
+	}
+
+	_, err := handler(context.Background(), request("create_issue", map[string]any{"owner": "owner", "repo": "repo"}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	// The installation lacks the permission on every repository
+	result, err := handler(context.Background(), request("create_issue", map[string]any{"owner": "owner", "repo": "other"}))
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "create_issue was forbidden earlier because the GitHub App installation lacks the issues=write permission")
+	assert.Equal(t, 1, calls)
+
+	// Permissions the installation has are only failed on the repository they were denied on
+	_, err = handler(context.Background(), request("create_or_update_file", map[string]any{"owner": "owner", "repo": "repo"}))
+	require.NoError(t, err)
+	_, err = handler(context.Background(), request("create_or_update_file", map[string]any{"owner": "owner", "repo": "other"}))
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func Test_GrantsPermission(t *testing.T) {
+	granted := map[string]string{"contents": "write", "issues": "read", "administration": "admin"}
+	assert.True(t, grantsPermission(granted, "contents=read"))
+	assert.True(t, grantsPermission(granted, "contents=write"))
+	assert.False(t, grantsPermission(granted, "issues=write"))
+	assert.True(t, grantsPermission(granted, "issues=write; pull_requests=read; contents=write"))
+	assert.True(t, grantsPermission(granted, "administration=write"))
+	assert.False(t, grantsPermission(granted, "pull_requests=read"))
+}
+
+// noPermissionGateTools looks up no tools, so that every call is gated as that of a tool that writes.
+func noPermissionGateTools(string) (server.ServerTool, bool) {
+	return server.ServerTool{}, false
+}
@@ -0,0 +1,42 @@
+package github
+
+import "sync/atomic"
+
+// Policy holds the settings restricting what tool calls may do and what their results show, which can change
+// while the server runs.
+type Policy struct {
+	// RestrictToRoots fails the tool calls naming repositories outside the roots of the client
+	RestrictToRoots bool
+
+	// ConfirmTools lists the tools whose calls the user of the client must confirm to run
+	ConfirmTools []string
+
+	// AllowRepositoryDeletion lists the repositories, as owner/repo or owner/*, that delete_repository may delete
+	AllowRepositoryDeletion []string
+
+	// PrivacyMode strips emails, avatar URLs and other personal data of users from tool results
+	PrivacyMode bool
+}
+
+// PolicyStore holds the current policy of the server. The policy is swapped as a whole when the configuration is
+// reloaded, so that a call sees either the former or the new policy but never a mix of both.
+type PolicyStore struct {
+	current atomic.Pointer[Policy]
+}
+
+// NewPolicyStore creates a PolicyStore holding the policy.
+func NewPolicyStore(policy Policy) *PolicyStore {
+	s := &PolicyStore{}
+	s.Store(policy)
+	return s
+}
+
+// Load returns the current policy, which must not be modified.
+func (s *PolicyStore) Load() *Policy {
+	return s.current.Load()
+}
+
+// Store replaces the current policy.
+func (s *PolicyStore) Store(policy Policy) {
+	s.current.Store(&policy)
+}
@@ -0,0 +1,120 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RedactedEmail replaces the email addresses found in tool results when privacy mode is on.
+const RedactedEmail = "[redacted email]"
+
+// personalFields lists the user fields removed in privacy mode, normalized to lower case without underscores
+// so that both REST and GraphQL names match. They are only removed from user shaped objects, and when they hold
+// a string.
+var personalFields = map[string]bool{
+	"email":           true,
+	"avatarurl":       true,
+	"gravatarid":      true,
+	"twitterusername": true,
+	"blog":            true,
+	"company":         true,
+	"location":        true,
+	"bio":             true,
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// normalizeFieldName lowers a field name and drops its underscores, so that REST and GraphQL names compare equal.
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// isPersonObject reports whether a JSON object describes a person: a GitHub user or organization, which has a
+// login along with an avatar URL or a type, or the author or committer of a commit, which has a name, an email
+// and a date. Other objects, such as repositories, keep the fields sharing the name of a personal field.
+func isPersonObject(v map[string]any) bool {
+	fields := make(map[string]bool, len(v))
+	for k := range v {
+		fields[normalizeFieldName(k)] = true
+	}
+	if fields["login"] && (fields["avatarurl"] || fields["type"]) {
+		return true
+	}
+	return fields["name"] && fields["email"] && fields["date"]
+}
+
+// RedactPersonalDataMiddleware strips emails, avatar URLs and other personal fields of users from JSON tool results
+// when the policy enables privacy mode, and redacts the email addresses left anywhere else in them, such as in
+// commit trailers. Structured content is redacted the same way.
+func RedactPersonalDataMiddleware(policies *PolicyStore) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			privacyMode := policies.Load().PrivacyMode
+			result, err := next(ctx, request)
+			if err != nil || result == nil || !privacyMode {
+				return result, err
+			}
+
+			for i, c := range result.Content {
+				text, ok := c.(mcp.TextContent)
+				if !ok {
+					continue
+				}
+				text.Text = redactPersonalData(text.Text)
+				result.Content[i] = text
+			}
+			if result.StructuredContent != nil {
+				result.StructuredContent = redactValue(result.StructuredContent)
+			}
+			return result, nil
+		}
+	}
+}
+
+// redactPersonalData redacts a JSON document, redacting emails only from text that isn't JSON.
+func redactPersonalData(text string) string {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return emailPattern.ReplaceAllString(text, RedactedEmail)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(redactValue(v)); err != nil {
+		return emailPattern.ReplaceAllString(text, RedactedEmail)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		person := isPersonObject(v)
+		for k, field := range v {
+			if _, ok := field.(string); ok && person && personalFields[normalizeFieldName(k)] {
+				delete(v, k)
+				continue
+			}
+			v[k] = redactValue(field)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	case string:
+		return emailPattern.ReplaceAllString(v, RedactedEmail)
+	default:
+		return v
+	}
+}
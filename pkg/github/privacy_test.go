@@ -0,0 +1,101 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RedactPersonalDataMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *mcp.CallToolResult
+		expected string
+	}{
+		{
+			name:     "strips personal fields of REST users",
+			result:   mcp.NewToolResultText(`{"login":"octocat","id":1,"email":"octocat@github.com","avatar_url":"https://avatars.githubusercontent.com/u/1","gravatar_id":"","company":"GitHub","location":"San Francisco","blog":"https://github.blog","twitter_username":"github","bio":"Hi"}`),
+			expected: `{"id":1,"login":"octocat"}`,
+		},
+		{
+			name:     "strips personal fields of GraphQL users",
+			result:   mcp.NewToolResultText(`{"author":{"login":"octocat","avatarUrl":"https://avatars.githubusercontent.com/u/1"}}`),
+			expected: `{"author":{"login":"octocat"}}`,
+		},
+		{
+			name:     "strips commit author emails in lists",
+			result:   mcp.NewToolResultText(`[{"sha":"abc","commit":{"author":{"name":"Octo Cat","email":"octocat@github.com","date":"2025-01-01T00:00:00Z"}}}]`),
+			expected: `[{"commit":{"author":{"date":"2025-01-01T00:00:00Z","name":"Octo Cat"}},"sha":"abc"}]`,
+		},
+		{
+			name:     "redacts emails in other fields",
+			result:   mcp.NewToolResultText(`{"message":"Fix bug\n\nSigned-off-by: Octo Cat <octocat@github.com>"}`),
+			expected: `{"message":"Fix bug\n\nSigned-off-by: Octo Cat <[redacted email]>"}`,
+		},
+		{
+			name:     "keeps fields of other objects sharing a personal field name",
+			result:   mcp.NewToolResultText(`{"location":{"path":"main.go","start_line":3},"count":12345678901234567890}`),
+			expected: `{"count":12345678901234567890,"location":{"path":"main.go","start_line":3}}`,
+		},
+		{
+			name:     "keeps fields of repositories sharing a personal field name",
+			result:   mcp.NewToolResultText(`{"full_name":"octo/hello","company":"Octo Corp","location":"eu-west","bio":"Sample repository","owner":{"login":"octocat","type":"User","avatar_url":"https://avatars.githubusercontent.com/u/1","company":"GitHub"}}`),
+			expected: `{"bio":"Sample repository","company":"Octo Corp","full_name":"octo/hello","location":"eu-west","owner":{"login":"octocat","type":"User"}}`,
+		},
+		{
+			name:     "redacts the emails left in objects other than users",
+			result:   mcp.NewToolResultText(`{"sha":"abc","email":"octocat@github.com"}`),
+			expected: `{"email":"[redacted email]","sha":"abc"}`,
+		},
+		{
+			name:     "redacts emails in plain text",
+			result:   mcp.NewToolResultText("From: octocat@github.com\nSubject: Hello"),
+			expected: "From: [redacted email]\nSubject: Hello",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := RedactPersonalDataMiddleware(NewPolicyStore(Policy{PrivacyMode: true}))(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tc.result, nil
+			})
+
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expected, textContent.Text)
+		})
+	}
+}
+
+func Test_RedactPersonalDataMiddleware_StructuredContent(t *testing.T) {
+	handler := RedactPersonalDataMiddleware(NewPolicyStore(Policy{PrivacyMode: true}))(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result := mcp.NewToolResultText(`{"login":"octocat","type":"User","email":"octocat@github.com"}`)
+		result.StructuredContent = map[string]any{"login": "octocat", "type": "User", "email": "octocat@github.com"}
+		return result, nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"login": "octocat", "type": "User"}, result.StructuredContent)
+}
+
+func Test_RedactPersonalDataMiddleware_PolicyChange(t *testing.T) {
+	policies := NewPolicyStore(Policy{})
+	handler := RedactPersonalDataMiddleware(policies)(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(`{"login":"octocat","type":"User","email":"octocat@github.com"}`), nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"login":"octocat","type":"User","email":"octocat@github.com"}`, getTextResult(t, result).Text)
+
+	// Calls made once privacy mode is enabled are redacted, without recreating the middleware
+	policies.Store(Policy{PrivacyMode: true})
+	result, err = handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"login":"octocat","type":"User"}`, getTextResult(t, result).Text)
+}
@@ -0,0 +1,127 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type progressTokenKey struct{}
+
+// ContextWithProgressToken returns a context carrying the progress token of the tool call being served.
+func ContextWithProgressToken(ctx context.Context, token mcp.ProgressToken) context.Context {
+	return context.WithValue(ctx, progressTokenKey{}, token)
+}
+
+// ProgressTokenFromContext returns the progress token of the tool call being served, if the client sent one.
+func ProgressTokenFromContext(ctx context.Context) (mcp.ProgressToken, bool) {
+	token := ctx.Value(progressTokenKey{})
+	return token, token != nil
+}
+
+// ProgressTokenMiddleware stores the progress token sent by the client in the context of the tool call,
+// so that code running further down the stack, such as HTTP transports, can report progress.
+func ProgressTokenMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Meta != nil && request.Params.Meta.ProgressToken != nil {
+			ctx = ContextWithProgressToken(ctx, request.Params.Meta.ProgressToken)
+		}
+		return next(ctx, request)
+	}
+}
+
+// PartialContentField is the field of progress notifications holding the partial result of a tool call,
+// as content in the same format as the content of tool results.
+const PartialContentField = "partialContent"
+
+// NotifyProgress sends a progress notification for the tool call in the context.
+// It is a no-op when the client didn't ask for progress or there is no session to notify.
+func NotifyProgress(ctx context.Context, progress float64, total float64, message string) {
+	notifyProgress(ctx, progress, total, message, nil)
+}
+
+// NotifyPartialResult sends a progress notification carrying a part of the result of the tool call in the context,
+// such as the result of one of the steps it runs, so that clients can start working on it before the call completes.
+// Partial results that aren't strings are sent as JSON. The final result of the call still holds every part.
+func NotifyPartialResult(ctx context.Context, progress float64, total float64, message string, partial any) {
+	if _, ok := ProgressTokenFromContext(ctx); !ok {
+		return
+	}
+	text, ok := partial.(string)
+	if !ok {
+		data, err := json.Marshal(partial)
+		if err != nil {
+			notifyProgress(ctx, progress, total, message, nil)
+			return
+		}
+		text = string(data)
+	}
+	notifyProgress(ctx, progress, total, message, []mcp.Content{mcp.NewTextContent(text)})
+}
+
+// downloadProgressInterval bounds how often downloads report their progress, so that large downloads don't flood
+// the client with notifications while short ones send none.
+const downloadProgressInterval = time.Second
+
+// NewProgressReader returns a reader that reports the bytes read from r as progress of the tool call in the
+// context, such as while downloading logs. total is the size of r in bytes, zero or less when it isn't known.
+func NewProgressReader(ctx context.Context, r io.Reader, total int64, message string) io.Reader {
+	if _, ok := ProgressTokenFromContext(ctx); !ok {
+		return r
+	}
+	return &progressReader{ctx: ctx, reader: r, total: total, message: message, reported: time.Now()}
+}
+
+type progressReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	total    int64
+	message  string
+	read     int64
+	reported time.Time
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if n > 0 && time.Since(r.reported) >= downloadProgressInterval {
+		r.reported = time.Now()
+		message := fmt.Sprintf("%s: %d KiB", r.message, r.read/1024)
+		if r.total > 0 {
+			message = fmt.Sprintf("%s: %d of %d KiB", r.message, r.read/1024, r.total/1024)
+		}
+		NotifyProgress(r.ctx, float64(r.read), float64(r.total), message)
+	}
+	return n, err
+}
+
+func notifyProgress(ctx context.Context, progress float64, total float64, message string, partial []mcp.Content) {
+	token, ok := ProgressTokenFromContext(ctx)
+	if !ok {
+		return
+	}
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return
+	}
+
+	params := map[string]any{
+		"progressToken": token,
+		"progress":      progress,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	if partial != nil {
+		params[PartialContentField] = partial
+	}
+	_ = s.SendNotificationToClient(ctx, "notifications/progress", params)
+}
@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProgressTokenMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		meta          *mcp.Meta
+		expectedOK    bool
+		expectedToken mcp.ProgressToken
+	}{
+		{
+			name:       "no meta",
+			meta:       nil,
+			expectedOK: false,
+		},
+		{
+			name:       "meta without progress token",
+			meta:       &mcp.Meta{},
+			expectedOK: false,
+		},
+		{
+			name:          "meta with progress token",
+			meta:          &mcp.Meta{ProgressToken: "abc"},
+			expectedOK:    true,
+			expectedToken: "abc",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(map[string]any{})
+			request.Params.Meta = tc.meta
+
+			var called bool
+			handler := ProgressTokenMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				called = true
+				token, ok := ProgressTokenFromContext(ctx)
+				assert.Equal(t, tc.expectedOK, ok)
+				assert.Equal(t, tc.expectedToken, token)
+				// Without a server in the context this must be a no-op rather than panic
+				NotifyProgress(ctx, 1, 2, "halfway")
+				return mcp.NewToolResultText("ok"), nil
+			})
+
+			_, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			assert.True(t, called)
+		})
+	}
+}
+
+type notificationSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *notificationSession) Initialize()       {}
+func (s *notificationSession) Initialized() bool { return true }
+func (s *notificationSession) SessionID() string { return "test" }
+func (s *notificationSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func Test_NotifyPartialResult(t *testing.T) {
+	s := NewServer("test", server.WithToolHandlerMiddleware(ProgressTokenMiddleware))
+	s.AddTool(mcp.NewTool("stream"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		NotifyPartialResult(ctx, 1, 2, "first", map[string]any{"number": 1})
+		NotifyPartialResult(ctx, 2, 2, "second", "plain text")
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	session := &notificationSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	ctx := s.WithContext(context.Background(), session)
+
+	// Without a progress token nothing is streamed
+	response := s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"stream"}}`))
+	_, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response, got %#v", response)
+	assert.Empty(t, session.notifications)
+
+	response = s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"stream","_meta":{"progressToken":"abc"}}}`))
+	_, ok = response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response, got %#v", response)
+	require.Len(t, session.notifications, 2)
+
+	expected := []string{`{"number":1}`, "plain text"}
+	for i, text := range expected {
+		notification := <-session.notifications
+		assert.Equal(t, "notifications/progress", notification.Method)
+		params := notification.Params.AdditionalFields
+		assert.Equal(t, "abc", params["progressToken"])
+		assert.Equal(t, float64(i+1), params["progress"])
+		assert.Equal(t, float64(2), params["total"])
+		assert.Equal(t, []mcp.Content{mcp.NewTextContent(text)}, params[PartialContentField])
+	}
+}
+
+func Test_NewProgressReader(t *testing.T) {
+	// Without a progress token the reader is returned as is
+	plain := strings.NewReader("content")
+	assert.Same(t, plain, NewProgressReader(context.Background(), plain, 7, "Downloading"))
+
+	s := NewServer("test", server.WithToolHandlerMiddleware(ProgressTokenMiddleware))
+	s.AddTool(mcp.NewTool("download"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		reader := NewProgressReader(ctx, strings.NewReader(strings.Repeat("a", 4096)), 4096, "Downloading logs")
+		// Report on the first read rather than after the interval
+		reader.(*progressReader).reported = time.Time{}
+		data := make([]byte, 1024)
+		read := 0
+		for {
+			n, err := reader.Read(data)
+			read += n
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("read %d bytes", read)), nil
+	})
+
+	session := &notificationSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	ctx := s.WithContext(context.Background(), session)
+	response := s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"download","_meta":{"progressToken":"abc"}}}`))
+	_, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a successful response, got %#v", response)
+
+	// Reads following the first one are within the interval and aren't reported
+	require.Len(t, session.notifications, 1)
+	notification := <-session.notifications
+	params := notification.Params.AdditionalFields
+	assert.Equal(t, float64(4096), params["total"])
+	assert.Equal(t, "Downloading logs: 1 of 4 KiB", params["message"])
+}
@@ -2,10 +2,13 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v74/github"
@@ -17,14 +20,35 @@ import (
 	"github.com/github/github-mcp-server/pkg/translations"
 )
 
+// PullRequestDetails is the output type of get_pull_request: the pull request along with the merge state status
+// GitHub only reports through GraphQL.
+type PullRequestDetails struct {
+	*github.PullRequest
+	MergeStateStatus string `json:"merge_state_status,omitempty"`
+}
+
+// mergeStateStatusQuery gets the merge state status of a pull request, which tells why it can or can't be merged.
+type mergeStateStatusQuery struct {
+	Repository struct {
+		PullRequest struct {
+			MergeStateStatus githubv4.String
+		} `graphql:"pullRequest(number: $pullNumber)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// mergeabilityRetryDelays are the waits before getting a pull request again while GitHub computes whether it can be
+// merged, which it does in a background job started by the first request.
+var mergeabilityRetryDelays = []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+
 // GetPullRequest creates a tool to get details of a specific pull request.
-func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+func GetPullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DESCRIPTION", "Get details of a specific pull request in a GitHub repository.")),
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DESCRIPTION", "Get details of a specific pull request in a GitHub repository, including whether it can be merged (mergeable, mergeable_state and merge_state_status).")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_PULL_REQUEST_USER_TITLE", "Get pull request details"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[PullRequestDetails](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -56,7 +80,7 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			pr, resp, err := getPullRequestMergeability(ctx, client, owner, repo, pullNumber, mergeabilityRetryDelays)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to get pull request",
@@ -74,7 +98,24 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(pr)
+			details := PullRequestDetails{PullRequest: pr}
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+			var query mergeStateStatusQuery
+			vars := map[string]any{
+				"owner":      githubv4.String(owner),
+				"repo":       githubv4.String(repo),
+				"pullNumber": githubv4.Int(pullNumber), // #nosec G115 - pull request numbers are always small positive integers
+			}
+			// The merge state status only adds to the mergeability, so the pull request is returned without it
+			// when it can't be queried, e.g. by tokens without GraphQL access
+			if err := gqlClient.Query(ctx, &query, vars); err == nil {
+				details.MergeStateStatus = string(query.Repository.PullRequest.MergeStateStatus)
+			}
+
+			r, err := json.Marshal(details)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -83,6 +124,25 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 		}
 }
 
+// getPullRequestMergeability gets a pull request, getting it again after each of the delays while GitHub is still
+// computing whether an open pull request can be merged. The mergeability is left unknown when it isn't computed in
+// time.
+func getPullRequestMergeability(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, delays []time.Duration) (*github.PullRequest, *github.Response, error) {
+	for attempt := 0; ; attempt++ {
+		pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+		if err != nil || pr.Mergeable != nil || pr.GetState() != "open" || attempt == len(delays) {
+			return pr, resp, err
+		}
+		_ = resp.Body.Close()
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(delays[attempt]):
+		}
+	}
+}
+
 // CreatePullRequest creates a tool to create a new pull request.
 func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("create_pull_request",
@@ -91,6 +151,7 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 				Title:        t("TOOL_CREATE_PULL_REQUEST_USER_TITLE", "Open new pull request"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[MinimalResponse](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -177,6 +238,15 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			}
 			pr, resp, err := client.PullRequests.Create(ctx, owner, repo, newPR)
 			if err != nil {
+				// A pull request for the same branches may have been created by an earlier attempt
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					if existing := findExistingPullRequest(ctx, client, owner, repo, head, base, title); existing != nil {
+						return MarshalledTextResult(MinimalResponse{
+							ID:  fmt.Sprintf("%d", existing.GetID()),
+							URL: existing.GetHTMLURL(),
+						}), nil
+					}
+				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to create pull request",
 					resp,
@@ -216,6 +286,7 @@ func UpdatePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t tra
 				Title:        t("TOOL_UPDATE_PULL_REQUEST_USER_TITLE", "Edit pull request"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[MinimalResponse](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -493,6 +564,7 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_PULL_REQUESTS_USER_TITLE", "List pull requests"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.PullRequest](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -520,6 +592,7 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -605,6 +678,7 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_MERGE_PULL_REQUEST_USER_TITLE", "Merge pull request"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[*github.PullRequestMergeResult](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -698,9 +772,10 @@ func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperF
 				Title:        t("TOOL_SEARCH_PULL_REQUESTS_USER_TITLE", "Search pull requests"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.IssuesSearchResult](),
 			mcp.WithString("query",
 				mcp.Required(),
-				mcp.Description("Search query using GitHub pull request search syntax"),
+				mcp.Description("Search query using GitHub pull request search syntax. Date qualifiers such as created:>-7d or merged:>\"last monday\" accept relative times."),
 			),
 			mcp.WithString("owner",
 				mcp.Description("Optional repository owner. If provided with repo, only pull requests for this repository are listed."),
@@ -729,6 +804,8 @@ func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithSearchAggregation(),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return searchHandler(ctx, getClient, request, "pr", "failed to search pull requests")
@@ -743,6 +820,7 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				Title:        t("TOOL_GET_PULL_REQUEST_FILES_USER_TITLE", "Get pull request files"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.CommitFile](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -818,6 +896,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 				Title:        t("TOOL_GET_PULL_REQUEST_STATUS_USER_TITLE", "Get pull request status checks"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.CombinedStatus](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -977,14 +1056,95 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 		}
 }
 
-// GetPullRequestReviewComments creates a tool to get the review comments on a pull request.
-func GetPullRequestReviewComments(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+// ReviewThreadComment is a comment of a pull request review thread.
+type ReviewThreadComment struct {
+	ID         string    `json:"id"`
+	DatabaseID int64     `json:"database_id"`
+	Author     string    `json:"author,omitempty"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+	URL        string    `json:"html_url"`
+}
+
+// ReviewThread is a thread of review comments anchored to the lines of a file of a pull request.
+type ReviewThread struct {
+	ID                string                `json:"id"`
+	Path              string                `json:"path"`
+	SubjectType       string                `json:"subject_type"`
+	Line              *int                  `json:"line,omitempty"`
+	StartLine         *int                  `json:"start_line,omitempty"`
+	OriginalLine      *int                  `json:"original_line,omitempty"`
+	OriginalStartLine *int                  `json:"original_start_line,omitempty"`
+	DiffSide          string                `json:"diff_side,omitempty"`
+	DiffHunk          string                `json:"diff_hunk,omitempty"`
+	IsResolved        bool                  `json:"is_resolved"`
+	ResolvedBy        string                `json:"resolved_by,omitempty"`
+	IsOutdated        bool                  `json:"is_outdated"`
+	TotalComments     int                   `json:"total_comments"`
+	Comments          []ReviewThreadComment `json:"comments"`
+}
+
+// reviewThreadsQuery lists the review threads of a pull request along with their first comments.
+type reviewThreadsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				Nodes []struct {
+					ID                githubv4.ID
+					Path              githubv4.String
+					SubjectType       githubv4.String
+					Line              *githubv4.Int
+					StartLine         *githubv4.Int
+					OriginalLine      *githubv4.Int
+					OriginalStartLine *githubv4.Int
+					DiffSide          githubv4.String
+					IsResolved        githubv4.Boolean
+					IsOutdated        githubv4.Boolean
+					ResolvedBy        *struct {
+						Login githubv4.String
+					}
+					Comments struct {
+						TotalCount githubv4.Int
+						Nodes      []struct {
+							ID         githubv4.ID
+							DatabaseID githubv4.Int `graphql:"databaseId"`
+							Author     *struct {
+								Login githubv4.String
+							}
+							Body      githubv4.String
+							DiffHunk  githubv4.String
+							CreatedAt githubv4.DateTime
+							URL       githubv4.URI
+						}
+					} `graphql:"comments(first: 100)"`
+				}
+				PageInfo struct {
+					HasNextPage githubv4.Boolean
+					EndCursor   githubv4.String
+				}
+			} `graphql:"reviewThreads(first: 100, after: $after)"`
+		} `graphql:"pullRequest(number: $pullNumber)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// optionalGQLInt converts a nullable GraphQL integer.
+func optionalGQLInt(i *githubv4.Int) *int {
+	if i == nil {
+		return nil
+	}
+	v := int(*i)
+	return &v
+}
+
+// GetPullRequestReviewComments creates a tool to get the review comments on a pull request, grouped into threads.
+func GetPullRequestReviewComments(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_review_comments",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEW_COMMENTS_DESCRIPTION", "Get pull request review comments. They are comments made on a portion of the unified diff during a pull request review. These are different from commit comments and issue comments in a pull request.")),
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEW_COMMENTS_DESCRIPTION", "Get pull request review comments, grouped into the threads they were made in. Each thread is anchored to a file and lines of the unified diff and tells whether it was resolved or is outdated. These are different from commit comments and issue comments in a pull request.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_PULL_REQUEST_REVIEW_COMMENTS_USER_TITLE", "Get pull request review comments"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]ReviewThread](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -997,6 +1157,9 @@ func GetPullRequestReviewComments(getClient GetClientFn, t translations.Translat
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			mcp.WithBoolean("include_resolved",
+				mcp.Description("Include resolved threads (default true). Set to false to only get the feedback still to address."),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1011,41 +1174,368 @@ func GetPullRequestReviewComments(getClient GetClientFn, t translations.Translat
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			includeResolved, err := OptionalBoolParamWithDefault(request, "include_resolved", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
-			opts := &github.PullRequestListCommentsOptions{
-				ListOptions: github.ListOptions{
-					PerPage: 100,
-				},
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			threads, err := listReviewThreads(ctx, client, owner, repo, pullNumber, includeResolved)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request review comments", err), nil
+			}
+
+			return MarshalledTextResult(threads), nil
+		}
+}
+
+// listReviewThreads lists the review threads of a pull request, leaving out the resolved ones unless includeResolved.
+func listReviewThreads(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int, includeResolved bool) ([]ReviewThread, error) {
+	threads := []ReviewThread{}
+	vars := map[string]interface{}{
+		"owner":      githubv4.String(owner),
+		"repo":       githubv4.String(repo),
+		"pullNumber": githubv4.Int(pullNumber),
+		"after":      (*githubv4.String)(nil),
+	}
+	for {
+		var q reviewThreadsQuery
+		if err := client.Query(ctx, &q, vars); err != nil {
+			return nil, err
+		}
+
+		for _, node := range q.Repository.PullRequest.ReviewThreads.Nodes {
+			if bool(node.IsResolved) && !includeResolved {
+				continue
+			}
+			thread := ReviewThread{
+				ID:                fmt.Sprint(node.ID),
+				Path:              string(node.Path),
+				SubjectType:       strings.ToLower(string(node.SubjectType)),
+				Line:              optionalGQLInt(node.Line),
+				StartLine:         optionalGQLInt(node.StartLine),
+				OriginalLine:      optionalGQLInt(node.OriginalLine),
+				OriginalStartLine: optionalGQLInt(node.OriginalStartLine),
+				DiffSide:          strings.ToLower(string(node.DiffSide)),
+				IsResolved:        bool(node.IsResolved),
+				IsOutdated:        bool(node.IsOutdated),
+				TotalComments:     int(node.Comments.TotalCount),
+				Comments:          make([]ReviewThreadComment, 0, len(node.Comments.Nodes)),
+			}
+			if node.ResolvedBy != nil {
+				thread.ResolvedBy = string(node.ResolvedBy.Login)
+			}
+			for i, c := range node.Comments.Nodes {
+				// Replies share the hunk of the comment starting the thread
+				if i == 0 {
+					thread.DiffHunk = string(c.DiffHunk)
+				}
+				comment := ReviewThreadComment{
+					ID:         fmt.Sprint(c.ID),
+					DatabaseID: int64(c.DatabaseID),
+					Body:       string(c.Body),
+					CreatedAt:  c.CreatedAt.Time,
+					URL:        c.URL.String(),
+				}
+				if c.Author != nil {
+					comment.Author = string(c.Author.Login)
+				}
+				thread.Comments = append(thread.Comments, comment)
+			}
+			threads = append(threads, thread)
+		}
+
+		pageInfo := q.Repository.PullRequest.ReviewThreads.PageInfo
+		if !pageInfo.HasNextPage {
+			break
+		}
+		NotifyProgress(ctx, float64(len(threads)), 0, fmt.Sprintf("Listed %d review threads", len(threads)))
+		vars["after"] = pageInfo.EndCursor
+	}
+
+	return threads, nil
+}
+
+// ReplyToReviewComment creates a tool to reply to a review comment in its thread.
+func ReplyToReviewComment(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("reply_to_review_comment",
+			mcp.WithDescription(t("TOOL_REPLY_TO_REVIEW_COMMENT_DESCRIPTION", "Reply to a pull request review comment, adding the reply to the thread of the comment on the diff rather than as a top-level comment of the pull request. Replies to replies are added to the same thread.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REPLY_TO_REVIEW_COMMENT_USER_TITLE", "Reply to review comment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[*github.PullRequestComment](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("in_reply_to",
+				mcp.Required(),
+				mcp.Description("The ID of the review comment to reply to (the database_id of a comment returned by get_pull_request_review_comments)"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("The text of the reply"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			inReplyTo, err := RequiredInt(request, "in_reply_to")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			comments, resp, err := client.PullRequests.ListComments(ctx, owner, repo, pullNumber, opts)
+			comment, resp, err := client.PullRequests.CreateCommentInReplyTo(ctx, owner, repo, pullNumber, body, int64(inReplyTo))
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get pull request review comments",
+					"failed to reply to review comment",
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
+			if resp.StatusCode != http.StatusCreated {
 				body, err := io.ReadAll(resp.Body)
 				if err != nil {
 					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request review comments: %s", string(body))), nil
+				return mcp.NewToolResultError(fmt.Sprintf("failed to reply to review comment: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(comments)
+			return MarshalledTextResult(comment), nil
+		}
+}
+
+// AppliedSuggestions describes the commit applying suggestions of review comments.
+type AppliedSuggestions struct {
+	SHA      string   `json:"sha"`
+	URL      string   `json:"html_url"`
+	Files    []string `json:"files"`
+	Comments []int64  `json:"comments"`
+}
+
+// ApplySuggestion creates a tool to commit the changes suggested in review comments to the head branch of a pull request.
+func ApplySuggestion(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("apply_suggestion",
+			mcp.WithDescription(t("TOOL_APPLY_SUGGESTION_DESCRIPTION", "Accept the suggested changes of one or more pull request review comments, committing them together to the head branch of the pull request. The comments must contain a suggestion block, be on the new version of the file and not be outdated, and their suggestions must not change the same lines.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_APPLY_SUGGESTION_USER_TITLE", "Apply review suggestions"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[AppliedSuggestions](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithArray("comment_ids",
+				mcp.Required(),
+				mcp.Description("The IDs of the review comments whose suggestions to apply (the database_id of comments returned by get_pull_request_review_comments)"),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithString("commit_message",
+				mcp.Description("Commit message (default: \"Apply suggestions from code review\")"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commentIDs, err := OptionalIntArrayParam(request, "comment_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(commentIDs) == 0 {
+				return mcp.NewToolResultError("missing required parameter: comment_ids"), nil
+			}
+			message, err := OptionalParam[string](request, "commit_message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if message == "" {
+				message = "Apply suggestions from code review"
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			if pr.GetHead().GetRepo() == nil {
+				return mcp.NewToolResultError("the head repository of the pull request no longer exists"), nil
+			}
+			headRepo := pr.GetHead().GetRepo()
+			headSHA := pr.GetHead().GetSHA()
+
+			// Group the suggestions by file, keeping the order files were first commented on
+			var paths []string
+			suggestionsByPath := map[string][]suggestion{}
+			for _, id := range commentIDs {
+				comment, resp, err := client.PullRequests.GetComment(ctx, owner, repo, int64(id))
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get review comment %d", id),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+
+				if !strings.HasSuffix(comment.GetPullRequestURL(), fmt.Sprintf("/pulls/%d", pullNumber)) {
+					return mcp.NewToolResultError(fmt.Sprintf("review comment %d is not on pull request #%d", id, pullNumber)), nil
+				}
+				lines, ok := parseSuggestion(comment.GetBody())
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("review comment %d has no suggestion", id)), nil
+				}
+				if comment.Line == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("review comment %d is outdated", id)), nil
+				}
+				if comment.GetSide() == "LEFT" {
+					return mcp.NewToolResultError(fmt.Sprintf("review comment %d is on the previous version of the file", id)), nil
+				}
+
+				s := suggestion{
+					CommentID: int64(id),
+					Path:      comment.GetPath(),
+					StartLine: comment.GetLine(),
+					EndLine:   comment.GetLine(),
+					Lines:     lines,
+				}
+				if comment.StartLine != nil {
+					s.StartLine = comment.GetStartLine()
+				}
+				if _, ok := suggestionsByPath[s.Path]; !ok {
+					paths = append(paths, s.Path)
+				}
+				suggestionsByPath[s.Path] = append(suggestionsByPath[s.Path], s)
+			}
+
+			additions := make([]githubv4.FileAddition, 0, len(paths))
+			for _, path := range paths {
+				file, _, resp, err := client.Repositories.GetContents(ctx, headRepo.GetOwner().GetLogin(), headRepo.GetName(), path, &github.RepositoryContentGetOptions{Ref: headSHA})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get %s", path),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				if file == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is not a file", path)), nil
+				}
+				content, err := file.GetContent()
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+				}
+
+				updated, err := applySuggestions(content, suggestionsByPath[path])
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				additions = append(additions, githubv4.FileAddition{
+					Path:     githubv4.String(path),
+					Contents: githubv4.Base64String(base64.StdEncoding.EncodeToString([]byte(updated))),
+				})
+			}
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var mutation struct {
+				CreateCommitOnBranch struct {
+					Commit struct {
+						OID githubv4.GitObjectID `graphql:"oid"`
+						URL githubv4.URI
+					}
+				} `graphql:"createCommitOnBranch(input: $input)"`
+			}
+			if err := gqlClient.Mutate(ctx, &mutation, githubv4.CreateCommitOnBranchInput{
+				Branch: githubv4.CommittableBranch{
+					RepositoryNameWithOwner: githubv4.NewString(githubv4.String(headRepo.GetFullName())),
+					BranchName:              githubv4.NewString(githubv4.String(pr.GetHead().GetRef())),
+				},
+				Message:         githubv4.CommitMessage{Headline: githubv4.String(message)},
+				ExpectedHeadOid: githubv4.GitObjectID(headSHA),
+				FileChanges:     &githubv4.FileChanges{Additions: &additions},
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to commit suggestions", err), nil
+			}
+
+			applied := make([]int64, 0, len(commentIDs))
+			for _, id := range commentIDs {
+				applied = append(applied, int64(id))
+			}
+			return MarshalledTextResult(AppliedSuggestions{
+				SHA:      string(mutation.CreateCommitOnBranch.Commit.OID),
+				URL:      mutation.CreateCommitOnBranch.Commit.URL.String(),
+				Files:    paths,
+				Comments: applied,
+			}), nil
 		}
 }
 
@@ -1057,6 +1547,7 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 				Title:        t("TOOL_GET_PULL_REQUEST_REVIEWS_USER_TITLE", "Get pull request reviews"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.PullRequestReview](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1731,7 +2222,7 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 
 func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_diff",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the diff of a pull request.")),
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the diff of a pull request. Diffs longer than max_lines are summarized per file instead, use get_pull_request_file_diff to get the diff of the files you need.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_PULL_REQUEST_DIFF_USER_TITLE", "Get pull request diff"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -1748,6 +2239,10 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			mcp.WithNumber("max_lines",
+				mcp.Description(fmt.Sprintf("Maximum number of lines of the diff to return whole, longer diffs are summarized per file (default %d)", DefaultMaxDiffLines)),
+				mcp.Min(1),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1758,40 +2253,120 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			maxLines, err := OptionalIntParamWithDefault(request, "max_lines", DefaultMaxDiffLines)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub client: %v", err)), nil
 			}
 
-			raw, resp, err := client.PullRequests.GetRaw(
-				ctx,
-				params.Owner,
-				params.Repo,
-				int(params.PullNumber),
-				github.RawOptions{Type: github.Diff},
-			)
+			diff, errResult, err := getPullRequestRawDiff(ctx, client, params.Owner, params.Repo, int(params.PullNumber))
+			if errResult != nil || err != nil {
+				return errResult, err
+			}
+
+			totalLines := strings.Count(diff, "\n") + 1
+			if totalLines <= maxLines {
+				// Return the raw response
+				return mcp.NewToolResultText(diff), nil
+			}
+			summary := summarizeDiff(splitDiff(diff), totalLines, fmt.Sprintf(
+				"The diff has %d lines, more than max_lines (%d). Use get_pull_request_file_diff to get the diff of individual files.",
+				totalLines, maxLines))
+			return MarshalledTextResult(summary), nil
+		}
+}
+
+// GetPullRequestFileDiff creates a tool to get the diff of a single file of a pull request.
+func GetPullRequestFileDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_file_diff",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_FILE_DIFF_DESCRIPTION", "Get the diff of a single file of a pull request. Use this when the diff of a pull request is too long to review at once.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_FILE_DIFF_USER_TITLE", "Get pull request file diff"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path of the file, or its previous path when it was renamed"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
 			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get pull request diff",
-					resp,
-					err,
-				), nil
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request diff: %s", string(body))), nil
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			defer func() { _ = resp.Body.Close() }()
+			diff, errResult, err := getPullRequestRawDiff(ctx, client, owner, repo, pullNumber)
+			if errResult != nil || err != nil {
+				return errResult, err
+			}
+
+			file, ok := findDiffFile(splitDiff(diff), path)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("file %s isn't changed by pull request #%d", path, pullNumber)), nil
+			}
+			return mcp.NewToolResultText(file.diff), nil
+		}
+}
+
+// getPullRequestRawDiff fetches the diff of a pull request, returning a tool result when it can't be fetched.
+func getPullRequestRawDiff(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (string, *mcp.CallToolResult, error) {
+	raw, resp, err := client.PullRequests.GetRaw(
+		ctx,
+		owner,
+		repo,
+		pullNumber,
+		github.RawOptions{Type: github.Diff},
+	)
+	if err != nil {
+		return "", ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get pull request diff",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
 
-			// Return the raw response
-			return mcp.NewToolResultText(string(raw)), nil
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read response body: %w", err)
 		}
+		return "", mcp.NewToolResultError(fmt.Sprintf("failed to get pull request diff: %s", string(body))), nil
+	}
+	return raw, nil, nil
 }
 
 // RequestCopilotReview creates a tool to request a Copilot review for a pull request.
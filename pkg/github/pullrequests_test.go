@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -21,7 +22,7 @@ import (
 func Test_GetPullRequest(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := GetPullRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetPullRequest(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "get_pull_request", tool.Name)
@@ -48,8 +49,26 @@ func Test_GetPullRequest(t *testing.T) {
 		User: &github.User{
 			Login: github.Ptr("testuser"),
 		},
+		Mergeable:      github.Ptr(true),
+		MergeableState: github.Ptr("clean"),
 	}
 
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			mergeStateStatusQuery{},
+			map[string]any{
+				"owner":      githubv4.String("owner"),
+				"repo":       githubv4.String("repo"),
+				"pullNumber": githubv4.Int(42),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{"mergeStateStatus": "CLEAN"},
+				},
+			}),
+		),
+	))
+
 	tests := []struct {
 		name           string
 		mockedClient   *http.Client
@@ -99,7 +118,7 @@ func Test_GetPullRequest(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetPullRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetPullRequest(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -123,17 +142,60 @@ func Test_GetPullRequest(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedPR github.PullRequest
+			var returnedPR PullRequestDetails
 			err = json.Unmarshal([]byte(textContent.Text), &returnedPR)
 			require.NoError(t, err)
 			assert.Equal(t, *tc.expectedPR.Number, *returnedPR.Number)
 			assert.Equal(t, *tc.expectedPR.Title, *returnedPR.Title)
 			assert.Equal(t, *tc.expectedPR.State, *returnedPR.State)
 			assert.Equal(t, *tc.expectedPR.HTMLURL, *returnedPR.HTMLURL)
+			assert.Equal(t, *tc.expectedPR.Mergeable, *returnedPR.Mergeable)
+			assert.Equal(t, *tc.expectedPR.MergeableState, *returnedPR.MergeableState)
+			assert.Equal(t, "CLEAN", returnedPR.MergeStateStatus)
 		})
 	}
 }
 
+func Test_GetPullRequestMergeability(t *testing.T) {
+	computing := &github.PullRequest{Number: github.Ptr(42), State: github.Ptr("open"), MergeableState: github.Ptr("unknown")}
+	computed := &github.PullRequest{Number: github.Ptr(42), State: github.Ptr("open"), Mergeable: github.Ptr(false), MergeableState: github.Ptr("dirty")}
+	delays := []time.Duration{0, 0}
+
+	t.Run("waits for the mergeability to be computed", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, computing, computing, computed),
+		))
+
+		pr, _, err := getPullRequestMergeability(context.Background(), client, "owner", "repo", 42, delays)
+		require.NoError(t, err)
+		require.NotNil(t, pr.Mergeable)
+		assert.False(t, *pr.Mergeable)
+		assert.Equal(t, "dirty", pr.GetMergeableState())
+	})
+
+	t.Run("gives up after the last delay", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, computing, computing, computing),
+		))
+
+		pr, _, err := getPullRequestMergeability(context.Background(), client, "owner", "repo", 42, delays)
+		require.NoError(t, err)
+		assert.Nil(t, pr.Mergeable)
+		assert.Equal(t, "unknown", pr.GetMergeableState())
+	})
+
+	t.Run("doesn't wait for closed pull requests", func(t *testing.T) {
+		closed := &github.PullRequest{Number: github.Ptr(42), State: github.Ptr("closed")}
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, closed),
+		))
+
+		pr, _, err := getPullRequestMergeability(context.Background(), client, "owner", "repo", 42, delays)
+		require.NoError(t, err)
+		assert.Nil(t, pr.Mergeable)
+	})
+}
+
 func Test_UpdatePullRequest(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1554,8 +1616,7 @@ func Test_UpdatePullRequestBranch(t *testing.T) {
 
 func Test_GetPullRequestComments(t *testing.T) {
 	// Verify tool definition once
-	mockClient := github.NewClient(nil)
-	tool, _ := GetPullRequestReviewComments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetPullRequestReviewComments(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "get_pull_request_review_comments", tool.Name)
@@ -1563,71 +1624,208 @@ func Test_GetPullRequestComments(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "include_resolved")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
 
-	// Setup mock PR comments for success case
-	mockComments := []*github.PullRequestComment{
-		{
-			ID:      github.Ptr(int64(101)),
-			Body:    github.Ptr("This looks good"),
-			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#discussion_r101"),
-			User: &github.User{
-				Login: github.Ptr("reviewer1"),
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	firstPage := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"pullRequest": map[string]any{
+				"reviewThreads": map[string]any{
+					"nodes": []map[string]any{
+						{
+							"id":                "PRRT_1",
+							"path":              "file1.go",
+							"subjectType":       "LINE",
+							"line":              12,
+							"startLine":         10,
+							"originalLine":      12,
+							"originalStartLine": 10,
+							"diffSide":          "RIGHT",
+							"isResolved":        false,
+							"isOutdated":        false,
+							"resolvedBy":        nil,
+							"comments": map[string]any{
+								"totalCount": 2,
+								"nodes": []map[string]any{
+									{
+										"id":         "PRRC_101",
+										"databaseId": 101,
+										"author":     map[string]any{"login": "reviewer1"},
+										"body":       "Please fix this",
+										"diffHunk":   "@@ -10,3 +10,3 @@",
+										"createdAt":  createdAt.Format(time.RFC3339),
+										"url":        "https://github.com/owner/repo/pull/42#discussion_r101",
+									},
+									{
+										"id":         "PRRC_102",
+										"databaseId": 102,
+										"author":     map[string]any{"login": "author"},
+										"body":       "Done",
+										"diffHunk":   "@@ -10,3 +10,3 @@",
+										"createdAt":  createdAt.Add(time.Hour).Format(time.RFC3339),
+										"url":        "https://github.com/owner/repo/pull/42#discussion_r102",
+									},
+								},
+							},
+						},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage": true,
+						"endCursor":   "cursor1",
+					},
+				},
 			},
-			Path:      github.Ptr("file1.go"),
-			Position:  github.Ptr(5),
-			CommitID:  github.Ptr("abcdef123456"),
-			CreatedAt: &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
-			UpdatedAt: &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
 		},
-		{
-			ID:      github.Ptr(int64(102)),
-			Body:    github.Ptr("Please fix this"),
-			HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42#discussion_r102"),
-			User: &github.User{
-				Login: github.Ptr("reviewer2"),
+	})
+	secondPage := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"pullRequest": map[string]any{
+				"reviewThreads": map[string]any{
+					"nodes": []map[string]any{
+						{
+							"id":                "PRRT_2",
+							"path":              "file2.go",
+							"subjectType":       "FILE",
+							"line":              nil,
+							"startLine":         nil,
+							"originalLine":      nil,
+							"originalStartLine": nil,
+							"diffSide":          "RIGHT",
+							"isResolved":        true,
+							"isOutdated":        true,
+							"resolvedBy":        map[string]any{"login": "author"},
+							"comments": map[string]any{
+								"totalCount": 1,
+								"nodes": []map[string]any{
+									{
+										"id":         "PRRC_103",
+										"databaseId": 103,
+										"author":     nil,
+										"body":       "Rename this file",
+										"diffHunk":   "",
+										"createdAt":  createdAt.Format(time.RFC3339),
+										"url":        "https://github.com/owner/repo/pull/42#discussion_r103",
+									},
+								},
+							},
+						},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage": false,
+						"endCursor":   "cursor2",
+					},
+				},
+			},
+		},
+	})
+
+	vars := func(after any) map[string]any {
+		return map[string]any{
+			"owner":      githubv4.String("owner"),
+			"repo":       githubv4.String("repo"),
+			"pullNumber": githubv4.Int(42),
+			"after":      after,
+		}
+	}
+	pagedClient := func() *http.Client {
+		return githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(reviewThreadsQuery{}, vars((*githubv4.String)(nil)), firstPage),
+			githubv4mock.NewQueryMatcher(reviewThreadsQuery{}, vars(githubv4.String("cursor1")), secondPage),
+		)
+	}
+
+	unresolvedThread := ReviewThread{
+		ID:                "PRRT_1",
+		Path:              "file1.go",
+		SubjectType:       "line",
+		Line:              github.Ptr(12),
+		StartLine:         github.Ptr(10),
+		OriginalLine:      github.Ptr(12),
+		OriginalStartLine: github.Ptr(10),
+		DiffSide:          "right",
+		DiffHunk:          "@@ -10,3 +10,3 @@",
+		TotalComments:     2,
+		Comments: []ReviewThreadComment{
+			{
+				ID:         "PRRC_101",
+				DatabaseID: 101,
+				Author:     "reviewer1",
+				Body:       "Please fix this",
+				CreatedAt:  createdAt,
+				URL:        "https://github.com/owner/repo/pull/42#discussion_r101",
+			},
+			{
+				ID:         "PRRC_102",
+				DatabaseID: 102,
+				Author:     "author",
+				Body:       "Done",
+				CreatedAt:  createdAt.Add(time.Hour),
+				URL:        "https://github.com/owner/repo/pull/42#discussion_r102",
+			},
+		},
+	}
+	resolvedThread := ReviewThread{
+		ID:            "PRRT_2",
+		Path:          "file2.go",
+		SubjectType:   "file",
+		DiffSide:      "right",
+		IsResolved:    true,
+		ResolvedBy:    "author",
+		IsOutdated:    true,
+		TotalComments: 1,
+		Comments: []ReviewThreadComment{
+			{
+				ID:         "PRRC_103",
+				DatabaseID: 103,
+				Body:       "Rename this file",
+				CreatedAt:  createdAt,
+				URL:        "https://github.com/owner/repo/pull/42#discussion_r103",
 			},
-			Path:      github.Ptr("file2.go"),
-			Position:  github.Ptr(10),
-			CommitID:  github.Ptr("abcdef123456"),
-			CreatedAt: &github.Timestamp{Time: time.Now().Add(-12 * time.Hour)},
-			UpdatedAt: &github.Timestamp{Time: time.Now().Add(-12 * time.Hour)},
 		},
 	}
 
 	tests := []struct {
-		name             string
-		mockedClient     *http.Client
-		requestArgs      map[string]interface{}
-		expectError      bool
-		expectedComments []*github.PullRequestComment
-		expectedErrMsg   string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedThreads []ReviewThread
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful comments fetch",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
-					mockComments,
-				),
-			),
+			name:         "successful threads fetch across pages",
+			mockedClient: pagedClient(),
 			requestArgs: map[string]interface{}{
 				"owner":      "owner",
 				"repo":       "repo",
 				"pullNumber": float64(42),
 			},
-			expectError:      false,
-			expectedComments: mockComments,
+			expectedThreads: []ReviewThread{unresolvedThread, resolvedThread},
 		},
 		{
-			name: "comments fetch fails",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
-					}),
+			name:         "resolved threads excluded",
+			mockedClient: pagedClient(),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"pullNumber":       float64(42),
+				"include_resolved": false,
+			},
+			expectedThreads: []ReviewThread{unresolvedThread},
+		},
+		{
+			name: "threads fetch fails",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					reviewThreadsQuery{},
+					map[string]any{
+						"owner":      githubv4.String("owner"),
+						"repo":       githubv4.String("repo"),
+						"pullNumber": githubv4.Int(999),
+						"after":      (*githubv4.String)(nil),
+					},
+					githubv4mock.ErrorResponse("Could not resolve to a PullRequest with the number of 999."),
 				),
 			),
 			requestArgs: map[string]interface{}{
@@ -1643,8 +1841,8 @@ func Test_GetPullRequestComments(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
-			client := github.NewClient(tc.mockedClient)
-			_, handler := GetPullRequestReviewComments(stubGetClientFn(client), translations.NullTranslationHelper)
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestReviewComments(stubGetGQLClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1668,17 +1866,349 @@ func Test_GetPullRequestComments(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedComments []*github.PullRequestComment
-			err = json.Unmarshal([]byte(textContent.Text), &returnedComments)
+			var returnedThreads []ReviewThread
+			err = json.Unmarshal([]byte(textContent.Text), &returnedThreads)
 			require.NoError(t, err)
-			assert.Len(t, returnedComments, len(tc.expectedComments))
-			for i, comment := range returnedComments {
-				assert.Equal(t, *tc.expectedComments[i].ID, *comment.ID)
-				assert.Equal(t, *tc.expectedComments[i].Body, *comment.Body)
-				assert.Equal(t, *tc.expectedComments[i].User.Login, *comment.User.Login)
-				assert.Equal(t, *tc.expectedComments[i].Path, *comment.Path)
-				assert.Equal(t, *tc.expectedComments[i].HTMLURL, *comment.HTMLURL)
+			assert.Equal(t, tc.expectedThreads, returnedThreads)
+		})
+	}
+}
+
+func Test_ReplyToReviewComment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReplyToReviewComment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "reply_to_review_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "in_reply_to")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "in_reply_to", "body"})
+
+	mockReply := &github.PullRequestComment{
+		ID:        github.Ptr(int64(102)),
+		Body:      github.Ptr("Fixed in the latest commit"),
+		InReplyTo: github.Ptr(int64(101)),
+		Path:      github.Ptr("file1.go"),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/42#discussion_r102"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedComment *github.PullRequestComment
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful reply",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsCommentsByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"body":        "Fixed in the latest commit",
+						"in_reply_to": float64(101),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockReply),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"in_reply_to": float64(101),
+				"body":        "Fixed in the latest commit",
+			},
+			expectedComment: mockReply,
+		},
+		{
+			name: "comment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsCommentsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"in_reply_to": float64(999),
+				"body":        "Fixed",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to reply to review comment",
+		},
+		{
+			name:         "missing body",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"in_reply_to": float64(101),
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: body",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReplyToReviewComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
 			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returnedComment github.PullRequestComment
+			err = json.Unmarshal([]byte(textContent.Text), &returnedComment)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedComment.ID, *returnedComment.ID)
+			assert.Equal(t, *tc.expectedComment.Body, *returnedComment.Body)
+			assert.Equal(t, *tc.expectedComment.InReplyTo, *returnedComment.InReplyTo)
+		})
+	}
+}
+
+func Test_ApplySuggestion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ApplySuggestion(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "apply_suggestion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "comment_ids")
+	assert.Contains(t, tool.InputSchema.Properties, "commit_message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "comment_ids"})
+
+	mockPR := &github.PullRequest{
+		Number: github.Ptr(42),
+		Head: &github.PullRequestBranch{
+			Ref: github.Ptr("feature"),
+			SHA: github.Ptr("abc123"),
+			Repo: &github.Repository{
+				Name:     github.Ptr("repo"),
+				FullName: github.Ptr("contributor/repo"),
+				Owner:    &github.User{Login: github.Ptr("contributor")},
+			},
+		},
+	}
+	mockFile := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Path:     github.Ptr("main.go"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("package main\n\nfunc main() {\n\tprintln(\"helo\")\n\tprintln(\"wrold\")\n}\n"))),
+	}
+	reviewComment := func(id int64, startLine *int, line int, body string) *github.PullRequestComment {
+		return &github.PullRequestComment{
+			ID:             github.Ptr(id),
+			Body:           github.Ptr(body),
+			Path:           github.Ptr("main.go"),
+			StartLine:      startLine,
+			Line:           github.Ptr(line),
+			Side:           github.Ptr("RIGHT"),
+			PullRequestURL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/42"),
+		}
+	}
+	typoComment := reviewComment(101, nil, 4, "Typo\n```suggestion\n\tprintln(\"hello\")\n```")
+	multiLineComment := reviewComment(102, github.Ptr(5), 6, "```suggestion\r\n\tprintln(\"world\")\r\n}\r\n```")
+	overlappingComment := reviewComment(103, github.Ptr(3), 4, "```suggestion\nfunc main() {}\n```")
+	plainComment := reviewComment(104, nil, 4, "Please fix this typo")
+
+	commitMutation := struct {
+		CreateCommitOnBranch struct {
+			Commit struct {
+				OID githubv4.GitObjectID `graphql:"oid"`
+				URL githubv4.URI
+			}
+		} `graphql:"createCommitOnBranch(input: $input)"`
+	}{}
+	fixedContent := "package main\n\nfunc main() {\n\tprintln(\"hello\")\n\tprintln(\"world\")\n}\n"
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		mockedGQLClient *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedResult  AppliedSuggestions
+		expectedErrMsg  string
+	}{
+		{
+			name: "applies a batch of suggestions in one commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsCommentsByOwnerByRepoByCommentId,
+					typoComment,
+					multiLineComment,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "abc123"}).andThen(
+						mockResponse(t, http.StatusOK, mockFile),
+					),
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					commitMutation,
+					githubv4.CreateCommitOnBranchInput{
+						Branch: githubv4.CommittableBranch{
+							RepositoryNameWithOwner: githubv4.NewString("contributor/repo"),
+							BranchName:              githubv4.NewString("feature"),
+						},
+						Message:         githubv4.CommitMessage{Headline: "Apply suggestions from code review"},
+						ExpectedHeadOid: "abc123",
+						FileChanges: &githubv4.FileChanges{
+							Additions: &[]githubv4.FileAddition{
+								{
+									Path:     "main.go",
+									Contents: githubv4.Base64String(base64.StdEncoding.EncodeToString([]byte(fixedContent))),
+								},
+							},
+						},
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"createCommitOnBranch": map[string]any{
+							"commit": map[string]any{
+								"oid": "def456",
+								"url": "https://github.com/contributor/repo/commit/def456",
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"comment_ids": []any{float64(101), float64(102)},
+			},
+			expectedResult: AppliedSuggestions{
+				SHA:      "def456",
+				URL:      "https://github.com/contributor/repo/commit/def456",
+				Files:    []string{"main.go"},
+				Comments: []int64{101, 102},
+			},
+		},
+		{
+			name: "comment without a suggestion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsCommentsByOwnerByRepoByCommentId,
+					plainComment,
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"comment_ids": []any{float64(104)},
+			},
+			expectError:    true,
+			expectedErrMsg: "review comment 104 has no suggestion",
+		},
+		{
+			name: "overlapping suggestions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsCommentsByOwnerByRepoByCommentId,
+					typoComment,
+					overlappingComment,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockFile,
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"comment_ids": []any{float64(101), float64(103)},
+			},
+			expectError:    true,
+			expectedErrMsg: "suggestions of comments 103 and 101 change the same lines of main.go",
+		},
+		{
+			name:            "missing comment ids",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pullNumber":  float64(42),
+				"comment_ids": []any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: comment_ids",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup clients with mocks
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQLClient)
+			_, handler := ApplySuggestion(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned AppliedSuggestions
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returned)
 		})
 	}
 }
@@ -2839,6 +3369,163 @@ index 5d6e7b2..8a4f5c3 100644
 	}
 }
 
+const multiFileDiff = `diff --git a/README.md b/README.md
+index 5d6e7b2..8a4f5c3 100644
+--- a/README.md
++++ b/README.md
+@@ -1,2 +1,3 @@
+ # Hello-World
+-Hello
++Hello World
++More
+diff --git a/old.go b/new.go
+similarity index 90%
+rename from old.go
+rename to new.go
+index 1111111..2222222 100644
+--- a/old.go
++++ b/new.go
+@@ -1 +1 @@
+-package old
++package new
+diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..3333333
+Binary files /dev/null and b/logo.png differ`
+
+func TestGetPullRequestDiff_Summary(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposPullsByOwnerByRepoByPullNumber,
+			expectPath(t, "/repos/owner/repo/pulls/42").andThen(
+				mockResponse(t, http.StatusOK, multiFileDiff),
+			),
+		),
+	)
+	_, handler := GetPullRequestDiff(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+		"max_lines":  float64(10),
+	})
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var summary DiffSummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+	assert.Contains(t, summary.Message, "get_pull_request_file_diff")
+	assert.Equal(t, 23, summary.TotalLines)
+	assert.Equal(t, 3, summary.Additions)
+	assert.Equal(t, 2, summary.Deletions)
+	assert.Equal(t, []DiffFile{
+		{Path: "README.md", Status: "modified", Additions: 2, Deletions: 1, Lines: 9},
+		{Path: "new.go", PreviousPath: "old.go", Status: "renamed", Additions: 1, Deletions: 1, Lines: 10},
+		{Path: "logo.png", Status: "added", Binary: true, Lines: 4},
+	}, summary.Files)
+}
+
+func TestGetPullRequestFileDiff(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestFileDiff(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_file_diff", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "path"})
+
+	tests := []struct {
+		name               string
+		path               string
+		mockedClient       *http.Client
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedDiff       string
+	}{
+		{
+			name: "file by path",
+			path: "README.md",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusOK, multiFileDiff),
+				),
+			),
+			expectedDiff: "diff --git a/README.md b/README.md\nindex 5d6e7b2..8a4f5c3 100644\n--- a/README.md\n+++ b/README.md\n@@ -1,2 +1,3 @@\n # Hello-World\n-Hello\n+Hello World\n+More",
+		},
+		{
+			name: "renamed file by previous path",
+			path: "old.go",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusOK, multiFileDiff),
+				),
+			),
+			expectedDiff: "diff --git a/old.go b/new.go\nsimilarity index 90%\nrename from old.go\nrename to new.go\nindex 1111111..2222222 100644\n--- a/old.go\n+++ b/new.go\n@@ -1 +1 @@\n-package old\n+package new",
+		},
+		{
+			name: "file not in diff",
+			path: "missing.go",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusOK, multiFileDiff),
+				),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "file missing.go isn't changed by pull request #42",
+		},
+		{
+			name: "diff fetch fails",
+			path: "README.md",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to get pull request diff",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestFileDiff(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       tc.path,
+			})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedDiff, textContent.Text)
+		})
+	}
+}
+
 func viewerQuery(login string) githubv4mock.Matcher {
 	return githubv4mock.NewQueryMatcher(
 		struct {
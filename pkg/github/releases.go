@@ -0,0 +1,550 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetRelease creates a tool to get a release of a GitHub repository by its ID.
+func GetRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_release",
+			mcp.WithDescription(t("TOOL_GET_RELEASE_DESCRIPTION", "Get a release of a GitHub repository by its ID, including draft releases, which can't be found by tag")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RELEASE_USER_TITLE", "Get release"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[*github.RepositoryRelease](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the release"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			release, resp, err := client.Repositories.GetRelease(ctx, owner, repo, int64(releaseID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get release",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(release)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// withReleaseOptions adds the parameters describing a release that create_release and update_release share.
+func withReleaseOptions() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("target_commitish",
+			mcp.Description("Branch or commit SHA the tag is created from when it doesn't exist yet. Defaults to the default branch"),
+		)(tool)
+		mcp.WithString("name",
+			mcp.Description("Name of the release"),
+		)(tool)
+		mcp.WithString("body",
+			mcp.Description("Description of the release, in Markdown"),
+		)(tool)
+		mcp.WithBoolean("draft",
+			mcp.Description("Whether the release is a draft, which isn't published"),
+		)(tool)
+		mcp.WithBoolean("prerelease",
+			mcp.Description("Whether the release is a prerelease"),
+		)(tool)
+		mcp.WithString("make_latest",
+			mcp.Description("Whether the release is marked as the latest release. legacy marks it based on its creation date and semantic version"),
+			mcp.Enum("true", "false", "legacy"),
+		)(tool)
+	}
+}
+
+// releaseFromRequest returns the fields of a release given in the request, leaving out the others.
+func releaseFromRequest(request mcp.CallToolRequest) (*github.RepositoryRelease, error) {
+	release := &github.RepositoryRelease{}
+	for name, field := range map[string]**string{
+		"tag_name":         &release.TagName,
+		"target_commitish": &release.TargetCommitish,
+		"name":             &release.Name,
+		"body":             &release.Body,
+		"make_latest":      &release.MakeLatest,
+	} {
+		value, ok, err := OptionalParamOK[string](request, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+	for name, field := range map[string]**bool{
+		"draft":      &release.Draft,
+		"prerelease": &release.Prerelease,
+	} {
+		value, ok, err := OptionalParamOK[bool](request, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+	return release, nil
+}
+
+// CreateRelease creates a tool to create a release in a GitHub repository.
+func CreateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_release",
+			mcp.WithDescription(t("TOOL_CREATE_RELEASE_DESCRIPTION", "Create a release in a GitHub repository, creating its tag when it doesn't exist yet")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_RELEASE_USER_TITLE", "Create release"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalResponse](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag_name",
+				mcp.Required(),
+				mcp.Description("Tag of the release (e.g., 'v1.0.0')"),
+			),
+			withReleaseOptions(),
+			mcp.WithBoolean("generate_release_notes",
+				mcp.Description("Whether to generate the name and the description of the release from the changes since the previous release, added after body"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := RequiredParam[string](request, "tag_name"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release, err := releaseFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			generateReleaseNotes, err := OptionalParam[bool](request, "generate_release_notes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if generateReleaseNotes {
+				release.GenerateReleaseNotes = github.Ptr(true)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateRelease(ctx, owner, repo, release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create release",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(MinimalResponse{
+				ID:  fmt.Sprintf("%d", created.GetID()),
+				URL: created.GetHTMLURL(),
+			}), nil
+		}
+}
+
+// UpdateRelease creates a tool to update a release of a GitHub repository.
+func UpdateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_release",
+			mcp.WithDescription(t("TOOL_UPDATE_RELEASE_DESCRIPTION", "Update a release of a GitHub repository, such as its description, or publish a draft release by setting draft to false. Only the given fields are changed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_RELEASE_USER_TITLE", "Update release"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalResponse](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the release"),
+			),
+			mcp.WithString("tag_name",
+				mcp.Description("New tag of the release"),
+			),
+			withReleaseOptions(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			release, err := releaseFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			updated, resp, err := client.Repositories.EditRelease(ctx, owner, repo, int64(releaseID), release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to update release",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(MinimalResponse{
+				ID:  fmt.Sprintf("%d", updated.GetID()),
+				URL: updated.GetHTMLURL(),
+			}), nil
+		}
+}
+
+// DeleteRelease creates a tool to delete a release of a GitHub repository.
+func DeleteRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_release",
+			mcp.WithDescription(t("TOOL_DELETE_RELEASE_DESCRIPTION", "Delete a release of a GitHub repository along with its assets. Its tag is kept")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_RELEASE_USER_TITLE", "Delete release"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[map[string]any](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the release"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteRelease(ctx, owner, repo, int64(releaseID))
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to delete release",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(map[string]any{
+				"message":    "Release has been deleted",
+				"release_id": releaseID,
+			}), nil
+		}
+}
+
+// UploadReleaseAsset creates a tool to upload an asset to a release, from base64 content or from a file of a
+// repository given by its resource URI.
+func UploadReleaseAsset(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("upload_release_asset",
+			mcp.WithDescription(t("TOOL_UPLOAD_RELEASE_ASSET_DESCRIPTION", "Upload an asset to a release of a GitHub repository. The asset is either given as base64 content, or as the repo:// resource URI of a file of a repository, which is streamed to the release without going through the conversation")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPLOAD_RELEASE_ASSET_USER_TITLE", "Upload release asset"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[*github.ReleaseAsset](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the release"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("File name of the asset"),
+			),
+			mcp.WithString("label",
+				mcp.Description("Label shown in place of the file name of the asset"),
+			),
+			mcp.WithString("content",
+				mcp.Description("Content of the asset, base64 encoded. Either content or resource_uri is required"),
+			),
+			mcp.WithString("resource_uri",
+				mcp.Description("repo:// resource URI of a file of a repository to upload, such as repo://owner/repo/refs/tags/v1.0.0/contents/dist/app.zip. Either content or resource_uri is required"),
+			),
+			mcp.WithString("content_type",
+				mcp.Description("Media type of the asset. Defaults to the type of its file name extension"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label, err := OptionalParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resourceURI, err := OptionalParam[string](request, "resource_uri")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentType, err := OptionalParam[string](request, "content_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (content == "") == (resourceURI == "") {
+				return mcp.NewToolResultError("exactly one of content or resource_uri is required"), nil
+			}
+			if contentType == "" {
+				contentType = mime.TypeByExtension(path.Ext(name))
+			}
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+
+			var body io.Reader
+			var size int64
+			if content != "" {
+				decoded, err := base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("content isn't valid base64: %s", err)), nil
+				}
+				body, size = bytes.NewReader(decoded), int64(len(decoded))
+			} else {
+				source, err := ParseRepositoryResourceURI(resourceURI)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				rawClient, err := getRawClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub raw content client: %w", err)
+				}
+				rawResp, err := rawClient.GetRawContent(ctx, source.Owner, source.Repo, source.Path, source.ContentOpts())
+				if err != nil {
+					return nil, fmt.Errorf("failed to get raw content: %w", err)
+				}
+				defer func() { _ = rawResp.Body.Close() }()
+				if rawResp.StatusCode != http.StatusOK {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get %s: %s", resourceURI, rawResp.Status)), nil
+				}
+				body, size = rawResp.Body, rawResp.ContentLength
+				if size < 0 {
+					// The uploads API needs the size of the asset up front
+					buffered, err := io.ReadAll(rawResp.Body)
+					if err != nil {
+						return nil, fmt.Errorf("failed to read raw content: %w", err)
+					}
+					body, size = bytes.NewReader(buffered), int64(len(buffered))
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			query := url.Values{"name": {name}}
+			if label != "" {
+				query.Set("label", label)
+			}
+			uploadURL := fmt.Sprintf("repos/%s/%s/releases/%d/assets?%s", owner, repo, releaseID, query.Encode())
+			req, err := client.NewUploadRequest(uploadURL, NewProgressReader(ctx, body, size, "Uploading asset"), size, contentType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create upload request: %w", err)
+			}
+			asset := new(github.ReleaseAsset)
+			resp, err := client.Do(ctx, req, asset)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to upload release asset",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(asset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RepositoryResourceFile is a file of a repository named by a repo:// resource URI.
+type RepositoryResourceFile struct {
+	Owner string
+	Repo  string
+	Path  string
+	// SHA or Ref select the version of the file, the default branch when both are empty
+	SHA string
+	Ref string
+}
+
+// ContentOpts returns the options of the raw client getting the version of the file.
+func (f RepositoryResourceFile) ContentOpts() *raw.ContentOpts {
+	return &raw.ContentOpts{SHA: f.SHA, Ref: f.Ref}
+}
+
+// ParseRepositoryResourceURI parses the repo:// resource URI of a file, in any of the forms of the repository
+// resource templates except pull requests.
+func ParseRepositoryResourceURI(uri string) (RepositoryResourceFile, error) {
+	invalid := fmt.Errorf("%q isn't the repo:// resource URI of a file such as repo://owner/repo/contents/path", uri)
+	rest, ok := strings.CutPrefix(uri, "repo://")
+	if !ok {
+		return RepositoryResourceFile{}, invalid
+	}
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" {
+		return RepositoryResourceFile{}, invalid
+	}
+	file := RepositoryResourceFile{Owner: parts[0], Repo: parts[1]}
+
+	rest = "/" + parts[2]
+	index := strings.Index(rest, "/contents/")
+	if index < 0 {
+		return RepositoryResourceFile{}, invalid
+	}
+	version, filePath := strings.Trim(rest[:index], "/"), rest[index+len("/contents/"):]
+	if filePath == "" || strings.HasSuffix(filePath, "/") {
+		return RepositoryResourceFile{}, errors.New("the resource URI must name a file, not a directory")
+	}
+	unescaped, err := url.PathUnescape(filePath)
+	if err != nil {
+		return RepositoryResourceFile{}, invalid
+	}
+	file.Path = unescaped
+
+	switch {
+	case version == "":
+	case strings.HasPrefix(version, "sha/"):
+		file.SHA = strings.TrimPrefix(version, "sha/")
+	case strings.HasPrefix(version, "refs/pull/"):
+		return RepositoryResourceFile{}, errors.New("pull request resource URIs aren't supported, use the URI of the head commit of the pull request")
+	case strings.HasPrefix(version, "refs/heads/"), strings.HasPrefix(version, "refs/tags/"):
+		file.Ref = version
+	case strings.HasPrefix(version, "refs/"):
+		file.Ref = strings.TrimPrefix(version, "refs/")
+	default:
+		return RepositoryResourceFile{}, invalid
+	}
+	return file, nil
+}
@@ -0,0 +1,302 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRelease(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_release", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposReleasesByOwnerByRepoByReleaseId,
+			&github.RepositoryRelease{ID: github.Ptr(int64(42)), TagName: github.Ptr("v1.0.0"), Draft: github.Ptr(true)},
+		),
+	))
+	_, handler := GetRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"release_id": float64(42),
+	}))
+	require.NoError(t, err)
+
+	var release github.RepositoryRelease
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &release))
+	assert.Equal(t, "v1.0.0", release.GetTagName())
+	assert.True(t, release.GetDraft())
+}
+
+func Test_CreateRelease(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_release", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag_name"})
+
+	var sent map[string]any
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposReleasesByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&sent)
+				w.WriteHeader(http.StatusCreated)
+				_ = json.NewEncoder(w).Encode(&github.RepositoryRelease{
+					ID:      github.Ptr(int64(42)),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+				})
+			}),
+		),
+	))
+	_, handler := CreateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":                  "owner",
+		"repo":                   "repo",
+		"tag_name":               "v1.0.0",
+		"name":                   "Version 1",
+		"draft":                  false,
+		"make_latest":            "true",
+		"generate_release_notes": true,
+	}))
+	require.NoError(t, err)
+
+	var response MinimalResponse
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, MinimalResponse{ID: "42", URL: "https://github.com/owner/repo/releases/tag/v1.0.0"}, response)
+	assert.Equal(t, map[string]any{
+		"tag_name":               "v1.0.0",
+		"name":                   "Version 1",
+		"draft":                  false,
+		"make_latest":            "true",
+		"generate_release_notes": true,
+	}, sent)
+}
+
+func Test_UpdateRelease(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_release", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id"})
+
+	var sent map[string]any
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&sent)
+				_ = json.NewEncoder(w).Encode(&github.RepositoryRelease{
+					ID:      github.Ptr(int64(42)),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+				})
+			}),
+		),
+	))
+	_, handler := UpdateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"release_id": float64(42),
+		"draft":      false,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	// Publishing a draft leaves the rest of the release as it was
+	assert.Equal(t, map[string]any{"draft": false}, sent)
+}
+
+func Test_DeleteRelease(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_release", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposReleasesByOwnerByRepoByReleaseId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		),
+	))
+	_, handler := DeleteRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"release_id": float64(42),
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, getTextResult(t, result).Text, "Release has been deleted")
+}
+
+func Test_UploadReleaseAsset(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UploadReleaseAsset(stubGetClientFn(mockClient), stubGetRawClientFn(raw.NewClient(mockClient, &url.URL{})), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "upload_release_asset", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id", "name"})
+
+	type upload struct {
+		query       url.Values
+		contentType string
+		body        string
+	}
+	newHandler := func(uploads *[]upload) server.ToolHandlerFunc {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostReposReleasesAssetsByOwnerByRepoByReleaseId,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					body, _ := io.ReadAll(r.Body)
+					*uploads = append(*uploads, upload{query: r.URL.Query(), contentType: r.Header.Get("Content-Type"), body: string(body)})
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(&github.ReleaseAsset{ID: github.Ptr(int64(7)), Name: github.Ptr(r.URL.Query().Get("name"))})
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				raw.GetRawReposContentsByOwnerByRepoByTagByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					_, _ = w.Write([]byte("zip contents"))
+				}),
+			),
+		)
+		client := github.NewClient(mockedClient)
+		rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+		_, handler := UploadReleaseAsset(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+		return handler
+	}
+
+	t.Run("uploads base64 content", func(t *testing.T) {
+		var uploads []upload
+		result, err := newHandler(&uploads)(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"release_id": float64(42),
+			"name":       "notes.txt",
+			"label":      "Release notes",
+			"content":    base64.StdEncoding.EncodeToString([]byte("hello")),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		require.Len(t, uploads, 1)
+		assert.Equal(t, "notes.txt", uploads[0].query.Get("name"))
+		assert.Equal(t, "Release notes", uploads[0].query.Get("label"))
+		assert.Equal(t, "text/plain; charset=utf-8", uploads[0].contentType)
+		assert.Equal(t, "hello", uploads[0].body)
+	})
+
+	t.Run("streams files of repositories", func(t *testing.T) {
+		var uploads []upload
+		result, err := newHandler(&uploads)(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"release_id":   float64(42),
+			"name":         "app.bin",
+			"resource_uri": "repo://owner/repo/refs/tags/v1.0.0/contents/dist/app.bin",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		require.Len(t, uploads, 1)
+		assert.Equal(t, "application/octet-stream", uploads[0].contentType)
+		assert.Equal(t, "zip contents", uploads[0].body)
+	})
+
+	t.Run("requires exactly one source", func(t *testing.T) {
+		var uploads []upload
+		result, err := newHandler(&uploads)(context.Background(), createMCPRequest(map[string]any{
+			"owner":        "owner",
+			"repo":         "repo",
+			"release_id":   float64(42),
+			"name":         "app.bin",
+			"content":      "aGVsbG8=",
+			"resource_uri": "repo://owner/repo/contents/app.bin",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "exactly one of content or resource_uri is required", getErrorResult(t, result).Text)
+		assert.Empty(t, uploads)
+	})
+}
+
+func Test_ParseRepositoryResourceURI(t *testing.T) {
+	tests := []struct {
+		uri           string
+		expected      RepositoryResourceFile
+		expectedError string
+	}{
+		{
+			uri:      "repo://owner/repo/contents/dist/app.zip",
+			expected: RepositoryResourceFile{Owner: "owner", Repo: "repo", Path: "dist/app.zip"},
+		},
+		{
+			uri:      "repo://owner/repo/refs/heads/main/contents/README.md",
+			expected: RepositoryResourceFile{Owner: "owner", Repo: "repo", Path: "README.md", Ref: "refs/heads/main"},
+		},
+		{
+			uri:      "repo://owner/repo/refs/tags/v1.0.0/contents/app%20v1.zip",
+			expected: RepositoryResourceFile{Owner: "owner", Repo: "repo", Path: "app v1.zip", Ref: "refs/tags/v1.0.0"},
+		},
+		{
+			uri:      "repo://owner/repo/sha/abc123/contents/app.zip",
+			expected: RepositoryResourceFile{Owner: "owner", Repo: "repo", Path: "app.zip", SHA: "abc123"},
+		},
+		{
+			uri:      "repo://owner/repo/refs/release/contents/app.zip",
+			expected: RepositoryResourceFile{Owner: "owner", Repo: "repo", Path: "app.zip", Ref: "release"},
+		},
+		{
+			uri:           "repo://owner/repo/contents/dist/",
+			expectedError: "the resource URI must name a file, not a directory",
+		},
+		{
+			uri:           "repo://owner/repo/refs/pull/1/head/contents/app.zip",
+			expectedError: "pull request resource URIs aren't supported, use the URI of the head commit of the pull request",
+		},
+		{
+			uri:           "https://github.com/owner/repo/blob/main/app.zip",
+			expectedError: `"https://github.com/owner/repo/blob/main/app.zip" isn't the repo:// resource URI of a file such as repo://owner/repo/contents/path`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.uri, func(t *testing.T) {
+			file, err := ParseRepositoryResourceURI(tc.uri)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, file)
+		})
+	}
+}
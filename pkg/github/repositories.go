@@ -6,16 +6,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
+	"path"
+	"sort"
 	"strings"
+	"unicode/utf8"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/lfs"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
 )
 
 func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
@@ -25,6 +31,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				Title:        t("TOOL_GET_COMMITS_USER_TITLE", "Get commit details"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[MinimalCommit](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -112,6 +119,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				Title:        t("TOOL_LIST_COMMITS_USER_TITLE", "List commits"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]MinimalCommit](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -126,7 +134,20 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithString("author",
 				mcp.Description("Author username or email address to filter commits by"),
 			),
+			mcp.WithString("path",
+				mcp.Description("Only list commits changing this file or directory"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only list commits made at or after this time (ISO 8601 timestamp or date, or a relative time such as -7d or last monday)"),
+			),
+			mcp.WithString("until",
+				mcp.Description("Only list commits made at or before this time (ISO 8601 timestamp or date, or a relative time such as -7d or last monday)"),
+			),
+			mcp.WithString("newer_than",
+				mcp.Description("SHA of a known commit, only commits listed before it are returned. Pages shorter than perPage mean it was reached, so there is no need to fetch the next one"),
+			),
 			WithPagination(),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -145,6 +166,22 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			until, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newerThan, err := OptionalParam[string](request, "newer_than")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -156,12 +193,25 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			}
 			opts := &github.CommitsListOptions{
 				SHA:    sha,
+				Path:   path,
 				Author: author,
 				ListOptions: github.ListOptions{
 					Page:    pagination.Page,
 					PerPage: perPage,
 				},
 			}
+			if since != "" {
+				opts.Since, err = parseISOTimestamp(since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", err.Error())), nil
+				}
+			}
+			if until != "" {
+				opts.Until, err = parseISOTimestamp(until)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", err.Error())), nil
+				}
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -185,6 +235,16 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil
 			}
 
+			// Commits are listed newest first, so the known commit and everything after it has already been seen
+			if newerThan != "" {
+				for i, commit := range commits {
+					if strings.HasPrefix(commit.GetSHA(), newerThan) {
+						commits = commits[:i]
+						break
+					}
+				}
+			}
+
 			// Convert to minimal commits
 			minimalCommits := make([]MinimalCommit, len(commits))
 			for i, commit := range commits {
@@ -208,6 +268,7 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				Title:        t("TOOL_LIST_BRANCHES_USER_TITLE", "List branches"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]MinimalBranch](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -217,6 +278,7 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Description("Repository name"),
 			),
 			WithPagination(),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -277,14 +339,177 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
+// BranchDivergence describes how far a branch is ahead of and behind its base.
+type BranchDivergence struct {
+	Base         string `json:"base"`
+	Head         string `json:"head"`
+	Status       string `json:"status"`
+	AheadBy      int    `json:"ahead_by"`
+	BehindBy     int    `json:"behind_by"`
+	MergeBaseSHA string `json:"merge_base_sha"`
+	NeedsUpdate  bool   `json:"needs_update"`
+}
+
+// GetBranchDivergence creates a tool to count the commits a branch is ahead of and behind another branch.
+func GetBranchDivergence(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_divergence",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_DIVERGENCE_DESCRIPTION", "Count the commits a branch is ahead of and behind a base branch in a GitHub repository, for example to decide whether a pull request branch needs updating before merging it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_DIVERGENCE_USER_TITLE", "Get branch divergence"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[BranchDivergence](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to compare. Use owner:branch for a branch of a fork"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Branch to compare against (defaults to repo default)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := OptionalParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if base == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				base = repository.GetDefaultBranch()
+			}
+
+			// Only the counts are needed, so list as few of the commits as possible
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, branch, &github.ListOptions{PerPage: 1})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to compare %s with %s", branch, base),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(BranchDivergence{
+				Base:         base,
+				Head:         branch,
+				Status:       comparison.GetStatus(),
+				AheadBy:      comparison.GetAheadBy(),
+				BehindBy:     comparison.GetBehindBy(),
+				MergeBaseSHA: comparison.GetMergeBaseCommit().GetSHA(),
+				NeedsUpdate:  comparison.GetBehindBy() > 0,
+			}), nil
+		}
+}
+
+// GetMergeBase creates a tool to get the best common ancestor of two refs in a GitHub repository.
+func GetMergeBase(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_merge_base",
+			mcp.WithDescription(t("TOOL_GET_MERGE_BASE_DESCRIPTION", "Get the merge base of two refs in a GitHub repository, which is the commit git merges them from, as git merge-base does")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MERGE_BASE_USER_TITLE", "Get merge base"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[MinimalCommit](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("First ref: a branch, tag or commit SHA"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Second ref: a branch, tag or commit SHA. Use owner:branch for a branch of a fork"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{PerPage: 1})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get merge base of %s and %s", base, head),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if comparison.MergeBaseCommit == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s and %s have no common ancestor", base, head)), nil
+			}
+
+			return MarshalledTextResult(convertToMinimalCommit(comparison.MergeBaseCommit, false)), nil
+		}
+}
+
 // CreateOrUpdateFile creates a tool to create or update a file in a GitHub repository.
-func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+func CreateOrUpdateFile(getClient GetClientFn, getLFSClient lfs.GetLFSClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_or_update_file",
 			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository. If updating, you must provide the SHA of the file you want to update. Use this tool to create or update a file in a GitHub repository remotely; do not use it for local file operations.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_OR_UPDATE_FILE_USER_TITLE", "Create or update file"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[*github.RepositoryContentResponse](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner (username or organization)"),
@@ -301,6 +526,14 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("Content of the file"),
 			),
+			mcp.WithString("encoding",
+				mcp.Description("Encoding of the content. Use 'base64' to upload binary files such as images."),
+				mcp.Enum("utf-8", "base64"),
+				mcp.DefaultString("utf-8"),
+			),
+			mcp.WithBoolean("lfs",
+				mcp.Description("Store the content in Git LFS and commit a pointer file in its place. Use for paths tracked by LFS in .gitattributes."),
+			),
 			mcp.WithString("message",
 				mcp.Required(),
 				mcp.Description("Commit message"),
@@ -339,8 +572,40 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			encoding, err := OptionalParam[string](request, "encoding")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			// json.Marshal encodes byte arrays with base64, which is required for the API.
-			contentBytes := []byte(content)
+			var contentBytes []byte
+			switch encoding {
+			case "", "utf-8":
+				contentBytes = []byte(content)
+			case "base64":
+				contentBytes, err = base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("content is not valid base64: %s", err)), nil
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported encoding %q, must be 'utf-8' or 'base64'", encoding)), nil
+			}
+
+			useLFS, err := OptionalParam[bool](request, "lfs")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if useLFS {
+				lfsClient, err := getLFSClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub LFS client: %w", err)
+				}
+				pointer, err := lfsClient.Upload(ctx, owner, repo, "refs/heads/"+branch, contentBytes)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to upload LFS object: %s", err)), nil
+				}
+				contentBytes = []byte(pointer.String())
+			}
 
 			// Create the file options
 			opts := &github.RepositoryContentFileOptions{
@@ -398,6 +663,7 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_CREATE_REPOSITORY_USER_TITLE", "Create repository"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[MinimalResponse](),
 			mcp.WithString("name",
 				mcp.Required(),
 				mcp.Description("Repository name"),
@@ -481,10 +747,205 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// maxLFSObjectSize is the size of the largest LFS object get_file_contents downloads in place of its pointer.
+const maxLFSObjectSize = 10 * 1024 * 1024
+
+// LFSPointerResult describes an LFS-tracked file whose object was not downloaded.
+type LFSPointerResult struct {
+	Path   string `json:"path"`
+	SHA    string `json:"sha,omitempty"`
+	LFS    bool   `json:"lfs"`
+	OID    string `json:"oid"`
+	Size   int64  `json:"size"`
+	Reason string `json:"reason"`
+}
+
+func lfsPointerResult(path, sha string, pointer lfs.Pointer, reason string) (*mcp.CallToolResult, error) {
+	r, err := json.Marshal(LFSPointerResult{
+		Path:   path,
+		SHA:    sha,
+		LFS:    true,
+		OID:    pointer.OID,
+		Size:   pointer.Size,
+		Reason: reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// SubmoduleResult describes a submodule, which has no content of its own in the repository.
+type SubmoduleResult struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+	// SHA is the commit of the submodule repository the submodule is at.
+	SHA    string `json:"sha"`
+	GitURL string `json:"git_url,omitempty"`
+	// Owner and Repo identify the submodule repository, when it is on GitHub.
+	Owner string `json:"owner,omitempty"`
+	Repo  string `json:"repo,omitempty"`
+}
+
+// SymlinkResult describes a symlink that doesn't point to a file of the repository.
+type SymlinkResult struct {
+	Type   string `json:"type"`
+	Path   string `json:"path"`
+	Target string `json:"target"`
+	// TargetPath is the path of the target in the repository, empty when it is outside of it.
+	TargetPath string `json:"target_path,omitempty"`
+}
+
+func submoduleResult(owner string, content *github.RepositoryContent) *mcp.CallToolResult {
+	result := SubmoduleResult{
+		Type:   "submodule",
+		Path:   content.GetPath(),
+		SHA:    content.GetSHA(),
+		GitURL: content.GetSubmoduleGitURL(),
+	}
+	result.Owner, result.Repo = parseSubmoduleURL(owner, result.GitURL)
+	return MarshalledTextResult(result)
+}
+
+func symlinkResult(content *github.RepositoryContent) *mcp.CallToolResult {
+	result := SymlinkResult{
+		Type:   "symlink",
+		Path:   content.GetPath(),
+		Target: content.GetTarget(),
+	}
+	if !path.IsAbs(result.Target) {
+		targetPath := path.Join(path.Dir(result.Path), result.Target)
+		if targetPath != ".." && !strings.HasPrefix(targetPath, "../") {
+			result.TargetPath = targetPath
+		}
+	}
+	return MarshalledTextResult(result)
+}
+
+// parseSubmoduleURL returns the owner and name of the repository a submodule URL points to, resolving URLs
+// relative to the superproject against its owner. It returns empty strings for URLs it can't make sense of.
+func parseSubmoduleURL(superprojectOwner, gitURL string) (string, string) {
+	trimmed := strings.TrimSuffix(strings.TrimSuffix(gitURL, "/"), ".git")
+	switch {
+	case strings.HasPrefix(trimmed, "../"):
+		// Relative URLs are relative to the URL of the superproject, which ends with owner/repo
+		trimmed = path.Join(superprojectOwner, "repo", trimmed)
+		if strings.HasPrefix(trimmed, "..") {
+			return "", ""
+		}
+	case strings.Contains(trimmed, "://"):
+		trimmed = trimmed[strings.Index(trimmed, "://")+len("://"):]
+		trimmed = trimmed[strings.Index(trimmed, "/")+1:]
+	case strings.Contains(trimmed, ":"):
+		// scp-like syntax, as in git@github.com:owner/repo
+		trimmed = trimmed[strings.Index(trimmed, ":")+1:]
+	default:
+		return "", ""
+	}
+
+	parts := strings.Split(trimmed, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", ""
+	}
+	return parts[0], parts[1]
+}
+
+func downloadLFSObject(ctx context.Context, getLFSClient lfs.GetLFSClientFn, owner, repo, ref string, pointer lfs.Pointer) ([]byte, error) {
+	lfsClient, err := getLFSClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub LFS client: %w", err)
+	}
+	resp, err := lfsClient.Download(ctx, owner, repo, ref, pointer)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return io.ReadAll(NewProgressReader(ctx, resp.Body, pointer.Size, "Downloading LFS object"))
+}
+
+// maxBlobRangeLength is the largest slice of a blob get_file_contents reads at once.
+const maxBlobRangeLength = 1024 * 1024
+
+// getBlobRange reads length bytes of a blob starting at offset through the Git blob API, streaming past
+// the bytes before the range when the API doesn't honor the Range header.
+func getBlobRange(ctx context.Context, client *github.Client, owner, repo, path, blobSHA string, size, offset, length int) (*mcp.CallToolResult, error) {
+	if size > 0 && offset >= size {
+		return mcp.NewToolResultError(fmt.Sprintf("offset %d is past the end of the %d byte file", offset, size)), nil
+	}
+
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/git/blobs/%s", owner, repo, blobSHA), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.raw")
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+
+	resp, err := client.BareDo(ctx, req)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get blob",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		if _, err := io.CopyN(io.Discard, resp.Body, int64(offset)); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("offset %d is past the end of the file", offset)), nil
+		}
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(length)))
+	if err != nil {
+		return mcp.NewToolResultError("failed to read response body"), nil
+	}
+
+	resourceURI, err := url.JoinPath("repo://", owner, repo, "sha", blobSHA, "contents", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource URI: %w", err)
+	}
+
+	// Ranges rarely fall on character boundaries, so drop the partial characters at both ends of text
+	start, end := trimPartialRunes(body)
+	if text := body[start:end]; len(text) > 0 && isTextContent(text) {
+		message := fmt.Sprintf("successfully downloaded bytes %d-%d of text file of %d bytes (SHA: %s)", offset+start, offset+end-1, size, blobSHA)
+		return mcp.NewToolResultResource(message, mcp.TextResourceContents{
+			URI:      resourceURI,
+			Text:     string(text),
+			MIMEType: resourceMIMEType(path, "text/plain", text),
+		}), nil
+	}
+
+	message := fmt.Sprintf("successfully downloaded bytes %d-%d of binary file of %d bytes (SHA: %s)", offset, offset+len(body)-1, size, blobSHA)
+	return mcp.NewToolResultResource(message, mcp.BlobResourceContents{
+		URI:      resourceURI,
+		Blob:     base64.StdEncoding.EncodeToString(body),
+		MIMEType: "application/octet-stream",
+	}), nil
+}
+
+// trimPartialRunes returns the bounds of b without the incomplete UTF-8 sequences at its start and end.
+func trimPartialRunes(b []byte) (int, int) {
+	start := 0
+	for start < len(b) && start < utf8.UTFMax-1 && !utf8.RuneStart(b[start]) {
+		start++
+	}
+	end := len(b)
+	for i := len(b) - 1; i >= start && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				end = i
+			}
+			break
+		}
+	}
+	return start, end
+}
+
 // GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
-func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, getLFSClient lfs.GetLFSClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_file_contents",
-			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository")),
+			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository. Submodules and symlinks that don't point to a file are returned as JSON entries describing what they point to.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -505,7 +966,16 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`"),
 			),
 			mcp.WithString("sha",
-				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+				mcp.Description("Accepts optional commit SHA. Cannot be used together with ref"),
+			),
+			mcp.WithNumber("offset",
+				mcp.Description("Byte offset to start reading a file from. When offset or length is set, the file is read through the Git blob API, which supports files larger than 1MB"),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("length",
+				mcp.Description(fmt.Sprintf("Number of bytes of the file to read from offset, at most %d", maxBlobRangeLength)),
+				mcp.Min(0),
+				mcp.Max(maxBlobRangeLength),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -529,6 +999,20 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			if err := MutuallyExclusiveParams(request, "ref", "sha"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			offset, err := OptionalIntParam(request, "offset")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			length, err := OptionalIntParam(request, "length")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if offset < 0 || length < 0 || length > maxBlobRangeLength {
+				return mcp.NewToolResultError(fmt.Sprintf("offset must not be negative and length must be between 0 and %d", maxBlobRangeLength)), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -562,6 +1046,25 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 				}
 				fileSHA = *fileContent.SHA
 
+				switch fileContent.GetType() {
+				case "submodule":
+					return submoduleResult(owner, fileContent), nil
+				case "symlink":
+					// Symlinks to files are resolved by the contents API, so this one points elsewhere
+					return symlinkResult(fileContent), nil
+				}
+				if resolvedPath := fileContent.GetPath(); resolvedPath != "" && resolvedPath != strings.TrimPrefix(path, "/") {
+					// The path is a symlink to a file, read the file it points to
+					path = resolvedPath
+				}
+
+				if offset > 0 || length > 0 {
+					if length == 0 {
+						length = maxBlobRangeLength
+					}
+					return getBlobRange(ctx, client, owner, repo, path, fileSHA, fileContent.GetSize(), offset, length)
+				}
+
 				rawClient, err := getRawClient(ctx)
 				if err != nil {
 					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
@@ -582,6 +1085,18 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 					}
 					contentType := resp.Header.Get("Content-Type")
 
+					// LFS-tracked files are stored as pointers, return the object they point to instead
+					if pointer, ok := lfs.ParsePointer(body); ok {
+						if pointer.Size > maxLFSObjectSize {
+							return lfsPointerResult(path, fileSHA, pointer, fmt.Sprintf("the LFS object is larger than %d bytes", maxLFSObjectSize))
+						}
+						body, err = downloadLFSObject(ctx, getLFSClient, owner, repo, ref, pointer)
+						if err != nil {
+							return lfsPointerResult(path, fileSHA, pointer, fmt.Sprintf("failed to download the LFS object: %s", err))
+						}
+						contentType = resourceMIMEType(path, "", body)
+					}
+
 					var resourceURI string
 					switch {
 					case sha != "":
@@ -601,7 +1116,7 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 						}
 					}
 
-					if strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text") {
+					if isTextContent(body) {
 						result := mcp.TextResourceContents{
 							URI:      resourceURI,
 							Text:     string(body),
@@ -614,10 +1129,30 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 						return mcp.NewToolResultResource("successfully downloaded text file", result), nil
 					}
 
+					mimeType := resourceMIMEType(path, contentType, body)
+					if isTextMIMEType(mimeType) {
+						// The extension or the server claim text, but the content says otherwise
+						mimeType = http.DetectContentType(body)
+					}
+
+					// Images are returned as image content so that clients can display them to the model
+					if strings.HasPrefix(mimeType, "image/") {
+						message := "successfully downloaded image file"
+						if fileSHA != "" {
+							message = fmt.Sprintf("successfully downloaded image file (SHA: %s)", fileSHA)
+						}
+						return &mcp.CallToolResult{
+							Content: []mcp.Content{
+								mcp.NewTextContent(fmt.Sprintf("%s from %s", message, resourceURI)),
+								mcp.NewImageContent(base64.StdEncoding.EncodeToString(body), mimeType),
+							},
+						}, nil
+					}
+
 					result := mcp.BlobResourceContents{
 						URI:      resourceURI,
 						Blob:     base64.StdEncoding.EncodeToString(body),
-						MIMEType: contentType,
+						MIMEType: mimeType,
 					}
 					// Include SHA in the result metadata
 					if fileSHA != "" {
@@ -772,6 +1307,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				ReadOnlyHint:    ToBoolPtr(false),
 				DestructiveHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[map[string]any](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner (username or organization)"),
@@ -942,6 +1478,7 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				Title:        t("TOOL_CREATE_BRANCH_USER_TITLE", "Create branch"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[*github.Reference](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1018,6 +1555,9 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 
 			createdRef, resp, err := client.Git.CreateRef(ctx, owner, repo, newRef)
 			if err != nil {
+				if existing := findExistingBranch(ctx, client, owner, repo, branch, ref.GetObject().GetSHA()); existing != nil {
+					return MarshalledTextResult(existing), nil
+				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
 					"failed to create branch",
 					resp,
@@ -1043,6 +1583,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[*github.Reference](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1207,14 +1748,24 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 		}
 }
 
-// ListTags creates a tool to list tags in a GitHub repository.
-func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("list_tags",
-			mcp.WithDescription(t("TOOL_LIST_TAGS_DESCRIPTION", "List git tags in a GitHub repository")),
+// SignedCommit describes a commit created through the GraphQL API, which GitHub signs as the authenticated user or
+// GitHub App.
+type SignedCommit struct {
+	SHA    string `json:"sha"`
+	URL    string `json:"html_url"`
+	Branch string `json:"branch"`
+}
+
+// CreateSignedCommit creates a tool to commit file changes to a branch with the createCommitOnBranch mutation, so
+// that the commit is signed by GitHub and shows as verified.
+func CreateSignedCommit(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_signed_commit",
+			mcp.WithDescription(t("TOOL_CREATE_SIGNED_COMMIT_DESCRIPTION", "Commit file additions, changes and deletions to an existing branch of a GitHub repository in a single commit that GitHub signs, so that it is verified. Use this instead of push_files on branches that require signed commits.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_LIST_TAGS_USER_TITLE", "List tags"),
-				ReadOnlyHint: ToBoolPtr(true),
+				Title:        t("TOOL_CREATE_SIGNED_COMMIT_USER_TITLE", "Create signed commit"),
+				ReadOnlyHint: ToBoolPtr(false),
 			}),
+			WithJSONOutputSchema[SignedCommit](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1223,16 +1774,203 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			WithPagination(),
-		),
-		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := RequiredParam[string](request, "owner")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			repo, err := RequiredParam[string](request, "repo")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to commit to"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message. The first line is the headline and the rest the body"),
+			),
+			mcp.WithArray("files",
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path", "content"},
+						"properties": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "path to the file",
+							},
+							"content": map[string]interface{}{
+								"type":        "string",
+								"description": "file content",
+							},
+						},
+					}),
+				mcp.Description("Array of file objects to add or change, each object with path (string) and content (string)"),
+			),
+			mcp.WithArray("deletions",
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+				mcp.Description("Paths of files to delete"),
+			),
+			mcp.WithString("expected_head_sha",
+				mcp.Description("SHA the branch is expected to point to. The commit fails if the branch moved. Defaults to the current head of the branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deletions, err := OptionalStringArrayParam(request, "deletions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedHeadSHA, err := OptionalParam[string](request, "expected_head_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var filesObj []interface{}
+			if files, ok := request.GetArguments()["files"]; ok && files != nil {
+				filesObj, ok = files.([]interface{})
+				if !ok {
+					return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
+				}
+			}
+			if len(filesObj) == 0 && len(deletions) == 0 {
+				return mcp.NewToolResultError("at least one file or deletion is required"), nil
+			}
+
+			additions := make([]githubv4.FileAddition, 0, len(filesObj))
+			for _, file := range filesObj {
+				fileMap, ok := file.(map[string]interface{})
+				if !ok {
+					return mcp.NewToolResultError("each file must be an object with path and content"), nil
+				}
+				path, ok := fileMap["path"].(string)
+				if !ok || path == "" {
+					return mcp.NewToolResultError("each file must have a path"), nil
+				}
+				content, ok := fileMap["content"].(string)
+				if !ok {
+					return mcp.NewToolResultError("each file must have content"), nil
+				}
+				additions = append(additions, githubv4.FileAddition{
+					Path:     githubv4.String(path),
+					Contents: githubv4.Base64String(base64.StdEncoding.EncodeToString([]byte(content))),
+				})
+			}
+			fileDeletions := make([]githubv4.FileDeletion, 0, len(deletions))
+			for _, path := range deletions {
+				if path == "" {
+					return mcp.NewToolResultError("each deletion must be a path"), nil
+				}
+				fileDeletions = append(fileDeletions, githubv4.FileDeletion{Path: githubv4.String(path)})
+			}
+
+			if expectedHeadSHA == "" {
+				client, err := getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				}
+				ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get branch reference",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				expectedHeadSHA = ref.GetObject().GetSHA()
+			}
+
+			commitMessage := githubv4.CommitMessage{Headline: githubv4.String(message)}
+			if headline, body, ok := strings.Cut(message, "\n"); ok {
+				commitMessage.Headline = githubv4.String(strings.TrimSpace(headline))
+				if body = strings.TrimSpace(body); body != "" {
+					commitMessage.Body = githubv4.NewString(githubv4.String(body))
+				}
+			}
+
+			fileChanges := &githubv4.FileChanges{}
+			if len(additions) > 0 {
+				fileChanges.Additions = &additions
+			}
+			if len(fileDeletions) > 0 {
+				fileChanges.Deletions = &fileDeletions
+			}
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var mutation struct {
+				CreateCommitOnBranch struct {
+					Commit struct {
+						OID githubv4.GitObjectID `graphql:"oid"`
+						URL githubv4.URI
+					}
+				} `graphql:"createCommitOnBranch(input: $input)"`
+			}
+			if err := gqlClient.Mutate(ctx, &mutation, githubv4.CreateCommitOnBranchInput{
+				Branch: githubv4.CommittableBranch{
+					RepositoryNameWithOwner: githubv4.NewString(githubv4.String(owner + "/" + repo)),
+					BranchName:              githubv4.NewString(githubv4.String(branch)),
+				},
+				Message:         commitMessage,
+				ExpectedHeadOid: githubv4.GitObjectID(expectedHeadSHA),
+				FileChanges:     fileChanges,
+			}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create signed commit", err), nil
+			}
+
+			return MarshalledTextResult(SignedCommit{
+				SHA:    string(mutation.CreateCommitOnBranch.Commit.OID),
+				URL:    mutation.CreateCommitOnBranch.Commit.URL.String(),
+				Branch: branch,
+			}), nil
+		}
+}
+
+// ListTags creates a tool to list tags in a GitHub repository.
+func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_tags",
+			mcp.WithDescription(t("TOOL_LIST_TAGS_DESCRIPTION", "List git tags in a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TAGS_USER_TITLE", "List tags"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]*github.RepositoryTag](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+			WithFlatten(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
@@ -1284,6 +2022,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 				Title:        t("TOOL_GET_TAG_USER_TITLE", "Get tag details"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.Tag](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1371,6 +2110,7 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				Title:        t("TOOL_LIST_RELEASES_USER_TITLE", "List releases"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.RepositoryRelease](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1380,6 +2120,7 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Description("Repository name"),
 			),
 			WithPagination(),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1436,6 +2177,7 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_GET_LATEST_RELEASE_USER_TITLE", "Get latest release"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.RepositoryRelease](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1490,6 +2232,7 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 				Title:        t("TOOL_GET_RELEASE_BY_TAG_USER_TITLE", "Get a release by tag name"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.RepositoryRelease](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("Repository owner"),
@@ -1692,6 +2435,7 @@ func ListStarredRepositories(getClient GetClientFn, t translations.TranslationHe
 				Title:        t("TOOL_LIST_STARRED_REPOSITORIES_USER_TITLE", "List starred repositories"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]MinimalRepository](),
 			mcp.WithString("username",
 				mcp.Description("Username to list starred repositories for. Defaults to the authenticated user."),
 			),
@@ -1911,3 +2655,467 @@ func UnstarRepository(getClient GetClientFn, t translations.TranslationHelperFun
 			return mcp.NewToolResultText(fmt.Sprintf("Successfully unstarred repository %s/%s", owner, repo)), nil
 		}
 }
+
+// WatchRepository creates a tool to watch a repository.
+func WatchRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("watch_repository",
+			mcp.WithDescription(t("TOOL_WATCH_REPOSITORY_DESCRIPTION", "Watch a GitHub repository, getting notified of all its activity")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_WATCH_REPOSITORY_USER_TITLE", "Watch repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			_, resp, err := client.Activity.SetRepositorySubscription(ctx, owner, repo, &github.Subscription{
+				Subscribed: ToBoolPtr(true),
+				Ignored:    ToBoolPtr(false),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to watch repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully watched repository %s/%s", owner, repo)), nil
+		}
+}
+
+// UnwatchRepository creates a tool to stop watching a repository.
+func UnwatchRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unwatch_repository",
+			mcp.WithDescription(t("TOOL_UNWATCH_REPOSITORY_DESCRIPTION", "Stop watching a GitHub repository, only getting notified when participating or mentioned")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNWATCH_REPOSITORY_USER_TITLE", "Unwatch repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Activity.DeleteRepositorySubscription(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to unwatch repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Successfully unwatched repository %s/%s", owner, repo)), nil
+		}
+}
+
+// ListStargazers creates a tool to list the users who starred a repository.
+func ListStargazers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_stargazers",
+			mcp.WithDescription(t("TOOL_LIST_STARGAZERS_DESCRIPTION", "List the users who starred a repository with when they starred it, oldest first. Use the last page to get the most recent stargazers.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_STARGAZERS_USER_TITLE", "List stargazers"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalStargazer](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			stargazers, resp, err := client.Activity.ListStargazers(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list stargazers of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalStargazers := make([]MinimalStargazer, 0, len(stargazers))
+			for _, stargazer := range stargazers {
+				minimalStargazer := MinimalStargazer{}
+				if user := convertToMinimalUser(stargazer.GetUser()); user != nil {
+					minimalStargazer.MinimalUser = *user
+				}
+				if stargazer.StarredAt != nil {
+					minimalStargazer.StarredAt = stargazer.StarredAt.Format("2006-01-02T15:04:05Z")
+				}
+				minimalStargazers = append(minimalStargazers, minimalStargazer)
+			}
+
+			return MarshalledTextResult(minimalStargazers), nil
+		}
+}
+
+// ListForks creates a tool to list the forks of a repository.
+func ListForks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_forks",
+			mcp.WithDescription(t("TOOL_LIST_FORKS_DESCRIPTION", "List the forks of a repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_FORKS_USER_TITLE", "List forks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalRepository](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("How to sort the forks: 'newest' or 'oldest' fork first, most 'stargazers' or 'watchers' first, or most recently 'pushed' to first. Forks are always paginated newest first when sorted by 'pushed', which only sorts each page. Default is 'newest'."),
+				mcp.Enum("newest", "oldest", "stargazers", "watchers", "pushed"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sortBy, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.RepositoryListForksOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			// The API has no way to sort forks by activity
+			if sortBy != "pushed" {
+				opts.Sort = sortBy
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			forks, resp, err := client.Repositories.ListForks(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list forks of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if sortBy == "pushed" {
+				sort.SliceStable(forks, func(i, j int) bool {
+					return forks[i].GetPushedAt().After(forks[j].GetPushedAt().Time)
+				})
+			}
+
+			minimalForks := make([]MinimalRepository, 0, len(forks))
+			for _, fork := range forks {
+				minimalForks = append(minimalForks, convertToMinimalRepository(fork))
+			}
+
+			return MarshalledTextResult(minimalForks), nil
+		}
+}
+
+// ListWatchers creates a tool to list the users watching a repository.
+func ListWatchers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_watchers",
+			mcp.WithDescription(t("TOOL_LIST_WATCHERS_DESCRIPTION", "List the users watching a repository, who are notified of all its activity")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WATCHERS_USER_TITLE", "List watchers"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalUser](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			watchers, resp, err := client.Activity.ListWatchers(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list watchers of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalWatchers := make([]MinimalUser, 0, len(watchers))
+			for _, watcher := range watchers {
+				if user := convertToMinimalUser(watcher); user != nil {
+					minimalWatchers = append(minimalWatchers, *user)
+				}
+			}
+
+			return MarshalledTextResult(minimalWatchers), nil
+		}
+}
+
+// MinimalRepositoryLicense is the output type for the license detected in a repository.
+type MinimalRepositoryLicense struct {
+	Key     string `json:"key"`
+	SPDXID  string `json:"spdx_id,omitempty"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	HTMLURL string `json:"html_url,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// RepositoryLanguage is the number of bytes of code written in a language in a repository.
+type RepositoryLanguage struct {
+	Name       string  `json:"name"`
+	Bytes      int     `json:"bytes"`
+	Percentage float64 `json:"percentage"`
+}
+
+// GetRepositoryLicense creates a tool to get the license detected in a repository.
+func GetRepositoryLicense(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_license",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_LICENSE_DESCRIPTION", "Get the license GitHub detected in a repository, with the content of its license file. The key is \"other\" when the file doesn't match a known license.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_LICENSE_USER_TITLE", "Get repository license"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[MinimalRepositoryLicense](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("include_content",
+				mcp.Description("Whether to include the content of the license file. Default is true."),
+				mcp.DefaultBool(true),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeContent, err := OptionalBoolParamWithDefault(request, "include_content", true)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			license, resp, err := client.Repositories.License(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get license of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalLicense := MinimalRepositoryLicense{
+				Key:     license.GetLicense().GetKey(),
+				SPDXID:  license.GetLicense().GetSPDXID(),
+				Name:    license.GetLicense().GetName(),
+				Path:    license.GetPath(),
+				HTMLURL: license.GetHTMLURL(),
+			}
+			if includeContent {
+				content := license.GetContent()
+				if license.GetEncoding() == "base64" {
+					decoded, err := base64.StdEncoding.DecodeString(content)
+					if err != nil {
+						return nil, fmt.Errorf("failed to decode license content: %w", err)
+					}
+					content = string(decoded)
+				}
+				minimalLicense.Content = content
+			}
+
+			return MarshalledTextResult(minimalLicense), nil
+		}
+}
+
+// GetRepositoryLanguages creates a tool to get the languages a repository is written in.
+func GetRepositoryLanguages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_languages",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_LANGUAGES_DESCRIPTION", "Get the languages a repository is written in as detected by GitHub, with the number of bytes of code and the share of the code written in each, largest first")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_LANGUAGES_USER_TITLE", "Get repository languages"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]RepositoryLanguage](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			languageBytes, resp, err := client.Repositories.ListLanguages(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get languages of %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			total := 0
+			for _, bytes := range languageBytes {
+				total += bytes
+			}
+			languages := make([]RepositoryLanguage, 0, len(languageBytes))
+			for name, bytes := range languageBytes {
+				// Shares are rounded to a tenth of a percent
+				languages = append(languages, RepositoryLanguage{
+					Name:       name,
+					Bytes:      bytes,
+					Percentage: math.Round(float64(bytes)*1000/float64(total)) / 10,
+				})
+			}
+			sort.Slice(languages, func(i, j int) bool {
+				if languages[i].Bytes != languages[j].Bytes {
+					return languages[i].Bytes > languages[j].Bytes
+				}
+				return languages[i].Name < languages[j].Name
+			})
+
+			return MarshalledTextResult(languages), nil
+		}
+}
@@ -10,12 +10,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/lfs"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,7 +27,8 @@ func Test_GetFileContents(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
 	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
-	tool, _ := GetFileContents(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	mockLFSClient := lfs.NewClient(mockClient, &url.URL{Scheme: "https", Host: "github.com", Path: "/"}, http.DefaultClient)
+	tool, _ := GetFileContents(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), stubGetLFSClientFn(mockLFSClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "get_file_contents", tool.Name)
@@ -38,6 +42,10 @@ func Test_GetFileContents(t *testing.T) {
 
 	// Mock response for raw content
 	mockRawContent := []byte("# Test Repository\n\nThis is a test repository.")
+	mockPNGContent := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01")
+	mockPDFContent := []byte("%PDF-1.7\n\x00\xff\xfe binary data")
+	mockLFSPointer := lfs.NewPointer(mockPNGContent)
+	mockLFSObject := mock.EndpointPattern{Pattern: "/lfs-objects/{oid}", Method: "GET"}
 
 	// Setup mock directory content for success case
 	mockDirContent := []*github.RepositoryContent{
@@ -113,7 +121,7 @@ func Test_GetFileContents(t *testing.T) {
 			},
 		},
 		{
-			name: "successful file blob content fetch",
+			name: "successful image content fetch",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetReposGitRefByOwnerByRepoByRef,
@@ -140,7 +148,7 @@ func Test_GetFileContents(t *testing.T) {
 					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 						w.Header().Set("Content-Type", "image/png")
-						_, _ = w.Write(mockRawContent)
+						_, _ = w.Write(mockPNGContent)
 					}),
 				),
 			),
@@ -151,12 +159,331 @@ func Test_GetFileContents(t *testing.T) {
 				"ref":   "refs/heads/main",
 			},
 			expectError: false,
+			expectedResult: mcp.ImageContent{
+				Type:     "image",
+				Data:     base64.StdEncoding.EncodeToString(mockPNGContent),
+				MIMEType: "image/png",
+			},
+		},
+		{
+			name: "successful file blob content fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						fileContent := &github.RepositoryContent{
+							Name: github.Ptr("doc.pdf"),
+							Path: github.Ptr("doc.pdf"),
+							SHA:  github.Ptr("def456"),
+							Type: github.Ptr("file"),
+						}
+						contentBytes, _ := json.Marshal(fileContent)
+						_, _ = w.Write(contentBytes)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "application/octet-stream")
+						_, _ = w.Write(mockPDFContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "doc.pdf",
+				"ref":   "refs/heads/main",
+			},
+			expectError: false,
 			expectedResult: mcp.BlobResourceContents{
-				URI:      "repo://owner/repo/refs/heads/main/contents/test.png",
-				Blob:     base64.StdEncoding.EncodeToString(mockRawContent),
+				URI:      "repo://owner/repo/refs/heads/main/contents/doc.pdf",
+				Blob:     base64.StdEncoding.EncodeToString(mockPDFContent),
+				MIMEType: "application/pdf",
+			},
+		},
+		{
+			name: "text served with a binary content type is returned as text",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						fileContent := &github.RepositoryContent{
+							Name: github.Ptr("notes.txt"),
+							Path: github.Ptr("notes.txt"),
+							SHA:  github.Ptr("def456"),
+							Type: github.Ptr("file"),
+						}
+						contentBytes, _ := json.Marshal(fileContent)
+						_, _ = w.Write(contentBytes)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "application/octet-stream")
+						_, _ = w.Write(mockRawContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "notes.txt",
+				"ref":   "refs/heads/main",
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/notes.txt",
+				Text:     "# Test Repository\n\nThis is a test repository.",
+				MIMEType: "application/octet-stream",
+			},
+		},
+		{
+			name: "LFS pointer is resolved to its object",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Name: github.Ptr("logo.png"),
+						Path: github.Ptr("logo.png"),
+						SHA:  github.Ptr("ghi789"),
+						Type: github.Ptr("file"),
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+						_, _ = w.Write([]byte(mockLFSPointer.String()))
+					}),
+				),
+				mock.WithRequestMatch(
+					lfs.PostLFSObjectsBatchByOwnerByRepo,
+					map[string]any{"objects": []map[string]any{{
+						"oid":     mockLFSPointer.OID,
+						"size":    mockLFSPointer.Size,
+						"actions": map[string]any{"download": map[string]any{"href": "https://lfs-storage.example.com/lfs-objects/" + mockLFSPointer.OID}},
+					}}},
+				),
+				mock.WithRequestMatchHandler(
+					mockLFSObject,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						_, _ = w.Write(mockPNGContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "logo.png",
+				"ref":   "refs/heads/main",
+			},
+			expectError: false,
+			expectedResult: mcp.ImageContent{
+				Type:     "image",
+				Data:     base64.StdEncoding.EncodeToString(mockPNGContent),
 				MIMEType: "image/png",
 			},
 		},
+		{
+			name: "LFS pointer metadata is returned when the object can't be downloaded",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Name: github.Ptr("logo.png"),
+						Path: github.Ptr("logo.png"),
+						SHA:  github.Ptr("ghi789"),
+						Type: github.Ptr("file"),
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+						_, _ = w.Write([]byte(mockLFSPointer.String()))
+					}),
+				),
+				mock.WithRequestMatch(
+					lfs.PostLFSObjectsBatchByOwnerByRepo,
+					map[string]any{"objects": []map[string]any{{
+						"oid":   mockLFSPointer.OID,
+						"size":  mockLFSPointer.Size,
+						"error": map[string]any{"code": 404, "message": "Object does not exist"},
+					}}},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "logo.png",
+				"ref":   "refs/heads/main",
+			},
+			expectError: false,
+			expectedResult: LFSPointerResult{
+				Path:   "logo.png",
+				SHA:    "ghi789",
+				LFS:    true,
+				OID:    mockLFSPointer.OID,
+				Size:   mockLFSPointer.Size,
+				Reason: "failed to download the LFS object: LFS object " + mockLFSPointer.OID + ": Object does not exist (404)",
+			},
+		},
+		{
+			name: "byte range is streamed from a blob when the API returns the full content",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Name: github.Ptr("big.txt"),
+						Path: github.Ptr("big.txt"),
+						SHA:  github.Ptr("blob123"),
+						Size: github.Ptr(16),
+						Type: github.Ptr("file"),
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "bytes=4-11", r.Header.Get("Range"))
+						assert.Equal(t, "application/vnd.github.raw", r.Header.Get("Accept"))
+						_, _ = w.Write([]byte("0123456789abcdef"))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"path":   "big.txt",
+				"ref":    "refs/heads/main",
+				"offset": float64(4),
+				"length": float64(8),
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/sha/blob123/contents/big.txt",
+				Text:     "456789ab",
+				MIMEType: "text/plain; charset=utf-8",
+			},
+		},
+		{
+			name: "byte range honored by the API drops partial characters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Name: github.Ptr("big.txt"),
+						Path: github.Ptr("big.txt"),
+						SHA:  github.Ptr("blob123"),
+						Size: github.Ptr(6),
+						Type: github.Ptr("file"),
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusPartialContent)
+						_, _ = w.Write([]byte("h\u00e9llo")[2:5])
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"path":   "big.txt",
+				"ref":    "refs/heads/main",
+				"offset": float64(2),
+				"length": float64(3),
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/sha/blob123/contents/big.txt",
+				Text:     "ll",
+				MIMEType: "text/plain; charset=utf-8",
+			},
+		},
+		{
+			name: "byte range past the end of the file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Name: github.Ptr("big.txt"),
+						Path: github.Ptr("big.txt"),
+						SHA:  github.Ptr("blob123"),
+						Size: github.Ptr(16),
+						Type: github.Ptr("file"),
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+						t.Error("the blob should not be requested")
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"path":   "big.txt",
+				"ref":    "refs/heads/main",
+				"offset": float64(16),
+			},
+			expectError:    false,
+			expectedResult: mcp.NewTextContent("offset 16 is past the end of the 16 byte file"),
+		},
 		{
 			name: "successful directory content fetch",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -231,50 +558,196 @@ func Test_GetFileContents(t *testing.T) {
 			expectError:    false,
 			expectedResult: mcp.NewToolResultError("Failed to get file contents. The path does not point to a file or directory, or the file does not exist in the repository."),
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
-			client := github.NewClient(tc.mockedClient)
-			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
-			_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
-
-			// Call handler
-			result, err := handler(context.Background(), request)
-
-			// Verify results
-			if tc.expectError {
-				require.Error(t, err)
-				assert.Contains(t, err.Error(), tc.expectedErrMsg)
-				return
-			}
-
-			require.NoError(t, err)
-			// Use the correct result helper based on the expected type
-			switch expected := tc.expectedResult.(type) {
-			case mcp.TextResourceContents:
-				textResource := getTextResourceResult(t, result)
-				assert.Equal(t, expected, textResource)
-			case mcp.BlobResourceContents:
-				blobResource := getBlobResourceResult(t, result)
-				assert.Equal(t, expected, blobResource)
-			case []*github.RepositoryContent:
-				// Directory content fetch returns a text result (JSON array)
-				textContent := getTextResult(t, result)
-				var returnedContents []*github.RepositoryContent
-				err = json.Unmarshal([]byte(textContent.Text), &returnedContents)
-				require.NoError(t, err, "Failed to unmarshal directory content result: %v", textContent.Text)
-				assert.Len(t, returnedContents, len(expected))
-				for i, content := range returnedContents {
-					assert.Equal(t, *expected[i].Name, *content.Name)
-					assert.Equal(t, *expected[i].Path, *content.Path)
-					assert.Equal(t, *expected[i].Type, *content.Type)
-				}
-			case mcp.TextContent:
+		{
+			name:         "ref and sha cannot be used together",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "README.md",
+				"ref":   "refs/heads/main",
+				"sha":   "abc123",
+			},
+			expectError:    false,
+			expectedResult: mcp.TextContent{Type: "text", Text: "parameters ref and sha cannot be used together"},
+		},
+		{
+			name: "symlink to a file is resolved",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type: github.Ptr("file"),
+						Name: github.Ptr("README.md"),
+						Path: github.Ptr("docs/README.md"),
+						SHA:  github.Ptr("abc123"),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					expectPath(t, "/owner/repo/refs/heads/main/docs/README.md").andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Content-Type", "text/markdown")
+							_, _ = w.Write(mockRawContent)
+						},
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "README.md",
+				"ref":   "refs/heads/main",
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/docs/README.md",
+				Text:     "# Test Repository\n\nThis is a test repository.",
+				MIMEType: "text/markdown",
+			},
+		},
+		{
+			name: "symlink to a directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type:   github.Ptr("symlink"),
+						Name:   github.Ptr("current"),
+						Path:   github.Ptr("releases/current"),
+						SHA:    github.Ptr("abc123"),
+						Target: github.Ptr("../versions/v2"),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "releases/current",
+				"ref":   "refs/heads/main",
+			},
+			expectError: false,
+			expectedResult: SymlinkResult{
+				Type:       "symlink",
+				Path:       "releases/current",
+				Target:     "../versions/v2",
+				TargetPath: "versions/v2",
+			},
+		},
+		{
+			name: "submodule",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type:            github.Ptr("submodule"),
+						Name:            github.Ptr("vendor-lib"),
+						Path:            github.Ptr("third_party/vendor-lib"),
+						SHA:             github.Ptr("0123456789abcdef"),
+						SubmoduleGitURL: github.Ptr("https://github.com/other/vendor-lib.git"),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "third_party/vendor-lib",
+				"ref":   "refs/heads/main",
+			},
+			expectError: false,
+			expectedResult: SubmoduleResult{
+				Type:   "submodule",
+				Path:   "third_party/vendor-lib",
+				SHA:    "0123456789abcdef",
+				GitURL: "https://github.com/other/vendor-lib.git",
+				Owner:  "other",
+				Repo:   "vendor-lib",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			mockLFSClient := lfs.NewClient(client, &url.URL{Scheme: "https", Host: "lfs.example.com", Path: "/"}, tc.mockedClient)
+			_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(mockRawClient), stubGetLFSClientFn(mockLFSClient), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			// Use the correct result helper based on the expected type
+			switch expected := tc.expectedResult.(type) {
+			case mcp.TextResourceContents:
+				textResource := getTextResourceResult(t, result)
+				assert.Equal(t, expected, textResource)
+			case mcp.BlobResourceContents:
+				blobResource := getBlobResourceResult(t, result)
+				assert.Equal(t, expected, blobResource)
+			case mcp.ImageContent:
+				require.Len(t, result.Content, 2)
+				assert.Equal(t, expected, result.Content[1])
+			case LFSPointerResult:
+				textContent := getTextResult(t, result)
+				var returned LFSPointerResult
+				require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+				assert.Equal(t, expected, returned)
+			case SymlinkResult:
+				textContent := getTextResult(t, result)
+				var returned SymlinkResult
+				require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+				assert.Equal(t, expected, returned)
+			case SubmoduleResult:
+				textContent := getTextResult(t, result)
+				var returned SubmoduleResult
+				require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+				assert.Equal(t, expected, returned)
+			case []*github.RepositoryContent:
+				// Directory content fetch returns a text result (JSON array)
+				textContent := getTextResult(t, result)
+				var returnedContents []*github.RepositoryContent
+				err = json.Unmarshal([]byte(textContent.Text), &returnedContents)
+				require.NoError(t, err, "Failed to unmarshal directory content result: %v", textContent.Text)
+				assert.Len(t, returnedContents, len(expected))
+				for i, content := range returnedContents {
+					assert.Equal(t, *expected[i].Name, *content.Name)
+					assert.Equal(t, *expected[i].Path, *content.Path)
+					assert.Equal(t, *expected[i].Type, *content.Type)
+				}
+			case mcp.TextContent:
 				textContent := getErrorResult(t, result)
 				require.Equal(t, textContent, expected)
 			}
@@ -282,6 +755,32 @@ func Test_GetFileContents(t *testing.T) {
 	}
 }
 
+func Test_parseSubmoduleURL(t *testing.T) {
+	tests := []struct {
+		gitURL        string
+		expectedOwner string
+		expectedRepo  string
+	}{
+		{gitURL: "https://github.com/other/lib.git", expectedOwner: "other", expectedRepo: "lib"},
+		{gitURL: "https://github.com/other/lib", expectedOwner: "other", expectedRepo: "lib"},
+		{gitURL: "git@github.com:other/lib.git", expectedOwner: "other", expectedRepo: "lib"},
+		{gitURL: "ssh://git@ghe.example.com/other/lib.git", expectedOwner: "other", expectedRepo: "lib"},
+		{gitURL: "../lib.git", expectedOwner: "owner", expectedRepo: "lib"},
+		{gitURL: "../../other/lib.git", expectedOwner: "other", expectedRepo: "lib"},
+		{gitURL: "../../../lib.git"},
+		{gitURL: "https://gitlab.com/group/subgroup/lib.git"},
+		{gitURL: "lib"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.gitURL, func(t *testing.T) {
+			owner, repo := parseSubmoduleURL("owner", tc.gitURL)
+			assert.Equal(t, tc.expectedOwner, owner)
+			assert.Equal(t, tc.expectedRepo, repo)
+		})
+	}
+}
+
 func Test_ForkRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -720,6 +1219,10 @@ func Test_ListCommits(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
 	assert.Contains(t, tool.InputSchema.Properties, "author")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
+	assert.Contains(t, tool.InputSchema.Properties, "newer_than")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
@@ -868,6 +1371,59 @@ func Test_ListCommits(t *testing.T) {
 			expectError:     false,
 			expectedCommits: mockCommits,
 		},
+		{
+			name: "successful commits fetch with path and time filters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"path":     "src/main.go",
+						"since":    "2025-01-01T00:00:00Z",
+						"until":    "2025-02-01T12:00:00Z",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCommits),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "src/main.go",
+				"since": "2025-01-01",
+				"until": "2025-02-01T12:00:00Z",
+			},
+			expectError:     false,
+			expectedCommits: mockCommits,
+		},
+		{
+			name: "only commits newer than a known SHA",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepo,
+					mockCommits,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"newer_than": "def456a",
+			},
+			expectError:     false,
+			expectedCommits: mockCommits[:1],
+		},
+		{
+			name:         "invalid since timestamp",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "not-a-date",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid ISO 8601 timestamp",
+		},
 		{
 			name: "commits fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -941,7 +1497,8 @@ func Test_ListCommits(t *testing.T) {
 func Test_CreateOrUpdateFile(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := CreateOrUpdateFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	mockLFSClient := lfs.NewClient(mockClient, &url.URL{Scheme: "https", Host: "github.com", Path: "/"}, http.DefaultClient)
+	tool, _ := CreateOrUpdateFile(stubGetClientFn(mockClient), stubGetLFSClientFn(mockLFSClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "create_or_update_file", tool.Name)
@@ -950,6 +1507,7 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "path")
 	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "encoding")
 	assert.Contains(t, tool.InputSchema.Properties, "message")
 	assert.Contains(t, tool.InputSchema.Properties, "branch")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
@@ -1037,6 +1595,85 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 			expectError:     false,
 			expectedContent: mockFileResponse,
 		},
+		{
+			name: "successful binary file creation from base64 content",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Add logo",
+						"content": "iVBORw0KGgoAAAANSUhEUg==", // Passed through without being encoded twice
+						"branch":  "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"path":     "docs/logo.png",
+				"content":  "iVBORw0KGgoAAAANSUhEUg==",
+				"encoding": "base64",
+				"message":  "Add logo",
+				"branch":   "main",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "successful LFS file creation commits a pointer",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					lfs.PostLFSObjectsBatchByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"operation": "upload",
+						"transfers": []interface{}{"basic"},
+						"ref":       map[string]interface{}{"name": "refs/heads/main"},
+						"objects":   []interface{}{map[string]interface{}{"oid": lfs.NewPointer([]byte("large asset")).OID, "size": float64(11)}},
+					}).andThen(
+						// The object is already stored, so there is nothing to upload
+						mockResponse(t, http.StatusOK, map[string]any{"objects": []map[string]any{{"oid": lfs.NewPointer([]byte("large asset")).OID, "size": 11}}}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Add asset",
+						"content": base64.StdEncoding.EncodeToString([]byte(lfs.NewPointer([]byte("large asset")).String())),
+						"branch":  "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "assets/asset.bin",
+				"content": "large asset",
+				"lfs":     true,
+				"message": "Add asset",
+				"branch":  "main",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name:         "invalid base64 content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"path":     "docs/logo.png",
+				"content":  "not base64!",
+				"encoding": "base64",
+				"message":  "Add logo",
+				"branch":   "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "content is not valid base64",
+		},
 		{
 			name: "file creation fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1065,7 +1702,8 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := CreateOrUpdateFile(stubGetClientFn(client), translations.NullTranslationHelper)
+			mockLFSClient := lfs.NewClient(client, &url.URL{Scheme: "https", Host: "lfs.example.com", Path: "/"}, tc.mockedClient)
+			_, handler := CreateOrUpdateFile(stubGetClientFn(client), stubGetLFSClientFn(mockLFSClient), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1731,44 +2369,270 @@ func Test_ListBranches(t *testing.T) {
 	}
 }
 
-func Test_DeleteFile(t *testing.T) {
+func Test_GetBranchDivergence(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := DeleteFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetBranchDivergence(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "delete_file", tool.Name)
+	assert.Equal(t, "get_branch_divergence", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "path")
-	assert.Contains(t, tool.InputSchema.Properties, "message")
-	assert.Contains(t, tool.InputSchema.Properties, "branch")
-	// SHA is no longer required since we're using Git Data API
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "message", "branch"})
-
-	// Setup mock objects for Git Data API
-	mockRef := &github.Reference{
-		Ref: github.Ptr("refs/heads/main"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("abc123"),
-		},
-	}
-
-	mockCommit := &github.Commit{
-		SHA: github.Ptr("abc123"),
-		Tree: &github.Tree{
-			SHA: github.Ptr("def456"),
-		},
-	}
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
 
-	mockTree := &github.Tree{
-		SHA: github.Ptr("ghi789"),
+	mockComparison := &github.CommitsComparison{
+		Status:          github.Ptr("diverged"),
+		AheadBy:         github.Ptr(2),
+		BehindBy:        github.Ptr(3),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("abc123")},
 	}
 
-	mockNewCommit := &github.Commit{
-		SHA:     github.Ptr("jkl012"),
-		Message: github.Ptr("Delete example file"),
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult BranchDivergence
+		expectedErrMsg string
+	}{
+		{
+			name: "compares with the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/compare/main...feature", r.URL.Path)
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(mockComparison)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "feature",
+			},
+			expectedResult: BranchDivergence{
+				Base:         "main",
+				Head:         "feature",
+				Status:       "diverged",
+				AheadBy:      2,
+				BehindBy:     3,
+				MergeBaseSHA: "abc123",
+				NeedsUpdate:  true,
+			},
+		},
+		{
+			name: "compares with another branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/compare/release...feature", r.URL.Path)
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(&github.CommitsComparison{
+							Status:          github.Ptr("ahead"),
+							AheadBy:         github.Ptr(1),
+							BehindBy:        github.Ptr(0),
+							MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("def456")},
+						})
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "feature",
+				"base":   "release",
+			},
+			expectedResult: BranchDivergence{
+				Base:         "release",
+				Head:         "feature",
+				Status:       "ahead",
+				AheadBy:      1,
+				MergeBaseSHA: "def456",
+			},
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "missing",
+				"base":   "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare missing with main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchDivergence(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var divergence BranchDivergence
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &divergence))
+			assert.Equal(t, tc.expectedResult, divergence)
+		})
+	}
+}
+
+func Test_GetMergeBase(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMergeBase(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_merge_base", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedResult MinimalCommit
+		expectedErrMsg string
+	}{
+		{
+			name: "returns the merge base",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/compare/v1.0...feature", r.URL.Path)
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(&github.CommitsComparison{
+							MergeBaseCommit: &github.RepositoryCommit{
+								SHA:     github.Ptr("abc123"),
+								HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
+								Commit:  &github.Commit{Message: github.Ptr("Release 1.0")},
+							},
+						})
+					}),
+				),
+			),
+			expectedResult: MinimalCommit{
+				SHA:     "abc123",
+				HTMLURL: "https://github.com/owner/repo/commit/abc123",
+				Commit:  &MinimalCommitInfo{Message: "Release 1.0"},
+			},
+		},
+		{
+			name: "refs without a common ancestor",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "No common ancestor between v1.0 and feature."}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get merge base of v1.0 and feature",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetMergeBase(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.0",
+				"head":  "feature",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var commit MinimalCommit
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &commit))
+			assert.Equal(t, tc.expectedResult, commit)
+		})
+	}
+}
+
+func Test_DeleteFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	// SHA is no longer required since we're using Git Data API
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "message", "branch"})
+
+	// Setup mock objects for Git Data API
+	mockRef := &github.Reference{
+		Ref: github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("abc123"),
+		},
+	}
+
+	mockCommit := &github.Commit{
+		SHA: github.Ptr("abc123"),
+		Tree: &github.Tree{
+			SHA: github.Ptr("def456"),
+		},
+	}
+
+	mockTree := &github.Tree{
+		SHA: github.Ptr("ghi789"),
+	}
+
+	mockNewCommit := &github.Commit{
+		SHA:     github.Ptr("jkl012"),
+		Message: github.Ptr("Delete example file"),
 		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
 	}
 
@@ -1909,81 +2773,279 @@ func Test_DeleteFile(t *testing.T) {
 	}
 }
 
-func Test_ListTags(t *testing.T) {
+func Test_CreateSignedCommit(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := ListTags(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := CreateSignedCommit(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "list_tags", tool.Name)
+	assert.Equal(t, "create_signed_commit", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.Contains(t, tool.InputSchema.Properties, "files")
+	assert.Contains(t, tool.InputSchema.Properties, "deletions")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_head_sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "message"})
 
-	// Setup mock tags for success case
-	mockTags := []*github.RepositoryTag{
-		{
-			Name: github.Ptr("v1.0.0"),
-			Commit: &github.Commit{
-				SHA: github.Ptr("v1.0.0-tag-sha"),
-				URL: github.Ptr("https://api.github.com/repos/owner/repo/commits/abc123"),
-			},
-			ZipballURL: github.Ptr("https://github.com/owner/repo/zipball/v1.0.0"),
-			TarballURL: github.Ptr("https://github.com/owner/repo/tarball/v1.0.0"),
-		},
-		{
-			Name: github.Ptr("v0.9.0"),
-			Commit: &github.Commit{
-				SHA: github.Ptr("v0.9.0-tag-sha"),
-				URL: github.Ptr("https://api.github.com/repos/owner/repo/commits/def456"),
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("abc123")},
+	}
+	commitMutation := struct {
+		CreateCommitOnBranch struct {
+			Commit struct {
+				OID githubv4.GitObjectID `graphql:"oid"`
+				URL githubv4.URI
+			}
+		} `graphql:"createCommitOnBranch(input: $input)"`
+	}{}
+	commitResponse := githubv4mock.DataResponse(map[string]any{
+		"createCommitOnBranch": map[string]any{
+			"commit": map[string]any{
+				"oid": "def456",
+				"url": "https://github.com/owner/repo/commit/def456",
 			},
-			ZipballURL: github.Ptr("https://github.com/owner/repo/zipball/v0.9.0"),
-			TarballURL: github.Ptr("https://github.com/owner/repo/tarball/v0.9.0"),
 		},
-	}
+	})
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedTags   []*github.RepositoryTag
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		mockedGQLClient *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedResult  SignedCommit
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful tags list",
+			name: "commits changes on the head of the branch",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.GetReposTagsByOwnerByRepo,
-					expectPath(
-						t,
-						"/repos/owner/repo/tags",
-					).andThen(
-						mockResponse(t, http.StatusOK, mockTags),
-					),
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					commitMutation,
+					githubv4.CreateCommitOnBranchInput{
+						Branch: githubv4.CommittableBranch{
+							RepositoryNameWithOwner: githubv4.NewString("owner/repo"),
+							BranchName:              githubv4.NewString("main"),
+						},
+						Message: githubv4.CommitMessage{
+							Headline: "Update docs",
+							Body:     githubv4.NewString("Remove the old guide."),
+						},
+						ExpectedHeadOid: "abc123",
+						FileChanges: &githubv4.FileChanges{
+							Additions: &[]githubv4.FileAddition{
+								{
+									Path:     "README.md",
+									Contents: githubv4.Base64String(base64.StdEncoding.EncodeToString([]byte("# Repo\n"))),
+								},
+							},
+							Deletions: &[]githubv4.FileDeletion{
+								{Path: "docs/old.md"},
+							},
+						},
+					},
+					nil,
+					commitResponse,
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update docs\n\nRemove the old guide.",
+				"files": []interface{}{
+					map[string]interface{}{"path": "README.md", "content": "# Repo\n"},
+				},
+				"deletions": []interface{}{"docs/old.md"},
+			},
+			expectedResult: SignedCommit{
+				SHA:    "def456",
+				URL:    "https://github.com/owner/repo/commit/def456",
+				Branch: "main",
 			},
-			expectError:  false,
-			expectedTags: mockTags,
 		},
 		{
-			name: "list tags fails",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.GetReposTagsByOwnerByRepo,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusInternalServerError)
-						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
-					}),
+			name:         "commits on the expected head",
+			mockedClient: mock.NewMockedHTTPClient(),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					commitMutation,
+					githubv4.CreateCommitOnBranchInput{
+						Branch: githubv4.CommittableBranch{
+							RepositoryNameWithOwner: githubv4.NewString("owner/repo"),
+							BranchName:              githubv4.NewString("main"),
+						},
+						Message:         githubv4.CommitMessage{Headline: "Remove old guide"},
+						ExpectedHeadOid: "fed789",
+						FileChanges: &githubv4.FileChanges{
+							Deletions: &[]githubv4.FileDeletion{
+								{Path: "docs/old.md"},
+							},
+						},
+					},
+					nil,
+					commitResponse,
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "owner",
+				"owner":             "owner",
+				"repo":              "repo",
+				"branch":            "main",
+				"message":           "Remove old guide",
+				"deletions":         []interface{}{"docs/old.md"},
+				"expected_head_sha": "fed789",
+			},
+			expectedResult: SignedCommit{
+				SHA:    "def456",
+				URL:    "https://github.com/owner/repo/commit/def456",
+				Branch: "main",
+			},
+		},
+		{
+			name:            "no changes",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Nothing",
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one file or deletion is required",
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"branch":    "missing",
+				"message":   "Remove old guide",
+				"deletions": []interface{}{"docs/old.md"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get branch reference",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup clients with mocks
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.mockedGQLClient)
+			_, handler := CreateSignedCommit(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var commit SignedCommit
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &commit))
+			assert.Equal(t, tc.expectedResult, commit)
+		})
+	}
+}
+
+func Test_ListTags(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTags(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_tags", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Setup mock tags for success case
+	mockTags := []*github.RepositoryTag{
+		{
+			Name: github.Ptr("v1.0.0"),
+			Commit: &github.Commit{
+				SHA: github.Ptr("v1.0.0-tag-sha"),
+				URL: github.Ptr("https://api.github.com/repos/owner/repo/commits/abc123"),
+			},
+			ZipballURL: github.Ptr("https://github.com/owner/repo/zipball/v1.0.0"),
+			TarballURL: github.Ptr("https://github.com/owner/repo/tarball/v1.0.0"),
+		},
+		{
+			Name: github.Ptr("v0.9.0"),
+			Commit: &github.Commit{
+				SHA: github.Ptr("v0.9.0-tag-sha"),
+				URL: github.Ptr("https://api.github.com/repos/owner/repo/commits/def456"),
+			},
+			ZipballURL: github.Ptr("https://github.com/owner/repo/zipball/v0.9.0"),
+			TarballURL: github.Ptr("https://github.com/owner/repo/tarball/v0.9.0"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedTags   []*github.RepositoryTag
+		expectedErrMsg string
+	}{
+		{
+			name: "successful tags list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTagsByOwnerByRepo,
+					expectPath(
+						t,
+						"/repos/owner/repo/tags",
+					).andThen(
+						mockResponse(t, http.StatusOK, mockTags),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:  false,
+			expectedTags: mockTags,
+		},
+		{
+			name: "list tags fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTagsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
 				"repo":  "repo",
 			},
 			expectError:    true,
@@ -2887,42 +3949,532 @@ func Test_ListStarredRepositories(t *testing.T) {
 	updatedAt := time.Now().Add(-2 * time.Hour)
 	mockStarredRepos := []*github.StarredRepository{
 		{
-			StarredAt: &github.Timestamp{Time: starredAt},
-			Repository: &github.Repository{
-				ID:              github.Ptr(int64(12345)),
-				Name:            github.Ptr("awesome-repo"),
-				FullName:        github.Ptr("owner/awesome-repo"),
-				Description:     github.Ptr("An awesome repository"),
-				HTMLURL:         github.Ptr("https://github.com/owner/awesome-repo"),
-				Language:        github.Ptr("Go"),
-				StargazersCount: github.Ptr(100),
-				ForksCount:      github.Ptr(25),
-				OpenIssuesCount: github.Ptr(5),
-				UpdatedAt:       &github.Timestamp{Time: updatedAt},
-				Private:         github.Ptr(false),
-				Fork:            github.Ptr(false),
-				Archived:        github.Ptr(false),
-				DefaultBranch:   github.Ptr("main"),
-			},
+			StarredAt: &github.Timestamp{Time: starredAt},
+			Repository: &github.Repository{
+				ID:              github.Ptr(int64(12345)),
+				Name:            github.Ptr("awesome-repo"),
+				FullName:        github.Ptr("owner/awesome-repo"),
+				Description:     github.Ptr("An awesome repository"),
+				HTMLURL:         github.Ptr("https://github.com/owner/awesome-repo"),
+				Language:        github.Ptr("Go"),
+				StargazersCount: github.Ptr(100),
+				ForksCount:      github.Ptr(25),
+				OpenIssuesCount: github.Ptr(5),
+				UpdatedAt:       &github.Timestamp{Time: updatedAt},
+				Private:         github.Ptr(false),
+				Fork:            github.Ptr(false),
+				Archived:        github.Ptr(false),
+				DefaultBranch:   github.Ptr("main"),
+			},
+		},
+		{
+			StarredAt: &github.Timestamp{Time: starredAt.Add(-12 * time.Hour)},
+			Repository: &github.Repository{
+				ID:              github.Ptr(int64(67890)),
+				Name:            github.Ptr("cool-project"),
+				FullName:        github.Ptr("user/cool-project"),
+				Description:     github.Ptr("A very cool project"),
+				HTMLURL:         github.Ptr("https://github.com/user/cool-project"),
+				Language:        github.Ptr("Python"),
+				StargazersCount: github.Ptr(500),
+				ForksCount:      github.Ptr(75),
+				OpenIssuesCount: github.Ptr(10),
+				UpdatedAt:       &github.Timestamp{Time: updatedAt.Add(-1 * time.Hour)},
+				Private:         github.Ptr(false),
+				Fork:            github.Ptr(true),
+				Archived:        github.Ptr(false),
+				DefaultBranch:   github.Ptr("master"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedCount  int
+	}{
+		{
+			name: "successful list for authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserStarred,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write(mock.MustMarshal(mockStarredRepos))
+					}),
+				),
+			),
+			requestArgs:   map[string]interface{}{},
+			expectError:   false,
+			expectedCount: 2,
+		},
+		{
+			name: "successful list for specific user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersStarredByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write(mock.MustMarshal(mockStarredRepos))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "testuser",
+			},
+			expectError:   false,
+			expectedCount: 2,
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserStarred,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "failed to list starred repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListStarredRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NotNil(t, result)
+				textResult, ok := result.Content[0].(mcp.TextContent)
+				require.True(t, ok, "Expected text content")
+				assert.Contains(t, textResult.Text, tc.expectedErrMsg)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+
+				// Parse the result and get the text content
+				textContent := getTextResult(t, result)
+
+				// Unmarshal and verify the result
+				var returnedRepos []MinimalRepository
+				err = json.Unmarshal([]byte(textContent.Text), &returnedRepos)
+				require.NoError(t, err)
+
+				assert.Len(t, returnedRepos, tc.expectedCount)
+				if tc.expectedCount > 0 {
+					assert.Equal(t, "awesome-repo", returnedRepos[0].Name)
+					assert.Equal(t, "owner/awesome-repo", returnedRepos[0].FullName)
+				}
+			}
+		})
+	}
+}
+
+func Test_StarRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := StarRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "star_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful star",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutUserStarredByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "testowner",
+				"repo":  "testrepo",
+			},
+			expectError: false,
+		},
+		{
+			name: "star fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutUserStarredByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "testowner",
+				"repo":  "nonexistent",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to star repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := StarRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NotNil(t, result)
+				textResult, ok := result.Content[0].(mcp.TextContent)
+				require.True(t, ok, "Expected text content")
+				assert.Contains(t, textResult.Text, tc.expectedErrMsg)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+
+				// Parse the result and get the text content
+				textContent := getTextResult(t, result)
+				assert.Contains(t, textContent.Text, "Successfully starred repository")
+			}
+		})
+	}
+}
+
+func Test_UnstarRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UnstarRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unstar_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful unstar",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteUserStarredByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "testowner",
+				"repo":  "testrepo",
+			},
+			expectError: false,
+		},
+		{
+			name: "unstar fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteUserStarredByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "testowner",
+				"repo":  "nonexistent",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to unstar repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UnstarRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NotNil(t, result)
+				textResult, ok := result.Content[0].(mcp.TextContent)
+				require.True(t, ok, "Expected text content")
+				assert.Contains(t, textResult.Text, tc.expectedErrMsg)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+
+				// Parse the result and get the text content
+				textContent := getTextResult(t, result)
+				assert.Contains(t, textContent.Text, "Successfully unstarred repository")
+			}
+		})
+	}
+}
+
+func Test_WatchRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := WatchRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "watch_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful watch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposSubscriptionByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"subscribed": true, "ignored": false}).andThen(
+						mockResponse(t, http.StatusOK, &github.Subscription{Subscribed: github.Ptr(true)}),
+					),
+				),
+			),
+		},
+		{
+			name: "watch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposSubscriptionByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to watch repository testowner/testrepo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := WatchRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "testowner",
+				"repo":  "testrepo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			assert.Equal(t, "Successfully watched repository testowner/testrepo", getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_UnwatchRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UnwatchRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unwatch_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposSubscriptionByOwnerByRepo,
+			expectPath(t, "/repos/testowner/testrepo/subscription").andThen(
+				mockResponse(t, http.StatusNoContent, ""),
+			),
+		),
+	))
+	_, handler := UnwatchRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "testowner",
+		"repo":  "testrepo",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "Successfully unwatched repository testowner/testrepo", getTextResult(t, result).Text)
+}
+
+func Test_ListStargazers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListStargazers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_stargazers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	starredAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockStargazers := []*github.Stargazer{
+		{
+			StarredAt: &github.Timestamp{Time: starredAt},
+			User: &github.User{
+				Login:   github.Ptr("octocat"),
+				ID:      github.Ptr(int64(1)),
+				HTMLURL: github.Ptr("https://github.com/octocat"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedStargazers []MinimalStargazer
+	}{
+		{
+			name: "successful list with starred_at timestamps",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStargazersByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "50",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockStargazers),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(50),
+			},
+			expectedStargazers: []MinimalStargazer{
+				{
+					MinimalUser: MinimalUser{
+						Login:      "octocat",
+						ID:         1,
+						ProfileURL: "https://github.com/octocat",
+					},
+					StarredAt: "2025-03-01T10:00:00Z",
+				},
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStargazersByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list stargazers of owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListStargazers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned []MinimalStargazer
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStargazers, returned)
+		})
+	}
+}
+
+func Test_ListForks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListForks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_forks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	pushedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockForks := []*github.Repository{
+		{
+			ID:       github.Ptr(int64(1)),
+			Name:     github.Ptr("repo"),
+			FullName: github.Ptr("stale/repo"),
+			Fork:     github.Ptr(true),
+			PushedAt: &github.Timestamp{Time: pushedAt.Add(-30 * 24 * time.Hour)},
 		},
 		{
-			StarredAt: &github.Timestamp{Time: starredAt.Add(-12 * time.Hour)},
-			Repository: &github.Repository{
-				ID:              github.Ptr(int64(67890)),
-				Name:            github.Ptr("cool-project"),
-				FullName:        github.Ptr("user/cool-project"),
-				Description:     github.Ptr("A very cool project"),
-				HTMLURL:         github.Ptr("https://github.com/user/cool-project"),
-				Language:        github.Ptr("Python"),
-				StargazersCount: github.Ptr(500),
-				ForksCount:      github.Ptr(75),
-				OpenIssuesCount: github.Ptr(10),
-				UpdatedAt:       &github.Timestamp{Time: updatedAt.Add(-1 * time.Hour)},
-				Private:         github.Ptr(false),
-				Fork:            github.Ptr(true),
-				Archived:        github.Ptr(false),
-				DefaultBranch:   github.Ptr("master"),
-			},
+			ID:       github.Ptr(int64(2)),
+			Name:     github.Ptr("repo"),
+			FullName: github.Ptr("active/repo"),
+			Fork:     github.Ptr(true),
+			PushedAt: &github.Timestamp{Time: pushedAt},
 		},
 	}
 
@@ -2932,54 +4484,66 @@ func Test_ListStarredRepositories(t *testing.T) {
 		requestArgs    map[string]interface{}
 		expectError    bool
 		expectedErrMsg string
-		expectedCount  int
+		expectedForks  []string
 	}{
 		{
-			name: "successful list for authenticated user",
+			name: "sorted by the API",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetUserStarred,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusOK)
-						_, _ = w.Write(mock.MustMarshal(mockStarredRepos))
-					}),
+					mock.GetReposForksByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"sort":     "stargazers",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockForks),
+					),
 				),
 			),
-			requestArgs:   map[string]interface{}{},
-			expectError:   false,
-			expectedCount: 2,
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sort":  "stargazers",
+			},
+			expectedForks: []string{"stale/repo", "active/repo"},
 		},
 		{
-			name: "successful list for specific user",
+			name: "most recently pushed first",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetUsersStarredByUsername,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusOK)
-						_, _ = w.Write(mock.MustMarshal(mockStarredRepos))
-					}),
+					mock.GetReposForksByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockForks),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"username": "testuser",
+				"owner": "owner",
+				"repo":  "repo",
+				"sort":  "pushed",
 			},
-			expectError:   false,
-			expectedCount: 2,
+			expectedForks: []string{"active/repo", "stale/repo"},
 		},
 		{
 			name: "list fails",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetUserStarred,
+					mock.GetReposForksByOwnerByRepo,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 						w.WriteHeader(http.StatusNotFound)
 						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
 					}),
 				),
 			),
-			requestArgs:    map[string]interface{}{},
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
 			expectError:    true,
-			expectedErrMsg: "failed to list starred repositories",
+			expectedErrMsg: "failed to list forks of owner/repo",
 		},
 	}
 
@@ -2987,208 +4551,248 @@ func Test_ListStarredRepositories(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ListStarredRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
+			_, handler := ListForks(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Call handler
-			result, err := handler(context.Background(), request)
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
 
-			// Verify results
 			if tc.expectError {
-				require.NotNil(t, result)
-				textResult, ok := result.Content[0].(mcp.TextContent)
-				require.True(t, ok, "Expected text content")
-				assert.Contains(t, textResult.Text, tc.expectedErrMsg)
-			} else {
-				require.NoError(t, err)
-				require.NotNil(t, result)
-
-				// Parse the result and get the text content
-				textContent := getTextResult(t, result)
-
-				// Unmarshal and verify the result
-				var returnedRepos []MinimalRepository
-				err = json.Unmarshal([]byte(textContent.Text), &returnedRepos)
-				require.NoError(t, err)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
 
-				assert.Len(t, returnedRepos, tc.expectedCount)
-				if tc.expectedCount > 0 {
-					assert.Equal(t, "awesome-repo", returnedRepos[0].Name)
-					assert.Equal(t, "owner/awesome-repo", returnedRepos[0].FullName)
-				}
+			var returned []MinimalRepository
+			err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+			require.NoError(t, err)
+			names := make([]string, 0, len(returned))
+			for _, fork := range returned {
+				names = append(names, fork.FullName)
 			}
+			assert.Equal(t, tc.expectedForks, names)
 		})
 	}
 }
 
-func Test_StarRepository(t *testing.T) {
+func Test_ListWatchers(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := StarRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ListWatchers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "star_repository", tool.Name)
+	assert.Equal(t, "list_watchers", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
+	mockClient = github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposSubscribersByOwnerByRepo,
+			[]*github.User{
+				{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1))},
+				{Login: github.Ptr("hubot"), ID: github.Ptr(int64(2))},
+			},
+		),
+	))
+	_, handler := ListWatchers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []MinimalUser
+	err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returned)
+	require.NoError(t, err)
+	assert.Equal(t, []MinimalUser{{Login: "octocat", ID: 1}, {Login: "hubot", ID: 2}}, returned)
+}
+
+func Test_GetRepositoryLicense(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryLicense(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_license", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "include_content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockLicense := &github.RepositoryLicense{
+		Name:     github.Ptr("LICENSE"),
+		Path:     github.Ptr("LICENSE"),
+		HTMLURL:  github.Ptr("https://github.com/owner/repo/blob/main/LICENSE"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("MIT License\n\nCopyright (c) 2025 Owner\n"))),
+		License: &github.License{
+			Key:    github.Ptr("mit"),
+			Name:   github.Ptr("MIT License"),
+			SPDXID: github.Ptr("MIT"),
+		},
+	}
+
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedLicense MinimalRepositoryLicense
 	}{
 		{
-			name: "successful star",
+			name: "license with content",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.PutUserStarredByOwnerByRepo,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNoContent)
-					}),
-				),
+				mock.WithRequestMatch(mock.GetReposLicenseByOwnerByRepo, mockLicense),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "testowner",
-				"repo":  "testrepo",
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedLicense: MinimalRepositoryLicense{
+				Key:     "mit",
+				SPDXID:  "MIT",
+				Name:    "MIT License",
+				Path:    "LICENSE",
+				HTMLURL: "https://github.com/owner/repo/blob/main/LICENSE",
+				Content: "MIT License\n\nCopyright (c) 2025 Owner\n",
 			},
-			expectError: false,
 		},
 		{
-			name: "star fails",
+			name: "license without content",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposLicenseByOwnerByRepo, mockLicense),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"include_content": false,
+			},
+			expectedLicense: MinimalRepositoryLicense{
+				Key:     "mit",
+				SPDXID:  "MIT",
+				Name:    "MIT License",
+				Path:    "LICENSE",
+				HTMLURL: "https://github.com/owner/repo/blob/main/LICENSE",
+			},
+		},
+		{
+			name: "no license",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.PutUserStarredByOwnerByRepo,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
-					}),
+					mock.GetReposLicenseByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "testowner",
-				"repo":  "nonexistent",
+				"owner": "owner",
+				"repo":  "repo",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to star repository",
+			expectedErrMsg: "failed to get license of owner/repo",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := StarRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetRepositoryLicense(stubGetClientFn(client), translations.NullTranslationHelper)
 
-			// Create call request
 			request := createMCPRequest(tc.requestArgs)
-
-			// Call handler
 			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
 
-			// Verify results
 			if tc.expectError {
-				require.NotNil(t, result)
-				textResult, ok := result.Content[0].(mcp.TextContent)
-				require.True(t, ok, "Expected text content")
-				assert.Contains(t, textResult.Text, tc.expectedErrMsg)
-			} else {
-				require.NoError(t, err)
-				require.NotNil(t, result)
-
-				// Parse the result and get the text content
-				textContent := getTextResult(t, result)
-				assert.Contains(t, textContent.Text, "Successfully starred repository")
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
 			}
+
+			textContent := getTextResult(t, result)
+			var returned MinimalRepositoryLicense
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedLicense, returned)
 		})
 	}
 }
 
-func Test_UnstarRepository(t *testing.T) {
+func Test_GetRepositoryLanguages(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := UnstarRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetRepositoryLanguages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "unstar_repository", tool.Name)
+	assert.Equal(t, "get_repository_languages", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedErrMsg string
+		name              string
+		mockedClient      *http.Client
+		expectError       bool
+		expectedErrMsg    string
+		expectedLanguages []RepositoryLanguage
 	}{
 		{
-			name: "successful unstar",
+			name: "languages sorted by size",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.DeleteUserStarredByOwnerByRepo,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNoContent)
-					}),
-				),
+				mock.WithRequestMatch(mock.GetReposLanguagesByOwnerByRepo, map[string]int{
+					"Shell":      1000,
+					"Go":         7000,
+					"Dockerfile": 1000,
+					"TypeScript": 1000,
+				}),
 			),
-			requestArgs: map[string]interface{}{
-				"owner": "testowner",
-				"repo":  "testrepo",
+			expectedLanguages: []RepositoryLanguage{
+				{Name: "Go", Bytes: 7000, Percentage: 70},
+				{Name: "Dockerfile", Bytes: 1000, Percentage: 10},
+				{Name: "Shell", Bytes: 1000, Percentage: 10},
+				{Name: "TypeScript", Bytes: 1000, Percentage: 10},
 			},
-			expectError: false,
 		},
 		{
-			name: "unstar fails",
+			name: "empty repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposLanguagesByOwnerByRepo, map[string]int{}),
+			),
+			expectedLanguages: []RepositoryLanguage{},
+		},
+		{
+			name: "repository not found",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.DeleteUserStarredByOwnerByRepo,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
-					}),
+					mock.GetReposLanguagesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
 				),
 			),
-			requestArgs: map[string]interface{}{
-				"owner": "testowner",
-				"repo":  "nonexistent",
-			},
 			expectError:    true,
-			expectedErrMsg: "failed to unstar repository",
+			expectedErrMsg: "failed to get languages of owner/repo",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := UnstarRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetRepositoryLanguages(stubGetClientFn(client), translations.NullTranslationHelper)
 
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
-
-			// Call handler
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			})
 			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
 
-			// Verify results
 			if tc.expectError {
-				require.NotNil(t, result)
-				textResult, ok := result.Content[0].(mcp.TextContent)
-				require.True(t, ok, "Expected text content")
-				assert.Contains(t, textResult.Text, tc.expectedErrMsg)
-			} else {
-				require.NoError(t, err)
-				require.NotNil(t, result)
-
-				// Parse the result and get the text content
-				textContent := getTextResult(t, result)
-				assert.Contains(t, textContent.Text, "Successfully unstarred repository")
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
 			}
+
+			textContent := getTextResult(t, result)
+			var returned []RepositoryLanguage
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedLanguages, returned)
 		})
 	}
 }
@@ -0,0 +1,120 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RepositoryDeletion is the output type of delete_repository, describing the repository that was deleted.
+type RepositoryDeletion struct {
+	FullName        string `json:"full_name"`
+	Visibility      string `json:"visibility"`
+	Fork            bool   `json:"fork"`
+	Archived        bool   `json:"archived"`
+	StargazersCount int    `json:"stargazers_count"`
+	ForksCount      int    `json:"forks_count"`
+}
+
+// RepositoryDeletionAllowed reports whether the allowlist lets the repository be deleted. Entries are
+// repositories as owner/repo, and owner/* matches every repository of the owner.
+func RepositoryDeletionAllowed(allowlist []string, owner, repo string) bool {
+	return slices.ContainsFunc(allowlist, func(allowed string) bool {
+		return strings.EqualFold(allowed, owner+"/"+repo) || strings.EqualFold(allowed, owner+"/*")
+	})
+}
+
+// DeleteRepository creates a tool to delete a repository. Only the repositories the allowlist of the policy matches
+// can be deleted, and each call must repeat the full name of the repository to delete.
+func DeleteRepository(getClient GetClientFn, policies *PolicyStore, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_repository",
+			mcp.WithDescription(t("TOOL_DELETE_REPOSITORY_DESCRIPTION", "Permanently delete a GitHub repository, with its issues, pull requests and wiki. Only repositories the server allows deleting can be deleted. Only use it when the user explicitly asks to delete the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REPOSITORY_USER_TITLE", "Delete repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[RepositoryDeletion](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("confirm_name",
+				mcp.Required(),
+				mcp.Description("Full name of the repository to delete, as owner/repo, which must match owner and repo exactly"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			confirmName, err := RequiredParam[string](request, "confirm_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fullName := owner + "/" + repo
+			if confirmName != fullName {
+				return mcp.NewToolResultError(fmt.Sprintf("confirm_name %q doesn't match the repository to delete, it must be exactly %q, nothing was deleted", confirmName, fullName)), nil
+			}
+			if !RepositoryDeletionAllowed(policies.Load().AllowRepositoryDeletion, owner, repo) {
+				return mcp.NewToolResultError(fmt.Sprintf("the server doesn't allow deleting %s, nothing was deleted", fullName)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			// Renamed and transferred repositories answer to their former names, which the deletion
+			// would then follow to a repository no one confirmed
+			if !strings.EqualFold(repository.GetFullName(), fullName) {
+				return mcp.NewToolResultError(fmt.Sprintf("%s was renamed or transferred to %s, confirm the deletion of %s instead, nothing was deleted", fullName, repository.GetFullName(), repository.GetFullName())), nil
+			}
+
+			resp, err = client.Repositories.Delete(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to delete repository",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if resp.StatusCode != http.StatusNoContent {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete repository: unexpected status %d", resp.StatusCode)), nil
+			}
+
+			return MarshalledTextResult(RepositoryDeletion{
+				FullName:        repository.GetFullName(),
+				Visibility:      repository.GetVisibility(),
+				Fork:            repository.GetFork(),
+				Archived:        repository.GetArchived(),
+				StargazersCount: repository.GetStargazersCount(),
+				ForksCount:      repository.GetForksCount(),
+			}), nil
+		}
+}
@@ -0,0 +1,118 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RepositoryDeletionAllowed(t *testing.T) {
+	allowlist := []string{"octo-org/scratch", "octo-sandbox/*"}
+
+	assert.True(t, RepositoryDeletionAllowed(allowlist, "octo-org", "scratch"))
+	assert.True(t, RepositoryDeletionAllowed(allowlist, "Octo-Org", "Scratch"))
+	assert.True(t, RepositoryDeletionAllowed(allowlist, "octo-sandbox", "anything"))
+	assert.False(t, RepositoryDeletionAllowed(allowlist, "octo-org", "production"))
+	assert.False(t, RepositoryDeletionAllowed(allowlist, "octo-sandbox-2", "anything"))
+	assert.False(t, RepositoryDeletionAllowed(nil, "octo-org", "scratch"))
+}
+
+func Test_DeleteRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRepository(stubGetClientFn(mockClient), NewPolicyStore(Policy{}), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "confirm_name"})
+
+	newClient := func(fullName string, deleted *[]string) *github.Client {
+		return github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposByOwnerByRepo,
+				&github.Repository{
+					FullName:        github.Ptr(fullName),
+					Visibility:      github.Ptr("private"),
+					StargazersCount: github.Ptr(2),
+				},
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					*deleted = append(*deleted, r.URL.Path)
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			),
+		))
+	}
+	allowlist := []string{"octo-org/scratch"}
+
+	tests := []struct {
+		name          string
+		fullName      string
+		args          map[string]any
+		expectDeleted bool
+		expectedError string
+	}{
+		{
+			name:          "deletes allowed repositories",
+			fullName:      "octo-org/scratch",
+			args:          map[string]any{"owner": "octo-org", "repo": "scratch", "confirm_name": "octo-org/scratch"},
+			expectDeleted: true,
+		},
+		{
+			name:          "confirm_name must match",
+			fullName:      "octo-org/scratch",
+			args:          map[string]any{"owner": "octo-org", "repo": "scratch", "confirm_name": "scratch"},
+			expectedError: `confirm_name "scratch" doesn't match the repository to delete, it must be exactly "octo-org/scratch", nothing was deleted`,
+		},
+		{
+			name:          "confirm_name is required",
+			fullName:      "octo-org/scratch",
+			args:          map[string]any{"owner": "octo-org", "repo": "scratch"},
+			expectedError: "missing required parameter: confirm_name",
+		},
+		{
+			name:          "repositories outside the allowlist aren't deleted",
+			fullName:      "octo-org/production",
+			args:          map[string]any{"owner": "octo-org", "repo": "production", "confirm_name": "octo-org/production"},
+			expectedError: "the server doesn't allow deleting octo-org/production, nothing was deleted",
+		},
+		{
+			name:          "renamed repositories aren't deleted",
+			fullName:      "octo-org/production",
+			args:          map[string]any{"owner": "octo-org", "repo": "scratch", "confirm_name": "octo-org/scratch"},
+			expectedError: "octo-org/scratch was renamed or transferred to octo-org/production, confirm the deletion of octo-org/production instead, nothing was deleted",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var deleted []string
+			_, handler := DeleteRepository(stubGetClientFn(newClient(tc.fullName, &deleted)), NewPolicyStore(Policy{AllowRepositoryDeletion: allowlist}), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, getErrorResult(t, result).Text)
+				assert.Empty(t, deleted)
+				return
+			}
+			var deletion RepositoryDeletion
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &deletion))
+			assert.Equal(t, RepositoryDeletion{FullName: "octo-org/scratch", Visibility: "private", StargazersCount: 2}, deletion)
+			assert.Equal(t, []string{"/repos/octo-org/scratch"}, deleted)
+		})
+	}
+}
@@ -1,16 +1,20 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"mime"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -32,7 +36,7 @@ func GetRepositoryResourceContent(getClient GetClientFn, getRawClient raw.GetRaw
 func GetRepositoryResourceBranchContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
 	return mcp.NewResourceTemplate(
 			"repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}", // Resource template
-			t("RESOURCE_REPOSITORY_CONTENT_BRANCH_DESCRIPTION", "Repository Content for specific branch"),
+			t("RESOURCE_REPOSITORY_CONTENT_BRANCH_DESCRIPTION", "Repository Content for specific branch. Branch names containing slashes, such as feature/x, are encoded as feature%2Fx"),
 		),
 		RepositoryResourceContentsHandler(getClient, getRawClient)
 }
@@ -64,6 +68,15 @@ func GetRepositoryResourcePrContent(getClient GetClientFn, getRawClient raw.GetR
 		RepositoryResourceContentsHandler(getClient, getRawClient)
 }
 
+// GetRepositoryResourceRefContent defines the resource template and handler for getting repository content for any ref.
+func GetRepositoryResourceRefContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/refs/{ref}/contents{/path*}", // Resource template
+			t("RESOURCE_REPOSITORY_CONTENT_REF_DESCRIPTION", "Repository Content for a branch, tag or commit. Refs containing slashes, such as feature/x, are encoded as feature%2Fx"),
+		),
+		RepositoryResourceContentsHandler(getClient, getRawClient)
+}
+
 // RepositoryResourceContentsHandler returns a handler function for repository content requests.
 func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.GetRawClientFn) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
@@ -90,26 +103,40 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 
 		opts := &github.RepositoryContentGetOptions{}
 		rawOpts := &raw.ContentOpts{}
+		// refSegments are the segments of the URI between the repository and the contents, naming the ref
+		var refSegments []string
 
 		sha, ok := request.Params.Arguments["sha"].([]string)
 		if ok && len(sha) > 0 {
 			opts.Ref = sha[0]
 			rawOpts.SHA = sha[0]
+			refSegments = []string{"sha", sha[0]}
 		}
 
 		branch, ok := request.Params.Arguments["branch"].([]string)
 		if ok && len(branch) > 0 {
 			opts.Ref = "refs/heads/" + branch[0]
 			rawOpts.Ref = "refs/heads/" + branch[0]
+			refSegments = []string{"refs", "heads", branch[0]}
 		}
 
 		tag, ok := request.Params.Arguments["tag"].([]string)
 		if ok && len(tag) > 0 {
 			opts.Ref = "refs/tags/" + tag[0]
 			rawOpts.Ref = "refs/tags/" + tag[0]
+			refSegments = []string{"refs", "tags", tag[0]}
 		}
+
+		ref, ok := request.Params.Arguments["ref"].([]string)
+		if ok && len(ref) > 0 {
+			opts.Ref = ref[0]
+			rawOpts.Ref = ref[0]
+			refSegments = []string{"refs", ref[0]}
+		}
+
 		prNumber, ok := request.Params.Arguments["prNumber"].([]string)
 		if ok && len(prNumber) > 0 {
+			refSegments = []string{"refs", "pull", prNumber[0], "head"}
 			// fetch the PR from the API to get the latest commit and use SHA
 			githubClient, err := getClient(ctx)
 			if err != nil {
@@ -127,9 +154,9 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 			rawOpts.SHA = sha
 			opts.Ref = sha
 		}
-		//  if it's a directory
+		//  if it's a directory, list its entries through the GitHub API
 		if path == "" || strings.HasSuffix(path, "/") {
-			return nil, fmt.Errorf("directories are not supported: %s", path)
+			return repositoryResourceDirectoryContents(ctx, getClient, request.Params.URI, owner, repo, refSegments, path, opts)
 		}
 		rawClient, err := getRawClient(ctx)
 
@@ -138,29 +165,24 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 		}
 
 		resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get raw content: %w", err)
+		}
 		defer func() {
 			_ = resp.Body.Close()
 		}()
 		// If the raw content is not found, we will fall back to the GitHub API (in case it is a directory)
 		switch {
-		case err != nil:
-			return nil, fmt.Errorf("failed to get raw content: %w", err)
 		case resp.StatusCode == http.StatusOK:
-			ext := filepath.Ext(path)
-			mimeType := resp.Header.Get("Content-Type")
-			if ext == ".md" {
-				mimeType = "text/markdown"
-			} else if mimeType == "" {
-				mimeType = mime.TypeByExtension(ext)
-			}
-
 			content, err := io.ReadAll(resp.Body)
 			if err != nil {
 				return nil, fmt.Errorf("failed to read file content: %w", err)
 			}
 
+			mimeType := resourceMIMEType(path, resp.Header.Get("Content-Type"), content)
+
 			switch {
-			case strings.HasPrefix(mimeType, "text"), strings.HasPrefix(mimeType, "application"):
+			case isTextMIMEType(mimeType):
 				return []mcp.ResourceContents{
 					mcp.TextResourceContents{
 						URI:      request.Params.URI,
@@ -185,8 +207,112 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 			}
 			return nil, fmt.Errorf("failed to fetch raw content: %s", string(body))
 		default:
-			// This should be unreachable because GetContents should return an error if neither file nor directory content is found.
-			return nil, errors.New("404 Not Found")
+			// The raw API doesn't serve directories, so the path may still be one
+			return repositoryResourceDirectoryContents(ctx, getClient, request.Params.URI, owner, repo, refSegments, path, opts)
 		}
 	}
 }
+
+// RepositoryResourceEntry is a directory entry returned when reading a directory resource.
+type RepositoryResourceEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int    `json:"size,omitempty"`
+	URI  string `json:"uri"`
+}
+
+// repositoryResourceDirectoryContents lists a directory as JSON, giving the resource URI of every entry so clients can browse further.
+// The URIs of the entries name the same ref as the directory, given by the segments of its URI.
+func repositoryResourceDirectoryContents(ctx context.Context, getClient GetClientFn, uri, owner, repo string, refSegments []string, path string, opts *github.RepositoryContentGetOptions) ([]mcp.ResourceContents, error) {
+	githubClient, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	fileContent, dirContent, _, err := githubClient.Repositories.GetContents(ctx, owner, repo, strings.TrimSuffix(path, "/"), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contents: %w", err)
+	}
+	if fileContent != nil {
+		// This should be unreachable because the file would have been served by the raw API.
+		return nil, errors.New("404 Not Found")
+	}
+
+	entries := make([]RepositoryResourceEntry, 0, len(dirContent))
+	for _, entry := range dirContent {
+		entries = append(entries, RepositoryResourceEntry{
+			Name: entry.GetName(),
+			Path: entry.GetPath(),
+			Type: entry.GetType(),
+			Size: entry.GetSize(),
+			URI:  repositoryContentsURI(owner, repo, refSegments, entry.GetPath()),
+		})
+	}
+
+	r, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal directory contents: %w", err)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(r),
+		},
+	}, nil
+}
+
+// repositoryContentsURI builds the resource URI of a path of a repository at the ref its segments name. Every segment
+// is escaped, so that branch and tag names containing slashes stay single segments.
+func repositoryContentsURI(owner, repo string, refSegments []string, path string) string {
+	segments := append([]string{owner, repo}, refSegments...)
+	segments = append(segments, "contents")
+	segments = append(segments, strings.Split(path, "/")...)
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return "repo://" + strings.Join(segments, "/")
+}
+
+// resourceMIMEType picks the MIME type of a file. The raw API serves most files as text/plain,
+// so the file extension is preferred, then the served type, then the sniffed content type.
+func resourceMIMEType(path, contentType string, content []byte) string {
+	ext := filepath.Ext(path)
+	if ext == ".md" {
+		return "text/markdown"
+	}
+	if byExt := mime.TypeByExtension(ext); ext != "" && byExt != "" {
+		return byExt
+	}
+	if contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(content)
+}
+
+// isTextContent reports whether content looks like text: valid UTF-8 without NUL bytes, as git decides.
+func isTextContent(content []byte) bool {
+	return utf8.Valid(content) && bytes.IndexByte(content, 0) < 0
+}
+
+// isTextMIMEType reports whether content of the given MIME type should be returned as text rather than as a blob.
+func isTextMIMEType(mimeType string) bool {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		mediaType = mimeType
+	}
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "application/x-javascript",
+		"application/yaml", "application/x-yaml", "application/toml", "application/x-sh", "application/sql":
+		return true
+	}
+	return false
+}
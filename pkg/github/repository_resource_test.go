@@ -10,6 +10,7 @@ import (
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -19,6 +20,7 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 	tests := []struct {
 		name           string
 		mockedClient   *http.Client
+		uri            string
 		requestArgs    map[string]any
 		expectError    string
 		expectedResult any
@@ -207,6 +209,184 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 				URI:      "",
 			}},
 		},
+		{
+			name: "successful text content fetch (ref)",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, "/owner/repo/release-1.0/Makefile", r.URL.Path)
+						w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+						_, err := w.Write([]byte("all: build"))
+						require.NoError(t, err)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"Makefile"},
+				"ref":   []string{"release-1.0"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     "all: build",
+				MIMEType: "text/plain; charset=utf-8",
+				URI:      "",
+			}},
+		},
+		{
+			name: "binary content served as octet-stream is returned as blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "application/octet-stream")
+						_, err := w.Write([]byte{0x00, 0x01, 0x02})
+						require.NoError(t, err)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"bin", "tool"},
+			},
+			expectedResult: []mcp.BlobResourceContents{{
+				Blob:     "AAEC",
+				MIMEType: "application/octet-stream",
+				URI:      "",
+			}},
+		},
+		{
+			name: "json content is returned as text",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+						_, err := w.Write([]byte(`{"name":"pkg"}`))
+						require.NoError(t, err)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"package.json"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     `{"name":"pkg"}`,
+				MIMEType: "application/json",
+				URI:      "",
+			}},
+		},
+		{
+			name: "root directory listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "application/json")
+						_, _ = w.Write([]byte(`[{"name":"README.md","path":"README.md","type":"file","size":42},{"name":"src","path":"src","type":"dir"}]`))
+					}),
+				),
+			),
+			uri: "repo://owner/repo/contents",
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     `[{"name":"README.md","path":"README.md","type":"file","size":42,"uri":"repo://owner/repo/contents/README.md"},{"name":"src","path":"src","type":"dir","uri":"repo://owner/repo/contents/src"}]`,
+				MIMEType: "application/json",
+				URI:      "repo://owner/repo/contents",
+			}},
+		},
+		{
+			name: "subdirectory listing falls back to the contents API",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, "refs/heads/main", r.URL.Query().Get("ref"))
+						w.Header().Set("Content-Type", "application/json")
+						_, _ = w.Write([]byte(`[{"name":"main.go","path":"src/main.go","type":"file","size":12}]`))
+					}),
+				),
+			),
+			uri: "repo://owner/repo/refs/heads/main/contents/src",
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"path":   []string{"src"},
+				"branch": []string{"main"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     `[{"name":"main.go","path":"src/main.go","type":"file","size":12,"uri":"repo://owner/repo/refs/heads/main/contents/src/main.go"}]`,
+				MIMEType: "application/json",
+				URI:      "repo://owner/repo/refs/heads/main/contents/src",
+			}},
+		},
+		{
+			name: "entry URIs of a directory escape the ref and path segments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, "refs/heads/feature/x", r.URL.Query().Get("ref"))
+						w.Header().Set("Content-Type", "application/json")
+						_, _ = w.Write([]byte(`[{"name":"getting started.md","path":"docs/getting started.md","type":"file","size":7}]`))
+					}),
+				),
+			),
+			// The URI differs from the one the arguments would give, so entry URIs must not be derived from it
+			uri: "repo://owner/repo/refs/heads/feature%2Fx/contents/docs/",
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"path":   []string{"docs", ""},
+				"branch": []string{"feature/x"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     `[{"name":"getting started.md","path":"docs/getting started.md","type":"file","size":7,"uri":"repo://owner/repo/refs/heads/feature%2Fx/contents/docs/getting%20started.md"}]`,
+				MIMEType: "application/json",
+				URI:      "repo://owner/repo/refs/heads/feature%2Fx/contents/docs/",
+			}},
+		},
+		{
+			name: "pull request directory entries keep the pull request ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&github.PullRequest{Head: &github.PullRequestBranch{SHA: github.Ptr("abc123")}},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, "abc123", r.URL.Query().Get("ref"))
+						w.Header().Set("Content-Type", "application/json")
+						_, _ = w.Write([]byte(`[{"name":"README.md","path":"README.md","type":"file","size":42}]`))
+					}),
+				),
+			),
+			uri: "repo://owner/repo/refs/pull/42/head/contents",
+			requestArgs: map[string]any{
+				"owner":    []string{"owner"},
+				"repo":     []string{"repo"},
+				"prNumber": []string{"42"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     `[{"name":"README.md","path":"README.md","type":"file","size":42,"uri":"repo://owner/repo/refs/pull/42/head/contents/README.md"}]`,
+				MIMEType: "application/json",
+				URI:      "repo://owner/repo/refs/pull/42/head/contents",
+			}},
+		},
 		{
 			name: "content fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -239,6 +419,7 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 					URI       string         `json:"uri"`
 					Arguments map[string]any `json:"arguments,omitempty"`
 				}{
+					URI:       tc.uri,
 					Arguments: tc.requestArgs,
 				},
 			}
@@ -278,3 +459,67 @@ func Test_GetRepositoryResourceTagContent(t *testing.T) {
 	tmpl, _ := GetRepositoryResourceTagContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
 	require.Equal(t, "repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}", tmpl.URITemplate.Raw())
 }
+
+func Test_GetRepositoryResourceRefContent(t *testing.T) {
+	mockRawClient := raw.NewClient(github.NewClient(nil), &url.URL{})
+	tmpl, _ := GetRepositoryResourceRefContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/refs/{ref}/contents{/path*}", tmpl.URITemplate.Raw())
+}
+
+func Test_RepositoryResourceContentWithSlashInRef(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+	tests := []struct {
+		name         string
+		template     func(GetClientFn, raw.GetRawClientFn, translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc)
+		uri          string
+		expectedPath string
+	}{
+		{
+			name:         "branch",
+			template:     GetRepositoryResourceBranchContent,
+			uri:          "repo://owner/repo/refs/heads/feature%2Fx/contents/docs/README.md",
+			expectedPath: "/owner/repo/refs/heads/feature/x/docs/README.md",
+		},
+		{
+			name:         "ref",
+			template:     GetRepositoryResourceRefContent,
+			uri:          "repo://owner/repo/refs/feature%2Fx/contents/docs/README.md",
+			expectedPath: "/owner/repo/feature/x/docs/README.md",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{Pattern: "/owner/repo/{rest:.*}", Method: "GET"},
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, tc.expectedPath, r.URL.Path)
+						w.Header().Set("Content-Type", "text/markdown")
+						_, _ = w.Write([]byte("# Feature"))
+					}),
+				),
+			))
+			tmpl, handler := tc.template(stubGetClientFn(client), stubGetRawClientFn(raw.NewClient(client, base)), translations.NullTranslationHelper)
+
+			// Unencoded slashes would spill into the path, so refs containing them must be encoded
+			values := tmpl.URITemplate.Match(tc.uri)
+			require.NotNil(t, values)
+			arguments := make(map[string]any, len(values))
+			for name, value := range values {
+				arguments[name] = value.V
+			}
+
+			request := mcp.ReadResourceRequest{}
+			request.Params.URI = tc.uri
+			request.Params.Arguments = arguments
+			resp, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.Equal(t, []mcp.ResourceContents{mcp.TextResourceContents{
+				URI:      tc.uri,
+				MIMEType: "text/markdown",
+				Text:     "# Feature",
+			}}, resp)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxCheckRunPages is the number of pages of check runs looked through for the required checks of a pull request.
+const maxCheckRunPages = 10
+
+// RequiredCheck is the state of a check required to pass before a pull request can be merged.
+type RequiredCheck struct {
+	Name string `json:"name"`
+	// Source is where the check is required: branch_protection or ruleset.
+	Source string `json:"source"`
+	// State is one of passing, pending, failing or missing.
+	State      string `json:"state"`
+	Conclusion string `json:"conclusion,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// RequiredChecksStatus is the output type of get_required_checks_status.
+type RequiredChecksStatus struct {
+	PullNumber int             `json:"pull_number"`
+	Base       string          `json:"base"`
+	HeadSHA    string          `json:"head_sha"`
+	AllPassing bool            `json:"all_passing"`
+	Missing    []string        `json:"missing"`
+	Pending    []string        `json:"pending"`
+	Failing    []string        `json:"failing"`
+	Checks     []RequiredCheck `json:"checks"`
+}
+
+// requiredCheck is a check required by the protection of a branch or by a ruleset. The check must be reported by
+// the app with appID, unless appID is 0.
+type requiredCheck struct {
+	name   string
+	appID  int64
+	source string
+}
+
+// GetRequiredChecksStatus creates a tool to evaluate the checks a pull request is required to pass against the check
+// runs and commit statuses of its head.
+func GetRequiredChecksStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_required_checks_status",
+			mcp.WithDescription(t("TOOL_GET_REQUIRED_CHECKS_STATUS_DESCRIPTION", "Get the state of the status checks a pull request must pass to be merged, as required by the branch protection and rulesets of its base branch. Reports by name which required checks are missing, pending or failing on the head commit.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REQUIRED_CHECKS_STATUS_USER_TITLE", "Get pull request required checks status"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[RequiredChecksStatus](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			status, errResult := getRequiredChecksStatus(ctx, client, owner, repo, pr.GetBase().GetRef(), pr.GetHead().GetSHA())
+			if errResult != nil {
+				return errResult, nil
+			}
+			status.PullNumber = pullNumber
+			return MarshalledTextResult(status), nil
+		}
+}
+
+// getRequiredChecksStatus evaluates the checks required on the base branch against the checks reported for headSHA.
+func getRequiredChecksStatus(ctx context.Context, client *github.Client, owner, repo, base, headSHA string) (RequiredChecksStatus, *mcp.CallToolResult) {
+	status := RequiredChecksStatus{
+		Base:    base,
+		HeadSHA: headSHA,
+		Missing: []string{},
+		Pending: []string{},
+		Failing: []string{},
+		Checks:  []RequiredCheck{},
+	}
+
+	required, errResult := listRequiredChecks(ctx, client, owner, repo, base)
+	if errResult != nil {
+		return status, errResult
+	}
+
+	runs, statuses, errResult := listCommitChecks(ctx, client, owner, repo, headSHA)
+	if errResult != nil {
+		return status, errResult
+	}
+
+	for _, check := range required {
+		result := evaluateRequiredCheck(check, runs, statuses)
+		switch result.State {
+		case "missing":
+			status.Missing = append(status.Missing, result.Name)
+		case "pending":
+			status.Pending = append(status.Pending, result.Name)
+		case "failing":
+			status.Failing = append(status.Failing, result.Name)
+		}
+		status.Checks = append(status.Checks, result)
+	}
+	status.AllPassing = len(status.Missing) == 0 && len(status.Pending) == 0 && len(status.Failing) == 0
+	return status, nil
+}
+
+// listCommitChecks returns the check runs and the commit statuses reported for a commit.
+func listCommitChecks(ctx context.Context, client *github.Client, owner, repo, sha string) ([]*github.CheckRun, []*github.RepoStatus, *mcp.CallToolResult) {
+	var runs []*github.CheckRun
+	opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for page := 0; page < maxCheckRunPages; page++ {
+		result, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, opts)
+		if err != nil {
+			return nil, nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to list check runs",
+				resp,
+				err,
+			)
+		}
+		_ = resp.Body.Close()
+		runs = append(runs, result.CheckRuns...)
+		if resp.NextPage == 0 {
+			break
+		}
+		NotifyProgress(ctx, float64(len(runs)), float64(result.GetTotal()), fmt.Sprintf("Listed %d of %d check runs", len(runs), result.GetTotal()))
+		opts.Page = resp.NextPage
+	}
+
+	combined, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, sha, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get combined status",
+			resp,
+			err,
+		)
+	}
+	_ = resp.Body.Close()
+	return runs, combined.Statuses, nil
+}
+
+// listRequiredChecks returns the checks the protection and the rulesets of a branch require, once each.
+func listRequiredChecks(ctx context.Context, client *github.Client, owner, repo, branch string) ([]requiredCheck, *mcp.CallToolResult) {
+	var required []requiredCheck
+	seen := map[string]bool{}
+	add := func(check requiredCheck) {
+		if !seen[check.name] {
+			seen[check.name] = true
+			required = append(required, check)
+		}
+	}
+
+	// Unprotected branches, and protected ones not requiring checks, are not found
+	checks, resp, err := client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, branch)
+	switch {
+	case err == nil:
+		_ = resp.Body.Close()
+		if checks.Checks != nil {
+			for _, check := range *checks.Checks {
+				add(requiredCheck{name: check.Context, appID: check.GetAppID(), source: "branch_protection"})
+			}
+		} else if checks.Contexts != nil {
+			for _, name := range *checks.Contexts {
+				add(requiredCheck{name: name, source: "branch_protection"})
+			}
+		}
+	case resp == nil || resp.StatusCode != http.StatusNotFound:
+		return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get branch protection required status checks",
+			resp,
+			err,
+		)
+	}
+
+	// Rulesets aren't found on GitHub Enterprise Server versions predating them
+	rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, branch, &github.ListOptions{PerPage: 100})
+	switch {
+	case err == nil:
+		_ = resp.Body.Close()
+	case resp == nil || resp.StatusCode != http.StatusNotFound:
+		return nil, ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get branch rules",
+			resp,
+			err,
+		)
+	}
+	if rules != nil {
+		for _, rule := range rules.RequiredStatusChecks {
+			for _, check := range rule.Parameters.RequiredStatusChecks {
+				add(requiredCheck{name: check.Context, appID: check.GetIntegrationID(), source: "ruleset"})
+			}
+		}
+	}
+	return required, nil
+}
+
+// evaluateRequiredCheck returns the state of a required check given the check runs and commit statuses of a commit.
+func evaluateRequiredCheck(check requiredCheck, runs []*github.CheckRun, statuses []*github.RepoStatus) RequiredCheck {
+	result := RequiredCheck{Name: check.name, Source: check.source, State: "missing"}
+
+	for _, run := range runs {
+		// An app ID of -1 lets any app report the check
+		if run.GetName() != check.name || (check.appID > 0 && run.GetApp().GetID() != check.appID) {
+			continue
+		}
+		result.URL = run.GetHTMLURL()
+		result.State, result.Conclusion = checkRunState(run)
+		return result
+	}
+
+	for _, repoStatus := range statuses {
+		if repoStatus.GetContext() != check.name {
+			continue
+		}
+		result.URL = repoStatus.GetTargetURL()
+		result.State, result.Conclusion = commitStatusState(repoStatus)
+		return result
+	}
+	return result
+}
+
+// checkRunState returns whether a check run is passing, pending or failing, along with its conclusion.
+func checkRunState(run *github.CheckRun) (string, string) {
+	if run.GetStatus() != "completed" {
+		return "pending", ""
+	}
+	switch run.GetConclusion() {
+	case "success", "neutral", "skipped":
+		return "passing", run.GetConclusion()
+	default:
+		return "failing", run.GetConclusion()
+	}
+}
+
+// commitStatusState returns whether a commit status is passing, pending or failing, along with its state.
+func commitStatusState(status *github.RepoStatus) (string, string) {
+	switch status.GetState() {
+	case "success":
+		return "passing", status.GetState()
+	case "pending":
+		return "pending", status.GetState()
+	default:
+		return "failing", status.GetState()
+	}
+}
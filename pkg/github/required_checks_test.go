@@ -0,0 +1,152 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRequiredChecksStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRequiredChecksStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_required_checks_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mockPR := &github.PullRequest{
+		Number: github.Ptr(42),
+		Base:   &github.PullRequestBranch{Ref: github.Ptr("main")},
+		Head:   &github.PullRequestBranch{Ref: github.Ptr("feature"), SHA: github.Ptr("head-sha")},
+	}
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedStatus RequiredChecksStatus
+		expectedErrMsg string
+	}{
+		{
+			name: "evaluates checks required by branch protection and rulesets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatch(
+					mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch,
+					&github.RequiredStatusChecks{
+						Checks: &[]*github.RequiredStatusCheck{
+							{Context: "build", AppID: github.Ptr(int64(1))},
+							{Context: "lint", AppID: github.Ptr(int64(-1))},
+							{Context: "ci/deploy"},
+						},
+					},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					[]byte(`[{"type": "required_status_checks", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 1,
+						"parameters": {"required_status_checks": [{"context": "test"}, {"context": "build"}, {"context": "security"}], "strict_required_status_checks_policy": false}}]`),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					&github.ListCheckRunsResults{
+						Total: github.Ptr(4),
+						CheckRuns: []*github.CheckRun{
+							// Reported by another app than the required one
+							{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"), App: &github.App{ID: github.Ptr(int64(2))}},
+							{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), App: &github.App{ID: github.Ptr(int64(1))}, HTMLURL: github.Ptr("https://github.com/owner/repo/runs/1")},
+							{Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"), App: &github.App{ID: github.Ptr(int64(3))}},
+							{Name: github.Ptr("test"), Status: github.Ptr("in_progress")},
+						},
+					},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					&github.CombinedStatus{
+						Statuses: []*github.RepoStatus{
+							{Context: github.Ptr("ci/deploy"), State: github.Ptr("success"), TargetURL: github.Ptr("https://ci.example.com/1")},
+						},
+					},
+				),
+			),
+			expectedStatus: RequiredChecksStatus{
+				PullNumber: 42,
+				Base:       "main",
+				HeadSHA:    "head-sha",
+				Missing:    []string{"security"},
+				Pending:    []string{"test"},
+				Failing:    []string{"lint"},
+				Checks: []RequiredCheck{
+					{Name: "build", Source: "branch_protection", State: "passing", Conclusion: "success", URL: "https://github.com/owner/repo/runs/1"},
+					{Name: "lint", Source: "branch_protection", State: "failing", Conclusion: "failure"},
+					{Name: "ci/deploy", Source: "branch_protection", State: "passing", Conclusion: "success", URL: "https://ci.example.com/1"},
+					{Name: "test", Source: "ruleset", State: "pending"},
+					{Name: "security", Source: "ruleset", State: "missing"},
+				},
+			},
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatchHandler(mock.GetReposBranchesProtectionRequiredStatusChecksByOwnerByRepoByBranch, notFound),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []byte(`[]`)),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, &github.ListCheckRunsResults{}),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, &github.CombinedStatus{}),
+			),
+			expectedStatus: RequiredChecksStatus{
+				PullNumber: 42,
+				Base:       "main",
+				HeadSHA:    "head-sha",
+				AllPassing: true,
+				Missing:    []string{},
+				Pending:    []string{},
+				Failing:    []string{},
+				Checks:     []RequiredCheck{},
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposPullsByOwnerByRepoByPullNumber, notFound),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetRequiredChecksStatus(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var status RequiredChecksStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ParseToolRetryAttempts parses the per-tool retry configuration, given as tool=attempts entries.
+func ParseToolRetryAttempts(entries []string) (map[string]int, error) {
+	attempts := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		tool, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || tool == "" {
+			return nil, fmt.Errorf("invalid tool retry attempts %q, expected tool=attempts", entry)
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid tool retry attempts %q, attempts must be a positive number", entry)
+		}
+		attempts[tool] = n
+	}
+	return attempts, nil
+}
+
+// ToolRetryMiddleware overrides the retry policy of the GitHub requests made by the listed tools. As the tools
+// were explicitly configured, their requests are retried even when they aren't idempotent.
+func ToolRetryMiddleware(attempts map[string]int) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if n, ok := attempts[request.Params.Name]; ok {
+				ctx = transport.ContextWithRetryPolicy(ctx, transport.RetryPolicy{MaxAttempts: n, RetryNonIdempotent: true})
+			}
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseToolRetryAttempts(t *testing.T) {
+	attempts, err := ParseToolRetryAttempts([]string{"add_issue_comment=2", " create_issue=1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"add_issue_comment": 2, "create_issue": 1}, attempts)
+
+	_, err = ParseToolRetryAttempts([]string{"add_issue_comment"})
+	assert.ErrorContains(t, err, "expected tool=attempts")
+
+	_, err = ParseToolRetryAttempts([]string{"add_issue_comment=0"})
+	assert.ErrorContains(t, err, "attempts must be a positive number")
+}
+
+func Test_ToolRetryMiddleware(t *testing.T) {
+	var policy transport.RetryPolicy
+	var ok bool
+	handler := ToolRetryMiddleware(map[string]int{"add_issue_comment": 4})(
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			policy, ok = transport.RetryPolicyFromContext(ctx)
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "add_issue_comment"
+	_, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, transport.RetryPolicy{MaxAttempts: 4, RetryNonIdempotent: true}, policy)
+
+	request.Params.Name = "get_me"
+	_, err = handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
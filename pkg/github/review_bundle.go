@@ -0,0 +1,275 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// ReviewBundlePullRequest is the metadata of the pull request of a review bundle.
+type ReviewBundlePullRequest struct {
+	Number         int    `json:"number"`
+	Title          string `json:"title"`
+	Body           string `json:"body,omitempty"`
+	Author         string `json:"author"`
+	State          string `json:"state"`
+	Draft          bool   `json:"draft,omitempty"`
+	Base           string `json:"base"`
+	Head           string `json:"head"`
+	HeadSHA        string `json:"head_sha"`
+	Mergeable      *bool  `json:"mergeable"`
+	MergeableState string `json:"mergeable_state,omitempty"`
+	Additions      int    `json:"additions"`
+	Deletions      int    `json:"deletions"`
+	ChangedFiles   int    `json:"changed_files"`
+	URL            string `json:"html_url"`
+}
+
+// LinkedIssue is an issue a pull request closes when merged.
+type LinkedIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	URL    string `json:"html_url"`
+}
+
+// CICheck is a check run or commit status reported for the head of a pull request.
+type CICheck struct {
+	Name string `json:"name"`
+	// State is one of passing, pending or failing.
+	State      string `json:"state"`
+	Conclusion string `json:"conclusion,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// CIStatus sums up the checks of the head of a pull request.
+type CIStatus struct {
+	// State is failing when any check fails, pending when any is still running, passing when all pass and none
+	// when there are no checks.
+	State  string    `json:"state"`
+	Checks []CICheck `json:"checks"`
+}
+
+// ReviewBundleReview is a review submitted on a pull request.
+type ReviewBundleReview struct {
+	Author      string     `json:"author"`
+	State       string     `json:"state"`
+	Body        string     `json:"body,omitempty"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+}
+
+// PullRequestReviewBundle is the output type of get_pull_request_review_bundle. Diffs longer than the maximum number
+// of lines are summarized per file in DiffSummary instead of being returned in Diff.
+type PullRequestReviewBundle struct {
+	PullRequest   ReviewBundlePullRequest `json:"pull_request"`
+	Diff          string                  `json:"diff,omitempty"`
+	DiffSummary   *DiffSummary            `json:"diff_summary,omitempty"`
+	LinkedIssues  []LinkedIssue           `json:"linked_issues"`
+	CI            CIStatus                `json:"ci"`
+	Reviews       []ReviewBundleReview    `json:"reviews"`
+	ReviewThreads []ReviewThread          `json:"review_threads"`
+}
+
+// linkedIssuesQuery lists the issues a pull request closes when merged.
+type linkedIssuesQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ClosingIssuesReferences struct {
+				Nodes []struct {
+					Number githubv4.Int
+					Title  githubv4.String
+					State  githubv4.String
+					URL    githubv4.URI
+				}
+			} `graphql:"closingIssuesReferences(first: 25)"`
+		} `graphql:"pullRequest(number: $pullNumber)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// GetPullRequestReviewBundle creates a tool to get everything needed to review a pull request in one call.
+func GetPullRequestReviewBundle(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_review_bundle",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEW_BUNDLE_DESCRIPTION", "Get the context needed to review a pull request in one call: its metadata, diff, linked issues, CI status, reviews and review comment threads. Diffs longer than max_diff_lines are summarized per file instead, use get_pull_request_file_diff to get the diff of the files you need.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_REVIEW_BUNDLE_USER_TITLE", "Get pull request review bundle"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[PullRequestReviewBundle](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("max_diff_lines",
+				mcp.Description(fmt.Sprintf("Maximum number of lines of the diff to return whole, longer diffs are summarized per file (default %d)", DefaultMaxDiffLines)),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxLines, err := OptionalIntParamWithDefault(request, "max_diff_lines", DefaultMaxDiffLines)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			pr, resp, err := getPullRequestMergeability(ctx, client, owner, repo, pullNumber, mergeabilityRetryDelays)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			bundle := PullRequestReviewBundle{
+				PullRequest: ReviewBundlePullRequest{
+					Number:         pr.GetNumber(),
+					Title:          pr.GetTitle(),
+					Body:           pr.GetBody(),
+					Author:         pr.GetUser().GetLogin(),
+					State:          pr.GetState(),
+					Draft:          pr.GetDraft(),
+					Base:           pr.GetBase().GetRef(),
+					Head:           pr.GetHead().GetRef(),
+					HeadSHA:        pr.GetHead().GetSHA(),
+					Mergeable:      pr.Mergeable,
+					MergeableState: pr.GetMergeableState(),
+					Additions:      pr.GetAdditions(),
+					Deletions:      pr.GetDeletions(),
+					ChangedFiles:   pr.GetChangedFiles(),
+					URL:            pr.GetHTMLURL(),
+				},
+				LinkedIssues: []LinkedIssue{},
+				CI:           CIStatus{State: "none", Checks: []CICheck{}},
+				Reviews:      []ReviewBundleReview{},
+			}
+
+			diff, errResult, err := getPullRequestRawDiff(ctx, client, owner, repo, pullNumber)
+			if errResult != nil || err != nil {
+				return errResult, err
+			}
+			totalLines := strings.Count(diff, "\n") + 1
+			if totalLines <= maxLines {
+				bundle.Diff = diff
+			} else {
+				summary := summarizeDiff(splitDiff(diff), totalLines, fmt.Sprintf(
+					"The diff has %d lines, more than max_diff_lines (%d). Use get_pull_request_file_diff to get the diff of individual files.",
+					totalLines, maxLines))
+				bundle.DiffSummary = &summary
+			}
+
+			var linked linkedIssuesQuery
+			vars := map[string]any{
+				"owner":      githubv4.String(owner),
+				"repo":       githubv4.String(repo),
+				"pullNumber": githubv4.Int(pullNumber), // #nosec G115 - pull request numbers are always small positive integers
+			}
+			if err := gqlClient.Query(ctx, &linked, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get linked issues", err), nil
+			}
+			for _, issue := range linked.Repository.PullRequest.ClosingIssuesReferences.Nodes {
+				bundle.LinkedIssues = append(bundle.LinkedIssues, LinkedIssue{
+					Number: int(issue.Number),
+					Title:  string(issue.Title),
+					State:  strings.ToLower(string(issue.State)),
+					URL:    issue.URL.String(),
+				})
+			}
+
+			runs, statuses, errResult := listCommitChecks(ctx, client, owner, repo, bundle.PullRequest.HeadSHA)
+			if errResult != nil {
+				return errResult, nil
+			}
+			bundle.CI = summarizeCI(runs, statuses)
+
+			reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request reviews",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			for _, review := range reviews {
+				bundleReview := ReviewBundleReview{
+					Author: review.GetUser().GetLogin(),
+					State:  strings.ToLower(review.GetState()),
+					Body:   review.GetBody(),
+				}
+				if review.SubmittedAt != nil {
+					bundleReview.SubmittedAt = &review.SubmittedAt.Time
+				}
+				bundle.Reviews = append(bundle.Reviews, bundleReview)
+			}
+
+			if bundle.ReviewThreads, err = listReviewThreads(ctx, gqlClient, owner, repo, pullNumber, true); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request review comments", err), nil
+			}
+
+			return MarshalledTextResult(bundle), nil
+		}
+}
+
+// summarizeCI sums up the check runs and commit statuses of a commit.
+func summarizeCI(runs []*github.CheckRun, statuses []*github.RepoStatus) CIStatus {
+	ci := CIStatus{State: "none", Checks: make([]CICheck, 0, len(runs)+len(statuses))}
+	for _, run := range runs {
+		check := CICheck{Name: run.GetName(), URL: run.GetHTMLURL()}
+		check.State, check.Conclusion = checkRunState(run)
+		ci.Checks = append(ci.Checks, check)
+	}
+	for _, status := range statuses {
+		check := CICheck{Name: status.GetContext(), URL: status.GetTargetURL()}
+		check.State, check.Conclusion = commitStatusState(status)
+		ci.Checks = append(ci.Checks, check)
+	}
+
+	for _, check := range ci.Checks {
+		switch {
+		case check.State == "failing":
+			ci.State = "failing"
+		case check.State == "pending" && ci.State != "failing":
+			ci.State = "pending"
+		case ci.State == "none":
+			ci.State = "passing"
+		}
+	}
+	return ci
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPullRequestReviewBundle(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestReviewBundle(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_review_bundle", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "max_diff_lines")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	submittedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	diff := "diff --git a/cache.go b/cache.go\n--- a/cache.go\n+++ b/cache.go\n@@ -1,2 +1,3 @@\n package cache\n+\n+var hits int\n"
+	restClient := func() *github.Client {
+		return github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposPullsByOwnerByRepoByPullNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.Contains(r.Header.Get("Accept"), "diff") {
+						_, _ = w.Write([]byte(diff))
+						return
+					}
+					_ = json.NewEncoder(w).Encode(&github.PullRequest{
+						Number:         github.Ptr(42),
+						Title:          github.Ptr("Add caching"),
+						State:          github.Ptr("open"),
+						User:           &github.User{Login: github.Ptr("author")},
+						Base:           &github.PullRequestBranch{Ref: github.Ptr("main")},
+						Head:           &github.PullRequestBranch{Ref: github.Ptr("feature/cache"), SHA: github.Ptr("head-sha")},
+						Mergeable:      github.Ptr(true),
+						MergeableState: github.Ptr("clean"),
+						Additions:      github.Ptr(2),
+						ChangedFiles:   github.Ptr(1),
+					})
+				}),
+			),
+			mock.WithRequestMatch(
+				mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+				&github.ListCheckRunsResults{
+					CheckRuns: []*github.CheckRun{
+						{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+						{Name: github.Ptr("test"), Status: github.Ptr("queued")},
+					},
+				},
+			),
+			mock.WithRequestMatch(
+				mock.GetReposCommitsStatusByOwnerByRepoByRef,
+				&github.CombinedStatus{Statuses: []*github.RepoStatus{{Context: github.Ptr("ci/lint"), State: github.Ptr("success")}}},
+			),
+			mock.WithRequestMatch(
+				mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+				[]*github.PullRequestReview{
+					{User: &github.User{Login: github.Ptr("reviewer")}, State: github.Ptr("CHANGES_REQUESTED"), Body: github.Ptr("Needs tests"), SubmittedAt: &github.Timestamp{Time: submittedAt}},
+				},
+			),
+		))
+	}
+	vars := map[string]any{
+		"owner":      githubv4.String("owner"),
+		"repo":       githubv4.String("repo"),
+		"pullNumber": githubv4.Int(42),
+	}
+	threadVars := map[string]any{
+		"owner":      githubv4.String("owner"),
+		"repo":       githubv4.String("repo"),
+		"pullNumber": githubv4.Int(42),
+		"after":      (*githubv4.String)(nil),
+	}
+	gqlClient := func() *githubv4.Client {
+		return githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewQueryMatcher(linkedIssuesQuery{}, vars, githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"closingIssuesReferences": map[string]any{
+							"nodes": []any{
+								map[string]any{"number": 7, "title": "Slow lookups", "state": "OPEN", "url": "https://github.com/owner/repo/issues/7"},
+							},
+						},
+					},
+				},
+			})),
+			githubv4mock.NewQueryMatcher(reviewThreadsQuery{}, threadVars, githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"reviewThreads": map[string]any{
+							"nodes": []any{
+								map[string]any{
+									"id":          "PRRT_1",
+									"path":        "cache.go",
+									"subjectType": "LINE",
+									"line":        3,
+									"diffSide":    "RIGHT",
+									"isResolved":  false,
+									"isOutdated":  false,
+									"comments": map[string]any{
+										"totalCount": 1,
+										"nodes": []any{
+											map[string]any{
+												"id":         "PRRC_1",
+												"databaseId": 1,
+												"author":     map[string]any{"login": "reviewer"},
+												"body":       "Guard this with a mutex",
+												"diffHunk":   "@@ -1,2 +1,3 @@",
+												"createdAt":  submittedAt.Format(time.RFC3339),
+												"url":        "https://github.com/owner/repo/pull/42#discussion_r1",
+											},
+										},
+									},
+								},
+							},
+							"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "cursor1"},
+						},
+					},
+				},
+			})),
+		))
+	}
+
+	t.Run("bundles the review context", func(t *testing.T) {
+		_, handler := GetPullRequestReviewBundle(stubGetClientFn(restClient()), stubGetGQLClientFn(gqlClient()), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+		}))
+		require.NoError(t, err)
+
+		var bundle PullRequestReviewBundle
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &bundle))
+		assert.Equal(t, ReviewBundlePullRequest{
+			Number:         42,
+			Title:          "Add caching",
+			Author:         "author",
+			State:          "open",
+			Base:           "main",
+			Head:           "feature/cache",
+			HeadSHA:        "head-sha",
+			Mergeable:      github.Ptr(true),
+			MergeableState: "clean",
+			Additions:      2,
+			ChangedFiles:   1,
+		}, bundle.PullRequest)
+		assert.Equal(t, diff, bundle.Diff)
+		assert.Nil(t, bundle.DiffSummary)
+		assert.Equal(t, []LinkedIssue{{Number: 7, Title: "Slow lookups", State: "open", URL: "https://github.com/owner/repo/issues/7"}}, bundle.LinkedIssues)
+		assert.Equal(t, CIStatus{
+			State: "pending",
+			Checks: []CICheck{
+				{Name: "build", State: "passing", Conclusion: "success"},
+				{Name: "test", State: "pending"},
+				{Name: "ci/lint", State: "passing", Conclusion: "success"},
+			},
+		}, bundle.CI)
+		assert.Equal(t, []ReviewBundleReview{{Author: "reviewer", State: "changes_requested", Body: "Needs tests", SubmittedAt: &submittedAt}}, bundle.Reviews)
+		require.Len(t, bundle.ReviewThreads, 1)
+		assert.Equal(t, "cache.go", bundle.ReviewThreads[0].Path)
+		assert.Equal(t, "Guard this with a mutex", bundle.ReviewThreads[0].Comments[0].Body)
+	})
+
+	t.Run("summarizes long diffs", func(t *testing.T) {
+		_, handler := GetPullRequestReviewBundle(stubGetClientFn(restClient()), stubGetGQLClientFn(gqlClient()), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":          "owner",
+			"repo":           "repo",
+			"pullNumber":     float64(42),
+			"max_diff_lines": float64(3),
+		}))
+		require.NoError(t, err)
+
+		var bundle PullRequestReviewBundle
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &bundle))
+		assert.Empty(t, bundle.Diff)
+		require.NotNil(t, bundle.DiffSummary)
+		require.Len(t, bundle.DiffSummary.Files, 1)
+		assert.Equal(t, "cache.go", bundle.DiffSummary.Files[0].Path)
+		assert.Equal(t, 2, bundle.DiffSummary.Additions)
+	})
+}
+
+func Test_SummarizeCI(t *testing.T) {
+	passing := &github.CheckRun{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")}
+	failing := &github.RepoStatus{Context: github.Ptr("ci/lint"), State: github.Ptr("failure")}
+	pending := &github.CheckRun{Name: github.Ptr("test"), Status: github.Ptr("in_progress")}
+
+	assert.Equal(t, "none", summarizeCI(nil, nil).State)
+	assert.Equal(t, "passing", summarizeCI([]*github.CheckRun{passing}, nil).State)
+	assert.Equal(t, "pending", summarizeCI([]*github.CheckRun{passing, pending}, nil).State)
+	assert.Equal(t, "failing", summarizeCI([]*github.CheckRun{pending, passing}, []*github.RepoStatus{failing}).State)
+}
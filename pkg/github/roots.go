@@ -0,0 +1,250 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// rootsWait bounds how long a tool call waits for the client to answer a request for its roots, so that a client
+// failing to answer doesn't hold up its calls.
+const rootsWait = 5 * time.Second
+
+// Root is a repository, or all the repositories of an owner when Repo is empty, that the client declared as one
+// of its roots.
+type Root struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo,omitempty"`
+}
+
+func (r Root) String() string {
+	if r.Repo == "" {
+		return r.Owner + "/*"
+	}
+	return r.Owner + "/" + r.Repo
+}
+
+// Contains tells whether the root covers the repository, or the owner itself when repo is empty.
+func (r Root) Contains(owner, repo string) bool {
+	if !strings.EqualFold(r.Owner, owner) {
+		return false
+	}
+	return r.Repo == "" || (repo != "" && strings.EqualFold(r.Repo, repo))
+}
+
+// ParseRoot reads a root URI of the client such as https://github.com/octo-org/octo-repo, or
+// https://github.com/octo-org for all the repositories of octo-org. Roots that aren't web URLs of the GitHub host,
+// such as the file roots of local directories, are ignored.
+func ParseRoot(webHost string, uri string) (Root, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || !strings.EqualFold(u.Hostname(), webHost) {
+		return Root{}, false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return Root{Owner: parts[0]}, true
+	case len(parts) >= 2 && parts[0] != "" && parts[1] != "":
+		return Root{Owner: parts[0], Repo: strings.TrimSuffix(parts[1], ".git")}, true
+	default:
+		return Root{}, false
+	}
+}
+
+// Roots holds the roots the client of the server declared, which are requested from the client when its session
+// starts and again whenever it notifies the server that they changed.
+type Roots struct {
+	webHost string
+
+	mu       sync.Mutex
+	roots    []Root
+	declared bool
+	pending  chan struct{}
+}
+
+// NewRoots creates the roots of a client of the server for the GitHub host with the given web hostname, such as
+// github.com, whose repository URLs are the roots taken into account.
+func NewRoots(webHost string) *Roots {
+	return &Roots{webHost: webHost}
+}
+
+// Request records that the roots were requested from the client, so that tool calls wait for its answer.
+func (r *Roots) Request() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending == nil {
+		r.pending = make(chan struct{})
+	}
+}
+
+// Set records the roots the client answered with.
+func (r *Roots) Set(roots []mcp.Root) {
+	parsed := make([]Root, 0, len(roots))
+	for _, root := range roots {
+		if p, ok := ParseRoot(r.webHost, root.URI); ok {
+			parsed = append(parsed, p)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.roots = parsed
+	r.declared = true
+	r.done()
+}
+
+// Fail records that the client didn't answer with its roots, keeping the roots it answered with before.
+func (r *Roots) Fail() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.done()
+}
+
+func (r *Roots) done() {
+	if r.pending != nil {
+		close(r.pending)
+		r.pending = nil
+	}
+}
+
+// Current returns the roots of the client, waiting for the answer to a request for them, and whether the client
+// declared its roots at all.
+func (r *Roots) Current(ctx context.Context) ([]Root, bool) {
+	r.mu.Lock()
+	pending := r.pending
+	r.mu.Unlock()
+	if pending != nil {
+		timer := time.NewTimer(rootsWait)
+		defer timer.Stop()
+		select {
+		case <-pending:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Root(nil), r.roots...), r.declared
+}
+
+type rootsScopeKey struct{}
+
+// rootsScope is what a tool call may reach when tool calls are restricted to the roots of the client.
+type rootsScope struct {
+	roots    []Root
+	declared bool
+}
+
+// checkRootsScope returns an error when tool calls are restricted to the roots of the client and the repository,
+// or the owner itself when repo is empty, isn't within them. Tools calling other tools check their targets with it,
+// as those calls don't go through the middlewares of the server.
+func checkRootsScope(ctx context.Context, owner, repo string) error {
+	scope, ok := ctx.Value(rootsScopeKey{}).(rootsScope)
+	if !ok || owner == "" {
+		return nil
+	}
+	for _, root := range scope.roots {
+		if root.Contains(owner, repo) {
+			return nil
+		}
+	}
+
+	target := owner
+	if repo != "" {
+		target = owner + "/" + repo
+	}
+	if !scope.declared || len(scope.roots) == 0 {
+		return fmt.Errorf("%s is outside the roots of the client, which declared no GitHub repositories as roots, and this server only allows tool calls within them", target)
+	}
+	names := make([]string, len(scope.roots))
+	for i, root := range scope.roots {
+		names[i] = root.String()
+	}
+	return fmt.Errorf("%s is outside the roots of the client (%s), and this server only allows tool calls within them", target, strings.Join(names, ", "))
+}
+
+// RootsMiddleware scopes tool calls to the roots of the client. The owner and repo arguments that calls leave out
+// default to the roots, when the tool takes them and the roots leave no doubt about their value. When the policy
+// restricts calls to the roots, calls naming a repository, or an owner, outside the roots fail.
+func RootsMiddleware(roots *Roots, policies *PolicyStore, getTool func(name string) (server.ServerTool, bool)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			current, declared := roots.Current(ctx)
+			if len(current) > 0 {
+				if tool, ok := getTool(request.Params.Name); ok {
+					request = withRootDefaults(request, tool.Tool, current)
+				}
+			}
+			if !policies.Load().RestrictToRoots {
+				return next(ctx, request)
+			}
+
+			ctx = context.WithValue(ctx, rootsScopeKey{}, rootsScope{roots: current, declared: declared})
+			args := request.GetArguments()
+			owner, _ := args["owner"].(string)
+			repo, _ := args["repo"].(string)
+			if err := checkRootsScope(ctx, owner, repo); err != nil {
+				LogToClient(ctx, mcp.LoggingLevelWarning, ClientLoggerRoots, err.Error(), map[string]any{"tool": request.Params.Name})
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// withRootDefaults fills in the owner and repo arguments the call leaves out, when the tool takes them and a
+// single root matches.
+func withRootDefaults(request mcp.CallToolRequest, tool mcp.Tool, roots []Root) mcp.CallToolRequest {
+	_, hasOwner := tool.InputSchema.Properties["owner"]
+	_, hasRepo := tool.InputSchema.Properties["repo"]
+	if !hasOwner {
+		return request
+	}
+	args := request.GetArguments()
+	owner, _ := args["owner"].(string)
+	repo, _ := args["repo"].(string)
+
+	filled := false
+	if owner == "" {
+		owner = roots[0].Owner
+		for _, root := range roots[1:] {
+			if !strings.EqualFold(root.Owner, owner) {
+				return request
+			}
+		}
+		filled = true
+	}
+	if hasRepo && repo == "" {
+		var matching []Root
+		for _, root := range roots {
+			if strings.EqualFold(root.Owner, owner) {
+				matching = append(matching, root)
+			}
+		}
+		if len(matching) == 1 && matching[0].Repo != "" {
+			repo = matching[0].Repo
+			filled = true
+		}
+	}
+	if !filled {
+		return request
+	}
+
+	defaulted := make(map[string]any, len(args)+2)
+	for k, v := range args {
+		defaulted[k] = v
+	}
+	defaulted["owner"] = owner
+	if repo != "" {
+		defaulted["repo"] = repo
+	}
+	request.Params.Arguments = defaulted
+	return request
+}
@@ -0,0 +1,179 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRoot(t *testing.T) {
+	tests := []struct {
+		uri      string
+		expected Root
+		ok       bool
+	}{
+		{uri: "https://github.com/octo-org/octo-repo", expected: Root{Owner: "octo-org", Repo: "octo-repo"}, ok: true},
+		{uri: "https://github.com/octo-org/octo-repo.git", expected: Root{Owner: "octo-org", Repo: "octo-repo"}, ok: true},
+		{uri: "https://GitHub.com/octo-org/octo-repo/tree/main", expected: Root{Owner: "octo-org", Repo: "octo-repo"}, ok: true},
+		{uri: "https://github.com/octo-org/", expected: Root{Owner: "octo-org"}, ok: true},
+		{uri: "https://github.com/"},
+		{uri: "https://ghe.example.com/octo-org/octo-repo"},
+		{uri: "file:///home/octocat/octo-repo"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.uri, func(t *testing.T) {
+			root, ok := ParseRoot("github.com", tc.uri)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, root)
+		})
+	}
+}
+
+func Test_RootContains(t *testing.T) {
+	repoRoot := Root{Owner: "octo-org", Repo: "octo-repo"}
+	assert.True(t, repoRoot.Contains("Octo-Org", "Octo-Repo"))
+	assert.False(t, repoRoot.Contains("octo-org", "other-repo"))
+	assert.False(t, repoRoot.Contains("octo-org", ""))
+
+	ownerRoot := Root{Owner: "octo-org"}
+	assert.True(t, ownerRoot.Contains("octo-org", "any-repo"))
+	assert.True(t, ownerRoot.Contains("octo-org", ""))
+	assert.False(t, ownerRoot.Contains("other-org", "any-repo"))
+}
+
+func Test_RootsCurrent(t *testing.T) {
+	roots := NewRoots("github.com")
+	current, declared := roots.Current(context.Background())
+	assert.Empty(t, current)
+	assert.False(t, declared)
+
+	// Calls wait for the answer to a pending request
+	roots.Request()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		roots.Set([]mcp.Root{
+			{URI: "https://github.com/octo-org/octo-repo"},
+			{URI: "file:///home/octocat/octo-repo"},
+		})
+	}()
+	current, declared = roots.Current(context.Background())
+	assert.Equal(t, []Root{{Owner: "octo-org", Repo: "octo-repo"}}, current)
+	assert.True(t, declared)
+
+	// A failed request keeps the roots answered before
+	roots.Request()
+	roots.Fail()
+	current, declared = roots.Current(context.Background())
+	assert.Equal(t, []Root{{Owner: "octo-org", Repo: "octo-repo"}}, current)
+	assert.True(t, declared)
+}
+
+func Test_RootsMiddleware(t *testing.T) {
+	tools := map[string]mcp.Tool{
+		"get_issue": mcp.NewTool("get_issue",
+			mcp.WithString("owner", mcp.Required()),
+			mcp.WithString("repo", mcp.Required()),
+			mcp.WithNumber("issue_number", mcp.Required()),
+		),
+		"list_org_repos": mcp.NewTool("list_org_repos",
+			mcp.WithString("owner", mcp.Required()),
+		),
+		"search_code": mcp.NewTool("search_code",
+			mcp.WithString("query", mcp.Required()),
+		),
+	}
+	getTool := func(name string) (server.ServerTool, bool) {
+		tool, ok := tools[name]
+		return server.ServerTool{Tool: tool}, ok
+	}
+
+	var received map[string]any
+	var handler server.ToolHandlerFunc = func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		received = request.GetArguments()
+		return mcp.NewToolResultText("ok"), nil
+	}
+	call := func(t *testing.T, roots *Roots, restrict bool, name string, args map[string]any) *mcp.CallToolResult {
+		received = nil
+		request := createMCPRequest(args)
+		request.Params.Name = name
+		result, err := RootsMiddleware(roots, NewPolicyStore(Policy{RestrictToRoots: restrict}), getTool)(handler)(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+	declared := func(uris ...string) *Roots {
+		roots := NewRoots("github.com")
+		declaredRoots := make([]mcp.Root, len(uris))
+		for i, uri := range uris {
+			declaredRoots[i] = mcp.Root{URI: uri}
+		}
+		roots.Set(declaredRoots)
+		return roots
+	}
+
+	t.Run("defaults owner and repo to a single repository root", func(t *testing.T) {
+		call(t, declared("https://github.com/octo-org/octo-repo"), false, "get_issue", map[string]any{"issue_number": float64(1)})
+		assert.Equal(t, map[string]any{"owner": "octo-org", "repo": "octo-repo", "issue_number": float64(1)}, received)
+	})
+
+	t.Run("defaults only the owner shared by the roots", func(t *testing.T) {
+		roots := declared("https://github.com/octo-org/octo-repo", "https://github.com/octo-org/other-repo")
+		call(t, roots, false, "get_issue", map[string]any{"issue_number": float64(1)})
+		assert.Equal(t, map[string]any{"owner": "octo-org", "issue_number": float64(1)}, received)
+
+		call(t, roots, false, "list_org_repos", map[string]any{})
+		assert.Equal(t, map[string]any{"owner": "octo-org"}, received)
+	})
+
+	t.Run("leaves calls alone when roots have several owners", func(t *testing.T) {
+		call(t, declared("https://github.com/octo-org/octo-repo", "https://github.com/other-org"), false, "get_issue", map[string]any{"issue_number": float64(1)})
+		assert.Equal(t, map[string]any{"issue_number": float64(1)}, received)
+	})
+
+	t.Run("keeps the arguments of the call", func(t *testing.T) {
+		call(t, declared("https://github.com/octo-org/octo-repo"), false, "get_issue", map[string]any{"owner": "other-org", "repo": "other-repo"})
+		assert.Equal(t, map[string]any{"owner": "other-org", "repo": "other-repo"}, received)
+	})
+
+	t.Run("allows calls outside the roots without restriction", func(t *testing.T) {
+		result := call(t, declared("https://github.com/octo-org/octo-repo"), false, "get_issue", map[string]any{"owner": "other-org", "repo": "other-repo"})
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("fails calls outside the roots with restriction", func(t *testing.T) {
+		result := call(t, declared("https://github.com/octo-org/octo-repo"), true, "get_issue", map[string]any{"owner": "octo-org", "repo": "other-repo"})
+		require.True(t, result.IsError)
+		assert.Nil(t, received)
+		assert.Equal(t, "octo-org/other-repo is outside the roots of the client (octo-org/octo-repo), and this server only allows tool calls within them", getErrorResult(t, result).Text)
+
+		result = call(t, declared("https://github.com/octo-org/octo-repo"), true, "get_issue", map[string]any{"issue_number": float64(1)})
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("fails calls naming repositories when the client declared no roots", func(t *testing.T) {
+		result := call(t, NewRoots("github.com"), true, "list_org_repos", map[string]any{"owner": "octo-org"})
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "declared no GitHub repositories as roots")
+
+		result = call(t, NewRoots("github.com"), true, "search_code", map[string]any{"query": "repo:octo-org/octo-repo"})
+		assert.False(t, result.IsError)
+	})
+}
+
+func Test_CheckRootsScope(t *testing.T) {
+	assert.NoError(t, checkRootsScope(context.Background(), "octo-org", "octo-repo"))
+
+	ctx := context.WithValue(context.Background(), rootsScopeKey{}, rootsScope{
+		roots:    []Root{{Owner: "octo-org"}, {Owner: "other-org", Repo: "other-repo"}},
+		declared: true,
+	})
+	assert.NoError(t, checkRootsScope(ctx, "octo-org", "any-repo"))
+	assert.NoError(t, checkRootsScope(ctx, "other-org", "other-repo"))
+	assert.NoError(t, checkRootsScope(ctx, "", ""))
+	assert.EqualError(t, checkRootsScope(ctx, "other-org", "any-repo"),
+		"other-org/any-repo is outside the roots of the client (octo-org/*, other-org/other-repo), and this server only allows tool calls within them")
+}
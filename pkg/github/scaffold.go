@@ -0,0 +1,295 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ScaffoldedRepository is the output type of scaffold_repository. Errors lists the steps of the profile that failed
+// after the repository was created, which can be retried with the dedicated tools.
+type ScaffoldedRepository struct {
+	FullName         string   `json:"full_name"`
+	URL              string   `json:"html_url"`
+	DefaultBranch    string   `json:"default_branch"`
+	CommitSHA        string   `json:"commit_sha"`
+	Files            []string `json:"files"`
+	Labels           []string `json:"labels"`
+	BranchProtection bool     `json:"branch_protection"`
+	Errors           []string `json:"errors,omitempty"`
+}
+
+// scaffoldProfile is the setup applied to a scaffolded repository after its files are committed.
+type scaffoldProfile struct {
+	Labels []struct {
+		Name        string `mapstructure:"name"`
+		Color       string `mapstructure:"color"`
+		Description string `mapstructure:"description"`
+	} `mapstructure:"labels"`
+	BranchProtection *struct {
+		RequiredApprovingReviewCount int      `mapstructure:"required_approving_review_count"`
+		RequireCodeOwnerReviews      bool     `mapstructure:"require_code_owner_reviews"`
+		RequiredStatusChecks         []string `mapstructure:"required_status_checks"`
+		EnforceAdmins                bool     `mapstructure:"enforce_admins"`
+	} `mapstructure:"branch_protection"`
+}
+
+// ScaffoldRepository creates a tool to create a repository with a set of files in its initial commit, and optionally
+// labels and protection of its default branch.
+func ScaffoldRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("scaffold_repository",
+			mcp.WithDescription(t("TOOL_SCAFFOLD_REPOSITORY_DESCRIPTION", "Create a GitHub repository whose initial commit contains the given files, e.g. README, LICENSE, CODEOWNERS and workflows, then optionally create labels and protect the default branch as described by a profile.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SCAFFOLD_REPOSITORY_USER_TITLE", "Scaffold repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[ScaffoldedRepository](),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Repository description"),
+			),
+			mcp.WithString("organization",
+				mcp.Description("Organization to create the repository in (omit to create in your personal account)"),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Whether repo should be private"),
+			),
+			mcp.WithArray("files",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path", "content"},
+						"properties": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "path to the file",
+							},
+							"content": map[string]interface{}{
+								"type":        "string",
+								"description": "file content",
+							},
+						},
+					}),
+				mcp.Description("Files of the initial commit, each object with path (string) and content (string)"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Message of the initial commit (default \"Initial commit\")"),
+			),
+			mcp.WithObject("profile",
+				mcp.Description("Setup to apply once the files are committed"),
+				mcp.Properties(map[string]any{
+					"labels": map[string]any{
+						"type":        "array",
+						"description": "Labels to create, replacing the default labels with the same names",
+						"items": map[string]any{
+							"type":     "object",
+							"required": []string{"name"},
+							"properties": map[string]any{
+								"name":        map[string]any{"type": "string"},
+								"color":       map[string]any{"type": "string", "description": "Hexadecimal color code without the leading #"},
+								"description": map[string]any{"type": "string"},
+							},
+						},
+					},
+					"branch_protection": map[string]any{
+						"type":        "object",
+						"description": "Protection of the default branch",
+						"properties": map[string]any{
+							"required_approving_review_count": map[string]any{"type": "number", "description": "Number of approving reviews required to merge pull requests, 0 to not require pull requests"},
+							"require_code_owner_reviews":      map[string]any{"type": "boolean", "description": "Require the approval of code owners"},
+							"required_status_checks":          map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Names of the status checks required to pass before merging"},
+							"enforce_admins":                  map[string]any{"type": "boolean", "description": "Apply the protection to administrators too"},
+						},
+					},
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			organization, err := OptionalParam[string](request, "organization")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			private, err := OptionalParam[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := OptionalParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if message == "" {
+				message = "Initial commit"
+			}
+			var profile scaffoldProfile
+			if err := mapstructure.Decode(request.GetArguments()["profile"], &profile); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid profile: %v", err)), nil
+			}
+
+			filesObj, ok := request.GetArguments()["files"].([]interface{})
+			if !ok || len(filesObj) == 0 {
+				return mcp.NewToolResultError("files parameter must be a non-empty array of objects with path and content"), nil
+			}
+			result := ScaffoldedRepository{Files: []string{}, Labels: []string{}}
+			entries := make([]*github.TreeEntry, 0, len(filesObj))
+			for _, file := range filesObj {
+				fileMap, ok := file.(map[string]interface{})
+				if !ok {
+					return mcp.NewToolResultError("each file must be an object with path and content"), nil
+				}
+				path, ok := fileMap["path"].(string)
+				if !ok || path == "" {
+					return mcp.NewToolResultError("each file must have a path"), nil
+				}
+				content, ok := fileMap["content"].(string)
+				if !ok {
+					return mcp.NewToolResultError("each file must have content"), nil
+				}
+				entries = append(entries, &github.TreeEntry{
+					Path:    github.Ptr(path),
+					Mode:    github.Ptr("100644"),
+					Type:    github.Ptr("blob"),
+					Content: github.Ptr(content),
+				})
+				result.Files = append(result.Files, path)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The Git database API doesn't work on empty repositories, so the repository is initialized and its
+			// generated commit replaced by one without parents
+			created, resp, err := client.Repositories.Create(ctx, organization, &github.Repository{
+				Name:        github.Ptr(name),
+				Description: github.Ptr(description),
+				Private:     github.Ptr(private),
+				AutoInit:    github.Ptr(true),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create repository",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			owner := created.GetOwner().GetLogin()
+			result.FullName, result.URL, result.DefaultBranch = created.GetFullName(), created.GetHTMLURL(), created.GetDefaultBranch()
+
+			// The repository exists from here, so failures say so
+			failed := func(step string, resp *github.Response, err error) *mcp.CallToolResult {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("repository %s was created, but %s failed", result.FullName, step),
+					resp,
+					err,
+				)
+			}
+			tree, resp, err := client.Git.CreateTree(ctx, owner, name, "", entries)
+			if err != nil {
+				return failed("creating the tree of the initial commit", resp, err), nil
+			}
+			_ = resp.Body.Close()
+			commit, resp, err := client.Git.CreateCommit(ctx, owner, name, &github.Commit{
+				Message: github.Ptr(message),
+				Tree:    tree,
+			}, nil)
+			if err != nil {
+				return failed("creating the initial commit", resp, err), nil
+			}
+			_ = resp.Body.Close()
+			_, resp, err = client.Git.UpdateRef(ctx, owner, name, &github.Reference{
+				Ref:    github.Ptr("refs/heads/" + result.DefaultBranch),
+				Object: &github.GitObject{SHA: commit.SHA},
+			}, true)
+			if err != nil {
+				return failed("updating the default branch", resp, err), nil
+			}
+			_ = resp.Body.Close()
+			result.CommitSHA = commit.GetSHA()
+
+			for _, label := range profile.Labels {
+				if label.Name == "" {
+					result.Errors = append(result.Errors, "skipped a label without a name")
+					continue
+				}
+				if err := applyLabel(ctx, client, owner, name, label.Name, label.Color, label.Description); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("failed to create label %s: %v", label.Name, err))
+					continue
+				}
+				result.Labels = append(result.Labels, label.Name)
+			}
+
+			if protection := profile.BranchProtection; protection != nil {
+				protectionRequest := &github.ProtectionRequest{EnforceAdmins: protection.EnforceAdmins}
+				if protection.RequiredApprovingReviewCount > 0 || protection.RequireCodeOwnerReviews {
+					protectionRequest.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+						RequiredApprovingReviewCount: protection.RequiredApprovingReviewCount,
+						RequireCodeOwnerReviews:      protection.RequireCodeOwnerReviews,
+					}
+				}
+				if len(protection.RequiredStatusChecks) > 0 {
+					checks := make([]*github.RequiredStatusCheck, 0, len(protection.RequiredStatusChecks))
+					for _, check := range protection.RequiredStatusChecks {
+						checks = append(checks, &github.RequiredStatusCheck{Context: check})
+					}
+					protectionRequest.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: true, Checks: &checks}
+				}
+				_, resp, err := client.Repositories.UpdateBranchProtection(ctx, owner, name, result.DefaultBranch, protectionRequest)
+				if err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("failed to protect %s: %v", result.DefaultBranch, err))
+				} else {
+					_ = resp.Body.Close()
+					result.BranchProtection = true
+				}
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// applyLabel creates a label, or updates the label with the same name when the repository already has one.
+func applyLabel(ctx context.Context, client *github.Client, owner, repo, name, color, description string) error {
+	label := &github.Label{Name: github.Ptr(name)}
+	if color != "" {
+		label.Color = github.Ptr(color)
+	}
+	if description != "" {
+		label.Description = github.Ptr(description)
+	}
+
+	_, resp, err := client.Issues.CreateLabel(ctx, owner, repo, label)
+	if err == nil {
+		_ = resp.Body.Close()
+		return nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnprocessableEntity {
+		return err
+	}
+	_, resp, err = client.Issues.EditLabel(ctx, owner, repo, name, label)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ScaffoldRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ScaffoldRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "scaffold_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "profile")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name", "files"})
+
+	createdRepo := &github.Repository{
+		Name:          github.Ptr("service"),
+		FullName:      github.Ptr("platform/service"),
+		HTMLURL:       github.Ptr("https://github.com/platform/service"),
+		DefaultBranch: github.Ptr("main"),
+		Owner:         &github.User{Login: github.Ptr("platform")},
+	}
+	files := []any{
+		map[string]any{"path": "README.md", "content": "# Service"},
+		map[string]any{"path": ".github/CODEOWNERS", "content": "* @platform/owners"},
+	}
+
+	t.Run("creates the repository with its initial commit and profile", func(t *testing.T) {
+		var protection map[string]any
+		var labels []string
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostOrgsReposByOrg,
+				expectRequestBody(t, map[string]any{
+					"name":        "service",
+					"description": "A new service",
+					"private":     true,
+					"auto_init":   true,
+				}).andThen(mockResponse(t, http.StatusCreated, createdRepo)),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.NotContains(t, body, "base_tree")
+					assert.Len(t, body["tree"], 2)
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(&github.Tree{SHA: github.Ptr("tree-sha")})
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitCommitsByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "Scaffold service", body["message"])
+					assert.Equal(t, "tree-sha", body["tree"])
+					assert.NotContains(t, body, "parents")
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(&github.Commit{SHA: github.Ptr("commit-sha")})
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposGitRefsByOwnerByRepoByRef,
+				expectRequestBody(t, map[string]any{"sha": "commit-sha", "force": true}).andThen(
+					mockResponse(t, http.StatusOK, &github.Reference{Ref: github.Ptr("refs/heads/main")}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposLabelsByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var label map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&label))
+					if label["name"] == "bug" {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"resource": "Label", "code": "already_exists", "field": "name"}]}`))
+						return
+					}
+					labels = append(labels, "created "+label["name"].(string))
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{}`))
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposLabelsByOwnerByRepoByName,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					labels = append(labels, "updated "+r.URL.Path)
+					_, _ = w.Write([]byte(`{}`))
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&protection))
+					_, _ = w.Write([]byte(`{}`))
+				}),
+			),
+		))
+		_, handler := ScaffoldRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"name":         "service",
+			"description":  "A new service",
+			"organization": "platform",
+			"private":      true,
+			"files":        files,
+			"message":      "Scaffold service",
+			"profile": map[string]any{
+				"labels": []any{
+					map[string]any{"name": "bug", "color": "d73a4a"},
+					map[string]any{"name": "incident", "color": "b60205", "description": "Production incident"},
+				},
+				"branch_protection": map[string]any{
+					"required_approving_review_count": float64(1),
+					"required_status_checks":          []any{"build"},
+				},
+			},
+		}))
+		require.NoError(t, err)
+
+		var scaffolded ScaffoldedRepository
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &scaffolded))
+		assert.Equal(t, ScaffoldedRepository{
+			FullName:         "platform/service",
+			URL:              "https://github.com/platform/service",
+			DefaultBranch:    "main",
+			CommitSHA:        "commit-sha",
+			Files:            []string{"README.md", ".github/CODEOWNERS"},
+			Labels:           []string{"bug", "incident"},
+			BranchProtection: true,
+		}, scaffolded)
+		assert.Equal(t, []string{"updated /repos/platform/service/labels/bug", "created incident"}, labels)
+		assert.Equal(t, float64(1), protection["required_pull_request_reviews"].(map[string]any)["required_approving_review_count"])
+		assert.Equal(t, []any{map[string]any{"context": "build"}}, protection["required_status_checks"].(map[string]any)["checks"])
+	})
+
+	t.Run("reports a failed commit along with the created repository", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(mock.PostUserRepos, mockResponse(t, http.StatusCreated, createdRepo)),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusConflict)
+					_, _ = w.Write([]byte(`{"message": "Git Repository is empty."}`))
+				}),
+			),
+		))
+		_, handler := ScaffoldRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"name":  "service",
+			"files": files,
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "repository platform/service was created, but creating the tree of the initial commit failed")
+	})
+
+	t.Run("requires files", func(t *testing.T) {
+		_, handler := ScaffoldRepository(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"name":  "service",
+			"files": []any{},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "files parameter must be a non-empty array of objects with path and content", getErrorResult(t, result).Text)
+	})
+}
@@ -0,0 +1,188 @@
+package github
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+)
+
+// OAuthScopesHeader lists the scopes of classic personal access tokens and OAuth tokens in GitHub responses.
+// It is absent for fine-grained personal access tokens and GitHub App tokens, which have permissions instead.
+const OAuthScopesHeader = "X-OAuth-Scopes"
+
+// parentScopes maps scopes to the broader scope that includes them, following GitHub's scope hierarchy.
+var parentScopes = map[string]string{
+	"repo:status":          "repo",
+	"repo_deployment":      "repo",
+	"public_repo":          "repo",
+	"repo:invite":          "repo",
+	"security_events":      "repo",
+	"read:org":             "write:org",
+	"write:org":            "admin:org",
+	"read:public_key":      "write:public_key",
+	"write:public_key":     "admin:public_key",
+	"read:repo_hook":       "write:repo_hook",
+	"write:repo_hook":      "admin:repo_hook",
+	"read:user":            "user",
+	"user:email":           "user",
+	"user:follow":          "user",
+	"read:packages":        "write:packages",
+	"read:discussion":      "write:discussion",
+	"read:gpg_key":         "write:gpg_key",
+	"write:gpg_key":        "admin:gpg_key",
+	"read:enterprise":      "admin:enterprise",
+	"manage_runners:org":   "admin:org",
+	"read:project":         "project",
+	"read:audit_log":       "admin:org",
+	"read:ssh_signing_key": "write:ssh_signing_key",
+}
+
+// TokenScopes returns the scopes listed in a response, and false when the response doesn't list any because
+// the token isn't a classic personal access token or OAuth token.
+func TokenScopes(header http.Header) ([]string, bool) {
+	values, ok := header[http.CanonicalHeaderKey(OAuthScopesHeader)]
+	if !ok {
+		return nil, false
+	}
+	var scopes []string
+	for _, value := range values {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes, true
+}
+
+// HasScope reports whether the granted scopes include the required one, either directly or through a broader scope.
+func HasScope(granted []string, required string) bool {
+	for scope := required; scope != ""; scope = parentScopes[scope] {
+		for _, g := range granted {
+			if g == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MissingScopes returns the required scopes that aren't included in the granted ones.
+func MissingScopes(granted []string, required []string) []string {
+	var missing []string
+	for _, scope := range required {
+		if !HasScope(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// toolsetScopes lists the scopes the tools of a toolset need from classic personal access tokens and OAuth tokens.
+// Toolsets missing from it only read public data, which needs no scope.
+var toolsetScopes = map[string][]string{
+	"repos":             {"repo"},
+	"releases":          {"repo"},
+	"issues":            {"repo"},
+	"pull_requests":     {"repo"},
+	"actions":           {"repo"},
+	"discussions":       {"repo"},
+	"code_security":     {"security_events"},
+	"secret_protection": {"security_events"},
+	"dependabot":        {"security_events"},
+	"notifications":     {"notifications"},
+	"orgs":              {"read:org"},
+	"projects":          {"read:project"},
+	"packages":          {"read:packages"},
+	"copilot":           {"manage_billing:copilot"},
+}
+
+// toolScopes lists the scopes of tools that need other scopes than the rest of their toolset.
+var toolScopes = map[string][]string{
+	"get_teams":                               {"read:org"},
+	"get_team_members":                        {"read:org"},
+	"search_orgs":                             {},
+	"list_org_events":                         {},
+	"update_org_settings":                     {"admin:org"},
+	"invite_org_member":                       {"admin:org"},
+	"remove_org_member":                       {"admin:org"},
+	"list_team_discussions":                   {"read:org", "read:discussion"},
+	"create_team_discussion":                  {"read:org", "write:discussion"},
+	"add_team_discussion_comment":             {"read:org", "write:discussion"},
+	"create_team":                             {"admin:org"},
+	"update_team":                             {"admin:org"},
+	"add_team_member":                         {"admin:org"},
+	"remove_team_member":                      {"admin:org"},
+	"add_team_repo":                           {"repo", "admin:org"},
+	"remove_team_repo":                        {"repo", "admin:org"},
+	"create_gist":                             {"gist"},
+	"update_gist":                             {"gist"},
+	"add_project_item":                        {"project"},
+	"delete_project_item":                     {"project"},
+	"star_repository":                         {"public_repo"},
+	"unstar_repository":                       {"public_repo"},
+	"watch_repository":                        {"notifications"},
+	"unwatch_repository":                      {"notifications"},
+	"delete_repository":                       {"delete_repo"},
+	"list_commit_statuses":                    {"repo:status"},
+	"create_commit_status":                    {"repo:status"},
+	"delete_package_version":                  {"read:packages", "delete:packages"},
+	"restore_package_version":                 {"write:packages"},
+	"list_repository_security_advisories":     {"repo"},
+	"list_org_repository_security_advisories": {"repo"},
+	"get_repository_security_advisory":        {"repo"},
+	"create_repository_security_advisory":     {"repo"},
+	"publish_repository_security_advisory":    {"repo"},
+	"request_security_advisory_cve":           {"repo"},
+}
+
+// limitingScopes lists scopes without which tools still work, but not for everything, with what they are needed for.
+var limitingScopes = map[string]map[string]string{
+	"create_or_update_file": {"workflow": "changing files in .github/workflows"},
+	"push_files":            {"workflow": "changing files in .github/workflows"},
+	"create_signed_commit":  {"workflow": "changing files in .github/workflows"},
+	"cherry_pick_commits":   {"workflow": "cherry-picking changes to .github/workflows"},
+	"delete_file":           {"workflow": "deleting files in .github/workflows"},
+	"scaffold_repository":   {"workflow": "scaffolding files in .github/workflows"},
+}
+
+// RequiredScopes returns the scopes a tool of a toolset needs from classic personal access tokens and OAuth tokens.
+func RequiredScopes(toolset, tool string) []string {
+	if scopes, ok := toolScopes[tool]; ok {
+		return scopes
+	}
+	return toolsetScopes[toolset]
+}
+
+// ToolScopeIssue describes a tool that the scopes of a token don't fully cover.
+type ToolScopeIssue struct {
+	Tool          string   `json:"tool"`
+	Toolset       string   `json:"toolset"`
+	MissingScopes []string `json:"missing_scopes"`
+	// Impact says what the tool can't do without the scopes, for tools that work without them otherwise.
+	Impact string `json:"impact,omitempty"`
+}
+
+// CheckToolScopes returns the active tools that can't be used with the granted scopes, and those that can but
+// not for everything, sorted by name.
+func CheckToolScopes(tsg *toolsets.ToolsetGroup, granted []string) (unavailable []ToolScopeIssue, limited []ToolScopeIssue) {
+	unavailable, limited = []ToolScopeIssue{}, []ToolScopeIssue{}
+	for name, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetActiveTools() {
+			if missing := MissingScopes(granted, RequiredScopes(name, tool.Tool.Name)); len(missing) > 0 {
+				unavailable = append(unavailable, ToolScopeIssue{Tool: tool.Tool.Name, Toolset: name, MissingScopes: missing})
+				continue
+			}
+			for scope, impact := range limitingScopes[tool.Tool.Name] {
+				if !HasScope(granted, scope) {
+					limited = append(limited, ToolScopeIssue{Tool: tool.Tool.Name, Toolset: name, MissingScopes: []string{scope}, Impact: impact})
+				}
+			}
+		}
+	}
+	sort.Slice(unavailable, func(i, j int) bool { return unavailable[i].Tool < unavailable[j].Tool })
+	sort.Slice(limited, func(i, j int) bool { return limited[i].Tool < limited[j].Tool })
+	return unavailable, limited
+}
@@ -0,0 +1,45 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TokenScopes(t *testing.T) {
+	header := http.Header{}
+	_, ok := TokenScopes(header)
+	assert.False(t, ok)
+
+	header.Set(OAuthScopesHeader, "")
+	scopes, ok := TokenScopes(header)
+	assert.True(t, ok)
+	assert.Empty(t, scopes)
+
+	header.Set(OAuthScopesHeader, "repo, read:org,workflow")
+	scopes, ok = TokenScopes(header)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"repo", "read:org", "workflow"}, scopes)
+}
+
+func Test_MissingScopes(t *testing.T) {
+	granted := []string{"repo", "admin:org", "read:user"}
+
+	assert.True(t, HasScope(granted, "repo"))
+	assert.True(t, HasScope(granted, "public_repo"))
+	assert.True(t, HasScope(granted, "read:org"))
+	assert.False(t, HasScope(granted, "user"))
+	assert.False(t, HasScope(granted, "workflow"))
+
+	assert.Equal(t, []string{"workflow", "delete_repo"}, MissingScopes(granted, []string{"repo", "workflow", "read:org", "delete_repo"}))
+	assert.Empty(t, MissingScopes(granted, nil))
+}
+
+func Test_RequiredScopes(t *testing.T) {
+	assert.Equal(t, []string{"repo"}, RequiredScopes("repos", "list_branches"))
+	assert.Equal(t, []string{"gist"}, RequiredScopes("gists", "create_gist"))
+	assert.Empty(t, RequiredScopes("gists", "list_gists"))
+	assert.Empty(t, RequiredScopes("orgs", "search_orgs"))
+	assert.Empty(t, RequiredScopes("users", "search_users"))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -22,6 +23,7 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				Title:        t("TOOL_SEARCH_REPOSITORIES_USER_TITLE", "Search repositories"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[MinimalSearchRepositoriesResult](),
 			mcp.WithString("query",
 				mcp.Required(),
 				mcp.Description("Repository search query. Examples: 'machine learning in:name stars:>1000 language:python', 'topic:react', 'user:facebook'. Supports advanced search syntax for precise filtering."),
@@ -31,12 +33,15 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.DefaultBool(true),
 			),
 			WithPagination(),
+			WithSearchAggregation(),
+			WithFlatten(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			query, err := RequiredParam[string](request, "query")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			query = normalizeDateQualifiers(query, time.Now())
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -45,6 +50,10 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			maxResults, err := OptionalMaxResultsParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			opts := &github.SearchOptions{
 				ListOptions: github.ListOptions{
 					Page:    pagination.Page,
@@ -56,6 +65,38 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+
+			if maxResults > 0 {
+				aggregate, resp, err := aggregateSearch(ctx, query, "created", maxResults, maxAggregatedSearchRequests, false,
+					func(ctx context.Context, query string, page, perPage int) (searchPage[*github.Repository], *github.Response, error) {
+						result, resp, err := client.Search.Repositories(ctx, query, &github.SearchOptions{
+							ListOptions: github.ListOptions{Page: page, PerPage: perPage},
+						})
+						if err != nil {
+							return searchPage[*github.Repository]{}, resp, err
+						}
+						return searchPage[*github.Repository]{Items: result.Repositories, Total: result.GetTotal(), Incomplete: result.GetIncompleteResults()}, resp, nil
+					},
+					func(repo *github.Repository) string { return repo.GetHTMLURL() },
+				)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to search repositories with query '%s'", query),
+						resp,
+						err,
+					), nil
+				}
+				if !minimalOutput {
+					return MarshalledTextResult(aggregate), nil
+				}
+				return MarshalledTextResult(&MinimalSearchRepositoriesResult{
+					TotalCount:        aggregate.TotalCount,
+					IncompleteResults: aggregate.IncompleteResults,
+					IncompleteReason:  aggregate.IncompleteReason,
+					Items:             convertToMinimalRepositories(aggregate.Items),
+				}), nil
+			}
+
 			result, resp, err := client.Search.Repositories(ctx, query, opts)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -77,41 +118,10 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 			// Return either minimal or full response based on parameter
 			var r []byte
 			if minimalOutput {
-				minimalRepos := make([]MinimalRepository, 0, len(result.Repositories))
-				for _, repo := range result.Repositories {
-					minimalRepo := MinimalRepository{
-						ID:            repo.GetID(),
-						Name:          repo.GetName(),
-						FullName:      repo.GetFullName(),
-						Description:   repo.GetDescription(),
-						HTMLURL:       repo.GetHTMLURL(),
-						Language:      repo.GetLanguage(),
-						Stars:         repo.GetStargazersCount(),
-						Forks:         repo.GetForksCount(),
-						OpenIssues:    repo.GetOpenIssuesCount(),
-						Private:       repo.GetPrivate(),
-						Fork:          repo.GetFork(),
-						Archived:      repo.GetArchived(),
-						DefaultBranch: repo.GetDefaultBranch(),
-					}
-
-					if repo.UpdatedAt != nil {
-						minimalRepo.UpdatedAt = repo.UpdatedAt.Format("2006-01-02T15:04:05Z")
-					}
-					if repo.CreatedAt != nil {
-						minimalRepo.CreatedAt = repo.CreatedAt.Format("2006-01-02T15:04:05Z")
-					}
-					if repo.Topics != nil {
-						minimalRepo.Topics = repo.Topics
-					}
-
-					minimalRepos = append(minimalRepos, minimalRepo)
-				}
-
 				minimalResult := &MinimalSearchRepositoriesResult{
 					TotalCount:        result.GetTotal(),
 					IncompleteResults: result.GetIncompleteResults(),
-					Items:             minimalRepos,
+					Items:             convertToMinimalRepositories(result.Repositories),
 				}
 
 				r, err = json.Marshal(minimalResult)
@@ -129,6 +139,41 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 		}
 }
 
+// convertToMinimalRepositories trims repository search results down to their MinimalRepository fields.
+func convertToMinimalRepositories(repos []*github.Repository) []MinimalRepository {
+	minimalRepos := make([]MinimalRepository, 0, len(repos))
+	for _, repo := range repos {
+		minimalRepo := MinimalRepository{
+			ID:            repo.GetID(),
+			Name:          repo.GetName(),
+			FullName:      repo.GetFullName(),
+			Description:   repo.GetDescription(),
+			HTMLURL:       repo.GetHTMLURL(),
+			Language:      repo.GetLanguage(),
+			Stars:         repo.GetStargazersCount(),
+			Forks:         repo.GetForksCount(),
+			OpenIssues:    repo.GetOpenIssuesCount(),
+			Private:       repo.GetPrivate(),
+			Fork:          repo.GetFork(),
+			Archived:      repo.GetArchived(),
+			DefaultBranch: repo.GetDefaultBranch(),
+		}
+
+		if repo.UpdatedAt != nil {
+			minimalRepo.UpdatedAt = repo.UpdatedAt.Format("2006-01-02T15:04:05Z")
+		}
+		if repo.CreatedAt != nil {
+			minimalRepo.CreatedAt = repo.CreatedAt.Format("2006-01-02T15:04:05Z")
+		}
+		if repo.Topics != nil {
+			minimalRepo.Topics = repo.Topics
+		}
+
+		minimalRepos = append(minimalRepos, minimalRepo)
+	}
+	return minimalRepos
+}
+
 // SearchCode creates a tool to search for code across GitHub repositories.
 func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_code",
@@ -137,6 +182,7 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				Title:        t("TOOL_SEARCH_CODE_USER_TITLE", "Search code"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.CodeSearchResult](),
 			mcp.WithString("query",
 				mcp.Required(),
 				mcp.Description("Search query using GitHub's powerful code search syntax. Examples: 'content:Skill language:Java org:github', 'NOT is:archived language:Python OR language:go', 'repo:github/github-mcp-server'. Supports exact matching, language filters, path filters, and more."),
@@ -149,6 +195,7 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithSearchAggregation(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			query, err := RequiredParam[string](request, "query")
@@ -168,6 +215,11 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			maxResults, err := OptionalMaxResultsParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			opts := &github.SearchOptions{
 				Sort:  sort,
 				Order: order,
@@ -182,6 +234,32 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			if maxResults > 0 {
+				// Code search has no date qualifiers to split queries by
+				aggregate, resp, err := aggregateSearch(ctx, query, "", maxResults, maxAggregatedCodeSearchRequests, false,
+					func(ctx context.Context, query string, page, perPage int) (searchPage[*github.CodeResult], *github.Response, error) {
+						result, resp, err := client.Search.Code(ctx, query, &github.SearchOptions{
+							Sort:        sort,
+							Order:       order,
+							ListOptions: github.ListOptions{Page: page, PerPage: perPage},
+						})
+						if err != nil {
+							return searchPage[*github.CodeResult]{}, resp, err
+						}
+						return searchPage[*github.CodeResult]{Items: result.CodeResults, Total: result.GetTotal(), Incomplete: result.GetIncompleteResults()}, resp, nil
+					},
+					func(code *github.CodeResult) string { return code.GetHTMLURL() },
+				)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to search code with query '%s'", query),
+						resp,
+						err,
+					), nil
+				}
+				return MarshalledTextResult(aggregate), nil
+			}
+
 			result, resp, err := client.Search.Code(ctx, query, opts)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -215,6 +293,7 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		query = normalizeDateQualifiers(query, time.Now())
 		sort, err := OptionalParam[string](request, "sort")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
@@ -227,6 +306,10 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
+		maxResults, err := OptionalMaxResultsParam(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
 		opts := &github.SearchOptions{
 			Sort:  sort,
@@ -246,6 +329,37 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 		if !hasTypeFilter(query) {
 			searchQuery = "type:" + accountType + " " + query
 		}
+
+		if maxResults > 0 {
+			aggregate, resp, err := aggregateSearch(ctx, searchQuery, "created", maxResults, maxAggregatedSearchRequests, order == "asc" && sort == "joined",
+				func(ctx context.Context, query string, page, perPage int) (searchPage[*github.User], *github.Response, error) {
+					result, resp, err := client.Search.Users(ctx, query, &github.SearchOptions{
+						Sort:        sort,
+						Order:       order,
+						ListOptions: github.ListOptions{Page: page, PerPage: perPage},
+					})
+					if err != nil {
+						return searchPage[*github.User]{}, resp, err
+					}
+					return searchPage[*github.User]{Items: result.Users, Total: result.GetTotal(), Incomplete: result.GetIncompleteResults()}, resp, nil
+				},
+				func(user *github.User) string { return user.GetLogin() },
+			)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to search %ss with query '%s'", accountType, query),
+					resp,
+					err,
+				), nil
+			}
+			return MarshalledTextResult(&MinimalSearchUsersResult{
+				TotalCount:        aggregate.TotalCount,
+				IncompleteResults: aggregate.IncompleteResults,
+				IncompleteReason:  aggregate.IncompleteReason,
+				Items:             convertToMinimalUsers(aggregate.Items),
+			}), nil
+		}
+
 		result, resp, err := client.Search.Users(ctx, searchQuery, opts)
 		if err != nil {
 			return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -264,23 +378,10 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 			return mcp.NewToolResultError(fmt.Sprintf("failed to search %ss: %s", accountType, string(body))), nil
 		}
 
-		minimalUsers := make([]MinimalUser, 0, len(result.Users))
-
-		for _, user := range result.Users {
-			if user.Login != nil {
-				mu := MinimalUser{
-					Login:      user.GetLogin(),
-					ID:         user.GetID(),
-					ProfileURL: user.GetHTMLURL(),
-					AvatarURL:  user.GetAvatarURL(),
-				}
-				minimalUsers = append(minimalUsers, mu)
-			}
-		}
 		minimalResp := &MinimalSearchUsersResult{
 			TotalCount:        result.GetTotal(),
 			IncompleteResults: result.GetIncompleteResults(),
-			Items:             minimalUsers,
+			Items:             convertToMinimalUsers(result.Users),
 		}
 		if result.Total != nil {
 			minimalResp.TotalCount = *result.Total
@@ -297,6 +398,23 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 	}
 }
 
+// convertToMinimalUsers trims user search results down to their MinimalUser fields.
+func convertToMinimalUsers(users []*github.User) []MinimalUser {
+	minimalUsers := make([]MinimalUser, 0, len(users))
+	for _, user := range users {
+		if user.Login != nil {
+			mu := MinimalUser{
+				Login:      user.GetLogin(),
+				ID:         user.GetID(),
+				ProfileURL: user.GetHTMLURL(),
+				AvatarURL:  user.GetAvatarURL(),
+			}
+			minimalUsers = append(minimalUsers, mu)
+		}
+	}
+	return minimalUsers
+}
+
 // SearchUsers creates a tool to search for GitHub users.
 func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_users",
@@ -305,6 +423,7 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			Title:        t("TOOL_SEARCH_USERS_USER_TITLE", "Search users"),
 			ReadOnlyHint: ToBoolPtr(true),
 		}),
+		WithJSONOutputSchema[MinimalSearchUsersResult](),
 		mcp.WithString("query",
 			mcp.Required(),
 			mcp.Description("User search query. Examples: 'john smith', 'location:seattle', 'followers:>100'. Search is automatically scoped to type:user."),
@@ -318,6 +437,7 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.Enum("asc", "desc"),
 		),
 		WithPagination(),
+		WithSearchAggregation(),
 	), userOrOrgHandler("user", getClient)
 }
 
@@ -330,6 +450,7 @@ func SearchOrgs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			Title:        t("TOOL_SEARCH_ORGS_USER_TITLE", "Search organizations"),
 			ReadOnlyHint: ToBoolPtr(true),
 		}),
+		WithJSONOutputSchema[MinimalSearchUsersResult](),
 		mcp.WithString("query",
 			mcp.Required(),
 			mcp.Description("Organization search query. Examples: 'microsoft', 'location:california', 'created:>=2025-01-01'. Search is automatically scoped to type:org."),
@@ -343,5 +464,6 @@ func SearchOrgs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			mcp.Enum("asc", "desc"),
 		),
 		WithPagination(),
+		WithSearchAggregation(),
 	), userOrOrgHandler("org", getClient)
 }
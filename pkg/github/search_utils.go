@@ -3,10 +3,12 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
+	"time"
 
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -46,6 +48,7 @@ func searchHandler(
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	query = normalizeDateQualifiers(query, time.Now())
 
 	if !hasSpecificFilter(query, "is", searchType) {
 		query = fmt.Sprintf("is:%s %s", searchType, query)
@@ -88,10 +91,37 @@ func searchHandler(
 		},
 	}
 
+	maxResults, err := OptionalMaxResultsParam(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	client, err := getClient(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get GitHub client: %w", errorPrefix, err)
 	}
+
+	if maxResults > 0 {
+		aggregate, _, err := aggregateSearch(ctx, query, "created", maxResults, maxAggregatedSearchRequests, order == "asc",
+			func(ctx context.Context, query string, page, perPage int) (searchPage[*github.Issue], *github.Response, error) {
+				result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{
+					Sort:        sort,
+					Order:       order,
+					ListOptions: github.ListOptions{Page: page, PerPage: perPage},
+				})
+				if err != nil {
+					return searchPage[*github.Issue]{}, resp, err
+				}
+				return searchPage[*github.Issue]{Items: result.Issues, Total: result.GetTotal(), Incomplete: result.GetIncompleteResults()}, resp, nil
+			},
+			func(issue *github.Issue) string { return issue.GetHTMLURL() },
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errorPrefix, err)
+		}
+		return MarshalledTextResult(aggregate), nil
+	}
+
 	result, resp, err := client.Search.Issues(ctx, query, opts)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
@@ -113,3 +143,203 @@ func searchHandler(
 
 	return mcp.NewToolResultText(string(r)), nil
 }
+
+const (
+	// searchResultWindow is the number of results GitHub returns at most for a single search query.
+	searchResultWindow = 1000
+	// maxAggregatedSearchResults bounds the results collected across pages and date ranges for max_results.
+	maxAggregatedSearchResults = 5000
+	// maxAggregatedSearchRequests bounds the requests made for a single aggregated search, as the search API
+	// only allows 30 requests a minute.
+	maxAggregatedSearchRequests = 30
+	// maxAggregatedCodeSearchRequests bounds the requests made for a single aggregated code search, as code
+	// search only allows 10 requests a minute.
+	maxAggregatedCodeSearchRequests = 10
+	// aggregatedSearchPerPage is the page size used when aggregating search results.
+	aggregatedSearchPerPage = 100
+
+	searchDateFormat = "2006-01-02T15:04:05Z"
+)
+
+// searchEpoch precedes the creation of anything that can be searched for on GitHub.
+var searchEpoch = time.Date(2007, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+// WithSearchAggregation adds the max_results parameter, which collects results across pages instead of returning one.
+func WithSearchAggregation() mcp.ToolOption {
+	return mcp.WithNumber("max_results",
+		mcp.Description(fmt.Sprintf("Collect up to this many results across pages instead of returning a single page, up to %d. Queries matching more than GitHub's limit of %d results are split by creation date ranges, newest first unless order is asc. When set, page and perPage are ignored.", maxAggregatedSearchResults, searchResultWindow)),
+		mcp.Min(1),
+		mcp.Max(maxAggregatedSearchResults),
+	)
+}
+
+// OptionalMaxResultsParam returns the max_results parameter of a search, which is 0 when results aren't aggregated.
+func OptionalMaxResultsParam(r mcp.CallToolRequest) (int, error) {
+	maxResults, err := OptionalIntParam(r, "max_results")
+	if err != nil {
+		return 0, err
+	}
+	if maxResults < 0 || maxResults > maxAggregatedSearchResults {
+		return 0, fmt.Errorf("max_results must be between 1 and %d", maxAggregatedSearchResults)
+	}
+	return maxResults, nil
+}
+
+// SearchAggregate is the result of a search aggregated across pages and date ranges.
+type SearchAggregate[T any] struct {
+	TotalCount        int    `json:"total_count"`
+	IncompleteResults bool   `json:"incomplete_results"`
+	IncompleteReason  string `json:"incomplete_reason,omitempty"`
+	Items             []T    `json:"items"`
+}
+
+// searchPage is a page of search results.
+type searchPage[T any] struct {
+	Items      []T
+	Total      int
+	Incomplete bool
+}
+
+// searchPageFunc fetches a page of results of a search query.
+type searchPageFunc[T any] func(ctx context.Context, query string, page, perPage int) (searchPage[T], *github.Response, error)
+
+var (
+	errSearchBudgetExhausted = errors.New("search request budget exhausted")
+	errSearchRateLimited     = errors.New("search rate limit reached")
+)
+
+type searchAggregator[T any] struct {
+	search      searchPageFunc[T]
+	maxRequests int
+	requests    int
+	// rateLimitReset is when the search rate limit resets, once a response said no requests remain.
+	rateLimitReset *time.Time
+}
+
+func (a *searchAggregator[T]) page(ctx context.Context, query string, page int) (searchPage[T], *github.Response, error) {
+	// Stop rather than wait for the rate limit to reset, which can take up to a minute per request
+	if a.rateLimitReset != nil {
+		return searchPage[T]{}, nil, errSearchRateLimited
+	}
+	if a.requests >= a.maxRequests {
+		return searchPage[T]{}, nil, errSearchBudgetExhausted
+	}
+	a.requests++
+	result, resp, err := a.search(ctx, query, page, aggregatedSearchPerPage)
+	if err == nil && resp != nil && resp.Rate.Limit > 0 && resp.Rate.Remaining == 0 {
+		a.rateLimitReset = &resp.Rate.Reset.Time
+	}
+	return result, resp, err
+}
+
+type searchDateRange struct {
+	from, to time.Time
+}
+
+// aggregateSearch collects up to maxResults results of query, deduplicated by key, in at most maxRequests requests.
+// When the query matches more results than GitHub returns for a single query and it isn't already filtered by
+// dateQualifier, it's split into ranges of dateQualifier, such as "created", that each match few enough results.
+func aggregateSearch[T any](ctx context.Context, query, dateQualifier string, maxResults, maxRequests int, oldestFirst bool, search searchPageFunc[T], key func(T) string) (*SearchAggregate[T], *github.Response, error) {
+	a := &searchAggregator[T]{search: search, maxRequests: maxRequests}
+	first, resp, err := a.page(ctx, query, 1)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	result := &SearchAggregate[T]{TotalCount: first.Total, Items: []T{}}
+	seen := map[string]bool{}
+	githubIncomplete := first.Incomplete
+	budgetExhausted := false
+	rateLimited := false
+	windowExceeded := false
+
+	// collect adds the results of a query matching at most searchResultWindow results, starting from its first page
+	collect := func(query string, page searchPage[T]) error {
+		for pageNum := 1; ; pageNum++ {
+			if pageNum > 1 {
+				var err error
+				if page, resp, err = a.page(ctx, query, pageNum); err != nil {
+					return err
+				}
+			}
+			githubIncomplete = githubIncomplete || page.Incomplete
+			collected := len(result.Items)
+			for _, item := range page.Items {
+				if len(result.Items) >= maxResults {
+					break
+				}
+				if k := key(item); !seen[k] {
+					seen[k] = true
+					result.Items = append(result.Items, item)
+				}
+			}
+			if added := result.Items[collected:]; len(added) > 0 {
+				NotifyPartialResult(ctx, float64(len(result.Items)), float64(min(maxResults, result.TotalCount)),
+					fmt.Sprintf("Collected %d results", len(result.Items)), added)
+			}
+			if len(result.Items) >= maxResults || len(page.Items) < aggregatedSearchPerPage || pageNum*aggregatedSearchPerPage >= searchResultWindow {
+				return nil
+			}
+		}
+	}
+
+	if first.Total <= searchResultWindow || maxResults <= searchResultWindow || dateQualifier == "" || hasFilter(query, dateQualifier) {
+		windowExceeded = first.Total > searchResultWindow && maxResults > searchResultWindow
+		err = collect(query, first)
+	} else {
+		// Ranges are split lazily, so that only the ones needed to reach maxResults are searched
+		stack := []searchDateRange{{from: searchEpoch, to: time.Now().UTC().Truncate(time.Second)}}
+		for len(stack) > 0 && len(result.Items) < maxResults && err == nil {
+			r := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			rangeQuery := fmt.Sprintf("%s %s:%s..%s", query, dateQualifier, r.from.Format(searchDateFormat), r.to.Format(searchDateFormat))
+			var page searchPage[T]
+			if page, resp, err = a.page(ctx, rangeQuery, 1); err != nil {
+				break
+			}
+			if page.Total > searchResultWindow {
+				if r.to.Sub(r.from) >= 2*time.Second {
+					mid := r.from.Add(r.to.Sub(r.from) / 2).Truncate(time.Second)
+					older := searchDateRange{from: r.from, to: mid}
+					newer := searchDateRange{from: mid.Add(time.Second), to: r.to}
+					if oldestFirst {
+						stack = append(stack, newer, older)
+					} else {
+						stack = append(stack, older, newer)
+					}
+					continue
+				}
+				windowExceeded = true
+			}
+			err = collect(rangeQuery, page)
+		}
+	}
+	if errors.Is(err, errSearchBudgetExhausted) {
+		budgetExhausted = true
+	} else if errors.Is(err, errSearchRateLimited) {
+		rateLimited = true
+	} else if err != nil {
+		return nil, resp, err
+	}
+
+	result.IncompleteResults = githubIncomplete || len(result.Items) < result.TotalCount
+	if !result.IncompleteResults {
+		return result, resp, nil
+	}
+	switch {
+	case rateLimited:
+		result.IncompleteReason = fmt.Sprintf("stopped as the search rate limit was reached, it resets at %s", a.rateLimitReset.UTC().Format(searchDateFormat))
+	case budgetExhausted:
+		result.IncompleteReason = fmt.Sprintf("stopped after %d search requests, narrow the query to see the remaining results", maxRequests)
+	case len(result.Items) >= maxResults:
+		result.IncompleteReason = fmt.Sprintf("stopped at max_results, %d of %d results were collected", len(result.Items), result.TotalCount)
+	case windowExceeded:
+		result.IncompleteReason = fmt.Sprintf("GitHub returns at most %d results per query, narrow the query to see the remaining results", searchResultWindow)
+	case githubIncomplete:
+		result.IncompleteReason = "GitHub timed out before finding all matching results"
+	default:
+		result.IncompleteReason = "fewer results were returned than GitHub counted, as results changed during the search"
+	}
+	return result, resp, nil
+}
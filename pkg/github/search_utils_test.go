@@ -1,9 +1,20 @@
 package github
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_hasFilter(t *testing.T) {
@@ -350,3 +361,183 @@ func Test_hasTypeFilter(t *testing.T) {
 		})
 	}
 }
+
+// fakeSearch searches items created at the given times, sorted newest first, honoring created:from..to ranges.
+func fakeSearch(created []time.Time, requests *int) searchPageFunc[time.Time] {
+	rangePattern := regexp.MustCompile(`created:(\S+)\.\.(\S+)`)
+	return func(_ context.Context, query string, page, perPage int) (searchPage[time.Time], *github.Response, error) {
+		*requests++
+		var matches []time.Time
+		from, to := time.Time{}, time.Now().Add(time.Hour)
+		if m := rangePattern.FindStringSubmatch(query); m != nil {
+			from, _ = time.Parse(searchDateFormat, m[1])
+			to, _ = time.Parse(searchDateFormat, m[2])
+		}
+		for _, c := range created {
+			if !c.Before(from) && !c.After(to) {
+				matches = append(matches, c)
+			}
+		}
+		sort.Slice(matches, func(i, j int) bool { return matches[i].After(matches[j]) })
+
+		// Like GitHub, only the first searchResultWindow results can be paged through
+		start := (page - 1) * perPage
+		end := min(start+perPage, len(matches), searchResultWindow)
+		if start >= end {
+			return searchPage[time.Time]{Total: len(matches)}, &github.Response{}, nil
+		}
+		return searchPage[time.Time]{Items: matches[start:end], Total: len(matches)}, &github.Response{}, nil
+	}
+}
+
+func Test_aggregateSearch(t *testing.T) {
+	start := time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC)
+	created := make([]time.Time, 1500)
+	for i := range created {
+		created[i] = start.Add(time.Duration(i) * 24 * time.Hour)
+	}
+	key := func(c time.Time) string { return c.Format(time.RFC3339) }
+	reset := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		query         string
+		dateQualifier string
+		maxResults    int
+		maxRequests   int
+		// rateLimitedAfter is the request after which no search requests remain, or 0 when they never run out.
+		rateLimitedAfter int
+		expectedItems    int
+		expectedReason   string
+	}{
+		{
+			name:           "stops at max_results",
+			query:          "is:issue",
+			dateQualifier:  "created",
+			maxResults:     150,
+			expectedItems:  150,
+			expectedReason: "stopped at max_results, 150 of 1500 results were collected",
+		},
+		{
+			name:          "splits queries over the result window by date",
+			query:         "is:issue",
+			dateQualifier: "created",
+			maxResults:    5000,
+			expectedItems: 1500,
+		},
+		{
+			name:           "can't split queries without a date qualifier",
+			query:          "is:issue",
+			maxResults:     5000,
+			expectedItems:  1000,
+			expectedReason: "GitHub returns at most 1000 results per query, narrow the query to see the remaining results",
+		},
+		{
+			name:           "doesn't split queries already filtered by date",
+			query:          "is:issue created:>2015-01-01",
+			dateQualifier:  "created",
+			maxResults:     5000,
+			expectedItems:  1000,
+			expectedReason: "GitHub returns at most 1000 results per query, narrow the query to see the remaining results",
+		},
+		{
+			name:           "stops when the request budget is exhausted",
+			query:          "is:issue",
+			maxResults:     5000,
+			maxRequests:    5,
+			expectedItems:  500,
+			expectedReason: "stopped after 5 search requests, narrow the query to see the remaining results",
+		},
+		{
+			name:             "stops when the rate limit is reached",
+			query:            "is:issue",
+			maxResults:       5000,
+			rateLimitedAfter: 3,
+			expectedItems:    300,
+			expectedReason:   "stopped as the search rate limit was reached, it resets at 2025-06-01T12:00:00Z",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			maxRequests := tc.maxRequests
+			if maxRequests == 0 {
+				maxRequests = maxAggregatedSearchRequests
+			}
+			requests := 0
+			search := fakeSearch(created, &requests)
+			if tc.rateLimitedAfter > 0 {
+				unlimited := search
+				search = func(ctx context.Context, query string, page, perPage int) (searchPage[time.Time], *github.Response, error) {
+					result, resp, err := unlimited(ctx, query, page, perPage)
+					resp.Rate = github.Rate{Limit: 30, Remaining: max(0, tc.rateLimitedAfter-requests), Reset: github.Timestamp{Time: reset}}
+					return result, resp, err
+				}
+			}
+
+			result, _, err := aggregateSearch(context.Background(), tc.query, tc.dateQualifier, tc.maxResults, maxRequests, false, search, key)
+			require.NoError(t, err)
+
+			assert.Equal(t, 1500, result.TotalCount)
+			assert.Len(t, result.Items, tc.expectedItems)
+			assert.Equal(t, tc.expectedReason != "", result.IncompleteResults)
+			assert.Equal(t, tc.expectedReason, result.IncompleteReason)
+			assert.LessOrEqual(t, requests, maxRequests)
+			if tc.rateLimitedAfter > 0 {
+				assert.Equal(t, tc.rateLimitedAfter, requests)
+			}
+
+			// Results are unique and newest first
+			for i := 1; i < len(result.Items); i++ {
+				assert.True(t, result.Items[i].Before(result.Items[i-1]), "results must be unique and newest first")
+			}
+		})
+	}
+}
+
+func Test_SearchIssues_MaxResults(t *testing.T) {
+	issue := func(n int) *github.Issue {
+		return &github.Issue{
+			Number:  github.Ptr(n),
+			HTMLURL: github.Ptr(fmt.Sprintf("https://github.com/owner/repo/issues/%d", n)),
+		}
+	}
+	pages := map[string][]*github.Issue{
+		"1": make([]*github.Issue, 0, 100),
+		// Results moving between pages are only returned once
+		"2": {issue(100), issue(101), issue(102)},
+	}
+	for i := 1; i <= 100; i++ {
+		pages["1"] = append(pages["1"], issue(i))
+	}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetSearchIssues,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&github.IssuesSearchResult{
+					Total:             github.Ptr(103),
+					IncompleteResults: github.Ptr(false),
+					Issues:            pages[r.URL.Query().Get("page")],
+				})
+			}),
+		),
+	)
+	_, handler := SearchIssues(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"query":       "repo:owner/repo is:open",
+		"max_results": float64(500),
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var returned SearchAggregate[*github.Issue]
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, 103, returned.TotalCount)
+	assert.Len(t, returned.Items, 102)
+	assert.True(t, returned.IncompleteResults)
+	assert.Equal(t, "fewer results were returned than GitHub counted, as results changed during the search", returned.IncompleteReason)
+}
@@ -22,6 +22,7 @@ func GetSecretScanningAlert(getClient GetClientFn, t translations.TranslationHel
 				Title:        t("TOOL_GET_SECRET_SCANNING_ALERT_USER_TITLE", "Get secret scanning alert"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.SecretScanningAlert](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("The owner of the repository."),
@@ -89,6 +90,7 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 				Title:        t("TOOL_LIST_SECRET_SCANNING_ALERTS_USER_TITLE", "List secret scanning alerts"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.SecretScanningAlert](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("The owner of the repository."),
@@ -161,3 +163,360 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// secretScanningBypassRequest is a request to push a secret blocked by push protection, which go-github doesn't
+// have a type for.
+type secretScanningBypassRequest struct {
+	Number     int `json:"number"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Requester struct {
+		ActorName string `json:"actor_name"`
+	} `json:"requester"`
+	Data []struct {
+		SecretType   string `json:"secret_type"`
+		BypassReason string `json:"bypass_reason"`
+		Path         string `json:"path"`
+		Branch       string `json:"branch"`
+	} `json:"data"`
+	ResourceIdentifier string `json:"resource_identifier"`
+	Status             string `json:"status"`
+	RequesterComment   string `json:"requester_comment"`
+	ExpiresAt          string `json:"expires_at"`
+	CreatedAt          string `json:"created_at"`
+	Responses          []struct {
+		Reviewer struct {
+			ActorName string `json:"actor_name"`
+		} `json:"reviewer"`
+		Status    string `json:"status"`
+		CreatedAt string `json:"created_at"`
+	} `json:"responses"`
+	HTMLURL string `json:"html_url"`
+}
+
+// BypassRequestSecret is a secret a push protection bypass request is for.
+type BypassRequestSecret struct {
+	SecretType string `json:"secret_type"`
+	// Reason is why the requester says the secret is safe to push: false_positive, used_in_tests or will_fix_later.
+	Reason string `json:"reason,omitempty"`
+	Path   string `json:"path,omitempty"`
+	Branch string `json:"branch,omitempty"`
+}
+
+// BypassRequestResponse is the review of a push protection bypass request.
+type BypassRequestResponse struct {
+	Reviewer  string `json:"reviewer"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// MinimalBypassRequest is the trimmed output type for push protection bypass requests.
+type MinimalBypassRequest struct {
+	Number           int                     `json:"number"`
+	Repository       string                  `json:"repository"`
+	Requester        string                  `json:"requester"`
+	Status           string                  `json:"status"`
+	RequesterComment string                  `json:"requester_comment,omitempty"`
+	CommitSHA        string                  `json:"commit_sha,omitempty"`
+	Secrets          []BypassRequestSecret   `json:"secrets"`
+	Responses        []BypassRequestResponse `json:"responses,omitempty"`
+	CreatedAt        string                  `json:"created_at,omitempty"`
+	ExpiresAt        string                  `json:"expires_at,omitempty"`
+	HTMLURL          string                  `json:"html_url"`
+}
+
+type listBypassRequestsOptions struct {
+	Status     string `url:"request_status,omitempty"`
+	Reviewer   string `url:"reviewer,omitempty"`
+	Requester  string `url:"requester,omitempty"`
+	TimePeriod string `url:"time_period,omitempty"`
+	Page       int    `url:"page,omitempty"`
+	PerPage    int    `url:"per_page,omitempty"`
+}
+
+func convertToMinimalBypassRequest(request secretScanningBypassRequest) MinimalBypassRequest {
+	minimalRequest := MinimalBypassRequest{
+		Number:           request.Number,
+		Repository:       request.Repository.FullName,
+		Requester:        request.Requester.ActorName,
+		Status:           request.Status,
+		RequesterComment: request.RequesterComment,
+		CommitSHA:        request.ResourceIdentifier,
+		Secrets:          make([]BypassRequestSecret, 0, len(request.Data)),
+		CreatedAt:        request.CreatedAt,
+		ExpiresAt:        request.ExpiresAt,
+		HTMLURL:          request.HTMLURL,
+	}
+	for _, data := range request.Data {
+		minimalRequest.Secrets = append(minimalRequest.Secrets, BypassRequestSecret{
+			SecretType: data.SecretType,
+			Reason:     data.BypassReason,
+			Path:       data.Path,
+			Branch:     data.Branch,
+		})
+	}
+	for _, response := range request.Responses {
+		minimalRequest.Responses = append(minimalRequest.Responses, BypassRequestResponse{
+			Reviewer:  response.Reviewer.ActorName,
+			Status:    response.Status,
+			CreatedAt: response.CreatedAt,
+		})
+	}
+	return minimalRequest
+}
+
+// ListSecretScanningBypassRequests creates a tool to list the requests to bypass push protection.
+func ListSecretScanningBypassRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_secret_scanning_bypass_requests",
+			mcp.WithDescription(t("TOOL_LIST_SECRET_SCANNING_BYPASS_REQUESTS_DESCRIPTION", "List the requests to push secrets blocked by push protection in a repository, or in all the repositories of an organization. Requests are made when delegated bypass is enabled.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_SECRET_SCANNING_BYPASS_REQUESTS_USER_TITLE", "List push protection bypass requests"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalBypassRequest](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository, or the organization when repo is not provided."),
+			),
+			mcp.WithString("repo",
+				mcp.Description("The name of the repository. If not provided, lists the requests of all the repositories of the organization."),
+			),
+			mcp.WithString("status",
+				mcp.Description("Filter by status. Defaults to all."),
+				mcp.Enum("open", "approved", "denied", "completed", "cancelled", "expired", "all"),
+			),
+			mcp.WithString("reviewer",
+				mcp.Description("Filter by the login of the user who reviewed the requests."),
+			),
+			mcp.WithString("requester",
+				mcp.Description("Filter by the login of the user who made the requests."),
+			),
+			mcp.WithString("time_period",
+				mcp.Description("Only list requests made in the last hour, day, week or month. Defaults to day."),
+				mcp.Enum("hour", "day", "week", "month"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, err := OptionalParam[string](request, "status")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reviewer, err := OptionalParam[string](request, "reviewer")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			requester, err := OptionalParam[string](request, "requester")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			timePeriod, err := OptionalParam[string](request, "time_period")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			u := fmt.Sprintf("orgs/%s/bypass-requests/secret-scanning", owner)
+			if repo != "" {
+				u = fmt.Sprintf("repos/%s/%s/bypass-requests/secret-scanning", owner, repo)
+			}
+			u, err = addOptions(u, listBypassRequestsOptions{
+				Status:     status,
+				Reviewer:   reviewer,
+				Requester:  requester,
+				TimePeriod: timePeriod,
+				Page:       pagination.Page,
+				PerPage:    pagination.PerPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to add options to request: %w", err)
+			}
+			req, err := client.NewRequest(http.MethodGet, u, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			var bypassRequests []secretScanningBypassRequest
+			resp, err := client.Do(ctx, req, &bypassRequests)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list bypass requests",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalRequests := make([]MinimalBypassRequest, 0, len(bypassRequests))
+			for _, bypassRequest := range bypassRequests {
+				minimalRequests = append(minimalRequests, convertToMinimalBypassRequest(bypassRequest))
+			}
+
+			return MarshalledTextResult(minimalRequests), nil
+		}
+}
+
+// GetSecretScanningBypassRequest creates a tool to get a request to bypass push protection.
+func GetSecretScanningBypassRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"get_secret_scanning_bypass_request",
+			mcp.WithDescription(t("TOOL_GET_SECRET_SCANNING_BYPASS_REQUEST_DESCRIPTION", "Get a request to push secrets blocked by push protection, with the secrets, the reasons given for them and the reviews of the request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_SECRET_SCANNING_BYPASS_REQUEST_USER_TITLE", "Get push protection bypass request"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[MinimalBypassRequest](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("bypass_request_number",
+				mcp.Required(),
+				mcp.Description("The number of the bypass request."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "bypass_request_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/bypass-requests/secret-scanning/%d", owner, repo, number), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			var bypassRequest secretScanningBypassRequest
+			resp, err := client.Do(ctx, req, &bypassRequest)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get bypass request %d", number),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalBypassRequest(bypassRequest)), nil
+		}
+}
+
+// ReviewSecretScanningBypassRequest creates a tool to approve or deny a request to bypass push protection.
+func ReviewSecretScanningBypassRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"review_secret_scanning_bypass_request",
+			mcp.WithDescription(t("TOOL_REVIEW_SECRET_SCANNING_BYPASS_REQUEST_DESCRIPTION", "Approve or deny a request to push secrets blocked by push protection. Once approved, the requester can push the commit with the secrets within the expiry of the request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_SECRET_SCANNING_BYPASS_REQUEST_USER_TITLE", "Review push protection bypass request"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalBypassRequest](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("bypass_request_number",
+				mcp.Required(),
+				mcp.Description("The number of the bypass request."),
+			),
+			mcp.WithString("status",
+				mcp.Required(),
+				mcp.Description("Whether to approve or deny the request."),
+				mcp.Enum("approve", "deny"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("The reason for the review, shown to the requester."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "bypass_request_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, err := RequiredParam[string](request, "status")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status != "approve" && status != "deny" {
+				return mcp.NewToolResultError("status must be approve or deny"), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			req, err := client.NewRequest(http.MethodPatch, fmt.Sprintf("repos/%s/%s/bypass-requests/secret-scanning/%d", owner, repo, number), map[string]string{
+				"status":  status,
+				"message": message,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			var bypassRequest secretScanningBypassRequest
+			resp, err := client.Do(ctx, req, &bypassRequest)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to review bypass request %d", number),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalBypassRequest(bypassRequest)), nil
+		}
+}
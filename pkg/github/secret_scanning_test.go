@@ -247,3 +247,297 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListSecretScanningBypassRequests(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ListSecretScanningBypassRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_secret_scanning_bypass_requests", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "status")
+	assert.Contains(t, tool.InputSchema.Properties, "time_period")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	mockBypassRequests := `[{
+		"id": 1,
+		"number": 42,
+		"repository": {"id": 7, "name": "repo", "full_name": "owner/repo"},
+		"requester": {"actor_id": 12, "actor_name": "monalisa"},
+		"request_type": "secret_scanning",
+		"data": [{"secret_type": "aws_access_key_id", "bypass_reason": "used_in_tests", "path": "testdata/keys.txt", "branch": "refs/heads/fixtures"}],
+		"resource_identifier": "827efc6d56897b048c772eb4087f854f46256132",
+		"status": "open",
+		"requester_comment": "Fake key for the fixtures",
+		"expires_at": "2025-06-08T12:00:00Z",
+		"created_at": "2025-06-01T12:00:00Z",
+		"responses": [],
+		"html_url": "https://github.com/owner/repo/exemptions/42"
+	}]`
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repository requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBypassRequestsSecretScanningByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"request_status": "open",
+						"time_period":    "week",
+						"page":           "1",
+						"per_page":       "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockBypassRequests),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"status":      "open",
+				"time_period": "week",
+			},
+		},
+		{
+			name: "organization requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsBypassRequestsSecretScanningByOrg,
+					expectPath(t, "/orgs/owner/bypass-requests/secret-scanning").andThen(
+						mockResponse(t, http.StatusOK, mockBypassRequests),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+			},
+		},
+		{
+			name: "delegated bypass not enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBypassRequestsSecretScanningByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list bypass requests",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListSecretScanningBypassRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned []MinimalBypassRequest
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, MinimalBypassRequest{
+				Number:           42,
+				Repository:       "owner/repo",
+				Requester:        "monalisa",
+				Status:           "open",
+				RequesterComment: "Fake key for the fixtures",
+				CommitSHA:        "827efc6d56897b048c772eb4087f854f46256132",
+				Secrets: []BypassRequestSecret{
+					{SecretType: "aws_access_key_id", Reason: "used_in_tests", Path: "testdata/keys.txt", Branch: "refs/heads/fixtures"},
+				},
+				CreatedAt: "2025-06-01T12:00:00Z",
+				ExpiresAt: "2025-06-08T12:00:00Z",
+				HTMLURL:   "https://github.com/owner/repo/exemptions/42",
+			}, returned[0])
+		})
+	}
+}
+
+func Test_GetSecretScanningBypassRequest(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetSecretScanningBypassRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_secret_scanning_bypass_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "bypass_request_number"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "reviewed request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBypassRequestsSecretScanningByOwnerByRepoByBypassRequestNumber,
+					expectPath(t, "/repos/owner/repo/bypass-requests/secret-scanning/42").andThen(
+						mockResponse(t, http.StatusOK, `{
+							"number": 42,
+							"repository": {"full_name": "owner/repo"},
+							"requester": {"actor_name": "monalisa"},
+							"data": [{"secret_type": "aws_access_key_id", "bypass_reason": "used_in_tests"}],
+							"status": "approved",
+							"responses": [{"id": 3, "reviewer": {"actor_id": 13, "actor_name": "octocat"}, "status": "approved", "created_at": "2025-06-01T13:00:00Z"}],
+							"html_url": "https://github.com/owner/repo/exemptions/42"
+						}`),
+					),
+				),
+			),
+		},
+		{
+			name: "request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBypassRequestsSecretScanningByOwnerByRepoByBypassRequestNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get bypass request 42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetSecretScanningBypassRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"bypass_request_number": float64(42),
+			})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned MinimalBypassRequest
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, 42, returned.Number)
+			assert.Equal(t, "approved", returned.Status)
+			assert.Equal(t, []BypassRequestResponse{
+				{Reviewer: "octocat", Status: "approved", CreatedAt: "2025-06-01T13:00:00Z"},
+			}, returned.Responses)
+		})
+	}
+}
+
+func Test_ReviewSecretScanningBypassRequest(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewSecretScanningBypassRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "review_secret_scanning_bypass_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "bypass_request_number", "status", "message"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "approve request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposBypassRequestsSecretScanningByOwnerByRepoByBypassRequestNumber,
+					expectRequestBody(t, map[string]any{
+						"status":  "approve",
+						"message": "The key is a fixture",
+					}).andThen(
+						mockResponse(t, http.StatusOK, `{"number": 42, "status": "approved", "html_url": "https://github.com/owner/repo/exemptions/42"}`),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"bypass_request_number": float64(42),
+				"status":                "approve",
+				"message":               "The key is a fixture",
+			},
+		},
+		{
+			name:         "invalid status",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"bypass_request_number": float64(42),
+				"status":                "approved",
+				"message":               "The key is a fixture",
+			},
+			expectError:    true,
+			expectedErrMsg: "status must be approve or deny",
+		},
+		{
+			name: "requester reviewing their own request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposBypassRequestsSecretScanningByOwnerByRepoByBypassRequestNumber,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"bypass_request_number": float64(42),
+				"status":                "deny",
+				"message":               "Rotate the key instead",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to review bypass request 42",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReviewSecretScanningBypassRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned MinimalBypassRequest
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, 42, returned.Number)
+			assert.Equal(t, "approved", returned.Status)
+		})
+	}
+}
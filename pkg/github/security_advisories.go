@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -20,6 +22,7 @@ func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.Translat
 				Title:        t("TOOL_LIST_GLOBAL_SECURITY_ADVISORIES_USER_TITLE", "List global security advisories"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.GlobalSecurityAdvisory](),
 			mcp.WithString("ghsaId",
 				mcp.Description("Filter by GitHub Security Advisory ID (format: GHSA-xxxx-xxxx-xxxx)."),
 			),
@@ -52,13 +55,13 @@ func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.Translat
 				mcp.Description("Filter advisories by affected package or version (e.g. \"package1,package2@1.0.0\")."),
 			),
 			mcp.WithString("published",
-				mcp.Description("Filter by publish date or date range (ISO 8601 date or range)."),
+				mcp.Description("Filter by publish date or date range (ISO 8601 date or range). Relative times such as >=-7d or last monday..today are also accepted."),
 			),
 			mcp.WithString("updated",
-				mcp.Description("Filter by update date or date range (ISO 8601 date or range)."),
+				mcp.Description("Filter by update date or date range (ISO 8601 date or range). Relative times such as >=-7d or last monday..today are also accepted."),
 			),
 			mcp.WithString("modified",
-				mcp.Description("Filter by publish or update date or date range (ISO 8601 date or range)."),
+				mcp.Description("Filter by publish or update date or date range (ISO 8601 date or range). Relative times such as >=-7d or last monday..today are also accepted."),
 			),
 		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
@@ -150,12 +153,15 @@ func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.Translat
 				opts.Affects = &affects
 			}
 			if published != "" {
+				published = normalizeDateFilter(published, time.Now())
 				opts.Published = &published
 			}
 			if updated != "" {
+				updated = normalizeDateFilter(updated, time.Now())
 				opts.Updated = &updated
 			}
 			if modified != "" {
+				modified = normalizeDateFilter(modified, time.Now())
 				opts.Modified = &modified
 			}
 
@@ -189,6 +195,7 @@ func ListRepositorySecurityAdvisories(getClient GetClientFn, t translations.Tran
 				Title:        t("TOOL_LIST_REPOSITORY_SECURITY_ADVISORIES_USER_TITLE", "List repository security advisories"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.SecurityAdvisory](),
 			mcp.WithString("owner",
 				mcp.Required(),
 				mcp.Description("The owner of the repository."),
@@ -278,6 +285,7 @@ func GetGlobalSecurityAdvisory(getClient GetClientFn, t translations.Translation
 				Title:        t("TOOL_GET_GLOBAL_SECURITY_ADVISORY_USER_TITLE", "Get a global security advisory"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[*github.GlobalSecurityAdvisory](),
 			mcp.WithString("ghsaId",
 				mcp.Description("GitHub Security Advisory ID (format: GHSA-xxxx-xxxx-xxxx)."),
 				mcp.Required(),
@@ -323,6 +331,7 @@ func ListOrgRepositorySecurityAdvisories(getClient GetClientFn, t translations.T
 				Title:        t("TOOL_LIST_ORG_REPOSITORY_SECURITY_ADVISORIES_USER_TITLE", "List org repository security advisories"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
+			WithJSONOutputSchema[[]*github.SecurityAdvisory](),
 			mcp.WithString("org",
 				mcp.Required(),
 				mcp.Description("The organization login."),
@@ -395,3 +404,339 @@ func ListOrgRepositorySecurityAdvisories(getClient GetClientFn, t translations.T
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// repositoryAdvisoryEcosystems are the package ecosystems repository security advisories can affect.
+var repositoryAdvisoryEcosystems = []string{"actions", "composer", "erlang", "go", "maven", "npm", "nuget", "other", "pip", "pub", "rubygems", "rust", "swift"}
+
+// repositoryAdvisoryVulnerability is a package affected by a repository security advisory, as the API takes it.
+type repositoryAdvisoryVulnerability struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name,omitempty"`
+	} `json:"package"`
+	VulnerableVersionRange *string `json:"vulnerable_version_range,omitempty"`
+	PatchedVersions        *string `json:"patched_versions,omitempty"`
+}
+
+// repositoryAdvisoryRequest is the body creating or updating a repository security advisory.
+type repositoryAdvisoryRequest struct {
+	Summary          string                            `json:"summary,omitempty"`
+	Description      string                            `json:"description,omitempty"`
+	CVEID            *string                           `json:"cve_id,omitempty"`
+	Vulnerabilities  []repositoryAdvisoryVulnerability `json:"vulnerabilities,omitempty"`
+	CWEIDs           []string                          `json:"cwe_ids,omitempty"`
+	Severity         *string                           `json:"severity,omitempty"`
+	CVSSVectorString *string                           `json:"cvss_vector_string,omitempty"`
+	StartPrivateFork bool                              `json:"start_private_fork,omitempty"`
+	State            string                            `json:"state,omitempty"`
+}
+
+func repositoryAdvisoryVulnerabilitiesFromRequest(request mcp.CallToolRequest) ([]repositoryAdvisoryVulnerability, error) {
+	items, ok := request.GetArguments()["vulnerabilities"].([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("vulnerabilities must be a non-empty array of objects with ecosystem and package")
+	}
+	vulnerabilities := make([]repositoryAdvisoryVulnerability, 0, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("vulnerability %d must be an object", i)
+		}
+		var vulnerability repositoryAdvisoryVulnerability
+		vulnerability.Package.Ecosystem, _ = fields["ecosystem"].(string)
+		vulnerability.Package.Name, _ = fields["package"].(string)
+		if vulnerability.Package.Ecosystem == "" {
+			return nil, fmt.Errorf("vulnerability %d is missing its ecosystem", i)
+		}
+		if versions, ok := fields["vulnerableVersionRange"].(string); ok && versions != "" {
+			vulnerability.VulnerableVersionRange = github.Ptr(versions)
+		}
+		if versions, ok := fields["patchedVersions"].(string); ok && versions != "" {
+			vulnerability.PatchedVersions = github.Ptr(versions)
+		}
+		vulnerabilities = append(vulnerabilities, vulnerability)
+	}
+	return vulnerabilities, nil
+}
+
+// withRepositoryAdvisory adds the parameters naming a repository security advisory.
+func withRepositoryAdvisory() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("The owner of the repository."),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("The name of the repository."),
+		)(tool)
+		mcp.WithString("ghsaId",
+			mcp.Required(),
+			mcp.Description("GitHub Security Advisory ID of the repository advisory (format: GHSA-xxxx-xxxx-xxxx)."),
+		)(tool)
+	}
+}
+
+func repositoryAdvisoryFromRequest(request mcp.CallToolRequest) (string, string, string, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return "", "", "", err
+	}
+	repo, err := RequiredParam[string](request, "repo")
+	if err != nil {
+		return "", "", "", err
+	}
+	ghsaID, err := RequiredParam[string](request, "ghsaId")
+	if err != nil {
+		return "", "", "", err
+	}
+	return owner, repo, ghsaID, nil
+}
+
+func GetRepositorySecurityAdvisory(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_security_advisory",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_SECURITY_ADVISORY_DESCRIPTION", "Get a repository security advisory, including draft advisories and the collaborators, credits and private fork working on them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_SECURITY_ADVISORY_USER_TITLE", "Get a repository security advisory"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[*github.SecurityAdvisory](),
+			withRepositoryAdvisory(),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, ghsaID, err := repositoryAdvisoryFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github has no method to get a single repository advisory
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/security-advisories/%s", owner, repo, ghsaID), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var advisory github.SecurityAdvisory
+			resp, err := client.Do(ctx, req, &advisory)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get repository advisory %s", ghsaID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(advisory), nil
+		}
+}
+
+func CreateRepositorySecurityAdvisory(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository_security_advisory",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_SECURITY_ADVISORY_DESCRIPTION", "Create a draft security advisory for a GitHub repository, to privately discuss and fix a vulnerability before publishing it. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REPOSITORY_SECURITY_ADVISORY_USER_TITLE", "Create a repository security advisory"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[*github.SecurityAdvisory](),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithString("summary",
+				mcp.Required(),
+				mcp.Description("A short summary of the advisory."),
+			),
+			mcp.WithString("description",
+				mcp.Required(),
+				mcp.Description("A detailed description of the vulnerability, in Markdown."),
+			),
+			mcp.WithArray("vulnerabilities",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"ecosystem"},
+						"properties": map[string]interface{}{
+							"ecosystem": map[string]interface{}{
+								"type":        "string",
+								"description": "Package ecosystem",
+								"enum":        repositoryAdvisoryEcosystems,
+							},
+							"package": map[string]interface{}{
+								"type":        "string",
+								"description": "Package name",
+							},
+							"vulnerableVersionRange": map[string]interface{}{
+								"type":        "string",
+								"description": "Range of the affected versions, such as < 1.2.3",
+							},
+							"patchedVersions": map[string]interface{}{
+								"type":        "string",
+								"description": "Versions fixing the vulnerability, such as 1.2.3",
+							},
+						},
+					}),
+				mcp.Description("The packages affected by the vulnerability."),
+			),
+			mcp.WithString("severity",
+				mcp.Description("Severity of the advisory. Can't be given along with cvssVectorString."),
+				mcp.Enum("critical", "high", "medium", "low"),
+			),
+			mcp.WithString("cvssVectorString",
+				mcp.Description("CVSS vector to compute the severity from. Can't be given along with severity."),
+			),
+			mcp.WithArray("cweIds",
+				mcp.Description("Common Weakness Enumeration IDs of the vulnerability (e.g. [\"CWE-79\"])."),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("cveId",
+				mcp.Description("CVE ID already assigned to the vulnerability, if any. One can be requested later with request_security_advisory_cve."),
+			),
+			mcp.WithBoolean("startPrivateFork",
+				mcp.Description("Whether to create a temporary private fork of the repository to collaborate on a fix."),
+			),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body := repositoryAdvisoryRequest{}
+			if body.Summary, err = RequiredParam[string](request, "summary"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if body.Description, err = RequiredParam[string](request, "description"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if body.Vulnerabilities, err = repositoryAdvisoryVulnerabilitiesFromRequest(request); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for name, field := range map[string]**string{
+				"severity":         &body.Severity,
+				"cvssVectorString": &body.CVSSVectorString,
+				"cveId":            &body.CVEID,
+			} {
+				value, err := OptionalParam[string](request, name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				*field = ToStringPtr(value)
+			}
+			if body.Severity != nil && body.CVSSVectorString != nil {
+				return mcp.NewToolResultError("severity and cvssVectorString can't be given together"), nil
+			}
+			if body.CWEIDs, err = OptionalStringArrayParam(request, "cweIds"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if body.StartPrivateFork, err = OptionalParam[bool](request, "startPrivateFork"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github has no method to create a repository advisory
+			req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("repos/%s/%s/security-advisories", owner, repo), body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var advisory github.SecurityAdvisory
+			resp, err := client.Do(ctx, req, &advisory)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create security advisory for %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(advisory), nil
+		}
+}
+
+func PublishRepositorySecurityAdvisory(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("publish_repository_security_advisory",
+			mcp.WithDescription(t("TOOL_PUBLISH_REPOSITORY_SECURITY_ADVISORY_DESCRIPTION", "Publish a draft repository security advisory, disclosing the vulnerability to everyone and alerting users of the affected packages. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PUBLISH_REPOSITORY_SECURITY_ADVISORY_USER_TITLE", "Publish a repository security advisory"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[*github.SecurityAdvisory](),
+			withRepositoryAdvisory(),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, ghsaID, err := repositoryAdvisoryFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github has no method to update a repository advisory
+			req, err := client.NewRequest(http.MethodPatch, fmt.Sprintf("repos/%s/%s/security-advisories/%s", owner, repo, ghsaID), repositoryAdvisoryRequest{State: "published"})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var advisory github.SecurityAdvisory
+			resp, err := client.Do(ctx, req, &advisory)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to publish repository advisory %s", ghsaID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(advisory), nil
+		}
+}
+
+func RequestSecurityAdvisoryCVE(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("request_security_advisory_cve",
+			mcp.WithDescription(t("TOOL_REQUEST_SECURITY_ADVISORY_CVE_DESCRIPTION", "Request a CVE ID from GitHub for a draft repository security advisory. GitHub reviews the request, and the CVE ID is assigned to the advisory once accepted. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REQUEST_SECURITY_ADVISORY_CVE_USER_TITLE", "Request a CVE for a repository security advisory"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withRepositoryAdvisory(),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, ghsaID, err := repositoryAdvisoryFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.SecurityAdvisories.RequestCVE(ctx, owner, repo, ghsaID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to request a CVE for repository advisory %s", ghsaID),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("A CVE was requested for %s, it will be assigned once GitHub accepts the request", ghsaID)), nil
+		}
+}
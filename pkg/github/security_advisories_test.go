@@ -524,3 +524,269 @@ func Test_ListOrgRepositorySecurityAdvisories(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetRepositorySecurityAdvisory(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositorySecurityAdvisory(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_repository_security_advisory", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ghsaId"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposSecurityAdvisoriesByOwnerByRepoByGhsaId,
+			expectPath(t, "/repos/owner/repo/security-advisories/GHSA-1111-1111-1111").andThen(
+				mockResponse(t, http.StatusOK, &github.SecurityAdvisory{
+					GHSAID:  github.Ptr("GHSA-1111-1111-1111"),
+					Summary: github.Ptr("Path traversal in the file server"),
+					State:   github.Ptr("draft"),
+				}),
+			),
+		),
+	))
+	_, handler := GetRepositorySecurityAdvisory(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":  "owner",
+		"repo":   "repo",
+		"ghsaId": "GHSA-1111-1111-1111",
+	}))
+	require.NoError(t, err)
+
+	var advisory github.SecurityAdvisory
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &advisory))
+	assert.Equal(t, "GHSA-1111-1111-1111", advisory.GetGHSAID())
+	assert.Equal(t, "draft", advisory.GetState())
+}
+
+func Test_CreateRepositorySecurityAdvisory(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepositorySecurityAdvisory(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_repository_security_advisory", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "severity")
+	assert.Contains(t, tool.InputSchema.Properties, "cvssVectorString")
+	assert.Contains(t, tool.InputSchema.Properties, "cweIds")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "summary", "description", "vulnerabilities"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "draft advisory is created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposSecurityAdvisoriesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"summary":     "Path traversal in the file server",
+						"description": "Paths with .. escape the served directory.",
+						"vulnerabilities": []any{
+							map[string]any{
+								"package":                  map[string]any{"ecosystem": "go", "name": "github.com/owner/repo"},
+								"vulnerable_version_range": "< 1.2.3",
+								"patched_versions":         "1.2.3",
+							},
+						},
+						"cwe_ids":            []any{"CWE-22"},
+						"severity":           "high",
+						"start_private_fork": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.SecurityAdvisory{
+							GHSAID: github.Ptr("GHSA-1111-1111-1111"),
+							State:  github.Ptr("draft"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"summary":     "Path traversal in the file server",
+				"description": "Paths with .. escape the served directory.",
+				"vulnerabilities": []any{
+					map[string]any{
+						"ecosystem":              "go",
+						"package":                "github.com/owner/repo",
+						"vulnerableVersionRange": "< 1.2.3",
+						"patchedVersions":        "1.2.3",
+					},
+				},
+				"cweIds":           []any{"CWE-22"},
+				"severity":         "high",
+				"startPrivateFork": true,
+			},
+		},
+		{
+			name:         "severity along with a CVSS vector",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"summary":          "Path traversal in the file server",
+				"description":      "Paths with .. escape the served directory.",
+				"vulnerabilities":  []any{map[string]any{"ecosystem": "go"}},
+				"severity":         "high",
+				"cvssVectorString": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N",
+			},
+			expectError:    true,
+			expectedErrMsg: "severity and cvssVectorString can't be given together",
+		},
+		{
+			name:         "vulnerability without ecosystem",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"summary":         "Path traversal in the file server",
+				"description":     "Paths with .. escape the served directory.",
+				"vulnerabilities": []any{map[string]any{"package": "github.com/owner/repo"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "vulnerability 0 is missing its ecosystem",
+		},
+		{
+			name: "no admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposSecurityAdvisoriesByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"summary":         "Path traversal in the file server",
+				"description":     "Paths with .. escape the served directory.",
+				"vulnerabilities": []any{map[string]any{"ecosystem": "go"}},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create security advisory for owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepositorySecurityAdvisory(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var advisory github.SecurityAdvisory
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &advisory))
+			assert.Equal(t, "GHSA-1111-1111-1111", advisory.GetGHSAID())
+		})
+	}
+}
+
+func Test_PublishRepositorySecurityAdvisory(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PublishRepositorySecurityAdvisory(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "publish_repository_security_advisory", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ghsaId"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PatchReposSecurityAdvisoriesByOwnerByRepoByGhsaId,
+			expect(t, expectations{
+				path:        "/repos/owner/repo/security-advisories/GHSA-1111-1111-1111",
+				requestBody: map[string]any{"state": "published"},
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.SecurityAdvisory{
+					GHSAID: github.Ptr("GHSA-1111-1111-1111"),
+					State:  github.Ptr("published"),
+				}),
+			),
+		),
+	))
+	_, handler := PublishRepositorySecurityAdvisory(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":  "owner",
+		"repo":   "repo",
+		"ghsaId": "GHSA-1111-1111-1111",
+	}))
+	require.NoError(t, err)
+
+	var advisory github.SecurityAdvisory
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &advisory))
+	assert.Equal(t, "published", advisory.GetState())
+}
+
+func Test_RequestSecurityAdvisoryCVE(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RequestSecurityAdvisoryCVE(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "request_security_advisory_cve", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ghsaId"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "CVE requested",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposSecurityAdvisoriesCveByOwnerByRepoByGhsaId,
+					mockResponse(t, http.StatusAccepted, "{}"),
+				),
+			),
+		},
+		{
+			name: "CVE already assigned",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposSecurityAdvisoriesCveByOwnerByRepoByGhsaId,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Advisory already has a CVE"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to request a CVE for repository advisory GHSA-1111-1111-1111",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RequestSecurityAdvisoryCVE(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"ghsaId": "GHSA-1111-1111-1111",
+			}))
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			if tc.expectError {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Contains(t, getTextResult(t, result).Text, "A CVE was requested for GHSA-1111-1111-1111")
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Authentication modes reported by get_server_info, derived from the prefix of the token.
+const (
+	AuthModePersonalAccessToken            = "personal_access_token"
+	AuthModeFineGrainedPersonalAccessToken = "fine_grained_personal_access_token"
+	AuthModeOAuth                          = "oauth"
+	AuthModeGitHubAppInstallation          = "github_app_installation"
+	AuthModeGitHubAppUser                  = "github_app_user"
+	AuthModeUnknown                        = "unknown"
+)
+
+// ServerInfo describes the deployment of the server, as reported by get_server_info.
+type ServerInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	Host      string `json:"host"`
+	AuthMode  string `json:"auth_mode"`
+}
+
+// serverInfoResult is the result of get_server_info.
+type serverInfoResult struct {
+	ServerInfo
+	ReadOnly        bool           `json:"read_only"`
+	EnabledToolsets []string       `json:"enabled_toolsets"`
+	ClientFeatures  ClientFeatures `json:"client_features"`
+}
+
+// TokenAuthMode returns how a token authenticates, based on the prefixes GitHub gives to its tokens.
+func TokenAuthMode(token string) string {
+	switch {
+	case strings.HasPrefix(token, "github_pat_"):
+		return AuthModeFineGrainedPersonalAccessToken
+	case strings.HasPrefix(token, "ghp_"):
+		return AuthModePersonalAccessToken
+	case strings.HasPrefix(token, "gho_"):
+		return AuthModeOAuth
+	case strings.HasPrefix(token, "ghs_"):
+		return AuthModeGitHubAppInstallation
+	case strings.HasPrefix(token, "ghu_"):
+		return AuthModeGitHubAppUser
+	default:
+		return AuthModeUnknown
+	}
+}
+
+// GetServerInfo creates a tool describing the server deployment the client is talking to.
+func GetServerInfo(tsg *toolsets.ToolsetGroup, info ServerInfo, readOnly bool, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_server_info",
+			mcp.WithDescription(t("TOOL_GET_SERVER_INFO_DESCRIPTION", "Get the version, build, GitHub host, authentication mode, read-only status and enabled toolsets of this GitHub MCP server. Use this to find out what the server can do, or when reporting an issue with it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_SERVER_INFO_USER_TITLE", "Get server info"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[serverInfoResult](),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enabled := make([]string, 0, len(tsg.Toolsets))
+			for name, toolset := range tsg.Toolsets {
+				if toolset.IsEnabled() {
+					enabled = append(enabled, name)
+				}
+			}
+			sort.Strings(enabled)
+
+			return MarshalledTextResult(serverInfoResult{
+				ServerInfo:      info,
+				ReadOnly:        readOnly,
+				EnabledToolsets: enabled,
+				ClientFeatures:  ClientFeaturesFromContext(ctx),
+			}), nil
+		}
+}
+
+// ToolParameter describes a parameter of a tool, as reported by describe_tools.
+type ToolParameter struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+// ToolDescription describes a tool of the server and whether clients can see it.
+type ToolDescription struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	ReadOnly    bool   `json:"read_only"`
+	Destructive bool   `json:"destructive"`
+	Enabled     bool   `json:"enabled"`
+	// DisabledReason says which server policy hides the tool, when it isn't enabled.
+	DisabledReason string          `json:"disabled_reason,omitempty"`
+	Parameters     []ToolParameter `json:"parameters"`
+}
+
+// ToolsetDescription describes a toolset of the server and its tools.
+type ToolsetDescription struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Enabled     bool              `json:"enabled"`
+	Tools       []ToolDescription `json:"tools"`
+}
+
+// describeToolsResult is the result of describe_tools.
+type describeToolsResult struct {
+	ReadOnly bool                 `json:"read_only"`
+	Toolsets []ToolsetDescription `json:"toolsets"`
+}
+
+// describeParameters lists the parameters of a tool from its input schema, required ones first.
+func describeParameters(tool mcp.Tool) []ToolParameter {
+	required := make(map[string]bool, len(tool.InputSchema.Required))
+	for _, name := range tool.InputSchema.Required {
+		required[name] = true
+	}
+
+	parameters := make([]ToolParameter, 0, len(tool.InputSchema.Properties))
+	for name, property := range tool.InputSchema.Properties {
+		parameter := ToolParameter{Name: name, Required: required[name]}
+		if schema, ok := property.(map[string]any); ok {
+			parameter.Type, _ = schema["type"].(string)
+			parameter.Description, _ = schema["description"].(string)
+			switch enum := schema["enum"].(type) {
+			case []string:
+				parameter.Enum = enum
+			case []any:
+				for _, value := range enum {
+					parameter.Enum = append(parameter.Enum, fmt.Sprint(value))
+				}
+			}
+		}
+		parameters = append(parameters, parameter)
+	}
+	sort.Slice(parameters, func(i, j int) bool {
+		if parameters[i].Required != parameters[j].Required {
+			return parameters[i].Required
+		}
+		return parameters[i].Name < parameters[j].Name
+	})
+	return parameters
+}
+
+// describeTool describes a tool of a toolset, with the reason it is disabled if it is.
+func describeTool(toolset *toolsets.Toolset, tool mcp.Tool) ToolDescription {
+	description := ToolDescription{
+		Name:        tool.Name,
+		Title:       tool.Annotations.Title,
+		ReadOnly:    tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint,
+		Destructive: tool.Annotations.DestructiveHint != nil && *tool.Annotations.DestructiveHint,
+		Parameters:  describeParameters(tool),
+	}
+
+	// Read-only mode is checked first, as enabling the toolset doesn't make its write tools available
+	switch {
+	case !description.ReadOnly && toolset.IsReadOnly():
+		description.DisabledReason = "the server is running in read-only mode, so tools that modify data are disabled"
+	case !toolset.IsEnabled():
+		description.DisabledReason = fmt.Sprintf("the %s toolset is not enabled", toolset.Name)
+	default:
+		description.Enabled = true
+	}
+	return description
+}
+
+// DescribeTools creates a tool describing every toolset and tool of the server, including the ones that are
+// disabled and why, to debug why a tool isn't available.
+func DescribeTools(tsg *toolsets.ToolsetGroup, readOnly bool, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("describe_tools",
+			mcp.WithDescription(t("TOOL_DESCRIBE_TOOLS_DESCRIPTION", "Describe the toolsets and tools of this GitHub MCP server, with the parameters and read-only and destructive annotations of each tool, and whether it is enabled. Tools that are disabled by the server configuration, such as read-only mode or a toolset that isn't enabled, are included with the reason. Use this to find out why a tool isn't available. Filter by toolset or tool to keep the result small.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DESCRIBE_TOOLS_USER_TITLE", "Describe tools"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[describeToolsResult](),
+			mcp.WithString("toolset",
+				mcp.Description("Only describe the tools of this toolset"),
+			),
+			mcp.WithString("tool",
+				mcp.Description("Only describe the tool with this name"),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			toolsetName, err := OptionalParam[string](request, "toolset")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toolName, err := OptionalParam[string](request, "tool")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if toolsetName != "" && tsg.Toolsets[toolsetName] == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("toolset %s not found", toolsetName)), nil
+			}
+
+			names := make([]string, 0, len(tsg.Toolsets))
+			for name := range tsg.Toolsets {
+				if toolsetName == "" || name == toolsetName {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+
+			result := describeToolsResult{ReadOnly: readOnly, Toolsets: []ToolsetDescription{}}
+			for _, name := range names {
+				toolset := tsg.Toolsets[name]
+				description := ToolsetDescription{
+					Name:        toolset.Name,
+					Description: toolset.Description,
+					Enabled:     toolset.IsEnabled(),
+					Tools:       []ToolDescription{},
+				}
+				tools := append(append([]server.ServerTool{}, toolset.GetReadTools()...), toolset.GetWriteTools()...)
+				for _, tool := range tools {
+					if toolName == "" || tool.Tool.Name == toolName {
+						description.Tools = append(description.Tools, describeTool(toolset, tool.Tool))
+					}
+				}
+				sort.Slice(description.Tools, func(i, j int) bool { return description.Tools[i].Name < description.Tools[j].Name })
+				if toolName == "" || len(description.Tools) > 0 {
+					result.Toolsets = append(result.Toolsets, description)
+				}
+			}
+			if toolName != "" && len(result.Toolsets) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("tool %s not found", toolName)), nil
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,195 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetServerInfo(t *testing.T) {
+	tsg := toolsets.NewToolsetGroup(true)
+	tsg.AddToolset(toolsets.NewToolset("repos", "Repositories"))
+	tsg.AddToolset(toolsets.NewToolset("issues", "Issues"))
+	tsg.AddToolset(toolsets.NewToolset("actions", "Actions"))
+	require.NoError(t, tsg.EnableToolsets([]string{"repos", "issues"}))
+
+	info := ServerInfo{
+		Version:   "v1.2.3",
+		Commit:    "abc123",
+		BuildDate: "2025-01-02",
+		Host:      "https://api.github.com/",
+		AuthMode:  AuthModePersonalAccessToken,
+	}
+	tool, handler := GetServerInfo(tsg, info, true, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_server_info", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+
+	var returned serverInfoResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, serverInfoResult{
+		ServerInfo:      info,
+		ReadOnly:        true,
+		EnabledToolsets: []string{"issues", "repos"},
+	}, returned)
+}
+
+func Test_TokenAuthMode(t *testing.T) {
+	tests := map[string]string{
+		"ghp_abc":         AuthModePersonalAccessToken,
+		"github_pat_abc":  AuthModeFineGrainedPersonalAccessToken,
+		"gho_abc":         AuthModeOAuth,
+		"ghs_abc":         AuthModeGitHubAppInstallation,
+		"ghu_abc":         AuthModeGitHubAppUser,
+		"0123456789abcde": AuthModeUnknown,
+	}
+	for token, expected := range tests {
+		assert.Equal(t, expected, TokenAuthMode(token), token)
+	}
+}
+
+func Test_DescribeTools(t *testing.T) {
+	newTool := func(name string, readOnly bool) server.ServerTool {
+		return toolsets.NewServerTool(mcp.NewTool(name,
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           name + " title",
+				ReadOnlyHint:    ToBoolPtr(readOnly),
+				DestructiveHint: ToBoolPtr(!readOnly),
+			}),
+			mcp.WithString("owner", mcp.Required(), mcp.Description("Repository owner")),
+			mcp.WithString("state", mcp.Description("State"), mcp.Enum("open", "closed")),
+		), nil)
+	}
+	tsg := toolsets.NewToolsetGroup(true)
+	tsg.AddToolset(toolsets.NewToolset("repos", "Repositories").
+		AddReadTools(newTool("list_branches", true)).
+		AddWriteTools(newTool("create_branch", false)))
+	tsg.AddToolset(toolsets.NewToolset("pull_requests", "Pull requests").
+		AddReadTools(newTool("list_pull_requests", true)).
+		AddWriteTools(newTool("create_pull_request", false)))
+	require.NoError(t, tsg.EnableToolsets([]string{"repos"}))
+
+	tool, handler := DescribeTools(tsg, true, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "describe_tools", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "toolset")
+	assert.Contains(t, tool.InputSchema.Properties, "tool")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	parameters := []ToolParameter{
+		{Name: "owner", Type: "string", Description: "Repository owner", Required: true},
+		{Name: "state", Type: "string", Description: "State", Enum: []string{"open", "closed"}},
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       describeToolsResult
+	}{
+		{
+			name:        "describes every toolset",
+			requestArgs: map[string]interface{}{},
+			expected: describeToolsResult{
+				ReadOnly: true,
+				Toolsets: []ToolsetDescription{
+					{
+						Name:        "pull_requests",
+						Description: "Pull requests",
+						Tools: []ToolDescription{
+							{Name: "create_pull_request", Title: "create_pull_request title", Destructive: true, DisabledReason: "the server is running in read-only mode, so tools that modify data are disabled", Parameters: parameters},
+							{Name: "list_pull_requests", Title: "list_pull_requests title", ReadOnly: true, DisabledReason: "the pull_requests toolset is not enabled", Parameters: parameters},
+						},
+					},
+					{
+						Name:        "repos",
+						Description: "Repositories",
+						Enabled:     true,
+						Tools: []ToolDescription{
+							{Name: "create_branch", Title: "create_branch title", Destructive: true, DisabledReason: "the server is running in read-only mode, so tools that modify data are disabled", Parameters: parameters},
+							{Name: "list_branches", Title: "list_branches title", ReadOnly: true, Enabled: true, Parameters: parameters},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:        "filters by toolset",
+			requestArgs: map[string]interface{}{"toolset": "repos"},
+			expected: describeToolsResult{
+				ReadOnly: true,
+				Toolsets: []ToolsetDescription{
+					{
+						Name:        "repos",
+						Description: "Repositories",
+						Enabled:     true,
+						Tools: []ToolDescription{
+							{Name: "create_branch", Title: "create_branch title", Destructive: true, DisabledReason: "the server is running in read-only mode, so tools that modify data are disabled", Parameters: parameters},
+							{Name: "list_branches", Title: "list_branches title", ReadOnly: true, Enabled: true, Parameters: parameters},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:        "filters by tool",
+			requestArgs: map[string]interface{}{"tool": "list_pull_requests"},
+			expected: describeToolsResult{
+				ReadOnly: true,
+				Toolsets: []ToolsetDescription{
+					{
+						Name:        "pull_requests",
+						Description: "Pull requests",
+						Tools: []ToolDescription{
+							{Name: "list_pull_requests", Title: "list_pull_requests title", ReadOnly: true, DisabledReason: "the pull_requests toolset is not enabled", Parameters: parameters},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:           "unknown toolset",
+			requestArgs:    map[string]interface{}{"toolset": "wikis"},
+			expectError:    true,
+			expectedErrMsg: "toolset wikis not found",
+		},
+		{
+			name:           "unknown tool",
+			requestArgs:    map[string]interface{}{"tool": "edit_wiki"},
+			expectError:    true,
+			expectedErrMsg: "tool edit_wiki not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned describeToolsResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/github/github-mcp-server/pkg/lfs"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/google/go-github/v74/github"
 	"github.com/shurcooL/githubv4"
@@ -44,6 +45,12 @@ func stubGetRawClientFn(client *raw.Client) raw.GetRawClientFn {
 	}
 }
 
+func stubGetLFSClientFn(client *lfs.Client) lfs.GetLFSClientFn {
+	return func(_ context.Context) (*lfs.Client, error) {
+		return client, nil
+	}
+}
+
 func badRequestHandler(msg string) http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
 		structuredErrorResponse := github.ErrorResponse{
@@ -478,6 +485,65 @@ func TestOptionalStringArrayParam(t *testing.T) {
 	}
 }
 
+func TestOptionalIntArrayParam(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		paramName   string
+		expected    []int
+		expectError bool
+	}{
+		{
+			name:        "parameter not in request",
+			params:      map[string]any{},
+			paramName:   "ids",
+			expected:    []int{},
+			expectError: false,
+		},
+		{
+			name: "valid any array parameter",
+			params: map[string]any{
+				"ids": []any{float64(1), float64(2)},
+			},
+			paramName:   "ids",
+			expected:    []int{1, 2},
+			expectError: false,
+		},
+		{
+			name: "wrong type parameter",
+			params: map[string]any{
+				"ids": 1,
+			},
+			paramName:   "ids",
+			expected:    []int{},
+			expectError: true,
+		},
+		{
+			name: "wrong slice type parameter",
+			params: map[string]any{
+				"ids": []any{float64(1), "2"},
+			},
+			paramName:   "ids",
+			expected:    []int{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.params)
+			result, err := OptionalIntArrayParam(request, tc.paramName)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestOptionalPaginationParams(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -560,3 +626,44 @@ func TestOptionalPaginationParams(t *testing.T) {
 		})
 	}
 }
+
+func TestMutuallyExclusiveParams(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]any
+		expectedErr string
+	}{
+		{
+			name:   "neither parameter",
+			params: map[string]any{},
+		},
+		{
+			name:   "one parameter",
+			params: map[string]any{"ref": "main"},
+		},
+		{
+			name:   "other parameter is empty",
+			params: map[string]any{"ref": "main", "sha": ""},
+		},
+		{
+			name:   "other parameter is null",
+			params: map[string]any{"ref": nil, "sha": "abc123"},
+		},
+		{
+			name:        "both parameters",
+			params:      map[string]any{"ref": "main", "sha": "abc123"},
+			expectedErr: "parameters ref and sha cannot be used together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := MutuallyExclusiveParams(createMCPRequest(tc.params), "ref", "sha")
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
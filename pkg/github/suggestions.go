@@ -0,0 +1,70 @@
+package github
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// suggestion is the change a reviewer suggested for lines of a file in a review comment.
+type suggestion struct {
+	CommentID int64
+	Path      string
+	StartLine int
+	EndLine   int
+	Lines     []string
+}
+
+// parseSuggestion returns the lines of the first suggestion block of the body of a review comment. An empty
+// suggestion block suggests removing the lines the comment is on.
+func parseSuggestion(body string) ([]string, bool) {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		fence := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, "`"))]
+		if len(fence) < 3 || strings.TrimSpace(trimmed[len(fence):]) != "suggestion" {
+			continue
+		}
+
+		suggested := []string{}
+		for _, line := range lines[i+1:] {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, "`") == "" {
+				return suggested, true
+			}
+			suggested = append(suggested, line)
+		}
+		// Unclosed blocks run to the end of the comment, as in rendered Markdown
+		return suggested, true
+	}
+	return nil, false
+}
+
+// applySuggestions replaces the lines of a file with the suggestions made for them, which must not overlap.
+func applySuggestions(content string, suggestions []suggestion) (string, error) {
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+	trailingNewline := strings.HasSuffix(content, newline)
+	lines := strings.Split(strings.TrimSuffix(content, newline), newline)
+
+	// Apply the suggestions from the bottom of the file up, so that line numbers still match
+	sorted := append([]suggestion(nil), suggestions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartLine > sorted[j].StartLine })
+	for i, s := range sorted {
+		if s.StartLine < 1 || s.EndLine < s.StartLine || s.EndLine > len(lines) {
+			return "", fmt.Errorf("suggestion of comment %d is on lines %d-%d, but %s has %d lines", s.CommentID, s.StartLine, s.EndLine, s.Path, len(lines))
+		}
+		if i > 0 && s.EndLine >= sorted[i-1].StartLine {
+			return "", fmt.Errorf("suggestions of comments %d and %d change the same lines of %s", s.CommentID, sorted[i-1].CommentID, s.Path)
+		}
+		lines = append(lines[:s.StartLine-1], append(append([]string(nil), s.Lines...), lines[s.EndLine:]...)...)
+	}
+
+	result := strings.Join(lines, newline)
+	if trailingNewline && len(lines) > 0 {
+		result += newline
+	}
+	return result, nil
+}
@@ -0,0 +1,180 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// FullResultURIPrefix is the prefix of the resource URIs pointing to the full content of summarized results.
+const FullResultURIPrefix = "github-mcp://results/"
+
+// summaryMaxTokens bounds the length of the summaries requested from the client.
+const summaryMaxTokens = 1024
+
+// unsummarizedTools lists the tools whose results must be returned verbatim, as agents act on their exact content.
+var unsummarizedTools = map[string]bool{
+	"get_file_contents": true,
+}
+
+// ResultStore keeps the full content of summarized results so that clients can still read them.
+// Once full, the oldest results are evicted first.
+type ResultStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	results    map[string]string
+	order      []string
+}
+
+// NewResultStore creates a ResultStore holding up to maxEntries results.
+func NewResultStore(maxEntries int) *ResultStore {
+	return &ResultStore{
+		maxEntries: maxEntries,
+		results:    make(map[string]string),
+	}
+}
+
+// Put stores a result and returns its id.
+func (s *ResultStore) Put(content string) string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	id := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.order) >= s.maxEntries && len(s.order) > 0 {
+		delete(s.results, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.results[id] = content
+	s.order = append(s.order, id)
+	return id
+}
+
+// Get returns the result stored under id.
+func (s *ResultStore) Get(id string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, ok := s.results[id]
+	return content, ok
+}
+
+// FullResultResourceTemplate defines the resource template and handler for reading the full content of summarized results.
+func FullResultResourceTemplate(store *ResultStore, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			FullResultURIPrefix+"{id}",
+			t("RESOURCE_FULL_RESULT_DESCRIPTION", "Full content of a summarized tool result"),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		func(_ context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			ids, ok := request.Params.Arguments["id"].([]string)
+			if !ok || len(ids) == 0 {
+				return nil, errors.New("id is required")
+			}
+			content, ok := store.Get(ids[0])
+			if !ok {
+				return nil, fmt.Errorf("result %s not found, it may have expired", ids[0])
+			}
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     content,
+				},
+			}, nil
+		}
+}
+
+// SummarizeLargeResultsMiddleware replaces tool results longer than threshold characters with a summary
+// written by the client's model through MCP sampling, along with a link to the full result in the store.
+// Results are returned unchanged when the client doesn't support sampling or summarizing fails.
+func SummarizeLargeResultsMiddleware(store *ResultStore, threshold int) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if err != nil || result == nil || result.IsError || unsummarizedTools[request.Params.Name] {
+				return result, err
+			}
+
+			text, ok := resultText(result)
+			if !ok || len(text) <= threshold || !clientSupportsSampling(ctx) {
+				return result, nil
+			}
+			s := server.ServerFromContext(ctx)
+			if s == nil {
+				return result, nil
+			}
+
+			NotifyProgress(ctx, 0, 0, fmt.Sprintf("Summarizing %d characters of output", len(text)))
+			summary, err := requestSummary(ctx, s, request.Params.Name, text)
+			if err != nil {
+				return result, nil
+			}
+
+			// Structured content is dropped, as it holds the full result the summary stands in for
+			uri := FullResultURIPrefix + store.Put(text)
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.NewTextContent(fmt.Sprintf("%s\n\nThis is a summary of a %d character result. The full result is available as the resource %s.", summary, len(text), uri)),
+					mcp.NewResourceLink(uri, "Full result of "+request.Params.Name, "The complete, unsummarized result", "text/plain"),
+				},
+			}, nil
+		}
+	}
+}
+
+// resultText joins the text content of a result, reporting false if it carries any other kind of content.
+func resultText(result *mcp.CallToolResult) (string, bool) {
+	var sb strings.Builder
+	for _, c := range result.Content {
+		text, ok := c.(mcp.TextContent)
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(text.Text)
+	}
+	return sb.String(), sb.Len() > 0
+}
+
+func clientSupportsSampling(ctx context.Context) bool {
+	return ClientFeaturesFromContext(ctx).Sampling
+}
+
+func requestSummary(ctx context.Context, s *server.MCPServer, toolName, text string) (string, error) {
+	result, err := s.RequestSampling(ctx, mcp.CreateMessageRequest{
+		CreateMessageParams: mcp.CreateMessageParams{
+			SystemPrompt: "You summarize the output of GitHub tools for an AI agent with a limited context window. Keep identifiers such as numbers, names, SHAs, URLs, file paths, error messages and counts exact. Do not add commentary.",
+			Messages: []mcp.SamplingMessage{
+				{
+					Role:    mcp.RoleUser,
+					Content: mcp.NewTextContent(fmt.Sprintf("Summarize the following output of the %s tool:\n\n%s", toolName, text)),
+				},
+			},
+			MaxTokens: summaryMaxTokens,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Depending on the transport the content is either decoded or left as a generic JSON object
+	switch content := result.Content.(type) {
+	case mcp.TextContent:
+		return content.Text, nil
+	case *mcp.TextContent:
+		return content.Text, nil
+	case map[string]any:
+		if summary, ok := content["text"].(string); ok && summary != "" {
+			return summary, nil
+		}
+	}
+	return "", errors.New("sampling did not return text")
+}
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type samplingHandlerFunc func(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error)
+
+func (f samplingHandlerFunc) CreateMessage(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	return f(ctx, request)
+}
+
+func Test_SummarizeLargeResultsMiddleware(t *testing.T) {
+	longText := strings.Repeat("a", 100)
+	summarize := samplingHandlerFunc(func(_ context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+		return &mcp.CreateMessageResult{
+			SamplingMessage: mcp.SamplingMessage{Role: mcp.RoleAssistant, Content: mcp.NewTextContent("short summary")},
+		}, nil
+	})
+
+	tests := []struct {
+		name            string
+		tool            string
+		toolResult      *mcp.CallToolResult
+		sampling        server.SamplingHandler
+		supportSampling bool
+		expectSummary   bool
+	}{
+		{
+			name:            "large result is summarized",
+			tool:            "list_issues",
+			toolResult:      mcp.NewToolResultText(longText),
+			sampling:        summarize,
+			supportSampling: true,
+			expectSummary:   true,
+		},
+		{
+			name: "structured content of a large result is dropped",
+			tool: "list_issues",
+			toolResult: &mcp.CallToolResult{
+				Content:           []mcp.Content{mcp.NewTextContent(longText)},
+				StructuredContent: map[string]any{"text": longText},
+			},
+			sampling:        summarize,
+			supportSampling: true,
+			expectSummary:   true,
+		},
+		{
+			name:            "small result is returned as is",
+			tool:            "list_issues",
+			toolResult:      mcp.NewToolResultText("tiny"),
+			sampling:        summarize,
+			supportSampling: true,
+		},
+		{
+			name:       "client without sampling gets the full result",
+			tool:       "list_issues",
+			toolResult: mcp.NewToolResultText(longText),
+			sampling:   summarize,
+		},
+		{
+			name:            "errors are never summarized",
+			tool:            "list_issues",
+			toolResult:      mcp.NewToolResultError(longText),
+			sampling:        summarize,
+			supportSampling: true,
+		},
+		{
+			name:            "file contents are never summarized",
+			tool:            "get_file_contents",
+			toolResult:      mcp.NewToolResultText(longText),
+			sampling:        summarize,
+			supportSampling: true,
+		},
+		{
+			name:       "failed sampling falls back to the full result",
+			tool:       "list_issues",
+			toolResult: mcp.NewToolResultText(longText),
+			sampling: samplingHandlerFunc(func(_ context.Context, _ mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+				return nil, errors.New("user declined")
+			}),
+			supportSampling: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewResultStore(10)
+			s := NewServer("test", server.WithToolHandlerMiddleware(SummarizeLargeResultsMiddleware(store, 50)))
+			s.AddTool(mcp.NewTool(tc.tool), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tc.toolResult, nil
+			})
+
+			session := server.NewInProcessSession("test", tc.sampling)
+			if tc.supportSampling {
+				session.SetClientCapabilities(mcp.ClientCapabilities{Sampling: &struct{}{}})
+			}
+			ctx := s.WithContext(context.Background(), session)
+
+			response := s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"`+tc.tool+`"}}`))
+			rpcResponse, ok := response.(mcp.JSONRPCResponse)
+			require.True(t, ok, "expected a successful response, got %#v", response)
+			value, ok := rpcResponse.Result.(mcp.CallToolResult)
+			require.True(t, ok)
+			result := &value
+
+			if !tc.expectSummary {
+				assert.Equal(t, tc.toolResult, result)
+				return
+			}
+
+			require.Len(t, result.Content, 2)
+			assert.Nil(t, result.StructuredContent)
+			text, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Contains(t, text.Text, "short summary")
+			assert.Contains(t, text.Text, FullResultURIPrefix)
+
+			link, ok := result.Content[1].(mcp.ResourceLink)
+			require.True(t, ok)
+			require.True(t, strings.HasPrefix(link.URI, FullResultURIPrefix))
+
+			full, ok := store.Get(strings.TrimPrefix(link.URI, FullResultURIPrefix))
+			require.True(t, ok)
+			assert.Equal(t, longText, full)
+		})
+	}
+}
+
+func Test_ResultStore_EvictsOldest(t *testing.T) {
+	store := NewResultStore(2)
+	first := store.Put("first")
+	second := store.Put("second")
+	third := store.Put("third")
+
+	_, ok := store.Get(first)
+	assert.False(t, ok)
+	content, ok := store.Get(second)
+	assert.True(t, ok)
+	assert.Equal(t, "second", content)
+	content, ok = store.Get(third)
+	assert.True(t, ok)
+	assert.Equal(t, "third", content)
+}
+
+func Test_FullResultResourceTemplate(t *testing.T) {
+	store := NewResultStore(10)
+	id := store.Put("the full result")
+	tmpl, handler := FullResultResourceTemplate(store, translations.NullTranslationHelper)
+	require.Equal(t, FullResultURIPrefix+"{id}", tmpl.URITemplate.Raw())
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = FullResultURIPrefix + id
+	request.Params.Arguments = map[string]any{"id": []string{id}}
+	contents, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, []mcp.ResourceContents{mcp.TextResourceContents{
+		URI:      FullResultURIPrefix + id,
+		MIMEType: "text/plain",
+		Text:     "the full result",
+	}}, contents)
+
+	request.Params.Arguments = map[string]any{"id": []string{"unknown"}}
+	_, err = handler(context.Background(), request)
+	require.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,748 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// teamRepoPermissions lists the permissions a team can be granted on a repository, from least to most privileged.
+var teamRepoPermissions = []string{"pull", "triage", "push", "maintain", "admin"}
+
+// MinimalTeamDiscussion is the trimmed output type for team discussions.
+type MinimalTeamDiscussion struct {
+	Number        int    `json:"number"`
+	Title         string `json:"title"`
+	Body          string `json:"body,omitempty"`
+	Author        string `json:"author,omitempty"`
+	Private       bool   `json:"private"`
+	Pinned        bool   `json:"pinned"`
+	CommentsCount int    `json:"comments_count"`
+	HTMLURL       string `json:"html_url,omitempty"`
+	CreatedAt     string `json:"created_at,omitempty"`
+}
+
+// MinimalTeamRepository is the output type for the repositories of a team, with the role of the team on them.
+type MinimalTeamRepository struct {
+	MinimalRepository
+	Role string `json:"role,omitempty"`
+}
+
+func convertToMinimalTeamDiscussion(discussion *github.TeamDiscussion) MinimalTeamDiscussion {
+	minimalDiscussion := MinimalTeamDiscussion{
+		Number:        discussion.GetNumber(),
+		Title:         discussion.GetTitle(),
+		Body:          discussion.GetBody(),
+		Author:        discussion.GetAuthor().GetLogin(),
+		Private:       discussion.GetPrivate(),
+		Pinned:        discussion.GetPinned(),
+		CommentsCount: discussion.GetCommentsCount(),
+		HTMLURL:       discussion.GetHTMLURL(),
+	}
+	if discussion.CreatedAt != nil {
+		minimalDiscussion.CreatedAt = discussion.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return minimalDiscussion
+}
+
+// withTeam adds the parameters identifying a team to a tool.
+func withTeam() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("org",
+			mcp.Required(),
+			mcp.Description("Organization login (owner) that contains the team"),
+		)(tool)
+		mcp.WithString("team_slug",
+			mcp.Required(),
+			mcp.Description("Team slug"),
+		)(tool)
+	}
+}
+
+func requiredTeam(request mcp.CallToolRequest) (string, string, error) {
+	org, err := RequiredParam[string](request, "org")
+	if err != nil {
+		return "", "", err
+	}
+	teamSlug, err := RequiredParam[string](request, "team_slug")
+	if err != nil {
+		return "", "", err
+	}
+	return org, teamSlug, nil
+}
+
+// ListTeamDiscussions creates a tool to list the discussions of a team.
+func ListTeamDiscussions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_discussions",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_DISCUSSIONS_DESCRIPTION", "List the discussions on the page of a team, most recent first")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_DISCUSSIONS_USER_TITLE", "List team discussions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalTeamDiscussion](),
+			withTeam(),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction of the creation date"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, err := requiredTeam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			discussions, resp, err := client.Teams.ListDiscussionsBySlug(ctx, org, teamSlug, &github.DiscussionListOptions{
+				Direction: direction,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list discussions of team %s/%s", org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalDiscussions := make([]MinimalTeamDiscussion, 0, len(discussions))
+			for _, discussion := range discussions {
+				minimalDiscussions = append(minimalDiscussions, convertToMinimalTeamDiscussion(discussion))
+			}
+
+			return MarshalledTextResult(minimalDiscussions), nil
+		}
+}
+
+// CreateTeamDiscussion creates a tool to start a discussion on the page of a team.
+func CreateTeamDiscussion(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_team_discussion",
+			mcp.WithDescription(t("TOOL_CREATE_TEAM_DISCUSSION_DESCRIPTION", "Start a discussion on the page of a team, notifying its members")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_TEAM_DISCUSSION_USER_TITLE", "Create team discussion"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalTeamDiscussion](),
+			withTeam(),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Discussion title"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Discussion body"),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Only show the discussion to the members of the team and organization owners. Default is false."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, err := requiredTeam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			private, err := OptionalParam[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			discussion, resp, err := client.Teams.CreateDiscussionBySlug(ctx, org, teamSlug, github.TeamDiscussion{
+				Title:   github.Ptr(title),
+				Body:    github.Ptr(body),
+				Private: github.Ptr(private),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create discussion for team %s/%s", org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalTeamDiscussion(discussion)), nil
+		}
+}
+
+// AddTeamDiscussionComment creates a tool to comment on a discussion of a team.
+func AddTeamDiscussionComment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_team_discussion_comment",
+			mcp.WithDescription(t("TOOL_ADD_TEAM_DISCUSSION_COMMENT_DESCRIPTION", "Add a comment to a discussion on the page of a team")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_TEAM_DISCUSSION_COMMENT_USER_TITLE", "Add team discussion comment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalResponse](),
+			withTeam(),
+			mcp.WithNumber("discussion_number",
+				mcp.Required(),
+				mcp.Description("Number of the team discussion"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Comment text"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, err := requiredTeam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			discussionNumber, err := RequiredInt(request, "discussion_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			comment, resp, err := client.Teams.CreateCommentBySlug(ctx, org, teamSlug, discussionNumber, github.DiscussionComment{
+				Body: github.Ptr(body),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to comment on discussion %d of team %s/%s", discussionNumber, org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(MinimalResponse{
+				ID:  fmt.Sprintf("%d", comment.GetNumber()),
+				URL: comment.GetHTMLURL(),
+			}), nil
+		}
+}
+
+// ListTeamRepos creates a tool to list the repositories a team has access to.
+func ListTeamRepos(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_repos",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_REPOS_DESCRIPTION", "List the repositories a team has access to, with the role of the team on each of them")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_REPOS_USER_TITLE", "List team repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[[]MinimalTeamRepository](),
+			withTeam(),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, err := requiredTeam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repos, resp, err := client.Teams.ListTeamReposBySlug(ctx, org, teamSlug, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list repositories of team %s/%s", org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			teamRepos := make([]MinimalTeamRepository, 0, len(repos))
+			for _, repo := range repos {
+				teamRepos = append(teamRepos, MinimalTeamRepository{
+					MinimalRepository: convertToMinimalRepository(repo),
+					Role:              repo.GetRoleName(),
+				})
+			}
+
+			return MarshalledTextResult(teamRepos), nil
+		}
+}
+
+// AddTeamRepo creates a tool to give a team access to a repository, or to change its permission on it.
+func AddTeamRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_team_repo",
+			mcp.WithDescription(t("TOOL_ADD_TEAM_REPO_DESCRIPTION", "Give a team access to a repository of the organization, or change the permission of a team that already has access to it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_TEAM_REPO_USER_TITLE", "Add or update team repository permission"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			withTeam(),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Permission to grant the team on the repository. Defaults to the permission of the team."),
+				mcp.Enum(teamRepoPermissions...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, err := requiredTeam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Teams.AddTeamRepoBySlug(ctx, org, teamSlug, owner, repo, &github.TeamAddTeamRepoOptions{
+				Permission: permission,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to add %s/%s to team %s/%s", owner, repo, org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if permission == "" {
+				return mcp.NewToolResultText(fmt.Sprintf("Team %s/%s now has access to %s/%s", org, teamSlug, owner, repo)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("Team %s/%s now has %s permission on %s/%s", org, teamSlug, permission, owner, repo)), nil
+		}
+}
+
+// RemoveTeamRepo creates a tool to remove the access of a team to a repository.
+func RemoveTeamRepo(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_team_repo",
+			mcp.WithDescription(t("TOOL_REMOVE_TEAM_REPO_DESCRIPTION", "Remove the access of a team to a repository. Members of the team keep the access they have to the repository through other means.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_TEAM_REPO_USER_TITLE", "Remove team repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			withTeam(),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, err := requiredTeam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Teams.RemoveTeamRepoBySlug(ctx, org, teamSlug, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to remove %s/%s from team %s/%s", owner, repo, org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("Team %s/%s no longer has access to %s/%s", org, teamSlug, owner, repo)), nil
+		}
+}
+
+// MinimalTeam is the trimmed output type for teams.
+type MinimalTeam struct {
+	ID                  int64  `json:"id"`
+	Slug                string `json:"slug"`
+	Name                string `json:"name"`
+	Description         string `json:"description,omitempty"`
+	Privacy             string `json:"privacy,omitempty"`
+	NotificationSetting string `json:"notification_setting,omitempty"`
+	Parent              string `json:"parent,omitempty"`
+	HTMLURL             string `json:"html_url,omitempty"`
+}
+
+// MinimalTeamMembership is the output type for the membership of a user in a team.
+type MinimalTeamMembership struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	// State is pending until users who aren't members of the organization yet accept its invitation.
+	State string `json:"state"`
+}
+
+func convertToMinimalTeam(team *github.Team) MinimalTeam {
+	return MinimalTeam{
+		ID:                  team.GetID(),
+		Slug:                team.GetSlug(),
+		Name:                team.GetName(),
+		Description:         team.GetDescription(),
+		Privacy:             team.GetPrivacy(),
+		NotificationSetting: team.GetNotificationSetting(),
+		Parent:              team.GetParent().GetSlug(),
+		HTMLURL:             team.GetHTMLURL(),
+	}
+}
+
+// withTeamSettings adds the parameters of the settings of a team shared by create_team and update_team.
+func withTeamSettings() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("description",
+			mcp.Description("Description of the team"),
+		)(tool)
+		mcp.WithString("privacy",
+			mcp.Description("Visibility of the team: secret teams are only visible to organization owners and their members, closed teams to all members of the organization. Nested teams must be closed"),
+			mcp.Enum("secret", "closed"),
+		)(tool)
+		mcp.WithString("notification_setting",
+			mcp.Description("Whether mentioning the team notifies its members"),
+			mcp.Enum("notifications_enabled", "notifications_disabled"),
+		)(tool)
+		mcp.WithString("parent_team_slug",
+			mcp.Description("Slug of the team to nest the team under"),
+		)(tool)
+	}
+}
+
+// teamSettingsFromRequest reads the parameters added by withTeamSettings, resolving the parent team to its ID.
+func teamSettingsFromRequest(ctx context.Context, client *github.Client, org string, request mcp.CallToolRequest, team *github.NewTeam) (*mcp.CallToolResult, error) {
+	for name, field := range map[string]**string{
+		"description":          &team.Description,
+		"privacy":              &team.Privacy,
+		"notification_setting": &team.NotificationSetting,
+	} {
+		value, ok, err := OptionalParamOK[string](request, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+	parentSlug, err := OptionalParam[string](request, "parent_team_slug")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if parentSlug == "" {
+		return nil, nil
+	}
+	parent, resp, err := client.Teams.GetTeamBySlug(ctx, org, parentSlug)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to get parent team %s/%s", org, parentSlug),
+			resp,
+			err,
+		), nil
+	}
+	_ = resp.Body.Close()
+	team.ParentTeamID = github.Ptr(parent.GetID())
+	return nil, nil
+}
+
+// CreateTeam creates a tool to create a team in an organization.
+func CreateTeam(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_team",
+			mcp.WithDescription(t("TOOL_CREATE_TEAM_DESCRIPTION", "Create a team in an organization, optionally with maintainers and nested under another team. The authenticated user becomes a maintainer of the team")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_TEAM_USER_TITLE", "Create team"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalTeam](),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login (owner) to create the team in"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the team, from which its slug is derived"),
+			),
+			withTeamSettings(),
+			mcp.WithArray("maintainers",
+				mcp.Description("Usernames of members of the organization to make maintainers of the team"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maintainers, err := OptionalStringArrayParam(request, "maintainers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			newTeam := github.NewTeam{Name: name, Maintainers: maintainers}
+			if result, err := teamSettingsFromRequest(ctx, client, org, request, &newTeam); result != nil || err != nil {
+				return result, err
+			}
+
+			team, resp, err := client.Teams.CreateTeam(ctx, org, newTeam)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create team %s in organization %s", name, org),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalTeam(team)), nil
+		}
+}
+
+// UpdateTeam creates a tool to change the settings of a team.
+func UpdateTeam(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_team",
+			mcp.WithDescription(t("TOOL_UPDATE_TEAM_DESCRIPTION", "Change the name, description, visibility, notifications or parent of a team. Only the given settings are changed. Renaming a team changes its slug")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_TEAM_USER_TITLE", "Update team"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalTeam](),
+			withTeam(),
+			mcp.WithString("name",
+				mcp.Description("New name of the team"),
+			),
+			withTeamSettings(),
+			mcp.WithBoolean("remove_parent",
+				mcp.Description("Move the team out of its parent team to the top level of the organization"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, err := requiredTeam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			removeParent, err := OptionalParam[bool](request, "remove_parent")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			newTeam := github.NewTeam{Name: name}
+			if result, err := teamSettingsFromRequest(ctx, client, org, request, &newTeam); result != nil || err != nil {
+				return result, err
+			}
+			if removeParent && newTeam.ParentTeamID != nil {
+				return mcp.NewToolResultError("remove_parent and parent_team_slug can't be given together"), nil
+			}
+			if newTeam.Name == "" {
+				// The API replaces the name of the team, so keep its current one when it isn't renamed.
+				team, resp, err := client.Teams.GetTeamBySlug(ctx, org, teamSlug)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get team %s/%s", org, teamSlug),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				newTeam.Name = team.GetName()
+			}
+
+			team, resp, err := client.Teams.EditTeamBySlug(ctx, org, teamSlug, newTeam, removeParent)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update team %s/%s", org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalTeam(team)), nil
+		}
+}
+
+// AddTeamMember creates a tool to add a user to a team, or to change their role in it.
+func AddTeamMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_team_member",
+			mcp.WithDescription(t("TOOL_ADD_TEAM_MEMBER_DESCRIPTION", "Add a user to a team, or change the role of a member of the team. Users who aren't members of the organization are invited to it and join the team once they accept")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_TEAM_MEMBER_USER_TITLE", "Add or update team member"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithJSONOutputSchema[MinimalTeamMembership](),
+			withTeam(),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the user to add"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role of the user in the team, maintainers can manage the team and its members. Defaults to member"),
+				mcp.Enum("member", "maintainer"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, err := requiredTeam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			membership, resp, err := client.Teams.AddTeamMembershipBySlug(ctx, org, teamSlug, username, &github.TeamAddTeamMembershipOptions{
+				Role: role,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to add %s to team %s/%s", username, org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(MinimalTeamMembership{
+				Username: username,
+				Role:     membership.GetRole(),
+				State:    membership.GetState(),
+			}), nil
+		}
+}
+
+// RemoveTeamMember creates a tool to remove a user from a team.
+func RemoveTeamMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_team_member",
+			mcp.WithDescription(t("TOOL_REMOVE_TEAM_MEMBER_DESCRIPTION", "Remove a user from a team, or cancel their pending invitation to it. The user stays a member of the organization and loses the access they had through the team.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_TEAM_MEMBER_USER_TITLE", "Remove team member"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			withTeam(),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username of the member to remove"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, err := requiredTeam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Teams.RemoveTeamMembershipBySlug(ctx, org, teamSlug, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to remove %s from team %s/%s", username, org, teamSlug),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return mcp.NewToolResultText(fmt.Sprintf("%s is no longer a member of team %s/%s", username, org, teamSlug)), nil
+		}
+}
@@ -0,0 +1,886 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListTeamDiscussions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamDiscussions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_team_discussions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "direction")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	mockDiscussions := []*github.TeamDiscussion{
+		{
+			Number:        github.Ptr(2),
+			Title:         github.Ptr("Quarterly planning"),
+			Body:          github.Ptr("Let's plan the next quarter"),
+			Author:        &github.User{Login: github.Ptr("octocat")},
+			Private:       github.Ptr(true),
+			CommentsCount: github.Ptr(3),
+			HTMLURL:       github.Ptr("https://github.com/orgs/octo-org/teams/core/discussions/2"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful discussions listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsDiscussionsByOrgByTeamSlug,
+					expectQueryParams(t, map[string]string{
+						"direction": "asc",
+						"page":      "1",
+						"per_page":  "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockDiscussions),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "core",
+				"direction": "asc",
+			},
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsDiscussionsByOrgByTeamSlug,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list discussions of team octo-org/missing",
+		},
+		{
+			name:         "missing team slug",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: team_slug",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTeamDiscussions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned []MinimalTeamDiscussion
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			require.Len(t, returned, 1)
+			assert.Equal(t, 2, returned[0].Number)
+			assert.Equal(t, "Quarterly planning", returned[0].Title)
+			assert.Equal(t, "octocat", returned[0].Author)
+			assert.True(t, returned[0].Private)
+			assert.Equal(t, 3, returned[0].CommentsCount)
+		})
+	}
+}
+
+func Test_CreateTeamDiscussion(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateTeamDiscussion(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_team_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "private")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "title", "body"})
+
+	mockDiscussion := &github.TeamDiscussion{
+		Number:  github.Ptr(5),
+		Title:   github.Ptr("Release retro"),
+		Body:    github.Ptr("How did the release go?"),
+		Private: github.Ptr(false),
+		HTMLURL: github.Ptr("https://github.com/orgs/octo-org/teams/core/discussions/5"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful discussion creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsTeamsDiscussionsByOrgByTeamSlug,
+					expectRequestBody(t, map[string]any{
+						"title":   "Release retro",
+						"body":    "How did the release go?",
+						"private": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockDiscussion),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "core",
+				"title":     "Release retro",
+				"body":      "How did the release go?",
+			},
+		},
+		{
+			name: "discussion creation forbidden",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsTeamsDiscussionsByOrgByTeamSlug,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "core",
+				"title":     "Release retro",
+				"body":      "How did the release go?",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create discussion for team octo-org/core",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTeamDiscussion(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned MinimalTeamDiscussion
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, 5, returned.Number)
+			assert.Equal(t, "Release retro", returned.Title)
+			assert.Equal(t, "https://github.com/orgs/octo-org/teams/core/discussions/5", returned.HTMLURL)
+		})
+	}
+}
+
+func Test_AddTeamDiscussionComment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddTeamDiscussionComment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_team_discussion_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "discussion_number", "body"})
+
+	mockComment := &github.DiscussionComment{
+		Number:  github.Ptr(4),
+		Body:    github.Ptr("Went well"),
+		HTMLURL: github.Ptr("https://github.com/orgs/octo-org/teams/core/discussions/5/comments/4"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsTeamsDiscussionsCommentsByOrgByTeamSlugByDiscussionNumber,
+					expectPath(t, "/orgs/octo-org/teams/core/discussions/5/comments").andThen(
+						expectRequestBody(t, map[string]any{
+							"body": "Went well",
+						}).andThen(
+							mockResponse(t, http.StatusCreated, mockComment),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":               "octo-org",
+				"team_slug":         "core",
+				"discussion_number": float64(5),
+				"body":              "Went well",
+			},
+		},
+		{
+			name: "discussion not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsTeamsDiscussionsCommentsByOrgByTeamSlugByDiscussionNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":               "octo-org",
+				"team_slug":         "core",
+				"discussion_number": float64(99),
+				"body":              "Went well",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to comment on discussion 99 of team octo-org/core",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddTeamDiscussionComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned MinimalResponse
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "4", returned.ID)
+			assert.Equal(t, "https://github.com/orgs/octo-org/teams/core/discussions/5/comments/4", returned.URL)
+		})
+	}
+}
+
+func Test_ListTeamRepos(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamRepos(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_team_repos", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	mockRepos := []*github.Repository{
+		{
+			ID:       github.Ptr(int64(1)),
+			Name:     github.Ptr("api"),
+			FullName: github.Ptr("octo-org/api"),
+			RoleName: github.Ptr("maintain"),
+		},
+		{
+			ID:       github.Ptr(int64(2)),
+			Name:     github.Ptr("docs"),
+			FullName: github.Ptr("octo-org/docs"),
+			RoleName: github.Ptr("pull"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful repositories listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsReposByOrgByTeamSlug,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepos),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "core",
+				"page":      float64(2),
+				"perPage":   float64(10),
+			},
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsReposByOrgByTeamSlug,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repositories of team octo-org/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTeamRepos(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned []MinimalTeamRepository
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			require.Len(t, returned, 2)
+			assert.Equal(t, "octo-org/api", returned[0].FullName)
+			assert.Equal(t, "maintain", returned[0].Role)
+			assert.Equal(t, "octo-org/docs", returned[1].FullName)
+			assert.Equal(t, "pull", returned[1].Role)
+		})
+	}
+}
+
+func Test_AddTeamRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddTeamRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_team_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "grant permission",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectPath(t, "/orgs/octo-org/teams/core/repos/octo-org/api").andThen(
+						expectRequestBody(t, map[string]any{
+							"permission": "maintain",
+						}).andThen(
+							mockResponse(t, http.StatusNoContent, ""),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"team_slug":  "core",
+				"owner":      "octo-org",
+				"repo":       "api",
+				"permission": "maintain",
+			},
+			expectedText: "Team octo-org/core now has maintain permission on octo-org/api",
+		},
+		{
+			name: "grant default permission",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectRequestBody(t, map[string]any{}).andThen(
+						mockResponse(t, http.StatusNoContent, ""),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "core",
+				"owner":     "octo-org",
+				"repo":      "api",
+			},
+			expectedText: "Team octo-org/core now has access to octo-org/api",
+		},
+		{
+			name: "repository outside the organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"team_slug":  "core",
+				"owner":      "someone-else",
+				"repo":       "api",
+				"permission": "push",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add someone-else/api to team octo-org/core",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddTeamRepo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_RemoveTeamRepo(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveTeamRepo(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_team_repo", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful removal",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectPath(t, "/orgs/octo-org/teams/core/repos/octo-org/api").andThen(
+						mockResponse(t, http.StatusNoContent, ""),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "core",
+				"owner":     "octo-org",
+				"repo":      "api",
+			},
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "missing",
+				"owner":     "octo-org",
+				"repo":      "api",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to remove octo-org/api from team octo-org/missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveTeamRepo(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, "Team octo-org/core no longer has access to octo-org/api", textContent.Text)
+		})
+	}
+}
+
+func Test_CreateTeam(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateTeam(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_team", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "maintainers")
+	assert.Contains(t, tool.InputSchema.Properties, "parent_team_slug")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "name"})
+
+	mockTeam := &github.Team{
+		ID:      github.Ptr(int64(8)),
+		Slug:    github.Ptr("backend"),
+		Name:    github.Ptr("Backend"),
+		Privacy: github.Ptr("closed"),
+		Parent:  &github.Team{Slug: github.Ptr("engineering")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "nested team with maintainers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsTeamsByOrgByTeamSlug, &github.Team{ID: github.Ptr(int64(7)), Slug: github.Ptr("engineering")}),
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsTeamsByOrg,
+					expectRequestBody(t, map[string]any{
+						"name":           "Backend",
+						"description":    "Backend services",
+						"privacy":        "closed",
+						"maintainers":    []any{"octocat"},
+						"parent_team_id": float64(7),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTeam),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":              "octo-org",
+				"name":             "Backend",
+				"description":      "Backend services",
+				"privacy":          "closed",
+				"maintainers":      []interface{}{"octocat"},
+				"parent_team_slug": "engineering",
+			},
+		},
+		{
+			name: "parent team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrgByTeamSlug,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":              "octo-org",
+				"name":             "Backend",
+				"parent_team_slug": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get parent team octo-org/missing",
+		},
+		{
+			name: "team already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsTeamsByOrg,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":  "octo-org",
+				"name": "Backend",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create team Backend in organization octo-org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTeam(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var team MinimalTeam
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &team))
+			assert.Equal(t, "backend", team.Slug)
+			assert.Equal(t, "engineering", team.Parent)
+		})
+	}
+}
+
+func Test_UpdateTeam(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateTeam(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_team", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "remove_parent")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	mockTeam := &github.Team{
+		ID:                  github.Ptr(int64(8)),
+		Slug:                github.Ptr("core"),
+		Name:                github.Ptr("Core"),
+		NotificationSetting: github.Ptr("notifications_disabled"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "keeps the current name",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsTeamsByOrgByTeamSlug, mockTeam),
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsTeamsByOrgByTeamSlug,
+					expectRequestBody(t, map[string]any{
+						"name":                 "Core",
+						"notification_setting": "notifications_disabled",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockTeam),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                  "octo-org",
+				"team_slug":            "core",
+				"notification_setting": "notifications_disabled",
+			},
+		},
+		{
+			name: "rename and move to the top level",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsTeamsByOrgByTeamSlug,
+					expectRequestBody(t, map[string]any{
+						"name":           "Core",
+						"parent_team_id": nil,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockTeam),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":           "octo-org",
+				"team_slug":     "core-old",
+				"name":          "Core",
+				"remove_parent": true,
+			},
+		},
+		{
+			name:         "remove_parent with a parent",
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetOrgsTeamsByOrgByTeamSlug, mockTeam)),
+			requestArgs: map[string]interface{}{
+				"org":              "octo-org",
+				"team_slug":        "backend",
+				"parent_team_slug": "core",
+				"remove_parent":    true,
+			},
+			expectError:    true,
+			expectedErrMsg: "remove_parent and parent_team_slug can't be given together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateTeam(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var team MinimalTeam
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &team))
+			assert.Equal(t, "core", team.Slug)
+		})
+	}
+}
+
+func Test_AddTeamMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddTeamMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_team_member", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "add as maintainer",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					expectRequestBody(t, map[string]any{"role": "maintainer"}).andThen(
+						mockResponse(t, http.StatusOK, &github.Membership{
+							Role:  github.Ptr("maintainer"),
+							State: github.Ptr("pending"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "core",
+				"username":  "octocat",
+				"role":      "maintainer",
+			},
+		},
+		{
+			name: "team is synchronized with an identity provider",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "core",
+				"username":  "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add octocat to team octo-org/core",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddTeamMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var membership MinimalTeamMembership
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &membership))
+			assert.Equal(t, MinimalTeamMembership{Username: "octocat", Role: "maintainer", State: "pending"}, membership)
+		})
+	}
+}
+
+func Test_RemoveTeamMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveTeamMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_team_member", tool.Name)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "username"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+			expectPath(t, "/orgs/octo-org/teams/core/memberships/octocat").andThen(
+				mockResponse(t, http.StatusNoContent, ""),
+			),
+		),
+	))
+	_, handler := RemoveTeamMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":       "octo-org",
+		"team_slug": "core",
+		"username":  "octocat",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "octocat is no longer a member of team octo-org/core", getTextResult(t, result).Text)
+}
@@ -0,0 +1,89 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// TokenValidation is the result of checking the scopes of the token against what the active tools need.
+type TokenValidation struct {
+	AuthMode string `json:"auth_mode"`
+	// ScopesListed is false for tokens with permissions rather than scopes, whose tools can't be checked.
+	ScopesListed     bool             `json:"scopes_listed"`
+	Scopes           []string         `json:"scopes"`
+	UnavailableTools []ToolScopeIssue `json:"unavailable_tools"`
+	LimitedTools     []ToolScopeIssue `json:"limited_tools"`
+	Message          string           `json:"message"`
+}
+
+// ValidateToken checks the scopes of the token of a client against the scopes the active tools of the group need.
+// The scopes are read from a rate limit request, which any valid token can make.
+func ValidateToken(ctx context.Context, client *github.Client, tsg *toolsets.ToolsetGroup, authMode string) (*TokenValidation, *github.Response, error) {
+	_, resp, err := client.RateLimit.Get(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	validation := &TokenValidation{
+		AuthMode:         authMode,
+		Scopes:           []string{},
+		UnavailableTools: []ToolScopeIssue{},
+		LimitedTools:     []ToolScopeIssue{},
+	}
+	scopes, listed := TokenScopes(resp.Header)
+	if !listed {
+		validation.Message = "The token has permissions rather than scopes, which GitHub doesn't list, so the tools weren't checked. Tools fail with a permission error when the token lacks a permission they need."
+		return validation, resp, nil
+	}
+
+	validation.ScopesListed = true
+	if scopes != nil {
+		validation.Scopes = scopes
+	}
+	validation.UnavailableTools, validation.LimitedTools = CheckToolScopes(tsg, scopes)
+	switch {
+	case len(validation.UnavailableTools) > 0:
+		validation.Message = fmt.Sprintf("%d of the enabled tools will fail because the token is missing scopes they need. Add the missing scopes to the token, or disable their toolsets.", len(validation.UnavailableTools))
+	case len(validation.LimitedTools) > 0:
+		validation.Message = fmt.Sprintf("The token has the scopes the enabled tools need, but %d of them can't do everything without more scopes.", len(validation.LimitedTools))
+	default:
+		validation.Message = "The token has the scopes all enabled tools need."
+	}
+	return validation, resp, nil
+}
+
+// ValidateTokenTool creates a tool reporting which of the enabled tools the scopes of the token don't cover.
+func ValidateTokenTool(getClient GetClientFn, tsg *toolsets.ToolsetGroup, authMode string, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("validate_token",
+			mcp.WithDescription(t("TOOL_VALIDATE_TOKEN_DESCRIPTION", "Check the scopes of the GitHub token against what the enabled tools need, listing the tools that will fail and the scopes they are missing, such as workflow to change workflow files. Use this before a multi-step task, or when a tool fails with a permission error.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_VALIDATE_TOKEN_USER_TITLE", "Validate token scopes"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[TokenValidation](),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			validation, resp, err := ValidateToken(ctx, client, tsg, authMode)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to validate token",
+					resp,
+					err,
+				), nil
+			}
+			return MarshalledTextResult(validation), nil
+		}
+}
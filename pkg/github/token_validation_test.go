@@ -0,0 +1,140 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateTokenTool(t *testing.T) {
+	newTool := func(name string) server.ServerTool {
+		return toolsets.NewServerTool(mcp.NewTool(name,
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(false)}),
+		), nil)
+	}
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("repos", "Repositories").
+		AddWriteTools(newTool("push_files"), newTool("create_branch")))
+	tsg.AddToolset(toolsets.NewToolset("notifications", "Notifications").
+		AddWriteTools(newTool("dismiss_notification")))
+	tsg.AddToolset(toolsets.NewToolset("gists", "Gists").
+		AddWriteTools(newTool("create_gist")))
+	require.NoError(t, tsg.EnableToolsets([]string{"repos", "notifications"}))
+
+	tool, _ := ValidateTokenTool(stubGetClientFn(github.NewClient(nil)), tsg, AuthModePersonalAccessToken, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "validate_token", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	rateLimitWithScopes := func(scopes *string) *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetRateLimit,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					if scopes != nil {
+						w.Header().Set(OAuthScopesHeader, *scopes)
+					}
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{"resources":{}}`))
+				}),
+			),
+		)
+	}
+	allScopes, repoScope := "repo, workflow, notifications", "repo"
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       TokenValidation
+	}{
+		{
+			name:         "token with all scopes",
+			mockedClient: rateLimitWithScopes(&allScopes),
+			expected: TokenValidation{
+				AuthMode:         AuthModePersonalAccessToken,
+				ScopesListed:     true,
+				Scopes:           []string{"repo", "workflow", "notifications"},
+				UnavailableTools: []ToolScopeIssue{},
+				LimitedTools:     []ToolScopeIssue{},
+				Message:          "The token has the scopes all enabled tools need.",
+			},
+		},
+		{
+			name:         "token missing scopes",
+			mockedClient: rateLimitWithScopes(&repoScope),
+			expected: TokenValidation{
+				AuthMode:     AuthModePersonalAccessToken,
+				ScopesListed: true,
+				Scopes:       []string{"repo"},
+				UnavailableTools: []ToolScopeIssue{
+					{Tool: "dismiss_notification", Toolset: "notifications", MissingScopes: []string{"notifications"}},
+				},
+				LimitedTools: []ToolScopeIssue{
+					{Tool: "push_files", Toolset: "repos", MissingScopes: []string{"workflow"}, Impact: "changing files in .github/workflows"},
+				},
+				Message: "1 of the enabled tools will fail because the token is missing scopes they need. Add the missing scopes to the token, or disable their toolsets.",
+			},
+		},
+		{
+			name:         "token with permissions",
+			mockedClient: rateLimitWithScopes(nil),
+			expected: TokenValidation{
+				AuthMode:         AuthModePersonalAccessToken,
+				Scopes:           []string{},
+				UnavailableTools: []ToolScopeIssue{},
+				LimitedTools:     []ToolScopeIssue{},
+				Message:          "The token has permissions rather than scopes, which GitHub doesn't list, so the tools weren't checked. Tools fail with a permission error when the token lacks a permission they need.",
+			},
+		},
+		{
+			name: "rejected token",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetRateLimit,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnauthorized)
+						_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to validate token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ValidateTokenTool(stubGetClientFn(client), tsg, AuthModePersonalAccessToken, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var validation TokenValidation
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &validation))
+			assert.Equal(t, tc.expected, validation)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/github/github-mcp-server/pkg/lfs"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -31,6 +32,10 @@ var (
 		ID:          "repos",
 		Description: "GitHub Repository related tools",
 	}
+	ToolsetMetadataReleases = ToolsetMetadata{
+		ID:          "releases",
+		Description: "GitHub Releases related tools",
+	}
 	ToolsetMetadataIssues = ToolsetMetadata{
 		ID:          "issues",
 		Description: "GitHub Issues related tools",
@@ -91,6 +96,18 @@ var (
 		ID:          "stargazers",
 		Description: "GitHub Stargazers related tools",
 	}
+	ToolsetMetadataPackages = ToolsetMetadata{
+		ID:          "packages",
+		Description: "GitHub Packages related tools, such as container images and npm packages",
+	}
+	ToolsetMetadataCopilot = ToolsetMetadata{
+		ID:          "copilot",
+		Description: "GitHub Copilot license management tools, to assign and cancel the Copilot seats of organizations",
+	}
+	ToolsetMetadataBatch = ToolsetMetadata{
+		ID:          "batch",
+		Description: "Run several tool calls, or a tool across many repositories, in a single request",
+	}
 	ToolsetMetadataDynamic = ToolsetMetadata{
 		ID:          "dynamic",
 		Description: "Discover GitHub MCP tools that can help achieve tasks by enabling additional sets of tools, you can control the enablement of any toolset to access its tools when this toolset is enabled.",
@@ -101,6 +118,7 @@ func AvailableTools() []ToolsetMetadata {
 	return []ToolsetMetadata{
 		ToolsetMetadataContext,
 		ToolsetMetadataRepos,
+		ToolsetMetadataReleases,
 		ToolsetMetadataIssues,
 		ToolsetMetadataPullRequests,
 		ToolsetMetadataUsers,
@@ -116,6 +134,9 @@ func AvailableTools() []ToolsetMetadata {
 		ToolsetMetadataSecurityAdvisories,
 		ToolsetMetadataProjects,
 		ToolsetMetadataStargazers,
+		ToolsetMetadataPackages,
+		ToolsetMetadataCopilot,
+		ToolsetMetadataBatch,
 		ToolsetMetadataDynamic,
 	}
 }
@@ -124,13 +145,16 @@ func GetDefaultToolsetIDs() []string {
 	return []string{
 		ToolsetMetadataContext.ID,
 		ToolsetMetadataRepos.ID,
+		ToolsetMetadataReleases.ID,
 		ToolsetMetadataIssues.ID,
 		ToolsetMetadataPullRequests.ID,
 		ToolsetMetadataUsers.ID,
 	}
 }
 
-func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc, contentWindowSize int) *toolsets.ToolsetGroup {
+// DefaultToolsetGroup creates the toolsets of the server. getReleaseClient returns the client reading the releases
+// of the server itself, which are published on github.com whichever host the other clients target.
+func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, getLFSClient lfs.GetLFSClientFn, getReleaseClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int, info ServerInfo) *toolsets.ToolsetGroup {
 	tsg := toolsets.NewToolsetGroup(readOnly)
 
 	// Define all available features with their default state (disabled)
@@ -138,24 +162,45 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	repos := toolsets.NewToolset(ToolsetMetadataRepos.ID, ToolsetMetadataRepos.Description).
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
-			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, getLFSClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
+			toolsets.NewServerTool(ListFileCommits(getClient, t)),
+			toolsets.NewServerTool(GetFileAtCommit(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
+			toolsets.NewServerTool(GetBranchDivergence(getClient, t)),
+			toolsets.NewServerTool(GetMergeBase(getClient, t)),
+			toolsets.NewServerTool(ListStaleBranches(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
 			toolsets.NewServerTool(ListReleases(getClient, t)),
 			toolsets.NewServerTool(GetLatestRelease(getClient, t)),
 			toolsets.NewServerTool(GetReleaseByTag(getClient, t)),
+			toolsets.NewServerTool(ListRepoEvents(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryLicense(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryLanguages(getClient, t)),
+			toolsets.NewServerTool(ListCollaborators(getClient, t)),
+			toolsets.NewServerTool(GetCollaboratorPermission(getClient, t)),
+			toolsets.NewServerTool(GetBranchProtection(getClient, t)),
+			toolsets.NewServerTool(ListCommitStatuses(getClient, t)),
 		).
 		AddWriteTools(
-			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
+			toolsets.NewServerTool(CreateOrUpdateFile(getClient, getLFSClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
+			toolsets.NewServerTool(ScaffoldRepository(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
+			toolsets.NewServerTool(DeleteBranches(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
+			toolsets.NewServerTool(CreateSignedCommit(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(CherryPickCommits(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(AddCollaborator(getClient, t)),
+			toolsets.NewServerTool(RemoveCollaborator(getClient, t)),
+			toolsets.NewServerTool(UpdateBranchProtection(getClient, t)),
+			toolsets.NewServerTool(DeleteBranchProtection(getClient, t)),
+			toolsets.NewServerTool(CreateCommitStatus(getClient, t)),
 		).
 		AddResourceTemplates(
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, t)),
@@ -163,6 +208,21 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceCommitContent(getClient, getRawClient, t)),
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceTagContent(getClient, getRawClient, t)),
 			toolsets.NewServerResourceTemplate(GetRepositoryResourcePrContent(getClient, getRawClient, t)),
+			toolsets.NewServerResourceTemplate(GetRepositoryResourceRefContent(getClient, getRawClient, t)),
+		).
+		AddPrompts(
+			toolsets.NewServerPrompt(SummarizeRepositoryActivityPrompt(getClient, t)),
+		)
+	// repos keeps listing releases and reading them by tag, as it did before the releases toolset existed
+	releases := toolsets.NewToolset(ToolsetMetadataReleases.ID, ToolsetMetadataReleases.Description).
+		AddReadTools(
+			toolsets.NewServerTool(GetRelease(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateRelease(getClient, t)),
+			toolsets.NewServerTool(UpdateRelease(getClient, t)),
+			toolsets.NewServerTool(DeleteRelease(getClient, t)),
+			toolsets.NewServerTool(UploadReleaseAsset(getClient, getRawClient, t)),
 		)
 	issues := toolsets.NewToolset(ToolsetMetadataIssues.ID, ToolsetMetadataIssues.Description).
 		AddReadTools(
@@ -170,39 +230,77 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getGQLClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
+			toolsets.NewServerTool(ListIssueComments(getClient, t)),
 			toolsets.NewServerTool(ListIssueTypes(getClient, t)),
 			toolsets.NewServerTool(ListSubIssues(getClient, t)),
+			toolsets.NewServerTool(ListMilestones(getClient, t)),
+			toolsets.NewServerTool(ListLabels(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(BulkUpdateIssues(getClient, t)),
+			toolsets.NewServerTool(CloseIssues(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
+			toolsets.NewServerTool(MinimizeComment(getGQLClient, t)),
+			toolsets.NewServerTool(UnminimizeComment(getGQLClient, t)),
 			toolsets.NewServerTool(AddSubIssue(getClient, t)),
 			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
 			toolsets.NewServerTool(ReprioritizeSubIssue(getClient, t)),
+			toolsets.NewServerTool(CreateMilestone(getClient, t)),
+			toolsets.NewServerTool(UpdateMilestone(getClient, t)),
+			toolsets.NewServerTool(DeleteMilestone(getClient, t)),
+			toolsets.NewServerTool(SetMilestone(getClient, t)),
+			toolsets.NewServerTool(CreateLabel(getClient, t)),
+			toolsets.NewServerTool(UpdateLabel(getClient, t)),
+			toolsets.NewServerTool(DeleteLabel(getClient, t)),
 		).AddPrompts(
 		toolsets.NewServerPrompt(AssignCodingAgentPrompt(t)),
 		toolsets.NewServerPrompt(IssueToFixWorkflowPrompt(t)),
+		toolsets.NewServerPrompt(TriageIssuesPrompt(getClient, t)),
 	)
 	users := toolsets.NewToolset(ToolsetMetadataUsers.ID, ToolsetMetadataUsers.Description).
 		AddReadTools(
 			toolsets.NewServerTool(SearchUsers(getClient, t)),
+			toolsets.NewServerTool(ListUserEvents(getClient, t)),
 		)
 	orgs := toolsets.NewToolset(ToolsetMetadataOrgs.ID, ToolsetMetadataOrgs.Description).
 		AddReadTools(
 			toolsets.NewServerTool(SearchOrgs(getClient, t)),
+			toolsets.NewServerTool(GetOrg(getClient, t)),
+			toolsets.NewServerTool(ListOrgMembers(getClient, t)),
+			toolsets.NewServerTool(ListOrgEvents(getClient, t)),
+			toolsets.NewServerTool(ListTeamDiscussions(getClient, t)),
+			toolsets.NewServerTool(ListTeamRepos(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateOrgSettings(getClient, t)),
+			toolsets.NewServerTool(InviteOrgMember(getClient, t)),
+			toolsets.NewServerTool(RemoveOrgMember(getClient, t)),
+			toolsets.NewServerTool(CreateTeam(getClient, t)),
+			toolsets.NewServerTool(UpdateTeam(getClient, t)),
+			toolsets.NewServerTool(AddTeamMember(getClient, t)),
+			toolsets.NewServerTool(RemoveTeamMember(getClient, t)),
+			toolsets.NewServerTool(CreateTeamDiscussion(getClient, t)),
+			toolsets.NewServerTool(AddTeamDiscussionComment(getClient, t)),
+			toolsets.NewServerTool(AddTeamRepo(getClient, t)),
+			toolsets.NewServerTool(RemoveTeamRepo(getClient, t)),
 		)
 	pullRequests := toolsets.NewToolset(ToolsetMetadataPullRequests.ID, ToolsetMetadataPullRequests.Description).
 		AddReadTools(
-			toolsets.NewServerTool(GetPullRequest(getClient, t)),
+			toolsets.NewServerTool(GetPullRequest(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(ListPullRequests(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestFiles(getClient, t)),
 			toolsets.NewServerTool(SearchPullRequests(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestStatus(getClient, t)),
-			toolsets.NewServerTool(GetPullRequestReviewComments(getClient, t)),
+			toolsets.NewServerTool(GetRequiredChecksStatus(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestReviewComments(getGQLClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestDiff(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestFileDiff(getClient, t)),
+			toolsets.NewServerTool(GetMergeConflicts(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestReviewBundle(getClient, getGQLClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(MergePullRequest(getClient, t)),
@@ -215,18 +313,34 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(CreateAndSubmitPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(CreatePendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(AddCommentToPendingReview(getGQLClient, t)),
+			toolsets.NewServerTool(ReplyToReviewComment(getClient, t)),
+			toolsets.NewServerTool(ApplySuggestion(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
+		).
+		AddPrompts(
+			toolsets.NewServerPrompt(ReviewPullRequestPrompt(getClient, t)),
 		)
 	codeSecurity := toolsets.NewToolset(ToolsetMetadataCodeSecurity.ID, ToolsetMetadataCodeSecurity.Description).
 		AddReadTools(
 			toolsets.NewServerTool(GetCodeScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListCodeScanningAnalyses(getClient, t)),
+			toolsets.NewServerTool(GetSarifUpload(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UploadSarif(getClient, t)),
+			toolsets.NewServerTool(DeleteCodeScanningAnalysis(getClient, t)),
 		)
 	secretProtection := toolsets.NewToolset(ToolsetMetadataSecretProtection.ID, ToolsetMetadataSecretProtection.Description).
 		AddReadTools(
 			toolsets.NewServerTool(GetSecretScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListSecretScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListSecretScanningBypassRequests(getClient, t)),
+			toolsets.NewServerTool(GetSecretScanningBypassRequest(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(ReviewSecretScanningBypassRequest(getClient, t)),
 		)
 	dependabot := toolsets.NewToolset(ToolsetMetadataDependabot.ID, ToolsetMetadataDependabot.Description).
 		AddReadTools(
@@ -244,6 +358,9 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(MarkAllNotificationsRead(getClient, t)),
 			toolsets.NewServerTool(ManageNotificationSubscription(getClient, t)),
 			toolsets.NewServerTool(ManageRepositoryNotificationSubscription(getClient, t)),
+			toolsets.NewServerTool(MarkNotificationDone(getClient, t)),
+			toolsets.NewServerTool(SubscribeToThread(getClient, t)),
+			toolsets.NewServerTool(UnsubscribeFromThread(getClient, t)),
 		)
 
 	discussions := toolsets.NewToolset(ToolsetMetadataDiscussions.ID, ToolsetMetadataDiscussions.Description).
@@ -257,14 +374,16 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	actions := toolsets.NewToolset(ToolsetMetadataActions.ID, ToolsetMetadataActions.Description).
 		AddReadTools(
 			toolsets.NewServerTool(ListWorkflows(getClient, t)),
+			toolsets.NewServerTool(GetWorkflow(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowRuns(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRun(getClient, t)),
-			toolsets.NewServerTool(GetWorkflowRunLogs(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowRunLogs(getClient, t, contentWindowSize)),
 			toolsets.NewServerTool(ListWorkflowJobs(getClient, t)),
 			toolsets.NewServerTool(GetJobLogs(getClient, t, contentWindowSize)),
 			toolsets.NewServerTool(ListWorkflowRunArtifacts(getClient, t)),
 			toolsets.NewServerTool(DownloadWorkflowRunArtifact(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunUsage(getClient, t)),
+			toolsets.NewServerTool(ListPendingDeployments(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(RunWorkflow(getClient, t)),
@@ -272,6 +391,7 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
 			toolsets.NewServerTool(CancelWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(DeleteWorkflowRunLogs(getClient, t)),
+			toolsets.NewServerTool(ReviewPendingDeployments(getClient, t)),
 		)
 
 	securityAdvisories := toolsets.NewToolset(ToolsetMetadataSecurityAdvisories.ID, ToolsetMetadataSecurityAdvisories.Description).
@@ -280,6 +400,12 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetGlobalSecurityAdvisory(getClient, t)),
 			toolsets.NewServerTool(ListRepositorySecurityAdvisories(getClient, t)),
 			toolsets.NewServerTool(ListOrgRepositorySecurityAdvisories(getClient, t)),
+			toolsets.NewServerTool(GetRepositorySecurityAdvisory(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateRepositorySecurityAdvisory(getClient, t)),
+			toolsets.NewServerTool(PublishRepositorySecurityAdvisory(getClient, t)),
+			toolsets.NewServerTool(RequestSecurityAdvisoryCVE(getClient, t)),
 		)
 
 	// Keep experiments alive so the system doesn't error out when it's always enabled
@@ -290,6 +416,10 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetMe(getClient, t)),
 			toolsets.NewServerTool(GetTeams(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(GetTeamMembers(getGQLClient, t)),
+			toolsets.NewServerTool(GetServerInfo(tsg, info, readOnly, t)),
+			toolsets.NewServerTool(DescribeTools(tsg, readOnly, t)),
+			toolsets.NewServerTool(ValidateTokenTool(getClient, tsg, info.AuthMode, t)),
+			toolsets.NewServerTool(CheckForUpdatesTool(getReleaseClient, info.Version, t)),
 		)
 
 	gists := toolsets.NewToolset(ToolsetMetadataGists.ID, ToolsetMetadataGists.Description).
@@ -317,15 +447,52 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	stargazers := toolsets.NewToolset(ToolsetMetadataStargazers.ID, ToolsetMetadataStargazers.Description).
 		AddReadTools(
 			toolsets.NewServerTool(ListStarredRepositories(getClient, t)),
+			toolsets.NewServerTool(ListStargazers(getClient, t)),
+			toolsets.NewServerTool(ListForks(getClient, t)),
+			toolsets.NewServerTool(ListWatchers(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(StarRepository(getClient, t)),
 			toolsets.NewServerTool(UnstarRepository(getClient, t)),
+			toolsets.NewServerTool(WatchRepository(getClient, t)),
+			toolsets.NewServerTool(UnwatchRepository(getClient, t)),
+		)
+
+	packages := toolsets.NewToolset(ToolsetMetadataPackages.ID, ToolsetMetadataPackages.Description).
+		AddReadTools(
+			toolsets.NewServerTool(ListPackages(getClient, t)),
+			toolsets.NewServerTool(ListPackageVersions(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(DeletePackageVersion(getClient, t)),
+			toolsets.NewServerTool(RestorePackageVersion(getClient, t)),
+		)
+
+	copilot := toolsets.NewToolset(ToolsetMetadataCopilot.ID, ToolsetMetadataCopilot.Description).
+		AddReadTools(
+			toolsets.NewServerTool(GetCopilotBilling(getClient, t)),
+			toolsets.NewServerTool(ListCopilotSeats(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddCopilotSeats(getClient, t)),
+			toolsets.NewServerTool(RemoveCopilotSeats(getClient, t)),
 		)
 
+	// The batch tool only calls the active tools of the group, so it can only modify data when they can
+	batch := toolsets.NewToolset(ToolsetMetadataBatch.ID, ToolsetMetadataBatch.Description).
+		AddReadTools(
+			toolsets.NewServerTool(MultiRepoQuery(getClient, tsg, t)),
+		)
+	if readOnly {
+		batch.AddReadTools(toolsets.NewServerTool(Batch(tsg, readOnly, t)))
+	} else {
+		batch.AddWriteTools(toolsets.NewServerTool(Batch(tsg, readOnly, t)))
+	}
+
 	// Add toolsets to the group
 	tsg.AddToolset(contextTools)
 	tsg.AddToolset(repos)
+	tsg.AddToolset(releases)
 	tsg.AddToolset(issues)
 	tsg.AddToolset(orgs)
 	tsg.AddToolset(users)
@@ -341,6 +508,9 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	tsg.AddToolset(securityAdvisories)
 	tsg.AddToolset(projects)
 	tsg.AddToolset(stargazers)
+	tsg.AddToolset(packages)
+	tsg.AddToolset(copilot)
+	tsg.AddToolset(batch)
 
 	return tsg
 }
@@ -356,7 +526,7 @@ func InitDynamicToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup, t trans
 			toolsets.NewServerTool(EnableToolset(s, tsg, t)),
 		)
 
-	dynamicToolSelection.Enabled = true
+	dynamicToolSelection.SetEnabled(true)
 	return dynamicToolSelection
 }
 
@@ -0,0 +1,133 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// serverRepositoryOwner and serverRepositoryName are the repository the server is released from.
+	serverRepositoryOwner = "github"
+	serverRepositoryName  = "github-mcp-server"
+
+	// maxReleaseNotesLength bounds the release notes returned along with an available update.
+	maxReleaseNotesLength = 4000
+)
+
+// UpdateCheck is the result of comparing the running server version against the latest release.
+type UpdateCheck struct {
+	CurrentVersion  string `json:"current_version"`
+	LatestVersion   string `json:"latest_version"`
+	UpdateAvailable bool   `json:"update_available"`
+	ReleaseURL      string `json:"release_url,omitempty"`
+	PublishedAt     string `json:"published_at,omitempty"`
+	ReleaseNotes    string `json:"release_notes,omitempty"`
+	Message         string `json:"message"`
+}
+
+// CheckForUpdates compares the running version against the latest release of the server, using a client for
+// github.com as that is where the server is released, even when it targets another host.
+func CheckForUpdates(ctx context.Context, client *github.Client, currentVersion string) (*UpdateCheck, *github.Response, error) {
+	release, resp, err := client.Repositories.GetLatestRelease(ctx, serverRepositoryOwner, serverRepositoryName)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	check := &UpdateCheck{
+		CurrentVersion: currentVersion,
+		LatestVersion:  release.GetTagName(),
+	}
+	newer, ok := isNewerVersion(currentVersion, release.GetTagName())
+	switch {
+	case !ok:
+		check.Message = fmt.Sprintf("The running version %q isn't a release, so it can't be compared with the latest release %s.", currentVersion, release.GetTagName())
+	case newer:
+		check.UpdateAvailable = true
+		check.ReleaseURL = release.GetHTMLURL()
+		check.PublishedAt = release.GetPublishedAt().UTC().Format("2006-01-02")
+		check.ReleaseNotes = release.GetBody()
+		if len(check.ReleaseNotes) > maxReleaseNotesLength {
+			check.ReleaseNotes = check.ReleaseNotes[:maxReleaseNotesLength] + "..."
+		}
+		check.Message = fmt.Sprintf("Version %s of the GitHub MCP server is available, running %s. See the release notes for new tools and toolsets.", release.GetTagName(), currentVersion)
+	default:
+		check.Message = fmt.Sprintf("The GitHub MCP server is up to date, running %s.", currentVersion)
+	}
+	return check, resp, nil
+}
+
+// CheckForUpdatesTool creates a tool reporting whether a newer version of the server has been released.
+func CheckForUpdatesTool(getReleaseClient GetClientFn, currentVersion string, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("check_for_updates",
+			mcp.WithDescription(t("TOOL_CHECK_FOR_UPDATES_DESCRIPTION", "Check whether a newer version of this GitHub MCP server has been released, returning its version and release notes.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CHECK_FOR_UPDATES_USER_TITLE", "Check for server updates"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithJSONOutputSchema[UpdateCheck](),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := getReleaseClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			check, resp, err := CheckForUpdates(ctx, client, currentVersion)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get the latest release",
+					resp,
+					err,
+				), nil
+			}
+			return MarshalledTextResult(check), nil
+		}
+}
+
+// isNewerVersion reports whether latest is a later release than current, both given as vMAJOR.MINOR.PATCH.
+// Pre-release suffixes are ignored. It returns false for ok when either isn't a release version.
+func isNewerVersion(current, latest string) (newer bool, ok bool) {
+	currentParts, ok := parseReleaseVersion(current)
+	if !ok {
+		return false, false
+	}
+	latestParts, ok := parseReleaseVersion(latest)
+	if !ok {
+		return false, false
+	}
+	for i := range currentParts {
+		if latestParts[i] != currentParts[i] {
+			return latestParts[i] > currentParts[i], true
+		}
+	}
+	return false, true
+}
+
+func parseReleaseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	fields := strings.Split(version, ".")
+	if len(fields) != 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
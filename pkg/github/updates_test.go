@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CheckForUpdatesTool(t *testing.T) {
+	tool, _ := CheckForUpdatesTool(stubGetClientFn(github.NewClient(nil)), "v1.0.0", translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "check_for_updates", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	release := &github.RepositoryRelease{
+		TagName:     github.Ptr("v1.2.0"),
+		HTMLURL:     github.Ptr("https://github.com/github/github-mcp-server/releases/tag/v1.2.0"),
+		Body:        github.Ptr("Adds the releases toolset"),
+		PublishedAt: &github.Timestamp{Time: time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		currentVersion string
+		expectError    bool
+		expected       UpdateCheck
+	}{
+		{
+			name:           "newer release available",
+			mockedClient:   mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetReposReleasesLatestByOwnerByRepo, release)),
+			currentVersion: "v1.1.9",
+			expected: UpdateCheck{
+				CurrentVersion:  "v1.1.9",
+				LatestVersion:   "v1.2.0",
+				UpdateAvailable: true,
+				ReleaseURL:      "https://github.com/github/github-mcp-server/releases/tag/v1.2.0",
+				PublishedAt:     "2025-03-04",
+				ReleaseNotes:    "Adds the releases toolset",
+				Message:         "Version v1.2.0 of the GitHub MCP server is available, running v1.1.9. See the release notes for new tools and toolsets.",
+			},
+		},
+		{
+			name:           "up to date",
+			mockedClient:   mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetReposReleasesLatestByOwnerByRepo, release)),
+			currentVersion: "v1.2.0",
+			expected: UpdateCheck{
+				CurrentVersion: "v1.2.0",
+				LatestVersion:  "v1.2.0",
+				Message:        "The GitHub MCP server is up to date, running v1.2.0.",
+			},
+		},
+		{
+			name:           "development build",
+			mockedClient:   mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetReposReleasesLatestByOwnerByRepo, release)),
+			currentVersion: "version",
+			expected: UpdateCheck{
+				CurrentVersion: "version",
+				LatestVersion:  "v1.2.0",
+				Message:        `The running version "version" isn't a release, so it can't be compared with the latest release v1.2.0.`,
+			},
+		},
+		{
+			name: "release lookup fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesLatestByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			currentVersion: "v1.0.0",
+			expectError:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := CheckForUpdatesTool(stubGetClientFn(github.NewClient(tc.mockedClient)), tc.currentVersion, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, "failed to get the latest release")
+				return
+			}
+
+			var returned UpdateCheck
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_isNewerVersion(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		newer, ok       bool
+	}{
+		{"v0.9.0", "v0.10.0", true, true},
+		{"v1.2.3", "v1.2.3", false, true},
+		{"1.3.0", "v1.2.9", false, true},
+		{"v1.2.3-rc.1", "v2.0.0", true, true},
+		{"version", "v1.0.0", false, false},
+		{"v1.0.0", "nightly", false, false},
+	}
+	for _, tc := range tests {
+		newer, ok := isNewerVersion(tc.current, tc.latest)
+		assert.Equal(t, tc.newer, newer, "%s -> %s", tc.current, tc.latest)
+		assert.Equal(t, tc.ok, ok, "%s -> %s", tc.current, tc.latest)
+	}
+}
@@ -2,13 +2,22 @@ package github
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// promptBodyLimit bounds the length of issue and pull request bodies embedded in prompts.
+const promptBodyLimit = 2000
+
 // IssueToFixWorkflowPrompt provides a guided workflow for creating an issue and then generating a PR to fix it
 func IssueToFixWorkflowPrompt(t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
 	return mcp.NewPrompt("IssueToFixWorkflow",
@@ -75,3 +84,358 @@ func IssueToFixWorkflowPrompt(t translations.TranslationHelperFunc) (tool mcp.Pr
 			}, nil
 		}
 }
+
+type promptPullRequestFile struct {
+	Filename  string `json:"filename"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+type promptPullRequest struct {
+	Number    int                     `json:"number"`
+	Title     string                  `json:"title"`
+	Body      string                  `json:"body,omitempty"`
+	Author    string                  `json:"author"`
+	State     string                  `json:"state"`
+	Draft     bool                    `json:"draft,omitempty"`
+	Base      string                  `json:"base"`
+	Head      string                  `json:"head"`
+	Additions int                     `json:"additions"`
+	Deletions int                     `json:"deletions"`
+	Files     []promptPullRequestFile `json:"files"`
+}
+
+type promptIssue struct {
+	Number    int      `json:"number"`
+	Title     string   `json:"title"`
+	Body      string   `json:"body,omitempty"`
+	Author    string   `json:"author"`
+	Labels    []string `json:"labels,omitempty"`
+	Comments  int      `json:"comments"`
+	CreatedAt string   `json:"created_at"`
+}
+
+type promptActivityItem struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	State       string `json:"state"`
+	PullRequest bool   `json:"pull_request,omitempty"`
+	Author      string `json:"author"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type promptCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author"`
+	Date    string `json:"date"`
+}
+
+type promptRelease struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name,omitempty"`
+	PublishedAt string `json:"published_at"`
+}
+
+// ReviewPullRequestPrompt provides a guided review of a pull request, pre-fetching its details and changed files
+func ReviewPullRequestPrompt(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("ReviewPullRequest",
+			mcp.WithPromptDescription(t("PROMPT_REVIEW_PULL_REQUEST_DESCRIPTION", "Review a pull request, with its details and changed files already fetched")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner"), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name"), mcp.RequiredArgument()),
+			mcp.WithArgument("pullNumber", mcp.ArgumentDescription("Pull request number"), mcp.RequiredArgument()),
+		), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+			pullNumber, err := strconv.Atoi(request.Params.Arguments["pullNumber"])
+			if err != nil {
+				return nil, fmt.Errorf("invalid pull request number: %w", err)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, _, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			files, _, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request files: %w", err)
+			}
+
+			details := promptPullRequest{
+				Number:    pr.GetNumber(),
+				Title:     pr.GetTitle(),
+				Body:      truncatePromptText(pr.GetBody()),
+				Author:    pr.GetUser().GetLogin(),
+				State:     pr.GetState(),
+				Draft:     pr.GetDraft(),
+				Base:      pr.GetBase().GetRef(),
+				Head:      pr.GetHead().GetRef(),
+				Additions: pr.GetAdditions(),
+				Deletions: pr.GetDeletions(),
+				Files:     make([]promptPullRequestFile, 0, len(files)),
+			}
+			for _, f := range files {
+				details.Files = append(details.Files, promptPullRequestFile{
+					Filename:  f.GetFilename(),
+					Status:    f.GetStatus(),
+					Additions: f.GetAdditions(),
+					Deletions: f.GetDeletions(),
+				})
+			}
+			detailsJSON, err := json.Marshal(details)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal pull request: %w", err)
+			}
+
+			messages := []mcp.PromptMessage{
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent("You are a thorough code reviewer. Review the pull request for correctness, bugs, security issues, missing tests and readability. Use `get_pull_request_review_bundle` to get the diff, linked issues, CI status and existing review comments in one call, `get_pull_request_file_diff` for the files of diffs too long to be returned whole, and `get_file_contents` when you need more context around a change. Be specific and reference files and lines."),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent(fmt.Sprintf("Please review pull request #%d in %s/%s. Here are its details and changed files:\n\n%s", pullNumber, owner, repo, detailsJSON)),
+				},
+				{
+					Role:    "assistant",
+					Content: mcp.NewTextContent(fmt.Sprintf("I'll review pull request #%d in %s/%s. Let me start by getting its review context.", pullNumber, owner, repo)),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent("Summarize your findings grouped by severity. Before posting anything to the pull request, show me the review and ask for confirmation, then use `create_pending_pull_request_review`, `add_comment_to_pending_review` and `submit_pending_pull_request_review` to post it."),
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		}
+}
+
+// TriageIssuesPrompt provides a guided triage of the newest open issues of a repository, pre-fetching them and the available labels
+func TriageIssuesPrompt(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("TriageNewIssues",
+			mcp.WithPromptDescription(t("PROMPT_TRIAGE_NEW_ISSUES_DESCRIPTION", "Triage the newest open issues in a repository, with the issues and available labels already fetched")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner"), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name"), mcp.RequiredArgument()),
+			mcp.WithArgument("limit", mcp.ArgumentDescription("Number of issues to triage, defaults to 10 (optional)")),
+		), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+			limit, err := promptIntArgument(request, "limit", 10)
+			if err != nil {
+				return nil, err
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			issues, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+				State:       "open",
+				Sort:        "created",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: limit},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list issues: %w", err)
+			}
+			labels, _, err := client.Issues.ListLabels(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list labels: %w", err)
+			}
+
+			triage := make([]promptIssue, 0, len(issues))
+			for _, issue := range issues {
+				// The issues API also returns pull requests
+				if issue.IsPullRequest() {
+					continue
+				}
+				triage = append(triage, promptIssue{
+					Number:    issue.GetNumber(),
+					Title:     issue.GetTitle(),
+					Body:      truncatePromptText(issue.GetBody()),
+					Author:    issue.GetUser().GetLogin(),
+					Labels:    promptLabelNames(issue.Labels),
+					Comments:  issue.GetComments(),
+					CreatedAt: issue.GetCreatedAt().Format(time.RFC3339),
+				})
+			}
+			issuesJSON, err := json.Marshal(triage)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal issues: %w", err)
+			}
+
+			messages := []mcp.PromptMessage{
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent("You are an experienced maintainer triaging incoming issues. For each issue, classify it (bug, feature request, question, ...), suggest labels from the repository's existing labels, estimate its priority, flag likely duplicates with `search_issues`, and note any missing information needed to act on it."),
+				},
+				{
+					Role: "user",
+					Content: mcp.NewTextContent(fmt.Sprintf("Please triage the newest open issues in %s/%s.\n\nAvailable labels: %s\n\nIssues:\n\n%s",
+						owner, repo, strings.Join(promptLabelNames(labels), ", "), issuesJSON)),
+				},
+				{
+					Role:    "assistant",
+					Content: mcp.NewTextContent(fmt.Sprintf("I'll go through the newest open issues in %s/%s and propose a triage for each.", owner, repo)),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent("Present your triage as a table first. Only apply labels with `update_issue` or ask reporters for details with `add_issue_comment` once I have confirmed."),
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		}
+}
+
+// SummarizeRepositoryActivityPrompt provides a summary of the recent activity of a repository, pre-fetching its commits, issues, pull requests and releases
+func SummarizeRepositoryActivityPrompt(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("SummarizeRepositoryActivity",
+			mcp.WithPromptDescription(t("PROMPT_SUMMARIZE_REPOSITORY_ACTIVITY_DESCRIPTION", "Summarize recent activity in a repository, with its commits, issues, pull requests and releases already fetched")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner"), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name"), mcp.RequiredArgument()),
+			mcp.WithArgument("days", mcp.ArgumentDescription("Number of days of activity to summarize, defaults to 7 (optional)")),
+		), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+			days, err := promptIntArgument(request, "days", 7)
+			if err != nil {
+				return nil, err
+			}
+			since := time.Now().AddDate(0, 0, -days)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			commits, _, err := client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{
+				Since:       since,
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commits: %w", err)
+			}
+			issues, _, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+				State:       "all",
+				Since:       since,
+				Sort:        "updated",
+				Direction:   "desc",
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list issues: %w", err)
+			}
+			releases, _, err := client.Repositories.ListReleases(ctx, owner, repo, &github.ListOptions{PerPage: 10})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list releases: %w", err)
+			}
+
+			activity := struct {
+				Commits  []promptCommit       `json:"commits"`
+				Issues   []promptActivityItem `json:"issues_and_pull_requests"`
+				Releases []promptRelease      `json:"releases"`
+			}{
+				Commits:  make([]promptCommit, 0, len(commits)),
+				Issues:   make([]promptActivityItem, 0, len(issues)),
+				Releases: []promptRelease{},
+			}
+			for _, c := range commits {
+				message, _, _ := strings.Cut(c.GetCommit().GetMessage(), "\n")
+				author := c.GetAuthor().GetLogin()
+				if author == "" {
+					author = c.GetCommit().GetAuthor().GetName()
+				}
+				activity.Commits = append(activity.Commits, promptCommit{
+					SHA:     c.GetSHA(),
+					Message: message,
+					Author:  author,
+					Date:    c.GetCommit().GetAuthor().GetDate().Format(time.RFC3339),
+				})
+			}
+			for _, issue := range issues {
+				activity.Issues = append(activity.Issues, promptActivityItem{
+					Number:      issue.GetNumber(),
+					Title:       issue.GetTitle(),
+					State:       issue.GetState(),
+					PullRequest: issue.IsPullRequest(),
+					Author:      issue.GetUser().GetLogin(),
+					UpdatedAt:   issue.GetUpdatedAt().Format(time.RFC3339),
+				})
+			}
+			for _, release := range releases {
+				if release.GetPublishedAt().Before(since) {
+					continue
+				}
+				activity.Releases = append(activity.Releases, promptRelease{
+					TagName:     release.GetTagName(),
+					Name:        release.GetName(),
+					PublishedAt: release.GetPublishedAt().Format(time.RFC3339),
+				})
+			}
+			activityJSON, err := json.Marshal(activity)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal activity: %w", err)
+			}
+
+			messages := []mcp.PromptMessage{
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent("You are a project assistant writing a concise activity digest for a repository. Highlight notable changes, merged and open pull requests, new and closed issues, releases, and anything that looks blocked or needs attention. Use `get_pull_request` or `get_issue` when an item needs more detail."),
+				},
+				{
+					Role:    "user",
+					Content: mcp.NewTextContent(fmt.Sprintf("Please summarize the activity in %s/%s over the last %d days (since %s):\n\n%s", owner, repo, days, since.Format("2006-01-02"), activityJSON)),
+				},
+				{
+					Role:    "assistant",
+					Content: mcp.NewTextContent(fmt.Sprintf("I'll summarize the last %d days of activity in %s/%s.", days, owner, repo)),
+				},
+			}
+			return &mcp.GetPromptResult{
+				Messages: messages,
+			}, nil
+		}
+}
+
+// promptIntArgument parses an optional positive integer prompt argument.
+func promptIntArgument(request mcp.GetPromptRequest, name string, defaultValue int) (int, error) {
+	v, ok := request.Params.Arguments[name]
+	if !ok || v == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", name, v)
+	}
+	return n, nil
+}
+
+func promptLabelNames(labels []*github.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, l := range labels {
+		names = append(names, l.GetName())
+	}
+	return names
+}
+
+func truncatePromptText(s string) string {
+	if len(s) <= promptBodyLimit {
+		return s
+	}
+	// Cut at a rune boundary so that multi-byte characters aren't split into invalid UTF-8
+	end := promptBodyLimit
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end] + "... (truncated)"
+}
@@ -0,0 +1,321 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v74/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPromptRequest(args map[string]string) mcp.GetPromptRequest {
+	request := mcp.GetPromptRequest{}
+	request.Params.Arguments = args
+	return request
+}
+
+func promptText(t *testing.T, result *mcp.GetPromptResult) string {
+	t.Helper()
+	var text string
+	for _, m := range result.Messages {
+		c, ok := m.Content.(mcp.TextContent)
+		require.True(t, ok, "expected text content")
+		text += c.Text + "\n"
+	}
+	return text
+}
+
+func Test_ReviewPullRequestPrompt(t *testing.T) {
+	prompt, _ := ReviewPullRequestPrompt(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	assert.Equal(t, "ReviewPullRequest", prompt.Name)
+	assert.NotEmpty(t, prompt.Description)
+	require.Len(t, prompt.Arguments, 3)
+	for _, arg := range prompt.Arguments {
+		assert.True(t, arg.Required, "argument %s should be required", arg.Name)
+	}
+
+	mockPR := &github.PullRequest{
+		Number: github.Ptr(42),
+		Title:  github.Ptr("Add caching"),
+		Body:   github.Ptr("Caches responses"),
+		State:  github.Ptr("open"),
+		User:   &github.User{Login: github.Ptr("octocat")},
+		Base:   &github.PullRequestBranch{Ref: github.Ptr("main")},
+		Head:   &github.PullRequestBranch{Ref: github.Ptr("feature/cache")},
+	}
+	mockFiles := []*github.CommitFile{
+		{Filename: github.Ptr("cache.go"), Status: github.Ptr("added"), Additions: github.Ptr(120)},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		args           map[string]string
+		expectError    string
+		expectContains []string
+	}{
+		{
+			name: "pre-fetches pull request and files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+				mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, mockFiles),
+			),
+			args: map[string]string{"owner": "owner", "repo": "repo", "pullNumber": "42"},
+			expectContains: []string{
+				"pull request #42 in owner/repo",
+				`"title":"Add caching"`,
+				`"head":"feature/cache"`,
+				`"filename":"cache.go"`,
+				"get_pull_request_review_bundle",
+			},
+		},
+		{
+			name:         "invalid pull request number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			args:         map[string]string{"owner": "owner", "repo": "repo", "pullNumber": "abc"},
+			expectError:  "invalid pull request number",
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			args:        map[string]string{"owner": "owner", "repo": "repo", "pullNumber": "42"},
+			expectError: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ReviewPullRequestPrompt(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), newPromptRequest(tc.args))
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			text := promptText(t, result)
+			for _, s := range tc.expectContains {
+				assert.Contains(t, text, s)
+			}
+		})
+	}
+}
+
+func Test_TriageIssuesPrompt(t *testing.T) {
+	prompt, _ := TriageIssuesPrompt(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	assert.Equal(t, "TriageNewIssues", prompt.Name)
+	assert.NotEmpty(t, prompt.Description)
+	require.Len(t, prompt.Arguments, 3)
+
+	mockIssues := []*github.Issue{
+		{
+			Number:    github.Ptr(7),
+			Title:     github.Ptr("Crash on startup"),
+			User:      &github.User{Login: github.Ptr("reporter")},
+			CreatedAt: &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		},
+		{
+			Number:           github.Ptr(8),
+			Title:            github.Ptr("Fix crash"),
+			PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/8")},
+		},
+	}
+	mockLabels := []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("enhancement")}}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		args           map[string]string
+		expectError    string
+		expectContains []string
+		expectExcludes []string
+	}{
+		{
+			name: "pre-fetches issues and labels, skipping pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "open",
+						"sort":      "created",
+						"direction": "desc",
+						"per_page":  "5",
+					}).andThen(mockResponse(t, http.StatusOK, mockIssues)),
+				),
+				mock.WithRequestMatch(mock.GetReposLabelsByOwnerByRepo, mockLabels),
+			),
+			args: map[string]string{"owner": "owner", "repo": "repo", "limit": "5"},
+			expectContains: []string{
+				"Available labels: bug, enhancement",
+				`"title":"Crash on startup"`,
+				`"created_at":"2025-01-02T03:04:05Z"`,
+				"newest open issues in owner/repo",
+			},
+			expectExcludes: []string{"Fix crash"},
+		},
+		{
+			name:         "invalid limit",
+			mockedClient: mock.NewMockedHTTPClient(),
+			args:         map[string]string{"owner": "owner", "repo": "repo", "limit": "-1"},
+			expectError:  "limit must be a positive integer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := TriageIssuesPrompt(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), newPromptRequest(tc.args))
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			text := promptText(t, result)
+			for _, s := range tc.expectContains {
+				assert.Contains(t, text, s)
+			}
+			for _, s := range tc.expectExcludes {
+				assert.NotContains(t, text, s)
+			}
+		})
+	}
+}
+
+func Test_SummarizeRepositoryActivityPrompt(t *testing.T) {
+	prompt, _ := SummarizeRepositoryActivityPrompt(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	assert.Equal(t, "SummarizeRepositoryActivity", prompt.Name)
+	assert.NotEmpty(t, prompt.Description)
+	require.Len(t, prompt.Arguments, 3)
+
+	now := time.Now()
+	mockCommits := []*github.RepositoryCommit{
+		{
+			SHA: github.Ptr("abc123"),
+			Commit: &github.Commit{
+				Message: github.Ptr("Add caching\n\nLonger description"),
+				Author:  &github.CommitAuthor{Name: github.Ptr("Octo Cat"), Date: &github.Timestamp{Time: now}},
+			},
+		},
+	}
+	mockIssues := []*github.Issue{
+		{Number: github.Ptr(7), Title: github.Ptr("Crash on startup"), State: github.Ptr("closed")},
+	}
+	mockReleases := []*github.RepositoryRelease{
+		{TagName: github.Ptr("v1.1.0"), PublishedAt: &github.Timestamp{Time: now.Add(-time.Hour)}},
+		{TagName: github.Ptr("v1.0.0"), PublishedAt: &github.Timestamp{Time: now.AddDate(0, -1, 0)}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		args           map[string]string
+		expectError    string
+		expectContains []string
+		expectExcludes []string
+	}{
+		{
+			name: "pre-fetches recent activity",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepo, mockCommits),
+				mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepo, mockIssues),
+				mock.WithRequestMatch(mock.GetReposReleasesByOwnerByRepo, mockReleases),
+			),
+			args: map[string]string{"owner": "owner", "repo": "repo"},
+			expectContains: []string{
+				"over the last 7 days",
+				`"message":"Add caching"`,
+				`"author":"Octo Cat"`,
+				`"title":"Crash on startup"`,
+				`"tag_name":"v1.1.0"`,
+			},
+			expectExcludes: []string{"Longer description", "v1.0.0"},
+		},
+		{
+			name: "commits fail to load",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			args:        map[string]string{"owner": "owner", "repo": "repo", "days": "30"},
+			expectError: "failed to list commits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := SummarizeRepositoryActivityPrompt(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), newPromptRequest(tc.args))
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			text := promptText(t, result)
+			for _, s := range tc.expectContains {
+				assert.Contains(t, text, s)
+			}
+			for _, s := range tc.expectExcludes {
+				assert.NotContains(t, text, s)
+			}
+		})
+	}
+}
+
+func Test_TruncatePromptText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "short text is kept",
+			text:     "Fixes the crash",
+			expected: "Fixes the crash",
+		},
+		{
+			name:     "long ASCII text is cut at the limit",
+			text:     strings.Repeat("a", promptBodyLimit+10),
+			expected: strings.Repeat("a", promptBodyLimit) + "... (truncated)",
+		},
+		{
+			// The limit falls in the middle of the three bytes of the last character
+			name:     "multi-byte characters aren't split",
+			text:     strings.Repeat("a", promptBodyLimit-1) + "日本語",
+			expected: strings.Repeat("a", promptBodyLimit-1) + "... (truncated)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			truncated := truncatePromptText(tc.text)
+			assert.Equal(t, tc.expected, truncated)
+			assert.True(t, utf8.ValidString(truncated))
+		})
+	}
+}
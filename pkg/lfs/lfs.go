@@ -0,0 +1,228 @@
+// Package lfs provides a client for the Git LFS batch API, used to read and store the objects of LFS-tracked files
+package lfs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gogithub "github.com/google/go-github/v74/github"
+)
+
+// PointerVersion is the version line of the pointer files committed in place of LFS-tracked files.
+const PointerVersion = "https://git-lfs.github.com/spec/v1"
+
+// MaxPointerSize is the size above which a file can't be a pointer file.
+const MaxPointerSize = 1024
+
+const mediaType = "application/vnd.git-lfs+json"
+
+// GetLFSClientFn is a function type that returns a Client instance.
+type GetLFSClientFn func(context.Context) (*Client, error)
+
+// Pointer identifies an LFS object.
+type Pointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// NewPointer returns the pointer of the object holding content.
+func NewPointer(content []byte) Pointer {
+	sum := sha256.Sum256(content)
+	return Pointer{OID: hex.EncodeToString(sum[:]), Size: int64(len(content))}
+}
+
+// ParsePointer parses the content of a pointer file, reporting false if the content is not a pointer.
+func ParsePointer(content []byte) (Pointer, bool) {
+	if len(content) > MaxPointerSize || !bytes.HasPrefix(content, []byte("version "+PointerVersion)) {
+		return Pointer{}, false
+	}
+
+	var p Pointer
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			return Pointer{}, false
+		}
+		switch key {
+		case "oid":
+			oid, ok := strings.CutPrefix(value, "sha256:")
+			if !ok || len(oid) != sha256.Size*2 {
+				return Pointer{}, false
+			}
+			p.OID = oid
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size < 0 {
+				return Pointer{}, false
+			}
+			p.Size = size
+		}
+	}
+	if p.OID == "" {
+		return Pointer{}, false
+	}
+	return p, true
+}
+
+// String returns the content of the pointer file.
+func (p Pointer) String() string {
+	return fmt.Sprintf("version %s\noid sha256:%s\nsize %d\n", PointerVersion, p.OID, p.Size)
+}
+
+// Client is a client for the Git LFS batch API.
+type Client struct {
+	url      *url.URL
+	client   *gogithub.Client
+	transfer *http.Client
+}
+
+// NewClient creates a new instance of the LFS Client. Requests to the batch API are made with the provided
+// GitHub client against lfsURL, the web URL of the host, while objects are transferred with the transfer client,
+// as the storage they live in authenticates requests with the headers returned by the batch API instead.
+func NewClient(client *gogithub.Client, lfsURL *url.URL, transfer *http.Client) *Client {
+	client = gogithub.NewClient(client.Client())
+	client.BaseURL = lfsURL
+	return &Client{client: client, url: lfsURL, transfer: transfer}
+}
+
+type batchRequest struct {
+	Operation string     `json:"operation"`
+	Transfers []string   `json:"transfers"`
+	Ref       *batchRef  `json:"ref,omitempty"`
+	Objects   []*Pointer `json:"objects"`
+}
+
+type batchRef struct {
+	Name string `json:"name"`
+}
+
+type batchResponse struct {
+	Objects []*batchObject `json:"objects"`
+}
+
+type batchObject struct {
+	Pointer
+	Actions map[string]*action `json:"actions"`
+	Error   *objectError       `json:"error"`
+}
+
+type action struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header"`
+}
+
+type objectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (c *Client) batch(ctx context.Context, owner, repo, operation, ref string, p Pointer) (*batchObject, error) {
+	body := &batchRequest{
+		Operation: operation,
+		Transfers: []string{"basic"},
+		Objects:   []*Pointer{&p},
+	}
+	if ref != "" {
+		body.Ref = &batchRef{Name: ref}
+	}
+
+	req, err := c.client.NewRequest(http.MethodPost, fmt.Sprintf("%s/%s.git/info/lfs/objects/batch", owner, repo), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", mediaType)
+	req.Header.Set("Content-Type", mediaType)
+
+	var resp batchResponse
+	if _, err := c.client.Do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Objects) != 1 {
+		return nil, fmt.Errorf("expected 1 object in LFS batch response, got %d", len(resp.Objects))
+	}
+	object := resp.Objects[0]
+	if object.Error != nil {
+		return nil, fmt.Errorf("LFS object %s: %s (%d)", p.OID, object.Error.Message, object.Error.Code)
+	}
+	return object, nil
+}
+
+func (c *Client) do(ctx context.Context, method string, a *action, body io.Reader, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, a.Href, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for k, v := range a.Header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.transfer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("LFS %s request failed with status %s", strings.ToLower(method), resp.Status)
+	}
+	return resp, nil
+}
+
+// Download fetches the content of the object identified by p. The caller must close the response body.
+func (c *Client) Download(ctx context.Context, owner, repo, ref string, p Pointer) (*http.Response, error) {
+	object, err := c.batch(ctx, owner, repo, "download", ref, p)
+	if err != nil {
+		return nil, err
+	}
+	download, ok := object.Actions["download"]
+	if !ok {
+		return nil, fmt.Errorf("LFS object %s has no download action", p.OID)
+	}
+	return c.do(ctx, http.MethodGet, download, nil, "")
+}
+
+// Upload stores content as an LFS object of the repository, returning the pointer to commit in its place.
+// Objects that are already stored are not uploaded again.
+func (c *Client) Upload(ctx context.Context, owner, repo, ref string, content []byte) (Pointer, error) {
+	p := NewPointer(content)
+	object, err := c.batch(ctx, owner, repo, "upload", ref, p)
+	if err != nil {
+		return Pointer{}, err
+	}
+
+	upload, ok := object.Actions["upload"]
+	if !ok {
+		return p, nil
+	}
+	resp, err := c.do(ctx, http.MethodPut, upload, bytes.NewReader(content), "application/octet-stream")
+	if err != nil {
+		return Pointer{}, err
+	}
+	_ = resp.Body.Close()
+
+	if verify, ok := object.Actions["verify"]; ok {
+		body, err := json.Marshal(p)
+		if err != nil {
+			return Pointer{}, err
+		}
+		resp, err := c.do(ctx, http.MethodPost, verify, bytes.NewReader(body), mediaType)
+		if err != nil {
+			return Pointer{}, err
+		}
+		_ = resp.Body.Close()
+	}
+	return p, nil
+}
@@ -0,0 +1,8 @@
+package lfs
+
+import "github.com/migueleliasweb/go-github-mock/src/mock"
+
+var PostLFSObjectsBatchByOwnerByRepo mock.EndpointPattern = mock.EndpointPattern{
+	Pattern: "/{owner}/{repo}.git/info/lfs/objects/batch",
+	Method:  "POST",
+}
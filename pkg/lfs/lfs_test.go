@@ -0,0 +1,155 @@
+package lfs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePointer(t *testing.T) {
+	oid := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name     string
+		content  string
+		expected Pointer
+		ok       bool
+	}{
+		{
+			name:     "valid pointer",
+			content:  "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 12345\n",
+			expected: Pointer{OID: oid, Size: 12345},
+			ok:       true,
+		},
+		{
+			name:    "regular file",
+			content: "# README\n",
+		},
+		{
+			name:    "invalid oid",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:abc\nsize 1\n",
+		},
+		{
+			name:    "invalid size",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize big\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, ok := ParsePointer([]byte(tc.content))
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, p)
+		})
+	}
+}
+
+func TestPointer_String(t *testing.T) {
+	p := NewPointer([]byte("hello"))
+	parsed, ok := ParsePointer([]byte(p.String()))
+	require.True(t, ok)
+	assert.Equal(t, p, parsed)
+	assert.Equal(t, int64(5), p.Size)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", p.OID)
+}
+
+// newLFSServer serves the batch API of octocat/hello along with its object storage.
+func newLFSServer(t *testing.T, objects map[string][]byte) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/octocat/hello.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, mediaType, r.Header.Get("Accept"))
+
+		var req batchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		p := *req.Objects[0]
+		object := &batchObject{Pointer: p, Actions: map[string]*action{}}
+		header := map[string]string{"Authorization": "RemoteAuth signed"}
+		_, exists := objects[p.OID]
+		switch {
+		case req.Operation == "download" && !exists:
+			object.Error = &objectError{Code: http.StatusNotFound, Message: "Object does not exist"}
+		case req.Operation == "download":
+			object.Actions["download"] = &action{Href: srv.URL + "/objects/" + p.OID, Header: header}
+		case req.Operation == "upload" && !exists:
+			object.Actions["upload"] = &action{Href: srv.URL + "/objects/" + p.OID, Header: header}
+			object.Actions["verify"] = &action{Href: srv.URL + "/verify", Header: header}
+		}
+
+		w.Header().Set("Content-Type", mediaType)
+		_ = json.NewEncoder(w).Encode(&batchResponse{Objects: []*batchObject{object}})
+	})
+	mux.HandleFunc("/objects/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "RemoteAuth signed", r.Header.Get("Authorization"), "object storage must not receive the GitHub token")
+		oid := strings.TrimPrefix(r.URL.Path, "/objects/")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write(objects[oid])
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[oid] = body
+		}
+	})
+	mux.HandleFunc("/verify", func(w http.ResponseWriter, r *http.Request) {
+		var p Pointer
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		if _, ok := objects[p.OID]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server) *Client {
+	t.Helper()
+	lfsURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	return NewClient(github.NewClient(nil).WithAuthToken("token"), lfsURL, http.DefaultClient)
+}
+
+func TestClient_Download(t *testing.T) {
+	content := []byte("large binary content")
+	p := NewPointer(content)
+	srv := newLFSServer(t, map[string][]byte{p.OID: content})
+	client := newTestClient(t, srv)
+
+	resp, err := client.Download(context.Background(), "octocat", "hello", "refs/heads/main", p)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, content, body)
+
+	_, err = client.Download(context.Background(), "octocat", "hello", "", NewPointer([]byte("missing")))
+	require.ErrorContains(t, err, "Object does not exist")
+}
+
+func TestClient_Upload(t *testing.T) {
+	objects := map[string][]byte{}
+	srv := newLFSServer(t, objects)
+	client := newTestClient(t, srv)
+	content := []byte("new binary content")
+
+	p, err := client.Upload(context.Background(), "octocat", "hello", "refs/heads/main", content)
+	require.NoError(t, err)
+	assert.Equal(t, NewPointer(content), p)
+	assert.Equal(t, content, objects[p.OID])
+
+	// Uploading again is a no-op since the object is already stored
+	p, err = client.Upload(context.Background(), "octocat", "hello", "refs/heads/main", content)
+	require.NoError(t, err)
+	assert.Equal(t, NewPointer(content), p)
+}
@@ -1,7 +1,13 @@
 package toolsets
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -29,10 +35,47 @@ func NewToolsetDoesNotExistError(name string) *ToolsetDoesNotExistError {
 	return &ToolsetDoesNotExistError{Name: name}
 }
 
+// StructuredItemsKey holds the results that aren't JSON objects in structured content, which must be an object.
+const StructuredItemsKey = "items"
+
+// NewServerTool creates a server tool. Its calls are validated against the input schema of the tool, and when
+// the tool declares an output schema, its JSON text results are returned as structured content as well.
 func NewServerTool(tool mcp.Tool, handler server.ToolHandlerFunc) server.ServerTool {
+	if tool.RawOutputSchema != nil {
+		handler = withStructuredContent(handler)
+	}
+	if tool.RawInputSchema == nil {
+		handler = withArgumentValidation(tool, handler)
+	}
 	return server.ServerTool{Tool: tool, Handler: handler}
 }
 
+// withStructuredContent copies the JSON text result of a handler into the structured content of the result.
+func withStructuredContent(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if err != nil || result == nil || result.IsError || result.StructuredContent != nil || len(result.Content) != 1 {
+			return result, err
+		}
+		text, ok := result.Content[0].(mcp.TextContent)
+		if !ok {
+			return result, nil
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(text.Text))
+		decoder.UseNumber()
+		var structured any
+		if err := decoder.Decode(&structured); err != nil || decoder.More() {
+			return result, nil
+		}
+		if _, ok := structured.(map[string]any); !ok {
+			structured = map[string]any{StructuredItemsKey: structured}
+		}
+		result.StructuredContent = structured
+		return result, nil
+	}
+}
+
 func NewServerResourceTemplate(resourceTemplate mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc) server.ServerResourceTemplate {
 	return server.ServerResourceTemplate{
 		Template: resourceTemplate,
@@ -51,10 +94,11 @@ func NewServerPrompt(prompt mcp.Prompt, handler server.PromptHandlerFunc) server
 type Toolset struct {
 	Name        string
 	Description string
-	Enabled     bool
-	readOnly    bool
-	writeTools  []server.ServerTool
-	readTools   []server.ServerTool
+	// enabled is read by tool calls while the configuration is reloaded or toolsets are enabled by clients
+	enabled    atomic.Bool
+	readOnly   bool
+	writeTools []server.ServerTool
+	readTools  []server.ServerTool
 	// resources are not tools, but the community seems to be moving towards namespaces as a broader concept
 	// and in order to have multiple servers running concurrently, we want to avoid overlapping resources too.
 	resourceTemplates []server.ServerResourceTemplate
@@ -62,8 +106,18 @@ type Toolset struct {
 	prompts []server.ServerPrompt
 }
 
+// IsEnabled reports whether the toolset is enabled.
+func (t *Toolset) IsEnabled() bool {
+	return t.enabled.Load()
+}
+
+// SetEnabled enables or disables the toolset, reporting whether that changed it.
+func (t *Toolset) SetEnabled(enabled bool) bool {
+	return t.enabled.CompareAndSwap(!enabled, enabled)
+}
+
 func (t *Toolset) GetActiveTools() []server.ServerTool {
-	if t.Enabled {
+	if t.IsEnabled() {
 		if t.readOnly {
 			return t.readTools
 		}
@@ -79,17 +133,26 @@ func (t *Toolset) GetAvailableTools() []server.ServerTool {
 	return append(t.readTools, t.writeTools...)
 }
 
+// GetReadTools returns the read-only tools of the toolset, whether or not it is enabled.
+func (t *Toolset) GetReadTools() []server.ServerTool {
+	return t.readTools
+}
+
+// GetWriteTools returns the tools of the toolset that modify data, including those that are not available
+// because the toolset is read-only.
+func (t *Toolset) GetWriteTools() []server.ServerTool {
+	return t.writeTools
+}
+
+// IsReadOnly reports whether only the read-only tools of the toolset are available.
+func (t *Toolset) IsReadOnly() bool {
+	return t.readOnly
+}
+
+// RegisterTools adds the active tools to the server at once, so that clients are notified of the change once.
 func (t *Toolset) RegisterTools(s *server.MCPServer) {
-	if !t.Enabled {
-		return
-	}
-	for _, tool := range t.readTools {
-		s.AddTool(tool.Tool, tool.Handler)
-	}
-	if !t.readOnly {
-		for _, tool := range t.writeTools {
-			s.AddTool(tool.Tool, tool.Handler)
-		}
+	if tools := t.GetActiveTools(); len(tools) > 0 {
+		s.AddTools(tools...)
 	}
 }
 
@@ -104,7 +167,7 @@ func (t *Toolset) AddPrompts(prompts ...server.ServerPrompt) *Toolset {
 }
 
 func (t *Toolset) GetActiveResourceTemplates() []server.ServerResourceTemplate {
-	if !t.Enabled {
+	if !t.IsEnabled() {
 		return nil
 	}
 	return t.resourceTemplates
@@ -115,7 +178,7 @@ func (t *Toolset) GetAvailableResourceTemplates() []server.ServerResourceTemplat
 }
 
 func (t *Toolset) RegisterResourcesTemplates(s *server.MCPServer) {
-	if !t.Enabled {
+	if !t.IsEnabled() {
 		return
 	}
 	for _, resource := range t.resourceTemplates {
@@ -124,7 +187,7 @@ func (t *Toolset) RegisterResourcesTemplates(s *server.MCPServer) {
 }
 
 func (t *Toolset) RegisterPrompts(s *server.MCPServer) {
-	if !t.Enabled {
+	if !t.IsEnabled() {
 		return
 	}
 	for _, prompt := range t.prompts {
@@ -164,6 +227,16 @@ type ToolsetGroup struct {
 	Toolsets     map[string]*Toolset
 	everythingOn bool
 	readOnly     bool
+
+	// resourceTemplatesDisabled skips registering resource templates, for clients that can't handle them
+	resourceTemplatesDisabled bool
+
+	// activeTools caches the tools of the enabled toolsets, so that they are only regenerated when
+	// toolsets are enabled or disabled. activeToolsKey names the enabled toolsets it was generated for.
+	activeToolsMu     sync.Mutex
+	activeToolsKey    string
+	activeTools       []server.ServerTool
+	activeToolsByName map[string]server.ServerTool
 }
 
 func NewToolsetGroup(readOnly bool) *ToolsetGroup {
@@ -185,7 +258,6 @@ func NewToolset(name string, description string) *Toolset {
 	return &Toolset{
 		Name:        name,
 		Description: description,
-		Enabled:     false,
 		readOnly:    false,
 	}
 }
@@ -200,7 +272,7 @@ func (tg *ToolsetGroup) IsEnabled(name string) bool {
 	if !exists {
 		return false
 	}
-	return feature.Enabled
+	return feature.IsEnabled()
 }
 
 func (tg *ToolsetGroup) EnableToolsets(names []string) error {
@@ -233,19 +305,99 @@ func (tg *ToolsetGroup) EnableToolset(name string) error {
 	if !exists {
 		return NewToolsetDoesNotExistError(name)
 	}
-	toolset.Enabled = true
-	tg.Toolsets[name] = toolset
+	toolset.SetEnabled(true)
 	return nil
 }
 
+// DisableResourceTemplates stops the resource templates of toolsets from being registered with servers.
+func (tg *ToolsetGroup) DisableResourceTemplates() {
+	tg.resourceTemplatesDisabled = true
+}
+
+// RemoveTools removes the named tools from the toolsets of the group, whether or not they are enabled, for tools
+// that can't work in the deployment of the server. It must be called before the tools are registered.
+func (tg *ToolsetGroup) RemoveTools(names ...string) {
+	removed := make(map[string]bool, len(names))
+	for _, name := range names {
+		removed[name] = true
+	}
+	keep := func(tools []server.ServerTool) []server.ServerTool {
+		kept := make([]server.ServerTool, 0, len(tools))
+		for _, tool := range tools {
+			if !removed[tool.Tool.Name] {
+				kept = append(kept, tool)
+			}
+		}
+		return kept
+	}
+	for _, toolset := range tg.Toolsets {
+		toolset.readTools = keep(toolset.readTools)
+		toolset.writeTools = keep(toolset.writeTools)
+	}
+
+	tg.activeToolsMu.Lock()
+	defer tg.activeToolsMu.Unlock()
+	tg.activeToolsByName = nil
+}
+
+// RegisterAll registers the functionality of the enabled toolsets with the server. The tools are added at once,
+// so that clients already connected are notified of the change once.
 func (tg *ToolsetGroup) RegisterAll(s *server.MCPServer) {
+	if tools := tg.ActiveTools(); len(tools) > 0 {
+		s.AddTools(tools...)
+	}
 	for _, toolset := range tg.Toolsets {
-		toolset.RegisterTools(s)
-		toolset.RegisterResourcesTemplates(s)
+		if !tg.resourceTemplatesDisabled {
+			toolset.RegisterResourcesTemplates(s)
+		}
 		toolset.RegisterPrompts(s)
 	}
 }
 
+// ActiveTools returns the tools of the enabled toolsets that are available in the current mode, sorted by name.
+// The list is cached until toolsets are enabled or disabled, and must not be modified.
+func (tg *ToolsetGroup) ActiveTools() []server.ServerTool {
+	tools, _ := tg.activeToolIndex()
+	return tools
+}
+
+// GetActiveTool returns the named tool if it belongs to an enabled toolset and is available in the current mode.
+func (tg *ToolsetGroup) GetActiveTool(name string) (server.ServerTool, bool) {
+	_, byName := tg.activeToolIndex()
+	tool, ok := byName[name]
+	return tool, ok
+}
+
+func (tg *ToolsetGroup) activeToolIndex() ([]server.ServerTool, map[string]server.ServerTool) {
+	// Toolsets can be enabled directly, so the cache is keyed by the enabled toolsets rather than invalidated
+	names := make([]string, 0, len(tg.Toolsets))
+	for name, toolset := range tg.Toolsets {
+		if toolset.IsEnabled() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	key := strings.Join(names, ",")
+
+	tg.activeToolsMu.Lock()
+	defer tg.activeToolsMu.Unlock()
+	if tg.activeToolsByName != nil && tg.activeToolsKey == key {
+		return tg.activeTools, tg.activeToolsByName
+	}
+
+	var tools []server.ServerTool
+	byName := make(map[string]server.ServerTool)
+	for _, name := range names {
+		for _, tool := range tg.Toolsets[name].GetActiveTools() {
+			tools = append(tools, tool)
+			byName[tool.Tool.Name] = tool
+		}
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Tool.Name < tools[j].Tool.Name })
+	tg.activeToolsKey, tg.activeTools, tg.activeToolsByName = key, tools, byName
+	return tools, byName
+}
+
 func (tg *ToolsetGroup) GetToolset(name string) (*Toolset, error) {
 	toolset, exists := tg.Toolsets[name]
 	if !exists {
@@ -253,3 +405,65 @@ func (tg *ToolsetGroup) GetToolset(name string) (*Toolset, error) {
 	}
 	return toolset, nil
 }
+
+// ApplyToolsets changes the enabled toolsets of a running server to exactly the named ones.
+// Tools, resource templates and prompts of newly enabled toolsets are registered, while the tools and
+// prompts of disabled toolsets are removed. Resource templates can't be removed from a running server,
+// so those of disabled toolsets stay registered until it restarts. The tools are diffed against the
+// registered ones, so that clients are notified once when they change and not at all when they don't.
+func (tg *ToolsetGroup) ApplyToolsets(s *server.MCPServer, names []string) error {
+	want := make(map[string]bool, len(names))
+	everythingOn := false
+	for _, name := range names {
+		if name == "all" {
+			everythingOn = true
+			continue
+		}
+		if _, exists := tg.Toolsets[name]; !exists {
+			return NewToolsetDoesNotExistError(name)
+		}
+		want[name] = true
+	}
+
+	_, before := tg.activeToolIndex()
+	var removedPrompts []string
+	for name, toolset := range tg.Toolsets {
+		enable := everythingOn || want[name]
+		switch {
+		case !enable && toolset.SetEnabled(false):
+			for _, prompt := range toolset.prompts {
+				removedPrompts = append(removedPrompts, prompt.Prompt.Name)
+			}
+		case enable && toolset.SetEnabled(true):
+			if !tg.resourceTemplatesDisabled {
+				toolset.RegisterResourcesTemplates(s)
+			}
+			toolset.RegisterPrompts(s)
+		}
+	}
+	tg.everythingOn = everythingOn
+	after, afterByName := tg.activeToolIndex()
+
+	var addedTools []server.ServerTool
+	for _, tool := range after {
+		if _, ok := before[tool.Tool.Name]; !ok {
+			addedTools = append(addedTools, tool)
+		}
+	}
+	var removedTools []string
+	for name := range before {
+		if _, ok := afterByName[name]; !ok {
+			removedTools = append(removedTools, name)
+		}
+	}
+	if len(addedTools) > 0 {
+		s.AddTools(addedTools...)
+	}
+	if len(removedTools) > 0 {
+		s.DeleteTools(removedTools...)
+	}
+	if len(removedPrompts) > 0 {
+		s.DeletePrompts(removedPrompts...)
+	}
+	return nil
+}
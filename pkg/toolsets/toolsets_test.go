@@ -1,8 +1,16 @@
 package toolsets
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"reflect"
+	"sort"
+	"sync"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
@@ -20,7 +28,7 @@ func TestAddToolset(t *testing.T) {
 
 	// Test adding a toolset
 	toolset := NewToolset("test-toolset", "A test toolset")
-	toolset.Enabled = true
+	toolset.SetEnabled(true)
 	tsg.AddToolset(toolset)
 
 	// Verify toolset was added correctly
@@ -41,7 +49,7 @@ func TestAddToolset(t *testing.T) {
 		t.Errorf("Expected toolset description to be 'A test toolset', got '%s'", toolset.Description)
 	}
 
-	if !toolset.Enabled {
+	if !toolset.IsEnabled() {
 		t.Error("Expected toolset to be enabled")
 	}
 
@@ -62,7 +70,7 @@ func TestAddToolset(t *testing.T) {
 		t.Errorf("Expected toolset description to be updated to 'Updated description', got '%s'", toolset.Description)
 	}
 
-	if toolset.Enabled {
+	if toolset.IsEnabled() {
 		t.Error("Expected toolset to be disabled after update")
 	}
 }
@@ -84,7 +92,7 @@ func TestIsEnabled(t *testing.T) {
 
 	// Test with enabled toolset
 	enabledToolset := NewToolset("enabled-toolset", "An enabled toolset")
-	enabledToolset.Enabled = true
+	enabledToolset.SetEnabled(true)
 	tsg.AddToolset(enabledToolset)
 	if !tsg.IsEnabled("enabled-toolset") {
 		t.Error("Expected IsEnabled to return true for enabled toolset")
@@ -250,3 +258,369 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func registeredToolNames(t *testing.T, s *server.MCPServer) []string {
+	t.Helper()
+	response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	rpcResponse, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("expected a successful response, got %#v", response)
+	}
+	result, ok := rpcResponse.Result.(mcp.ListToolsResult)
+	if !ok {
+		t.Fatalf("expected a list tools result, got %#v", rpcResponse.Result)
+	}
+	names := make([]string, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newReadTool(name string) server.ServerTool {
+	readOnly := true
+	return NewServerTool(
+		mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly})),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+}
+
+func TestToolsetGroup_ApplyToolsets(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("first", "first toolset").AddReadTools(newReadTool("first_tool")))
+	tsg.AddToolset(NewToolset("second", "second toolset").AddReadTools(newReadTool("second_tool")))
+
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	if err := tsg.EnableToolsets([]string{"first"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	tsg.RegisterAll(s)
+
+	tests := []struct {
+		names         []string
+		expectedTools []string
+	}{
+		{names: []string{"second"}, expectedTools: []string{"second_tool"}},
+		{names: []string{"first", "second"}, expectedTools: []string{"first_tool", "second_tool"}},
+		{names: []string{}, expectedTools: []string{}},
+		{names: []string{"all"}, expectedTools: []string{"first_tool", "second_tool"}},
+	}
+	for _, tc := range tests {
+		if err := tsg.ApplyToolsets(s, tc.names); err != nil {
+			t.Fatalf("expected no error applying %v, got %v", tc.names, err)
+		}
+		got := registeredToolNames(t, s)
+		if len(got) != len(tc.expectedTools) {
+			t.Fatalf("applying %v: expected tools %v, got %v", tc.names, tc.expectedTools, got)
+		}
+		for i := range got {
+			if got[i] != tc.expectedTools[i] {
+				t.Errorf("applying %v: expected tools %v, got %v", tc.names, tc.expectedTools, got)
+			}
+		}
+	}
+
+	// Unknown toolsets are rejected without changing anything
+	err := tsg.ApplyToolsets(s, []string{"first", "does-not-exist"})
+	if !errors.Is(err, NewToolsetDoesNotExistError("does-not-exist")) {
+		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
+	}
+	if got := registeredToolNames(t, s); len(got) != 2 {
+		t.Errorf("expected tools to be unchanged, got %v", got)
+	}
+}
+
+func TestToolsetGroup_ApplyToolsets_ConcurrentCalls(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("first", "first toolset").AddReadTools(newReadTool("first_tool")))
+	tsg.AddToolset(NewToolset("second", "second toolset").AddReadTools(newReadTool("second_tool")))
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	tsg.RegisterAll(s)
+
+	// Tool calls look up tools while the configuration is reloaded, which the race detector checks
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				tsg.GetActiveTool("first_tool")
+				tsg.Toolsets["second"].IsEnabled()
+			}
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		names := []string{"first"}
+		if i%2 == 0 {
+			names = []string{"second"}
+		}
+		if err := tsg.ApplyToolsets(s, names); err != nil {
+			t.Fatalf("expected no error applying %v, got %v", names, err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestToolsetGroup_DisableResourceTemplates(t *testing.T) {
+	newGroup := func() *ToolsetGroup {
+		tsg := NewToolsetGroup(false)
+		tsg.AddToolset(NewToolset("first", "first toolset").
+			AddReadTools(newReadTool("first_tool")).
+			AddResourceTemplates(server.ServerResourceTemplate{
+				Template: mcp.NewResourceTemplate("first://{id}", "First"),
+				Handler: func(_ context.Context, _ mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+					return nil, nil
+				},
+			}))
+		tsg.AddToolset(NewToolset("second", "second toolset"))
+		if err := tsg.EnableToolsets([]string{"first"}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		return tsg
+	}
+	templateCount := func(s *server.MCPServer) int {
+		response := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"resources/templates/list"}`))
+		rpcResponse, ok := response.(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("expected a successful response, got %#v", response)
+		}
+		return len(rpcResponse.Result.(mcp.ListResourceTemplatesResult).ResourceTemplates)
+	}
+
+	s := server.NewMCPServer("test", "1.0.0", server.WithResourceCapabilities(true, true))
+	newGroup().RegisterAll(s)
+	if got := templateCount(s); got != 1 {
+		t.Errorf("expected 1 resource template, got %d", got)
+	}
+
+	s = server.NewMCPServer("test", "1.0.0", server.WithResourceCapabilities(true, true))
+	tsg := newGroup()
+	tsg.DisableResourceTemplates()
+	tsg.RegisterAll(s)
+	if err := tsg.ApplyToolsets(s, []string{"second"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := tsg.ApplyToolsets(s, []string{"first"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := templateCount(s); got != 0 {
+		t.Errorf("expected no resource templates, got %d", got)
+	}
+	if got := registeredToolNames(t, s); len(got) != 1 {
+		t.Errorf("expected tools to be registered, got %v", got)
+	}
+}
+
+type notificationSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *notificationSession) Initialize()       {}
+func (s *notificationSession) Initialized() bool { return true }
+func (s *notificationSession) SessionID() string { return "test" }
+func (s *notificationSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func (s *notificationSession) toolsListChanged() int {
+	count := 0
+	for {
+		select {
+		case notification := <-s.notifications:
+			if notification.Method == mcp.MethodNotificationToolsListChanged {
+				count++
+			}
+		default:
+			return count
+		}
+	}
+}
+
+func TestToolsetGroup_ApplyToolsets_ListChanged(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("first", "first toolset").AddReadTools(newReadTool("first_tool"), newReadTool("shared_tool")))
+	tsg.AddToolset(NewToolset("second", "second toolset").AddReadTools(newReadTool("second_tool"), newReadTool("other_tool")))
+	tsg.AddToolset(NewToolset("empty", "empty toolset"))
+
+	s := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	session := &notificationSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+	if err := s.RegisterSession(context.Background(), session); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := tsg.EnableToolsets([]string{"first"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	tsg.RegisterAll(s)
+	if got := session.toolsListChanged(); got != 1 {
+		t.Errorf("expected registering to notify once, got %d notifications", got)
+	}
+
+	tests := []struct {
+		names         []string
+		notifications int
+	}{
+		{names: []string{"first"}, notifications: 0},
+		{names: []string{"first", "empty"}, notifications: 0},
+		{names: []string{"second"}, notifications: 2},
+		{names: []string{"first", "second"}, notifications: 1},
+		{names: []string{"all"}, notifications: 0},
+		{names: []string{}, notifications: 1},
+		{names: []string{}, notifications: 0},
+	}
+	for _, tc := range tests {
+		if err := tsg.ApplyToolsets(s, tc.names); err != nil {
+			t.Fatalf("expected no error applying %v, got %v", tc.names, err)
+		}
+		if got := session.toolsListChanged(); got != tc.notifications {
+			t.Errorf("applying %v: expected %d notifications, got %d", tc.names, tc.notifications, got)
+		}
+	}
+}
+
+func TestToolsetGroup_ActiveTools(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("first", "first toolset").AddReadTools(newReadTool("zeta_tool"), newReadTool("alpha_tool")))
+	tsg.AddToolset(NewToolset("second", "second toolset").AddReadTools(newReadTool("beta_tool")))
+	if err := tsg.EnableToolsets([]string{"first"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	names := func(tools []server.ServerTool) []string {
+		names := make([]string, 0, len(tools))
+		for _, tool := range tools {
+			names = append(names, tool.Tool.Name)
+		}
+		return names
+	}
+	tools := tsg.ActiveTools()
+	if got := names(tools); !reflect.DeepEqual(got, []string{"alpha_tool", "zeta_tool"}) {
+		t.Errorf("expected sorted active tools, got %v", got)
+	}
+	if again := tsg.ActiveTools(); &again[0] != &tools[0] {
+		t.Errorf("expected the active tools to be cached")
+	}
+	if _, ok := tsg.GetActiveTool("beta_tool"); ok {
+		t.Errorf("expected tools of disabled toolsets to be inactive")
+	}
+
+	// Enabling a toolset directly regenerates the list
+	tsg.Toolsets["second"].SetEnabled(true)
+	if got := names(tsg.ActiveTools()); !reflect.DeepEqual(got, []string{"alpha_tool", "beta_tool", "zeta_tool"}) {
+		t.Errorf("expected the active tools to include the enabled toolset, got %v", got)
+	}
+	if _, ok := tsg.GetActiveTool("beta_tool"); !ok {
+		t.Errorf("expected tools of enabled toolsets to be active")
+	}
+}
+
+func TestToolset_ReadAndWriteTools(t *testing.T) {
+	writeOnly := false
+	writeTool := NewServerTool(
+		mcp.NewTool("write_tool", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &writeOnly})),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+	tsg := NewToolsetGroup(true)
+	toolset := NewToolset("toolset", "a toolset").AddReadTools(newReadTool("read_tool")).AddWriteTools(writeTool)
+	tsg.AddToolset(toolset)
+
+	if !toolset.IsReadOnly() {
+		t.Fatal("expected the toolset of a read-only group to be read-only")
+	}
+	if len(toolset.GetReadTools()) != 1 || toolset.GetReadTools()[0].Tool.Name != "read_tool" {
+		t.Errorf("expected the read tool, got %v", toolset.GetReadTools())
+	}
+	// Write tools are kept so that they can be described, but they aren't available
+	if len(toolset.GetWriteTools()) != 1 || toolset.GetWriteTools()[0].Tool.Name != "write_tool" {
+		t.Errorf("expected the write tool, got %v", toolset.GetWriteTools())
+	}
+	if len(toolset.GetAvailableTools()) != 1 {
+		t.Errorf("expected only the read tool to be available, got %v", toolset.GetAvailableTools())
+	}
+}
+
+func TestToolsetGroup_RemoveTools(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("toolset", "a toolset").AddReadTools(newReadTool("read_tool"), newReadTool("other_tool")))
+	if err := tsg.EnableToolsets([]string{"toolset"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(tsg.ActiveTools()) != 2 {
+		t.Fatalf("expected 2 active tools, got %d", len(tsg.ActiveTools()))
+	}
+
+	tsg.RemoveTools("other_tool", "missing_tool")
+
+	tools := tsg.ActiveTools()
+	if len(tools) != 1 || tools[0].Tool.Name != "read_tool" {
+		t.Errorf("expected only read_tool to be active, got %v", tools)
+	}
+	if _, ok := tsg.GetActiveTool("other_tool"); ok {
+		t.Error("expected the removed tool not to be found")
+	}
+}
+
+func TestNewServerTool_StructuredContent(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     *mcp.CallToolResult
+		structured any
+	}{
+		{
+			name:       "object",
+			result:     mcp.NewToolResultText(`{"number":42,"title":"Bug"}`),
+			structured: map[string]any{"number": json.Number("42"), "title": "Bug"},
+		},
+		{
+			name:       "array",
+			result:     mcp.NewToolResultText(`[{"number":42}]`),
+			structured: map[string]any{StructuredItemsKey: []any{map[string]any{"number": json.Number("42")}}},
+		},
+		{
+			name:   "plain text",
+			result: mcp.NewToolResultText("successfully starred repository"),
+		},
+		{
+			name:   "error",
+			result: mcp.NewToolResultError(`{"message":"Not Found"}`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := mcp.NewTool("test_tool", mcp.WithRawOutputSchema(json.RawMessage(`{"type":"object"}`)))
+			serverTool := NewServerTool(tool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tc.result, nil
+			})
+
+			result, err := serverTool.Handler(context.Background(), mcp.CallToolRequest{})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(result.StructuredContent, tc.structured) {
+				t.Errorf("expected structured content %#v, got %#v", tc.structured, result.StructuredContent)
+			}
+		})
+	}
+
+	// Tools without an output schema are left unchanged
+	serverTool := NewServerTool(mcp.NewTool("test_tool"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(`{"number":42}`), nil
+	})
+	result, err := serverTool.Handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.StructuredContent != nil {
+		t.Errorf("expected no structured content, got %#v", result.StructuredContent)
+	}
+}
@@ -0,0 +1,176 @@
+package toolsets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// withArgumentValidation checks the arguments of calls against the input schema of the tool before calling the
+// handler, so that malformed calls fail with a precise error instead of a request to GitHub.
+func withArgumentValidation(tool mcp.Tool, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if err := ValidateArguments(tool.InputSchema, request.GetArguments()); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return handler(ctx, request)
+	}
+}
+
+// ValidateArguments checks that the arguments have the required parameters, and that the parameters have the
+// types, values and ranges their schema allows. Null arguments are treated as absent.
+func ValidateArguments(schema mcp.ToolInputSchema, args map[string]any) error {
+	for _, name := range schema.Required {
+		if value, ok := args[name]; !ok || value == nil {
+			return fmt.Errorf("missing required parameter: %s", name)
+		}
+	}
+
+	// Check the arguments in order, so that the same call always reports the same error
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := schema.Properties[name].(map[string]any)
+		if !ok || args[name] == nil {
+			continue
+		}
+		if err := validateValue(name, property, args[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateValue checks a value against the schema of a parameter, or of the items of an array parameter.
+func validateValue(name string, schema map[string]any, value any) error {
+	schemaType, _ := schema["type"].(string)
+	switch schemaType {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return typeError(name, schemaType, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return typeError(name, schemaType, value)
+		}
+	case "number", "integer":
+		number, ok := toFloat(value)
+		if !ok {
+			return typeError(name, schemaType, value)
+		}
+		if schemaType == "integer" && number != math.Trunc(number) {
+			return fmt.Errorf("parameter %s must be an integer, got %v", name, number)
+		}
+		if minimum, ok := toFloat(schema["minimum"]); ok && number < minimum {
+			return fmt.Errorf("parameter %s must be at least %v, got %v", name, minimum, number)
+		}
+		if maximum, ok := toFloat(schema["maximum"]); ok && number > maximum {
+			return fmt.Errorf("parameter %s must be at most %v, got %v", name, maximum, number)
+		}
+	case "array":
+		var items []any
+		switch v := value.(type) {
+		case []any:
+			items = v
+		case []string:
+			for _, item := range v {
+				items = append(items, item)
+			}
+		default:
+			return typeError(name, schemaType, value)
+		}
+		if minItems, ok := toFloat(schema["minItems"]); ok && float64(len(items)) < minItems {
+			return fmt.Errorf("parameter %s must have at least %v items, got %d", name, minItems, len(items))
+		}
+		if maxItems, ok := toFloat(schema["maxItems"]); ok && float64(len(items)) > maxItems {
+			return fmt.Errorf("parameter %s must have at most %v items, got %d", name, maxItems, len(items))
+		}
+		if itemSchema, ok := schema["items"].(map[string]any); ok {
+			for i, item := range items {
+				if err := validateValue(fmt.Sprintf("%s[%d]", name, i), itemSchema, item); err != nil {
+					return err
+				}
+			}
+		}
+	case "object":
+		if _, ok := value.(map[string]any); !ok {
+			return typeError(name, schemaType, value)
+		}
+	}
+
+	if enum := enumValues(schema["enum"]); len(enum) > 0 && schemaType != "array" {
+		for _, allowed := range enum {
+			if fmt.Sprint(value) == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("parameter %s must be one of %s, got %v", name, strings.Join(enum, ", "), value)
+	}
+	return nil
+}
+
+// typeError describes a value that doesn't have the type of its parameter, in JSON terms.
+func typeError(name, schemaType string, value any) error {
+	return fmt.Errorf("parameter %s must be of type %s, got %s", name, schemaType, jsonType(value))
+}
+
+// jsonType names the JSON type of a decoded value.
+func jsonType(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, json.Number:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// toFloat converts a number from a decoded argument or schema to a float.
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// enumValues returns the allowed values of a schema as strings.
+func enumValues(enum any) []string {
+	switch values := enum.(type) {
+	case []string:
+		return values
+	case []any:
+		strs := make([]string, 0, len(values))
+		for _, value := range values {
+			strs = append(strs, fmt.Sprint(value))
+		}
+		return strs
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+package toolsets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestValidateArguments(t *testing.T) {
+	tool := mcp.NewTool("test_tool",
+		mcp.WithString("owner", mcp.Required()),
+		mcp.WithString("state", mcp.Enum("open", "closed")),
+		mcp.WithNumber("perPage", mcp.Min(1), mcp.Max(100)),
+		mcp.WithBoolean("draft"),
+		mcp.WithArray("labels", mcp.WithStringItems()),
+		mcp.WithObject("inputs"),
+	)
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expectedErr string
+	}{
+		{
+			name: "valid arguments",
+			args: map[string]any{
+				"owner":   "octocat",
+				"state":   "open",
+				"perPage": float64(100),
+				"draft":   true,
+				"labels":  []any{"bug"},
+				"inputs":  map[string]any{"key": "value"},
+			},
+		},
+		{
+			name: "null optional arguments are ignored",
+			args: map[string]any{"owner": "octocat", "state": nil},
+		},
+		{
+			name: "unknown arguments are ignored",
+			args: map[string]any{"owner": "octocat", "other": 1},
+		},
+		{
+			name:        "missing required parameter",
+			args:        map[string]any{"state": "open"},
+			expectedErr: "missing required parameter: owner",
+		},
+		{
+			name:        "null required parameter",
+			args:        map[string]any{"owner": nil},
+			expectedErr: "missing required parameter: owner",
+		},
+		{
+			name:        "wrong type",
+			args:        map[string]any{"owner": float64(1)},
+			expectedErr: "parameter owner must be of type string, got number",
+		},
+		{
+			name:        "value not in enum",
+			args:        map[string]any{"owner": "octocat", "state": "merged"},
+			expectedErr: "parameter state must be one of open, closed, got merged",
+		},
+		{
+			name:        "number below minimum",
+			args:        map[string]any{"owner": "octocat", "perPage": float64(0)},
+			expectedErr: "parameter perPage must be at least 1, got 0",
+		},
+		{
+			name:        "number above maximum",
+			args:        map[string]any{"owner": "octocat", "perPage": float64(101)},
+			expectedErr: "parameter perPage must be at most 100, got 101",
+		},
+		{
+			name:        "number given as a string",
+			args:        map[string]any{"owner": "octocat", "perPage": "10"},
+			expectedErr: "parameter perPage must be of type number, got string",
+		},
+		{
+			name:        "wrong type of array item",
+			args:        map[string]any{"owner": "octocat", "labels": []any{"bug", true}},
+			expectedErr: "parameter labels[1] must be of type string, got boolean",
+		},
+		{
+			name:        "wrong type of boolean",
+			args:        map[string]any{"owner": "octocat", "draft": "yes"},
+			expectedErr: "parameter draft must be of type boolean, got string",
+		},
+		{
+			name:        "wrong type of object",
+			args:        map[string]any{"owner": "octocat", "inputs": []any{}},
+			expectedErr: "parameter inputs must be of type object, got array",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateArguments(tool.InputSchema, tc.args)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestNewServerTool_ValidatesArguments(t *testing.T) {
+	called := false
+	serverTool := NewServerTool(
+		mcp.NewTool("test_tool", mcp.WithString("owner", mcp.Required())),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			called = true
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{}
+	result, err := serverTool.Handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !result.IsError || called {
+		t.Fatal("expected an error result without calling the handler")
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; text != "missing required parameter: owner" {
+		t.Errorf("expected the validation error, got %q", text)
+	}
+
+	request.Params.Arguments = map[string]any{"owner": "octocat"}
+	result, err = serverTool.Handler(context.Background(), request)
+	if err != nil || result.IsError || !called {
+		t.Fatalf("expected the handler to be called, got %v, %v", result, err)
+	}
+}
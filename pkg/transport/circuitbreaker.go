@@ -0,0 +1,151 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCircuitBreakerThreshold is the number of consecutive failures to a host that open its circuit.
+	DefaultCircuitBreakerThreshold = 5
+
+	// DefaultCircuitBreakerCooldown is how long an open circuit fails requests before letting one through again.
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+)
+
+// ErrCircuitOpen is wrapped by the errors returned for requests failed fast by an open circuit.
+var ErrCircuitOpen = errors.New("GitHub appears unavailable")
+
+// CircuitOpenError is returned for requests to a host whose circuit is open.
+type CircuitOpenError struct {
+	Host       string
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %s failed repeatedly with server errors or timeouts, retry after %s",
+		ErrCircuitOpen, e.Host, e.RetryAfter.Round(time.Second))
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// CircuitStateFunc is called when the circuit of a host opens or closes.
+type CircuitStateFunc func(host string, open bool)
+
+// CircuitBreakerTransport stops sending requests to a host after consecutive server errors or timeouts, failing
+// them fast with a CircuitOpenError instead. Once the cooldown has elapsed a single request is let through to
+// probe the host: the circuit closes when it succeeds and opens again when it fails.
+type CircuitBreakerTransport struct {
+	transport http.RoundTripper
+	threshold int
+	cooldown  time.Duration
+	onChange  CircuitStateFunc
+
+	// now is overridable for tests.
+	now func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuit is the state of the requests to a single host.
+type circuit struct {
+	failures int
+	openedAt time.Time
+	open     bool
+	probing  bool
+}
+
+// NewCircuitBreakerTransport creates a CircuitBreakerTransport wrapping the provided transport. A threshold of zero
+// or less disables the circuit breaker, and onChange may be nil.
+func NewCircuitBreakerTransport(transport http.RoundTripper, threshold int, cooldown time.Duration, onChange CircuitStateFunc) *CircuitBreakerTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &CircuitBreakerTransport{
+		transport: transport,
+		threshold: threshold,
+		cooldown:  cooldown,
+		onChange:  onChange,
+		now:       time.Now,
+		circuits:  make(map[string]*circuit),
+	}
+}
+
+func (t *CircuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.threshold <= 0 {
+		return t.transport.RoundTrip(req)
+	}
+
+	host := req.URL.Host
+	probe, err := t.allow(host)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	t.record(host, probe, isServerFailure(resp, err))
+	return resp, err
+}
+
+// allow reports whether a request to host may be sent, and whether it probes a circuit whose cooldown elapsed.
+func (t *CircuitBreakerTransport) allow(host string) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c, ok := t.circuits[host]
+	if !ok || !c.open {
+		return false, nil
+	}
+	if remaining := c.openedAt.Add(t.cooldown).Sub(t.now()); remaining > 0 || c.probing {
+		return false, &CircuitOpenError{Host: host, RetryAfter: max(remaining, time.Second)}
+	}
+	c.probing = true
+	return true, nil
+}
+
+// record updates the circuit of host with the outcome of a request.
+func (t *CircuitBreakerTransport) record(host string, probe, failed bool) {
+	t.mu.Lock()
+	c, ok := t.circuits[host]
+	if !ok {
+		c = &circuit{}
+		t.circuits[host] = c
+	}
+
+	var changed, open bool
+	switch {
+	case !failed:
+		changed = c.open
+		*c = circuit{}
+	case probe || !c.open:
+		c.failures++
+		c.probing = false
+		if probe || c.failures >= t.threshold {
+			changed = !c.open
+			c.open = true
+			c.openedAt = t.now()
+		}
+	}
+	open = c.open
+	t.mu.Unlock()
+
+	if changed && t.onChange != nil {
+		t.onChange(host, open)
+	}
+}
+
+// isServerFailure reports whether a request failed in a way suggesting that GitHub is unavailable. Requests
+// cancelled by the caller don't say anything about the host.
+func isServerFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
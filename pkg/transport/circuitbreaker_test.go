@@ -0,0 +1,110 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CircuitBreakerTransport(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	var changes []bool
+	now := time.Now()
+	cb := NewCircuitBreakerTransport(http.DefaultTransport, 3, 30*time.Second, func(_ string, open bool) {
+		changes = append(changes, open)
+	})
+	cb.now = func() time.Time { return now }
+	client := &http.Client{Transport: cb}
+
+	get := func() (*http.Response, error) {
+		resp, err := client.Get(srv.URL)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	// Failures below the threshold reach the server
+	for i := 0; i < 3; i++ {
+		resp, err := get()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, []bool{true}, changes)
+
+	// The open circuit fails fast
+	now = now.Add(10 * time.Second)
+	_, err := get()
+	var openErr *CircuitOpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 20*time.Second, openErr.RetryAfter)
+	assert.Contains(t, err.Error(), "GitHub appears unavailable")
+	assert.Contains(t, err.Error(), "retry after 20s")
+	assert.Equal(t, int32(3), calls.Load())
+
+	// A failing probe opens the circuit for another cooldown
+	now = now.Add(25 * time.Second)
+	resp, err := get()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(4), calls.Load())
+	_, err = get()
+	require.ErrorIs(t, err, ErrCircuitOpen)
+
+	// A succeeding probe closes it
+	status.Store(http.StatusOK)
+	now = now.Add(31 * time.Second)
+	for i := 0; i < 2; i++ {
+		resp, err = get()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, int32(6), calls.Load())
+	assert.Equal(t, []bool{true, false}, changes)
+}
+
+func Test_CircuitBreakerTransport_PerHost(t *testing.T) {
+	failing := &failingTransport{err: errors.New("i/o timeout")}
+	cb := NewCircuitBreakerTransport(failing, 2, time.Minute, nil)
+
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+		require.NoError(t, err)
+		_, err = cb.RoundTrip(req)
+		require.Error(t, err)
+	}
+	assert.Equal(t, 2, failing.calls)
+
+	// Other hosts aren't affected
+	req, err := http.NewRequest(http.MethodGet, "https://raw.githubusercontent.com/octocat/hello/main/README.md", nil)
+	require.NoError(t, err)
+	_, err = cb.RoundTrip(req)
+	require.ErrorContains(t, err, "i/o timeout")
+	assert.Equal(t, 3, failing.calls)
+
+	// A disabled circuit breaker never opens
+	failing = &failingTransport{err: errors.New("i/o timeout")}
+	cb = NewCircuitBreakerTransport(failing, 0, time.Minute, nil)
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+		require.NoError(t, err)
+		_, _ = cb.RoundTrip(req)
+	}
+	assert.Equal(t, 3, failing.calls)
+}
@@ -0,0 +1,108 @@
+package transport
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptedEncodings are the content encodings requested from GitHub.
+const acceptedEncodings = "gzip, deflate"
+
+// CompressionTransport asks GitHub for compressed responses and transparently decompresses them, so that large
+// diffs, logs and file contents aren't transferred uncompressed. Go's transport only negotiates gzip on its own,
+// and not at all for requests that set their own Accept-Encoding header.
+//
+// Responses to MCP clients aren't compressed: the server only talks to them over stdio, which has no content
+// encoding to negotiate and no network to save bandwidth on. An HTTP transport would need to compress its responses
+// on its own.
+type CompressionTransport struct {
+	transport http.RoundTripper
+}
+
+// NewCompressionTransport creates a CompressionTransport wrapping the provided transport.
+func NewCompressionTransport(transport http.RoundTripper) *CompressionTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &CompressionTransport{transport: transport}
+}
+
+func (t *CompressionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Leave requests that negotiate their own encoding alone, as well as range requests for which
+	// compression would apply to the range rather than the whole content.
+	if req.Header.Get("Accept-Encoding") != "" || req.Header.Get("Range") != "" || req.Method == http.MethodHead {
+		return t.transport.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", acceptedEncodings)
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	var body io.ReadCloser
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		body = &lazyDecompressor{compressed: resp.Body, open: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		}}
+	case "deflate":
+		body = &lazyDecompressor{compressed: resp.Body, open: openDeflate}
+	default:
+		return resp, nil
+	}
+
+	resp.Body = body
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// openDeflate decompresses an HTTP deflate body, which is zlib-wrapped (RFC 9110 section 8.4.1.2). Some servers
+// send raw deflate data instead, which is decompressed as such when the zlib header is missing.
+func openDeflate(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	header, err := buffered.Peek(2)
+	if err != nil && len(header) < 2 {
+		// Too short for a zlib stream, let flate report what's wrong with it
+		return flate.NewReader(buffered), nil
+	}
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+	return flate.NewReader(buffered), nil
+}
+
+// lazyDecompressor decompresses a body on the first read, so that closing an unread body doesn't fail on
+// an empty or truncated stream.
+type lazyDecompressor struct {
+	compressed io.ReadCloser
+	open       func(io.Reader) (io.ReadCloser, error)
+	reader     io.ReadCloser
+	err        error
+}
+
+func (d *lazyDecompressor) Read(p []byte) (int, error) {
+	if d.reader == nil && d.err == nil {
+		d.reader, d.err = d.open(d.compressed)
+	}
+	if d.err != nil {
+		return 0, d.err
+	}
+	return d.reader.Read(p)
+}
+
+func (d *lazyDecompressor) Close() error {
+	if d.reader != nil {
+		_ = d.reader.Close()
+	}
+	return d.compressed.Close()
+}
@@ -0,0 +1,76 @@
+package transport
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CompressionTransport(t *testing.T) {
+	content := strings.Repeat("diff --git a/file b/file\n", 100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Accept-Encoding", r.Header.Get("Accept-Encoding"))
+		var buf bytes.Buffer
+		encoding := r.URL.Query().Get("encoding")
+		switch encoding {
+		case "gzip":
+			zw := gzip.NewWriter(&buf)
+			_, _ = zw.Write([]byte(content))
+			_ = zw.Close()
+		case "deflate":
+			zw := zlib.NewWriter(&buf)
+			_, _ = zw.Write([]byte(content))
+			_ = zw.Close()
+		case "raw-deflate":
+			// Sent by servers that don't wrap deflate data in zlib
+			zw, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+			_, _ = zw.Write([]byte(content))
+			_ = zw.Close()
+			encoding = "deflate"
+		default:
+			_, _ = w.Write([]byte(content))
+			return
+		}
+		w.Header().Set("Content-Encoding", encoding)
+		_, _ = w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewCompressionTransport(http.DefaultTransport)}
+	for _, encoding := range []string{"gzip", "deflate", "raw-deflate", "identity"} {
+		t.Run(encoding, func(t *testing.T) {
+			resp, err := client.Get(srv.URL + "?encoding=" + encoding)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, content, string(body))
+			assert.Equal(t, "gzip, deflate", resp.Header.Get("X-Accept-Encoding"))
+			assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		})
+	}
+
+	// Requests negotiating their own encoding are left alone
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"?encoding=gzip", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+
+	// Unread bodies can be closed
+	resp, err = client.Get(srv.URL + "?encoding=gzip")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
@@ -0,0 +1,131 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrQueueTimeout is returned when a request waited longer than the queue timeout for a free slot.
+var ErrQueueTimeout = errors.New("timed out waiting for a free GitHub request slot")
+
+// ConcurrencyLimitTransport bounds the number of requests in flight, both across the
+// whole server and per destination host. Requests over the limit are queued until a
+// slot frees up, the request context is cancelled, or the queue timeout expires.
+// A request holds its slot until its response body is closed.
+type ConcurrencyLimitTransport struct {
+	transport    http.RoundTripper
+	global       chan struct{}
+	perHostLimit int
+	queueTimeout time.Duration
+
+	mu      sync.Mutex
+	perHost map[string]chan struct{}
+}
+
+// NewConcurrencyLimitTransport creates a ConcurrencyLimitTransport wrapping the provided transport.
+// A limit of zero or less disables that limit, and a queue timeout of zero or less lets
+// queued requests wait for as long as their context allows.
+func NewConcurrencyLimitTransport(transport http.RoundTripper, maxConcurrent, maxConcurrentPerHost int, queueTimeout time.Duration) *ConcurrencyLimitTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	t := &ConcurrencyLimitTransport{
+		transport:    transport,
+		perHostLimit: maxConcurrentPerHost,
+		queueTimeout: queueTimeout,
+		perHost:      make(map[string]chan struct{}),
+	}
+	if maxConcurrent > 0 {
+		t.global = make(chan struct{}, maxConcurrent)
+	}
+	return t
+}
+
+func (t *ConcurrencyLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.queueTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.queueTimeout)
+		defer cancel()
+	}
+
+	release, err := t.acquire(ctx, req.URL.Host)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil {
+			return nil, fmt.Errorf("%w after %s", ErrQueueTimeout, t.queueTimeout)
+		}
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// acquire takes a global slot and a per host slot, returning a function that gives both back.
+func (t *ConcurrencyLimitTransport) acquire(ctx context.Context, host string) (func(), error) {
+	var sems []chan struct{}
+	if t.global != nil {
+		sems = append(sems, t.global)
+	}
+	if hostSem := t.hostSemaphore(host); hostSem != nil {
+		sems = append(sems, hostSem)
+	}
+
+	release := func(acquired []chan struct{}) func() {
+		var once sync.Once
+		return func() {
+			once.Do(func() {
+				for i := len(acquired) - 1; i >= 0; i-- {
+					<-acquired[i]
+				}
+			})
+		}
+	}
+
+	for i, sem := range sems {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			release(sems[:i])()
+			return nil, ctx.Err()
+		}
+	}
+	return release(sems), nil
+}
+
+func (t *ConcurrencyLimitTransport) hostSemaphore(host string) chan struct{} {
+	if t.perHostLimit <= 0 {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sem, ok := t.perHost[host]
+	if !ok {
+		sem = make(chan struct{}, t.perHostLimit)
+		t.perHost[host] = sem
+	}
+	return sem
+}
+
+// releasingBody frees the concurrency slot of a request once its body has been closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
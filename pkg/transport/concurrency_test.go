@@ -0,0 +1,185 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingServer returns a server whose handlers block until release is closed,
+// tracking the highest number of requests it served at once.
+func blockingServer(release <-chan struct{}, maxSeen *atomic.Int32) *httptest.Server {
+	var inFlight atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxSeen.Load()
+			if n <= seen || maxSeen.CompareAndSwap(seen, n) {
+				break
+			}
+		}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func Test_ConcurrencyLimitTransport(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxConcurrent   int
+		maxPerHost      int
+		requests        int
+		expectedMaxSeen int32
+	}{
+		{
+			name:            "global limit bounds requests in flight",
+			maxConcurrent:   2,
+			requests:        6,
+			expectedMaxSeen: 2,
+		},
+		{
+			name:            "per host limit bounds requests in flight",
+			maxPerHost:      3,
+			requests:        6,
+			expectedMaxSeen: 3,
+		},
+		{
+			name:            "lowest limit wins",
+			maxConcurrent:   4,
+			maxPerHost:      1,
+			requests:        4,
+			expectedMaxSeen: 1,
+		},
+		{
+			name:            "no limits",
+			requests:        4,
+			expectedMaxSeen: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			release := make(chan struct{})
+			var maxSeen atomic.Int32
+			srv := blockingServer(release, &maxSeen)
+			defer srv.Close()
+
+			rt := NewConcurrencyLimitTransport(http.DefaultTransport, tc.maxConcurrent, tc.maxPerHost, 0)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, tc.requests)
+			for range tc.requests {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+					if err != nil {
+						errs <- err
+						return
+					}
+					resp, err := rt.RoundTrip(req)
+					if err != nil {
+						errs <- err
+						return
+					}
+					_ = resp.Body.Close()
+				}()
+			}
+
+			// Give every request the chance to reach the server before unblocking them.
+			assert.Eventually(t, func() bool { return maxSeen.Load() >= tc.expectedMaxSeen }, time.Second, 10*time.Millisecond)
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedMaxSeen, maxSeen.Load())
+		})
+	}
+}
+
+func Test_ConcurrencyLimitTransport_QueueTimeout(t *testing.T) {
+	release := make(chan struct{})
+	var maxSeen atomic.Int32
+	srv := blockingServer(release, &maxSeen)
+	defer srv.Close()
+	defer close(release)
+
+	rt := NewConcurrencyLimitTransport(http.DefaultTransport, 1, 0, 50*time.Millisecond)
+
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		if resp, err := rt.RoundTrip(req); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	require.Eventually(t, func() bool { return maxSeen.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.ErrorIs(t, err, ErrQueueTimeout)
+}
+
+func Test_ConcurrencyLimitTransport_ContextCancelled(t *testing.T) {
+	release := make(chan struct{})
+	var maxSeen atomic.Int32
+	srv := blockingServer(release, &maxSeen)
+	defer srv.Close()
+	defer close(release)
+
+	rt := NewConcurrencyLimitTransport(http.DefaultTransport, 1, 0, time.Minute)
+
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		if resp, err := rt.RoundTrip(req); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	require.Eventually(t, func() bool { return maxSeen.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrQueueTimeout)
+}
+
+func Test_ConcurrencyLimitTransport_ReleasesOnBodyClose(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	rt := NewConcurrencyLimitTransport(http.DefaultTransport, 1, 0, 50*time.Millisecond)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+
+	// The slot is held until the body is closed.
+	_, err = rt.RoundTrip(req.Clone(context.Background()))
+	require.ErrorIs(t, err, ErrQueueTimeout)
+
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, resp.Body.Close(), "closing twice must not release the slot twice")
+
+	resp, err = rt.RoundTrip(req.Clone(context.Background()))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Empty(t, rt.global, "all slots should be free once bodies are closed")
+}
@@ -0,0 +1,254 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// InstallationTokenRefreshMargin is how long before they expire installation tokens are replaced. GitHub issues
+	// them for an hour, so a token is used for about 55 minutes.
+	InstallationTokenRefreshMargin = 5 * time.Minute
+
+	// appJWTLifetime is how long the JWTs authenticating as the GitHub App are valid, within GitHub's limit of ten
+	// minutes. They are issued a minute in the past to allow for clock drift.
+	appJWTLifetime = 9 * time.Minute
+
+	// installationTokenRetryInterval is how long to wait before refreshing a token again after a failed refresh.
+	installationTokenRetryInterval = 30 * time.Second
+)
+
+// installationToken is the cached token of an installation. Its lock is held while it is refreshed, so that a
+// burst of requests waits for a single exchange instead of each making one.
+type installationToken struct {
+	mu          sync.Mutex
+	token       string
+	expiresAt   time.Time
+	permissions map[string]string
+}
+
+// InstallationTokenSource exchanges the private key of a GitHub App for installation tokens, caching them per
+// installation until shortly before they expire.
+type InstallationTokenSource struct {
+	appID   int64
+	key     *rsa.PrivateKey
+	baseURL *url.URL
+	client  *http.Client
+	now     func() time.Time
+
+	mu     sync.Mutex
+	tokens map[int64]*installationToken
+}
+
+// NewInstallationTokenSource creates an InstallationTokenSource for the GitHub App with the ID and PEM encoded
+// private key, exchanging tokens with the REST API at baseURL over the provided transport.
+func NewInstallationTokenSource(appID int64, privateKeyPEM []byte, baseURL *url.URL, transport http.RoundTripper) (*InstallationTokenSource, error) {
+	key, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &InstallationTokenSource{
+		appID:   appID,
+		key:     key,
+		baseURL: baseURL,
+		client:  &http.Client{Transport: transport},
+		now:     time.Now,
+		tokens:  make(map[int64]*installationToken),
+	}, nil
+}
+
+// parseRSAPrivateKey parses a PEM encoded RSA key, in the PKCS #1 format GitHub generates keys in or in PKCS #8.
+func parseRSAPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("failed to decode GitHub App private key: not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("failed to parse GitHub App private key: not an RSA key")
+	}
+	return key, nil
+}
+
+// Token returns a token for the installation, exchanging a new one when there is none cached or the cached one
+// expires within InstallationTokenRefreshMargin.
+func (s *InstallationTokenSource) Token(ctx context.Context, installationID int64) (string, error) {
+	cached := s.cached(installationID)
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+	if cached.token != "" && s.now().Before(cached.expiresAt.Add(-InstallationTokenRefreshMargin)) {
+		return cached.token, nil
+	}
+	if err := s.refresh(ctx, installationID, cached); err != nil {
+		return "", err
+	}
+	return cached.token, nil
+}
+
+// KeepFresh refreshes the token of the installation shortly before it expires until the context is done, so that
+// requests don't wait for the exchange. Failed refreshes are reported to onError and tried again.
+func (s *InstallationTokenSource) KeepFresh(ctx context.Context, installationID int64, onError func(error)) {
+	cached := s.cached(installationID)
+	for {
+		cached.mu.Lock()
+		wait := cached.expiresAt.Add(-InstallationTokenRefreshMargin).Sub(s.now())
+		if cached.token != "" && wait < installationTokenRetryInterval {
+			// Tokens issued for less than the refresh margin would otherwise be refreshed continuously
+			wait = installationTokenRetryInterval
+		}
+		cached.mu.Unlock()
+
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		if _, err := s.Token(ctx, installationID); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if onError != nil {
+				onError(err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(installationTokenRetryInterval):
+			}
+		}
+	}
+}
+
+// Permissions returns the permissions granted to the installation, such as {"issues": "write"}, as of its latest
+// token, or nil before a token was exchanged. The map must not be modified.
+func (s *InstallationTokenSource) Permissions(installationID int64) map[string]string {
+	cached := s.cached(installationID)
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+	return cached.permissions
+}
+
+func (s *InstallationTokenSource) cached(installationID int64) *installationToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cached, ok := s.tokens[installationID]
+	if !ok {
+		cached = &installationToken{}
+		s.tokens[installationID] = cached
+	}
+	return cached
+}
+
+// refresh exchanges a JWT of the app for a new token of the installation. The lock of the cached token is held.
+func (s *InstallationTokenSource) refresh(ctx context.Context, installationID int64, cached *installationToken) error {
+	jwt, err := s.appJWT()
+	if err != nil {
+		return err
+	}
+
+	endpoint := s.baseURL.JoinPath("app", "installations", strconv.FormatInt(installationID, 10), "access_tokens")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create installation token request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to create installation token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create installation token for installation %d: unexpected status %s", installationID, resp.Status)
+	}
+
+	var body struct {
+		Token       string            `json:"token"`
+		ExpiresAt   time.Time         `json:"expires_at"`
+		Permissions map[string]string `json:"permissions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("failed to decode installation token: %w", err)
+	}
+	cached.token, cached.expiresAt, cached.permissions = body.Token, body.ExpiresAt, body.Permissions
+	return nil
+}
+
+// appJWT creates a JWT authenticating as the GitHub App, signed with RS256 as GitHub requires.
+func (s *InstallationTokenSource) appJWT() (string, error) {
+	now := s.now()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]any{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(s.appID, 10),
+	})
+
+	var unsigned bytes.Buffer
+	unsigned.WriteString(base64.RawURLEncoding.EncodeToString(header))
+	unsigned.WriteByte('.')
+	unsigned.WriteString(base64.RawURLEncoding.EncodeToString(claims))
+
+	digest := sha256.Sum256(unsigned.Bytes())
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+	return unsigned.String() + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// InstallationAuthTransport authenticates requests with the token of a GitHub App installation.
+type InstallationAuthTransport struct {
+	transport      http.RoundTripper
+	source         *InstallationTokenSource
+	installationID int64
+}
+
+// NewInstallationAuthTransport creates an InstallationAuthTransport wrapping the provided transport.
+func NewInstallationAuthTransport(transport http.RoundTripper, source *InstallationTokenSource, installationID int64) *InstallationAuthTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &InstallationAuthTransport{transport: transport, source: source, installationID: installationID}
+}
+
+func (t *InstallationAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token(req.Context(), t.installationID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.transport.RoundTrip(req)
+}
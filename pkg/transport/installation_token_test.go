@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InstallationTokenSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var exchanges atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app/installations/42/access_tokens":
+			// The JWT must be signed with the key of the app and issued by it
+			jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			parts := strings.Split(jwt, ".")
+			require.Len(t, parts, 3)
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			require.NoError(t, err)
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+			claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+			require.NoError(t, err)
+			var decoded map[string]any
+			require.NoError(t, json.Unmarshal(claims, &decoded))
+			assert.Equal(t, "7", decoded["iss"])
+
+			n := exchanges.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":%q,"permissions":{"contents":"read","issues":"write"}}`, n, now.Add(time.Hour).Format(time.RFC3339))
+		case "/repos/o/r":
+			_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	source, err := NewInstallationTokenSource(7, keyPEM, baseURL, nil)
+	require.NoError(t, err)
+	source.now = func() time.Time { return now }
+
+	// A burst of requests exchanges a single token
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := source.Token(context.Background(), 42)
+			assert.NoError(t, err)
+			assert.Equal(t, "ghs_1", token)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), exchanges.Load())
+	assert.Equal(t, map[string]string{"contents": "read", "issues": "write"}, source.Permissions(42))
+
+	client := &http.Client{Transport: NewInstallationAuthTransport(nil, source, 42)}
+	resp, err := client.Get(srv.URL + "/repos/o/r")
+	require.NoError(t, err)
+	authorization, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer ghs_1", string(authorization))
+
+	// Tokens are replaced once they expire within the refresh margin
+	now = now.Add(time.Hour - InstallationTokenRefreshMargin + time.Second)
+	token, err := source.Token(context.Background(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_2", token)
+
+	// Other installations have their own tokens
+	_, err = source.Token(context.Background(), 43)
+	assert.ErrorContains(t, err, "failed to create installation token for installation 43")
+	assert.Nil(t, source.Permissions(43))
+}
+
+func Test_NewInstallationTokenSourceInvalidKey(t *testing.T) {
+	_, err := NewInstallationTokenSource(7, []byte("not a key"), &url.URL{}, nil)
+	assert.ErrorContains(t, err, "not PEM encoded")
+}
@@ -0,0 +1,204 @@
+package transport
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultOfflineCacheSize is the number of responses kept to be served while GitHub is unreachable.
+	DefaultOfflineCacheSize = 500
+
+	// maxOfflineCacheBodySize bounds the size of the responses that are cached.
+	maxOfflineCacheBodySize = 1 << 20
+
+	// maxOfflineCacheTotalSize bounds the size of all the cached responses together.
+	maxOfflineCacheTotalSize = 32 << 20
+
+	// StaleResponseHeader is set on responses served from the offline cache, to the time they were cached.
+	StaleResponseHeader = "X-Github-Mcp-Cached-At"
+)
+
+// StaleFunc is called when a response cached at the given time is served in place of a failed request.
+type StaleFunc func(ctx context.Context, cachedAt time.Time)
+
+// OfflineCacheTransport keeps the recent successful responses to GET requests, and serves them when the same
+// request later fails because GitHub is unreachable, with a network error, a 5xx response or an open circuit.
+// Responses older than the max age are never served. Downloads, such as archives, logs and release assets, are
+// never cached, as they are large and rarely requested again.
+type OfflineCacheTransport struct {
+	transport  http.RoundTripper
+	maxAge     time.Duration
+	maxEntries int
+	onStale    StaleFunc
+
+	// now and maxBytes are overridable for tests.
+	now      func() time.Time
+	maxBytes int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	size    int
+}
+
+// cachedResponse is a response kept by the offline cache.
+type cachedResponse struct {
+	key      string
+	status   int
+	header   http.Header
+	body     []byte
+	cachedAt time.Time
+}
+
+// NewOfflineCacheTransport creates an OfflineCacheTransport wrapping the provided transport. A max age of zero or
+// less disables the cache, and onStale may be nil.
+func NewOfflineCacheTransport(transport http.RoundTripper, maxAge time.Duration, maxEntries int, onStale StaleFunc) *OfflineCacheTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultOfflineCacheSize
+	}
+	return &OfflineCacheTransport{
+		transport:  transport,
+		maxAge:     maxAge,
+		maxEntries: maxEntries,
+		onStale:    onStale,
+		now:        time.Now,
+		maxBytes:   maxOfflineCacheTotalSize,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (t *OfflineCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.maxAge <= 0 || req.Method != http.MethodGet {
+		return t.transport.RoundTrip(req)
+	}
+
+	key := offlineCacheKey(req)
+	resp, err := t.transport.RoundTrip(req)
+	if isServerFailure(resp, err) {
+		if cached, ok := t.get(key); ok {
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			if t.onStale != nil {
+				t.onStale(req.Context(), cached.cachedAt)
+			}
+			return cached.response(req), nil
+		}
+		return resp, err
+	}
+
+	if resp.StatusCode != http.StatusOK || isDownload(req, resp) {
+		return resp, nil
+	}
+
+	// Keep a copy of the body, unless it's too large to be worth holding on to
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxOfflineCacheBodySize+1))
+	if readErr != nil || len(body) > maxOfflineCacheBodySize {
+		resp.Body = &prefixedBody{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.put(&cachedResponse{
+		key:      key,
+		status:   resp.StatusCode,
+		header:   resp.Header.Clone(),
+		body:     body,
+		cachedAt: t.now(),
+	})
+	return resp, nil
+}
+
+func (t *OfflineCacheTransport) get(key string) (*cachedResponse, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	elem, ok := t.entries[key]
+	if !ok {
+		return nil, false
+	}
+	cached := elem.Value.(*cachedResponse)
+	if t.now().Sub(cached.cachedAt) > t.maxAge {
+		t.remove(elem)
+		return nil, false
+	}
+	return cached, true
+}
+
+func (t *OfflineCacheTransport) put(cached *cachedResponse) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if elem, ok := t.entries[cached.key]; ok {
+		t.remove(elem)
+	}
+	t.entries[cached.key] = t.order.PushFront(cached)
+	t.size += len(cached.body)
+	for t.order.Len() > t.maxEntries || t.size > t.maxBytes {
+		t.remove(t.order.Back())
+	}
+}
+
+// remove drops a cached response, the lock must be held.
+func (t *OfflineCacheTransport) remove(elem *list.Element) {
+	cached := t.order.Remove(elem).(*cachedResponse)
+	delete(t.entries, cached.key)
+	t.size -= len(cached.body)
+}
+
+// response rebuilds the cached response for req.
+func (c *cachedResponse) response(req *http.Request) *http.Response {
+	header := c.header.Clone()
+	header.Set(StaleResponseHeader, c.cachedAt.UTC().Format(time.RFC3339))
+	return &http.Response{
+		Status:        strconv.Itoa(c.status) + " " + http.StatusText(c.status),
+		StatusCode:    c.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
+
+// offlineCacheKey identifies a request, including its credentials so responses are never served to another user.
+func offlineCacheKey(req *http.Request) string {
+	auth := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.URL.String() + " " + req.Header.Get("Accept") + " " + hex.EncodeToString(auth[:])
+}
+
+// isDownload reports whether the request downloads a file rather than reading the API: requests that followed a
+// redirect, which GitHub answers downloads with, binary responses, and the archive, log and artifact endpoints.
+func isDownload(req *http.Request, resp *http.Response) bool {
+	if req.Response != nil {
+		return true
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/octet-stream") ||
+		strings.Contains(req.Header.Get("Accept"), "application/octet-stream") {
+		return true
+	}
+	path := req.URL.Path
+	return strings.Contains(path, "/zipball") || strings.Contains(path, "/tarball") ||
+		strings.HasSuffix(path, "/logs") || strings.HasSuffix(path, "/zip")
+}
+
+// prefixedBody reads the start of a body that was already consumed before the rest of it.
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,194 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OfflineCacheTransport(t *testing.T) {
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("hello from " + r.URL.Path))
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	var staleAt []time.Time
+	ct := NewOfflineCacheTransport(http.DefaultTransport, 10*time.Minute, 2, func(_ context.Context, cachedAt time.Time) {
+		staleAt = append(staleAt, cachedAt)
+	})
+	ct.now = func() time.Time { return now }
+
+	do := func(method, path, token string) (*http.Response, string) {
+		req, err := http.NewRequest(method, srv.URL+path, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := ct.RoundTrip(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	cachedAt := now
+	resp, body := do(http.MethodGet, "/a", "token")
+	assert.Equal(t, "hello from /a", body)
+	assert.Empty(t, resp.Header.Get(StaleResponseHeader))
+	_, _ = do(http.MethodGet, "/b", "token")
+
+	down.Store(true)
+	now = now.Add(5 * time.Minute)
+
+	// Cached responses are served while the server fails
+	resp, body = do(http.MethodGet, "/a", "token")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "hello from /a", body)
+	assert.Equal(t, cachedAt.UTC().Format(time.RFC3339), resp.Header.Get(StaleResponseHeader))
+	assert.Equal(t, []time.Time{cachedAt}, staleAt)
+
+	// Responses are never served to other credentials or other methods
+	resp, _ = do(http.MethodGet, "/a", "other")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	resp, _ = do(http.MethodPost, "/a", "token")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	// Responses older than the max age expire
+	now = now.Add(6 * time.Minute)
+	resp, _ = do(http.MethodGet, "/b", "token")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func Test_OfflineCacheTransport_Eviction(t *testing.T) {
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	ct := NewOfflineCacheTransport(http.DefaultTransport, time.Hour, 2, nil)
+	client := &http.Client{Transport: ct}
+	get := func(path string) int {
+		resp, err := client.Get(srv.URL + path)
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	for _, path := range []string{"/a", "/b", "/c"} {
+		require.Equal(t, http.StatusOK, get(path))
+	}
+
+	down.Store(true)
+	assert.Equal(t, http.StatusBadGateway, get("/a"), "least recently cached response is evicted")
+	assert.Equal(t, http.StatusOK, get("/b"))
+	assert.Equal(t, http.StatusOK, get("/c"))
+}
+
+func Test_OfflineCacheTransport_TotalSize(t *testing.T) {
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer srv.Close()
+
+	ct := NewOfflineCacheTransport(http.DefaultTransport, time.Hour, 10, nil)
+	ct.maxBytes = 25
+	client := &http.Client{Transport: ct}
+	get := func(path string) int {
+		resp, err := client.Get(srv.URL + path)
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	for _, path := range []string{"/a", "/b", "/c"} {
+		require.Equal(t, http.StatusOK, get(path))
+	}
+	assert.Equal(t, 20, ct.size)
+
+	down.Store(true)
+	assert.Equal(t, http.StatusBadGateway, get("/a"), "least recently cached response is evicted to stay within the total size")
+	assert.Equal(t, http.StatusOK, get("/b"))
+	assert.Equal(t, http.StatusOK, get("/c"))
+}
+
+func Test_OfflineCacheTransport_SkipsDownloads(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/hello/releases/assets/1":
+			w.Header().Set("Content-Type", "application/octet-stream")
+		case "/redirect":
+			http.Redirect(w, r, "/redirected", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		path   string
+		accept string
+	}{
+		{name: "binary response", path: "/repos/octo/hello/releases/assets/1"},
+		{name: "binary accepted", path: "/repos/octo/hello/releases/assets/2", accept: "application/octet-stream"},
+		{name: "redirected request", path: "/redirect"},
+		{name: "archive", path: "/repos/octo/hello/zipball/main"},
+		{name: "logs", path: "/repos/octo/hello/actions/runs/1/logs"},
+		{name: "artifact", path: "/repos/octo/hello/actions/artifacts/1/zip"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ct := NewOfflineCacheTransport(http.DefaultTransport, time.Hour, 10, nil)
+			req, err := http.NewRequest(http.MethodGet, srv.URL+tc.path, nil)
+			require.NoError(t, err)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			resp, err := (&http.Client{Transport: ct}).Do(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, "data", string(body))
+			assert.Zero(t, ct.order.Len())
+			assert.Zero(t, ct.size)
+		})
+	}
+
+	t.Run("API response", func(t *testing.T) {
+		ct := NewOfflineCacheTransport(http.DefaultTransport, time.Hour, 10, nil)
+		resp, err := (&http.Client{Transport: ct}).Get(srv.URL + "/repos/octo/hello")
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		assert.Equal(t, 1, ct.order.Len())
+	})
+}
@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+)
+
+// PageLinksFunc is called with the Link header of a successful response to a list request. The header is empty
+// when the list has a single page.
+type PageLinksFunc func(ctx context.Context, link string)
+
+// PaginationTransport reports the pagination links of the responses to list requests, which are the requests
+// with a page or per_page parameter, so that tool results can tell whether there are more pages to fetch.
+type PaginationTransport struct {
+	transport http.RoundTripper
+	onPage    PageLinksFunc
+}
+
+// NewPaginationTransport creates a PaginationTransport wrapping the provided transport.
+func NewPaginationTransport(transport http.RoundTripper, onPage PageLinksFunc) *PaginationTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &PaginationTransport{transport: transport, onPage: onPage}
+}
+
+func (t *PaginationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || t.onPage == nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, err
+	}
+	if query := req.URL.Query(); query.Has("page") || query.Has("per_page") {
+		t.onPage(req.Context(), resp.Header.Get("Link"))
+	}
+	return resp, nil
+}
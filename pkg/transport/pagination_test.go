@@ -0,0 +1,41 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PaginationTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("Link", `<https://api.github.com/repos/o/r/issues?page=2>; rel="next"`)
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var links []string
+	client := &http.Client{Transport: NewPaginationTransport(http.DefaultTransport, func(_ context.Context, link string) {
+		links = append(links, link)
+	})}
+	get := func(path string) {
+		resp, err := client.Get(srv.URL + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	get("/issues?page=1&per_page=30")
+	get("/issues?per_page=30")
+	// Requests that aren't for lists, and failed ones, aren't reported
+	get("/issues/1")
+	get("/missing?page=1")
+
+	assert.Equal(t, []string{`<https://api.github.com/repos/o/r/issues?page=2>; rel="next"`, ""}, links)
+}
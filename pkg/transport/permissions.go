@@ -0,0 +1,40 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+)
+
+// AcceptedPermissionsHeader lists the permissions of fine-grained personal access tokens and GitHub Apps that
+// GitHub accepts for a request, any of which would have allowed it.
+const AcceptedPermissionsHeader = "X-Accepted-GitHub-Permissions"
+
+// PermissionDeniedFunc is called with the path of a request that was forbidden, and the permissions GitHub accepts
+// for it.
+type PermissionDeniedFunc func(ctx context.Context, path string, accepted string)
+
+// PermissionDeniedTransport reports the requests GitHub forbids for lack of a permission, so that tools the
+// credential can't use are known before they are called again.
+type PermissionDeniedTransport struct {
+	transport http.RoundTripper
+	onDenied  PermissionDeniedFunc
+}
+
+// NewPermissionDeniedTransport creates a PermissionDeniedTransport wrapping the provided transport.
+func NewPermissionDeniedTransport(transport http.RoundTripper, onDenied PermissionDeniedFunc) *PermissionDeniedTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &PermissionDeniedTransport{transport: transport, onDenied: onDenied}
+}
+
+func (t *PermissionDeniedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || t.onDenied == nil || resp.StatusCode != http.StatusForbidden {
+		return resp, err
+	}
+	if accepted := resp.Header.Get(AcceptedPermissionsHeader); accepted != "" {
+		t.onDenied(req.Context(), req.URL.Path, accepted)
+	}
+	return resp, nil
+}
@@ -0,0 +1,43 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PermissionDeniedTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/hooks":
+			w.Header().Set(AcceptedPermissionsHeader, "repository_hooks=read")
+			w.WriteHeader(http.StatusForbidden)
+		case "/repos/o/r/issues":
+			w.Header().Set(AcceptedPermissionsHeader, "issues=read")
+		case "/orgs/o/members":
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	var denied [][2]string
+	client := &http.Client{Transport: NewPermissionDeniedTransport(http.DefaultTransport, func(_ context.Context, path, accepted string) {
+		denied = append(denied, [2]string{path, accepted})
+	})}
+	get := func(path string) {
+		resp, err := client.Get(srv.URL + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	get("/repos/o/r/hooks")
+	// Allowed requests, and requests forbidden for other reasons, aren't reported
+	get("/repos/o/r/issues")
+	get("/orgs/o/members")
+
+	assert.Equal(t, [][2]string{{"/repos/o/r/hooks", "repository_hooks=read"}}, denied)
+}
@@ -0,0 +1,50 @@
+package transport
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMaxIdleConns is the number of idle connections kept open across all hosts.
+	DefaultMaxIdleConns = 100
+
+	// DefaultMaxIdleConnsPerHost is the number of idle connections kept open to a single host. It is well
+	// above Go's default of 2, so that bursts of concurrent requests to the API don't close and reopen
+	// connections, paying for a TLS handshake every time.
+	DefaultMaxIdleConnsPerHost = 10
+
+	// DefaultIdleConnTimeout is how long an idle connection is kept open.
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// PoolConfig tunes the connections kept open to GitHub.
+type PoolConfig struct {
+	// MaxIdleConns is the number of idle connections kept open across all hosts, zero means no limit.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the number of idle connections kept open to a single host.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept open, zero means no limit.
+	IdleConnTimeout time.Duration
+
+	// DisableHTTP2 restricts connections to HTTP/1.1, for proxies that mishandle HTTP/2.
+	DisableHTTP2 bool
+}
+
+// NewPooledTransport creates the transport whose connections are shared by all the GitHub clients of the server.
+// It is based on http.DefaultTransport, so it honours the proxy environment variables.
+func NewPooledTransport(cfg PoolConfig) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = cfg.MaxIdleConns
+	t.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	t.IdleConnTimeout = cfg.IdleConnTimeout
+	t.ForceAttemptHTTP2 = !cfg.DisableHTTP2
+	if cfg.DisableHTTP2 {
+		// A non-nil empty map is how HTTP/2 is turned off for a transport
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return t
+}
@@ -0,0 +1,76 @@
+package transport
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewPooledTransport(t *testing.T) {
+	pooled := NewPooledTransport(PoolConfig{
+		MaxIdleConns:        50,
+		MaxIdleConnsPerHost: 5,
+		IdleConnTimeout:     time.Minute,
+	})
+	assert.Equal(t, 50, pooled.MaxIdleConns)
+	assert.Equal(t, 5, pooled.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Minute, pooled.IdleConnTimeout)
+	assert.True(t, pooled.ForceAttemptHTTP2)
+	assert.NotNil(t, pooled.Proxy, "proxy environment variables are honoured")
+
+	pooled = NewPooledTransport(PoolConfig{DisableHTTP2: true})
+	assert.False(t, pooled.ForceAttemptHTTP2)
+	assert.NotNil(t, pooled.TLSNextProto)
+	assert.Empty(t, pooled.TLSNextProto)
+
+	// http.DefaultTransport is left untouched
+	assert.True(t, http.DefaultTransport.(*http.Transport).ForceAttemptHTTP2)
+}
+
+func Test_NewPooledTransport_HTTP2(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	for _, disable := range []bool{false, true} {
+		pooled := NewPooledTransport(PoolConfig{MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost, DisableHTTP2: disable})
+		pooled.TLSClientConfig = &tls.Config{
+			RootCAs:    srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+
+		var wg sync.WaitGroup
+		protos := make([]int, 4)
+		for i := range protos {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				resp, err := (&http.Client{Transport: pooled}).Get(srv.URL)
+				if !assert.NoError(t, err) {
+					return
+				}
+				defer func() { _ = resp.Body.Close() }()
+				protos[i] = resp.ProtoMajor
+			}(i)
+		}
+		wg.Wait()
+
+		expected := 2
+		if disable {
+			expected = 1
+		}
+		for _, proto := range protos {
+			require.Equal(t, expected, proto, "disable HTTP/2: %v", disable)
+		}
+		pooled.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,182 @@
+// Package transport provides http.RoundTripper middleware used by the GitHub
+// clients of the MCP server.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRateLimitMaxRetries is the number of times a rate limited request is retried
+	// before the rate limited response is handed back to the caller.
+	DefaultRateLimitMaxRetries = 3
+
+	// defaultBaseBackoff is the first backoff used when GitHub doesn't tell us how long to wait.
+	defaultBaseBackoff = time.Second
+
+	// maxPeekBodySize bounds how much of a 403 body we read to detect secondary rate limits.
+	maxPeekBodySize = 64 * 1024
+)
+
+// RateLimitRetryFunc is called before the transport waits to retry a rate limited request.
+// The context is the one of the original request, so it can be used to reach the MCP session.
+type RateLimitRetryFunc func(ctx context.Context, attempt int, wait time.Duration)
+
+// RateLimitTransport retries requests that were rejected by GitHub's primary or
+// secondary rate limits. It honours the Retry-After and X-RateLimit-Reset headers
+// and falls back to exponential backoff, but never waits longer in total than MaxWait.
+type RateLimitTransport struct {
+	// Transport is the underlying round tripper, http.DefaultTransport is used when nil.
+	Transport http.RoundTripper
+
+	// MaxWait is the total time a single request may spend waiting for rate limits to reset.
+	// A zero value disables retries.
+	MaxWait time.Duration
+
+	// MaxRetries is the maximum number of retries for a single request.
+	MaxRetries int
+
+	// OnRetry is called before waiting to retry a request, it may be nil.
+	OnRetry RateLimitRetryFunc
+
+	// now and sleep are overridable for tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRateLimitTransport creates a RateLimitTransport wrapping the provided transport.
+func NewRateLimitTransport(transport http.RoundTripper, maxWait time.Duration, onRetry RateLimitRetryFunc) *RateLimitTransport {
+	return &RateLimitTransport{
+		Transport:  transport,
+		MaxWait:    maxWait,
+		MaxRetries: DefaultRateLimitMaxRetries,
+		OnRetry:    onRetry,
+	}
+}
+
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	var waited time.Duration
+	for attempt := 1; ; attempt++ {
+		resp, err := transport.RoundTrip(req)
+		if err != nil || t.MaxWait <= 0 || attempt > t.MaxRetries {
+			return resp, err
+		}
+
+		if !isRateLimited(resp) {
+			return resp, nil
+		}
+
+		wait := t.retryDelay(resp, attempt)
+		if waited+wait > t.MaxWait {
+			// Waiting would blow the budget, let the caller see the rate limit error.
+			return resp, nil
+		}
+
+		// The body of a request can only be replayed when we know how to get it again.
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if t.OnRetry != nil {
+			t.OnRetry(req.Context(), attempt, wait)
+		}
+
+		if err := t.wait(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		waited += wait
+	}
+}
+
+// retryDelay works out how long to wait before retrying, following GitHub's documented guidance:
+// https://docs.github.com/en/rest/using-the-rest-api/best-practices-for-using-the-rest-api#handle-rate-limit-errors-appropriately
+func (t *RateLimitTransport) retryDelay(resp *http.Response, attempt int) time.Duration {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if wait := time.Unix(reset, 0).Sub(t.currentTime()); wait > 0 {
+				return wait
+			}
+			return 0
+		}
+	}
+
+	return defaultBaseBackoff << (attempt - 1)
+}
+
+func (t *RateLimitTransport) currentTime() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+func (t *RateLimitTransport) wait(ctx context.Context, d time.Duration) error {
+	if t.sleep != nil {
+		return t.sleep(ctx, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRateLimited reports whether the response was rejected by a primary or secondary rate limit.
+// Secondary rate limits don't always carry headers, so for 403s we peek at the body and restore it.
+func isRateLimited(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		if resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			return true
+		}
+		if resp.Body == nil {
+			return false
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxPeekBodySize))
+		rest := resp.Body
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), rest), rest}
+		if err != nil {
+			return false
+		}
+		return strings.Contains(strings.ToLower(string(body)), "rate limit")
+	default:
+		return false
+	}
+}
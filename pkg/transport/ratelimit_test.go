@@ -0,0 +1,212 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noSleep records the waits requested by the transport without actually sleeping.
+func noSleep(waits *[]time.Duration) func(context.Context, time.Duration) error {
+	return func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+}
+
+func Test_RateLimitTransport(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name            string
+		maxWait         time.Duration
+		responses       []func(w http.ResponseWriter)
+		expectedStatus  int
+		expectedCalls   int32
+		expectedWaits   []time.Duration
+		expectedRetries int
+	}{
+		{
+			name:    "successful request is not retried",
+			maxWait: time.Minute,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  1,
+		},
+		{
+			name:    "retries after Retry-After on 429",
+			maxWait: time.Minute,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("Retry-After", "2")
+					w.WriteHeader(http.StatusTooManyRequests)
+				},
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			expectedStatus:  http.StatusOK,
+			expectedCalls:   2,
+			expectedWaits:   []time.Duration{2 * time.Second},
+			expectedRetries: 1,
+		},
+		{
+			name:    "waits until reset when primary rate limit is exhausted",
+			maxWait: time.Minute,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("X-RateLimit-Remaining", "0")
+					w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(10*time.Second).Unix(), 10))
+					w.WriteHeader(http.StatusForbidden)
+				},
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			expectedStatus:  http.StatusOK,
+			expectedCalls:   2,
+			expectedWaits:   []time.Duration{10 * time.Second},
+			expectedRetries: 1,
+		},
+		{
+			name:    "backs off exponentially on secondary rate limit without headers",
+			maxWait: time.Minute,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit"}`))
+				},
+				func(w http.ResponseWriter) {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit"}`))
+				},
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+			},
+			expectedStatus:  http.StatusOK,
+			expectedCalls:   3,
+			expectedWaits:   []time.Duration{time.Second, 2 * time.Second},
+			expectedRetries: 2,
+		},
+		{
+			name:    "plain 403 is not retried and body is preserved",
+			maxWait: time.Minute,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+				},
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedCalls:  1,
+		},
+		{
+			name:    "gives up when the wait exceeds the budget",
+			maxWait: 5 * time.Second,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("Retry-After", "30")
+					w.WriteHeader(http.StatusTooManyRequests)
+				},
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCalls:  1,
+		},
+		{
+			name:    "zero budget disables retries",
+			maxWait: 0,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusTooManyRequests)
+				},
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCalls:  1,
+		},
+		{
+			name:    "stops after max retries",
+			maxWait: time.Hour,
+			responses: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusTooManyRequests)
+				},
+			},
+			expectedStatus:  http.StatusTooManyRequests,
+			expectedCalls:   DefaultRateLimitMaxRetries + 1,
+			expectedWaits:   []time.Duration{time.Second, time.Second, time.Second},
+			expectedRetries: DefaultRateLimitMaxRetries,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(calls.Add(1))
+				if r.Body != nil {
+					body, _ := io.ReadAll(r.Body)
+					assert.Equal(t, "payload", string(body), "body should be replayed on every attempt")
+				}
+				idx := n - 1
+				if idx >= len(tc.responses) {
+					idx = len(tc.responses) - 1
+				}
+				tc.responses[idx](w)
+			}))
+			defer srv.Close()
+
+			var waits []time.Duration
+			retries := 0
+			rt := NewRateLimitTransport(http.DefaultTransport, tc.maxWait, func(_ context.Context, _ int, _ time.Duration) {
+				retries++
+			})
+			rt.now = func() time.Time { return now }
+			rt.sleep = noSleep(&waits)
+
+			req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))
+			require.NoError(t, err)
+
+			resp, err := rt.RoundTrip(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedCalls, calls.Load())
+			assert.Equal(t, tc.expectedWaits, waits)
+			assert.Equal(t, tc.expectedRetries, retries)
+
+			if tc.expectedStatus == http.StatusForbidden {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Contains(t, string(body), "Resource not accessible by integration")
+			}
+		})
+	}
+}
+
+func Test_RateLimitTransport_ContextCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rt := NewRateLimitTransport(http.DefaultTransport, time.Minute, func(_ context.Context, _ int, _ time.Duration) {
+		cancel()
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+
+	_, err = rt.RoundTrip(req)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,167 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the number of times a request failing transiently is attempted in total.
+	DefaultRetryMaxAttempts = 3
+
+	// defaultRetryBackoff is the wait before the first retry, doubling for every following one.
+	defaultRetryBackoff = 500 * time.Millisecond
+)
+
+// RetryPolicy controls how requests failing transiently, such as with network errors or 502, 503 and 504
+// responses, are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is attempted in total. Values below 2 disable retries.
+	MaxAttempts int
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests, which may duplicate what they create
+	// when the first attempt reached GitHub but its response was lost.
+	RetryNonIdempotent bool
+}
+
+type retryPolicyKey struct{}
+
+// ContextWithRetryPolicy returns a context overriding the retry policy of the requests made with it.
+func ContextWithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// RetryPolicyFromContext returns the retry policy set with ContextWithRetryPolicy.
+func RetryPolicyFromContext(ctx context.Context) (RetryPolicy, bool) {
+	policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy)
+	return policy, ok
+}
+
+// RetryFunc is called before the transport waits to retry a request that failed transiently.
+type RetryFunc func(ctx context.Context, attempt int, wait time.Duration, reason string)
+
+// RetryTransport retries requests that failed transiently with exponential backoff. By default only requests
+// with idempotent methods are retried, as retrying others may repeat their side effects.
+type RetryTransport struct {
+	// Transport is the underlying round tripper, http.DefaultTransport is used when nil.
+	Transport http.RoundTripper
+
+	// Policy applies to requests whose context doesn't carry a policy of its own.
+	Policy RetryPolicy
+
+	// OnRetry is called before waiting to retry a request, it may be nil.
+	OnRetry RetryFunc
+
+	// backoff and sleep are overridable for tests.
+	backoff time.Duration
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryTransport creates a RetryTransport wrapping the provided transport.
+func NewRetryTransport(transport http.RoundTripper, policy RetryPolicy, onRetry RetryFunc) *RetryTransport {
+	return &RetryTransport{
+		Transport: transport,
+		Policy:    policy,
+		OnRetry:   onRetry,
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	policy := t.Policy
+	if p, ok := RetryPolicyFromContext(req.Context()); ok {
+		policy = p
+	}
+	if policy.MaxAttempts < 2 || (!isIdempotent(req.Method) && !policy.RetryNonIdempotent) {
+		return transport.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := transport.RoundTrip(req)
+		reason := transientFailure(req.Context(), resp, err)
+		if reason == "" || attempt >= policy.MaxAttempts {
+			return resp, err
+		}
+
+		// The body of a request can only be replayed when we know how to get it again.
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		wait := t.retryBackoff() << (attempt - 1)
+		if t.OnRetry != nil {
+			t.OnRetry(req.Context(), attempt, wait, reason)
+		}
+		if err := t.wait(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *RetryTransport) retryBackoff() time.Duration {
+	if t.backoff > 0 {
+		return t.backoff
+	}
+	return defaultRetryBackoff
+}
+
+func (t *RetryTransport) wait(ctx context.Context, d time.Duration) error {
+	if t.sleep != nil {
+		return t.sleep(ctx, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isIdempotent reports whether repeating a request with the method has the same effect as making it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// transientFailure describes why a request failed in a way that's worth retrying, or returns an empty string.
+func transientFailure(ctx context.Context, resp *http.Response, err error) string {
+	if err != nil {
+		// Requests given up by the caller must not be retried
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return ""
+		}
+		return err.Error()
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return resp.Status
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,163 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RetryTransport(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		policy         RetryPolicy
+		contextPolicy  *RetryPolicy
+		statuses       []int
+		expectedStatus int
+		expectedCalls  int32
+		expectedWaits  []time.Duration
+	}{
+		{
+			name:           "retries idempotent requests on 502 and 503",
+			method:         http.MethodGet,
+			policy:         RetryPolicy{MaxAttempts: 3},
+			statuses:       []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  3,
+			expectedWaits:  []time.Duration{time.Millisecond, 2 * time.Millisecond},
+		},
+		{
+			name:           "gives up after max attempts",
+			method:         http.MethodDelete,
+			policy:         RetryPolicy{MaxAttempts: 2},
+			statuses:       []int{http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusOK},
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedCalls:  2,
+			expectedWaits:  []time.Duration{time.Millisecond},
+		},
+		{
+			name:           "doesn't retry client errors",
+			method:         http.MethodGet,
+			policy:         RetryPolicy{MaxAttempts: 3},
+			statuses:       []int{http.StatusNotFound, http.StatusOK},
+			expectedStatus: http.StatusNotFound,
+			expectedCalls:  1,
+		},
+		{
+			name:           "doesn't retry non-idempotent requests by default",
+			method:         http.MethodPost,
+			policy:         RetryPolicy{MaxAttempts: 3},
+			statuses:       []int{http.StatusBadGateway, http.StatusOK},
+			expectedStatus: http.StatusBadGateway,
+			expectedCalls:  1,
+		},
+		{
+			name:           "retries non-idempotent requests when the context allows it",
+			method:         http.MethodPost,
+			policy:         RetryPolicy{MaxAttempts: 3},
+			contextPolicy:  &RetryPolicy{MaxAttempts: 2, RetryNonIdempotent: true},
+			statuses:       []int{http.StatusBadGateway, http.StatusOK},
+			expectedStatus: http.StatusOK,
+			expectedCalls:  2,
+			expectedWaits:  []time.Duration{time.Millisecond},
+		},
+		{
+			name:           "context policy can disable retries",
+			method:         http.MethodGet,
+			policy:         RetryPolicy{MaxAttempts: 3},
+			contextPolicy:  &RetryPolicy{MaxAttempts: 1},
+			statuses:       []int{http.StatusBadGateway, http.StatusOK},
+			expectedStatus: http.StatusBadGateway,
+			expectedCalls:  1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := calls.Add(1)
+				if r.Body != nil {
+					body, _ := io.ReadAll(r.Body)
+					if r.Method == http.MethodPost {
+						assert.Equal(t, `{"body":"hello"}`, string(body), "body must be replayed on retries")
+					}
+				}
+				w.WriteHeader(tc.statuses[n-1])
+			}))
+			defer srv.Close()
+
+			var waits []time.Duration
+			rt := NewRetryTransport(http.DefaultTransport, tc.policy, nil)
+			rt.backoff = time.Millisecond
+			rt.sleep = noSleep(&waits)
+
+			ctx := context.Background()
+			if tc.contextPolicy != nil {
+				ctx = ContextWithRetryPolicy(ctx, *tc.contextPolicy)
+			}
+			var body io.Reader
+			if tc.method == http.MethodPost {
+				body = strings.NewReader(`{"body":"hello"}`)
+			}
+			req, err := http.NewRequestWithContext(ctx, tc.method, srv.URL, body)
+			require.NoError(t, err)
+
+			resp, err := (&http.Client{Transport: rt}).Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedCalls, calls.Load())
+			assert.Equal(t, tc.expectedWaits, waits)
+		})
+	}
+}
+
+type failingTransport struct {
+	calls int
+	err   error
+}
+
+func (f *failingTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func Test_RetryTransport_NetworkErrors(t *testing.T) {
+	var waits []time.Duration
+	var reasons []string
+	failing := &failingTransport{err: errors.New("connection reset by peer")}
+	rt := NewRetryTransport(failing, RetryPolicy{MaxAttempts: 3}, func(_ context.Context, _ int, _ time.Duration, reason string) {
+		reasons = append(reasons, reason)
+	})
+	rt.sleep = noSleep(&waits)
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.ErrorContains(t, err, "connection reset by peer")
+	assert.Equal(t, 3, failing.calls)
+	assert.Equal(t, []string{"connection reset by peer", "connection reset by peer"}, reasons)
+	assert.Equal(t, []time.Duration{defaultRetryBackoff, 2 * defaultRetryBackoff}, waits)
+
+	// Cancelled requests aren't retried
+	failing = &failingTransport{err: context.Canceled}
+	rt.Transport = failing
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, failing.calls)
+}
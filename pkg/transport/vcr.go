@@ -0,0 +1,285 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// VCRMode is whether a VCRTransport records interactions or replays them.
+type VCRMode string
+
+const (
+	// VCRModeRecord sends requests to GitHub and records them along with their responses.
+	VCRModeRecord VCRMode = "record"
+
+	// VCRModeReplay answers requests from the recorded interactions, without sending them.
+	VCRModeReplay VCRMode = "replay"
+
+	// redacted replaces the secrets found in recorded interactions.
+	redacted = "REDACTED"
+)
+
+// recordedResponseHeaders are the response headers kept in fixtures, the others are dropped as they are either
+// irrelevant to the server or vary between runs, like dates, request IDs and rate limit counters.
+var recordedResponseHeaders = []string{
+	"Content-Type",
+	"Link",
+	"Location",
+	"X-Accepted-Oauth-Scopes",
+	"X-Oauth-Scopes",
+}
+
+// tokenPattern matches the formats of GitHub tokens, in case one shows up somewhere else than the known secrets.
+var tokenPattern = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)
+
+// VCRInteraction is a request and the response GitHub gave to it.
+type VCRInteraction struct {
+	Request  VCRRequest  `json:"request"`
+	Response VCRResponse `json:"response"`
+}
+
+// VCRRequest is a recorded request. Its headers aren't recorded, they only carry credentials and client details.
+type VCRRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// VCRResponse is a recorded response. Bodies that aren't valid UTF-8 are kept base64 encoded in BodyBase64.
+type VCRResponse struct {
+	Status     int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// Cassette is the fixture of a test: the interactions it had with GitHub, and the values it generated while being
+// recorded, such as unique repository names, which it has to use again for its requests to match on replay.
+type Cassette struct {
+	Values       []string          `json:"values,omitempty"`
+	Interactions []*VCRInteraction `json:"interactions"`
+}
+
+// VCRTransport records the interactions with GitHub to a fixture file, sanitized of credentials, and replays them
+// deterministically on later runs. Requests are matched on their method, URL and body, in the order they were
+// recorded, so that the same request can get different responses as the state of GitHub changes.
+type VCRTransport struct {
+	transport http.RoundTripper
+	mode      VCRMode
+	path      string
+	secrets   []string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed []bool
+	values   int
+}
+
+// NewVCRTransport creates a VCRTransport wrapping the provided transport, which is only used when recording. The
+// fixture at path is loaded when replaying, and written by Save when recording. The secrets, typically the token
+// used to record, are redacted from the fixture wherever they appear.
+func NewVCRTransport(transport http.RoundTripper, mode VCRMode, path string, secrets ...string) (*VCRTransport, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	t := &VCRTransport{
+		transport: transport,
+		mode:      mode,
+		path:      path,
+		cassette:  &Cassette{Interactions: []*VCRInteraction{}},
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			t.secrets = append(t.secrets, secret)
+		}
+	}
+
+	switch mode {
+	case VCRModeRecord:
+	case VCRModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		if err := json.Unmarshal(data, t.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+		}
+		t.replayed = make([]bool, len(t.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown VCR mode %q, must be %s or %s", mode, VCRModeRecord, VCRModeReplay)
+	}
+	return t, nil
+}
+
+// Mode returns whether the transport records or replays.
+func (t *VCRTransport) Mode() VCRMode {
+	return t.mode
+}
+
+// Value returns a value generated by the test, such as a unique name. When recording, the value is generated and
+// kept in the fixture, and when replaying, the recorded values are returned in the order they were generated.
+func (t *VCRTransport) Value(generate func() string) (string, error) {
+	if t.mode == VCRModeRecord {
+		value := generate()
+		t.mu.Lock()
+		t.cassette.Values = append(t.cassette.Values, value)
+		t.mu.Unlock()
+		return value, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.values >= len(t.cassette.Values) {
+		return "", fmt.Errorf("fixture %s has no more recorded values, record it again", t.path)
+	}
+	value := t.cassette.Values[t.values]
+	t.values++
+	return value, nil
+}
+
+func (t *VCRTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := VCRRequest{
+		Method: req.Method,
+		URL:    t.sanitize(req.URL.String()),
+		Body:   t.sanitize(string(body)),
+	}
+
+	if t.mode == VCRModeReplay {
+		return t.replay(req, recorded)
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &VCRInteraction{Request: recorded, Response: VCRResponse{Status: resp.StatusCode}}
+	for _, name := range recordedResponseHeaders {
+		for _, value := range resp.Header.Values(name) {
+			if interaction.Response.Header == nil {
+				interaction.Response.Header = http.Header{}
+			}
+			interaction.Response.Header.Add(name, t.sanitize(value))
+		}
+	}
+	if utf8.Valid(respBody) {
+		interaction.Response.Body = t.sanitize(string(respBody))
+	} else {
+		interaction.Response.BodyBase64 = base64.StdEncoding.EncodeToString(respBody)
+	}
+
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	t.mu.Unlock()
+	return resp, nil
+}
+
+// replay answers a request with the first recorded interaction matching it that wasn't replayed yet.
+func (t *VCRTransport) replay(req *http.Request, recorded VCRRequest) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.cassette.Interactions {
+		if t.replayed[i] || interaction.Request != recorded {
+			continue
+		}
+		t.replayed[i] = true
+
+		body := []byte(interaction.Response.Body)
+		if interaction.Response.BodyBase64 != "" {
+			var err error
+			if body, err = base64.StdEncoding.DecodeString(interaction.Response.BodyBase64); err != nil {
+				return nil, fmt.Errorf("invalid recorded body in fixture %s: %w", t.path, err)
+			}
+		}
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set("Content-Length", strconv.Itoa(len(body)))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("fixture %s has no recorded interaction left for %s %s, record it again", t.path, recorded.Method, recorded.URL)
+}
+
+// Unreplayed returns the recorded interactions that weren't replayed, which usually means the test changed since
+// its fixture was recorded.
+func (t *VCRTransport) Unreplayed() []VCRRequest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var requests []VCRRequest
+	for i, replayed := range t.replayed {
+		if !replayed {
+			requests = append(requests, t.cassette.Interactions[i].Request)
+		}
+	}
+	return requests
+}
+
+// Save writes the recorded interactions to the fixture, it does nothing when replaying.
+func (t *VCRTransport) Save() error {
+	if t.mode != VCRModeRecord {
+		return nil
+	}
+
+	t.mu.Lock()
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixture: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	if err := os.WriteFile(t.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// sanitize redacts the secrets and anything looking like a GitHub token.
+func (t *VCRTransport) sanitize(s string) string {
+	for _, secret := range t.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return tokenPattern.ReplaceAllString(s, redacted)
+}
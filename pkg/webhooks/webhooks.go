@@ -0,0 +1,225 @@
+// Package webhooks receives GitHub webhook deliveries so that they can be forwarded to MCP clients.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// NotificationMethod is the MCP notification method used to forward webhook events to clients.
+const NotificationMethod = "notifications/github/webhook"
+
+// maxPayloadSize matches the largest payload GitHub will deliver.
+const maxPayloadSize = 25 * 1024 * 1024
+
+// Event is a webhook delivery received from GitHub.
+type Event struct {
+	// Name is the event type, as sent in the X-GitHub-Event header.
+	Name string `json:"event"`
+	// DeliveryID uniquely identifies the delivery, as sent in the X-GitHub-Delivery header.
+	DeliveryID string `json:"delivery_id"`
+	// Action is the activity that triggered the event, if any (e.g. "opened").
+	Action string `json:"action,omitempty"`
+	// Repository is the full name of the repository the event belongs to, if any.
+	Repository string `json:"repository,omitempty"`
+	// Payload is the raw event payload.
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Filter selects the events that are forwarded. Empty lists match everything.
+type Filter struct {
+	// Repos is a list of repositories as owner/repo. A repository of owner/* matches every repository of the owner.
+	Repos []string
+	// Events is a list of event names, optionally qualified with an action (e.g. "issues" or "issues.opened").
+	Events []string
+}
+
+// Matches reports whether the event passes the filter.
+func (f Filter) Matches(e Event) bool {
+	return f.matchesRepo(e.Repository) && f.matchesEvent(e.Name, e.Action)
+}
+
+func (f Filter) matchesRepo(repo string) bool {
+	if len(f.Repos) == 0 {
+		return true
+	}
+	owner, _, _ := strings.Cut(repo, "/")
+	return slices.ContainsFunc(f.Repos, func(r string) bool {
+		return strings.EqualFold(r, repo) || strings.EqualFold(r, owner+"/*")
+	})
+}
+
+func (f Filter) matchesEvent(name, action string) bool {
+	if len(f.Events) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(f.Events, func(e string) bool {
+		return e == name || (action != "" && e == name+"."+action)
+	})
+}
+
+// Handler is an http.Handler receiving GitHub webhook deliveries. Deliveries are verified
+// against the webhook secret, filtered, and passed on to the deliver function.
+type Handler struct {
+	secret  []byte
+	filter  atomic.Pointer[Filter]
+	deliver func(Event)
+}
+
+// NewHandler creates a Handler verifying deliveries with the given secret.
+func NewHandler(secret string, filter Filter, deliver func(Event)) (*Handler, error) {
+	if secret == "" {
+		return nil, errors.New("a webhook secret is required to verify deliveries")
+	}
+	h := &Handler{
+		secret:  []byte(secret),
+		deliver: deliver,
+	}
+	h.SetFilter(filter)
+	return h, nil
+}
+
+// SetFilter replaces the filter of the events that are forwarded, applying to the deliveries received from then on.
+func (h *Handler) SetFilter(filter Filter) {
+	h.filter.Store(&filter)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxPayloadSize {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := verifySignature(h.secret, body, r.Header.Get("X-Hub-Signature-256")); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	name := r.Header.Get("X-GitHub-Event")
+	if name == "" {
+		http.Error(w, "missing X-GitHub-Event header", http.StatusBadRequest)
+		return
+	}
+
+	var payload struct {
+		Action     string `json:"action"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "payload is not valid JSON", http.StatusBadRequest)
+		return
+	}
+
+	event := Event{
+		Name:       name,
+		DeliveryID: r.Header.Get("X-GitHub-Delivery"),
+		Action:     payload.Action,
+		Repository: payload.Repository.FullName,
+		Payload:    body,
+	}
+
+	// Pings are sent when a webhook is created and only confirm that the endpoint is reachable
+	if name != "ping" && h.filter.Load().Matches(event) {
+		h.deliver(event)
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verifySignature checks the X-Hub-Signature-256 header against the HMAC of the payload.
+func verifySignature(secret, payload []byte, header string) error {
+	if header == "" {
+		return errors.New("missing X-Hub-Signature-256 header")
+	}
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return errors.New("unsupported signature format")
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("signature does not match payload")
+	}
+	return nil
+}
+
+// NotificationParams returns the parameters of the MCP notification forwarding the event.
+func NotificationParams(e Event) map[string]any {
+	params := map[string]any{
+		"event":       e.Name,
+		"delivery_id": e.DeliveryID,
+		"payload":     e.Payload,
+	}
+	if e.Action != "" {
+		params["action"] = e.Action
+	}
+	if e.Repository != "" {
+		params["repository"] = e.Repository
+	}
+	return params
+}
+
+// UpdatedResourceURIs returns the URIs of the repository resources whose content changed because of the event.
+func UpdatedResourceURIs(e Event) []string {
+	if e.Repository == "" {
+		return nil
+	}
+
+	switch e.Name {
+	case "push":
+		var push struct {
+			Ref     string `json:"ref"`
+			Deleted bool   `json:"deleted"`
+		}
+		if err := json.Unmarshal(e.Payload, &push); err != nil || push.Deleted || push.Ref == "" {
+			return nil
+		}
+		// Branch and tag names are single segments of resource URIs, so slashes in them are encoded
+		for _, prefix := range []string{"refs/heads/", "refs/tags/"} {
+			if name, ok := strings.CutPrefix(push.Ref, prefix); ok {
+				return []string{fmt.Sprintf("repo://%s/%s%s/contents", e.Repository, prefix, url.PathEscape(name))}
+			}
+		}
+		return nil
+	case "pull_request":
+		if e.Action != "synchronize" && e.Action != "opened" && e.Action != "reopened" {
+			return nil
+		}
+		var pr struct {
+			Number int `json:"number"`
+		}
+		if err := json.Unmarshal(e.Payload, &pr); err != nil || pr.Number == 0 {
+			return nil
+		}
+		return []string{fmt.Sprintf("repo://%s/refs/pull/%d/head/contents", e.Repository, pr.Number)}
+	default:
+		return nil
+	}
+}
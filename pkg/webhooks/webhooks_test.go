@@ -0,0 +1,265 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "s3cr3t"
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func Test_Handler(t *testing.T) {
+	issueOpened := `{"action":"opened","repository":{"full_name":"octo/hello"}}`
+
+	tests := []struct {
+		name              string
+		method            string
+		event             string
+		payload           string
+		signature         string
+		filter            Filter
+		expectedStatus    int
+		expectedDelivered []Event
+	}{
+		{
+			name:           "delivers verified event",
+			method:         http.MethodPost,
+			event:          "issues",
+			payload:        issueOpened,
+			signature:      sign(testSecret, issueOpened),
+			expectedStatus: http.StatusAccepted,
+			expectedDelivered: []Event{{
+				Name:       "issues",
+				DeliveryID: "delivery-1",
+				Action:     "opened",
+				Repository: "octo/hello",
+				Payload:    []byte(issueOpened),
+			}},
+		},
+		{
+			name:           "rejects missing signature",
+			method:         http.MethodPost,
+			event:          "issues",
+			payload:        issueOpened,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "rejects signature made with another secret",
+			method:         http.MethodPost,
+			event:          "issues",
+			payload:        issueOpened,
+			signature:      sign("wrong", issueOpened),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "rejects non POST requests",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "rejects invalid JSON",
+			method:         http.MethodPost,
+			event:          "issues",
+			payload:        "not json",
+			signature:      sign(testSecret, "not json"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "does not forward pings",
+			method:         http.MethodPost,
+			event:          "ping",
+			payload:        `{"zen":"Keep it logically awesome."}`,
+			signature:      sign(testSecret, `{"zen":"Keep it logically awesome."}`),
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "filters out other repositories",
+			method:         http.MethodPost,
+			event:          "issues",
+			payload:        issueOpened,
+			signature:      sign(testSecret, issueOpened),
+			filter:         Filter{Repos: []string{"octo/other"}},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "filters out other actions",
+			method:         http.MethodPost,
+			event:          "issues",
+			payload:        issueOpened,
+			signature:      sign(testSecret, issueOpened),
+			filter:         Filter{Events: []string{"issues.closed", "workflow_run"}},
+			expectedStatus: http.StatusAccepted,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var delivered []Event
+			h, err := NewHandler(testSecret, tc.filter, func(e Event) {
+				delivered = append(delivered, e)
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(tc.method, "/webhook", strings.NewReader(tc.payload))
+			req.Header.Set("X-GitHub-Event", tc.event)
+			req.Header.Set("X-GitHub-Delivery", "delivery-1")
+			if tc.signature != "" {
+				req.Header.Set("X-Hub-Signature-256", tc.signature)
+			}
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedDelivered, delivered)
+		})
+	}
+}
+
+func Test_NewHandler_RequiresSecret(t *testing.T) {
+	_, err := NewHandler("", Filter{}, func(Event) {})
+	require.Error(t, err)
+}
+
+func Test_Handler_SetFilter(t *testing.T) {
+	issueOpened := `{"action":"opened","repository":{"full_name":"octo/hello"}}`
+	var delivered []string
+	h, err := NewHandler(testSecret, Filter{Repos: []string{"octo/other"}}, func(e Event) {
+		delivered = append(delivered, e.DeliveryID)
+	})
+	require.NoError(t, err)
+
+	deliver := func(id string) {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(issueOpened))
+		req.Header.Set("X-GitHub-Event", "issues")
+		req.Header.Set("X-GitHub-Delivery", id)
+		req.Header.Set("X-Hub-Signature-256", sign(testSecret, issueOpened))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusAccepted, rec.Code)
+	}
+
+	deliver("delivery-1")
+	h.SetFilter(Filter{Repos: []string{"octo/*"}})
+	deliver("delivery-2")
+
+	// Only the deliveries received once the filter matches the repository are forwarded
+	assert.Equal(t, []string{"delivery-2"}, delivered)
+}
+
+func Test_Filter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   Filter
+		event    Event
+		expected bool
+	}{
+		{
+			name:     "empty filter matches everything",
+			event:    Event{Name: "push", Repository: "octo/hello"},
+			expected: true,
+		},
+		{
+			name:     "repository match is case insensitive",
+			filter:   Filter{Repos: []string{"Octo/Hello"}},
+			event:    Event{Name: "push", Repository: "octo/hello"},
+			expected: true,
+		},
+		{
+			name:     "owner wildcard matches any repository of the owner",
+			filter:   Filter{Repos: []string{"octo/*"}},
+			event:    Event{Name: "push", Repository: "octo/hello"},
+			expected: true,
+		},
+		{
+			name:     "owner wildcard does not match other owners",
+			filter:   Filter{Repos: []string{"octo/*"}},
+			event:    Event{Name: "push", Repository: "other/hello"},
+			expected: false,
+		},
+		{
+			name:     "event name matches every action",
+			filter:   Filter{Events: []string{"workflow_run"}},
+			event:    Event{Name: "workflow_run", Action: "completed"},
+			expected: true,
+		},
+		{
+			name:     "qualified event matches its action",
+			filter:   Filter{Events: []string{"workflow_run.completed"}},
+			event:    Event{Name: "workflow_run", Action: "completed"},
+			expected: true,
+		},
+		{
+			name:     "qualified event does not match other actions",
+			filter:   Filter{Events: []string{"workflow_run.completed"}},
+			event:    Event{Name: "workflow_run", Action: "requested"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.filter.Matches(tc.event))
+		})
+	}
+}
+
+func Test_UpdatedResourceURIs(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    Event
+		expected []string
+	}{
+		{
+			name:     "push to a branch",
+			event:    Event{Name: "push", Repository: "octo/hello", Payload: []byte(`{"ref":"refs/heads/main"}`)},
+			expected: []string{"repo://octo/hello/refs/heads/main/contents"},
+		},
+		{
+			name:     "push to a branch with a slash",
+			event:    Event{Name: "push", Repository: "octo/hello", Payload: []byte(`{"ref":"refs/heads/feature/x"}`)},
+			expected: []string{"repo://octo/hello/refs/heads/feature%2Fx/contents"},
+		},
+		{
+			name:     "pushed tag",
+			event:    Event{Name: "push", Repository: "octo/hello", Payload: []byte(`{"ref":"refs/tags/v1.0.0"}`)},
+			expected: []string{"repo://octo/hello/refs/tags/v1.0.0/contents"},
+		},
+		{
+			name:  "deleted branch",
+			event: Event{Name: "push", Repository: "octo/hello", Payload: []byte(`{"ref":"refs/heads/old","deleted":true}`)},
+		},
+		{
+			name:     "pull request synchronized",
+			event:    Event{Name: "pull_request", Action: "synchronize", Repository: "octo/hello", Payload: []byte(`{"number":42}`)},
+			expected: []string{"repo://octo/hello/refs/pull/42/head/contents"},
+		},
+		{
+			name:  "pull request labeled",
+			event: Event{Name: "pull_request", Action: "labeled", Repository: "octo/hello", Payload: []byte(`{"number":42}`)},
+		},
+		{
+			name:  "issue event",
+			event: Event{Name: "issues", Action: "opened", Repository: "octo/hello", Payload: []byte(`{}`)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, UpdatedResourceURIs(tc.event))
+		})
+	}
+}